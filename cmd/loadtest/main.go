@@ -0,0 +1,208 @@
+// Command loadtest fires concurrent purchases at one ticket group of a running
+// server and checks that no more tickets were handed out than were available.
+//
+//	go run ./cmd/loadtest -event 1 -group 3 -buyers 200
+//
+// Buyers are registered on the fly, so point it at a development database.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/services"
+)
+
+type envelope struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Code    string          `json:"code"`
+}
+
+type client struct {
+	baseURL string
+	http    *http.Client
+}
+
+func main() {
+	baseURL := flag.String("url", "http://localhost:8080/api/v1", "API base URL")
+	eventID := flag.Uint("event", 0, "event the ticket group belongs to")
+	groupID := flag.Uint("group", 0, "ticket group to buy from")
+	buyers := flag.Int("buyers", 50, "number of concurrent buyers")
+	quantity := flag.Int("quantity", 1, "tickets per purchase")
+	flag.Parse()
+
+	if *eventID == 0 || *groupID == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	c := &client{baseURL: *baseURL, http: &http.Client{Timeout: 30 * time.Second}}
+
+	before, err := c.group(*eventID, *groupID)
+	if err != nil {
+		log.Fatalf("Failed to load ticket group: %v", err)
+	}
+	log.Printf("Group %d: %d available, %d sold, %d held", before.GroupID, before.AvailableAmount, before.SoldAmount, before.HeldAmount)
+
+	tokens, err := c.registerBuyers(*buyers)
+	if err != nil {
+		log.Fatalf("Failed to register buyers: %v", err)
+	}
+
+	statuses := c.purchaseConcurrently(tokens, &services.PurchaseTicketFromGroupRequest{
+		EventID:       *eventID,
+		GroupID:       *groupID,
+		Quantity:      *quantity,
+		PaymentMethod: models.PaymentTypeCard,
+	})
+
+	after, err := c.group(*eventID, *groupID)
+	if err != nil {
+		log.Fatalf("Failed to reload ticket group: %v", err)
+	}
+
+	// Declined charges hold their tickets for a retry, so they count
+	// against the group just like completed purchases
+	sold := statuses[http.StatusCreated] * *quantity
+	held := statuses[http.StatusPaymentRequired] * *quantity
+	log.Printf("Responses: %v", statuses)
+	log.Printf("Group %d: %d available, %d sold, %d held", after.GroupID, after.AvailableAmount, after.SoldAmount, after.HeldAmount)
+
+	var failures []string
+	if sold+held > before.AvailableAmount {
+		failures = append(failures, fmt.Sprintf("%d tickets handed out but only %d were available", sold+held, before.AvailableAmount))
+	}
+	if after.AvailableAmount != before.AvailableAmount-sold-held {
+		failures = append(failures, fmt.Sprintf("expected %d tickets left, found %d", before.AvailableAmount-sold-held, after.AvailableAmount))
+	}
+	if after.SoldAmount+after.HeldAmount > after.TotalAmount {
+		failures = append(failures, fmt.Sprintf("%d sold and %d held exceed the group total of %d", after.SoldAmount, after.HeldAmount, after.TotalAmount))
+	}
+
+	if len(failures) > 0 {
+		for _, failure := range failures {
+			log.Printf("OVERSELL: %s", failure)
+		}
+		os.Exit(1)
+	}
+	log.Println("No oversell detected")
+}
+
+func (c *client) registerBuyers(n int) ([]string, error) {
+	run := time.Now().Unix()
+	tokens := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		username := fmt.Sprintf("lt%d_%d", run, i)
+		var tokenResponse services.TokenResponse
+		status, err := c.do(http.MethodPost, "/auth/register", "", &services.RegisterRequest{
+			Username: username,
+			Email:    username + "@loadtest.invalid",
+			Password: "LoadTest123!",
+			Name:     "Load",
+			Surname:  "Test",
+			UserType: int(models.UserTypeUser),
+		}, &tokenResponse)
+		if err != nil {
+			return nil, err
+		}
+		if status != http.StatusCreated {
+			return nil, fmt.Errorf("registering %s returned %d", username, status)
+		}
+		tokens = append(tokens, tokenResponse.AccessToken)
+	}
+	return tokens, nil
+}
+
+// purchaseConcurrently releases every buyer at once and counts the
+// responses by status code
+func (c *client) purchaseConcurrently(tokens []string, req *services.PurchaseTicketFromGroupRequest) map[int]int {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		start    = make(chan struct{})
+		statuses = make(map[int]int)
+	)
+
+	for _, token := range tokens {
+		wg.Add(1)
+		go func(token string) {
+			defer wg.Done()
+			<-start
+			status, err := c.do(http.MethodPost, "/tickets/purchase-group", token, req, nil)
+			if err != nil {
+				log.Printf("Purchase request failed: %v", err)
+			}
+			mu.Lock()
+			statuses[status]++
+			mu.Unlock()
+		}(token)
+	}
+
+	close(start)
+	wg.Wait()
+	return statuses
+}
+
+func (c *client) group(eventID, groupID uint) (*models.GroupedTicket, error) {
+	var groups []models.GroupedTicket
+	status, err := c.do(http.MethodGet, fmt.Sprintf("/events/%d/grouped-tickets", eventID), "", nil, &groups)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("listing tickets returned %d", status)
+	}
+	for i := range groups {
+		if groups[i].GroupID == groupID {
+			return &groups[i], nil
+		}
+	}
+	// Groups with nothing left drop out of the available listing
+	return &models.GroupedTicket{GroupID: groupID}, nil
+}
+
+// do sends a JSON request and decodes the response envelope's data into out
+func (c *client) do(method, path, token string, body, out interface{}) (int, error) {
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			return 0, err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, &payload)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var env envelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return resp.StatusCode, err
+	}
+	if out != nil && env.Success && len(env.Data) > 0 {
+		if err := json.Unmarshal(env.Data, out); err != nil {
+			return resp.StatusCode, err
+		}
+	}
+	return resp.StatusCode, nil
+}
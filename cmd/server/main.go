@@ -3,41 +3,54 @@ package main
 import (
 	"context"
 	"errors"
-	"eticketing/internal/models"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
+	_ "time/tzdata" // Event time zones must resolve on hosts without a zoneinfo database
 
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	"eticketing/internal/config"
 	"eticketing/internal/database"
+	"eticketing/internal/events"
+	"eticketing/internal/fraud"
 	"eticketing/internal/handlers"
+	"eticketing/internal/jobs"
+	"eticketing/internal/lifecycle"
 	"eticketing/internal/middleware"
+	"eticketing/internal/models"
+	"eticketing/internal/moderation"
+	"eticketing/internal/outbox"
+	"eticketing/internal/payments"
 	"eticketing/internal/repositories"
 	"eticketing/internal/services"
+	"eticketing/internal/tracing"
 	"eticketing/internal/utils"
 )
 
 func main() {
 	// Load environment variables from .env file
-	if err := godotenv.Load(); err != nil {
+	if err := config.LoadEnvFile(envFile); err != nil {
 		log.Println("No .env file found, using environment variables")
 	}
 
 	// Load configuration
 	cfg := config.Load()
 
+	// Initialize tracing
+	shutdownTracing, err := tracing.Init(context.Background(), &cfg.Tracing)
+	if err != nil {
+		log.Fatal("Failed to initialize tracing:", err)
+	}
+
 	// Initialize database
 	db, err := database.NewConnection(cfg)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
-	defer db.Close()
 
 	// Run migrations
 	if err := db.AutoMigrate(); err != nil {
@@ -53,42 +66,221 @@ func main() {
 	adminRepo := repositories.NewAdminRepository(db.DB)
 	eventRepo := repositories.NewEventRepository(db.DB)
 	ticketRepo := repositories.NewTicketRepository(db.DB)
+	ticketGroupRepo := repositories.NewTicketGroupRepository(db.DB)
 	purchasedTicketRepo := repositories.NewPurchasedTicketRepository(db.DB)
 	paymentRepo := repositories.NewPaymentRepository(db.DB)
 	transferRepo := repositories.NewTransferRepository(db.DB)
 	saleRepo := repositories.NewSaleRepository(db.DB)
 	paymentMethodRepo := repositories.NewPaymentMethodRepository(db.DB)
+	outboxRepo := repositories.NewOutboxRepository(db.DB)
+	webhookRepo := repositories.NewWebhookRepository(db.DB)
+	priceTierRepo := repositories.NewPriceTierRepository(db.DB)
+	giftRepo := repositories.NewGiftRepository(db.DB)
+	bulkOrderRepo := repositories.NewBulkOrderRepository(db.DB)
+	orderRepo := repositories.NewOrderRepository(db.DB)
+	disputeRepo := repositories.NewDisputeRepository(db.DB)
+	reportRepo := repositories.NewEventReportRepository(db.DB)
+	followRepo := repositories.NewFollowRepository(db.DB)
+	dataExportRepo := repositories.NewDataExportRepository(db.DB)
+	ticketImportRepo := repositories.NewTicketImportRepository(db.DB)
+	auditRepo := repositories.NewAuditLogRepository(db.DB)
+	venueRepo := repositories.NewVenueRepository(db.DB)
+	eventMemberRepo := repositories.NewEventMemberRepository(db.DB)
+	deadLetterRepo := repositories.NewDeadLetterRepository(db.DB)
+	featureFlagRepo := repositories.NewFeatureFlagRepository(db.DB)
+	maintenanceRepo := repositories.NewMaintenanceRepository(db.DB)
+	analyticsRepo := repositories.NewAnalyticsRepository(db.DB)
+	refundRequestRepo := repositories.NewRefundRequestRepository(db.DB)
+	queueRepo := repositories.NewQueueRepository(db.DB)
+	lotteryRepo := repositories.NewLotteryRepository(db.DB)
+	denylistRepo := repositories.NewDenylistRepository(db.DB)
+	policyRepo := repositories.NewPolicyRepository(db.DB)
+	announcementRepo := repositories.NewAnnouncementRepository(db.DB)
+	sellerDocumentRepo := repositories.NewSellerDocumentRepository(db.DB)
+	txManager := repositories.NewTransactionManager(db.DB)
+
+	// Payment providers hold the card data; only sandbox mocks exist until a
+	// real Stripe/Braintree client is integrated
+	paymentProviders := payments.NewRegistry()
+	if cfg.Payment.IsMocked {
+		resilience := payments.ResilienceOptions{
+			Timeout:          cfg.Payment.ProviderTimeout,
+			Retries:          cfg.Payment.ProviderRetries,
+			BackoffBase:      cfg.Payment.ProviderBackoff,
+			BreakerThreshold: cfg.Payment.BreakerThreshold,
+			BreakerCooldown:  cfg.Payment.BreakerCooldown,
+		}
+		mock := payments.MockOptions{
+			ApprovalRate: cfg.Payment.MockApprovalRate,
+			Latency:      cfg.Payment.MockLatency,
+			Seed:         cfg.Payment.MockSeed,
+		}
+		paymentProviders = payments.NewRegistry(
+			payments.NewResilientProvider(payments.NewMockProvider("stripe", mock), resilience),
+			payments.NewResilientProvider(payments.NewMockProvider("braintree", mock), resilience),
+			payments.NewResilientProvider(payments.NewMockProvider(payments.MockGateway, mock), resilience),
+		)
+	}
 
 	// Initialize services
-	authService := services.NewAuthService(userRepo, sellerRepo, adminRepo, jwtManager)
-	userService := services.NewUserService(userRepo)
+	featureFlagService := services.NewFeatureFlagService(featureFlagRepo, cfg.Server.Environment)
+	maintenanceService := services.NewMaintenanceService(maintenanceRepo)
+	eventAccess := services.NewEventAccess(eventMemberRepo)
+	giftService := services.NewGiftService(giftRepo, purchasedTicketRepo, txManager)
+	denylistService := services.NewDenylistService(denylistRepo, paymentMethodRepo, auditRepo, txManager)
+	policyService := services.NewPolicyService(policyRepo, auditRepo, txManager)
+	sellerOnboardingService := services.NewSellerOnboardingService(sellerRepo, sellerDocumentRepo, auditRepo, outboxRepo, txManager)
+	authService := services.NewAuthService(userRepo, sellerRepo, adminRepo, giftService, denylistService, policyService, jwtManager)
+	userService := services.NewUserService(userRepo, cfg.Account.DeletionGracePeriod)
 	sellerService := services.NewSellerService(sellerRepo, eventRepo, paymentRepo, ticketRepo)
-	adminService := services.NewAdminService(adminRepo, userRepo, sellerRepo, eventRepo, paymentRepo)
-	paymentService := services.NewPaymentService(paymentRepo, eventRepo, sellerRepo, cfg.Payment.IsMocked)
-	eventService := services.NewEventService(eventRepo, ticketRepo)
-	ticketService := services.NewTicketService(ticketRepo, purchasedTicketRepo, eventRepo, saleRepo, paymentService) // Updated this line
-	transferService := services.NewTransferService(transferRepo, purchasedTicketRepo, userRepo)
-	saleService := services.NewSaleService(saleRepo, eventRepo)
-	paymentMethodService := services.NewPaymentMethodService(paymentMethodRepo)
-	pdfService := services.NewPDFService()
+	adminService := services.NewAdminService(adminRepo, userRepo, sellerRepo, eventRepo, paymentRepo, outboxRepo, txManager)
+	paymentService := services.NewPaymentService(paymentRepo, paymentMethodRepo, purchasedTicketRepo, paymentProviders, eventRepo, eventAccess, sellerRepo, outboxRepo, txManager, cfg.Payment.IsMocked)
+	refundRequestService := services.NewRefundRequestService(refundRequestRepo, paymentRepo, paymentService, cfg.Payment.RefundCutoff)
+	purchaseHistoryService := services.NewPurchaseHistoryService(paymentRepo, purchasedTicketRepo, refundRequestRepo, cfg.Server.PublicURL)
+	pricingService := services.NewPricingService(priceTierRepo, ticketRepo, ticketGroupRepo, eventRepo, eventAccess, txManager)
+	eventService := services.NewEventService(eventRepo, eventAccess, ticketRepo, venueRepo, saleRepo, outboxRepo, txManager, pricingService, newModerator(&cfg.Moderation))
+	ticketService := services.NewTicketService(ticketRepo, ticketGroupRepo, purchasedTicketRepo, eventRepo, eventAccess, saleRepo, lotteryRepo, userRepo, giftRepo, paymentService, pricingService, featureFlagService, newScreener(&cfg.Fraud, paymentRepo, denylistService), orderRepo, outboxRepo, txManager, cfg.Payment.RetryGrace, cfg.Fraud.ReviewWindow)
+	transferService := services.NewTransferService(transferRepo, purchasedTicketRepo, userRepo, eventRepo, paymentService, outboxRepo, txManager, cfg.Server.PublicURL)
+	saleService := services.NewSaleService(saleRepo, lotteryRepo, eventRepo, ticketGroupRepo, outboxRepo, auditRepo, eventAccess, txManager)
+	paymentMethodService := services.NewPaymentMethodService(paymentMethodRepo, paymentProviders)
+	pdfService := services.NewPDFService(paymentRepo, disputeRepo)
+	ticketImportService := services.NewTicketImportService(ticketImportRepo, eventRepo, saleRepo, eventAccess, ticketService, outboxRepo, txManager)
+	ticketCodeKey := cfg.TicketCode.SigningKey
+	if ticketCodeKey == "" {
+		ticketCodeKey = cfg.JWT.Secret
+	}
+	ticketCodes := services.NewTicketCodeSigner(ticketCodeKey, cfg.TicketCode.Period)
+	deepLinkKey := cfg.DeepLink.SigningKey
+	if deepLinkKey == "" {
+		deepLinkKey = cfg.JWT.Secret
+	}
+	universalURL := cfg.DeepLink.UniversalURL
+	if universalURL == "" {
+		universalURL = cfg.Server.PublicURL
+	}
+	deepLinkService := services.NewDeepLinkService(purchasedTicketRepo, deepLinkKey, cfg.DeepLink.Scheme, universalURL, cfg.DeepLink.TTL)
+	lotteryService := services.NewLotteryService(lotteryRepo, saleRepo, outboxRepo, txManager, cfg.Lottery.ClaimWindow)
+	queueService := services.NewQueueService(queueRepo, eventRepo, ticketRepo, cfg.Queue.AdmitPerMinute, cfg.Queue.AdmissionWindow)
+	attendeeService := services.NewAttendeeService(eventRepo, purchasedTicketRepo, eventAccess, ticketCodes)
+	bulkOrderService := services.NewBulkOrderService(bulkOrderRepo, eventRepo, eventAccess, ticketService, paymentService, cfg.Bulk.MaxQuantity, cfg.Bulk.ApprovalThreshold)
+	venueService := services.NewVenueService(venueRepo, eventRepo)
+	calendarService := services.NewCalendarService(purchasedTicketRepo, userRepo, cfg.Server.PublicURL)
+	reportService := services.NewReportService(reportRepo, eventRepo)
+	followService := services.NewFollowService(followRepo, sellerRepo, eventRepo, outboxRepo)
+	dataExportService := services.NewDataExportService(dataExportRepo, userRepo, orderRepo, bulkOrderRepo, purchasedTicketRepo, paymentRepo,
+		paymentMethodRepo, transferRepo, followRepo, outboxRepo, txManager, cfg.Server.PublicURL, cfg.Account.ExportTTL)
+	disputeService := services.NewDisputeService(disputeRepo, paymentRepo, purchasedTicketRepo, outboxRepo, txManager, paymentProviders, cfg.Payment.WebhookSecret)
+	webhookService := services.NewWebhookService(webhookRepo, outboxRepo, cfg.Outbox.WebhookTimeout)
+	analyticsSalt := cfg.Analytics.Salt
+	if analyticsSalt == "" {
+		analyticsSalt = cfg.JWT.Secret
+	}
+	analyticsService := services.NewAnalyticsService(analyticsRepo, eventRepo, eventAccess, analyticsSalt, cfg.Analytics.Enabled)
+
+	// Initialize outbox delivery
+	dispatcher := outbox.NewDispatcher(outboxRepo, cfg.Outbox.BatchSize, cfg.Outbox.MaxAttempts, cfg.Outbox.RetryBackoff)
+	for _, topic := range []string{
+		models.OutboxTopicTicketSold,
+		models.OutboxTopicOrderRefunded,
+		models.OutboxTopicEventApproved,
+		models.OutboxTopicEventRejected,
+		models.OutboxTopicEventSuspended,
+		models.OutboxTopicEventPublished,
+		models.OutboxTopicSaleCreated,
+		models.OutboxTopicDisputeUpdated,
+	} {
+		dispatcher.Subscribe(topic, outbox.LogNotifier)
+		dispatcher.Subscribe(topic, webhookService.FanOut)
+	}
+	for _, topic := range []string{
+		models.OutboxTopicEventApproved,
+		models.OutboxTopicEventPublished,
+		models.OutboxTopicSaleCreated,
+	} {
+		dispatcher.Subscribe(topic, followService.FanOut)
+	}
+	dispatcher.Subscribe(models.OutboxTopicTicketGifted, outbox.LogNotifier)
+	dispatcher.Subscribe(models.OutboxTopicTransferAccepted, outbox.LogNotifier)
+	dispatcher.Subscribe(models.OutboxTopicFollowerNotice, outbox.LogNotifier)
+	dispatcher.Subscribe(models.OutboxTopicLotteryResult, outbox.LogNotifier)
+	dispatcher.Subscribe(models.OutboxTopicOrderReviewed, outbox.LogNotifier)
+	dispatcher.Subscribe(models.OutboxTopicSellerReviewed, outbox.LogNotifier)
+	dispatcher.Subscribe(models.OutboxTopicDataExportRequested, dataExportService.Generate)
+	dispatcher.Subscribe(models.OutboxTopicDataExportReady, outbox.LogNotifier)
+	dispatcher.Subscribe(models.OutboxTopicTicketImportQueued, ticketImportService.Process)
+	dispatcher.Subscribe(models.OutboxTopicWebhookDelivery, webhookService.Deliver)
+	dispatcher.Subscribe(models.OutboxTopicTicketSold, analyticsService.RecordPurchase)
+
+	// Domain events also go to the configured broker, if any
+	eventPublisher, err := events.NewPublisher(&cfg.Events)
+	if err != nil {
+		log.Fatal("Failed to configure events publisher:", err)
+	}
+	if eventPublisher != nil {
+		for _, topic := range events.Topics() {
+			dispatcher.Subscribe(topic, events.Forward(eventPublisher))
+		}
+	}
+
+	// Initialize background jobs
+	scheduler := jobs.NewScheduler()
+	if cfg.Jobs.Enabled {
+		registerJobs(scheduler, &cfg.Jobs, &cfg.Outbox, userService, dataExportService, transferService, paymentService, refundRequestService, ticketService, eventService, queueService, lotteryService, dispatcher)
+	}
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
-	userHandler := handlers.NewUserHandler(userService)
-	sellerHandler := handlers.NewSellerHandler(sellerService)
-	adminHandler := handlers.NewAdminHandler(adminService)
+	userHandler := handlers.NewUserHandler(userService, purchaseHistoryService)
+	sellerHandler := handlers.NewSellerHandler(sellerService, eventService)
+	adminHandler := handlers.NewAdminHandler(adminService, services.NewDuplicateAccountService(userRepo, paymentMethodRepo, paymentRepo, auditRepo))
 	eventHandler := handlers.NewEventHandler(eventService)
 	ticketHandler := handlers.NewTicketHandler(ticketService)
 	transferHandler := handlers.NewTransferHandler(transferService)
 	saleHandler := handlers.NewSaleHandler(saleService)
 	paymentMethodHandler := handlers.NewPaymentMethodHandler(paymentMethodService)
-	paymentHandler := handlers.NewPaymentHandler(paymentService)
-	pdfHandler := handlers.NewPDFHandler(pdfService, purchasedTicketRepo, eventRepo)
+	paymentHandler := handlers.NewPaymentHandler(paymentService, refundRequestService)
+	pdfHandler := handlers.NewPDFHandler(pdfService, purchasedTicketRepo, eventRepo, giftService, ticketCodes)
+	deepLinkHandler := handlers.NewDeepLinkHandler(deepLinkService)
+	queueHandler := handlers.NewQueueHandler(queueService)
+	lotteryHandler := handlers.NewLotteryHandler(lotteryService)
+	denylistHandler := handlers.NewDenylistHandler(denylistService)
+	policyHandler := handlers.NewPolicyHandler(policyService)
+	announcementHandler := handlers.NewAnnouncementHandler(services.NewAnnouncementService(announcementRepo))
+	sellerOnboardingHandler := handlers.NewSellerOnboardingHandler(sellerOnboardingService)
+	fraudReviewHandler := handlers.NewFraudReviewHandler(services.NewFraudReviewService(orderRepo, eventRepo, userRepo, outboxRepo, txManager, ticketService, cfg.Payment.RetryGrace))
+	healthHandler := handlers.NewHealthHandler(db, &cfg.Redis, "1.0.0")
+	jobHandler := handlers.NewJobHandler(scheduler)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	attendeeHandler := handlers.NewAttendeeHandler(attendeeService)
+	pricingHandler := handlers.NewPricingHandler(pricingService)
+	bulkOrderHandler := handlers.NewBulkOrderHandler(bulkOrderService)
+	disputeHandler := handlers.NewDisputeHandler(disputeService)
+	venueHandler := handlers.NewVenueHandler(venueService, eventService)
+	calendarHandler := handlers.NewCalendarHandler(calendarService)
+	reportHandler := handlers.NewReportHandler(reportService)
+	followHandler := handlers.NewFollowHandler(followService)
+	eventMemberHandler := handlers.NewEventMemberHandler(services.NewEventMemberService(eventMemberRepo, eventRepo, sellerRepo))
+	dataExportHandler := handlers.NewDataExportHandler(dataExportService)
+	auditHandler := handlers.NewAuditHandler(services.NewAuditService(auditRepo, adminRepo, userRepo, sellerRepo, jwtManager))
+	deadLetterHandler := handlers.NewDeadLetterHandler(services.NewDeadLetterService(deadLetterRepo, outboxRepo, txManager))
+	ticketImportHandler := handlers.NewTicketImportHandler(ticketImportService)
+	featureFlagHandler := handlers.NewFeatureFlagHandler(featureFlagService)
+	maintenanceHandler := handlers.NewMaintenanceHandler(maintenanceService)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService, services.NewSalesVelocityService(eventRepo, eventAccess, ticketRepo, purchasedTicketRepo, saleRepo))
 
 	gin.SetMode(gin.ReleaseMode)
 
+	if err := utils.RegisterValidators(); err != nil {
+		log.Fatal("Failed to register request validators:", err)
+	}
+
+	rateLimiter := middleware.NewRateLimiter(cfg.Server.RateLimitRefill, cfg.Server.RateLimitBurst)
+	runtimeConfigService := services.NewRuntimeConfigService(envFile, cfg.Runtime(), rateLimiter, featureFlagService, auditRepo)
+	configHandler := handlers.NewConfigHandler(runtimeConfigService)
+
 	// Initialize router
-	router := setupRouter(
+	// Each handler registers its own routes
+	modules := []handlers.RouteRegistrar{
 		authHandler,
 		userHandler,
 		sellerHandler,
@@ -100,7 +292,49 @@ func main() {
 		paymentMethodHandler,
 		paymentHandler,
 		pdfHandler,
+		deepLinkHandler,
+		queueHandler,
+		lotteryHandler,
+		fraudReviewHandler,
+		denylistHandler,
+		policyHandler,
+		sellerOnboardingHandler,
+		jobHandler,
+		webhookHandler,
+		attendeeHandler,
+		pricingHandler,
+		bulkOrderHandler,
+		disputeHandler,
+		venueHandler,
+		calendarHandler,
+		reportHandler,
+		followHandler,
+		dataExportHandler,
+		auditHandler,
+		eventMemberHandler,
+		deadLetterHandler,
+		ticketImportHandler,
+		featureFlagHandler,
+		configHandler,
+		maintenanceHandler,
+		announcementHandler,
+		analyticsHandler,
+		healthHandler,
+	}
+
+	router := setupRouter(
+		modules,
+		healthHandler,
 		jwtManager,
+		auditRepo,
+		&cfg.Tracing,
+		&cfg.Server,
+		rateLimiter,
+		featureFlagService,
+		queueService,
+		maintenanceService,
+		policyService,
+		sellerOnboardingService,
 	)
 
 	// Create HTTP server
@@ -111,6 +345,8 @@ func main() {
 		WriteTimeout: cfg.Server.WriteTimeout,
 	}
 
+	scheduler.Start()
+
 	// Start server in a goroutine
 	go func() {
 		log.Printf("Server starting on %s:%s", cfg.Server.Host, cfg.Server.Port)
@@ -119,183 +355,302 @@ func main() {
 		}
 	}()
 
+	// Components stop in this order: nothing new is accepted, then background
+	// work drains, then the tracer and database it may still use are closed
+	shutdown := lifecycle.NewManager(cfg.Server.ShutdownTimeout)
+	shutdown.Add("http server", func(ctx context.Context) error {
+		if err := server.Shutdown(ctx); err != nil {
+			// Drop whatever connections are still open
+			_ = server.Close()
+			return err
+		}
+		return nil
+	})
+	shutdown.Add("rate limiter", func(ctx context.Context) error {
+		rateLimiter.Stop()
+		return nil
+	})
+	// Also drains the outbox dispatcher, which runs as a scheduled job
+	shutdown.Add("background jobs", scheduler.Shutdown)
+	if eventPublisher != nil {
+		shutdown.Add("events publisher", func(ctx context.Context) error {
+			return eventPublisher.Close()
+		})
+	}
+	shutdown.Add("tracing", shutdownTracing)
+	shutdown.Add("database", func(ctx context.Context) error {
+		return db.Close()
+	})
+
+	// SIGHUP reloads the runtime settings instead of stopping the server
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			result, err := runtimeConfigService.Reload(0, "")
+			if err != nil {
+				log.Printf("config: %v", err)
+				continue
+			}
+			log.Printf("config: reloaded, %d setting(s) changed", len(result.Changes))
+		}
+	}()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
 
-	// Create context with timeout for graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	// Shutdown server gracefully
-	if err := server.Shutdown(ctx); err != nil {
-		log.Printf("Server forced to shutdown: %v", err)
-		// Force shutdown if graceful shutdown fails
-		if err := server.Close(); err != nil {
-			log.Printf("Error closing server: %v", err)
-		}
-	} else {
-		log.Println("Server gracefully stopped")
+	if err := shutdown.Shutdown(); err != nil {
+		log.Printf("Shutdown finished with errors: %v", err)
 	}
 
 	log.Println("Server exited")
 }
 
+func registerJobs(
+	scheduler *jobs.Scheduler,
+	cfg *config.JobsConfig,
+	outboxCfg *config.OutboxConfig,
+	userService *services.UserService,
+	dataExportService *services.DataExportService,
+	transferService *services.TransferService,
+	paymentService *services.PaymentService,
+	refundRequestService *services.RefundRequestService,
+	ticketService *services.TicketService,
+	eventService *services.EventService,
+	queueService *services.QueueService,
+	lotteryService *services.LotteryService,
+	dispatcher *outbox.Dispatcher,
+) {
+	scheduler.Register("transfer_expiry", cfg.TransferExpiryInterval, func(ctx context.Context) error {
+		expired, err := transferService.ExpireStaleTransfers(cfg.TransferTTL)
+		if expired > 0 {
+			log.Printf("jobs: cancelled %d stale transfer(s)", expired)
+		}
+		return err
+	})
+
+	scheduler.Register("payment_expiry", cfg.PaymentExpiryInterval, func(ctx context.Context) error {
+		expired, err := paymentService.ExpireStalePayments(cfg.PaymentTTL)
+		if expired > 0 {
+			log.Printf("jobs: failed %d stale pending payment(s)", expired)
+		}
+		return err
+	})
+
+	scheduler.Register("refund_requests", cfg.RefundRequestInterval, func(ctx context.Context) error {
+		closed, err := refundRequestService.ClosePastRequests()
+		if closed > 0 {
+			log.Printf("jobs: closed %d refund request(s) for past events", closed)
+		}
+		return err
+	})
+
+	scheduler.Register("order_expiry", cfg.OrderExpiryInterval, func(ctx context.Context) error {
+		expired, err := ticketService.ExpireHeldOrders(100)
+		if expired > 0 {
+			log.Printf("jobs: released tickets of %d unpaid order(s)", expired)
+		}
+		return err
+	})
+
+	scheduler.Register("event_publish", cfg.EventPublishInterval, func(ctx context.Context) error {
+		published, err := eventService.PublishScheduledEvents(100)
+		if published > 0 {
+			log.Printf("jobs: published %d scheduled event(s)", published)
+		}
+		return err
+	})
+
+	scheduler.Register("queue_dispense", cfg.QueueDispenseInterval, func(ctx context.Context) error {
+		expired, err := queueService.ExpireAdmissions()
+		if err != nil {
+			return err
+		}
+		if expired > 0 {
+			log.Printf("jobs: expired %d unused queue admission(s)", expired)
+		}
+
+		admitted, err := queueService.Dispense(cfg.QueueDispenseInterval)
+		if admitted > 0 {
+			log.Printf("jobs: admitted %d buyer(s) from waiting rooms", admitted)
+		}
+		return err
+	})
+
+	scheduler.Register("lottery_draw", cfg.LotteryDrawInterval, func(ctx context.Context) error {
+		drawn, err := lotteryService.DrawDue(20)
+		if drawn > 0 {
+			log.Printf("jobs: drew %d lottery sale(s)", drawn)
+		}
+		return err
+	})
+
+	scheduler.Register("account_deletion", cfg.AccountDeletionInterval, func(ctx context.Context) error {
+		anonymized, err := userService.AnonymizeDueAccounts(100)
+		if anonymized > 0 {
+			log.Printf("jobs: anonymized %d deleted account(s)", anonymized)
+		}
+		return err
+	})
+
+	scheduler.Register("data_export_cleanup", cfg.DataExportCleanupInterval, func(ctx context.Context) error {
+		deleted, err := dataExportService.PurgeExpired()
+		if deleted > 0 {
+			log.Printf("jobs: deleted %d expired data export(s)", deleted)
+		}
+		return err
+	})
+
+	scheduler.Register("client_info_cleanup", cfg.ClientInfoCleanupInterval, func(ctx context.Context) error {
+		payments, err := paymentService.ClearClientInfo(cfg.ClientInfoRetention)
+		if err != nil {
+			return err
+		}
+		orders, err := ticketService.ClearOrderClientInfo(cfg.ClientInfoRetention)
+		if payments+orders > 0 {
+			log.Printf("jobs: cleared client info of %d payment(s) and %d order(s)", payments, orders)
+		}
+		return err
+	})
+
+	scheduler.Register("outbox_dispatch", outboxCfg.DispatchInterval, func(ctx context.Context) error {
+		_, err := dispatcher.Dispatch(ctx)
+		return err
+	})
+}
+
+// envFile is read at startup and again on every configuration reload
+const envFile = ".env"
+
 func setupRouter(
-	authHandler *handlers.AuthHandler,
-	userHandler *handlers.UserHandler,
-	sellerHandler *handlers.SellerHandler,
-	adminHandler *handlers.AdminHandler,
-	eventHandler *handlers.EventHandler,
-	ticketHandler *handlers.TicketHandler,
-	transferHandler *handlers.TransferHandler,
-	saleHandler *handlers.SaleHandler,
-	paymentMethodHandler *handlers.PaymentMethodHandler,
-	paymentHandler *handlers.PaymentHandler,
-	pdfHandler *handlers.PDFHandler,
+	modules []handlers.RouteRegistrar,
+	healthHandler *handlers.HealthHandler,
 	jwtManager *utils.JWTManager,
+	auditRepo repositories.AuditLogRepository,
+	tracingCfg *config.TracingConfig,
+	serverCfg *config.ServerConfig,
+	rateLimiter *middleware.RateLimiter,
+	featureFlags middleware.FeatureChecker,
+	queue middleware.QueueGate,
+	maintenance middleware.MaintenanceChecker,
+	policies middleware.PolicyChecker,
+	onboarding middleware.SellerApproval,
 ) *gin.Engine {
 	router := gin.New()
 
 	// Global middleware
+	if tracingCfg.Enabled {
+		router.Use(otelgin.Middleware(tracingCfg.ServiceName))
+	}
+	router.Use(middleware.RequestIDMiddleware())
+	router.Use(middleware.LocaleMiddleware())
 	router.Use(middleware.LoggingMiddleware())
 	router.Use(middleware.RecoveryMiddleware())
 	router.Use(middleware.CORSMiddleware())
+	router.Use(middleware.SecurityHeadersMiddleware(serverCfg.HSTSMaxAge))
+	router.Use(middleware.CompressionMiddleware(serverCfg.CompressionMinBytes))
 
-	// Rate limiting middleware
-	router.Use(middleware.RateLimitMiddleware(time.Minute, 500))
-
-	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":    "healthy",
-			"timestamp": time.Now().UTC(),
-			"version":   "1.0.0",
-		})
-	})
+	// Answers 503 to everyone but admins while maintenance mode is on;
+	// health checks stay live
+	router.Use(middleware.MaintenanceMiddleware(maintenance, jwtManager))
 
-	// API routes
-	api := router.Group("/api/v1")
-	{
-		// Auth routes (public)
-		auth := api.Group("/auth")
-		{
-			auth.POST("/register", authHandler.Register)
-			auth.POST("/login", authHandler.Login)
-			auth.POST("/refresh", authHandler.RefreshToken)
-			auth.POST("/logout", authHandler.Logout)
-		}
-
-		// Events routes (public for viewing)
-		events := api.Group("/events")
-		{
-			events.GET("", eventHandler.GetEvents)
-			events.GET("/:event_id", eventHandler.GetEvent)
-			events.GET("/:event_id/tickets", ticketHandler.GetEventTickets)                         // Legacy endpoint
-			events.GET("/:event_id/grouped-tickets", ticketHandler.GetAvailableGroupedEventTickets) // New grouped endpoint
-			events.GET("/:event_id/sales", saleHandler.GetSalesByEvent)
+	// Rate limiting middleware
+	router.Use(rateLimiter.Middleware())
+
+	// Health check endpoints
+	router.GET("/health", healthHandler.Health)
+	router.GET("/health/live", healthHandler.Live)
+	router.GET("/health/ready", healthHandler.Ready)
+
+	// Both API versions are served by the same handlers and services. Every
+	// module registers its routes for each version; where v2 breaks
+	// compatibility it gets its own handler, and v1 endpoints it drops are
+	// marked deprecated.
+	registerAPI := func(api *gin.RouterGroup, version handlers.APIVersion) {
+		// Accounts that haven't accepted the policies in force can only read
+		signedIn := api.Group("",
+			middleware.AuthMiddleware(jwtManager),
+			middleware.AuditMiddleware(auditRepo),
+			middleware.RequirePolicyAcceptance(policies),
+		)
+
+		// Bodies are capped per group rather than globally, as a group
+		// inherits its parent's limit and can't raise it
+		bodyLimit := middleware.BodyLimitMiddleware(serverCfg.MaxBodyBytes)
+		protected := signedIn.Group("", bodyLimit)
+
+		routes := &handlers.Routes{
+			Version:   version,
+			Public:    api.Group("", bodyLimit),
+			Events:    api.Group("/events", bodyLimit, middleware.ETagMiddleware()),
+			Protected: protected,
+			Seller:    protected.Group("/seller", middleware.RequireRole(models.UserTypeSeller)),
+			Admin:     protected.Group("/admin", middleware.RequireRole(models.UserTypeAdmin)),
+			SellerUploads: signedIn.Group("/seller",
+				middleware.BodyLimitMiddleware(serverCfg.MaxUploadBytes),
+				middleware.RequireRole(models.UserTypeSeller),
+			),
+			JWTManager: jwtManager,
+			Features:   featureFlags,
+			Queue:      queue,
+			Onboarding: onboarding,
+			V1Sunset:   serverCfg.V1Sunset,
 		}
-
-		// Sales routes (public for viewing specific sale)
-		sales := api.Group("/sales")
-		{
-			sales.GET("/:sale_id", saleHandler.GetSale)
+		for _, module := range modules {
+			module.RegisterRoutes(routes)
 		}
+	}
 
-		// Protected routes
-		protected := api.Group("")
-		protected.Use(middleware.AuthMiddleware(jwtManager))
-		{
-			// User routes
-			users := protected.Group("/users")
-			{
-				users.GET("/profile", userHandler.GetProfile)
-				users.PUT("/profile", userHandler.UpdateProfile)
-				users.PUT("/password", userHandler.ChangePassword)
-				users.DELETE("/profile", userHandler.DeleteAccount)
-			}
+	registerAPI(router.Group("/api/v1", middleware.APIVersionMiddleware("1")), handlers.APIV1)
+	registerAPI(router.Group("/api/v2", middleware.APIVersionMiddleware("2")), handlers.APIV2)
 
-			// Ticket routes
-			tickets := protected.Group("/tickets")
-			{
-				tickets.POST("/purchase", ticketHandler.PurchaseTicket)                // Legacy individual ticket purchase
-				tickets.POST("/purchase-group", ticketHandler.PurchaseTicketFromGroup) // New grouped ticket purchase
-				tickets.GET("/my", ticketHandler.GetMyTickets)
-				tickets.POST("/transfer", transferHandler.InitiateTransfer) // Updated to use transferHandler
+	return router
+}
 
-				tickets.GET("/:ticket_id/download", pdfHandler.DownloadTicketPDF)
-				tickets.GET("/:ticket_id/view", pdfHandler.ViewTicketPDF)
-			}
+// newScreener builds the checks purchases go through before they are
+// charged, plus the external fraud API when one is configured. The
+// admin-managed denylist applies even with the fraud checks turned off.
+func newScreener(cfg *config.FraudConfig, paymentRepo repositories.PaymentRepository, denylist fraud.Denylist) *fraud.Screener {
+	checkers := []fraud.Checker{fraud.NewDenylistChecker(denylist)}
+	if !cfg.Enabled {
+		return fraud.NewScreener(checkers...)
+	}
 
-			// Transfer routes
-			transfers := protected.Group("/transfers")
-			{
-				transfers.GET("/active", transferHandler.GetActiveTransfers)
-				transfers.POST("/:transfer_id/accept", transferHandler.AcceptTransfer)
-				transfers.POST("/:transfer_id/reject", transferHandler.RejectTransfer)
-				transfers.GET("/history", transferHandler.GetTransferHistory)
-			}
+	checkers = append(checkers,
+		fraud.NewVelocityChecker(paymentRepo, cfg.VelocityWindow, cfg.ReviewAttempts, cfg.DeclineAttempts, cfg.IPReviewAttempts),
+		fraud.NewCardListChecker(cfg.BlockedCards),
+	)
+	if cfg.EmailMismatch {
+		checkers = append(checkers, fraud.NewEmailMismatchChecker())
+	}
+	if cfg.APIURL != "" {
+		checkers = append(checkers, fraud.NewRemoteChecker(cfg.APIURL, cfg.APIKey, cfg.APITimeout))
+	}
 
-			payments := protected.Group("/payments")
-			{
-				payments.GET("/my", paymentHandler.GetUserPayments)
-				payments.GET("/:id", paymentHandler.GetPaymentStatus)
-			}
+	return fraud.NewScreener(checkers...)
+}
 
-			// Seller routes
-			seller := protected.Group("/seller")
-			seller.Use(middleware.RequireRole(models.UserTypeSeller))
-			{
-				seller.GET("/profile", sellerHandler.GetProfile)
-				seller.PUT("/profile", sellerHandler.UpdateProfile)
-				seller.PUT("/password", sellerHandler.ChangePassword)
-				seller.DELETE("/profile", sellerHandler.DeleteAccount)
-
-				seller.POST("/events", eventHandler.CreateEvent)
-				seller.GET("/events", eventHandler.GetMyEvents)
-				seller.PUT("/events/:event_id", eventHandler.UpdateEvent)
-				seller.DELETE("/events/:event_id", eventHandler.DeleteEvent)
-
-				// Sales management for sellers
-				seller.POST("/sales", saleHandler.CreateSale)
-				seller.PUT("/sales/:sale_id", saleHandler.UpdateSale)
-				seller.DELETE("/sales/:sale_id", saleHandler.DeleteSale)
-
-				seller.POST("/tickets", ticketHandler.CreateTickets)
-				seller.PUT("/events/:event_id/tickets", ticketHandler.UpdateTickets)
-				seller.DELETE("/events/:event_id/tickets", ticketHandler.DeleteTickets)
-				seller.GET("/events/:event_id/grouped-tickets", ticketHandler.GetGroupedEventTickets)
-
-				seller.GET("/payments", paymentHandler.GetSellerPayments)
-
-				seller.GET("/stats", sellerHandler.GetStats)
-			}
+// newModerator builds the event content checks: the local word lists, plus
+// the external moderation API when one is configured
+func newModerator(cfg *config.ModerationConfig) *moderation.Moderator {
+	if !cfg.Enabled {
+		return moderation.NewModerator()
+	}
 
-			// Admin routes
-			admin := protected.Group("/admin")
-			admin.Use(middleware.RequireRole(models.UserTypeAdmin))
-			{
-				admin.GET("/events/pending", adminHandler.GetPendingEvents)
-				admin.POST("/events/:event_id/approve", adminHandler.ApproveEvent)
-				admin.POST("/events/:event_id/reject", adminHandler.RejectEvent)
-				admin.GET("/stats", func(c *gin.Context) {
-					c.JSON(http.StatusOK, gin.H{"message": "Admin stats - not implemented yet"})
-				})
-			}
+	blocked, flagged := cfg.BlockWords, cfg.FlagWords
+	if len(blocked) == 0 {
+		blocked = moderation.DefaultBlockedWords
+	}
+	if len(flagged) == 0 {
+		flagged = moderation.DefaultFlaggedWords
+	}
 
-			paymentMethods := protected.Group("/payment-methods")
-			admin.Use(middleware.RequireRole(models.UserTypeUser))
-			{
-				paymentMethods.POST("", paymentMethodHandler.CreatePaymentMethod)
-				paymentMethods.GET("", paymentMethodHandler.GetPaymentMethods)
-				paymentMethods.GET("/:id", paymentMethodHandler.GetPaymentMethod)
-				paymentMethods.PUT("/:id", paymentMethodHandler.UpdatePaymentMethod)
-				paymentMethods.DELETE("/:id", paymentMethodHandler.DeletePaymentMethod)
-				paymentMethods.POST("/:id/set-default", paymentMethodHandler.SetDefaultPaymentMethod)
-			}
-		}
+	checkers := []moderation.Checker{moderation.NewWordListChecker(blocked, flagged)}
+	if cfg.APIURL != "" {
+		checkers = append(checkers, moderation.NewRemoteChecker(cfg.APIURL, cfg.APIKey, cfg.APITimeout))
 	}
 
-	return router
+	return moderation.NewModerator(checkers...)
 }
@@ -9,18 +9,49 @@ import (
 
 type (
 	Config struct {
-		Server   ServerConfig   `envconfig:"SERVER"`
-		Database DatabaseConfig `envconfig:"DB"`
-		Redis    RedisConfig    `envconfig:"REDIS"`
-		JWT      JWTConfig      `envconfig:"JWT"`
-		Payment  Payment        `envconfig:"PAYMENT"`
+		Server     ServerConfig     `envconfig:"SERVER"`
+		Database   DatabaseConfig   `envconfig:"DB"`
+		Redis      RedisConfig      `envconfig:"REDIS"`
+		JWT        JWTConfig        `envconfig:"JWT"`
+		Payment    Payment          `envconfig:"PAYMENT"`
+		Tracing    TracingConfig    `envconfig:"OTEL"`
+		Jobs       JobsConfig       `envconfig:"JOBS"`
+		Outbox     OutboxConfig     `envconfig:"OUTBOX"`
+		Bulk       BulkConfig       `envconfig:"BULK_ORDER"`
+		Moderation ModerationConfig `envconfig:"MODERATION"`
+		Account    AccountConfig    `envconfig:"ACCOUNT"`
+		Events     EventsConfig     `envconfig:"EVENTS"`
+		Analytics  AnalyticsConfig  `envconfig:"ANALYTICS"`
+		TicketCode TicketCodeConfig `envconfig:"TICKET_CODE"`
+		DeepLink   DeepLinkConfig   `envconfig:"DEEP_LINK"`
+		Queue      QueueConfig      `envconfig:"QUEUE"`
+		Lottery    LotteryConfig    `envconfig:"LOTTERY"`
+		Fraud      FraudConfig      `envconfig:"FRAUD"`
 	}
 
 	ServerConfig struct {
 		Port         string        `envconfig:"PORT" default:"8080"`
 		Host         string        `envconfig:"HOST" default:"0.0.0.0"`
+		PublicURL    string        `envconfig:"PUBLIC_URL" default:"http://localhost:8080"` // Base of links sent by email or handed to calendar apps
 		ReadTimeout  time.Duration `envconfig:"READ_TIMEOUT" default:"10s"`
 		WriteTimeout time.Duration `envconfig:"WRITE_TIMEOUT" default:"10s"`
+		MaxBodyBytes int64         `envconfig:"MAX_BODY_BYTES" default:"1048576"`
+		HSTSMaxAge   time.Duration `envconfig:"HSTS_MAX_AGE" default:"8760h"` // 0 disables Strict-Transport-Security
+		V1Sunset     time.Time     `envconfig:"V1_SUNSET"`                    // RFC 3339; announced on deprecated /api/v1 endpoints
+		// Body cap of seller document uploads and CSV ticket imports instead of
+		// MaxBodyBytes; room for a 10 MiB document and its multipart framing
+		MaxUploadBytes int64 `envconfig:"MAX_UPLOAD_BYTES" default:"11534336"`
+		// Deadline for stopping the server, jobs and connections on SIGTERM
+		ShutdownTimeout time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"15s"`
+		// Smallest JSON/text response worth gzipping; 0 disables compression
+		CompressionMinBytes int `envconfig:"COMPRESSION_MIN_BYTES" default:"1024"`
+		// Deployment name, e.g. production or staging; feature flags can be limited to some
+		Environment string `envconfig:"ENVIRONMENT" default:"development"`
+
+		// Per client IP: up to RateLimitBurst requests, refilled one per
+		// RateLimitRefill. Reloadable, see Runtime.
+		RateLimitBurst  int           `envconfig:"RATE_LIMIT_BURST" default:"500"`
+		RateLimitRefill time.Duration `envconfig:"RATE_LIMIT_REFILL" default:"1m"`
 	}
 
 	DatabaseConfig struct {
@@ -32,6 +63,9 @@ type (
 		SSLMode  string `envconfig:"SSL_MODE" default:"disable"`
 		MaxConns int    `envconfig:"MAX_CONNS" default:"25"`
 		MaxIdle  int    `envconfig:"MAX_IDLE" default:"5"`
+
+		SlowQueryThreshold time.Duration `envconfig:"SLOW_QUERY_THRESHOLD" default:"200ms"` // Queries slower than this are logged; 0 disables
+		ReplicaHosts       []string      `envconfig:"REPLICA_HOSTS"`                        // host or host:port of read replicas; reads stay on the primary when empty
 	}
 
 	RedisConfig struct {
@@ -46,19 +80,171 @@ type (
 		AccessDuration  time.Duration `envconfig:"ACCESS_DURATION" default:"15m"`
 		RefreshDuration time.Duration `envconfig:"REFRESH_DURATION" default:"168h"` // 7 days
 		Issuer          string        `envconfig:"ISSUER" default:"e-ticketing-system"`
+
+		ImpersonationDuration time.Duration `envconfig:"IMPERSONATION_DURATION" default:"15m"` // Lifetime of support impersonation tokens
 	}
 
 	Payment struct {
-		IsMocked bool `envconfig:"IS_MOCKED" default:"true"`
+		IsMocked      bool          `envconfig:"IS_MOCKED" default:"true"`
+		RetryGrace    time.Duration `envconfig:"RETRY_GRACE" default:"10m"` // How long tickets stay held after a failed charge
+		WebhookSecret string        `envconfig:"WEBHOOK_SECRET"`            // Verifies provider webhooks; they are refused while unset
+
+		// Guards on provider calls; an unreachable provider fails the charge
+		// and the tickets are held for a retry
+		ProviderTimeout  time.Duration `envconfig:"PROVIDER_TIMEOUT" default:"5s"` // Per attempt
+		ProviderRetries  int           `envconfig:"PROVIDER_RETRIES" default:"2"`
+		ProviderBackoff  time.Duration `envconfig:"PROVIDER_BACKOFF" default:"200ms"`
+		BreakerThreshold int           `envconfig:"BREAKER_THRESHOLD" default:"5"` // Consecutive failures; 0 disables the breaker
+		BreakerCooldown  time.Duration `envconfig:"BREAKER_COOLDOWN" default:"30s"`
+
+		// How the mocked providers behave while IS_MOCKED is set. A seed makes
+		// the sequence of approvals and declines repeatable.
+		MockApprovalRate float64       `envconfig:"MOCK_APPROVAL_RATE" default:"0.9"`
+		MockLatency      time.Duration `envconfig:"MOCK_LATENCY" default:"0s"`
+		MockSeed         uint64        `envconfig:"MOCK_SEED" default:"0"`
+
+		// Customers can ask for refunds until this long before an event,
+		// unless the event sets its own cutoff
+		RefundCutoff time.Duration `envconfig:"REFUND_CUTOFF" default:"48h"`
+
+		// Share of each customer payment kept by the platform. Reloadable.
+		PlatformFeeRate float64 `envconfig:"PLATFORM_FEE_RATE" default:"0.05"`
+	}
+
+	TracingConfig struct {
+		Enabled     bool    `envconfig:"ENABLED" default:"false"`
+		Endpoint    string  `envconfig:"ENDPOINT" default:"localhost:4318"` // OTLP/HTTP collector
+		Insecure    bool    `envconfig:"INSECURE" default:"true"`
+		ServiceName string  `envconfig:"SERVICE_NAME" default:"e-ticketing-backend"`
+		SampleRatio float64 `envconfig:"SAMPLE_RATIO" default:"1"`
+	}
+
+	// JobsConfig holds background job schedules; an interval of 0 disables a job
+	JobsConfig struct {
+		Enabled                   bool          `envconfig:"ENABLED" default:"true"`
+		TransferExpiryInterval    time.Duration `envconfig:"TRANSFER_EXPIRY_INTERVAL" default:"5m"`
+		TransferTTL               time.Duration `envconfig:"TRANSFER_TTL" default:"72h"`
+		PaymentExpiryInterval     time.Duration `envconfig:"PAYMENT_EXPIRY_INTERVAL" default:"1m"`
+		PaymentTTL                time.Duration `envconfig:"PAYMENT_TTL" default:"15m"`
+		OrderExpiryInterval       time.Duration `envconfig:"ORDER_EXPIRY_INTERVAL" default:"1m"`
+		EventPublishInterval      time.Duration `envconfig:"EVENT_PUBLISH_INTERVAL" default:"1m"`
+		AccountDeletionInterval   time.Duration `envconfig:"ACCOUNT_DELETION_INTERVAL" default:"1h"`
+		DataExportCleanupInterval time.Duration `envconfig:"DATA_EXPORT_CLEANUP_INTERVAL" default:"1h"`
+		ClientInfoCleanupInterval time.Duration `envconfig:"CLIENT_INFO_CLEANUP_INTERVAL" default:"24h"`
+		ClientInfoRetention       time.Duration `envconfig:"CLIENT_INFO_RETENTION" default:"2160h"` // Purchase IPs and device fingerprints are kept 90 days
+		RefundRequestInterval     time.Duration `envconfig:"REFUND_REQUEST_INTERVAL" default:"15m"` // Closes requests for events that took place
+		QueueDispenseInterval     time.Duration `envconfig:"QUEUE_DISPENSE_INTERVAL" default:"1m"`  // Admits the next buyers of every waiting room
+		LotteryDrawInterval       time.Duration `envconfig:"LOTTERY_DRAW_INTERVAL" default:"1m"`    // Draws lottery sales once registration closes
+	}
+
+	// OutboxConfig controls delivery of queued notifications and webhooks
+	OutboxConfig struct {
+		DispatchInterval time.Duration `envconfig:"DISPATCH_INTERVAL" default:"5s"`
+		BatchSize        int           `envconfig:"BATCH_SIZE" default:"50"`
+		MaxAttempts      int           `envconfig:"MAX_ATTEMPTS" default:"8"`
+		RetryBackoff     time.Duration `envconfig:"RETRY_BACKOFF" default:"30s"`
+		WebhookTimeout   time.Duration `envconfig:"WEBHOOK_TIMEOUT" default:"10s"`
+	}
+
+	// BulkConfig holds organization order limits for events that don't set their own
+	BulkConfig struct {
+		MaxQuantity       int `envconfig:"MAX_QUANTITY" default:"200"`
+		ApprovalThreshold int `envconfig:"APPROVAL_THRESHOLD" default:"50"`
+	}
+
+	// ModerationConfig controls scanning of event titles and descriptions.
+	// Empty word lists fall back to the built-in ones.
+	ModerationConfig struct {
+		Enabled    bool          `envconfig:"ENABLED" default:"true"`
+		BlockWords []string      `envconfig:"BLOCK_WORDS"` // Comma-separated; refused outright
+		FlagWords  []string      `envconfig:"FLAG_WORDS"`  // Comma-separated; held for admin review
+		APIURL     string        `envconfig:"API_URL"`     // Optional external moderation API
+		APIKey     string        `envconfig:"API_KEY"`
+		APITimeout time.Duration `envconfig:"API_TIMEOUT" default:"3s"`
+	}
+
+	// EventsConfig chooses where domain events (ticket sales, transfers,
+	// event moderation) are published besides the in-process subscribers
+	EventsConfig struct {
+		Publisher     string        `envconfig:"PUBLISHER"` // none (default), log, nats or kafka_rest
+		NATSURL       string        `envconfig:"NATS_URL" default:"nats://localhost:4222"`
+		KafkaRESTURL  string        `envconfig:"KAFKA_REST_URL" default:"http://localhost:8082"`
+		SubjectPrefix string        `envconfig:"SUBJECT_PREFIX" default:"eticketing."` // Prepended to the topic, e.g. eticketing.ticket.sold
+		Timeout       time.Duration `envconfig:"TIMEOUT" default:"5s"`
+	}
+
+	// AnalyticsConfig controls behavioral event tracking
+	AnalyticsConfig struct {
+		Enabled bool   `envconfig:"ENABLED" default:"true"`
+		Salt    string `envconfig:"SALT"` // Keys the visitor hash; falls back to JWT_SECRET. Changing it splits visitors in two.
+	}
+
+	// TicketCodeConfig controls the rotating QR codes shown in the app
+	TicketCodeConfig struct {
+		SigningKey string        `envconfig:"SIGNING_KEY"`          // Falls back to JWT_SECRET
+		Period     time.Duration `envconfig:"PERIOD" default:"30s"` // How often the code changes
+	}
+
+	// DeepLinkConfig controls the links that open tickets in the mobile app
+	DeepLinkConfig struct {
+		SigningKey   string        `envconfig:"SIGNING_KEY"`                 // Falls back to JWT_SECRET
+		Scheme       string        `envconfig:"SCHEME" default:"eticketing"` // Custom URL scheme the app registers
+		UniversalURL string        `envconfig:"UNIVERSAL_URL"`               // Domain the app claims for universal links; falls back to SERVER_PUBLIC_URL
+		TTL          time.Duration `envconfig:"TTL" default:"720h"`          // How long an emailed link keeps working
+	}
+
+	// QueueConfig controls the waiting rooms of high-demand sales
+	QueueConfig struct {
+		AdmitPerMinute  int           `envconfig:"ADMIT_PER_MINUTE" default:"100"` // For events that don't set their own rate
+		AdmissionWindow time.Duration `envconfig:"ADMISSION_WINDOW" default:"10m"` // Time an admitted buyer has to buy
+	}
+
+	// LotteryConfig controls lottery sales
+	LotteryConfig struct {
+		ClaimWindow time.Duration `envconfig:"CLAIM_WINDOW" default:"24h"` // Time winners have to buy, cut short by the sale's end
+	}
+
+	// FraudConfig controls the checks purchases go through before they are
+	// charged. A threshold of 0 turns its check off.
+	FraudConfig struct {
+		Enabled          bool          `envconfig:"ENABLED" default:"true"`
+		VelocityWindow   time.Duration `envconfig:"VELOCITY_WINDOW" default:"1h"`
+		ReviewAttempts   int           `envconfig:"REVIEW_ATTEMPTS" default:"5"`     // Buyer's payment attempts in the window that send a purchase to review
+		DeclineAttempts  int           `envconfig:"DECLINE_ATTEMPTS" default:"15"`   // Buyer's attempts in the window that decline it
+		IPReviewAttempts int           `envconfig:"IP_REVIEW_ATTEMPTS" default:"20"` // Attempts from one IP, across accounts, that send it to review
+		EmailMismatch    bool          `envconfig:"EMAIL_MISMATCH" default:"true"`   // Review purchases paid from another email's wallet
+		BlockedCards     []string      `envconfig:"BLOCKED_CARDS"`                   // Comma-separated provider card tokens to decline
+		ReviewWindow     time.Duration `envconfig:"REVIEW_WINDOW" default:"48h"`     // Tickets of unreviewed orders are released after this
+		APIURL           string        `envconfig:"API_URL"`                         // Optional external fraud scoring API
+		APIKey           string        `envconfig:"API_KEY"`
+		APITimeout       time.Duration `envconfig:"API_TIMEOUT" default:"3s"`
+	}
+
+	// AccountConfig controls account deletion
+	AccountConfig struct {
+		DeletionGracePeriod time.Duration `envconfig:"DELETION_GRACE_PERIOD" default:"720h"` // Time to cancel before data is anonymized
+		ExportTTL           time.Duration `envconfig:"EXPORT_TTL" default:"168h"`            // How long a data export can be downloaded
 	}
 )
 
 func Load() *Config {
+	cfg, err := parse()
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
+	return cfg
+}
+
+func parse() (*Config, error) {
 	var cfg Config
 
 	if err := envconfig.Process("", &cfg); err != nil {
-		log.Fatal("Failed to load configuration:", err)
+		return nil, err
+	}
+	if err := cfg.Runtime().Validate(); err != nil {
+		return nil, err
 	}
 
-	return &cfg
+	return &cfg, nil
 }
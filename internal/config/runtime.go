@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Runtime is the part of the configuration that can be changed while the
+// server runs, by sending it SIGHUP or through the admin API. Everything
+// else is read once at startup.
+type Runtime struct {
+	RateLimitBurst  int
+	RateLimitRefill time.Duration
+	PlatformFeeRate float64
+}
+
+func (c *Config) Runtime() Runtime {
+	return Runtime{
+		RateLimitBurst:  c.Server.RateLimitBurst,
+		RateLimitRefill: c.Server.RateLimitRefill,
+		PlatformFeeRate: c.Payment.PlatformFeeRate,
+	}
+}
+
+func (r Runtime) Validate() error {
+	if r.RateLimitBurst < 1 {
+		return fmt.Errorf("SERVER_RATE_LIMIT_BURST must be at least 1, got %d", r.RateLimitBurst)
+	}
+	if r.RateLimitRefill <= 0 {
+		return fmt.Errorf("SERVER_RATE_LIMIT_REFILL must be positive, got %s", r.RateLimitRefill)
+	}
+	if r.PlatformFeeRate < 0 || r.PlatformFeeRate >= 1 {
+		return fmt.Errorf("PAYMENT_PLATFORM_FEE_RATE must be at least 0 and below 1, got %g", r.PlatformFeeRate)
+	}
+	return nil
+}
+
+// processEnv records which variables the process was started with, so a
+// reload lets the env file fill in the rest without overriding them, just
+// as at startup
+var processEnv = map[string]bool{}
+
+// LoadEnvFile sets the variables in path that the environment doesn't
+// already set. It is called once at startup, before Load.
+func LoadEnvFile(path string) error {
+	for _, entry := range os.Environ() {
+		key, _, _ := strings.Cut(entry, "=")
+		processEnv[key] = true
+	}
+	return godotenv.Load(path)
+}
+
+// ReloadRuntime reads the env file and environment again and returns the
+// reloadable settings. An invalid configuration is returned as an error and
+// nothing is changed.
+func ReloadRuntime(envFile string) (Runtime, error) {
+	values, err := godotenv.Read(envFile)
+	if err != nil && !os.IsNotExist(err) {
+		return Runtime{}, fmt.Errorf("failed to read %s: %w", envFile, err)
+	}
+	for key, value := range values {
+		if !processEnv[key] {
+			_ = os.Setenv(key, value)
+		}
+	}
+
+	cfg, err := parse()
+	if err != nil {
+		return Runtime{}, err
+	}
+	return cfg.Runtime(), nil
+}
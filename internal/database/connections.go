@@ -1,56 +1,104 @@
 package database
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"net"
 	"time"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 
 	"eticketing/internal/config"
+	"eticketing/internal/tracing"
 )
 
 type Database struct {
-	DB *gorm.DB
+	DB       *gorm.DB
+	replicas []*sql.DB
+
+	// Fee rate recorded on payments credited before the rate was stored
+	// per payment
+	platformFeeRate float64
 }
 
 func NewConnection(cfg *config.Config) (*Database, error) {
-	dsn := fmt.Sprintf(
-		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.Name,
-	)
-
 	gormConfig := &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Error),
+		Logger: newQueryLogger(cfg.Database.SlowQueryThreshold),
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
 	}
 
-	db, err := gorm.Open(mysql.Open(dsn), gormConfig)
+	db, err := gorm.Open(mysql.Open(dsn(&cfg.Database, cfg.Database.Host, cfg.Database.Port)), gormConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	if cfg.Tracing.Enabled {
+		if err := db.Use(tracing.NewGormPlugin()); err != nil {
+			return nil, fmt.Errorf("failed to register tracing plugin: %w", err)
+		}
+	}
+
 	// Configure connection pool
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sql.DB: %w", err)
 	}
+	configurePool(sqlDB, &cfg.Database)
 
-	sqlDB.SetMaxOpenConns(cfg.Database.MaxConns)
-	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdle)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	database := &Database{DB: db, platformFeeRate: cfg.Payment.PlatformFeeRate}
 
-	return &Database{DB: db}, nil
+	// Reads outside transactions go to the replicas when any are configured
+	for _, replicaHost := range cfg.Database.ReplicaHosts {
+		host, port := replicaHost, cfg.Database.Port
+		if h, p, err := net.SplitHostPort(replicaHost); err == nil {
+			host, port = h, p
+		}
+
+		replica, err := sql.Open("mysql", dsn(&cfg.Database, host, port))
+		if err != nil {
+			database.Close()
+			return nil, fmt.Errorf("failed to connect to replica %s: %w", replicaHost, err)
+		}
+		configurePool(replica, &cfg.Database)
+		database.replicas = append(database.replicas, replica)
+	}
+
+	if len(database.replicas) > 0 {
+		if err := db.Use(NewReplicaPlugin(database.replicas)); err != nil {
+			database.Close()
+			return nil, fmt.Errorf("failed to register replica plugin: %w", err)
+		}
+	}
+
+	return database, nil
+}
+
+func dsn(cfg *config.DatabaseConfig, host, port string) string {
+	return fmt.Sprintf(
+		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		cfg.User,
+		cfg.Password,
+		host,
+		port,
+		cfg.Name,
+	)
+}
+
+func configurePool(sqlDB *sql.DB, cfg *config.DatabaseConfig) {
+	sqlDB.SetMaxOpenConns(cfg.MaxConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdle)
+	sqlDB.SetConnMaxLifetime(time.Hour)
 }
 
 func (d *Database) Close() error {
+	for _, replica := range d.replicas {
+		_ = replica.Close()
+	}
+
 	sqlDB, err := d.DB.DB()
 	if err != nil {
 		return err
@@ -65,3 +113,11 @@ func (d *Database) Ping() error {
 	}
 	return sqlDB.Ping()
 }
+
+func (d *Database) PingContext(ctx context.Context) error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
@@ -1,31 +1,405 @@
 package database
 
 import (
+	"encoding/json"
 	"log"
 
 	"eticketing/internal/models"
+	"gorm.io/gorm"
 )
 
 func (d *Database) AutoMigrate() error {
 	log.Println("Running database migrations...")
 
+	// Checked before migrating, which adds the column
+	backfillTimestamps := d.DB.Migrator().HasTable(&models.PurchasedTicket{}) &&
+		!d.DB.Migrator().HasColumn(&models.PurchasedTicket{}, "purchased_at")
+	backfillRecordTimestamps := d.DB.Migrator().HasTable(&models.OrderItem{}) &&
+		!d.DB.Migrator().HasColumn(&models.OrderItem{}, "created_at")
+	backfillFeeRates := d.DB.Migrator().HasTable(&models.Payment{}) &&
+		!d.DB.Migrator().HasColumn(&models.Payment{}, "platform_fee_rate")
+	preserveEventData := d.DB.Migrator().HasTable(&models.Event{}) &&
+		!d.DB.Migrator().HasColumn(&models.Event{}, "legacy_data")
+
 	err := d.DB.AutoMigrate(
 		&models.Admin{},
 		&models.User{},
+		&models.Venue{},
 		&models.Event{},
+		&models.EventMember{},
 		&models.Sale{},
+		&models.SaleAllocation{},
+		&models.LotteryEntry{},
+		&models.TicketGroup{},
 		&models.Ticket{},
+		&models.PriceTier{},
 		&models.PurchasedTicket{},
+		&models.TicketImport{},
+		&models.TicketGift{},
+		&models.BulkOrder{},
+		&models.Order{},
+		&models.OrderItem{},
 		&models.Payment{},
+		&models.Refund{},
+		&models.RefundItem{},
+		&models.RefundRequest{},
 		&models.PaymentMethod{},
+		&models.Dispute{},
+		&models.EventReport{},
+		&models.Follow{},
+		&models.DataExport{},
+		&models.AuditLog{},
+		&models.DenylistEntry{},
+		&models.PolicyDocument{},
+		&models.PolicyAcceptance{},
+		&models.Announcement{},
+		&models.SellerDocument{},
 		&models.ActiveTicketTransfer{},
 		&models.DoneTicketTransfer{},
+		&models.TicketClaimLink{},
+		&models.OutboxMessage{},
+		&models.DeadLetter{},
+		&models.SellerWebhook{},
+		&models.WebhookDelivery{},
+		&models.FeatureFlag{},
+		&models.MaintenanceMode{},
+		&models.AnalyticsEvent{},
+		&models.QueueEntry{},
 	)
 
 	if err != nil {
 		return err
 	}
 
+	if err := d.purgeLocalCardData(); err != nil {
+		return err
+	}
+
+	if err := d.backfillTicketGroups(); err != nil {
+		return err
+	}
+
+	if backfillTimestamps {
+		if err := d.backfillTimestamps(); err != nil {
+			return err
+		}
+	}
+
+	if backfillRecordTimestamps {
+		if err := d.backfillRecordTimestamps(); err != nil {
+			return err
+		}
+	}
+
+	if backfillFeeRates {
+		if err := d.backfillFeeRates(); err != nil {
+			return err
+		}
+	}
+
+	if preserveEventData {
+		if err := d.preserveLegacyEventData(); err != nil {
+			return err
+		}
+	}
+
 	log.Println("Database migrations completed successfully")
 	return nil
 }
+
+// purgeLocalCardData removes payment details stored before tokenization.
+// Methods without a provider token can't be charged and are deleted.
+func (d *Database) purgeLocalCardData() error {
+	migrator := d.DB.Migrator()
+	if !migrator.HasColumn(&models.PaymentMethod{}, "data") {
+		return nil
+	}
+
+	if err := d.DB.Where("provider = ?", "").Delete(&models.PaymentMethod{}).Error; err != nil {
+		return err
+	}
+
+	log.Println("Dropped locally stored payment method data")
+	return migrator.DropColumn(&models.PaymentMethod{}, "data")
+}
+
+// backfillTicketGroups moves tickets created before ticket groups existed
+// into groups. Tickets were grouped by their details, description included,
+// so editing a description split a group; the backfill groups them without
+// the description, which rejoins those splits, and keeps the longest one.
+// Price tier schedules, orders and sale allocations are attached to their
+// groups; an allocation matching no group is left without one.
+func (d *Database) backfillTicketGroups() error {
+	var ungrouped, unlinked int64
+	if err := d.DB.Model(&models.Ticket{}).Where("group_id = 0 OR group_id IS NULL").Count(&ungrouped).Error; err != nil {
+		return err
+	}
+	// Allocations were matched on group details until they referenced groups
+	if err := d.DB.Model(&models.SaleAllocation{}).Where("group_id IS NULL").Count(&unlinked).Error; err != nil {
+		return err
+	}
+	if ungrouped == 0 && unlinked == 0 {
+		return nil
+	}
+
+	err := d.DB.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			`INSERT INTO ticket_groups (event_id, sale_id, price, type, is_vip, title, description, place, created_at)
+			SELECT event_id, sale_id, price, type, is_vip, title, MAX(description), place, UNIX_TIMESTAMP()
+			FROM tickets
+			WHERE group_id = 0 OR group_id IS NULL
+			GROUP BY event_id, sale_id, price, type, is_vip, title, place`,
+
+			`UPDATE tickets
+			JOIN ticket_groups ON ticket_groups.event_id = tickets.event_id
+				AND ticket_groups.sale_id = tickets.sale_id
+				AND ticket_groups.price = tickets.price
+				AND ticket_groups.type = tickets.type
+				AND ticket_groups.is_vip = tickets.is_vip
+				AND ticket_groups.title = tickets.title
+				AND ticket_groups.place = tickets.place
+			SET tickets.group_id = ticket_groups.id
+			WHERE tickets.group_id = 0 OR tickets.group_id IS NULL`,
+
+			`UPDATE price_tiers
+			JOIN ticket_groups ON ticket_groups.event_id = price_tiers.event_id
+				AND ticket_groups.sale_id = price_tiers.sale_id
+				AND ticket_groups.price = price_tiers.group_price
+				AND ticket_groups.type = price_tiers.type
+				AND ticket_groups.is_vip = price_tiers.is_vip
+				AND ticket_groups.title = price_tiers.title
+				AND ticket_groups.place = price_tiers.place
+			SET price_tiers.group_id = ticket_groups.id
+			WHERE price_tiers.group_id = 0 OR price_tiers.group_id IS NULL`,
+
+			`UPDATE orders
+			JOIN ticket_groups ON ticket_groups.event_id = orders.event_id
+				AND ticket_groups.sale_id = orders.sale_id
+				AND ticket_groups.price = orders.price
+				AND ticket_groups.type = orders.type
+				AND ticket_groups.is_vip = orders.is_vip
+				AND ticket_groups.title = orders.title
+				AND ticket_groups.place = orders.place
+			SET orders.group_id = ticket_groups.id
+			WHERE orders.group_id = 0 OR orders.group_id IS NULL`,
+
+			`UPDATE bulk_orders
+			JOIN ticket_groups ON ticket_groups.event_id = bulk_orders.event_id
+				AND ticket_groups.sale_id = bulk_orders.sale_id
+				AND ticket_groups.price = bulk_orders.price
+				AND ticket_groups.type = bulk_orders.type
+				AND ticket_groups.is_vip = bulk_orders.is_vip
+				AND ticket_groups.title = bulk_orders.title
+				AND ticket_groups.place = bulk_orders.place
+			SET bulk_orders.group_id = ticket_groups.id
+			WHERE bulk_orders.group_id = 0 OR bulk_orders.group_id IS NULL`,
+
+			// IGNORE skips a second cap on the same group, which the unique
+			// (sale_id, group_id) key refuses; it keeps matching no group
+			`UPDATE IGNORE sale_allocations
+			JOIN ticket_groups ON ticket_groups.sale_id = sale_allocations.sale_id
+				AND ticket_groups.price = sale_allocations.price
+				AND ticket_groups.type = sale_allocations.type
+				AND ticket_groups.is_vip = sale_allocations.is_vip
+				AND ticket_groups.title = sale_allocations.title
+				AND ticket_groups.place = sale_allocations.place
+			SET sale_allocations.group_id = ticket_groups.id
+			WHERE sale_allocations.group_id IS NULL`,
+		}
+
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Backfilled ticket groups for %d tickets and %d sale allocations", ungrouped, unlinked)
+	return nil
+}
+
+// backfillTimestamps dates rows created before models had created and
+// updated timestamps, where the time can be worked out: payments by their
+// date, purchased tickets by their payment, tickets by their group and
+// orders by when they were placed. Other rows keep 0, meaning unknown.
+func (d *Database) backfillTimestamps() error {
+	statements := []string{
+		`UPDATE payments SET created_at = date, updated_at = date WHERE created_at = 0`,
+
+		`UPDATE purchased_tickets
+		JOIN payments ON payments.id = purchased_tickets.payment_id
+		SET purchased_tickets.purchased_at = payments.date, purchased_tickets.updated_at = payments.date
+		WHERE purchased_tickets.purchased_at = 0`,
+
+		`UPDATE tickets
+		JOIN ticket_groups ON ticket_groups.id = tickets.group_id
+		SET tickets.created_at = ticket_groups.created_at, tickets.updated_at = ticket_groups.created_at
+		WHERE tickets.created_at = 0`,
+
+		`UPDATE orders SET updated_at = COALESCE(completed_at, created_at) WHERE updated_at = 0`,
+	}
+
+	err := d.DB.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Println("Backfilled purchase times and created/updated timestamps")
+	return nil
+}
+
+// backfillRecordTimestamps dates the remaining rows created before every
+// model had created and updated timestamps. Rows that recorded when they
+// were created, by whatever name, take that time; their last change is the
+// latest time they recorded, e.g. when they were resolved or delivered.
+// Order and refund items are dated by their order and refund.
+func (d *Database) backfillRecordTimestamps() error {
+	statements := []string{
+		`UPDATE order_items
+		JOIN orders ON orders.id = order_items.order_id
+		SET order_items.created_at = orders.created_at, order_items.updated_at = orders.created_at
+		WHERE order_items.created_at = 0`,
+
+		`UPDATE refund_items
+		JOIN refunds ON refunds.id = refund_items.refund_id
+		SET refund_items.created_at = refunds.created_at, refund_items.updated_at = refunds.created_at
+		WHERE refund_items.created_at = 0`,
+
+		`UPDATE active_ticket_transfers SET created_at = date, updated_at = date WHERE created_at = 0`,
+		`UPDATE done_ticket_transfers SET created_at = date, updated_at = completed_at WHERE created_at = 0`,
+		`UPDATE seller_documents SET created_at = uploaded_at, updated_at = uploaded_at WHERE created_at = 0`,
+		`UPDATE policy_documents SET created_at = published_at, updated_at = published_at WHERE created_at = 0`,
+		`UPDATE policy_acceptances SET created_at = accepted_at, updated_at = accepted_at WHERE created_at = 0`,
+		`UPDATE queue_entries SET created_at = joined_at, updated_at = COALESCE(admitted_at, joined_at) WHERE created_at = 0`,
+		`UPDATE dead_letters SET created_at = failed_at, updated_at = COALESCE(resolved_at, failed_at) WHERE created_at = 0`,
+		`UPDATE analytics_events SET created_at = occurred_at, updated_at = occurred_at WHERE created_at = 0`,
+		`UPDATE disputes SET created_at = opened_at WHERE created_at = 0`,
+		`UPDATE maintenance_modes SET created_at = updated_at WHERE created_at = 0`,
+
+		`UPDATE ticket_groups SET updated_at = created_at WHERE updated_at = 0`,
+		`UPDATE venues SET updated_at = created_at WHERE updated_at = 0`,
+		`UPDATE follows SET updated_at = created_at WHERE updated_at = 0`,
+		`UPDATE event_members SET updated_at = created_at WHERE updated_at = 0`,
+		`UPDATE refunds SET updated_at = created_at WHERE updated_at = 0`,
+		`UPDATE seller_webhooks SET updated_at = created_at WHERE updated_at = 0`,
+		`UPDATE webhook_deliveries SET updated_at = created_at WHERE updated_at = 0`,
+		`UPDATE audit_logs SET updated_at = created_at WHERE updated_at = 0`,
+		`UPDATE lottery_entries SET updated_at = created_at WHERE updated_at = 0`,
+		`UPDATE bulk_orders SET updated_at = COALESCE(decided_at, created_at) WHERE updated_at = 0`,
+		`UPDATE outbox_messages SET updated_at = COALESCE(delivered_at, created_at) WHERE updated_at = 0`,
+		`UPDATE data_exports SET updated_at = COALESCE(completed_at, created_at) WHERE updated_at = 0`,
+		`UPDATE event_reports SET updated_at = COALESCE(resolved_at, created_at) WHERE updated_at = 0`,
+		`UPDATE refund_requests SET updated_at = COALESCE(resolved_at, created_at) WHERE updated_at = 0`,
+		`UPDATE ticket_gifts SET updated_at = COALESCE(delivered_at, created_at) WHERE updated_at = 0`,
+		`UPDATE ticket_imports SET updated_at = COALESCE(completed_at, created_at) WHERE updated_at = 0`,
+		`UPDATE ticket_claim_links SET updated_at = COALESCE(claimed_at, created_at) WHERE updated_at = 0`,
+	}
+
+	err := d.DB.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Println("Backfilled created/updated timestamps of the remaining models")
+	return nil
+}
+
+// backfillFeeRates records the configured fee rate on customer payments
+// whose seller was credited before rates were stored per payment. The rate
+// could only be changed by a restart until then, so the current one is the
+// best record of what those sellers were credited.
+func (d *Database) backfillFeeRates() error {
+	result := d.DB.Model(&models.Payment{}).
+		Where("user_type = ? AND event_id > 0 AND parent_payment_id IS NULL AND status IN ?",
+			models.UserTypeUser, []models.PaymentStatus{models.PaymentStatusCompleted, models.PaymentStatusRefunded}).
+		Where("platform_fee_rate IS NULL").
+		UpdateColumn("platform_fee_rate", d.platformFeeRate)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	log.Printf("Recorded a fee rate of %g on %d existing payments", d.platformFeeRate, result.RowsAffected)
+	return nil
+}
+
+// preserveLegacyEventData sets aside event data written before it had a
+// schema. A blob that doesn't match EventDetails is copied to legacy_data as
+// it was, and data keeps only the fields that do match, so neither reading
+// nor updating the event loses what the blob held.
+func (d *Database) preserveLegacyEventData() error {
+	var events []struct {
+		ID   uint
+		Data string
+	}
+	if err := d.DB.Model(&models.Event{}).Select("id, data").Where("data IS NOT NULL").Find(&events).Error; err != nil {
+		return err
+	}
+
+	preserved := 0
+	err := d.DB.Transaction(func(tx *gorm.DB) error {
+		for _, event := range events {
+			var details models.EventDetails
+			if event.Data == "" || models.ParseEventDetails([]byte(event.Data), &details) == nil {
+				continue
+			}
+
+			err := tx.Model(&models.Event{}).Where("id = ?", event.ID).UpdateColumns(map[string]interface{}{
+				"legacy_data": event.Data,
+				"data":        salvageEventDetails([]byte(event.Data)),
+			}).Error
+			if err != nil {
+				return err
+			}
+			preserved++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Kept the data of %d events that didn't match the event details schema", preserved)
+	return nil
+}
+
+// salvageEventDetails keeps the fields of a legacy blob that match the
+// schema, dropping unknown fields and those of the wrong type
+func salvageEventDetails(data []byte) models.EventDetails {
+	var details models.EventDetails
+
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(data, &fields) != nil {
+		return details
+	}
+	for name, value := range fields {
+		field, err := json.Marshal(map[string]json.RawMessage{name: value})
+		if err != nil {
+			continue
+		}
+		// Checked on its own first, as a field of the wrong type can be
+		// half decoded
+		var checked models.EventDetails
+		if models.ParseEventDetails(field, &checked) == nil {
+			_ = json.Unmarshal(field, &details)
+		}
+	}
+	return details
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"eticketing/internal/utils"
+)
+
+// queryLogger logs failed queries like GORM's default logger and, when a
+// threshold is set, every query slower than it along with the request ID of
+// the query's context
+type queryLogger struct {
+	logger.Interface
+	slowThreshold time.Duration
+}
+
+func newQueryLogger(slowThreshold time.Duration) logger.Interface {
+	return &queryLogger{
+		Interface:     logger.Default.LogMode(logger.Error),
+		slowThreshold: slowThreshold,
+	}
+}
+
+func (l *queryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &queryLogger{
+		Interface:     l.Interface.LogMode(level),
+		slowThreshold: l.slowThreshold,
+	}
+}
+
+func (l *queryLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	elapsed := time.Since(begin)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		l.Interface.Trace(ctx, begin, fc, err)
+		return
+	}
+
+	if l.slowThreshold <= 0 || elapsed < l.slowThreshold {
+		return
+	}
+
+	requestID := utils.RequestIDFrom(ctx)
+	if requestID == "" {
+		requestID = "-"
+	}
+
+	sql, rows := fc()
+	log.Printf("SLOW SQL request_id=%s duration=%s threshold=%s rows=%d sql=%s",
+		requestID, elapsed.Round(time.Millisecond), l.slowThreshold, rows, sql)
+}
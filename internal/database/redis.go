@@ -0,0 +1,58 @@
+package database
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"eticketing/internal/config"
+)
+
+// PingRedis opens a short-lived connection to Redis and issues a PING.
+// It speaks raw RESP so the health checks don't need a full client.
+func PingRedis(cfg *config.RedisConfig, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(cfg.Host, cfg.Port), timeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(conn)
+
+	if cfg.Password != "" {
+		if err := sendRedisCommand(conn, reader, "AUTH", cfg.Password); err != nil {
+			return fmt.Errorf("redis auth failed: %w", err)
+		}
+	}
+
+	return sendRedisCommand(conn, reader, "PING")
+}
+
+func sendRedisCommand(conn net.Conn, reader *bufio.Reader, args ...string) error {
+	var cmd strings.Builder
+	cmd.WriteString(fmt.Sprintf("*%d\r\n", len(args)))
+	for _, arg := range args {
+		cmd.WriteString(fmt.Sprintf("$%d\r\n%s\r\n", len(arg), arg))
+	}
+
+	if _, err := conn.Write([]byte(cmd.String())); err != nil {
+		return err
+	}
+
+	reply, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(reply, "-") {
+		return fmt.Errorf("redis error: %s", strings.TrimSpace(reply[1:]))
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"database/sql"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// ReplicaPlugin sends reads to read replicas, round-robin. Reads inside a
+// transaction or taking row locks stay on the primary, as does every write.
+type ReplicaPlugin struct {
+	replicas []*sql.DB
+	next     atomic.Uint64
+}
+
+func NewReplicaPlugin(replicas []*sql.DB) *ReplicaPlugin {
+	return &ReplicaPlugin{replicas: replicas}
+}
+
+func (p *ReplicaPlugin) Name() string {
+	return "replicas"
+}
+
+func (p *ReplicaPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	if err := cb.Query().Before("gorm:query").Register("replicas:query", p.route); err != nil {
+		return err
+	}
+	return cb.Row().Before("gorm:row").Register("replicas:row", p.route)
+}
+
+func (p *ReplicaPlugin) route(db *gorm.DB) {
+	if len(p.replicas) == 0 {
+		return
+	}
+
+	// A transaction's reads must see its own writes
+	if _, inTx := db.Statement.ConnPool.(gorm.TxCommitter); inTx {
+		return
+	}
+
+	// SELECT ... FOR UPDATE has to lock rows on the primary
+	if _, locking := db.Statement.Clauses["FOR"]; locking {
+		return
+	}
+
+	i := p.next.Add(1) % uint64(len(p.replicas))
+	db.Statement.ConnPool = p.replicas[i]
+}
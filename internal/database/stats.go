@@ -0,0 +1,49 @@
+package database
+
+import "database/sql"
+
+// PoolStats is a JSON-friendly snapshot of a connection pool
+type PoolStats struct {
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	WaitDurationMs     int64 `json:"wait_duration_ms"`
+	MaxIdleClosed      int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64 `json:"max_lifetime_closed"`
+}
+
+type DatabaseStats struct {
+	Primary  PoolStats   `json:"primary"`
+	Replicas []PoolStats `json:"replicas,omitempty"`
+}
+
+// Stats reports the connection pools of the primary and any replicas
+func (d *Database) Stats() (*DatabaseStats, error) {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &DatabaseStats{Primary: poolStats(sqlDB.Stats())}
+	for _, replica := range d.replicas {
+		stats.Replicas = append(stats.Replicas, poolStats(replica.Stats()))
+	}
+	return stats, nil
+}
+
+func poolStats(s sql.DBStats) PoolStats {
+	return PoolStats{
+		MaxOpenConnections: s.MaxOpenConnections,
+		OpenConnections:    s.OpenConnections,
+		InUse:              s.InUse,
+		Idle:               s.Idle,
+		WaitCount:          s.WaitCount,
+		WaitDurationMs:     s.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      s.MaxIdleClosed,
+		MaxIdleTimeClosed:  s.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  s.MaxLifetimeClosed,
+	}
+}
@@ -0,0 +1,88 @@
+// Package events publishes domain events to a message broker so other
+// systems (analytics, CRM, data warehouse) can react to what happens here
+// without the core services knowing about them.
+//
+// Domain events are outbox messages: services record them in the same
+// transaction as the change, and the outbox dispatcher hands them to the
+// in-process subscribers (notifications, webhooks, follower fan-out) and,
+// through Forward, to the configured Publisher.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"eticketing/internal/models"
+	"eticketing/internal/outbox"
+)
+
+// types names the domain events published for each outbox topic. Topics
+// that only drive internal work (exports, webhook calls) aren't published.
+var types = map[string]string{
+	models.OutboxTopicTicketSold:       "TicketPurchased",
+	models.OutboxTopicOrderRefunded:    "OrderRefunded",
+	models.OutboxTopicTicketGifted:     "TicketGifted",
+	models.OutboxTopicTransferAccepted: "TransferAccepted",
+	models.OutboxTopicEventApproved:    "EventApproved",
+	models.OutboxTopicEventRejected:    "EventRejected",
+	models.OutboxTopicEventSuspended:   "EventSuspended",
+	models.OutboxTopicEventPublished:   "EventPublished",
+	models.OutboxTopicSaleCreated:      "SaleCreated",
+	models.OutboxTopicDisputeUpdated:   "DisputeUpdated",
+}
+
+// Topics lists the outbox topics that are published as domain events
+func Topics() []string {
+	topics := make([]string, 0, len(types))
+	for topic := range types {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+// Event is the envelope every domain event is published in
+type Event struct {
+	ID         uint            `json:"id"`          // Outbox message ID; redeliveries repeat it
+	Type       string          `json:"type"`        // e.g. TicketPurchased
+	Topic      string          `json:"topic"`       // e.g. ticket.sold
+	Key        string          `json:"key"`         // Partition key: the event ID when there is one, so one event's changes stay in order
+	OccurredAt int64           `json:"occurred_at"` // Unix timestamp
+	Data       json.RawMessage `json:"data"`
+}
+
+// Publisher sends domain events to a broker. Publish must not return until
+// the broker has accepted the event; an error makes the outbox retry it.
+type Publisher interface {
+	Publish(ctx context.Context, event *Event) error
+	Close() error
+}
+
+// FromMessage wraps an outbox message in the domain event envelope
+func FromMessage(message *models.OutboxMessage) *Event {
+	event := &Event{
+		ID:         message.ID,
+		Type:       types[message.Topic],
+		Topic:      message.Topic,
+		OccurredAt: message.CreatedAt,
+		Data:       json.RawMessage(message.Payload),
+	}
+
+	var subject struct {
+		EventID uint `json:"event_id"`
+	}
+	if err := json.Unmarshal(event.Data, &subject); err == nil && subject.EventID != 0 {
+		event.Key = fmt.Sprintf("event-%d", subject.EventID)
+	}
+	return event
+}
+
+// Forward is an outbox handler that publishes each message it is given
+func Forward(publisher Publisher) outbox.Handler {
+	return func(ctx context.Context, message *models.OutboxMessage) error {
+		if err := publisher.Publish(ctx, FromMessage(message)); err != nil {
+			return fmt.Errorf("failed to publish %s: %w", message.Topic, err)
+		}
+		return nil
+	}
+}
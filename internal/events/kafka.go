@@ -0,0 +1,84 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// KafkaRESTPublisher publishes events through a Kafka REST proxy (v2 API).
+// Each event is a record on topic <prefix><topic>, keyed by Event.Key.
+type KafkaRESTPublisher struct {
+	baseURL string
+	prefix  string
+	client  *http.Client
+}
+
+func NewKafkaRESTPublisher(baseURL, prefix string, timeout time.Duration) *KafkaRESTPublisher {
+	return &KafkaRESTPublisher{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		prefix:  prefix,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key,omitempty"`
+	Value *Event `json:"value"`
+}
+
+func (p *KafkaRESTPublisher) Publish(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(kafkaRecords{Records: []kafkaRecord{{Key: event.Key, Value: event}}})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/topics/%s", p.baseURL, p.prefix+event.Topic)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("kafka REST proxy returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	// A 200 can still carry a per-record failure
+	var result struct {
+		Offsets []struct {
+			Error string `json:"error"`
+		} `json:"offsets"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("invalid kafka REST proxy response: %w", err)
+	}
+	for _, offset := range result.Offsets {
+		if offset.Error != "" {
+			return fmt.Errorf("kafka REST proxy rejected the record: %s", offset.Error)
+		}
+	}
+	return nil
+}
+
+func (p *KafkaRESTPublisher) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
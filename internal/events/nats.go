@@ -0,0 +1,136 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NATSPublisher publishes events to a NATS server over its text protocol.
+// Each event goes to <prefix><topic>, e.g. eticketing.ticket.sold, and is
+// followed by a PING so Publish only returns once the server has it.
+type NATSPublisher struct {
+	url     string
+	prefix  string
+	timeout time.Duration
+
+	mutex  sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func NewNATSPublisher(serverURL, prefix string, timeout time.Duration) *NATSPublisher {
+	return &NATSPublisher{url: serverURL, prefix: prefix, timeout: timeout}
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := p.publish(ctx, p.prefix+event.Topic, body); err != nil {
+		// The connection is in an unknown state; start over next time
+		p.closeConn()
+		return err
+	}
+	return nil
+}
+
+func (p *NATSPublisher) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.closeConn()
+	return nil
+}
+
+func (p *NATSPublisher) connect(ctx context.Context) error {
+	server, err := url.Parse(p.url)
+	if err != nil {
+		return fmt.Errorf("invalid NATS URL: %w", err)
+	}
+
+	dialer := net.Dialer{Timeout: p.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", server.Host)
+	if err != nil {
+		return err
+	}
+	p.conn, p.reader = conn, bufio.NewReader(conn)
+	p.setDeadline(ctx)
+
+	// The server greets with INFO before it accepts anything
+	line, err := p.reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "INFO") {
+		p.closeConn()
+		return fmt.Errorf("unexpected NATS greeting %q: %v", strings.TrimSpace(line), err)
+	}
+
+	options := map[string]interface{}{"verbose": false, "pedantic": false, "name": "eticketing"}
+	if server.User != nil {
+		options["user"] = server.User.Username()
+		if password, ok := server.User.Password(); ok {
+			options["pass"] = password
+		}
+	}
+	connect, _ := json.Marshal(options)
+	if _, err := fmt.Fprintf(p.conn, "CONNECT %s\r\n", connect); err != nil {
+		p.closeConn()
+		return err
+	}
+	return nil
+}
+
+func (p *NATSPublisher) publish(ctx context.Context, subject string, body []byte) error {
+	p.setDeadline(ctx)
+	if _, err := fmt.Fprintf(p.conn, "PUB %s %d\r\n%s\r\nPING\r\n", subject, len(body), body); err != nil {
+		return err
+	}
+
+	for {
+		line, err := p.reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		switch line = strings.TrimSpace(line); {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("NATS: %s", line)
+		}
+	}
+}
+
+func (p *NATSPublisher) setDeadline(ctx context.Context) {
+	deadline := time.Now().Add(p.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	_ = p.conn.SetDeadline(deadline)
+}
+
+func (p *NATSPublisher) closeConn() {
+	if p.conn != nil {
+		_ = p.conn.Close()
+		p.conn, p.reader = nil, nil
+	}
+}
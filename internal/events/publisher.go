@@ -0,0 +1,38 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"eticketing/internal/config"
+)
+
+// NewPublisher builds the publisher EVENTS_PUBLISHER names. It returns nil
+// when publishing is off.
+func NewPublisher(cfg *config.EventsConfig) (Publisher, error) {
+	switch cfg.Publisher {
+	case "", "none":
+		return nil, nil
+	case "log":
+		return LogPublisher{}, nil
+	case "nats":
+		return NewNATSPublisher(cfg.NATSURL, cfg.SubjectPrefix, cfg.Timeout), nil
+	case "kafka_rest":
+		return NewKafkaRESTPublisher(cfg.KafkaRESTURL, cfg.SubjectPrefix, cfg.Timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown events publisher %q", cfg.Publisher)
+	}
+}
+
+// LogPublisher writes events to the application log, for development
+type LogPublisher struct{}
+
+func (LogPublisher) Publish(ctx context.Context, event *Event) error {
+	log.Printf("event: %s #%d %s", event.Type, event.ID, event.Data)
+	return nil
+}
+
+func (LogPublisher) Close() error {
+	return nil
+}
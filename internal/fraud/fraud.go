@@ -0,0 +1,73 @@
+package fraud
+
+import (
+	"context"
+	"log"
+
+	"eticketing/internal/models"
+)
+
+// Decision is what a checker decided about a purchase, ordered by severity
+// so the strictest decision of several checkers wins
+type Decision int
+
+const (
+	DecisionAllow   Decision = iota
+	DecisionReview           // Held until an admin approves it
+	DecisionDecline          // Refused outright
+)
+
+type Result struct {
+	Decision Decision
+	Reasons  []string
+}
+
+// Purchase is what the checkers see of a purchase about to be charged
+type Purchase struct {
+	UserID             uint                   `json:"user_id"`
+	Email              string                 `json:"email"`
+	EventID            uint                   `json:"event_id"`
+	Amount             float64                `json:"amount"`
+	Quantity           int                    `json:"quantity"`
+	ClientIP           string                 `json:"client_ip,omitempty"`
+	DeviceFingerprint  string                 `json:"device_fingerprint,omitempty"`
+	GiftRecipientEmail string                 `json:"gift_recipient_email,omitempty"`
+	PaymentMethods     []models.PaymentMethod `json:"payment_methods,omitempty"` // Stored methods to be charged; tokens are not serialized
+}
+
+// Checker scores a purchase, e.g. against the buyer's recent activity or an
+// external fraud scoring API
+type Checker interface {
+	Name() string
+	Check(ctx context.Context, purchase *Purchase) (*Result, error)
+}
+
+// Screener runs purchases through every configured checker. A checker that
+// fails sends the purchase to review instead of declining it, so an outage
+// of an external service doesn't turn genuine buyers away.
+type Screener struct {
+	checkers []Checker
+}
+
+func NewScreener(checkers ...Checker) *Screener {
+	return &Screener{checkers: checkers}
+}
+
+func (s *Screener) Check(ctx context.Context, purchase *Purchase) *Result {
+	combined := &Result{Decision: DecisionAllow}
+
+	for _, checker := range s.checkers {
+		result, err := checker.Check(ctx, purchase)
+		if err != nil {
+			log.Printf("fraud: %s check failed: %v", checker.Name(), err)
+			result = &Result{Decision: DecisionReview, Reasons: []string{checker.Name() + " check unavailable"}}
+		}
+
+		if result.Decision > combined.Decision {
+			combined.Decision = result.Decision
+		}
+		combined.Reasons = append(combined.Reasons, result.Reasons...)
+	}
+
+	return combined
+}
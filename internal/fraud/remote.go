@@ -0,0 +1,79 @@
+package fraud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RemoteChecker asks an external fraud scoring API about the purchase. The
+// API receives the Purchase as JSON and answers
+// {"decision": "allow|review|decline", "reasons": ["..."]}.
+type RemoteChecker struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+func NewRemoteChecker(url, apiKey string, timeout time.Duration) *RemoteChecker {
+	return &RemoteChecker{
+		url:    url,
+		apiKey: apiKey,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *RemoteChecker) Name() string {
+	return "fraud API"
+}
+
+func (c *RemoteChecker) Check(ctx context.Context, purchase *Purchase) (*Result, error) {
+	body, err := json.Marshal(purchase)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var decoded struct {
+		Decision string   `json:"decision"`
+		Reasons  []string `json:"reasons"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	result := &Result{Reasons: decoded.Reasons}
+	switch decoded.Decision {
+	case "allow":
+		result.Decision = DecisionAllow
+	case "review":
+		result.Decision = DecisionReview
+	case "decline":
+		result.Decision = DecisionDecline
+	default:
+		return nil, fmt.Errorf("unknown decision %q", decoded.Decision)
+	}
+
+	return result, nil
+}
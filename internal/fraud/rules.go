@@ -0,0 +1,98 @@
+package fraud
+
+import (
+	"context"
+	"strings"
+)
+
+// EmailMismatchChecker sends a purchase to review when a wallet it is paid
+// from (PayPal, Google Pay) belongs to another email address than the
+// buyer's account
+type EmailMismatchChecker struct{}
+
+func NewEmailMismatchChecker() *EmailMismatchChecker {
+	return &EmailMismatchChecker{}
+}
+
+func (c *EmailMismatchChecker) Name() string {
+	return "email mismatch"
+}
+
+func (c *EmailMismatchChecker) Check(ctx context.Context, purchase *Purchase) (*Result, error) {
+	result := &Result{Decision: DecisionAllow}
+	for _, method := range purchase.PaymentMethods {
+		if method.Email != "" && !strings.EqualFold(method.Email, purchase.Email) {
+			result.Decision = DecisionReview
+			result.Reasons = append(result.Reasons, "paid from a wallet of another email address")
+		}
+	}
+	return result, nil
+}
+
+// CardListChecker declines purchases paid with a blocked card, matched by
+// the provider's token
+type CardListChecker struct {
+	blocked map[string]bool
+}
+
+func NewCardListChecker(tokens []string) *CardListChecker {
+	blocked := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		if token = strings.TrimSpace(token); token != "" {
+			blocked[token] = true
+		}
+	}
+	return &CardListChecker{blocked: blocked}
+}
+
+func (c *CardListChecker) Name() string {
+	return "card list"
+}
+
+func (c *CardListChecker) Check(ctx context.Context, purchase *Purchase) (*Result, error) {
+	result := &Result{Decision: DecisionAllow}
+	for _, method := range purchase.PaymentMethods {
+		if c.blocked[method.Token] {
+			result.Decision = DecisionDecline
+			result.Reasons = append(result.Reasons, "card "+method.Brand+" *"+method.Last4+" is blocked")
+		}
+	}
+	return result, nil
+}
+
+// Denylist is satisfied by services.DenylistService
+type Denylist interface {
+	Match(email, ip string, cardTokens []string) ([]string, error)
+}
+
+// DenylistChecker declines purchases by a denylisted email or email domain,
+// from a denylisted IP range, or paid with a denylisted card
+type DenylistChecker struct {
+	denylist Denylist
+}
+
+func NewDenylistChecker(denylist Denylist) *DenylistChecker {
+	return &DenylistChecker{denylist: denylist}
+}
+
+func (c *DenylistChecker) Name() string {
+	return "denylist"
+}
+
+func (c *DenylistChecker) Check(ctx context.Context, purchase *Purchase) (*Result, error) {
+	tokens := make([]string, 0, len(purchase.PaymentMethods))
+	for _, method := range purchase.PaymentMethods {
+		tokens = append(tokens, method.Token)
+	}
+
+	reasons, err := c.denylist.Match(purchase.Email, purchase.ClientIP, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Decision: DecisionAllow, Reasons: reasons}
+	if len(reasons) > 0 {
+		result.Decision = DecisionDecline
+	}
+	return result, nil
+}
@@ -0,0 +1,75 @@
+package fraud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"eticketing/internal/models"
+)
+
+// History is satisfied by repositories.PaymentRepository
+type History interface {
+	CountByUserSince(userID uint, userType models.UserType, since int64) (int64, error)
+	CountByClientIPSince(clientIP string, since int64) (int64, error)
+}
+
+// VelocityChecker counts the payment attempts made recently by the buyer
+// and from the buyer's IP. Failed attempts count too, as runs of declined
+// cards are typical of card testing. A threshold of 0 turns its check off.
+type VelocityChecker struct {
+	history   History
+	window    time.Duration
+	reviewAt  int // Attempts by the buyer that send the purchase to review
+	declineAt int // Attempts by the buyer that decline it
+	ipAt      int // Attempts from the IP, across accounts, that send it to review
+}
+
+func NewVelocityChecker(history History, window time.Duration, reviewAt, declineAt, ipAt int) *VelocityChecker {
+	return &VelocityChecker{
+		history:   history,
+		window:    window,
+		reviewAt:  reviewAt,
+		declineAt: declineAt,
+		ipAt:      ipAt,
+	}
+}
+
+func (c *VelocityChecker) Name() string {
+	return "velocity"
+}
+
+func (c *VelocityChecker) Check(ctx context.Context, purchase *Purchase) (*Result, error) {
+	since := time.Now().Add(-c.window).Unix()
+	result := &Result{Decision: DecisionAllow}
+
+	if c.reviewAt > 0 || c.declineAt > 0 {
+		count, err := c.history.CountByUserSince(purchase.UserID, models.UserTypeUser, since)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case c.declineAt > 0 && count >= int64(c.declineAt):
+			result.Decision = DecisionDecline
+			result.Reasons = append(result.Reasons, fmt.Sprintf("%d payment attempts by the buyer in %s", count, c.window))
+		case c.reviewAt > 0 && count >= int64(c.reviewAt):
+			result.Decision = DecisionReview
+			result.Reasons = append(result.Reasons, fmt.Sprintf("%d payment attempts by the buyer in %s", count, c.window))
+		}
+	}
+
+	if c.ipAt > 0 && purchase.ClientIP != "" {
+		count, err := c.history.CountByClientIPSince(purchase.ClientIP, since)
+		if err != nil {
+			return nil, err
+		}
+		if count >= int64(c.ipAt) {
+			if result.Decision < DecisionReview {
+				result.Decision = DecisionReview
+			}
+			result.Reasons = append(result.Reasons, fmt.Sprintf("%d payment attempts from %s in %s", count, purchase.ClientIP, c.window))
+		}
+	}
+
+	return result, nil
+}
@@ -1,21 +1,46 @@
 package handlers
 
 import (
+	"fmt"
+	"io"
+	"net/http"
 	"strconv"
+	"time"
 
 	"eticketing/internal/middleware"
 	"eticketing/internal/models"
+	"eticketing/internal/repositories"
 	"eticketing/internal/services"
 	"eticketing/internal/utils"
 	"github.com/gin-gonic/gin"
 )
 
 type AdminHandler struct {
-	adminService *services.AdminService
+	adminService            *services.AdminService
+	duplicateAccountService *services.DuplicateAccountService
 }
 
-func NewAdminHandler(adminService *services.AdminService) *AdminHandler {
-	return &AdminHandler{adminService: adminService}
+func NewAdminHandler(adminService *services.AdminService, duplicateAccountService *services.DuplicateAccountService) *AdminHandler {
+	return &AdminHandler{
+		adminService:            adminService,
+		duplicateAccountService: duplicateAccountService,
+	}
+}
+
+// RegisterRoutes adds event moderation, the reconciliation report and the
+// duplicate account report
+func (h *AdminHandler) RegisterRoutes(routes *Routes) {
+	routes.Admin.GET("/events/pending", h.GetPendingEvents)
+	routes.Admin.GET("/events/export", h.ExportEvents) // CSV; ?status=, ?seller_id=
+	routes.Admin.POST("/events/:event_id/approve", h.ApproveEvent)
+	routes.Admin.POST("/events/:event_id/reject", h.RejectEvent)
+	routes.Admin.POST("/events/:event_id/unpublish", h.UnpublishEvent) // Pending investigation
+	routes.Admin.POST("/events/:event_id/reinstate", h.ReinstateEvent)
+	routes.Admin.GET("/reports/reconciliation", h.GetReconciliationReport)
+	routes.Admin.GET("/reports/duplicate-accounts", h.GetDuplicateAccountReport) // ?from=, ?to=, ?min_ip_accounts=
+	routes.Admin.GET("/stats", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "Admin stats - not implemented yet"})
+	})
 }
 
 func (h *AdminHandler) GetProfile(c *gin.Context) {
@@ -27,7 +52,7 @@ func (h *AdminHandler) GetProfile(c *gin.Context) {
 
 	profile, err := h.adminService.GetProfile(currentUser.UserID)
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -49,7 +74,7 @@ func (h *AdminHandler) UpdateProfile(c *gin.Context) {
 
 	profile, err := h.adminService.UpdateProfile(currentUser.UserID, &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -71,7 +96,7 @@ func (h *AdminHandler) ChangePassword(c *gin.Context) {
 
 	err = h.adminService.ChangePassword(currentUser.UserID, &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -92,7 +117,7 @@ func (h *AdminHandler) GetSystemStats(c *gin.Context) {
 
 	stats, err := h.adminService.GetSystemStats()
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -116,7 +141,7 @@ func (h *AdminHandler) GetPendingEvents(c *gin.Context) {
 
 	events, err := h.adminService.GetPendingEvents(page, limit)
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -143,7 +168,7 @@ func (h *AdminHandler) ApproveEvent(c *gin.Context) {
 
 	err = h.adminService.ApproveEvent(uint(eventID))
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -178,9 +203,194 @@ func (h *AdminHandler) RejectEvent(c *gin.Context) {
 
 	err = h.adminService.RejectEvent(uint(eventID), req.Reason)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
 	utils.SuccessResponse(c, "Event rejected successfully", nil)
 }
+
+func (h *AdminHandler) UnpublishEvent(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	if currentUser.UserType != models.UserTypeAdmin {
+		utils.ForbiddenResponse(c, "Admin access required")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data")
+		return
+	}
+
+	if err := h.adminService.SuspendEvent(uint(eventID), req.Reason); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Event unpublished pending investigation", nil)
+}
+
+func (h *AdminHandler) ReinstateEvent(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	if currentUser.UserType != models.UserTypeAdmin {
+		utils.ForbiddenResponse(c, "Admin access required")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	if err := h.adminService.ReinstateEvent(uint(eventID)); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Event reinstated successfully", nil)
+}
+
+// GetReconciliationReport returns the payment reconciliation report for
+// from..to (unix seconds, defaulting to the last 30 days); format=csv
+// downloads the per-event lines instead
+func (h *AdminHandler) GetReconciliationReport(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	if currentUser.UserType != models.UserTypeAdmin {
+		utils.ForbiddenResponse(c, "Admin access required")
+		return
+	}
+
+	to := time.Now().Unix()
+	if value := c.Query("to"); value != "" {
+		if to, err = strconv.ParseInt(value, 10, 64); err != nil {
+			utils.BadRequestResponse(c, "Invalid to timestamp")
+			return
+		}
+	}
+
+	from := to - int64((30 * 24 * time.Hour).Seconds())
+	if value := c.Query("from"); value != "" {
+		if from, err = strconv.ParseInt(value, 10, 64); err != nil {
+			utils.BadRequestResponse(c, "Invalid from timestamp")
+			return
+		}
+	}
+
+	report, err := h.adminService.GetReconciliationReport(from, to)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		utils.StreamCSV(c, fmt.Sprintf("reconciliation_%d_%d.csv", from, to), func(w io.Writer) error {
+			return h.adminService.ExportReconciliationCSV(report, w)
+		})
+		return
+	}
+
+	utils.SuccessResponse(c, "Reconciliation report generated successfully", report)
+}
+
+// GetDuplicateAccountReport flags customer accounts that share a stored
+// card, or an IP with several other accounts over the period (30 days by
+// default)
+func (h *AdminHandler) GetDuplicateAccountReport(c *gin.Context) {
+	filter := services.DuplicateAccountFilter{
+		To:            time.Now().Unix(),
+		MinIPAccounts: 3,
+		Limit:         100,
+	}
+
+	var err error
+	if value := c.Query("to"); value != "" {
+		if filter.To, err = strconv.ParseInt(value, 10, 64); err != nil {
+			utils.BadRequestResponse(c, "Invalid to timestamp")
+			return
+		}
+	}
+
+	filter.From = filter.To - int64((30 * 24 * time.Hour).Seconds())
+	if value := c.Query("from"); value != "" {
+		if filter.From, err = strconv.ParseInt(value, 10, 64); err != nil {
+			utils.BadRequestResponse(c, "Invalid from timestamp")
+			return
+		}
+	}
+
+	if value := c.Query("min_ip_accounts"); value != "" {
+		if filter.MinIPAccounts, err = strconv.Atoi(value); err != nil {
+			utils.BadRequestResponse(c, "Invalid min_ip_accounts")
+			return
+		}
+	}
+
+	report, err := h.duplicateAccountService.GetReport(filter)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Duplicate account report generated successfully", report)
+}
+
+// ExportEvents streams every event as CSV for offline reporting
+func (h *AdminHandler) ExportEvents(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	if currentUser.UserType != models.UserTypeAdmin {
+		utils.ForbiddenResponse(c, "Admin access required")
+		return
+	}
+
+	var filter repositories.EventExportFilter
+	if value := c.Query("status"); value != "" {
+		status, err := strconv.Atoi(value)
+		if err != nil {
+			utils.BadRequestResponse(c, "Invalid status")
+			return
+		}
+		filter.Status = models.EventStatus(status)
+	}
+	if value := c.Query("seller_id"); value != "" {
+		sellerID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			utils.BadRequestResponse(c, "Invalid seller ID")
+			return
+		}
+		filter.SellerID = uint(sellerID)
+	}
+
+	utils.StreamCSV(c, fmt.Sprintf("events_%s.csv", time.Now().UTC().Format("20060102")), func(w io.Writer) error {
+		return h.adminService.ExportEventsCSV(filter, w)
+	})
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/models"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AnalyticsHandler struct {
+	analyticsService     *services.AnalyticsService
+	salesVelocityService *services.SalesVelocityService
+}
+
+func NewAnalyticsHandler(analyticsService *services.AnalyticsService, salesVelocityService *services.SalesVelocityService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService:     analyticsService,
+		salesVelocityService: salesVelocityService,
+	}
+}
+
+// RegisterRoutes adds analytics ingestion, the seller funnel and sales
+// velocity
+func (h *AnalyticsHandler) RegisterRoutes(routes *Routes) {
+	// Signed-in visitors are recognised, others send a session ID
+	routes.Public.POST("/analytics/events", middleware.OptionalAuthMiddleware(routes.JWTManager), h.TrackEvents)
+
+	// Views -> holds -> purchases from the analytics pipeline
+	routes.Seller.GET("/events/:event_id/funnel", h.GetFunnel) // ?from=&to= (unix seconds, default last 30 days)
+
+	// Tickets sold per hour or day from payments, with a sellout projection
+	routes.Seller.GET("/events/:event_id/sales-velocity", h.GetSalesVelocity) // ?interval=day (default) or hour
+}
+
+// TrackEvents ingests a batch of steps (event views, holds) reported by a
+// client, signed in or not
+func (h *AnalyticsHandler) TrackEvents(c *gin.Context) {
+	var req services.TrackEventsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	// Only users' own steps are attributed to their account
+	var userID uint
+	if currentUser, err := middleware.GetCurrentUser(c); err == nil && currentUser.UserType == models.UserTypeUser {
+		userID = currentUser.UserID
+	}
+
+	recorded, err := h.analyticsService.Track(&req, userID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.AcceptedResponse(c, "Analytics events recorded", gin.H{"recorded": recorded})
+}
+
+// GetFunnel reports the event's views, holds and purchases with the
+// drop-off between them, over the last 30 days unless from/to are given
+func (h *AnalyticsHandler) GetFunnel(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	to := time.Now().Unix()
+	if value := c.Query("to"); value != "" {
+		if to, err = strconv.ParseInt(value, 10, 64); err != nil {
+			utils.BadRequestResponse(c, "Invalid to timestamp")
+			return
+		}
+	}
+
+	from := to - int64((30 * 24 * time.Hour).Seconds())
+	if value := c.Query("from"); value != "" {
+		if from, err = strconv.ParseInt(value, 10, 64); err != nil {
+			utils.BadRequestResponse(c, "Invalid from timestamp")
+			return
+		}
+	}
+
+	funnel, err := h.analyticsService.GetFunnel(uint(eventID), currentUser.UserID, from, to)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Funnel retrieved successfully", funnel)
+}
+
+// GetSalesVelocity reports the event's ticket sales per hour or day since
+// its sale started, how full each ticket group is, and when the remaining
+// tickets are projected to sell out
+func (h *AnalyticsHandler) GetSalesVelocity(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	report, err := h.salesVelocityService.GetSalesVelocity(uint(eventID), currentUser.UserID, c.DefaultQuery("interval", services.SalesIntervalDay))
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Sales velocity retrieved successfully", report)
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/models"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AnnouncementHandler struct {
+	announcementService *services.AnnouncementService
+}
+
+func NewAnnouncementHandler(announcementService *services.AnnouncementService) *AnnouncementHandler {
+	return &AnnouncementHandler{announcementService: announcementService}
+}
+
+// RegisterRoutes adds the banners frontends show and their admin
+// management
+func (h *AnnouncementHandler) RegisterRoutes(routes *Routes) {
+	// Signed-in callers also get the announcements for their role
+	routes.Public.GET("/announcements", middleware.OptionalAuthMiddleware(routes.JWTManager), h.GetActiveAnnouncements)
+
+	routes.Admin.GET("/announcements", h.ListAnnouncements) // Scheduled, running and ended
+	routes.Admin.POST("/announcements", h.CreateAnnouncement)
+	routes.Admin.PUT("/announcements/:id", h.UpdateAnnouncement)
+	routes.Admin.DELETE("/announcements/:id", h.DeleteAnnouncement)
+}
+
+func (h *AnnouncementHandler) GetActiveAnnouncements(c *gin.Context) {
+	var userType models.UserType
+	if currentUser, err := middleware.GetCurrentUser(c); err == nil {
+		userType = currentUser.UserType
+	}
+
+	announcements, err := h.announcementService.Active(userType)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Announcements retrieved successfully", announcements)
+}
+
+func (h *AnnouncementHandler) ListAnnouncements(c *gin.Context) {
+	page, limit, offset := pageParams(c, 20)
+	announcements, total, err := h.announcementService.List(limit, offset)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Announcements retrieved successfully", announcements, utils.CalculatePagination(page, limit, total))
+}
+
+func (h *AnnouncementHandler) CreateAnnouncement(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	var req services.CreateAnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	announcement, err := h.announcementService.Create(&req, currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Announcement created successfully", announcement)
+}
+
+func (h *AnnouncementHandler) UpdateAnnouncement(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid announcement ID")
+		return
+	}
+
+	var req services.UpdateAnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	announcement, err := h.announcementService.Update(uint(id), &req, currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Announcement updated successfully", announcement)
+}
+
+func (h *AnnouncementHandler) DeleteAnnouncement(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid announcement ID")
+		return
+	}
+
+	if err := h.announcementService.Delete(uint(id)); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Announcement deleted successfully", nil)
+}
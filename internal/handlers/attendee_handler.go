@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/repositories"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AttendeeHandler struct {
+	attendeeService *services.AttendeeService
+}
+
+func NewAttendeeHandler(attendeeService *services.AttendeeService) *AttendeeHandler {
+	return &AttendeeHandler{attendeeService: attendeeService}
+}
+
+// RegisterRoutes adds the attendee list and check-in for organizers
+func (h *AttendeeHandler) RegisterRoutes(routes *Routes) {
+	routes.Seller.GET("/events/:event_id/attendees", h.GetAttendees)
+	routes.Seller.GET("/events/:event_id/attendees/summary", h.GetSummary)
+	routes.Seller.GET("/events/:event_id/attendees/export", h.ExportAttendees)
+	routes.Seller.POST("/events/:event_id/attendees/:ticket_id/check-in", h.CheckIn)
+	routes.Seller.POST("/events/:event_id/check-in", h.CheckInByCode) // Scanned QR code
+	routes.Seller.GET("/events/:event_id/accommodations", h.GetAccommodations)
+}
+
+func (h *AttendeeHandler) GetAttendees(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	filter, err := parseAttendeeFilter(c)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+
+	attendees, err := h.attendeeService.GetAttendees(uint(eventID), currentUser.UserID, filter, page, limit)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Attendees retrieved successfully", attendees)
+}
+
+func (h *AttendeeHandler) GetSummary(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	summary, err := h.attendeeService.GetSummary(uint(eventID), currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Attendee summary retrieved successfully", summary)
+}
+
+func (h *AttendeeHandler) GetAccommodations(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	report, err := h.attendeeService.GetAccommodationReport(uint(eventID), currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Accommodation report retrieved successfully", report)
+}
+
+func (h *AttendeeHandler) ExportAttendees(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	filter, err := parseAttendeeFilter(c)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.StreamCSV(c, fmt.Sprintf("attendees_event_%d.csv", eventID), func(w io.Writer) error {
+		return h.attendeeService.ExportAttendeesCSV(uint(eventID), currentUser.UserID, filter, w)
+	})
+}
+
+func (h *AttendeeHandler) CheckIn(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	ticketID, err := strconv.ParseUint(c.Param("ticket_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid ticket ID")
+		return
+	}
+
+	attendee, err := h.attendeeService.CheckIn(uint(eventID), currentUser.UserID, uint(ticketID))
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Ticket checked in successfully", attendee)
+}
+
+// CheckInByCode checks in a ticket from its scanned QR code
+func (h *AttendeeHandler) CheckInByCode(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	var req services.CheckInByCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	attendee, err := h.attendeeService.CheckInByCode(uint(eventID), currentUser.UserID, req.Code)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Ticket checked in successfully", attendee)
+}
+
+func parseAttendeeFilter(c *gin.Context) (repositories.AttendeeFilter, error) {
+	filter := repositories.AttendeeFilter{
+		Search:      c.Query("search"),
+		TicketTitle: c.Query("ticket_title"),
+	}
+
+	if value := c.Query("checked_in"); value != "" {
+		checkedIn, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid checked_in value: %s", value)
+		}
+		filter.CheckedIn = &checkedIn
+	}
+
+	if value := c.Query("needs_accommodation"); value != "" {
+		needsAccommodation, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid needs_accommodation value: %s", value)
+		}
+		filter.NeedsAccommodation = needsAccommodation
+	}
+
+	return filter, nil
+}
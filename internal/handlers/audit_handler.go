@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// impersonationTypes maps the :type route segment to the account type
+var impersonationTypes = map[string]models.UserType{
+	"user":   models.UserTypeUser,
+	"seller": models.UserTypeSeller,
+}
+
+type AuditHandler struct {
+	auditService *services.AuditService
+}
+
+func NewAuditHandler(auditService *services.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// RegisterRoutes adds impersonation and the audit log
+func (h *AuditHandler) RegisterRoutes(routes *Routes) {
+	routes.Admin.POST("/impersonate/:type/:id", h.Impersonate) // Super admins only; type is user or seller
+	routes.Admin.GET("/audit-log", h.GetAuditLog)
+}
+
+func (h *AuditHandler) Impersonate(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	if currentUser.UserType != models.UserTypeAdmin {
+		utils.ForbiddenResponse(c, "Admin access required")
+		return
+	}
+
+	userType, ok := impersonationTypes[c.Param("type")]
+	if !ok {
+		utils.BadRequestResponse(c, "Type must be user or seller")
+		return
+	}
+
+	targetID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid ID")
+		return
+	}
+
+	response, err := h.auditService.Impersonate(currentUser.UserID, userType, uint(targetID), c.ClientIP())
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Impersonation token issued", response)
+}
+
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	if currentUser.UserType != models.UserTypeAdmin {
+		utils.ForbiddenResponse(c, "Admin access required")
+		return
+	}
+
+	actorID, _ := strconv.ParseUint(c.Query("actor_id"), 10, 32)
+	actorType, _ := strconv.Atoi(c.Query("actor_type"))
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filter := repositories.AuditLogFilter{
+		ActorID:          uint(actorID),
+		ActorType:        models.UserType(actorType),
+		ImpersonatedOnly: c.Query("impersonated") == "true",
+	}
+
+	entries, err := h.auditService.ListAuditLog(filter, page, limit)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Audit log retrieved successfully", entries)
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"net/http"
+
 	"eticketing/internal/services"
 	"eticketing/internal/utils"
 	"github.com/gin-gonic/gin"
@@ -14,16 +16,31 @@ func NewAuthHandler(authService *services.AuthService) *AuthHandler {
 	return &AuthHandler{authService: authService}
 }
 
+// RegisterRoutes adds the public sign-in endpoints
+func (h *AuthHandler) RegisterRoutes(routes *Routes) {
+	auth := routes.Public.Group("/auth")
+	{
+		auth.POST("/register", h.Register)
+		auth.POST("/login", h.Login)
+		auth.POST("/refresh", h.RefreshToken)
+		auth.POST("/logout", h.Logout)
+	}
+}
+
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req services.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.BadRequestResponse(c, "Invalid request data")
 		return
 	}
+	if req.Locale == "" {
+		req.Locale = string(utils.Locale(c))
+	}
+	req.ClientIP = c.ClientIP()
 
 	response, err := h.authService.Register(&req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
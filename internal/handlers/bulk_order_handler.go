@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/models"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type BulkOrderHandler struct {
+	bulkOrderService *services.BulkOrderService
+}
+
+func NewBulkOrderHandler(bulkOrderService *services.BulkOrderService) *BulkOrderHandler {
+	return &BulkOrderHandler{bulkOrderService: bulkOrderService}
+}
+
+// RegisterRoutes adds organization orders
+func (h *BulkOrderHandler) RegisterRoutes(routes *Routes) {
+	tickets := routes.Protected.Group("/tickets")
+	{
+		tickets.POST("/bulk-orders", routes.RequireFeature(models.FeatureBulkOrders), h.CreateBulkOrder) // Organization orders above the per-order limit
+		tickets.GET("/bulk-orders", h.GetMyBulkOrders)
+	}
+
+	routes.Seller.GET("/events/:event_id/bulk-orders", h.GetEventBulkOrders)
+	routes.Seller.POST("/bulk-orders/:order_id/approve", h.ApproveBulkOrder)
+	routes.Seller.POST("/bulk-orders/:order_id/reject", h.RejectBulkOrder)
+	routes.Seller.POST("/bulk-orders/:order_id/invoice-paid", h.MarkInvoicePaid)
+}
+
+func (h *BulkOrderHandler) CreateBulkOrder(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	if currentUser.UserType != models.UserTypeUser {
+		utils.ForbiddenResponse(c, "Only users can place organization orders")
+		return
+	}
+
+	var req services.CreateBulkOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	req.UserID = currentUser.UserID
+	response, err := h.bulkOrderService.CreateBulkOrder(c.Request.Context(), &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Organization order placed successfully", response)
+}
+
+func (h *BulkOrderHandler) GetMyBulkOrders(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	orders, err := h.bulkOrderService.GetUserBulkOrders(currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Organization orders retrieved successfully", orders)
+}
+
+func (h *BulkOrderHandler) GetEventBulkOrders(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	orders, err := h.bulkOrderService.GetEventBulkOrders(uint(eventID), currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Organization orders retrieved successfully", orders)
+}
+
+func (h *BulkOrderHandler) ApproveBulkOrder(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	orderID, err := strconv.ParseUint(c.Param("order_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID")
+		return
+	}
+
+	response, err := h.bulkOrderService.ApproveBulkOrder(c.Request.Context(), uint(orderID), currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Organization order processed", response)
+}
+
+func (h *BulkOrderHandler) RejectBulkOrder(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	orderID, err := strconv.ParseUint(c.Param("order_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID")
+		return
+	}
+
+	var req services.RejectBulkOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	order, err := h.bulkOrderService.RejectBulkOrder(uint(orderID), currentUser.UserID, req.Reason)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Organization order rejected", order)
+}
+
+func (h *BulkOrderHandler) MarkInvoicePaid(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	orderID, err := strconv.ParseUint(c.Param("order_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID")
+		return
+	}
+
+	order, err := h.bulkOrderService.MarkInvoicePaid(uint(orderID), currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Invoice marked as paid", order)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"path"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarHandler struct {
+	calendarService *services.CalendarService
+}
+
+func NewCalendarHandler(calendarService *services.CalendarService) *CalendarHandler {
+	return &CalendarHandler{calendarService: calendarService}
+}
+
+// RegisterRoutes adds the calendar feed and its link
+func (h *CalendarHandler) RegisterRoutes(routes *Routes) {
+	// Calendar feed (authenticated by the token in its URL, not JWT)
+	routes.Public.GET("/tickets/my/calendar.ics", h.GetCalendarFeed)
+	routes.Protected.POST("/tickets/my/calendar-link", h.CreateCalendarLink)
+}
+
+// CreateCalendarLink returns the user's calendar feed URL. Calendar apps
+// can't send a bearer token, so the URL carries its own; ?rotate=true
+// replaces it when a link has leaked.
+func (h *CalendarHandler) CreateCalendarLink(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	token, err := h.calendarService.GetFeedToken(currentUser.UserID, c.Query("rotate") == "true")
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	// The feed sits beside this route, under the same API version
+	feedPath := path.Join(path.Dir(c.FullPath()), "calendar.ics")
+	feedURL := h.calendarService.FeedURL(feedPath, token)
+
+	utils.SuccessResponse(c, "Calendar link retrieved successfully", gin.H{
+		"token": token,
+		"url":   feedURL,
+	})
+}
+
+func (h *CalendarHandler) GetCalendarFeed(c *gin.Context) {
+	var buf bytes.Buffer
+	if err := h.calendarService.WriteFeed(c.Query("token"), &buf); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("Content-Disposition", "inline; filename=calendar.ics")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", buf.Bytes())
+}
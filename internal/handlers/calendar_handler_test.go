@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+)
+
+type fakeUserRepository struct {
+	repositories.UserRepository
+	users map[uint]*models.User
+}
+
+func (r *fakeUserRepository) GetByID(id uint) (*models.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return user, nil
+}
+
+func (r *fakeUserRepository) Update(user *models.User) error {
+	r.users[user.ID] = user
+	return nil
+}
+
+// TestCalendarLinkUsesPublicURL checks the feed URL is built from the
+// configured public URL, whatever host the request claims, and stays on the
+// API version it was asked through
+func TestCalendarLinkUsesPublicURL(t *testing.T) {
+	token := "feedtoken"
+	users := &fakeUserRepository{users: map[uint]*models.User{
+		1: {ID: 1, CalendarToken: &token},
+	}}
+	calendarService := services.NewCalendarService(nil, users, "https://tickets.example.com")
+	router := newTestRouter(&utils.JWTClaims{UserID: 1, UserType: models.UserTypeUser}, NewCalendarHandler(calendarService))
+
+	tests := []struct {
+		path    string
+		wantURL string
+	}{
+		{"/api/v1/tickets/my/calendar-link", "https://tickets.example.com/api/v1/tickets/my/calendar.ics?token=feedtoken"},
+		{"/api/v2/tickets/my/calendar-link", "https://tickets.example.com/api/v2/tickets/my/calendar.ics?token=feedtoken"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			req.Host = "attacker.example"
+			req.Header.Set("X-Forwarded-Host", "attacker.example")
+			req.Header.Set("X-Forwarded-Proto", "http")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200: %s", w.Code, w.Body)
+			}
+
+			var body struct {
+				Data struct {
+					URL string `json:"url"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if body.Data.URL != tt.wantURL {
+				t.Errorf("url = %q, want %q", body.Data.URL, tt.wantURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ConfigHandler struct {
+	runtimeConfigService *services.RuntimeConfigService
+}
+
+func NewConfigHandler(runtimeConfigService *services.RuntimeConfigService) *ConfigHandler {
+	return &ConfigHandler{runtimeConfigService: runtimeConfigService}
+}
+
+// RegisterRoutes adds the runtime config endpoints
+func (h *ConfigHandler) RegisterRoutes(routes *Routes) {
+	routes.Admin.GET("/config", h.GetRuntimeConfig)
+	routes.Admin.POST("/config/reload", h.ReloadConfig) // Same as sending SIGHUP
+}
+
+// GetRuntimeConfig shows the settings a reload can change
+func (h *ConfigHandler) GetRuntimeConfig(c *gin.Context) {
+	utils.SuccessResponse(c, "Runtime configuration retrieved successfully", h.runtimeConfigService.Settings())
+}
+
+// ReloadConfig re-reads the env file and environment, like sending the
+// server SIGHUP
+func (h *ConfigHandler) ReloadConfig(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	result, err := h.runtimeConfigService.Reload(currentUser.UserID, c.ClientIP())
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Configuration reloaded successfully", result)
+}
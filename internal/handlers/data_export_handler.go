@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/models"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type DataExportHandler struct {
+	dataExportService *services.DataExportService
+}
+
+func NewDataExportHandler(dataExportService *services.DataExportService) *DataExportHandler {
+	return &DataExportHandler{dataExportService: dataExportService}
+}
+
+// RegisterRoutes adds personal data exports
+func (h *DataExportHandler) RegisterRoutes(routes *Routes) {
+	// Downloads are authenticated by the token in the emailed link
+	routes.Public.GET("/data-exports/:token", h.DownloadExport)
+
+	routes.Protected.POST("/users/data-export", h.RequestExport)
+	routes.Protected.GET("/users/data-export", h.GetExport)
+}
+
+func (h *DataExportHandler) RequestExport(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	if currentUser.UserType != models.UserTypeUser {
+		utils.ForbiddenResponse(c, "Only users can export their data")
+		return
+	}
+
+	export, err := h.dataExportService.RequestExport(currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Data export requested; you will be emailed when it is ready", export)
+}
+
+func (h *DataExportHandler) GetExport(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	export, err := h.dataExportService.GetLatestExport(currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Data export retrieved successfully", export)
+}
+
+// DownloadExport serves the archive behind an emailed link; the token in the
+// URL authenticates the download
+func (h *DataExportHandler) DownloadExport(c *gin.Context) {
+	archive, err := h.dataExportService.GetArchive(c.Param("token"))
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=data-export.zip")
+	c.Data(http.StatusOK, "application/zip", archive)
+}
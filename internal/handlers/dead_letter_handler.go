@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type DeadLetterHandler struct {
+	deadLetterService *services.DeadLetterService
+}
+
+func NewDeadLetterHandler(deadLetterService *services.DeadLetterService) *DeadLetterHandler {
+	return &DeadLetterHandler{deadLetterService: deadLetterService}
+}
+
+// RegisterRoutes adds the outbox dead letter queue for admins
+func (h *DeadLetterHandler) RegisterRoutes(routes *Routes) {
+	routes.Admin.GET("/dead-letters", h.GetDeadLetters) // ?status=0 for all, ?topic=
+	routes.Admin.GET("/dead-letters/:id", h.GetDeadLetter)
+	routes.Admin.POST("/dead-letters/:id/retry", h.RetryDeadLetter)
+	routes.Admin.POST("/dead-letters/:id/discard", h.DiscardDeadLetter)
+}
+
+func (h *DeadLetterHandler) GetDeadLetters(c *gin.Context) {
+	status, _ := strconv.Atoi(c.DefaultQuery("status", "1"))
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filter := repositories.DeadLetterFilter{
+		Topic:  c.Query("topic"),
+		Status: models.DeadLetterStatus(status),
+	}
+
+	letters, err := h.deadLetterService.ListDeadLetters(filter, page, limit)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Dead letters retrieved successfully", letters)
+}
+
+func (h *DeadLetterHandler) GetDeadLetter(c *gin.Context) {
+	letterID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid dead letter ID")
+		return
+	}
+
+	letter, err := h.deadLetterService.GetDeadLetter(uint(letterID))
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Dead letter retrieved successfully", letter)
+}
+
+func (h *DeadLetterHandler) RetryDeadLetter(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	letterID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid dead letter ID")
+		return
+	}
+
+	letter, err := h.deadLetterService.RetryDeadLetter(uint(letterID), currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Message queued for redelivery", letter)
+}
+
+func (h *DeadLetterHandler) DiscardDeadLetter(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	letterID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid dead letter ID")
+		return
+	}
+
+	letter, err := h.deadLetterService.DiscardDeadLetter(uint(letterID), currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Dead letter discarded", letter)
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type DeepLinkHandler struct {
+	deepLinkService *services.DeepLinkService
+}
+
+func NewDeepLinkHandler(deepLinkService *services.DeepLinkService) *DeepLinkHandler {
+	return &DeepLinkHandler{deepLinkService: deepLinkService}
+}
+
+// RegisterRoutes adds the links that open tickets in the mobile app
+func (h *DeepLinkHandler) RegisterRoutes(routes *Routes) {
+	routes.Protected.GET("/tickets/:ticket_id/deep-link", h.CreateTicketLink)
+	routes.Protected.GET("/deep-links/:token", h.ResolveTicketLink) // Called by the app when a link is opened
+}
+
+// CreateTicketLink returns signed app and universal links to one of the
+// user's tickets; ?format=png returns the universal link as a QR code
+func (h *DeepLinkHandler) CreateTicketLink(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	ticketID, err := strconv.ParseUint(c.Param("ticket_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid ticket ID")
+		return
+	}
+
+	link, err := h.deepLinkService.CreateTicketLink(uint(ticketID), currentUser.UserID, time.Now())
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if c.Query("format") == "png" {
+		png, err := link.QRCode()
+		if err != nil {
+			utils.InternalErrorResponse(c, "Failed to generate QR code")
+			return
+		}
+		c.Header("Cache-Control", "no-store")
+		c.Data(http.StatusOK, "image/png", png)
+		return
+	}
+
+	utils.SuccessResponse(c, "Ticket link created successfully", link)
+}
+
+// ResolveTicketLink checks a link the app was opened with and returns the
+// ticket it points to
+func (h *DeepLinkHandler) ResolveTicketLink(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	ticket, err := h.deepLinkService.ResolveTicketLink(c.Param("token"), currentUser.UserID, time.Now())
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Ticket link is valid", ticket)
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/models"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type DenylistHandler struct {
+	denylistService *services.DenylistService
+}
+
+func NewDenylistHandler(denylistService *services.DenylistService) *DenylistHandler {
+	return &DenylistHandler{denylistService: denylistService}
+}
+
+// RegisterRoutes adds admin management of the emails, domains, cards and
+// IP ranges barred from registering and buying
+func (h *DenylistHandler) RegisterRoutes(routes *Routes) {
+	routes.Admin.GET("/denylist", h.ListEntries) // ?kind=1 email, 2 domain, 3 card, 4 IP range
+	routes.Admin.POST("/denylist", h.CreateEntry)
+	routes.Admin.PUT("/denylist/:id", h.UpdateEntry) // Reason only
+	routes.Admin.DELETE("/denylist/:id", h.DeleteEntry)
+}
+
+func (h *DenylistHandler) ListEntries(c *gin.Context) {
+	kind, err := strconv.Atoi(c.DefaultQuery("kind", "0"))
+	if err != nil || kind < 0 || kind > int(models.DenylistKindIPRange) {
+		utils.BadRequestResponse(c, "Invalid kind")
+		return
+	}
+
+	page, limit, offset := pageParams(c, 50)
+	entries, total, err := h.denylistService.List(models.DenylistKind(kind), limit, offset)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Denylist retrieved successfully", entries, utils.CalculatePagination(page, limit, total))
+}
+
+func (h *DenylistHandler) CreateEntry(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	var req services.CreateDenylistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	entry, err := h.denylistService.Create(&req, currentUser.UserID, c.ClientIP())
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Denylist entry added successfully", entry)
+}
+
+func (h *DenylistHandler) UpdateEntry(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid denylist entry ID")
+		return
+	}
+
+	var req services.UpdateDenylistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	entry, err := h.denylistService.Update(uint(id), &req, currentUser.UserID, c.ClientIP())
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Denylist entry updated successfully", entry)
+}
+
+func (h *DenylistHandler) DeleteEntry(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid denylist entry ID")
+		return
+	}
+
+	if err := h.denylistService.Delete(uint(id), currentUser.UserID, c.ClientIP()); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Denylist entry removed successfully", nil)
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/models"
+	"eticketing/internal/payments"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// maxWebhookBody caps provider webhook payloads; dispute events are tiny
+const maxWebhookBody = 64 << 10
+
+type DisputeHandler struct {
+	disputeService *services.DisputeService
+}
+
+func NewDisputeHandler(disputeService *services.DisputeService) *DisputeHandler {
+	return &DisputeHandler{disputeService: disputeService}
+}
+
+// RegisterRoutes adds the provider webhook and dispute review for admins
+func (h *DisputeHandler) RegisterRoutes(routes *Routes) {
+	// Payment provider callbacks (authenticated by signature, not JWT)
+	routes.Public.POST("/webhooks/payments/:provider", h.HandleProviderWebhook)
+
+	routes.Admin.GET("/disputes", h.GetDisputes)
+	routes.Admin.GET("/disputes/:id", h.GetDispute)
+	routes.Admin.PUT("/disputes/:id", h.UpdateDispute)
+}
+
+// HandleProviderWebhook receives dispute notifications from a payment
+// provider. Requests are signed with the shared PAYMENT_WEBHOOK_SECRET.
+func (h *DisputeHandler) HandleProviderWebhook(c *gin.Context) {
+	provider := c.Param("provider")
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBody))
+	if err != nil {
+		utils.BadRequestResponse(c, "Failed to read request body")
+		return
+	}
+
+	if err := h.disputeService.VerifyWebhook(provider, c.GetHeader("X-Provider-Timestamp"), c.GetHeader("X-Provider-Signature"), body); err != nil {
+		utils.UnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	var event payments.DisputeEvent
+	if err := binding.JSON.BindBody(body, &event); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	dispute, err := h.disputeService.HandleDisputeEvent(provider, &event)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Dispute event processed", dispute)
+}
+
+func (h *DisputeHandler) GetDisputes(c *gin.Context) {
+	status, _ := strconv.Atoi(c.DefaultQuery("status", "0"))
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	disputes, err := h.disputeService.ListDisputes(models.DisputeStatus(status), page, limit)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Disputes retrieved successfully", disputes)
+}
+
+func (h *DisputeHandler) GetDispute(c *gin.Context) {
+	disputeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid dispute ID")
+		return
+	}
+
+	dispute, err := h.disputeService.GetDispute(uint(disputeID))
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Dispute retrieved successfully", dispute)
+}
+
+func (h *DisputeHandler) UpdateDispute(c *gin.Context) {
+	disputeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid dispute ID")
+		return
+	}
+
+	var req services.UpdateDisputeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	dispute, err := h.disputeService.UpdateDispute(uint(disputeID), &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Dispute updated successfully", dispute)
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+)
+
+func TestServiceErrorStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   apperrors.Code
+	}{
+		{"bad request", apperrors.New(apperrors.CodeSaleNotActive, "sale is not active"), http.StatusBadRequest, apperrors.CodeSaleNotActive},
+		{"validation", apperrors.Validation("bad input"), http.StatusBadRequest, apperrors.CodeValidationFailed},
+		{"unauthorized", apperrors.Unauthorized("sign in"), http.StatusUnauthorized, apperrors.CodeUnauthorized},
+		{"payment required", apperrors.PaymentRequired("declined"), http.StatusPaymentRequired, apperrors.CodePaymentDeclined},
+		{"forbidden", apperrors.Forbidden("not yours"), http.StatusForbidden, apperrors.CodeForbidden},
+		{"not found", apperrors.NotFound("missing"), http.StatusNotFound, apperrors.CodeNotFound},
+		{"conflict", apperrors.Conflict("taken"), http.StatusConflict, apperrors.CodeConflict},
+		{"internal", apperrors.Internal("broken"), http.StatusInternalServerError, apperrors.CodeInternal},
+		{"unavailable", apperrors.Unavailable("later"), http.StatusServiceUnavailable, apperrors.CodeUnavailable},
+		{"specific code", apperrors.Forbidden("not approved").WithCode(apperrors.CodeSellerNotApproved), http.StatusForbidden, apperrors.CodeSellerNotApproved},
+		{"wrapped", fmt.Errorf("purchase: %w", apperrors.Conflict("sold")), http.StatusConflict, apperrors.CodeConflict},
+		{"untyped", errors.New("plain"), http.StatusBadRequest, apperrors.CodeBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", func(c *gin.Context) {
+				utils.ServiceErrorResponse(c, http.StatusBadRequest, tt.err)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			assertStatus(t, w, tt.wantStatus, tt.wantCode)
+		})
+	}
+}
+
+// fakeEventRepository serves events from a map; other methods aren't
+// implemented
+type fakeEventRepository struct {
+	repositories.EventRepository
+	events    map[uint]*models.Event
+	deleteErr error
+}
+
+func (r *fakeEventRepository) GetByID(id uint) (*models.Event, error) {
+	event, ok := r.events[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return event, nil
+}
+
+func (r *fakeEventRepository) Delete(id uint) error {
+	return r.deleteErr
+}
+
+// TestEventOwnershipStatus sends deletes through the seller route and
+// checks ownership failures answer 403 rather than 400
+func TestEventOwnershipStatus(t *testing.T) {
+	seller := &utils.JWTClaims{UserID: 1, UserType: models.UserTypeSeller}
+
+	tests := []struct {
+		name       string
+		eventID    uint
+		deleteErr  error
+		wantStatus int
+		wantCode   apperrors.Code
+	}{
+		{"own event", 1, nil, http.StatusOK, ""},
+		{"another seller's event", 2, nil, http.StatusForbidden, apperrors.CodeForbidden},
+		{"missing event", 3, nil, http.StatusNotFound, apperrors.CodeNotFound},
+		{"storage failure", 1, errors.New("connection lost"), http.StatusInternalServerError, apperrors.CodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &fakeEventRepository{
+				events: map[uint]*models.Event{
+					1: {ID: 1, SellerID: 1},
+					2: {ID: 2, SellerID: 2},
+				},
+				deleteErr: tt.deleteErr,
+			}
+			eventService := services.NewEventService(events, nil, nil, nil, nil, nil, nil, nil, nil)
+			router := newTestRouter(seller, NewEventHandler(eventService))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/seller/events/%d", tt.eventID), nil))
+			assertStatus(t, w, tt.wantStatus, tt.wantCode)
+		})
+	}
+}
+
+func assertStatus(t *testing.T, w *httptest.ResponseRecorder, wantStatus int, wantCode apperrors.Code) {
+	t.Helper()
+
+	if w.Code != wantStatus {
+		t.Errorf("status = %d, want %d: %s", w.Code, wantStatus, w.Body)
+	}
+
+	var body utils.APIResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if body.Code != string(wantCode) {
+		t.Errorf("code = %q, want %q", body.Code, wantCode)
+	}
+}
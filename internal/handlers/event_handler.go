@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"net/http"
 	"strconv"
 
 	"eticketing/internal/middleware"
@@ -18,6 +19,18 @@ func NewEventHandler(eventService *services.EventService) *EventHandler {
 	return &EventHandler{eventService: eventService}
 }
 
+// RegisterRoutes adds event browsing and the seller's event management
+func (h *EventHandler) RegisterRoutes(routes *Routes) {
+	routes.Events.GET("", h.GetEvents)
+	routes.Events.GET("/nearby", h.GetNearbyEvents)
+	routes.Events.GET("/:event_id", h.GetEvent)
+
+	routes.Seller.POST("/events", routes.RequireApprovedSeller(), h.CreateEvent)
+	routes.Seller.GET("/events", h.GetMyEvents)
+	routes.Seller.PUT("/events/:event_id", h.UpdateEvent)
+	routes.Seller.DELETE("/events/:event_id", h.DeleteEvent)
+}
+
 func (h *EventHandler) CreateEvent(c *gin.Context) {
 	currentUser, err := middleware.GetCurrentUser(c)
 	if err != nil {
@@ -32,14 +45,14 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 
 	var req services.CreateEventRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequestResponse(c, "Invalid request data")
+		utils.BindingErrorResponse(c, err)
 		return
 	}
 
 	req.SellerID = currentUser.UserID
-	event, err := h.eventService.CreateEvent(&req)
+	event, err := h.eventService.CreateEvent(c.Request.Context(), &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -56,7 +69,7 @@ func (h *EventHandler) GetEvents(c *gin.Context) {
 	events, err = h.eventService.GetEvents(page, limit)
 
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -72,7 +85,7 @@ func (h *EventHandler) GetEvent(c *gin.Context) {
 
 	event, err := h.eventService.GetEventByID(uint(eventID))
 	if err != nil {
-		utils.NotFoundResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
 		return
 	}
 
@@ -94,13 +107,13 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 
 	var req services.UpdateEventRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequestResponse(c, "Invalid request data")
+		utils.BindingErrorResponse(c, err)
 		return
 	}
 
-	event, err := h.eventService.UpdateEvent(uint(eventID), currentUser.UserID, &req)
+	event, err := h.eventService.UpdateEvent(c.Request.Context(), uint(eventID), currentUser.UserID, &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -122,7 +135,7 @@ func (h *EventHandler) DeleteEvent(c *gin.Context) {
 
 	err = h.eventService.DeleteEvent(uint(eventID), currentUser.UserID)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -146,9 +159,44 @@ func (h *EventHandler) GetMyEvents(c *gin.Context) {
 
 	events, err := h.eventService.GetEventsBySeller(currentUser.UserID, page, limit)
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
 	utils.SuccessResponse(c, "Events retrieved successfully", events)
 }
+
+// GetNearbyEvents lists upcoming events within radius km (default 10, at
+// most 200) of lat/lng, closest first
+func (h *EventHandler) GetNearbyEvents(c *gin.Context) {
+	lat, err := strconv.ParseFloat(c.Query("lat"), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		utils.BadRequestResponse(c, "Invalid lat")
+		return
+	}
+
+	lng, err := strconv.ParseFloat(c.Query("lng"), 64)
+	if err != nil || lng < -180 || lng > 180 {
+		utils.BadRequestResponse(c, "Invalid lng")
+		return
+	}
+
+	radius, err := strconv.ParseFloat(c.DefaultQuery("radius", "10"), 64)
+	if err != nil || radius <= 0 || radius > 200 {
+		utils.BadRequestResponse(c, "Radius must be between 0 and 200 km")
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	events, err := h.eventService.GetNearbyEvents(lat, lng, radius, limit)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Nearby events retrieved successfully", events)
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type EventMemberHandler struct {
+	memberService *services.EventMemberService
+}
+
+func NewEventMemberHandler(memberService *services.EventMemberService) *EventMemberHandler {
+	return &EventMemberHandler{memberService: memberService}
+}
+
+// RegisterRoutes adds co-managing sellers
+func (h *EventMemberHandler) RegisterRoutes(routes *Routes) {
+	routes.Seller.GET("/shared-events", h.GetSharedEvents)
+	routes.Seller.POST("/events/:event_id/members", h.AddMember)
+	routes.Seller.GET("/events/:event_id/members", h.GetMembers)
+	routes.Seller.PUT("/events/:event_id/members/:seller_id", h.UpdateMember)
+	routes.Seller.DELETE("/events/:event_id/members/:seller_id", h.RemoveMember) // Members may remove themselves
+}
+
+func (h *EventMemberHandler) AddMember(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	var req services.AddEventMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	member, err := h.memberService.AddMember(uint(eventID), currentUser.UserID, &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Event member added successfully", member)
+}
+
+func (h *EventMemberHandler) GetMembers(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	members, err := h.memberService.ListMembers(uint(eventID), currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Event members retrieved successfully", members)
+}
+
+func (h *EventMemberHandler) UpdateMember(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	sellerID, err := strconv.ParseUint(c.Param("seller_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid seller ID")
+		return
+	}
+
+	var req services.UpdateEventMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	member, err := h.memberService.UpdateMember(uint(eventID), uint(sellerID), currentUser.UserID, &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Event member updated successfully", member)
+}
+
+// RemoveMember takes a collaborator off an event; members can also use it
+// to leave
+func (h *EventMemberHandler) RemoveMember(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	sellerID, err := strconv.ParseUint(c.Param("seller_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid seller ID")
+		return
+	}
+
+	if err := h.memberService.RemoveMember(uint(eventID), uint(sellerID), currentUser.UserID); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Event member removed successfully", nil)
+}
+
+func (h *EventMemberHandler) GetSharedEvents(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	events, err := h.memberService.ListSharedEvents(currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Shared events retrieved successfully", events)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type FeatureFlagHandler struct {
+	featureFlagService *services.FeatureFlagService
+}
+
+func NewFeatureFlagHandler(featureFlagService *services.FeatureFlagService) *FeatureFlagHandler {
+	return &FeatureFlagHandler{featureFlagService: featureFlagService}
+}
+
+// RegisterRoutes adds feature flag management and the caller's flags
+func (h *FeatureFlagHandler) RegisterRoutes(routes *Routes) {
+	routes.Protected.GET("/users/features", h.GetMyFeatures) // Which flagged features are on for the caller
+
+	routes.Admin.GET("/feature-flags", h.GetFlags)
+	routes.Admin.POST("/feature-flags", h.CreateFlag)
+	routes.Admin.PUT("/feature-flags/:id", h.UpdateFlag)
+	routes.Admin.DELETE("/feature-flags/:id", h.DeleteFlag)
+}
+
+func (h *FeatureFlagHandler) GetFlags(c *gin.Context) {
+	flags, err := h.featureFlagService.ListFlags()
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Feature flags retrieved successfully", flags)
+}
+
+func (h *FeatureFlagHandler) CreateFlag(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	var req services.CreateFeatureFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	flag, err := h.featureFlagService.CreateFlag(&req, currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Feature flag created successfully", flag)
+}
+
+func (h *FeatureFlagHandler) UpdateFlag(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	flagID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid feature flag ID")
+		return
+	}
+
+	var req services.UpdateFeatureFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	flag, err := h.featureFlagService.UpdateFlag(uint(flagID), &req, currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Feature flag updated successfully", flag)
+}
+
+func (h *FeatureFlagHandler) DeleteFlag(c *gin.Context) {
+	flagID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid feature flag ID")
+		return
+	}
+
+	if err := h.featureFlagService.DeleteFlag(uint(flagID)); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Feature flag deleted successfully", nil)
+}
+
+// GetMyFeatures tells the caller which flagged features they can use
+func (h *FeatureFlagHandler) GetMyFeatures(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	utils.SuccessResponse(c, "Features retrieved successfully", h.featureFlagService.Features(currentUser.UserID))
+}
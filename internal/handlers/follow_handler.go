@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/models"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type FollowHandler struct {
+	followService *services.FollowService
+}
+
+func NewFollowHandler(followService *services.FollowService) *FollowHandler {
+	return &FollowHandler{followService: followService}
+}
+
+// RegisterRoutes adds following sellers
+func (h *FollowHandler) RegisterRoutes(routes *Routes) {
+	routes.Protected.GET("/users/following", h.GetFollowing)
+	routes.Protected.POST("/sellers/:seller_id/follow", h.FollowSeller)
+	routes.Protected.DELETE("/sellers/:seller_id/follow", h.UnfollowSeller)
+}
+
+func (h *FollowHandler) FollowSeller(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	if currentUser.UserType != models.UserTypeUser {
+		utils.ForbiddenResponse(c, "Only users can follow sellers")
+		return
+	}
+
+	sellerID, err := strconv.ParseUint(c.Param("seller_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid seller ID")
+		return
+	}
+
+	followed, err := h.followService.Follow(currentUser.UserID, uint(sellerID))
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Seller followed successfully", followed)
+}
+
+func (h *FollowHandler) UnfollowSeller(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	sellerID, err := strconv.ParseUint(c.Param("seller_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid seller ID")
+		return
+	}
+
+	if err := h.followService.Unfollow(currentUser.UserID, uint(sellerID)); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Seller unfollowed successfully", nil)
+}
+
+func (h *FollowHandler) GetFollowing(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	following, err := h.followService.ListFollowing(currentUser.UserID, page, limit)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Followed sellers retrieved successfully", following)
+}
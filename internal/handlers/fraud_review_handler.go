@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/models"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type FraudReviewHandler struct {
+	fraudReviewService *services.FraudReviewService
+}
+
+func NewFraudReviewHandler(fraudReviewService *services.FraudReviewService) *FraudReviewHandler {
+	return &FraudReviewHandler{fraudReviewService: fraudReviewService}
+}
+
+// RegisterRoutes adds the admin queue of orders held by the fraud checks
+func (h *FraudReviewHandler) RegisterRoutes(routes *Routes) {
+	routes.Admin.GET("/fraud-reviews", h.ListPending)                // Oldest first
+	routes.Admin.POST("/fraud-reviews/:order_id/approve", h.Approve) // {"note": "..."}; the buyer may then pay
+	routes.Admin.POST("/fraud-reviews/:order_id/reject", h.Reject)   // {"note": "..."}; the tickets are released
+}
+
+func (h *FraudReviewHandler) ListPending(c *gin.Context) {
+	page, limit, offset := pageParams(c, 20)
+	items, total, err := h.fraudReviewService.ListPending(limit, offset)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Orders under review retrieved successfully", items, utils.CalculatePagination(page, limit, total))
+}
+
+func (h *FraudReviewHandler) Approve(c *gin.Context) {
+	h.decide(c, h.fraudReviewService.Approve, "Order approved successfully")
+}
+
+func (h *FraudReviewHandler) Reject(c *gin.Context) {
+	h.decide(c, h.fraudReviewService.Reject, "Order rejected successfully")
+}
+
+func (h *FraudReviewHandler) decide(c *gin.Context, decision func(orderID, adminID uint, req *services.ReviewDecisionRequest) (*models.Order, error), message string) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	orderID, err := strconv.ParseUint(c.Param("order_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID")
+		return
+	}
+
+	var req services.ReviewDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	order, err := decision(uint(orderID), currentUser.UserID, &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, message, order)
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"eticketing/internal/config"
+	"eticketing/internal/database"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const healthProbeTimeout = 2 * time.Second
+
+type HealthHandler struct {
+	db       *database.Database
+	redisCfg *config.RedisConfig
+	version  string
+}
+
+type DependencyStatus struct {
+	Status    string `json:"status"` // "up" or "down"
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+type HealthResponse struct {
+	Status       string                      `json:"status"`
+	Timestamp    time.Time                   `json:"timestamp"`
+	Version      string                      `json:"version"`
+	Dependencies map[string]DependencyStatus `json:"dependencies,omitempty"`
+}
+
+func NewHealthHandler(db *database.Database, redisCfg *config.RedisConfig, version string) *HealthHandler {
+	return &HealthHandler{
+		db:       db,
+		redisCfg: redisCfg,
+		version:  version,
+	}
+}
+
+// RegisterRoutes adds the database pool stats for admins; the probes are
+// public and registered outside the API
+func (h *HealthHandler) RegisterRoutes(routes *Routes) {
+	routes.Admin.GET("/health/db", h.DatabaseStats)
+}
+
+// Health is kept for backward compatibility with existing monitoring
+func (h *HealthHandler) Health(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthResponse{
+		Status:    "healthy",
+		Timestamp: time.Now().UTC(),
+		Version:   h.version,
+	})
+}
+
+// Live reports whether the process is running; it never touches dependencies
+// so a slow database can't get the pod restarted.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthResponse{
+		Status:    "alive",
+		Timestamp: time.Now().UTC(),
+		Version:   h.version,
+	})
+}
+
+// Ready pings every dependency and returns 503 if any of them is down
+func (h *HealthHandler) Ready(c *gin.Context) {
+	dependencies := map[string]DependencyStatus{
+		"database": probe(func() error {
+			ctx, cancel := context.WithTimeout(c.Request.Context(), healthProbeTimeout)
+			defer cancel()
+			return h.db.PingContext(ctx)
+		}),
+		"redis": probe(func() error {
+			return database.PingRedis(h.redisCfg, healthProbeTimeout)
+		}),
+	}
+
+	status := "ready"
+	statusCode := http.StatusOK
+	for _, dependency := range dependencies {
+		if dependency.Status != "up" {
+			status = "not_ready"
+			statusCode = http.StatusServiceUnavailable
+			break
+		}
+	}
+
+	c.JSON(statusCode, HealthResponse{
+		Status:       status,
+		Timestamp:    time.Now().UTC(),
+		Version:      h.version,
+		Dependencies: dependencies,
+	})
+}
+
+// DatabaseStats reports the database connection pools, so pool exhaustion
+// shows up before requests start timing out
+func (h *HealthHandler) DatabaseStats(c *gin.Context) {
+	stats, err := h.db.Stats()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusServiceUnavailable, "Database stats unavailable: "+err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Database stats retrieved successfully", stats)
+}
+
+func probe(ping func() error) DependencyStatus {
+	start := time.Now()
+	err := ping()
+	latency := time.Since(start).Milliseconds()
+
+	if err != nil {
+		return DependencyStatus{
+			Status:    "down",
+			LatencyMs: latency,
+			Error:     err.Error(),
+		}
+	}
+
+	return DependencyStatus{
+		Status:    "up",
+		LatencyMs: latency,
+	}
+}
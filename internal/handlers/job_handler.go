@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"errors"
+
+	"eticketing/internal/jobs"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type JobHandler struct {
+	scheduler *jobs.Scheduler
+}
+
+func NewJobHandler(scheduler *jobs.Scheduler) *JobHandler {
+	return &JobHandler{scheduler: scheduler}
+}
+
+// RegisterRoutes adds the admin job controls
+func (h *JobHandler) RegisterRoutes(routes *Routes) {
+	routes.Admin.GET("/jobs", h.GetJobs)
+	routes.Admin.POST("/jobs/:name/run", h.RunJob)
+}
+
+func (h *JobHandler) GetJobs(c *gin.Context) {
+	utils.SuccessResponse(c, "Jobs retrieved successfully", h.scheduler.Stats())
+}
+
+func (h *JobHandler) RunJob(c *gin.Context) {
+	err := h.scheduler.RunNow(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		switch {
+		case errors.Is(err, jobs.ErrJobNotFound):
+			utils.NotFoundResponse(c, err.Error())
+		case errors.Is(err, jobs.ErrJobRunning):
+			utils.ConflictResponse(c, err.Error())
+		default:
+			utils.InternalErrorResponse(c, "Job failed: "+err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, "Job completed successfully", nil)
+}
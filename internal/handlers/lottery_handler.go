@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type LotteryHandler struct {
+	lotteryService *services.LotteryService
+}
+
+func NewLotteryHandler(lotteryService *services.LotteryService) *LotteryHandler {
+	return &LotteryHandler{lotteryService: lotteryService}
+}
+
+// RegisterRoutes adds registering for lottery sales and checking the result
+func (h *LotteryHandler) RegisterRoutes(routes *Routes) {
+	lottery := routes.Protected.Group("/sales/:sale_id/lottery")
+	{
+		lottery.POST("", h.Register)
+		lottery.GET("", h.GetEntry) // Status and, for winners, the deadline to buy
+		lottery.DELETE("", h.Withdraw)
+	}
+}
+
+func (h *LotteryHandler) Register(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	saleID, err := strconv.ParseUint(c.Param("sale_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid sale ID")
+		return
+	}
+
+	entry, err := h.lotteryService.Register(uint(saleID), currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Registered for the lottery", entry)
+}
+
+func (h *LotteryHandler) GetEntry(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	saleID, err := strconv.ParseUint(c.Param("sale_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid sale ID")
+		return
+	}
+
+	entry, err := h.lotteryService.GetEntry(uint(saleID), currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Lottery entry retrieved successfully", entry)
+}
+
+func (h *LotteryHandler) Withdraw(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	saleID, err := strconv.ParseUint(c.Param("sale_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid sale ID")
+		return
+	}
+
+	if err := h.lotteryService.Withdraw(uint(saleID), currentUser.UserID); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Withdrawn from the lottery", nil)
+}
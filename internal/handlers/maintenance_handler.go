@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type MaintenanceHandler struct {
+	maintenanceService *services.MaintenanceService
+}
+
+func NewMaintenanceHandler(maintenanceService *services.MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{maintenanceService: maintenanceService}
+}
+
+// RegisterRoutes adds the maintenance mode switch
+func (h *MaintenanceHandler) RegisterRoutes(routes *Routes) {
+	routes.Admin.GET("/maintenance", h.GetMaintenance)
+	routes.Admin.PUT("/maintenance", h.UpdateMaintenance) // {"enabled": true, "read_only": false, "message": "...", "ends_at": ...}
+}
+
+func (h *MaintenanceHandler) GetMaintenance(c *gin.Context) {
+	utils.SuccessResponse(c, "Maintenance mode retrieved successfully", h.maintenanceService.Current())
+}
+
+func (h *MaintenanceHandler) UpdateMaintenance(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	var req services.UpdateMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	mode, err := h.maintenanceService.Update(&req, currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Maintenance mode updated successfully", mode)
+}
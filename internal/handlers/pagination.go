@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxPageLimit caps ?limit= on paginated listings
+const maxPageLimit = 100
+
+// pageParams reads the standard ?page= and ?limit= parameters. A missing or
+// invalid page is the first one; a missing, invalid or too large limit
+// falls back to defaultLimit.
+func pageParams(c *gin.Context, defaultLimit int) (page, limit, offset int) {
+	page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ = strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultLimit)))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > maxPageLimit {
+		limit = defaultLimit
+	}
+
+	return page, limit, (page - 1) * limit
+}
@@ -1,23 +1,57 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"strconv"
 
 	"eticketing/internal/middleware"
 	"eticketing/internal/models"
+	"eticketing/internal/repositories"
 	"eticketing/internal/services"
 	"eticketing/internal/utils"
 	"github.com/gin-gonic/gin"
 )
 
 type PaymentHandler struct {
-	paymentService *services.PaymentService
+	paymentService       *services.PaymentService
+	refundRequestService *services.RefundRequestService
 }
 
-func NewPaymentHandler(paymentService *services.PaymentService) *PaymentHandler {
-	return &PaymentHandler{paymentService: paymentService}
+func NewPaymentHandler(paymentService *services.PaymentService, refundRequestService *services.RefundRequestService) *PaymentHandler {
+	return &PaymentHandler{
+		paymentService:       paymentService,
+		refundRequestService: refundRequestService,
+	}
 }
 
+// RegisterRoutes adds payment history, refund requests and the admin refund endpoints
+func (h *PaymentHandler) RegisterRoutes(routes *Routes) {
+	payments := routes.Protected.Group("/payments")
+	{
+		payments.POST("", routes.RequireFeature(models.FeatureDirectPayments), h.ProcessPayment) // Standalone charge; ticket purchases pay through the purchase flow
+
+		payments.GET("/my", h.GetUserPayments)
+		payments.POST("/refund-requests", h.RequestRefund) // {"payment_id": 1, "reason": "..."}; closes before the event, see refund_cutoff_hours
+		payments.GET("/refund-requests", h.GetMyRefundRequests)
+		payments.GET("/:id", h.GetPaymentStatus)
+	}
+
+	routes.Seller.GET("/payments", h.GetSellerPayments)
+
+	routes.Admin.GET("/payments", h.FindPaymentByTransaction)  // ?transaction_id= as returned by the provider
+	routes.Admin.POST("/payments/:id/refund", h.RefundPayment) // Whole, some tickets or an amount; {"purchased_ticket_ids": [...], "amount": ..., "withheld_fee": ...}
+	routes.Admin.GET("/payments/:id/refunds", h.GetRefunds)
+	routes.Admin.GET("/refund-requests", h.GetRefundRequests)                 // ?status= (default pending, 0 for all)
+	routes.Admin.POST("/refund-requests/:id/approve", h.ApproveRefundRequest) // Same body as a refund
+	routes.Admin.POST("/refund-requests/:id/reject", h.RejectRefundRequest)   // {"note": "..."}
+}
+
+// ProcessPayment makes a standalone charge on the caller's account. Tickets
+// are paid for through the purchase flow, so the charge never credits an
+// event's seller.
 func (h *PaymentHandler) ProcessPayment(c *gin.Context) {
 	currentUser, err := middleware.GetCurrentUser(c)
 	if err != nil {
@@ -31,10 +65,17 @@ func (h *PaymentHandler) ProcessPayment(c *gin.Context) {
 		return
 	}
 
+	if req.PaymentMethod == models.PaymentTypeInvoice {
+		utils.BadRequestResponse(c, "Invoices are only issued for organization orders")
+		return
+	}
+
 	req.UserID = currentUser.UserID
-	response, err := h.paymentService.ProcessPayment(&req)
+	req.UserType = currentUser.UserType
+	req.EventID = 0
+	response, err := h.paymentService.ProcessPayment(c.Request.Context(), &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -61,13 +102,19 @@ func (h *PaymentHandler) GetUserPayments(c *gin.Context) {
 
 	offset := (page - 1) * limit
 
-	payments, err := h.paymentService.GetUserPayments(currentUser.UserID, models.UserTypeUser, limit, offset)
+	filter, err := parsePaymentFilter(c)
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.BadRequestResponse(c, err.Error())
 		return
 	}
 
-	utils.SuccessResponse(c, "Payments retrieved successfully", payments)
+	payments, total, err := h.paymentService.GetUserPayments(currentUser.UserID, models.UserTypeUser, filter, limit, offset)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Payments retrieved successfully", payments, utils.CalculatePagination(page, limit, total))
 }
 
 func (h *PaymentHandler) GetSellerPayments(c *gin.Context) {
@@ -96,26 +143,38 @@ func (h *PaymentHandler) GetSellerPayments(c *gin.Context) {
 
 	offset := (page - 1) * limit
 
+	filter, err := parsePaymentFilter(c)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
 	// Get seller revenue payments
-	payments, err := h.paymentService.GetUserPayments(currentUser.UserID, models.UserTypeSeller, limit, offset)
+	payments, total, err := h.paymentService.GetUserPayments(currentUser.UserID, models.UserTypeSeller, filter, limit, offset)
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.SuccessResponse(c, "Seller payments retrieved successfully", payments)
+	utils.PaginatedSuccessResponse(c, "Seller payments retrieved successfully", payments, utils.CalculatePagination(page, limit, total))
 }
 
 func (h *PaymentHandler) GetPaymentStatus(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
 	paymentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		utils.BadRequestResponse(c, "Invalid payment ID")
 		return
 	}
 
-	response, err := h.paymentService.GetPaymentStatus(uint(paymentID))
+	response, err := h.paymentService.GetPaymentStatus(uint(paymentID), currentUser.UserID, currentUser.UserType)
 	if err != nil {
-		utils.NotFoundResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
 		return
 	}
 
@@ -141,11 +200,206 @@ func (h *PaymentHandler) RefundPayment(c *gin.Context) {
 		return
 	}
 
-	err = h.paymentService.RefundPayment(uint(paymentID))
+	// An empty body refunds whatever is left of the payment
+	var req services.RefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	refund, err := h.paymentService.RefundPayment(c.Request.Context(), uint(paymentID), currentUser.UserID, &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
-	utils.SuccessResponse(c, "Payment refunded successfully", nil)
+	utils.SuccessResponse(c, "Payment refunded successfully", refund)
+}
+
+func (h *PaymentHandler) GetRefunds(c *gin.Context) {
+	paymentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid payment ID")
+		return
+	}
+
+	refunds, err := h.paymentService.GetRefunds(uint(paymentID))
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Refunds retrieved successfully", refunds)
+}
+
+// FindPaymentByTransaction is a support lookup by the provider's transaction reference
+func (h *PaymentHandler) FindPaymentByTransaction(c *gin.Context) {
+	transactionID := c.Query("transaction_id")
+	if transactionID == "" {
+		utils.BadRequestResponse(c, "transaction_id is required")
+		return
+	}
+
+	payment, err := h.paymentService.FindByTransaction(transactionID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Payment retrieved successfully", payment)
+}
+
+// RequestRefund asks for one of the user's orders to be refunded, until
+// the event's refund window closes
+func (h *PaymentHandler) RequestRefund(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	var req services.CreateRefundRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	request, err := h.refundRequestService.CreateRequest(currentUser.UserID, &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Refund request submitted successfully", request)
+}
+
+func (h *PaymentHandler) GetMyRefundRequests(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	requests, err := h.refundRequestService.GetMyRequests(currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Refund requests retrieved successfully", requests)
+}
+
+func (h *PaymentHandler) GetRefundRequests(c *gin.Context) {
+	status, _ := strconv.Atoi(c.DefaultQuery("status", strconv.Itoa(int(models.RefundRequestStatusPending))))
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	requests, err := h.refundRequestService.ListRequests(models.RefundRequestStatus(status), page, limit)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Refund requests retrieved successfully", requests)
+}
+
+func (h *PaymentHandler) ApproveRefundRequest(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	requestID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid refund request ID")
+		return
+	}
+
+	// An empty body refunds whatever is left of the order
+	var req services.RefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	refund, err := h.refundRequestService.ApproveRequest(c.Request.Context(), uint(requestID), currentUser.UserID, &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Refund request approved successfully", refund)
+}
+
+func (h *PaymentHandler) RejectRefundRequest(c *gin.Context) {
+	requestID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid refund request ID")
+		return
+	}
+
+	var req services.RejectRefundRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	if err := h.refundRequestService.RejectRequest(uint(requestID), &req); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Refund request rejected successfully", nil)
+}
+
+func parsePaymentFilter(c *gin.Context) (repositories.PaymentFilter, error) {
+	filter := repositories.PaymentFilter{
+		Direction: c.Query("direction"),
+		Sort:      c.Query("sort"),
+	}
+
+	if value := c.Query("status"); value != "" {
+		status, err := strconv.Atoi(value)
+		if err != nil || status < int(models.PaymentStatusPending) || status > int(models.PaymentStatusRefunded) {
+			return filter, fmt.Errorf("invalid status value: %s", value)
+		}
+		filter.Status = models.PaymentStatus(status)
+	}
+
+	if value := c.Query("event_id"); value != "" {
+		eventID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return filter, fmt.Errorf("invalid event_id value: %s", value)
+		}
+		filter.EventID = uint(eventID)
+	}
+
+	for name, target := range map[string]*int64{"from": &filter.From, "to": &filter.To} {
+		if value := c.Query(name); value != "" {
+			timestamp, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s timestamp: %s", name, value)
+			}
+			*target = timestamp
+		}
+	}
+
+	for name, target := range map[string]**float64{"min_amount": &filter.MinAmount, "max_amount": &filter.MaxAmount} {
+		if value := c.Query(name); value != "" {
+			amount, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s value: %s", name, value)
+			}
+			*target = &amount
+		}
+	}
+
+	return filter, nil
 }
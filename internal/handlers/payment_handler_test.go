@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+)
+
+type fakePaymentRepository struct {
+	repositories.PaymentRepository
+	payments map[uint]*models.Payment
+}
+
+func (r *fakePaymentRepository) GetByID(id uint) (*models.Payment, error) {
+	payment, ok := r.payments[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *payment
+	return &copied, nil
+}
+
+func (r *fakePaymentRepository) ListByParent(parentID uint) ([]models.Payment, error) {
+	var components []models.Payment
+	for _, payment := range r.payments {
+		if payment.ParentPaymentID != nil && *payment.ParentPaymentID == parentID {
+			components = append(components, *payment)
+		}
+	}
+	return components, nil
+}
+
+type fakePurchasedTicketRepository struct {
+	repositories.PurchasedTicketRepository
+}
+
+func (r *fakePurchasedTicketRepository) ListByPayment(paymentID uint) ([]models.PurchasedTicket, error) {
+	return nil, nil
+}
+
+type fakeEventMemberRepository struct {
+	repositories.EventMemberRepository
+	members []models.EventMember
+}
+
+func (r *fakeEventMemberRepository) Get(eventID, sellerID uint) (*models.EventMember, error) {
+	for i := range r.members {
+		if r.members[i].EventID == eventID && r.members[i].SellerID == sellerID {
+			return &r.members[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// TestPaymentVisibility requests a payment through its route as each kind
+// of viewer
+func TestPaymentVisibility(t *testing.T) {
+	const (
+		buyerID       = 10
+		ownerSellerID = 20
+		viewerID      = 21 // Co-manages the event with view_sales
+		editorID      = 22 // Co-manages the event without view_sales
+	)
+
+	event := models.Event{ID: 5, SellerID: ownerSellerID}
+	parentID := uint(1)
+	paymentRepo := &fakePaymentRepository{payments: map[uint]*models.Payment{
+		1: {ID: 1, UserID: buyerID, UserType: models.UserTypeUser, Type: models.PaymentTypeSplit, EventID: event.ID, Event: event},
+		2: {ID: 2, UserID: buyerID, UserType: models.UserTypeUser, Type: models.PaymentTypeCard, ParentPaymentID: &parentID},
+	}}
+	memberRepo := &fakeEventMemberRepository{members: []models.EventMember{
+		{EventID: event.ID, SellerID: viewerID, Permissions: models.EventPermissionViewSales},
+		{EventID: event.ID, SellerID: editorID, Permissions: models.EventPermissionEditEvent},
+	}}
+	paymentService := services.NewPaymentService(paymentRepo, nil, &fakePurchasedTicketRepository{}, nil, nil,
+		services.NewEventAccess(memberRepo), nil, nil, nil, true)
+
+	tests := []struct {
+		name       string
+		viewer     utils.JWTClaims
+		paymentID  uint
+		wantStatus int
+	}{
+		{"payer", utils.JWTClaims{UserID: buyerID, UserType: models.UserTypeUser}, 1, http.StatusOK},
+		{"payer viewing a split component", utils.JWTClaims{UserID: buyerID, UserType: models.UserTypeUser}, 2, http.StatusOK},
+		{"event owner", utils.JWTClaims{UserID: ownerSellerID, UserType: models.UserTypeSeller}, 1, http.StatusOK},
+		{"co-manager who views sales", utils.JWTClaims{UserID: viewerID, UserType: models.UserTypeSeller}, 1, http.StatusOK},
+		{"co-manager who doesn't view sales", utils.JWTClaims{UserID: editorID, UserType: models.UserTypeSeller}, 1, http.StatusForbidden},
+		{"admin", utils.JWTClaims{UserID: 1, UserType: models.UserTypeAdmin}, 1, http.StatusOK},
+		{"another user", utils.JWTClaims{UserID: buyerID + 1, UserType: models.UserTypeUser}, 1, http.StatusForbidden},
+		{"seller sharing the payer's ID", utils.JWTClaims{UserID: buyerID, UserType: models.UserTypeSeller}, 1, http.StatusForbidden},
+		{"missing payment", utils.JWTClaims{UserID: 1, UserType: models.UserTypeAdmin}, 3, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viewer := tt.viewer
+			router := newTestRouter(&viewer, NewPaymentHandler(paymentService, nil))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/payments/%d", tt.paymentID), nil))
+
+			wantCode := apperrors.Code("")
+			switch tt.wantStatus {
+			case http.StatusForbidden:
+				wantCode = apperrors.CodeForbidden
+			case http.StatusNotFound:
+				wantCode = apperrors.CodeNotFound
+			}
+			assertStatus(t, w, tt.wantStatus, wantCode)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"net/http"
 	"strconv"
 
 	"eticketing/internal/middleware"
@@ -17,6 +18,19 @@ func NewPaymentMethodHandler(paymentMethodService *services.PaymentMethodService
 	return &PaymentMethodHandler{paymentMethodService: paymentMethodService}
 }
 
+// RegisterRoutes adds the stored payment method endpoints
+func (h *PaymentMethodHandler) RegisterRoutes(routes *Routes) {
+	paymentMethods := routes.Protected.Group("/payment-methods")
+	{
+		paymentMethods.POST("", h.CreatePaymentMethod)
+		paymentMethods.GET("", h.GetPaymentMethods)
+		paymentMethods.GET("/:id", h.GetPaymentMethod)
+		paymentMethods.PUT("/:id", h.UpdatePaymentMethod)
+		paymentMethods.DELETE("/:id", h.DeletePaymentMethod)
+		paymentMethods.POST("/:id/set-default", h.SetDefaultPaymentMethod)
+	}
+}
+
 func (h *PaymentMethodHandler) CreatePaymentMethod(c *gin.Context) {
 	currentUser, err := middleware.GetCurrentUser(c)
 	if err != nil {
@@ -26,14 +40,14 @@ func (h *PaymentMethodHandler) CreatePaymentMethod(c *gin.Context) {
 
 	var req services.CreatePaymentMethodRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequestResponse(c, "Invalid request data")
+		utils.BindingErrorResponse(c, err)
 		return
 	}
 
 	req.UserID = currentUser.UserID
-	response, err := h.paymentMethodService.CreatePaymentMethod(&req)
+	response, err := h.paymentMethodService.CreatePaymentMethod(c.Request.Context(), &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -49,7 +63,7 @@ func (h *PaymentMethodHandler) GetPaymentMethods(c *gin.Context) {
 
 	methods, err := h.paymentMethodService.GetUserPaymentMethods(currentUser.UserID)
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -71,7 +85,7 @@ func (h *PaymentMethodHandler) GetPaymentMethod(c *gin.Context) {
 
 	method, err := h.paymentMethodService.GetPaymentMethod(uint(methodID), currentUser.UserID)
 	if err != nil {
-		utils.NotFoundResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
 		return
 	}
 
@@ -99,7 +113,7 @@ func (h *PaymentMethodHandler) UpdatePaymentMethod(c *gin.Context) {
 
 	err = h.paymentMethodService.UpdatePaymentMethod(uint(methodID), currentUser.UserID, &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -121,7 +135,7 @@ func (h *PaymentMethodHandler) DeletePaymentMethod(c *gin.Context) {
 
 	err = h.paymentMethodService.DeletePaymentMethod(uint(methodID), currentUser.UserID)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -143,7 +157,7 @@ func (h *PaymentMethodHandler) SetDefaultPaymentMethod(c *gin.Context) {
 
 	err = h.paymentMethodService.SetDefaultPaymentMethod(uint(methodID), currentUser.UserID)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
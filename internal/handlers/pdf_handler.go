@@ -3,8 +3,10 @@ package handlers
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"eticketing/internal/middleware"
+	"eticketing/internal/models"
 	"eticketing/internal/repositories"
 	"eticketing/internal/services"
 	"eticketing/internal/utils"
@@ -15,20 +17,87 @@ type PDFHandler struct {
 	pdfService          *services.PDFService
 	purchasedTicketRepo repositories.PurchasedTicketRepository
 	eventRepo           repositories.EventRepository
+	giftService         *services.GiftService
+	codes               *services.TicketCodeSigner
 }
 
 func NewPDFHandler(
 	pdfService *services.PDFService,
 	purchasedTicketRepo repositories.PurchasedTicketRepository,
 	eventRepo repositories.EventRepository,
+	giftService *services.GiftService,
+	codes *services.TicketCodeSigner,
 ) *PDFHandler {
 	return &PDFHandler{
 		pdfService:          pdfService,
 		purchasedTicketRepo: purchasedTicketRepo,
 		eventRepo:           eventRepo,
+		giftService:         giftService,
+		codes:               codes,
 	}
 }
 
+// RegisterRoutes adds ticket downloads and the live entry code
+func (h *PDFHandler) RegisterRoutes(routes *Routes) {
+	tickets := routes.Protected.Group("/tickets")
+	{
+		tickets.GET("/:ticket_id/download", h.DownloadTicketPDF)
+		tickets.GET("/:ticket_id/view", h.ViewTicketPDF)
+		tickets.GET("/:ticket_id/live-code", h.GetLiveCode) // Rotating QR code for the app
+	}
+}
+
+// GetLiveCode returns the ticket's rotating QR code for the app to show.
+// It changes every period, so a screenshot stops working within a minute.
+func (h *PDFHandler) GetLiveCode(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	ticketID, err := strconv.ParseUint(c.Param("ticket_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid ticket ID")
+		return
+	}
+
+	purchasedTicket, err := h.purchasedTicketRepo.GetByID(uint(ticketID))
+	if err != nil {
+		utils.NotFoundResponse(c, "Ticket not found")
+		return
+	}
+
+	if purchasedTicket.UserID != currentUser.UserID {
+		utils.ForbiddenResponse(c, "You can only show your own tickets")
+		return
+	}
+
+	if purchasedTicket.IsInvalidated {
+		utils.ForbiddenResponse(c, "This ticket has been invalidated")
+		return
+	}
+
+	// Like the PDF, unsettled orders get no entry code
+	reason, err := h.pdfService.PreviewReason(purchasedTicket)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Failed to check the order's payment")
+		return
+	}
+	if reason != "" {
+		utils.ForbiddenResponse(c, reason)
+		return
+	}
+
+	if err := h.ensureQRSecret(purchasedTicket); err != nil {
+		utils.InternalErrorResponse(c, "Failed to prepare ticket code")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	utils.SuccessResponse(c, "Ticket code generated successfully", h.codes.Live(purchasedTicket, time.Now()))
+}
+
 func (h *PDFHandler) DownloadTicketPDF(c *gin.Context) {
 	currentUser, err := middleware.GetCurrentUser(c)
 	if err != nil {
@@ -55,6 +124,11 @@ func (h *PDFHandler) DownloadTicketPDF(c *gin.Context) {
 		return
 	}
 
+	if purchasedTicket.IsInvalidated {
+		utils.ForbiddenResponse(c, "This ticket has been invalidated")
+		return
+	}
+
 	// Get event information
 	event, err := h.eventRepo.GetByID(purchasedTicket.Ticket.EventID)
 	if err != nil {
@@ -62,11 +136,31 @@ func (h *PDFHandler) DownloadTicketPDF(c *gin.Context) {
 		return
 	}
 
+	if err := h.ensureQRSecret(purchasedTicket); err != nil {
+		utils.InternalErrorResponse(c, "Failed to prepare ticket code")
+		return
+	}
+
 	// Prepare PDF data
 	pdfData := &services.TicketPDFData{
 		PurchasedTicket: purchasedTicket,
 		Event:           event,
-		QRCodeURL:       "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+		QRPayload:       services.TicketQRPayload(purchasedTicket),
+	}
+
+	if err := h.applyGift(pdfData); err != nil {
+		utils.InternalErrorResponse(c, "Failed to load gift information")
+		return
+	}
+
+	// Unsettled orders only get a watermarked preview without the entry code
+	pdfData.PreviewReason, err = h.pdfService.PreviewReason(purchasedTicket)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Failed to check the order's payment")
+		return
+	}
+	if pdfData.PreviewReason != "" {
+		c.Header("X-Ticket-Preview", "true")
 	}
 
 	// Generate PDF
@@ -78,6 +172,9 @@ func (h *PDFHandler) DownloadTicketPDF(c *gin.Context) {
 
 	// Set response headers for PDF download
 	filename := fmt.Sprintf("ticket_%d_%s.pdf", purchasedTicket.ID, event.Title)
+	if pdfData.PreviewReason != "" {
+		filename = "preview_" + filename
+	}
 	// Sanitize filename for safe download
 	filename = utils.SanitizeFilename(filename)
 
@@ -115,6 +212,11 @@ func (h *PDFHandler) ViewTicketPDF(c *gin.Context) {
 		return
 	}
 
+	if purchasedTicket.IsInvalidated {
+		utils.ForbiddenResponse(c, "This ticket has been invalidated")
+		return
+	}
+
 	// Get event information
 	event, err := h.eventRepo.GetByID(purchasedTicket.Ticket.EventID)
 	if err != nil {
@@ -122,11 +224,31 @@ func (h *PDFHandler) ViewTicketPDF(c *gin.Context) {
 		return
 	}
 
+	if err := h.ensureQRSecret(purchasedTicket); err != nil {
+		utils.InternalErrorResponse(c, "Failed to prepare ticket code")
+		return
+	}
+
 	// Prepare PDF data
 	pdfData := &services.TicketPDFData{
 		PurchasedTicket: purchasedTicket,
 		Event:           event,
-		QRCodeURL:       "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+		QRPayload:       services.TicketQRPayload(purchasedTicket),
+	}
+
+	if err := h.applyGift(pdfData); err != nil {
+		utils.InternalErrorResponse(c, "Failed to load gift information")
+		return
+	}
+
+	// Unsettled orders only get a watermarked preview without the entry code
+	pdfData.PreviewReason, err = h.pdfService.PreviewReason(purchasedTicket)
+	if err != nil {
+		utils.InternalErrorResponse(c, "Failed to check the order's payment")
+		return
+	}
+	if pdfData.PreviewReason != "" {
+		c.Header("X-Ticket-Preview", "true")
 	}
 
 	// Generate PDF
@@ -144,3 +266,34 @@ func (h *PDFHandler) ViewTicketPDF(c *gin.Context) {
 	// Write PDF to response
 	c.Data(200, "application/pdf", pdfBytes)
 }
+
+// ensureQRSecret gives tickets issued before QR secrets existed their first
+// one, so every PDF carries a code check-in can verify
+func (h *PDFHandler) ensureQRSecret(ticket *models.PurchasedTicket) error {
+	if ticket.QRSecret != "" {
+		return nil
+	}
+	secret, err := h.purchasedTicketRepo.RotateQRSecret(ticket.ID)
+	if err != nil {
+		return err
+	}
+	ticket.QRSecret = secret
+	return nil
+}
+
+// applyGift adds the sender and message to the PDF when the current owner
+// received the ticket as a gift
+func (h *PDFHandler) applyGift(pdfData *services.TicketPDFData) error {
+	gift, err := h.giftService.GetGiftForTicket(pdfData.PurchasedTicket.ID)
+	if err != nil {
+		return err
+	}
+
+	if gift != nil && gift.Status == models.GiftStatusDelivered &&
+		gift.RecipientID != nil && *gift.RecipientID == pdfData.PurchasedTicket.UserID {
+		pdfData.GiftFrom = gift.SenderName
+		pdfData.GiftMessage = gift.Message
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/models"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type PolicyHandler struct {
+	policyService *services.PolicyService
+}
+
+func NewPolicyHandler(policyService *services.PolicyService) *PolicyHandler {
+	return &PolicyHandler{policyService: policyService}
+}
+
+// RegisterRoutes adds the terms of service and privacy policy in force,
+// accepting them, and admin publishing of new versions
+func (h *PolicyHandler) RegisterRoutes(routes *Routes) {
+	routes.Public.GET("/policies", h.GetCurrentPolicies)
+	routes.Protected.GET("/policies/status", h.GetPolicyStatus)
+	routes.Protected.POST("/policies/accept", h.AcceptPolicies)
+
+	routes.Admin.GET("/policies", h.ListPolicyVersions) // ?kind=1 terms, 2 privacy
+	routes.Admin.POST("/policies", h.PublishPolicy)
+}
+
+func (h *PolicyHandler) GetCurrentPolicies(c *gin.Context) {
+	utils.SuccessResponse(c, "Policies retrieved successfully", h.policyService.Current())
+}
+
+func (h *PolicyHandler) GetPolicyStatus(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	statuses, err := h.policyService.Status(currentUser.UserID, currentUser.UserType)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Policy status retrieved successfully", statuses)
+}
+
+func (h *PolicyHandler) AcceptPolicies(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+	if currentUser.ImpersonatorID != 0 {
+		utils.ForbiddenResponse(c, "Policies can't be accepted on another account's behalf")
+		return
+	}
+
+	var req services.AcceptPoliciesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	statuses, err := h.policyService.Accept(currentUser.UserID, currentUser.UserType, &req, c.ClientIP())
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Policies accepted successfully", statuses)
+}
+
+func (h *PolicyHandler) ListPolicyVersions(c *gin.Context) {
+	kind, err := strconv.Atoi(c.DefaultQuery("kind", "0"))
+	if err != nil || kind < 0 || kind > int(models.PolicyKindPrivacy) {
+		utils.BadRequestResponse(c, "Invalid kind")
+		return
+	}
+
+	page, limit, offset := pageParams(c, 20)
+	documents, total, err := h.policyService.ListVersions(models.PolicyKind(kind), limit, offset)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Policies retrieved successfully", documents, utils.CalculatePagination(page, limit, total))
+}
+
+func (h *PolicyHandler) PublishPolicy(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	var req services.PublishPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	document, err := h.policyService.Publish(&req, currentUser.UserID, c.ClientIP())
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Policy published successfully", document)
+}
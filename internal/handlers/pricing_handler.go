@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type PricingHandler struct {
+	pricingService *services.PricingService
+}
+
+func NewPricingHandler(pricingService *services.PricingService) *PricingHandler {
+	return &PricingHandler{pricingService: pricingService}
+}
+
+// RegisterRoutes adds price tiers
+func (h *PricingHandler) RegisterRoutes(routes *Routes) {
+	routes.Events.GET("/:event_id/price-tiers", h.GetEventPricing)
+	routes.Seller.PUT("/events/:event_id/price-tiers", h.SetPriceTiers)
+}
+
+func (h *PricingHandler) GetEventPricing(c *gin.Context) {
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	pricing, err := h.pricingService.GetEventPricing(uint(eventID))
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Price tiers retrieved successfully", pricing)
+}
+
+func (h *PricingHandler) SetPriceTiers(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	var req services.SetPriceTiersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	pricing, err := h.pricingService.SetPriceTiers(uint(eventID), currentUser.UserID, &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Price tiers saved successfully", pricing)
+}
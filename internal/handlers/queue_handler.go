@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type QueueHandler struct {
+	queueService *services.QueueService
+}
+
+func NewQueueHandler(queueService *services.QueueService) *QueueHandler {
+	return &QueueHandler{queueService: queueService}
+}
+
+// RegisterRoutes adds joining and polling an event's waiting room
+func (h *QueueHandler) RegisterRoutes(routes *Routes) {
+	routes.Protected.POST("/events/:event_id/queue", h.JoinQueue)
+	routes.Protected.GET("/queue/:token", h.GetQueueStatus) // Poll until admitted, then buy with X-Queue-Token
+}
+
+func (h *QueueHandler) JoinQueue(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	status, err := h.queueService.Join(uint(eventID), currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Joined the waiting room", status)
+}
+
+func (h *QueueHandler) GetQueueStatus(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	status, err := h.queueService.GetStatus(c.Param("token"), currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Queue status retrieved successfully", status)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/models"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ReportHandler struct {
+	reportService *services.ReportService
+}
+
+func NewReportHandler(reportService *services.ReportService) *ReportHandler {
+	return &ReportHandler{reportService: reportService}
+}
+
+// RegisterRoutes adds abuse reports
+func (h *ReportHandler) RegisterRoutes(routes *Routes) {
+	// Flag an event for admin review
+	routes.Protected.POST("/events/:event_id/report", h.ReportEvent)
+
+	routes.Admin.GET("/reports", h.GetReports) // Abuse reports; ?status=0 for all
+	routes.Admin.PUT("/reports/:report_id", h.ResolveReport)
+}
+
+func (h *ReportHandler) ReportEvent(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	var req services.ReportEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	report, err := h.reportService.ReportEvent(uint(eventID), currentUser.UserID, currentUser.UserType, &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Report submitted successfully", report)
+}
+
+func (h *ReportHandler) GetReports(c *gin.Context) {
+	status, _ := strconv.Atoi(c.DefaultQuery("status", "1"))
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	reports, err := h.reportService.ListReports(models.EventReportStatus(status), page, limit)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Reports retrieved successfully", reports)
+}
+
+func (h *ReportHandler) ResolveReport(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	reportID, err := strconv.ParseUint(c.Param("report_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid report ID")
+		return
+	}
+
+	var req services.ResolveReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	report, err := h.reportService.ResolveReport(uint(reportID), currentUser.UserID, &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Report resolved successfully", report)
+}
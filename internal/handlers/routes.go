@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"time"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// APIVersion is the major API version a set of routes is registered for
+type APIVersion int
+
+const (
+	APIV1 APIVersion = iota + 1
+	APIV2
+)
+
+// Routes are the groups of one API version that handlers add their
+// endpoints to. The groups carry the authentication and role checks, so a
+// handler only decides which group each of its endpoints belongs in.
+type Routes struct {
+	Version APIVersion
+
+	Public    *gin.RouterGroup // No authentication
+	Events    *gin.RouterGroup // Public event browsing, answered with ETags
+	Protected *gin.RouterGroup // Any signed-in account
+	Seller    *gin.RouterGroup // Sellers only
+	// Sellers only, for file uploads; bodies may be up to MAX_UPLOAD_BYTES
+	// instead of MAX_BODY_BYTES
+	SellerUploads *gin.RouterGroup
+	Admin         *gin.RouterGroup // Admins only
+
+	JWTManager *utils.JWTManager
+	Features   middleware.FeatureChecker
+	Queue      middleware.QueueGate
+	Onboarding middleware.SellerApproval
+	V1Sunset   time.Time
+}
+
+// RouteRegistrar is implemented by every handler that serves API routes
+type RouteRegistrar interface {
+	RegisterRoutes(routes *Routes)
+}
+
+// Deprecated marks a v1 endpoint that v2 replaces with successor
+func (r *Routes) Deprecated(successor string) gin.HandlerFunc {
+	return middleware.DeprecatedMiddleware(r.V1Sunset, "/api/v2"+successor)
+}
+
+// RequireQueueAdmission rejects purchases of events with a waiting room
+// that don't carry an admitted queue token
+func (r *Routes) RequireQueueAdmission() gin.HandlerFunc {
+	return middleware.RequireQueueAdmission(r.Queue)
+}
+
+// RequireApprovedSeller rejects sellers whose onboarding hasn't been
+// approved yet
+func (r *Routes) RequireApprovedSeller() gin.HandlerFunc {
+	return middleware.RequireApprovedSeller(r.Onboarding)
+}
+
+// RequireFeature rejects the request while the feature flag for key is off
+func (r *Routes) RequireFeature(key string) gin.HandlerFunc {
+	return middleware.RequireFeature(r.Features, key)
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"eticketing/internal/config"
+	"eticketing/internal/middleware"
+	"eticketing/internal/models"
+	"eticketing/internal/utils"
+)
+
+// allModules lists every route registrar served by the API, with no
+// services behind them. Routes can be registered and their parameters
+// parsed; anything past that panics.
+func allModules() []RouteRegistrar {
+	return []RouteRegistrar{
+		&AuthHandler{}, &UserHandler{}, &SellerHandler{}, &AdminHandler{}, &EventHandler{},
+		&TicketHandler{}, &TransferHandler{}, &SaleHandler{}, &PaymentMethodHandler{}, &PaymentHandler{},
+		&PDFHandler{}, &DeepLinkHandler{}, &QueueHandler{}, &LotteryHandler{}, &FraudReviewHandler{},
+		&DenylistHandler{}, &PolicyHandler{}, &SellerOnboardingHandler{}, &JobHandler{}, &WebhookHandler{},
+		&AttendeeHandler{}, &PricingHandler{}, &BulkOrderHandler{}, &DisputeHandler{}, &VenueHandler{},
+		&CalendarHandler{}, &ReportHandler{}, &FollowHandler{}, &DataExportHandler{}, &AuditHandler{},
+		&EventMemberHandler{}, &DeadLetterHandler{}, &TicketImportHandler{}, &FeatureFlagHandler{},
+		&ConfigHandler{}, &MaintenanceHandler{}, &AnnouncementHandler{}, &AnalyticsHandler{}, &HealthHandler{},
+	}
+}
+
+// allowAll passes every feature, queue and onboarding check
+type allowAll struct{}
+
+func (allowAll) Require(key string, userID uint) error { return nil }
+func (allowAll) RequireApproved(sellerID uint) error   { return nil }
+func (allowAll) CompletePurchase(token string) error   { return nil }
+func (allowAll) AdmitPurchase(eventID, ticketID, userID uint, token string) (bool, error) {
+	return true, nil
+}
+
+// newTestRouter registers modules for both API versions without the
+// authentication middleware. Protected routes run as claims.
+func newTestRouter(claims *utils.JWTClaims, modules ...RouteRegistrar) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	gin.DefaultErrorWriter = io.Discard // Stack traces of the expected panics
+	router := gin.New()
+	router.Use(middleware.RecoveryMiddleware())
+
+	for _, version := range []APIVersion{APIV1, APIV2} {
+		api := router.Group("/api/v" + strconv.Itoa(int(version)))
+		protected := api.Group("", func(c *gin.Context) {
+			c.Set(middleware.AuthorizationPayloadKey, claims)
+		})
+		routes := &Routes{
+			Version:       version,
+			Public:        api,
+			Events:        api.Group("/events"),
+			Protected:     protected,
+			Seller:        protected.Group("/seller"),
+			SellerUploads: protected.Group("/seller"),
+			Admin:         protected.Group("/admin"),
+			JWTManager:    utils.NewJWTManager(&config.JWTConfig{Secret: "test-secret"}),
+			Features:      allowAll{},
+			Queue:         allowAll{},
+			Onboarding:    allowAll{},
+		}
+		for _, module := range modules {
+			module.RegisterRoutes(routes)
+		}
+	}
+	return router
+}
+
+// paramsRead maps "Handler.Method" to the path parameters the method
+// reads, directly or through other methods of the same handler
+func paramsRead(t *testing.T) map[string][]string {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatalf("parse handlers: %v", err)
+	}
+
+	direct := make(map[string][]string)
+	calls := make(map[string][]string)
+	for _, file := range packages["handlers"].Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Body == nil {
+				continue
+			}
+			star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			receiver := star.X.(*ast.Ident).Name
+			name := receiver + "." + fn.Name.Name
+
+			ast.Inspect(fn.Body, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				selector, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				if selector.Sel.Name == "Param" && len(call.Args) == 1 {
+					if literal, ok := call.Args[0].(*ast.BasicLit); ok && literal.Kind == token.STRING {
+						param, _ := strconv.Unquote(literal.Value)
+						direct[name] = append(direct[name], param)
+					}
+				}
+				if ident, ok := selector.X.(*ast.Ident); ok && ident.Name == fn.Recv.List[0].Names[0].Name {
+					calls[name] = append(calls[name], receiver+"."+selector.Sel.Name)
+				}
+				return true
+			})
+		}
+	}
+
+	var collect func(name string, seen map[string]bool) []string
+	collect = func(name string, seen map[string]bool) []string {
+		if seen[name] {
+			return nil
+		}
+		seen[name] = true
+		params := append([]string(nil), direct[name]...)
+		for _, callee := range calls[name] {
+			params = append(params, collect(callee, seen)...)
+		}
+		return params
+	}
+
+	read := make(map[string][]string)
+	for name := range calls {
+		read[name] = collect(name, map[string]bool{})
+	}
+	for name := range direct {
+		read[name] = collect(name, map[string]bool{})
+	}
+	return read
+}
+
+var handlerName = regexp.MustCompile(`\(\*(\w+)\)\.(\w+)-fm$`)
+
+// TestRouteParamsMatchHandlers checks that every path parameter a handler
+// reads is one its route defines; a mismatch reads as empty and the
+// handler answers 400 for every request
+func TestRouteParamsMatchHandlers(t *testing.T) {
+	read := paramsRead(t)
+	router := newTestRouter(&utils.JWTClaims{}, allModules()...)
+
+	for _, route := range router.Routes() {
+		defined := make(map[string]bool)
+		for _, segment := range strings.Split(route.Path, "/") {
+			if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+				defined[segment[1:]] = true
+			}
+		}
+
+		match := handlerName.FindStringSubmatch(route.Handler)
+		if match == nil {
+			if len(defined) > 0 {
+				t.Errorf("%s %s: handler %s is not a handler method, so its parameters can't be checked",
+					route.Method, route.Path, route.Handler)
+			}
+			continue
+		}
+
+		for _, param := range read[match[1]+"."+match[2]] {
+			if !defined[param] {
+				t.Errorf("%s %s: %s.%s reads :%s, which the route doesn't define",
+					route.Method, route.Path, match[1], match[2], param)
+			}
+		}
+	}
+}
+
+// TestRouteParamsReachHandlers sends requests through routes whose
+// parameters were once misnamed. Past the parameter the handlers reach
+// their missing service and answer 500 instead of 400.
+func TestRouteParamsReachHandlers(t *testing.T) {
+	user := &utils.JWTClaims{UserID: 1, UserType: models.UserTypeUser}
+	router := newTestRouter(user, &TransferHandler{}, &TicketHandler{})
+
+	tests := []struct {
+		method, path string
+	}{
+		{http.MethodPost, "/api/v1/transfers/7/accept"},
+		{http.MethodPost, "/api/v1/transfers/7/reject"},
+		{http.MethodGet, "/api/v1/events/7/tickets"},
+		{http.MethodDelete, "/api/v2/tickets/7/claim-link"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("status = %d, want the handler to get past the parameter: %s", w.Code, w.Body)
+			}
+		})
+	}
+}
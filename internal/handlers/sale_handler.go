@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"net/http"
 	"strconv"
 
 	"eticketing/internal/middleware"
@@ -18,6 +19,22 @@ func NewSaleHandler(saleService *services.SaleService) *SaleHandler {
 	return &SaleHandler{saleService: saleService}
 }
 
+// RegisterRoutes adds sale browsing and sale management for sellers and admins
+func (h *SaleHandler) RegisterRoutes(routes *Routes) {
+	routes.Events.GET("/:event_id/sales", h.GetSalesByEvent)
+	routes.Public.GET("/sales/:sale_id", h.GetSale)
+
+	routes.Seller.POST("/sales", h.CreateSale)
+	routes.Seller.GET("/sales", h.GetMySales)
+	routes.Seller.PUT("/sales/:sale_id", h.UpdateSale)
+	routes.Seller.DELETE("/sales/:sale_id", h.DeleteSale)
+	routes.Seller.PUT("/sales/:sale_id/allocations", h.SetAllocation)
+	routes.Seller.DELETE("/sales/:sale_id/allocations/:allocation_id", h.DeleteAllocation)
+
+	routes.Admin.PUT("/sales/:sale_id", h.AdminUpdateSale) // Any seller's sale; recorded on the audit log
+	routes.Admin.DELETE("/sales/:sale_id", h.AdminDeleteSale)
+}
+
 func (h *SaleHandler) CreateSale(c *gin.Context) {
 	currentUser, err := middleware.GetCurrentUser(c)
 	if err != nil {
@@ -32,19 +49,52 @@ func (h *SaleHandler) CreateSale(c *gin.Context) {
 
 	var req services.CreateSaleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequestResponse(c, "Invalid request data")
+		utils.BindingErrorResponse(c, err)
 		return
 	}
 
 	sale, err := h.saleService.CreateSale(&req, currentUser.UserID)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
 	utils.CreatedResponse(c, "Sale created successfully", sale)
 }
 
+// GetMySales lists the seller's sales across all their events
+// (?status=active|upcoming|expired, ?event_id=)
+func (h *SaleHandler) GetMySales(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	if currentUser.UserType != models.UserTypeSeller {
+		utils.ForbiddenResponse(c, "Only sellers can access this endpoint")
+		return
+	}
+
+	eventID, _ := strconv.ParseUint(c.Query("event_id"), 10, 32)
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	sales, err := h.saleService.GetSellerSales(currentUser.UserID, uint(eventID), c.Query("status"), page, limit)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Sales retrieved successfully", sales)
+}
+
 func (h *SaleHandler) GetSalesByEvent(c *gin.Context) {
 	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
 	if err != nil {
@@ -54,7 +104,7 @@ func (h *SaleHandler) GetSalesByEvent(c *gin.Context) {
 
 	sales, err := h.saleService.GetSalesByEvent(uint(eventID))
 	if err != nil {
-		utils.NotFoundResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
 		return
 	}
 
@@ -70,7 +120,7 @@ func (h *SaleHandler) GetSale(c *gin.Context) {
 
 	sale, err := h.saleService.GetSaleByID(uint(saleID))
 	if err != nil {
-		utils.NotFoundResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
 		return
 	}
 
@@ -97,13 +147,13 @@ func (h *SaleHandler) UpdateSale(c *gin.Context) {
 
 	var req services.UpdateSaleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequestResponse(c, "Invalid request data")
+		utils.BindingErrorResponse(c, err)
 		return
 	}
 
 	sale, err := h.saleService.UpdateSale(uint(saleID), currentUser.UserID, &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -130,9 +180,117 @@ func (h *SaleHandler) DeleteSale(c *gin.Context) {
 
 	err = h.saleService.DeleteSale(uint(saleID), currentUser.UserID)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
 	utils.SuccessResponse(c, "Sale deleted successfully", nil)
 }
+
+// AdminUpdateSale lets an admin reschedule a sale regardless of which
+// seller owns it
+func (h *SaleHandler) AdminUpdateSale(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	saleID, err := strconv.ParseUint(c.Param("sale_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid sale ID")
+		return
+	}
+
+	var req services.UpdateSaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	sale, err := h.saleService.AdminUpdateSale(uint(saleID), currentUser.UserID, c.ClientIP(), &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Sale updated successfully", sale)
+}
+
+// AdminDeleteSale lets an admin remove a sale regardless of which seller
+// owns it
+func (h *SaleHandler) AdminDeleteSale(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	saleID, err := strconv.ParseUint(c.Param("sale_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid sale ID")
+		return
+	}
+
+	if err := h.saleService.AdminDeleteSale(uint(saleID), currentUser.UserID, c.ClientIP()); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Sale deleted successfully", nil)
+}
+
+func (h *SaleHandler) SetAllocation(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	saleID, err := strconv.ParseUint(c.Param("sale_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid sale ID")
+		return
+	}
+
+	var req services.SaleAllocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	allocation, err := h.saleService.SetAllocation(uint(saleID), currentUser.UserID, &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Sale allocation saved successfully", allocation)
+}
+
+func (h *SaleHandler) DeleteAllocation(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	saleID, err := strconv.ParseUint(c.Param("sale_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid sale ID")
+		return
+	}
+
+	allocationID, err := strconv.ParseUint(c.Param("allocation_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid allocation ID")
+		return
+	}
+
+	if err := h.saleService.DeleteAllocation(uint(saleID), uint(allocationID), currentUser.UserID); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Sale allocation deleted successfully", nil)
+}
@@ -2,6 +2,9 @@
 package handlers
 
 import (
+	"net/http"
+	"strconv"
+
 	"eticketing/internal/middleware"
 	"eticketing/internal/models"
 	"eticketing/internal/services"
@@ -9,12 +12,53 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// publicProfileEventLimit caps the upcoming events shown on a seller's page
+const publicProfileEventLimit = 20
+
 type SellerHandler struct {
 	sellerService *services.SellerService
+	eventService  *services.EventService
+}
+
+func NewSellerHandler(sellerService *services.SellerService, eventService *services.EventService) *SellerHandler {
+	return &SellerHandler{
+		sellerService: sellerService,
+		eventService:  eventService,
+	}
 }
 
-func NewSellerHandler(sellerService *services.SellerService) *SellerHandler {
-	return &SellerHandler{sellerService: sellerService}
+// RegisterRoutes adds the seller account endpoints and public seller pages
+func (h *SellerHandler) RegisterRoutes(routes *Routes) {
+	routes.Public.GET("/sellers/:seller_id/public", h.GetPublicProfile)
+
+	routes.Seller.GET("/profile", h.GetProfile)
+	routes.Seller.PUT("/profile", h.UpdateProfile)
+	routes.Seller.PUT("/password", h.ChangePassword)
+	routes.Seller.DELETE("/profile", h.DeleteAccount)
+	routes.Seller.GET("/stats", h.GetStats)
+}
+
+func (h *SellerHandler) GetPublicProfile(c *gin.Context) {
+	sellerID, err := strconv.ParseUint(c.Param("seller_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid seller ID")
+		return
+	}
+
+	profile, err := h.sellerService.GetPublicProfile(uint(sellerID))
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
+		return
+	}
+
+	events, err := h.eventService.GetUpcomingEventsBySeller(profile.ID, publicProfileEventLimit)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	profile.UpcomingEvents = events
+
+	utils.SuccessResponse(c, "Seller profile retrieved successfully", profile)
 }
 
 func (h *SellerHandler) GetProfile(c *gin.Context) {
@@ -26,7 +70,7 @@ func (h *SellerHandler) GetProfile(c *gin.Context) {
 
 	profile, err := h.sellerService.GetProfile(currentUser.UserID)
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -40,7 +84,7 @@ func (h *SellerHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	var req services.UpdateProfileRequest
+	var req services.UpdateSellerProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.BadRequestResponse(c, "Invalid request data")
 		return
@@ -48,7 +92,7 @@ func (h *SellerHandler) UpdateProfile(c *gin.Context) {
 
 	profile, err := h.sellerService.UpdateProfile(currentUser.UserID, &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -70,7 +114,7 @@ func (h *SellerHandler) ChangePassword(c *gin.Context) {
 
 	err = h.sellerService.ChangePassword(currentUser.UserID, &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -91,7 +135,7 @@ func (h *SellerHandler) GetStats(c *gin.Context) {
 
 	stats, err := h.sellerService.GetSellerStats(currentUser.UserID)
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -107,7 +151,7 @@ func (h *SellerHandler) DeleteAccount(c *gin.Context) {
 
 	err = h.sellerService.DeleteAccount(currentUser.UserID)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
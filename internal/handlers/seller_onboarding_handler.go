@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/models"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type SellerOnboardingHandler struct {
+	onboardingService *services.SellerOnboardingService
+}
+
+func NewSellerOnboardingHandler(onboardingService *services.SellerOnboardingService) *SellerOnboardingHandler {
+	return &SellerOnboardingHandler{onboardingService: onboardingService}
+}
+
+// RegisterRoutes adds seller onboarding: document upload and submission by
+// sellers, and the admin review queue
+func (h *SellerOnboardingHandler) RegisterRoutes(routes *Routes) {
+	routes.Seller.GET("/onboarding", h.GetOnboarding)
+	routes.SellerUploads.POST("/onboarding/documents", h.UploadDocument) // Multipart "file" and "kind"
+	routes.Seller.DELETE("/onboarding/documents/:document_id", h.DeleteDocument)
+	routes.Seller.POST("/onboarding/submit", h.SubmitOnboarding)
+
+	routes.Admin.GET("/seller-onboarding", h.ListOnboardings) // ?status=2 (awaiting review) by default
+	routes.Admin.GET("/sellers/:seller_id/onboarding", h.GetSellerOnboarding)
+	routes.Admin.GET("/sellers/:seller_id/onboarding/documents/:document_id", h.DownloadDocument)
+	routes.Admin.POST("/sellers/:seller_id/onboarding/approve", h.ApproveSeller)
+	routes.Admin.POST("/sellers/:seller_id/onboarding/reject", h.RejectSeller)
+}
+
+func (h *SellerOnboardingHandler) GetOnboarding(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	onboarding, err := h.onboardingService.Get(currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Onboarding retrieved successfully", onboarding)
+}
+
+func (h *SellerOnboardingHandler) UploadDocument(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid request data")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	document, err := h.onboardingService.UploadDocument(currentUser.UserID, c.PostForm("kind"), header.Filename, data)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Document uploaded successfully", document)
+}
+
+func (h *SellerOnboardingHandler) DeleteDocument(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	documentID, err := strconv.ParseUint(c.Param("document_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid document ID")
+		return
+	}
+
+	if err := h.onboardingService.DeleteDocument(currentUser.UserID, uint(documentID)); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Document deleted successfully", nil)
+}
+
+func (h *SellerOnboardingHandler) SubmitOnboarding(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	onboarding, err := h.onboardingService.Submit(currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Onboarding submitted for review", onboarding)
+}
+
+func (h *SellerOnboardingHandler) ListOnboardings(c *gin.Context) {
+	status, err := strconv.Atoi(c.DefaultQuery("status", strconv.Itoa(int(models.SellerOnboardingPendingReview))))
+	if err != nil || status < int(models.SellerOnboardingIncomplete) || status > int(models.SellerOnboardingRejected) {
+		utils.BadRequestResponse(c, "Invalid status")
+		return
+	}
+
+	page, limit, offset := pageParams(c, 20)
+	onboardings, total, err := h.onboardingService.List(models.SellerOnboardingStatus(status), limit, offset)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Seller onboardings retrieved successfully", onboardings, utils.CalculatePagination(page, limit, total))
+}
+
+func (h *SellerOnboardingHandler) GetSellerOnboarding(c *gin.Context) {
+	sellerID, err := strconv.ParseUint(c.Param("seller_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid seller ID")
+		return
+	}
+
+	onboarding, err := h.onboardingService.Get(uint(sellerID))
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Onboarding retrieved successfully", onboarding)
+}
+
+func (h *SellerOnboardingHandler) DownloadDocument(c *gin.Context) {
+	sellerID, err := strconv.ParseUint(c.Param("seller_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid seller ID")
+		return
+	}
+	documentID, err := strconv.ParseUint(c.Param("document_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid document ID")
+		return
+	}
+
+	document, err := h.onboardingService.GetDocument(uint(sellerID), uint(documentID))
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"seller-%d-document-%d\"", document.SellerID, document.ID))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, document.ContentType, document.Data)
+}
+
+func (h *SellerOnboardingHandler) ApproveSeller(c *gin.Context) {
+	h.review(c, true)
+}
+
+func (h *SellerOnboardingHandler) RejectSeller(c *gin.Context) {
+	h.review(c, false)
+}
+
+func (h *SellerOnboardingHandler) review(c *gin.Context, approve bool) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	sellerID, err := strconv.ParseUint(c.Param("seller_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid seller ID")
+		return
+	}
+
+	var req services.ReviewSellerRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	var onboarding *services.SellerOnboarding
+	if approve {
+		onboarding, err = h.onboardingService.Approve(uint(sellerID), &req, currentUser.UserID, c.ClientIP())
+	} else {
+		onboarding, err = h.onboardingService.Reject(uint(sellerID), &req, currentUser.UserID, c.ClientIP())
+	}
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Seller onboarding reviewed successfully", onboarding)
+}
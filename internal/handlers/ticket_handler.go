@@ -1,15 +1,22 @@
 package handlers
 
 import (
+	"net/http"
 	"strconv"
+	"strings"
 
 	"eticketing/internal/middleware"
 	"eticketing/internal/models"
+	"eticketing/internal/repositories"
 	"eticketing/internal/services"
 	"eticketing/internal/utils"
 	"github.com/gin-gonic/gin"
 )
 
+// DeviceFingerprintHeader optionally carries the app's device fingerprint
+// on purchases, kept with the payment for fraud investigations
+const DeviceFingerprintHeader = "X-Device-Fingerprint"
+
 type TicketHandler struct {
 	ticketService *services.TicketService
 }
@@ -18,6 +25,37 @@ func NewTicketHandler(ticketService *services.TicketService) *TicketHandler {
 	return &TicketHandler{ticketService: ticketService}
 }
 
+// RegisterRoutes adds ticket listings, purchases, orders awaiting payment and ticket management for sellers
+func (h *TicketHandler) RegisterRoutes(routes *Routes) {
+	if routes.Version == APIV1 {
+		routes.Events.GET("/:event_id/tickets", routes.Deprecated("/events/:event_id/grouped-tickets"), h.GetEventTickets) // Legacy endpoint
+	}
+	routes.Events.GET("/:event_id/grouped-tickets", h.GetAvailableGroupedEventTickets) // New grouped endpoint
+
+	tickets := routes.Protected.Group("/tickets")
+	{
+		if routes.Version == APIV1 {
+			tickets.POST("/purchase", routes.Deprecated("/tickets/purchase-group"), routes.RequireQueueAdmission(), h.PurchaseTicket) // Legacy individual ticket purchase
+		}
+		tickets.POST("/purchase-group", routes.RequireQueueAdmission(), h.PurchaseTicketFromGroup) // New grouped ticket purchase
+		tickets.GET("/my", h.GetMyTickets)                                                         // ?when=upcoming|past, ?event_id=, ?used=, paginated
+	}
+
+	// Orders awaiting payment after a declined charge
+	orders := routes.Protected.Group("/orders")
+	{
+		orders.GET("/:id", h.GetOrder)
+		orders.POST("/:id/retry-payment", h.RetryOrderPayment)
+	}
+
+	routes.Seller.POST("/tickets", h.CreateTickets)
+	routes.Seller.PUT("/events/:event_id/tickets", h.UpdateTickets)
+	routes.Seller.DELETE("/events/:event_id/tickets", h.DeleteTickets)
+	routes.Seller.PATCH("/ticket-groups/:group_id/quantity", h.AdjustTicketQuantity)
+	routes.Seller.POST("/ticket-groups/:group_id/move", h.MoveTicketGroup) // Unsold tickets into another sale of the event
+	routes.Seller.GET("/events/:event_id/grouped-tickets", h.GetGroupedEventTickets)
+}
+
 func (h *TicketHandler) CreateTickets(c *gin.Context) {
 	currentUser, err := middleware.GetCurrentUser(c)
 	if err != nil {
@@ -38,7 +76,7 @@ func (h *TicketHandler) CreateTickets(c *gin.Context) {
 
 	err = h.ticketService.CreateTickets(&req, currentUser.UserID)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -75,7 +113,7 @@ func (h *TicketHandler) UpdateTickets(c *gin.Context) {
 
 	err = h.ticketService.UpdateTickets(uint(eventID), currentUser.UserID, reqBody.OldTicket, &reqBody.Updates)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -108,13 +146,79 @@ func (h *TicketHandler) DeleteTickets(c *gin.Context) {
 
 	err = h.ticketService.DeleteTickets(uint(eventID), currentUser.UserID, groupedTicket)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
 	utils.SuccessResponse(c, "Tickets deleted successfully", nil)
 }
 
+func (h *TicketHandler) AdjustTicketQuantity(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	if currentUser.UserType != models.UserTypeSeller {
+		utils.ForbiddenResponse(c, "Only sellers can change ticket quantities")
+		return
+	}
+
+	groupID, err := strconv.ParseUint(c.Param("group_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid ticket group ID")
+		return
+	}
+
+	var req services.AdjustTicketQuantityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	quantity, err := h.ticketService.AdjustTicketQuantity(uint(groupID), currentUser.UserID, &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Ticket quantity updated successfully", quantity)
+}
+
+func (h *TicketHandler) MoveTicketGroup(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	if currentUser.UserType != models.UserTypeSeller {
+		utils.ForbiddenResponse(c, "Only sellers can move tickets")
+		return
+	}
+
+	groupID, err := strconv.ParseUint(c.Param("group_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid ticket group ID")
+		return
+	}
+
+	var req services.MoveTicketGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	move, err := h.ticketService.MoveTicketGroup(uint(groupID), currentUser.UserID, &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Tickets moved successfully", move)
+}
+
 func (h *TicketHandler) GetGroupedEventTickets(c *gin.Context) {
 	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
 	if err != nil {
@@ -122,13 +226,14 @@ func (h *TicketHandler) GetGroupedEventTickets(c *gin.Context) {
 		return
 	}
 
-	tickets, err := h.ticketService.GetGroupedTicketsByEvent(uint(eventID))
+	page, limit, offset := pageParams(c, 50)
+	tickets, total, err := h.ticketService.GetGroupedTicketsByEvent(uint(eventID), limit, offset)
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.SuccessResponse(c, "Grouped tickets retrieved successfully", tickets)
+	utils.PaginatedSuccessResponse(c, "Grouped tickets retrieved successfully", tickets, utils.CalculatePagination(page, limit, total))
 }
 
 // Public endpoints
@@ -140,13 +245,14 @@ func (h *TicketHandler) GetAvailableGroupedEventTickets(c *gin.Context) {
 		return
 	}
 
-	tickets, err := h.ticketService.GetAvailableGroupedTicketsByEvent(uint(eventID))
+	page, limit, offset := pageParams(c, 50)
+	tickets, total, err := h.ticketService.GetAvailableGroupedTicketsByEvent(uint(eventID), limit, offset)
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.SuccessResponse(c, "Available grouped tickets retrieved successfully", tickets)
+	utils.PaginatedSuccessResponse(c, "Available grouped tickets retrieved successfully", tickets, utils.CalculatePagination(page, limit, total))
 }
 
 // User endpoints - Ticket purchasing
@@ -165,15 +271,85 @@ func (h *TicketHandler) PurchaseTicketFromGroup(c *gin.Context) {
 	}
 
 	req.UserID = currentUser.UserID
-	response, err := h.ticketService.PurchaseTicketFromGroup(&req)
+	req.ClientIP, req.DeviceFingerprint = clientInfo(c)
+	response, err := h.ticketService.PurchaseTicketFromGroup(c.Request.Context(), &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if response.PendingOrder != nil && response.PendingOrder.Status == models.OrderStatusUnderReview {
+		utils.AcceptedResponse(c, "Your order is being reviewed; tickets are held and nothing was charged", response)
+		return
+	}
+	if response.PendingOrder != nil {
+		utils.PaymentRequiredResponse(c, "Payment failed: "+response.PaymentInfo.Message+"; tickets are held for a retry", response)
 		return
 	}
 
 	utils.CreatedResponse(c, "Tickets purchased successfully", response)
 }
 
+func (h *TicketHandler) RetryOrderPayment(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	orderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID")
+		return
+	}
+
+	var req services.RetryPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	req.ClientIP, req.DeviceFingerprint = clientInfo(c)
+	response, err := h.ticketService.RetryOrderPayment(c.Request.Context(), uint(orderID), currentUser.UserID, &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if response.PendingOrder != nil && response.PendingOrder.Status == models.OrderStatusUnderReview {
+		utils.AcceptedResponse(c, "Your order is being reviewed; tickets are held and nothing was charged", response)
+		return
+	}
+	if response.PendingOrder != nil {
+		utils.PaymentRequiredResponse(c, "Payment failed: "+response.PaymentInfo.Message, response)
+		return
+	}
+
+	utils.SuccessResponse(c, "Tickets purchased successfully", response)
+}
+
+func (h *TicketHandler) GetOrder(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	orderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID")
+		return
+	}
+
+	order, err := h.ticketService.GetOrder(uint(orderID), currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Order retrieved successfully", order)
+}
+
 func (h *TicketHandler) PurchaseTicket(c *gin.Context) {
 	currentUser, err := middleware.GetCurrentUser(c)
 	if err != nil {
@@ -188,9 +364,10 @@ func (h *TicketHandler) PurchaseTicket(c *gin.Context) {
 	}
 
 	req.UserID = currentUser.UserID
-	response, err := h.ticketService.PurchaseTicket(&req)
+	req.ClientIP, req.DeviceFingerprint = clientInfo(c)
+	response, err := h.ticketService.PurchaseTicket(c.Request.Context(), &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -204,17 +381,44 @@ func (h *TicketHandler) GetMyTickets(c *gin.Context) {
 		return
 	}
 
-	tickets, err := h.ticketService.GetUserTickets(currentUser.UserID)
+	filter := repositories.OwnedTicketFilter{When: c.Query("when")}
+	if value := c.Query("event_id"); value != "" {
+		eventID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			utils.BadRequestResponse(c, "Invalid event ID")
+			return
+		}
+		filter.EventID = uint(eventID)
+	}
+	if value := c.Query("used"); value != "" {
+		used, err := strconv.ParseBool(value)
+		if err != nil {
+			utils.BadRequestResponse(c, "Invalid used value")
+			return
+		}
+		filter.Used = &used
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+
+	tickets, total, err := h.ticketService.GetUserTickets(currentUser.UserID, filter, limit, (page-1)*limit)
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.SuccessResponse(c, "Tickets retrieved successfully", tickets)
+	utils.PaginatedSuccessResponse(c, "Tickets retrieved successfully", tickets, utils.CalculatePagination(page, limit, total))
 }
 
 func (h *TicketHandler) GetEventTickets(c *gin.Context) {
-	eventID, err := strconv.ParseUint(c.Param("eventId"), 10, 32)
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
 	if err != nil {
 		utils.BadRequestResponse(c, "Invalid event ID")
 		return
@@ -222,7 +426,7 @@ func (h *TicketHandler) GetEventTickets(c *gin.Context) {
 
 	tickets, err := h.ticketService.GetEventTickets(uint(eventID))
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -245,9 +449,19 @@ func (h *TicketHandler) TransferTicket(c *gin.Context) {
 	req.FromUserID = currentUser.UserID
 	err = h.ticketService.TransferTicket(&req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
 	utils.SuccessResponse(c, "Ticket transfer initiated successfully", nil)
 }
+
+// clientInfo returns where a purchase is being made from. Fingerprints
+// longer than the column are cut short rather than refused.
+func clientInfo(c *gin.Context) (ip, fingerprint string) {
+	fingerprint = strings.TrimSpace(c.GetHeader(DeviceFingerprintHeader))
+	if len(fingerprint) > 128 {
+		fingerprint = fingerprint[:128]
+	}
+	return c.ClientIP(), fingerprint
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type TicketImportHandler struct {
+	ticketImportService *services.TicketImportService
+}
+
+func NewTicketImportHandler(ticketImportService *services.TicketImportService) *TicketImportHandler {
+	return &TicketImportHandler{ticketImportService: ticketImportService}
+}
+
+// RegisterRoutes adds CSV ticket imports
+func (h *TicketImportHandler) RegisterRoutes(routes *Routes) {
+	routes.SellerUploads.POST("/events/:event_id/tickets/import", h.ImportTickets) // CSV; ?dry_run=true only validates
+	routes.Seller.GET("/events/:event_id/tickets/imports", h.GetImports)
+	routes.Seller.GET("/events/:event_id/tickets/imports/:import_id", h.GetImport)
+}
+
+// ImportTickets accepts a CSV of ticket groups, either as a multipart "file"
+// field or as the raw request body. With ?dry_run=true the file is only
+// validated; otherwise it is queued and created in the background.
+func (h *TicketImportHandler) ImportTickets(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	fileName, data, err := readImportFile(c)
+	if err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	if c.Query("dry_run") == "true" {
+		preview, err := h.ticketImportService.Preview(uint(eventID), currentUser.UserID, data)
+		if err != nil {
+			utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+			return
+		}
+		utils.SuccessResponse(c, "Ticket import validated", preview)
+		return
+	}
+
+	ticketImport, preview, err := h.ticketImportService.Import(uint(eventID), currentUser.UserID, fileName, data)
+	if err != nil {
+		if preview != nil {
+			utils.ServiceErrorResponseWithData(c, http.StatusBadRequest, err, preview)
+			return
+		}
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.AcceptedResponse(c, "Ticket import queued", gin.H{
+		"import":  ticketImport,
+		"preview": preview,
+	})
+}
+
+func (h *TicketImportHandler) GetImports(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	imports, err := h.ticketImportService.ListImports(uint(eventID), currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Ticket imports retrieved successfully", imports)
+}
+
+// GetImport returns an import's status and its per-row report
+func (h *TicketImportHandler) GetImport(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.ParseUint(c.Param("event_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid event ID")
+		return
+	}
+
+	importID, err := strconv.ParseUint(c.Param("import_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid import ID")
+		return
+	}
+
+	ticketImport, err := h.ticketImportService.GetImport(uint(eventID), currentUser.UserID, uint(importID))
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Ticket import retrieved successfully", ticketImport)
+}
+
+func readImportFile(c *gin.Context) (string, []byte, error) {
+	if !strings.HasPrefix(c.ContentType(), "multipart/") {
+		data, err := io.ReadAll(c.Request.Body)
+		return "", data, err
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return "", nil, err
+	}
+	file, err := header.Open()
+	if err != nil {
+		return "", nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	return header.Filename, data, err
+}
@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"errors"
+	"io"
+	"net/http"
+	"path"
 	"strconv"
 
 	"eticketing/internal/middleware"
+	"eticketing/internal/models"
 	"eticketing/internal/services"
 	"eticketing/internal/utils"
 	"github.com/gin-gonic/gin"
@@ -17,6 +22,29 @@ func NewTransferHandler(transferService *services.TransferService) *TransferHand
 	return &TransferHandler{transferService: transferService}
 }
 
+// RegisterRoutes adds transfers and claim links
+func (h *TransferHandler) RegisterRoutes(routes *Routes) {
+	// Claim link preview (authenticated by the token in the link)
+	routes.Public.GET("/claim-links/:token", h.GetClaimLink)
+
+	tickets := routes.Protected.Group("/tickets")
+	{
+		tickets.POST("/transfer", routes.RequireFeature(models.FeatureTransfers), h.InitiateTransfer)
+		tickets.POST("/:ticket_id/claim-link", routes.RequireFeature(models.FeatureTransfers), h.CreateClaimLink)
+		tickets.DELETE("/:ticket_id/claim-link", h.RevokeClaimLink)
+	}
+
+	transfers := routes.Protected.Group("/transfers")
+	{
+		transfers.GET("/active", h.GetActiveTransfers)
+		transfers.POST("/:transfer_id/accept", routes.RequireFeature(models.FeatureTransfers), h.AcceptTransfer)
+		transfers.POST("/:transfer_id/reject", h.RejectTransfer)
+		transfers.GET("/history", h.GetTransferHistory)
+		transfers.GET("/rejected", h.GetRejectedTransfers) // Declined and cancelled
+	}
+	routes.Protected.POST("/claim-links/:token/claim", routes.RequireFeature(models.FeatureTransfers), h.ClaimTicket)
+}
+
 func (h *TransferHandler) InitiateTransfer(c *gin.Context) {
 	currentUser, err := middleware.GetCurrentUser(c)
 	if err != nil {
@@ -33,7 +61,7 @@ func (h *TransferHandler) InitiateTransfer(c *gin.Context) {
 	req.FromUserID = currentUser.UserID
 	response, err := h.transferService.InitiateTransfer(&req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -47,13 +75,14 @@ func (h *TransferHandler) GetActiveTransfers(c *gin.Context) {
 		return
 	}
 
-	transfers, err := h.transferService.GetActiveTransfers(currentUser.UserID)
+	page, limit, offset := pageParams(c, 20)
+	transfers, total, err := h.transferService.GetActiveTransfers(currentUser.UserID, limit, offset)
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.SuccessResponse(c, "Active transfers retrieved successfully", transfers)
+	utils.PaginatedSuccessResponse(c, "Active transfers retrieved successfully", transfers, utils.CalculatePagination(page, limit, total))
 }
 
 func (h *TransferHandler) AcceptTransfer(c *gin.Context) {
@@ -69,13 +98,20 @@ func (h *TransferHandler) AcceptTransfer(c *gin.Context) {
 		return
 	}
 
-	err = h.transferService.AcceptTransfer(uint(transferID), currentUser.UserID)
+	// The body is only needed to pay a transfer fee
+	var req services.AcceptTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	response, err := h.transferService.AcceptTransfer(c.Request.Context(), uint(transferID), currentUser.UserID, &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
-	utils.SuccessResponse(c, "Transfer accepted successfully", nil)
+	utils.SuccessResponse(c, "Transfer accepted successfully", response)
 }
 
 func (h *TransferHandler) RejectTransfer(c *gin.Context) {
@@ -93,13 +129,32 @@ func (h *TransferHandler) RejectTransfer(c *gin.Context) {
 
 	err = h.transferService.RejectTransfer(uint(transferID), currentUser.UserID)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
 	utils.SuccessResponse(c, "Transfer rejected successfully", nil)
 }
 
+// GetRejectedTransfers lists declined and cancelled transfers the user sent
+// or received
+func (h *TransferHandler) GetRejectedTransfers(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	page, limit, offset := pageParams(c, 20)
+	transfers, total, err := h.transferService.GetRejectedTransfers(currentUser.UserID, limit, offset)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Rejected transfers retrieved successfully", transfers, utils.CalculatePagination(page, limit, total))
+}
+
 func (h *TransferHandler) GetTransferHistory(c *gin.Context) {
 	currentUser, err := middleware.GetCurrentUser(c)
 	if err != nil {
@@ -107,11 +162,94 @@ func (h *TransferHandler) GetTransferHistory(c *gin.Context) {
 		return
 	}
 
-	history, err := h.transferService.GetTransferHistory(currentUser.UserID)
+	page, limit, offset := pageParams(c, 20)
+	history, total, err := h.transferService.GetTransferHistory(currentUser.UserID, limit, offset)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Transfer history retrieved successfully", history, utils.CalculatePagination(page, limit, total))
+}
+
+// CreateClaimLink opens a one-time claim link for the user's ticket,
+// replacing any link it already had
+func (h *TransferHandler) CreateClaimLink(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	ticketID, err := strconv.ParseUint(c.Param("ticket_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid ticket ID")
+		return
+	}
+
+	// Links open at /claim-links/:token under the API version of this
+	// route, which is /tickets/:ticket_id/claim-link
+	linksPath := path.Join(path.Dir(path.Dir(path.Dir(c.FullPath()))), "claim-links")
+	link, err := h.transferService.CreateClaimLink(uint(ticketID), currentUser.UserID, linksPath)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Claim link created successfully", link)
+}
+
+func (h *TransferHandler) RevokeClaimLink(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	ticketID, err := strconv.ParseUint(c.Param("ticket_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid ticket ID")
+		return
+	}
+
+	if err := h.transferService.RevokeClaimLink(uint(ticketID), currentUser.UserID); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Claim link revoked successfully", nil)
+}
+
+// GetClaimLink shows what a claim link offers; the token is the credential
+func (h *TransferHandler) GetClaimLink(c *gin.Context) {
+	preview, err := h.transferService.GetClaimLink(c.Param("token"))
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Claim link retrieved successfully", preview)
+}
+
+func (h *TransferHandler) ClaimTicket(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	// The body is only needed to pay a transfer fee
+	var req services.AcceptTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	response, err := h.transferService.ClaimTicket(c.Request.Context(), c.Param("token"), currentUser.UserID, &req)
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
-	utils.SuccessResponse(c, "Transfer history retrieved successfully", history)
+	utils.SuccessResponse(c, "Ticket claimed successfully", response)
 }
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"net/http"
+
 	"eticketing/internal/middleware"
 	"eticketing/internal/services"
 	"eticketing/internal/utils"
@@ -8,11 +10,25 @@ import (
 )
 
 type UserHandler struct {
-	userService *services.UserService
+	userService            *services.UserService
+	purchaseHistoryService *services.PurchaseHistoryService
+}
+
+func NewUserHandler(userService *services.UserService, purchaseHistoryService *services.PurchaseHistoryService) *UserHandler {
+	return &UserHandler{userService: userService, purchaseHistoryService: purchaseHistoryService}
 }
 
-func NewUserHandler(userService *services.UserService) *UserHandler {
-	return &UserHandler{userService: userService}
+// RegisterRoutes adds the account endpoints for users
+func (h *UserHandler) RegisterRoutes(routes *Routes) {
+	users := routes.Protected.Group("/users")
+	{
+		users.GET("/profile", h.GetProfile)
+		users.PUT("/profile", h.UpdateProfile)
+		users.PUT("/password", h.ChangePassword)
+		users.DELETE("/profile", h.DeleteAccount)
+		users.POST("/profile/restore", h.CancelAccountDeletion)
+		users.GET("/purchases", h.GetPurchases) // Orders grouped by event
+	}
 }
 
 func (h *UserHandler) GetProfile(c *gin.Context) {
@@ -24,7 +40,7 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 
 	profile, err := h.userService.GetProfile(currentUser.UserID)
 	if err != nil {
-		utils.InternalErrorResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -46,7 +62,7 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 
 	profile, err := h.userService.UpdateProfile(currentUser.UserID, &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -68,7 +84,7 @@ func (h *UserHandler) ChangePassword(c *gin.Context) {
 
 	err = h.userService.ChangePassword(currentUser.UserID, &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -82,11 +98,44 @@ func (h *UserHandler) DeleteAccount(c *gin.Context) {
 		return
 	}
 
-	err = h.userService.DeleteAccount(currentUser.UserID)
+	deletion, err := h.userService.DeleteAccount(currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Account deletion scheduled", deletion)
+}
+
+func (h *UserHandler) CancelAccountDeletion(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	if err := h.userService.CancelAccountDeletion(currentUser.UserID); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Account deletion cancelled", nil)
+}
+
+// GetPurchases returns the user's orders grouped by event, with their
+// tickets and refund states
+func (h *UserHandler) GetPurchases(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	purchases, err := h.purchaseHistoryService.GetPurchases(currentUser.UserID)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	utils.SuccessResponse(c, "Account deleted successfully", nil)
+	utils.SuccessResponse(c, "Purchases retrieved successfully", purchases)
 }
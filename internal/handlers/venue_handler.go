@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"eticketing/internal/middleware"
+	"eticketing/internal/services"
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type VenueHandler struct {
+	venueService *services.VenueService
+	eventService *services.EventService
+}
+
+func NewVenueHandler(venueService *services.VenueService, eventService *services.EventService) *VenueHandler {
+	return &VenueHandler{
+		venueService: venueService,
+		eventService: eventService,
+	}
+}
+
+// RegisterRoutes adds venue browsing and the seller's venues
+func (h *VenueHandler) RegisterRoutes(routes *Routes) {
+	venues := routes.Public.Group("/venues")
+	{
+		venues.GET("", h.GetVenues)
+		venues.GET("/:venue_id", h.GetVenue)
+		venues.GET("/:venue_id/events", h.GetVenueEvents)
+	}
+
+	routes.Seller.POST("/venues", h.CreateVenue)
+	routes.Seller.GET("/venues", h.GetMyVenues)
+	routes.Seller.PUT("/venues/:venue_id", h.UpdateVenue)
+	routes.Seller.DELETE("/venues/:venue_id", h.DeleteVenue)
+}
+
+func (h *VenueHandler) GetVenues(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	venues, err := h.venueService.GetVenues(page, limit)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Venues retrieved successfully", venues)
+}
+
+func (h *VenueHandler) GetVenue(c *gin.Context) {
+	venueID, err := strconv.ParseUint(c.Param("venue_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid venue ID")
+		return
+	}
+
+	venue, err := h.venueService.GetVenue(uint(venueID))
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Venue retrieved successfully", venue)
+}
+
+func (h *VenueHandler) GetVenueEvents(c *gin.Context) {
+	venueID, err := strconv.ParseUint(c.Param("venue_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid venue ID")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	events, err := h.eventService.GetEventsByVenue(uint(venueID), page, limit)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusNotFound, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Venue events retrieved successfully", events)
+}
+
+func (h *VenueHandler) CreateVenue(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	var req services.VenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	venue, err := h.venueService.CreateVenue(currentUser.UserID, &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Venue created successfully", venue)
+}
+
+func (h *VenueHandler) GetMyVenues(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	venues, err := h.venueService.GetSellerVenues(currentUser.UserID)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Venues retrieved successfully", venues)
+}
+
+func (h *VenueHandler) UpdateVenue(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	venueID, err := strconv.ParseUint(c.Param("venue_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid venue ID")
+		return
+	}
+
+	var req services.VenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingErrorResponse(c, err)
+		return
+	}
+
+	venue, err := h.venueService.UpdateVenue(uint(venueID), currentUser.UserID, &req)
+	if err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Venue updated successfully", venue)
+}
+
+func (h *VenueHandler) DeleteVenue(c *gin.Context) {
+	currentUser, err := middleware.GetCurrentUser(c)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Unauthorized")
+		return
+	}
+
+	venueID, err := strconv.ParseUint(c.Param("venue_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid venue ID")
+		return
+	}
+
+	if err := h.venueService.DeleteVenue(uint(venueID), currentUser.UserID); err != nil {
+		utils.ServiceErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Venue deleted successfully", nil)
+}
@@ -0,0 +1,108 @@
+// Package i18n translates API messages. Messages are written in English
+// throughout the code base and the English text is the lookup key, so a
+// message without a translation is simply returned in English.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Locale string
+
+const (
+	English   Locale = "en"
+	Ukrainian Locale = "uk"
+
+	Default = English
+)
+
+// ContextKey is the gin context key the request's locale is stored under
+const ContextKey = "locale"
+
+// catalogs map lowercased English messages to their translation. English
+// needs no catalog.
+var catalogs = map[Locale]map[string]string{
+	Ukrainian: ukrainian,
+}
+
+// Supported reports whether locale is one the API speaks
+func Supported(locale Locale) bool {
+	return locale == English || catalogs[locale] != nil
+}
+
+// Parse maps a language tag such as "uk-UA" to a supported locale
+func Parse(tag string) (Locale, bool) {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	locale := Locale(base)
+	return locale, Supported(locale)
+}
+
+// Negotiate picks the supported locale the Accept-Language header prefers
+// most, or Default when it names none
+func Negotiate(header string) Locale {
+	type candidate struct {
+		locale Locale
+		q      float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		locale, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{locale: locale, q: q})
+		}
+	}
+	if len(candidates) == 0 {
+		return Default
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	return candidates[0].locale
+}
+
+// T translates message into locale. With args, message is a format string
+// and is looked up before the args are applied.
+func T(locale Locale, message string, args ...interface{}) string {
+	if translated, ok := catalogs[locale][strings.ToLower(message)]; ok {
+		message = translated
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// Translate translates message when it has an entry for locale. Messages
+// that end in a detail after a colon ("payment failed: card expired") are
+// translated up to the colon and keep the detail as is.
+func Translate(locale Locale, message string) string {
+	catalog := catalogs[locale]
+	if catalog == nil {
+		return message
+	}
+	if translated, ok := catalog[strings.ToLower(message)]; ok {
+		return translated
+	}
+	if prefix, detail, found := strings.Cut(message, ": "); found {
+		if translated, ok := catalog[strings.ToLower(prefix)]; ok {
+			return translated + ": " + detail
+		}
+	}
+	return message
+}
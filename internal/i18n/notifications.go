@@ -0,0 +1,77 @@
+package i18n
+
+// subjects holds the subject line of each notification, by outbox topic
+var subjects = map[string]map[Locale]string{
+	"ticket.sold": {
+		English:   "Your tickets are confirmed",
+		Ukrainian: "Ваші квитки підтверджено",
+	},
+	"order.refunded": {
+		English:   "Your order has been refunded",
+		Ukrainian: "Кошти за замовлення повернено",
+	},
+	"ticket.gifted": {
+		English:   "You have received tickets",
+		Ukrainian: "Вам подарували квитки",
+	},
+	"transfer.accepted": {
+		English:   "Your ticket transfer was accepted",
+		Ukrainian: "Передачу вашого квитка прийнято",
+	},
+	"event.approved": {
+		English:   "Your event has been approved",
+		Ukrainian: "Вашу подію схвалено",
+	},
+	"event.rejected": {
+		English:   "Your event has been rejected",
+		Ukrainian: "Вашу подію відхилено",
+	},
+	"event.suspended": {
+		English:   "Your event has been suspended",
+		Ukrainian: "Вашу подію призупинено",
+	},
+	"event.published": {
+		English:   "Your event is now published",
+		Ukrainian: "Вашу подію опубліковано",
+	},
+	"sale.created": {
+		English:   "A new sale has been scheduled",
+		Ukrainian: "Заплановано новий продаж",
+	},
+	"dispute.updated": {
+		English:   "A payment dispute has been updated",
+		Ukrainian: "Спір щодо платежу оновлено",
+	},
+	"follower.notice": {
+		English:   "News from an organizer you follow",
+		Ukrainian: "Новини від організатора, за яким ви стежите",
+	},
+	"lottery.result": {
+		English:   "Your lottery sale result",
+		Ukrainian: "Результат розіграшу квитків",
+	},
+	"order.reviewed": {
+		English:   "An update on your ticket order",
+		Ukrainian: "Оновлення щодо вашого замовлення квитків",
+	},
+	"seller.reviewed": {
+		English:   "An update on your organizer account",
+		Ukrainian: "Оновлення щодо вашого акаунта організатора",
+	},
+	"data_export.ready": {
+		English:   "Your data export is ready",
+		Ukrainian: "Експорт ваших даних готовий",
+	},
+}
+
+// Subject returns the subject line of the notification sent for topic,
+// falling back to English and then to the topic itself
+func Subject(locale Locale, topic string) string {
+	if subject, ok := subjects[topic][locale]; ok {
+		return subject
+	}
+	if subject, ok := subjects[topic][Default]; ok {
+		return subject
+	}
+	return topic
+}
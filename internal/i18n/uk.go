@@ -0,0 +1,147 @@
+package i18n
+
+// ukrainian is keyed by the lowercased English message. Format strings keep
+// their verbs in the same order as the English text.
+var ukrainian = map[string]string{
+	// Common request errors
+	"unauthorized":                          "Потрібна авторизація",
+	"invalid request data":                  "Некоректні дані запиту",
+	"validation failed":                     "Помилка валідації",
+	"admin access required":                 "Потрібен доступ адміністратора",
+	"only sellers can access this endpoint": "Доступ лише для продавців",
+	"invalid event id":                      "Некоректний ID події",
+	"invalid sale id":                       "Некоректний ID продажу",
+	"invalid seller id":                     "Некоректний ID продавця",
+	"invalid order id":                      "Некоректний ID замовлення",
+	"invalid venue id":                      "Некоректний ID майданчика",
+	"invalid payment method id":             "Некоректний ID способу оплати",
+	"invalid payment id":                    "Некоректний ID платежу",
+	"invalid ticket id":                     "Некоректний ID квитка",
+	"invalid ticket group id":               "Некоректний ID групи квитків",
+	"invalid transfer id":                   "Некоректний ID передачі",
+	"invalid dispute id":                    "Некоректний ID спору",
+	"invalid webhook id":                    "Некоректний ID вебхука",
+	"invalid user type":                     "Некоректний тип користувача",
+	"invalid token type":                    "Некоректний тип токена",
+	"request body exceeds %d bytes":         "Тіло запиту перевищує %d байт",
+	"rate limit exceeded":                   "Перевищено ліміт запитів",
+	"too many requests":                     "Забагато запитів",
+	"this feature is currently unavailable": "Ця функція зараз недоступна",
+	"configuration was not reloaded":        "Конфігурацію не перезавантажено",
+
+	"the service is down for maintenance. please try again later.": "Сервіс на технічному обслуговуванні. Спробуйте пізніше.",
+	"the updated terms must be accepted first":                     "Спершу прийміть оновлені умови",
+
+	// Not found
+	"event not found":            "Подію не знайдено",
+	"seller not found":           "Продавця не знайдено",
+	"user not found":             "Користувача не знайдено",
+	"admin not found":            "Адміністратора не знайдено",
+	"sale not found":             "Продаж не знайдено",
+	"venue not found":            "Майданчик не знайдено",
+	"ticket not found":           "Квиток не знайдено",
+	"purchased ticket not found": "Придбаний квиток не знайдено",
+	"ticket group not found":     "Групу квитків не знайдено",
+	"payment not found":          "Платіж не знайдено",
+	"payment method not found":   "Спосіб оплати не знайдено",
+	"order not found":            "Замовлення не знайдено",
+	"transfer not found":         "Передачу не знайдено",
+	"dispute not found":          "Спір не знайдено",
+	"event member not found":     "Учасника події не знайдено",
+	"dead letter not found":      "Повідомлення не знайдено",
+	"data export not found":      "Експорт даних не знайдено",
+	"feature flag not found":     "Прапорець функції не знайдено",
+
+	// Auth and accounts
+	"invalid email or password":       "Неправильна електронна пошта або пароль",
+	"username already taken":          "Ім'я користувача вже зайняте",
+	"current password is incorrect":   "Поточний пароль неправильний",
+	"username validation failed":      "Некоректне ім'я користувача",
+	"password validation failed":      "Некоректний пароль",
+	"user registered successfully":    "Користувача зареєстровано",
+	"login successful":                "Вхід виконано",
+	"token refreshed successfully":    "Токен оновлено",
+	"profile retrieved successfully":  "Профіль отримано",
+	"profile updated successfully":    "Профіль оновлено",
+	"password changed successfully":   "Пароль змінено",
+	"failed to update profile":        "Не вдалося оновити профіль",
+	"failed to update password":       "Не вдалося змінити пароль",
+	"failed to generate access token": "Не вдалося створити токен доступу",
+
+	// Events and sales
+	"events retrieved successfully":                             "Події отримано",
+	"event retrieved successfully":                              "Подію отримано",
+	"event created successfully":                                "Подію створено",
+	"event updated successfully":                                "Подію оновлено",
+	"event deleted successfully":                                "Подію видалено",
+	"event date must be in the future":                          "Дата події має бути в майбутньому",
+	"publication time must be before the event date":            "Час публікації має передувати даті події",
+	"sales retrieved successfully":                              "Продажі отримано",
+	"sale created successfully":                                 "Продаж створено",
+	"sale updated successfully":                                 "Продаж оновлено",
+	"sale deleted successfully":                                 "Продаж видалено",
+	"sale does not belong to this event":                        "Продаж не належить до цієї події",
+	"sale dates overlap with existing sale":                     "Дати продажу перетинаються з наявним продажем",
+	"sale start date must be in the future":                     "Дата початку продажу має бути в майбутньому",
+	"sale end date must be after start date":                    "Дата завершення продажу має бути пізніше дати початку",
+	"venues retrieved successfully":                             "Майданчики отримано",
+	"failed to retrieve events":                                 "Не вдалося отримати події",
+	"failed to retrieve sales":                                  "Не вдалося отримати продажі",
+	"unauthorized to manage members of this event":              "Немає прав керувати учасниками цієї події",
+	"unauthorized to change tickets for this event":             "Немає прав змінювати квитки цієї події",
+	"seller profile retrieved successfully":                     "Профіль продавця отримано",
+	"organization orders retrieved successfully":                "Замовлення організацій отримано",
+	"order is not awaiting approval":                            "Замовлення не очікує на схвалення",
+	"invoice payment is only available for organization orders": "Оплата за рахунком доступна лише для замовлень організацій",
+
+	// Tickets and purchases
+	"tickets created successfully":                             "Квитки створено",
+	"tickets updated successfully":                             "Квитки оновлено",
+	"tickets deleted successfully":                             "Квитки видалено",
+	"tickets retrieved successfully":                           "Квитки отримано",
+	"ticket purchased successfully":                            "Квиток придбано",
+	"tickets purchased successfully":                           "Квитки придбано",
+	"ticket checked in successfully":                           "Квиток зареєстровано на вході",
+	"ticket import queued":                                     "Імпорт квитків поставлено в чергу",
+	"ticket import validated":                                  "Файл імпорту перевірено",
+	"no unsold tickets found matching criteria":                "Непроданих квитків за цими критеріями не знайдено",
+	"not enough tickets available":                             "Недостатньо доступних квитків",
+	"sale allocation for this ticket group is sold out":        "Квоту продажу для цієї групи квитків вичерпано",
+	"ticket is not available":                                  "Квиток недоступний",
+	"sale is not currently active":                             "Продаж зараз не активний",
+	"event is not approved for ticket sales":                   "Подію не схвалено для продажу квитків",
+	"order is not awaiting payment":                            "Замовлення не очікує на оплату",
+	"payment window has expired and the tickets were released": "Час на оплату минув, квитки звільнено",
+	"this ticket has been invalidated":                         "Цей квиток анульовано",
+	"ticket has been invalidated":                              "Квиток анульовано",
+	"ticket has already been checked in":                       "Квиток уже зареєстровано на вході",
+	"ticket does not belong to this event":                     "Квиток не належить до цієї події",
+	"failed to retrieve tickets":                               "Не вдалося отримати квитки",
+	"failed to prepare ticket code":                            "Не вдалося підготувати код квитка",
+	"failed to generate pdf":                                   "Не вдалося створити PDF",
+
+	// Transfers
+	"transfer initiated successfully":        "Передачу ініційовано",
+	"ticket transfer initiated successfully": "Передачу квитка ініційовано",
+	"transfer accepted successfully":         "Передачу прийнято",
+	"transfer rejected successfully":         "Передачу відхилено",
+	"transfer is not in pending status":      "Передача не очікує на відповідь",
+	"unauthorized to transfer this ticket":   "Немає прав передавати цей квиток",
+	"cannot transfer used ticket":            "Не можна передати використаний квиток",
+	"cannot transfer invalidated ticket":     "Не можна передати анульований квиток",
+	"failed to load transfers":               "Не вдалося завантажити передачі",
+
+	// Payments
+	"payment failed":                  "Оплата не пройшла",
+	"unknown payment reference":       "Невідомий ідентифікатор платежу",
+	"failed to update payment status": "Не вдалося оновити статус платежу",
+	"failed to create payment record": "Не вдалося створити запис платежу",
+
+	// Validation errors, see utils.BindingErrors
+	"is required":         "є обов'язковим",
+	"is invalid":          "має некоректне значення",
+	"must be at least %s": "має бути не менше %s",
+	"must be at most %s":  "має бути не більше %s",
+	"must be after %s":    "має бути пізніше %s",
+	"must be a unix timestamp in seconds between 1970 and 2100": "має бути Unix-часом у секундах між 1970 і 2100 роками",
+}
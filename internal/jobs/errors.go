@@ -0,0 +1,8 @@
+package jobs
+
+import "errors"
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+	ErrJobRunning  = errors.New("job is already running")
+)
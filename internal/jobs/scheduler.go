@@ -0,0 +1,192 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// JobFunc is a unit of periodic work. It should return promptly once ctx is cancelled.
+type JobFunc func(ctx context.Context) error
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      JobFunc
+	stats    JobStats
+}
+
+// JobStats is a snapshot of a job's run history, exposed to admins
+type JobStats struct {
+	Name           string `json:"name"`
+	Interval       string `json:"interval"`
+	Runs           int64  `json:"runs"`
+	Failures       int64  `json:"failures"`
+	Running        bool   `json:"running"`
+	LastRunAt      int64  `json:"last_run_at"` // Unix timestamp, 0 if never run
+	LastDurationMs int64  `json:"last_duration_ms"`
+	LastError      string `json:"last_error,omitempty"`
+}
+
+type Scheduler struct {
+	jobs    map[string]*job
+	mutex   sync.RWMutex
+	wg      sync.WaitGroup
+	cancel  context.CancelFunc
+	started bool
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		jobs: make(map[string]*job),
+	}
+}
+
+// Register adds a job that runs every interval. A non-positive interval
+// disables the job, which lets deployments switch jobs off through config.
+func (s *Scheduler) Register(name string, interval time.Duration, run JobFunc) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.started {
+		log.Printf("jobs: cannot register %q after scheduler start", name)
+		return
+	}
+
+	if interval <= 0 {
+		log.Printf("jobs: %q disabled (interval %s)", name, interval)
+		return
+	}
+
+	s.jobs[name] = &job{
+		name:     name,
+		interval: interval,
+		run:      run,
+		stats:    JobStats{Name: name, Interval: interval.String()},
+	}
+}
+
+// Start launches one goroutine per registered job
+func (s *Scheduler) Start() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, j)
+	}
+
+	log.Printf("jobs: scheduler started with %d job(s)", len(s.jobs))
+}
+
+// Shutdown stops scheduling new runs and waits for in-flight runs to finish,
+// or until ctx expires.
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.mutex.Lock()
+	if !s.started {
+		s.mutex.Unlock()
+		return nil
+	}
+	s.cancel()
+	s.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Println("jobs: scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RunNow triggers a job immediately, outside its regular schedule
+func (s *Scheduler) RunNow(ctx context.Context, name string) error {
+	s.mutex.RLock()
+	j, exists := s.jobs[name]
+	s.mutex.RUnlock()
+
+	if !exists {
+		return ErrJobNotFound
+	}
+
+	return s.execute(ctx, j)
+}
+
+// Stats returns a snapshot of every registered job, sorted by name
+func (s *Scheduler) Stats() []JobStats {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	stats := make([]JobStats, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		stats = append(stats, j.stats)
+	}
+
+	sort.Slice(stats, func(a, b int) bool {
+		return stats[a].Name < stats[b].Name
+	})
+
+	return stats
+}
+
+func (s *Scheduler) loop(ctx context.Context, j *job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.execute(ctx, j); err != nil {
+				log.Printf("jobs: %q failed: %v", j.name, err)
+			}
+		}
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, j *job) error {
+	s.mutex.Lock()
+	if j.stats.Running {
+		s.mutex.Unlock()
+		return ErrJobRunning
+	}
+	j.stats.Running = true
+	s.mutex.Unlock()
+
+	start := time.Now()
+	err := j.run(ctx)
+	duration := time.Since(start)
+
+	s.mutex.Lock()
+	j.stats.Running = false
+	j.stats.Runs++
+	j.stats.LastRunAt = start.Unix()
+	j.stats.LastDurationMs = duration.Milliseconds()
+	j.stats.LastError = ""
+	if err != nil {
+		j.stats.Failures++
+		j.stats.LastError = err.Error()
+	}
+	s.mutex.Unlock()
+
+	return err
+}
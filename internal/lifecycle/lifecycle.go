@@ -0,0 +1,53 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+type component struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// Manager stops the application's long-running components on shutdown, one
+// after another in the order they were added, under a single deadline. Add
+// them so that each one only depends on those added after it: the HTTP
+// server first, the database last.
+type Manager struct {
+	timeout    time.Duration
+	components []component
+}
+
+func NewManager(timeout time.Duration) *Manager {
+	return &Manager{timeout: timeout}
+}
+
+// Add registers a component to stop on shutdown
+func (m *Manager) Add(name string, stop func(ctx context.Context) error) {
+	m.components = append(m.components, component{name: name, stop: stop})
+}
+
+// Shutdown stops every component. A component that fails or runs past the
+// deadline is logged and the rest are still stopped, so the database is
+// closed even when a job hangs.
+func (m *Manager) Shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	var errs []error
+	for _, c := range m.components {
+		start := time.Now()
+		if err := c.stop(ctx); err != nil {
+			log.Printf("lifecycle: %s did not stop cleanly: %v", c.name, err)
+			errs = append(errs, fmt.Errorf("%s: %w", c.name, err))
+			continue
+		}
+		log.Printf("lifecycle: %s stopped in %s", c.name, time.Since(start).Round(time.Millisecond))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditMiddleware records every change made by an authenticated caller, and
+// every request at all made with an impersonation token. It runs after
+// AuthMiddleware; a failure to record is logged rather than failing the
+// request.
+func AuditMiddleware(auditRepo repositories.AuditLogRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		claims, err := GetCurrentUser(c)
+		if err != nil {
+			return
+		}
+
+		impersonated := claims.ImpersonatorID != 0
+		if c.Request.Method == http.MethodGet && !impersonated {
+			return
+		}
+
+		entry := &models.AuditLog{
+			ActorID:    claims.UserID,
+			ActorType:  claims.UserType,
+			Action:     c.Request.Method + " " + c.FullPath(),
+			Path:       c.Request.URL.Path,
+			StatusCode: c.Writer.Status(),
+			IP:         c.ClientIP(),
+			CreatedAt:  time.Now().Unix(),
+		}
+		if impersonated {
+			impersonatorID := claims.ImpersonatorID
+			entry.ImpersonatorID = &impersonatorID
+		}
+
+		if err := auditRepo.Create(entry); err != nil {
+			log.Printf("audit: failed to record %s by %d: %v", entry.Action, entry.ActorID, err)
+		}
+	}
+}
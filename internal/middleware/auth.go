@@ -56,6 +56,20 @@ func AuthMiddleware(jwtManager *utils.JWTManager) gin.HandlerFunc {
 	}
 }
 
+// OptionalAuthMiddleware identifies the caller when they send a valid
+// access token and lets the request through either way
+func OptionalAuthMiddleware(jwtManager *utils.JWTManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fields := strings.Fields(c.GetHeader(AuthorizationHeaderKey))
+		if len(fields) >= 2 && strings.ToLower(fields[0]) == AuthorizationTypeBearer {
+			if payload, err := jwtManager.ValidateToken(fields[1]); err == nil && payload.Type == "access" {
+				c.Set(AuthorizationPayloadKey, payload)
+			}
+		}
+		c.Next()
+	}
+}
+
 func RequireRole(roles ...models.UserType) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		payload, exists := c.Get(AuthorizationPayloadKey)
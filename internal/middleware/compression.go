@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CompressionMiddleware gzips JSON and text responses of at least minBytes
+// for clients that accept it. Other content types, such as ticket PDFs and
+// export archives, are already compressed and pass through unchanged. A
+// minBytes of 0 turns compression off.
+//
+// Brotli would need a third-party encoder, so only gzip is offered.
+func CompressionMiddleware(minBytes int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if minBytes <= 0 || !acceptsGzip(c.GetHeader("Accept-Encoding")) {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{ResponseWriter: c.Writer, minBytes: minBytes}
+		c.Writer = writer
+		defer func() {
+			writer.Close()
+			c.Writer = writer.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// compressWriter buffers the start of the body until it is known to be big
+// enough to compress, then streams the rest through gzip
+type compressWriter struct {
+	gin.ResponseWriter
+	minBytes int
+	buf      bytes.Buffer
+	gz       *gzip.Writer
+	decided  bool
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		return w.write(data)
+	}
+
+	w.buf.Write(data)
+	if w.buf.Len() >= w.minBytes {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends what has been written so far, so streamed responses keep
+// streaming; they are compressed whatever their size
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide(true)
+	}
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Close writes out a response that stayed under the size threshold and
+// finishes the gzip stream of one that did not
+func (w *compressWriter) Close() error {
+	if !w.decided {
+		if err := w.decide(false); err != nil {
+			return err
+		}
+	}
+	if w.gz != nil {
+		return w.gz.Close()
+	}
+	return nil
+}
+
+// decide picks plain or gzip output once and writes the buffered start of
+// the body through it
+func (w *compressWriter) decide(compress bool) error {
+	w.decided = true
+
+	header := w.Header()
+	if !compressible(header.Get("Content-Type")) || header.Get("Content-Encoding") != "" {
+		return w.flushBuffer()
+	}
+	header.Add("Vary", "Accept-Encoding")
+
+	status := w.Status()
+	if !compress || status == http.StatusNoContent || status == http.StatusNotModified {
+		return w.flushBuffer()
+	}
+
+	header.Set("Content-Encoding", "gzip")
+	header.Del("Content-Length")
+	// The body no longer matches a strong tag byte for byte
+	if etag := header.Get("ETag"); strings.HasPrefix(etag, `"`) {
+		header.Set("ETag", "W/"+etag)
+	}
+	w.gz = gzip.NewWriter(w.ResponseWriter)
+	return w.flushBuffer()
+}
+
+func (w *compressWriter) flushBuffer() error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := w.write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+func (w *compressWriter) write(data []byte) (int, error) {
+	if w.gz != nil {
+		return w.gz.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasPrefix(mediaType, "text/")
+}
+
+// acceptsGzip reports whether Accept-Encoding allows gzip, honouring q=0
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		return q > 0
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// FeatureChecker is satisfied by services.FeatureFlagService
+type FeatureChecker interface {
+	Require(key string, userID uint) error
+}
+
+// RequireFeature refuses the request with 403 FEATURE_DISABLED while the
+// feature flag key is off for the caller
+func RequireFeature(features FeatureChecker, key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var userID uint
+		if claims, err := GetCurrentUser(c); err == nil {
+			userID = claims.UserID
+		}
+
+		if err := features.Require(key, userID); err != nil {
+			utils.ServiceErrorResponse(c, http.StatusForbidden, err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"eticketing/internal/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleMiddleware picks the response language from Accept-Language and
+// announces it in Content-Language. Response helpers in utils translate
+// their messages into it.
+func LocaleMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.Negotiate(c.GetHeader("Accept-Language"))
+		c.Set(i18n.ContextKey, locale)
+		c.Header("Content-Language", string(locale))
+		c.Header("Vary", "Accept-Language")
+
+		c.Next()
+	}
+}
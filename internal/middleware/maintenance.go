@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+const defaultMaintenanceMessage = "The service is down for maintenance. Please try again later."
+
+// MaintenanceChecker is satisfied by services.MaintenanceService
+type MaintenanceChecker interface {
+	Current() models.MaintenanceMode
+}
+
+// MaintenanceMiddleware answers 503 while maintenance mode is on. Health
+// checks, signing in and admins (including their impersonation sessions)
+// are let through so the switch can be turned off again, and so are
+// announcements; in read-only mode so are all reads.
+func MaintenanceMiddleware(maintenance MaintenanceChecker, jwtManager *utils.JWTManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		mode := maintenance.Current()
+		if !mode.Enabled || maintenanceExempt(c, &mode, jwtManager) {
+			c.Next()
+			return
+		}
+
+		if mode.EndsAt != nil {
+			if wait := time.Until(time.Unix(*mode.EndsAt, 0)); wait > 0 {
+				c.Header("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			}
+		}
+
+		message := mode.Message
+		if message == "" {
+			message = defaultMaintenanceMessage
+		}
+		err := apperrors.Unavailable(message).WithCode(apperrors.CodeMaintenance)
+		utils.ServiceErrorResponseWithData(c, http.StatusServiceUnavailable, err, gin.H{
+			"read_only": mode.ReadOnly,
+			"ends_at":   mode.EndsAt,
+		})
+		c.Abort()
+	}
+}
+
+func maintenanceExempt(c *gin.Context, mode *models.MaintenanceMode, jwtManager *utils.JWTManager) bool {
+	path := c.Request.URL.Path
+	if strings.HasPrefix(path, "/health") {
+		return true
+	}
+	if strings.HasSuffix(path, "/auth/login") || strings.HasSuffix(path, "/auth/refresh") {
+		return true
+	}
+	if strings.HasSuffix(path, "/announcements") && c.Request.Method == http.MethodGet {
+		return true // Banners explaining the maintenance
+	}
+	if mode.ReadOnly {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return true
+		}
+	}
+
+	// The token is checked again by AuthMiddleware on protected routes
+	fields := strings.Fields(c.GetHeader(AuthorizationHeaderKey))
+	if len(fields) < 2 || strings.ToLower(fields[0]) != AuthorizationTypeBearer {
+		return false
+	}
+	claims, err := jwtManager.ValidateToken(fields[1])
+	if err != nil || claims.Type != "access" {
+		return false
+	}
+	return claims.UserType == models.UserTypeAdmin || claims.ImpersonatorID != 0
+}
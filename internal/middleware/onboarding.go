@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// SellerApproval is satisfied by services.SellerOnboardingService
+type SellerApproval interface {
+	RequireApproved(sellerID uint) error
+}
+
+// RequireApprovedSeller refuses the request with 403 SELLER_NOT_APPROVED
+// until an admin has approved the calling seller's onboarding
+func RequireApprovedSeller(approval SellerApproval) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := GetCurrentUser(c)
+		if err != nil {
+			utils.UnauthorizedResponse(c, "Unauthorized")
+			c.Abort()
+			return
+		}
+
+		if err := approval.RequireApproved(claims.UserID); err != nil {
+			utils.ServiceErrorResponse(c, http.StatusForbidden, err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"eticketing/internal/models"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// PolicyChecker is satisfied by services.PolicyService
+type PolicyChecker interface {
+	Pending(userID uint, userType models.UserType) ([]models.PolicyDocument, error)
+}
+
+// RequirePolicyAcceptance refuses changes with 403 POLICY_NOT_ACCEPTED
+// from accounts that haven't accepted the terms of service and privacy
+// policy in force. Reads stay open, as do accepting, closing the account
+// and exporting its data; admins and their impersonation sessions aren't
+// asked. When the check fails the request is let through.
+func RequirePolicyAcceptance(policies PolicyChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := GetCurrentUser(c)
+		if err != nil || policyExempt(c, claims) {
+			c.Next()
+			return
+		}
+
+		pending, err := policies.Pending(claims.UserID, claims.UserType)
+		if err != nil {
+			log.Printf("policies: failed to check acceptance for user %d: %v", claims.UserID, err)
+			c.Next()
+			return
+		}
+		if len(pending) == 0 {
+			c.Next()
+			return
+		}
+
+		required := make([]gin.H, 0, len(pending))
+		for _, document := range pending {
+			required = append(required, gin.H{
+				"kind":    document.Kind,
+				"version": document.Version,
+				"title":   document.Title,
+				"summary": document.Summary,
+			})
+		}
+		err = apperrors.Forbidden("the updated terms must be accepted first").WithCode(apperrors.CodePolicyNotAccepted)
+		utils.ServiceErrorResponseWithData(c, http.StatusForbidden, err, gin.H{"policies": required})
+		c.Abort()
+	}
+}
+
+func policyExempt(c *gin.Context, claims *utils.JWTClaims) bool {
+	if claims.UserType == models.UserTypeAdmin || claims.ImpersonatorID != 0 {
+		return true
+	}
+
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	path := c.Request.URL.Path
+	return strings.HasSuffix(path, "/policies/accept") ||
+		strings.HasSuffix(path, "/users/profile") && c.Request.Method == http.MethodDelete ||
+		strings.HasSuffix(path, "/users/profile/restore") ||
+		strings.HasSuffix(path, "/users/data-export")
+}
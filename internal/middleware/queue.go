@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// QueueTokenHeader carries the buyer's waiting room token on purchases
+const QueueTokenHeader = "X-Queue-Token"
+
+// QueueGate is satisfied by services.QueueService
+type QueueGate interface {
+	AdmitPurchase(eventID, ticketID, userID uint, token string) (bool, error)
+	CompletePurchase(token string) error
+}
+
+// RequireQueueAdmission refuses purchases of events with a waiting room
+// unless they carry an admitted queue token, and closes the admission
+// once the purchase went through. The event is read from the request body,
+// which is left in place for the handler.
+func RequireQueueAdmission(gate QueueGate) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := GetCurrentUser(c)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			utils.BadRequestResponse(c, "Invalid request data")
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var target struct {
+			EventID  uint `json:"event_id"`
+			TicketID uint `json:"ticket_id"`
+		}
+		if json.Unmarshal(body, &target) != nil || (target.EventID == 0 && target.TicketID == 0) {
+			c.Next() // The handler rejects the malformed request
+			return
+		}
+
+		token := c.GetHeader(QueueTokenHeader)
+		queued, err := gate.AdmitPurchase(target.EventID, target.TicketID, claims.UserID, token)
+		if err != nil {
+			utils.ServiceErrorResponse(c, http.StatusForbidden, err)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+
+		// A declined charge still holds the tickets for a retry, which
+		// doesn't go through the queue again
+		status := c.Writer.Status()
+		if queued && (status < http.StatusBadRequest || status == http.StatusPaymentRequired) {
+			if err := gate.CompletePurchase(token); err != nil {
+				log.Printf("queue: failed to close admission: %v", err)
+			}
+		}
+	}
+}
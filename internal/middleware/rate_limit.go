@@ -5,6 +5,8 @@ import (
 	"sync"
 	"time"
 
+	"eticketing/internal/i18n"
+	"eticketing/internal/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -13,6 +15,8 @@ type RateLimiter struct {
 	mutex    sync.RWMutex
 	rate     time.Duration
 	capacity int
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
 type Visitor struct {
@@ -26,6 +30,7 @@ func NewRateLimiter(rate time.Duration, capacity int) *RateLimiter {
 		visitors: make(map[string]*Visitor),
 		rate:     rate,
 		capacity: capacity,
+		stop:     make(chan struct{}),
 	}
 
 	// Clean up old visitors every minute
@@ -34,6 +39,23 @@ func NewRateLimiter(rate time.Duration, capacity int) *RateLimiter {
 	return rl
 }
 
+// SetLimits changes the burst and refill interval. Clients keep the tokens
+// they have, capped at the new burst.
+func (rl *RateLimiter) SetLimits(rate time.Duration, capacity int) {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	rl.rate = rate
+	rl.capacity = capacity
+}
+
+func (rl *RateLimiter) limits() (time.Duration, int) {
+	rl.mutex.RLock()
+	defer rl.mutex.RUnlock()
+
+	return rl.rate, rl.capacity
+}
+
 func (rl *RateLimiter) getVisitor(ip string) *Visitor {
 	rl.mutex.Lock()
 	defer rl.mutex.Unlock()
@@ -51,16 +73,17 @@ func (rl *RateLimiter) getVisitor(ip string) *Visitor {
 }
 
 func (rl *RateLimiter) allow(ip string) bool {
+	rate, capacity := rl.limits()
 	visitor := rl.getVisitor(ip)
 	visitor.mutex.Lock()
 	defer visitor.mutex.Unlock()
 
 	now := time.Now()
-	tokensToAdd := int(now.Sub(visitor.lastSeen) / rl.rate)
+	tokensToAdd := int(now.Sub(visitor.lastSeen) / rate)
 	visitor.tokens += tokensToAdd
 
-	if visitor.tokens > rl.capacity {
-		visitor.tokens = rl.capacity
+	if visitor.tokens > capacity {
+		visitor.tokens = capacity
 	}
 
 	visitor.lastSeen = now
@@ -73,9 +96,24 @@ func (rl *RateLimiter) allow(ip string) bool {
 	return false
 }
 
+// Stop ends the visitor cleanup goroutine
+func (rl *RateLimiter) Stop() {
+	rl.stopOnce.Do(func() {
+		close(rl.stop)
+	})
+}
+
 func (rl *RateLimiter) cleanupVisitors() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
 	for {
-		time.Sleep(time.Minute)
+		select {
+		case <-rl.stop:
+			return
+		case <-ticker.C:
+		}
+
 		rl.mutex.Lock()
 
 		for ip, visitor := range rl.visitors {
@@ -88,17 +126,17 @@ func (rl *RateLimiter) cleanupVisitors() {
 	}
 }
 
-func RateLimitMiddleware(rate time.Duration, capacity int) gin.HandlerFunc {
-	limiter := NewRateLimiter(rate, capacity)
-
+// Middleware rejects requests from clients that ran out of tokens
+func (rl *RateLimiter) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		clientIP := c.ClientIP()
 
-		if !limiter.allow(clientIP) {
+		if !rl.allow(clientIP) {
+			locale := utils.Locale(c)
 			c.JSON(http.StatusTooManyRequests, gin.H{
 				"success": false,
-				"message": "Rate limit exceeded",
-				"error":   "Too many requests",
+				"message": i18n.T(locale, "Rate limit exceeded"),
+				"error":   i18n.T(locale, "Too many requests"),
 			})
 			c.Abort()
 			return
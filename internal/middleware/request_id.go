@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"eticketing/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMiddleware tags every request with an ID, reusing the caller's
+// X-Request-ID when it sends one, and echoes it back in the response. The ID
+// travels in the request context so queries run with it can be traced back.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 64 {
+			requestID, _ = utils.RandomHex(8)
+		}
+
+		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(utils.WithRequestID(c.Request.Context(), requestID))
+
+		c.Next()
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+type AnalyticsEventType string
+
+const (
+	AnalyticsEventView     AnalyticsEventType = "view"     // Event page viewed
+	AnalyticsEventHold     AnalyticsEventType = "hold"     // Tickets added to the cart
+	AnalyticsEventPurchase AnalyticsEventType = "purchase" // Recorded by the server from ticket.sold
+)
+
+// AnalyticsEvent is one anonymized step a visitor took towards buying
+// tickets. No user ID, IP or session ID is kept: VisitorID is a keyed hash
+// that only tells whether two steps were taken by the same visitor.
+type AnalyticsEvent struct {
+	ID         uint               `json:"id" gorm:"primaryKey"`
+	Type       AnalyticsEventType `json:"type" gorm:"size:16;not null;index:idx_analytics_event_type_time"`
+	EventID    uint               `json:"event_id" gorm:"not null;index:idx_analytics_event_type_time"`
+	SaleID     *uint              `json:"sale_id"`
+	VisitorID  string             `json:"visitor_id" gorm:"size:32;not null"`
+	Quantity   int                `json:"quantity" gorm:"default:1"`
+	Source     string             `json:"source" gorm:"size:32"`                                           // Client-reported channel, e.g. web or ios
+	SourceID   *uint              `json:"-" gorm:"uniqueIndex"`                                            // Outbox message a server-recorded step came from
+	OccurredAt int64              `json:"occurred_at" gorm:"not null;index:idx_analytics_event_type_time"` // Unix timestamp
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
+}
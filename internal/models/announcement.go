@@ -0,0 +1,35 @@
+package models
+
+type AnnouncementAudience int
+
+const (
+	AnnouncementAudienceEveryone AnnouncementAudience = 1 // Signed-out visitors included
+	AnnouncementAudienceUsers    AnnouncementAudience = 2
+	AnnouncementAudienceSellers  AnnouncementAudience = 3
+)
+
+// How prominently frontends show an announcement
+const (
+	AnnouncementLevelInfo     = "info"
+	AnnouncementLevelWarning  = "warning"
+	AnnouncementLevelCritical = "critical"
+)
+
+// Announcement is a banner frontends show to an audience between StartsAt
+// and EndsAt, such as a maintenance notice or a policy change
+type Announcement struct {
+	ID       uint                 `json:"id" gorm:"primaryKey"`
+	Title    string               `json:"title" gorm:"size:200;not null"`
+	Message  string               `json:"message" gorm:"type:text;not null"`
+	Level    string               `json:"level" gorm:"size:16;not null;default:info"`
+	Audience AnnouncementAudience `json:"audience" gorm:"not null;index"`
+	LinkURL  string               `json:"link_url,omitempty" gorm:"size:500"` // Where to read more
+
+	StartsAt int64  `json:"starts_at" gorm:"not null;index"` // Unix timestamp
+	EndsAt   *int64 `json:"ends_at" gorm:"index"`            // Shown until removed when nil
+
+	CreatedBy uint  `json:"created_by" gorm:"not null"`
+	UpdatedBy uint  `json:"updated_by"`
+	CreatedAt int64 `json:"created_at" gorm:"not null"`
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"`
+}
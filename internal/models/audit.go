@@ -0,0 +1,35 @@
+package models
+
+const (
+	AdminRoleRegular = 1
+	AdminRoleSuper   = 2
+)
+
+const (
+	AuditActionImpersonationStarted = "impersonation.start"
+	AuditActionSaleUpdatedByAdmin   = "sale.admin_update"
+	AuditActionSaleDeletedByAdmin   = "sale.admin_delete"
+	AuditActionConfigReloaded       = "config.reload"
+	AuditActionDenylistAdded        = "denylist.add"
+	AuditActionDenylistUpdated      = "denylist.update"
+	AuditActionDenylistRemoved      = "denylist.remove"
+	AuditActionPolicyPublished      = "policy.publish"
+	AuditActionSellerApproved       = "seller.onboarding_approve"
+	AuditActionSellerRejected       = "seller.onboarding_reject"
+)
+
+// AuditLog records who did what. Requests made with an impersonation token
+// carry the ID of the admin behind them.
+type AuditLog struct {
+	ID             uint     `json:"id" gorm:"primaryKey"`
+	ActorID        uint     `json:"actor_id" gorm:"not null;index:idx_audit_actor"`
+	ActorType      UserType `json:"actor_type" gorm:"not null;index:idx_audit_actor"`
+	ImpersonatorID *uint    `json:"impersonator_id" gorm:"index"` // Admin acting as the actor
+	Action         string   `json:"action" gorm:"not null"`       // Route, e.g. "POST /api/v1/tickets/purchase-group", or a named action
+	Path           string   `json:"path"`
+	Details        string   `json:"details,omitempty" gorm:"type:text"`
+	StatusCode     int      `json:"status_code"`
+	IP             string   `json:"ip"`
+	CreatedAt      int64    `json:"created_at" gorm:"not null;index"` // Unix timestamp
+	UpdatedAt      int64    `json:"updated_at" gorm:"not null"`       // Unix timestamp
+}
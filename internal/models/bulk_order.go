@@ -0,0 +1,39 @@
+package models
+
+type BulkOrderStatus int
+
+const (
+	BulkOrderStatusPendingApproval BulkOrderStatus = 1 // Waiting for the seller to approve
+	BulkOrderStatusInvoiced        BulkOrderStatus = 2 // Tickets issued, invoice not yet paid
+	BulkOrderStatusCompleted       BulkOrderStatus = 3
+	BulkOrderStatusRejected        BulkOrderStatus = 4
+	BulkOrderStatusFailed          BulkOrderStatus = 5
+)
+
+// BulkOrder is an organization purchase above the regular per-order limit.
+// The ticket group is identified the same way as in a group purchase.
+type BulkOrder struct {
+	ID               uint            `json:"id" gorm:"primaryKey"`
+	EventID          uint            `json:"event_id" gorm:"not null;index"`
+	SaleID           uint            `json:"sale_id" gorm:"not null"`
+	GroupID          uint            `json:"group_id"`
+	UserID           uint            `json:"user_id" gorm:"not null;index"`
+	OrganizationName string          `json:"organization_name" gorm:"not null"`
+	Price            float64         `json:"price" gorm:"not null"`
+	Type             TicketType      `json:"type" gorm:"not null"`
+	IsVip            bool            `json:"is_vip" gorm:"default:false"`
+	Title            string          `json:"title" gorm:"not null"`
+	Place            string          `json:"place" gorm:"not null"`
+	Quantity         int             `json:"quantity" gorm:"not null"`
+	PaymentMethod    PaymentType     `json:"payment_method" gorm:"not null"`
+	Status           BulkOrderStatus `json:"status" gorm:"default:1"`
+	PaymentID        *uint           `json:"payment_id"`
+	InvoiceNumber    string          `json:"invoice_number"`
+	TotalAmount      float64         `json:"total_amount" gorm:"default:0"`
+	Note             string          `json:"note" gorm:"type:text"` // Seller's rejection reason or failure details
+	CreatedAt        int64           `json:"created_at" gorm:"not null"`
+	UpdatedAt        int64           `json:"updated_at" gorm:"not null"`
+	DecidedAt        *int64          `json:"decided_at"`
+
+	Event Event `json:"-" gorm:"foreignKey:EventID"`
+}
@@ -0,0 +1,26 @@
+package models
+
+type DataExportStatus int
+
+const (
+	DataExportStatusPending DataExportStatus = 1
+	DataExportStatusReady   DataExportStatus = 2
+	DataExportStatusFailed  DataExportStatus = 3
+)
+
+// DataExport is a user's request for a copy of their personal data. The
+// archive is built in the background and can be downloaded with Token until
+// ExpiresAt.
+type DataExport struct {
+	ID          uint             `json:"id" gorm:"primaryKey"`
+	UserID      uint             `json:"user_id" gorm:"not null;index"`
+	Status      DataExportStatus `json:"status" gorm:"default:1"`
+	Token       *string          `json:"-" gorm:"uniqueIndex;size:64"` // Set once the archive is ready
+	Archive     []byte           `json:"-" gorm:"type:longblob"`       // ZIP of JSON files
+	Size        int              `json:"size"`
+	Error       string           `json:"error,omitempty" gorm:"type:text"`
+	CreatedAt   int64            `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt   int64            `json:"updated_at" gorm:"not null"` // Unix timestamp
+	CompletedAt *int64           `json:"completed_at"`
+	ExpiresAt   *int64           `json:"expires_at"`
+}
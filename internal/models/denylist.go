@@ -0,0 +1,23 @@
+package models
+
+type DenylistKind int
+
+const (
+	DenylistKindEmail   DenylistKind = 1
+	DenylistKindDomain  DenylistKind = 2 // Email domain, e.g. "mailinator.com"
+	DenylistKindCard    DenylistKind = 3 // Provider card token
+	DenylistKindIPRange DenylistKind = 4 // CIDR; a single IP is kept as a /32 or /128
+)
+
+// DenylistEntry bars an email, email domain, card or IP range from
+// registering and buying. Entries are managed by admins.
+type DenylistEntry struct {
+	ID        uint         `json:"id" gorm:"primaryKey"`
+	Kind      DenylistKind `json:"kind" gorm:"not null;uniqueIndex:idx_denylist_kind_value"`
+	Value     string       `json:"-" gorm:"size:255;not null;uniqueIndex:idx_denylist_kind_value"` // Lowercased email or domain, card token or CIDR
+	Label     string       `json:"label"`                                                          // How the entry is shown; a card's brand and last digits, never its token
+	Reason    string       `json:"reason,omitempty" gorm:"type:text"`
+	CreatedBy uint         `json:"created_by" gorm:"not null"`
+	CreatedAt int64        `json:"created_at" gorm:"not null"`
+	UpdatedAt int64        `json:"updated_at"`
+}
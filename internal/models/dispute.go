@@ -0,0 +1,31 @@
+package models
+
+type DisputeStatus int
+
+const (
+	DisputeStatusOpen        DisputeStatus = 1 // Opened by the cardholder's bank, awaiting a response
+	DisputeStatusUnderReview DisputeStatus = 2 // Evidence submitted, bank is deciding
+	DisputeStatusWon         DisputeStatus = 3
+	DisputeStatusLost        DisputeStatus = 4 // Funds returned to the customer; tickets invalidated
+)
+
+// Dispute is a chargeback raised against a customer payment, reported by the
+// payment provider's webhooks and tracked by admins until it is decided
+type Dispute struct {
+	ID                uint          `json:"id" gorm:"primaryKey"`
+	Provider          string        `json:"provider" gorm:"not null;uniqueIndex:idx_provider_dispute"`
+	ProviderDisputeID string        `json:"provider_dispute_id" gorm:"not null;uniqueIndex:idx_provider_dispute"`
+	PaymentID         uint          `json:"payment_id" gorm:"not null;index"` // Charge the dispute was raised against
+	UserID            uint          `json:"user_id" gorm:"not null"`
+	EventID           uint          `json:"event_id" gorm:"default:0"`
+	SellerID          uint          `json:"seller_id" gorm:"default:0;index"`
+	Amount            float64       `json:"amount" gorm:"not null"`
+	Reason            string        `json:"reason"`
+	Status            DisputeStatus `json:"status" gorm:"default:1;index"`
+	Note              string        `json:"note" gorm:"type:text"`      // Admin notes
+	AdjustmentID      *uint         `json:"adjustment_id"`              // Seller payment row reversing their revenue
+	OpenedAt          int64         `json:"opened_at" gorm:"not null"`  // Unix timestamp
+	CreatedAt         int64         `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt         int64         `json:"updated_at" gorm:"not null"` // Unix timestamp
+	ResolvedAt        *int64        `json:"resolved_at"`                // Unix timestamp, nullable
+}
@@ -7,30 +7,116 @@ const (
 	EventStatusApproved  EventStatus = 2
 	EventStatusRejected  EventStatus = 3
 	EventStatusCancelled EventStatus = 4
+	EventStatusSuspended EventStatus = 5 // Unpublished by an admin pending investigation
+)
+
+// TransferFeePayer is who pays an event's transfer fee when a ticket changes hands
+type TransferFeePayer int
+
+const (
+	TransferFeePayerRecipient TransferFeePayer = 1
+	TransferFeePayerSender    TransferFeePayer = 2
 )
 
 type Event struct {
-	ID          uint        `json:"id" gorm:"primaryKey"`
-	Title       string      `json:"title" gorm:"not null"`
-	Description string      `json:"description" gorm:"type:text"`
-	Date        int64       `json:"date" gorm:"not null"` // Unix timestamp
-	Address     string      `json:"address" gorm:"not null"`
-	Data        string      `json:"data" gorm:"type:json"` // Additional event data as JSON
-	SellerID    uint        `json:"seller_id" gorm:"not null"`
-	Status      EventStatus `json:"status" gorm:"default:1"`
+	ID          uint         `json:"id" gorm:"primaryKey"`
+	Title       string       `json:"title" gorm:"not null"`
+	Description string       `json:"description" gorm:"type:text"`
+	Date        int64        `json:"date" gorm:"not null"` // Unix timestamp
+	Address     string       `json:"address" gorm:"not null"`
+	Data        EventDetails `json:"data" gorm:"type:json"` // Lineup, age limit, door time and links
+	LegacyData  *string      `json:"-" gorm:"type:json"`    // Data written before it had a schema, kept as it was
+	SellerID    uint         `json:"seller_id" gorm:"not null"`
+	Status      EventStatus  `json:"status" gorm:"default:1"`
+	Capacity    int          `json:"capacity" gorm:"default:0"` // Most tickets the event may have; 0 = unlimited
+	VenueID     *uint        `json:"venue_id" gorm:"index"`
+	Latitude    *float64     `json:"latitude"` // Falls back to the venue's coordinates when unset
+	Longitude   *float64     `json:"longitude"`
+
+	// Approved events stay out of public listings until PublishAt; the
+	// event_publish job clears it once the event is published
+	PublishAt       *int64 `json:"publish_at" gorm:"index"`
+	NotifyFollowers bool   `json:"notify_followers" gorm:"default:false"` // Announce the event to followers when published
+
+	// Set when moderation wants an admin to review the title or description
+	Flagged     bool   `json:"flagged" gorm:"default:false"`
+	FlagReasons string `json:"flag_reasons" gorm:"type:text"`
+
+	// Organization orders; 0 falls back to the configured defaults
+	BulkMaxQuantity       int `json:"bulk_max_quantity" gorm:"default:0"`
+	BulkApprovalThreshold int `json:"bulk_approval_threshold" gorm:"default:0"`
+
+	// Flat fee charged when a ticket is transferred; 0 = free
+	TransferFee      float64          `json:"transfer_fee" gorm:"default:0"`
+	TransferFeePayer TransferFeePayer `json:"transfer_fee_payer" gorm:"default:1"`
+
+	// Door staff must match a photo ID against the name printed on the ticket
+	IDCheckRequired bool `json:"id_check_required" gorm:"default:false"`
+
+	// Customers can ask for a refund until this many hours before the
+	// event; 0 falls back to the configured default
+	RefundCutoffHours int `json:"refund_cutoff_hours" gorm:"default:0"`
+
+	// IANA zone the event takes place in; Date stays a Unix timestamp and
+	// is only shown in this zone on tickets, notifications and responses
+	Timezone string `json:"timezone" gorm:"size:64;default:'UTC'"`
+
+	// High-demand sales send buyers through a waiting room that admits
+	// QueueAdmitPerMinute of them a minute; 0 uses the configured rate
+	QueueEnabled        bool `json:"queue_enabled" gorm:"default:false"`
+	QueueAdmitPerMinute int  `json:"queue_admit_per_minute" gorm:"default:0"`
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
 
 	// Relationships
 	Seller  Seller   `json:"seller" gorm:"foreignKey:SellerID"`
+	Venue   *Venue   `json:"venue,omitempty" gorm:"foreignKey:VenueID"`
 	Tickets []Ticket `json:"tickets,omitempty" gorm:"foreignKey:EventID"`
 	Sales   []Sale   `json:"sales,omitempty" gorm:"foreignKey:EventID"`
 }
 
+type SaleMode int
+
+const (
+	SaleModeFirstCome SaleMode = 1 // Whoever buys first during the window
+	SaleModeLottery   SaleMode = 2 // Registered buyers are drawn at StartDate
+)
+
 type Sale struct {
-	ID        uint  `json:"id" gorm:"primaryKey"`
-	StartDate int64 `json:"start_date" gorm:"not null"` // Unix timestamp
-	EndDate   int64 `json:"end_date" gorm:"not null"`   // Unix timestamp
-	EventID   uint  `json:"event_id" gorm:"not null"`
+	ID        uint     `json:"id" gorm:"primaryKey"`
+	StartDate int64    `json:"start_date" gorm:"not null"` // Unix timestamp
+	EndDate   int64    `json:"end_date" gorm:"not null"`   // Unix timestamp
+	EventID   uint     `json:"event_id" gorm:"not null"`
+	Mode      SaleMode `json:"mode" gorm:"default:1"`
+
+	// Lottery sales take registrations until StartDate, then draw
+	// LotteryWinners of them, who alone may buy
+	LotteryWinners int    `json:"lottery_winners" gorm:"default:0"`
+	LotteryDrawnAt *int64 `json:"lottery_drawn_at"`
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
 
 	// Relationships
 	Event Event `json:"event" gorm:"foreignKey:EventID"`
 }
+
+// SaleAllocation caps how many tickets of one group a sale may sell,
+// e.g. only the first 100 standing tickets go at the early-bird price.
+// The group's details are a copy as of when the cap was set.
+type SaleAllocation struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	SaleID       uint       `json:"sale_id" gorm:"not null;uniqueIndex:idx_sale_allocations_sale_group,priority:1"`
+	GroupID      *uint      `json:"group_id" gorm:"uniqueIndex:idx_sale_allocations_sale_group,priority:2"` // Nil for caps set before groups had IDs that match no group
+	Price        float64    `json:"price" gorm:"not null"`
+	Type         TicketType `json:"type" gorm:"not null"`
+	IsVip        bool       `json:"is_vip" gorm:"default:false"`
+	Title        string     `json:"title" gorm:"not null"`
+	Place        string     `json:"place" gorm:"not null"`
+	MaxQuantity  int        `json:"max_quantity" gorm:"not null"`
+	SoldQuantity int        `json:"sold_quantity" gorm:"default:0"`
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
+}
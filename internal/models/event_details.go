@@ -0,0 +1,68 @@
+package models
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// EventDetails is the structured extra information shown on an event page.
+// It is stored as a JSON document in the event's data column.
+type EventDetails struct {
+	Lineup      []string    `json:"lineup,omitempty"`        // Performers or speakers, in billing order
+	AgeLimit    *int        `json:"age_limit,omitempty"`     // Minimum attendee age
+	DoorsOpenAt *int64      `json:"doors_open_at,omitempty"` // Unix timestamp
+	Links       []EventLink `json:"links,omitempty"`
+}
+
+// EventLink points to a page about the event elsewhere (artist site, stream...)
+type EventLink struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// Value stores the details as JSON
+func (d EventDetails) Value() (driver.Value, error) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads the details back. A blob that doesn't match the schema fails
+// the query rather than reading as empty details, which the next update
+// would store over it; the migration moves such blobs to LegacyData.
+func (d *EventDetails) Scan(value interface{}) error {
+	*d = EventDetails{}
+
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported event details type %T", value)
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+	return ParseEventDetails(data, d)
+}
+
+// ParseEventDetails decodes stored details, refusing fields the schema
+// doesn't have
+func ParseEventDetails(data []byte, d *EventDetails) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(d); err != nil {
+		*d = EventDetails{}
+		return fmt.Errorf("event details don't match their schema: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package models
+
+// EventPermission is what a co-managing seller may do with an event. They
+// combine as bit flags.
+type EventPermission int
+
+const (
+	EventPermissionEditEvent     EventPermission = 1 << iota // Update the event's details
+	EventPermissionManageTickets                             // Create, change and remove tickets, price tiers and sales; decide organization orders
+	EventPermissionViewSales                                 // See attendees, check them in and see organization orders
+)
+
+// EventMember lets a seller other than the owner help manage an event, e.g.
+// the officers of a student organization sharing one event
+type EventMember struct {
+	ID          uint            `json:"id" gorm:"primaryKey"`
+	EventID     uint            `json:"event_id" gorm:"not null;uniqueIndex:idx_event_member"`
+	SellerID    uint            `json:"seller_id" gorm:"not null;uniqueIndex:idx_event_member;index"`
+	Permissions EventPermission `json:"permissions" gorm:"not null"`
+	InvitedBy   uint            `json:"invited_by" gorm:"not null"`
+	CreatedAt   int64           `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt   int64           `json:"updated_at" gorm:"not null"` // Unix timestamp
+
+	// Relationships
+	Event  Event  `json:"-" gorm:"foreignKey:EventID"`
+	Seller Seller `json:"-" gorm:"foreignKey:SellerID"`
+}
@@ -0,0 +1,36 @@
+package models
+
+type EventReportStatus int
+
+const (
+	EventReportStatusOpen      EventReportStatus = 1
+	EventReportStatusDismissed EventReportStatus = 2 // Reviewed, nothing wrong found
+	EventReportStatusActioned  EventReportStatus = 3 // Reviewed and acted on, e.g. the event was unpublished
+)
+
+const (
+	EventReportReasonFraud         = "fraud"
+	EventReportReasonInappropriate = "inappropriate"
+	EventReportReasonMisleading    = "misleading"
+	EventReportReasonOther         = "other"
+)
+
+// EventReport is a flag raised by a user against an event, queued for admins
+// to review
+type EventReport struct {
+	ID           uint              `json:"id" gorm:"primaryKey"`
+	EventID      uint              `json:"event_id" gorm:"not null;index"`
+	ReporterID   uint              `json:"reporter_id" gorm:"not null;index:idx_report_reporter"`
+	ReporterType UserType          `json:"reporter_type" gorm:"not null;index:idx_report_reporter"`
+	Reason       string            `json:"reason" gorm:"not null"`
+	Details      string            `json:"details" gorm:"type:text"`
+	Status       EventReportStatus `json:"status" gorm:"default:1;index"`
+	Note         string            `json:"note" gorm:"type:text"`      // Admin notes
+	ResolvedBy   *uint             `json:"resolved_by"`                // Admin ID
+	CreatedAt    int64             `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt    int64             `json:"updated_at" gorm:"not null"` // Unix timestamp
+	ResolvedAt   *int64            `json:"resolved_at"`                // Unix timestamp, nullable
+
+	// Relationships
+	Event Event `json:"event" gorm:"foreignKey:EventID"`
+}
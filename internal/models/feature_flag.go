@@ -0,0 +1,31 @@
+package models
+
+// Feature flag keys the code checks. A key without a flag row is enabled,
+// so features stay on until an admin creates a flag to turn them off.
+const (
+	FeatureTransfers  = "transfers"   // Ticket transfers between users
+	FeatureGifts      = "gifts"       // Buying tickets for someone else by email
+	FeatureBulkOrders = "bulk_orders" // Organization orders above the per-order limit
+
+	FeatureDirectPayments = "direct_payments" // Standalone charges outside a ticket purchase
+)
+
+// FeatureFlag switches a capability on or off without a redeploy
+type FeatureFlag struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	Key         string `json:"key" gorm:"uniqueIndex;size:64;not null"`
+	Description string `json:"description" gorm:"type:text"`
+	Enabled     bool   `json:"enabled" gorm:"default:false"`
+
+	// Share of users, 0-100, the flag is on for while enabled. Users are
+	// bucketed by ID, so each one sees the same answer on every request.
+	RolloutPercent int `json:"rollout_percent" gorm:"default:100"`
+
+	// Comma-separated SERVER_ENVIRONMENT names the flag applies in; the
+	// feature is off everywhere else. Empty means every environment.
+	Environments string `json:"environments" gorm:"size:255"`
+
+	UpdatedBy uint  `json:"updated_by"`                 // Admin who last changed the flag
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"`
+}
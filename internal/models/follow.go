@@ -0,0 +1,15 @@
+package models
+
+// Follow subscribes a user to news from a seller: newly published events
+// and the sales opened for them
+type Follow struct {
+	ID        uint  `json:"id" gorm:"primaryKey"`
+	UserID    uint  `json:"user_id" gorm:"not null;uniqueIndex:idx_follow_user_seller"`
+	SellerID  uint  `json:"seller_id" gorm:"not null;uniqueIndex:idx_follow_user_seller;index"`
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
+
+	// Relationships
+	User   User   `json:"-" gorm:"foreignKey:UserID"`
+	Seller Seller `json:"-" gorm:"foreignKey:SellerID"`
+}
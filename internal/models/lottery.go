@@ -0,0 +1,25 @@
+package models
+
+type LotteryEntryStatus int
+
+const (
+	LotteryEntryStatusRegistered LotteryEntryStatus = 1 // Waiting for the draw
+	LotteryEntryStatusWon        LotteryEntryStatus = 2 // May buy until PurchaseBy
+	LotteryEntryStatusLost       LotteryEntryStatus = 3
+	LotteryEntryStatusPurchased  LotteryEntryStatus = 4 // Bought with the right it won
+)
+
+// LotteryEntry is a user's registration for a lottery sale. Winners get a
+// right to buy once before PurchaseBy.
+type LotteryEntry struct {
+	ID         uint               `json:"id" gorm:"primaryKey"`
+	SaleID     uint               `json:"sale_id" gorm:"not null;uniqueIndex:idx_lottery_sale_user"`
+	UserID     uint               `json:"user_id" gorm:"not null;uniqueIndex:idx_lottery_sale_user;index"`
+	Status     LotteryEntryStatus `json:"status" gorm:"default:1"`
+	CreatedAt  int64              `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt  int64              `json:"updated_at" gorm:"not null"` // Unix timestamp
+	PurchaseBy *int64             `json:"purchase_by"`                // Set for winners
+
+	// Relationships
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// MaintenanceModeID is the primary key of the single MaintenanceMode row
+const MaintenanceModeID = 1
+
+// MaintenanceMode is the site-wide maintenance switch. While it is on,
+// everyone but admins gets 503 responses; in read-only mode only requests
+// that change something are refused.
+type MaintenanceMode struct {
+	ID        uint   `json:"-" gorm:"primaryKey"`
+	Enabled   bool   `json:"enabled" gorm:"default:false"`
+	ReadOnly  bool   `json:"read_only" gorm:"default:false"`
+	Message   string `json:"message" gorm:"type:text"` // Shown to clients; a generic message when empty
+	EndsAt    *int64 `json:"ends_at"`                  // Expected end, sent as Retry-After; Unix timestamp
+	UpdatedBy uint   `json:"updated_by"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
@@ -0,0 +1,71 @@
+package models
+
+type OrderStatus int
+
+const (
+	OrderStatusAwaitingPayment OrderStatus = 1 // Charge failed; tickets held for a retry
+	OrderStatusPaid            OrderStatus = 2
+	OrderStatusExpired         OrderStatus = 3 // Grace window passed, tickets released
+	OrderStatusUnderReview     OrderStatus = 4 // Held by the fraud checks until an admin decides; nothing charged yet
+	OrderStatusRejected        OrderStatus = 5 // Refused on review, tickets released
+	OrderStatusPaying          OrderStatus = 6 // Charge in flight; tickets stay held until it settles
+	OrderStatusFailed          OrderStatus = 7 // Charge failed for good, tickets released
+)
+
+// Order holds the tickets of a group purchase from before it is charged
+// until it is paid or released: while the charge is in flight, after a
+// failed charge so the buyer can retry it, or while the fraud checks hold
+// it for review. The purchase request fields are kept to finish the
+// purchase exactly as first requested.
+type Order struct {
+	ID            uint        `json:"id" gorm:"primaryKey"`
+	UserID        uint        `json:"user_id" gorm:"not null;index"`
+	EventID       uint        `json:"event_id" gorm:"not null"`
+	SaleID        uint        `json:"sale_id" gorm:"not null"`
+	GroupID       uint        `json:"group_id"`
+	Price         float64     `json:"price" gorm:"not null"` // List price of the group
+	Type          TicketType  `json:"type" gorm:"not null"`
+	IsVip         bool        `json:"is_vip" gorm:"default:false"`
+	Title         string      `json:"title" gorm:"not null"`
+	Place         string      `json:"place" gorm:"not null"`
+	Quantity      int         `json:"quantity" gorm:"not null"`
+	TotalAmount   float64     `json:"total_amount" gorm:"not null"`
+	Status        OrderStatus `json:"status" gorm:"default:1;index"`
+	AllocationID  *uint       `json:"-"` // Sale allocation reserved for the order, released on expiry
+	GiftEmail     string      `json:"gift_recipient_email,omitempty"`
+	GiftMessage   string      `json:"gift_message,omitempty" gorm:"type:text"`
+	Attempts      int         `json:"attempts" gorm:"default:1"`
+	LastPaymentID *uint       `json:"last_payment_id"`
+	HoldExpiresAt int64       `json:"hold_expires_at" gorm:"not null"`
+	CreatedAt     int64       `json:"created_at" gorm:"not null"`
+	UpdatedAt     int64       `json:"updated_at" gorm:"not null"`
+	CompletedAt   *int64      `json:"completed_at"`
+
+	// Passed on to the tickets once the order is paid
+	AccommodationRequest string `json:"accommodation_request,omitempty" gorm:"type:text"`
+
+	// Where the order was placed from; cleared with the payment's
+	ClientIP          string `json:"-" gorm:"size:45"`
+	DeviceFingerprint string `json:"-" gorm:"size:128"`
+
+	// Fraud review of an order held before its first charge
+	FraudReasons string `json:"-" gorm:"type:text"`
+	ReviewedBy   *uint  `json:"-"`
+	ReviewedAt   *int64 `json:"reviewed_at,omitempty"`
+	ReviewNote   string `json:"review_note,omitempty" gorm:"type:text"` // Shown to the buyer when rejected
+
+	Items []OrderItem `json:"items" gorm:"foreignKey:OrderID"`
+}
+
+// OrderItem is one held ticket at the price quoted when the order was placed
+type OrderItem struct {
+	ID       uint    `json:"id" gorm:"primaryKey"`
+	OrderID  uint    `json:"order_id" gorm:"not null;index"`
+	TicketID uint    `json:"ticket_id" gorm:"not null"`
+	Price    float64 `json:"price" gorm:"not null"`
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
+
+	Ticket Ticket `json:"-" gorm:"foreignKey:TicketID"`
+}
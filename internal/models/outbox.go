@@ -0,0 +1,76 @@
+package models
+
+type OutboxStatus int
+
+const (
+	OutboxStatusPending   OutboxStatus = 1
+	OutboxStatusDelivered OutboxStatus = 2
+	OutboxStatusFailed    OutboxStatus = 3 // Gave up after max attempts
+)
+
+const (
+	OutboxTopicTicketSold          = "ticket.sold"
+	OutboxTopicOrderRefunded       = "order.refunded"
+	OutboxTopicTicketGifted        = "ticket.gifted"
+	OutboxTopicTransferAccepted    = "transfer.accepted"
+	OutboxTopicEventApproved       = "event.approved"
+	OutboxTopicEventRejected       = "event.rejected"
+	OutboxTopicEventSuspended      = "event.suspended"
+	OutboxTopicEventPublished      = "event.published"
+	OutboxTopicSaleCreated         = "sale.created"
+	OutboxTopicDisputeUpdated      = "dispute.updated"
+	OutboxTopicDataExportRequested = "data_export.requested" // Builds the archive in the background
+	OutboxTopicDataExportReady     = "data_export.ready"     // Emails the user the download link
+	OutboxTopicTicketImportQueued  = "ticket_import.queued"  // Creates the tickets of an uploaded CSV
+	OutboxTopicWebhookDelivery     = "webhook.delivery"      // One seller webhook call, fanned out from the topics above
+	OutboxTopicFollowerNotice      = "follower.notice"       // One follower's notification of a seller's new event or sale
+	OutboxTopicLotteryResult       = "lottery.result"        // Tells one entrant whether they won a lottery sale
+	OutboxTopicOrderReviewed       = "order.reviewed"        // Tells a buyer the outcome of their order's fraud review
+	OutboxTopicSellerReviewed      = "seller.reviewed"       // Tells a seller the outcome of their onboarding review
+)
+
+// OutboxMessage is a side effect (notification, webhook) recorded in the same
+// transaction as the business change and delivered later by the dispatcher
+type OutboxMessage struct {
+	ID            uint         `json:"id" gorm:"primaryKey"`
+	Topic         string       `json:"topic" gorm:"not null;index"`
+	Payload       string       `json:"payload" gorm:"type:json"`
+	Status        OutboxStatus `json:"status" gorm:"default:1;index"`
+	Attempts      int          `json:"attempts" gorm:"default:0"`
+	NextAttemptAt int64        `json:"next_attempt_at" gorm:"not null;index"` // Unix timestamp
+	LastError     string       `json:"last_error" gorm:"type:text"`
+	CreatedAt     int64        `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt     int64        `json:"updated_at" gorm:"not null"` // Unix timestamp
+	DeliveredAt   *int64       `json:"delivered_at"`               // Unix timestamp, nullable
+
+	// Set on messages fanned out from another message, so a handler run
+	// again for the same source message doesn't queue them twice
+	DedupeKey *string `json:"-" gorm:"size:96;uniqueIndex"`
+}
+
+type DeadLetterStatus int
+
+const (
+	DeadLetterStatusOpen      DeadLetterStatus = 1
+	DeadLetterStatusRetried   DeadLetterStatus = 2 // Message was put back in the outbox
+	DeadLetterStatusDiscarded DeadLetterStatus = 3
+)
+
+// DeadLetter is a copy of an outbox message the dispatcher gave up on, kept
+// for admins to inspect and then retry or discard. A message that fails again
+// after a retry gets a new dead letter.
+type DeadLetter struct {
+	ID              uint             `json:"id" gorm:"primaryKey"`
+	OutboxMessageID uint             `json:"outbox_message_id" gorm:"not null;index"`
+	Topic           string           `json:"topic" gorm:"not null;index"`
+	Payload         string           `json:"payload" gorm:"type:json"`
+	Attempts        int              `json:"attempts"`
+	LastError       string           `json:"last_error" gorm:"type:text"`
+	Status          DeadLetterStatus `json:"status" gorm:"default:1;index"`
+	FailedAt        int64            `json:"failed_at" gorm:"not null"` // Unix timestamp
+	ResolvedAt      *int64           `json:"resolved_at"`               // Unix timestamp, nullable
+	ResolvedBy      *uint            `json:"resolved_by"`               // Admin who retried or discarded it
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
+}
@@ -8,6 +8,9 @@ const (
 	PaymentTypePayPal    PaymentType = 2
 	PaymentTypeGooglePay PaymentType = 3
 	PaymentTypeStripe    PaymentType = 4
+	PaymentTypeInvoice   PaymentType = 5 // Organization orders only; settled offline
+	PaymentTypeSplit     PaymentType = 6 // Parent of component payments on several methods
+	PaymentTypeApplePay  PaymentType = 7
 )
 
 const (
@@ -19,24 +22,61 @@ const (
 
 type Payment struct {
 	ID          uint          `json:"id" gorm:"primaryKey"`
-	UserID      uint          `json:"user_id" gorm:"not null"`
-	UserType    UserType      `json:"user_type" gorm:"not null"`
-	Date        int64         `json:"date" gorm:"not null"` // Unix timestamp
+	UserID      uint          `json:"user_id" gorm:"not null;index:idx_payments_user_date,priority:1"`
+	UserType    UserType      `json:"user_type" gorm:"not null;index:idx_payments_user_date,priority:2"`
+	Date        int64         `json:"date" gorm:"not null;index:idx_payments_user_date,priority:3"` // Unix timestamp
 	Type        PaymentType   `json:"type" gorm:"not null"`
 	Amount      float64       `json:"amount" gorm:"not null"`
 	Status      PaymentStatus `json:"status" gorm:"default:1"`
 	Description string        `json:"description" gorm:"type:text"`
-	EventID     uint          `json:"event_id" gorm:"default:0"`
+	EventID     uint          `json:"event_id" gorm:"default:0;index"`
+
+	// Set on component payments of a split payment
+	ParentPaymentID *uint `json:"parent_payment_id,omitempty" gorm:"index"`
+	PaymentMethodID *uint `json:"payment_method_id,omitempty"`
+
+	// Returned to the customer so far. The payment stays completed until
+	// all of it has been refunded.
+	RefundedAmount float64 `json:"refunded_amount" gorm:"default:0"`
+
+	// The platform's share when the seller was credited for this payment.
+	// Refunds and chargebacks debit the seller at the same rate, so a fee
+	// change later on doesn't alter what the sale was worth. Nil until the
+	// seller is credited.
+	PlatformFeeRate *float64 `json:"platform_fee_rate,omitempty"`
+
+	// The charge as the provider knows it, for matching statements and
+	// support lookups. Empty until the charge is attempted.
+	TransactionID string `json:"transaction_id,omitempty" gorm:"size:128;index"`
+	Provider      string `json:"provider,omitempty" gorm:"size:32"`
+
+	// Where the customer paid from, for fraud investigations. Cleared once
+	// JOBS_CLIENT_INFO_RETENTION has passed.
+	ClientIP          string `json:"-" gorm:"size:45;index"`
+	DeviceFingerprint string `json:"-" gorm:"size:128;index"`
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
 
 	Event Event `json:"event" gorm:"foreignKey:EventID"`
 }
 
+// PaymentMethod is a payment instrument vaulted at the provider. Only the
+// provider's token and the display details it returns are stored here.
 type PaymentMethod struct {
 	ID        uint        `json:"id" gorm:"primaryKey"`
 	Type      PaymentType `json:"type" gorm:"not null"`
-	Token     string      `json:"token" gorm:"not null"`
-	Data      string      `json:"data" gorm:"type:json"`
+	Provider  string      `json:"provider" gorm:"not null;default:''"`
+	Token     string      `json:"-" gorm:"not null"`
+	Brand     string      `json:"brand"`
+	Last4     string      `json:"last4" gorm:"size:4"`
+	ExpMonth  int         `json:"exp_month"`
+	ExpYear   int         `json:"exp_year"`
+	Email     string      `json:"email"` // Wallet account, for PayPal / Google Pay
 	UserID    uint        `json:"user_id" gorm:"not null"`
 	UserType  UserType    `json:"user_type" gorm:"not null"`
 	IsDefault bool        `json:"is_default" gorm:"default:false"`
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
 }
@@ -0,0 +1,40 @@
+package models
+
+type PolicyKind int
+
+const (
+	PolicyKindTerms   PolicyKind = 1 // Terms of service
+	PolicyKindPrivacy PolicyKind = 2 // Privacy policy
+)
+
+// PolicyDocument is one published version of the terms of service or the
+// privacy policy. The newest version of each kind is the one in force;
+// accounts that haven't accepted it can only read until they do.
+type PolicyDocument struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	Kind        PolicyKind `json:"kind" gorm:"not null;uniqueIndex:idx_policy_kind_version"`
+	Version     int        `json:"version" gorm:"not null;uniqueIndex:idx_policy_kind_version"` // Counts up from 1 per kind
+	Title       string     `json:"title" gorm:"not null"`
+	Content     string     `json:"content" gorm:"type:text;not null"`
+	Summary     string     `json:"summary,omitempty" gorm:"type:text"` // What changed since the previous version
+	PublishedBy uint       `json:"published_by" gorm:"not null"`
+	PublishedAt int64      `json:"published_at" gorm:"not null"`
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
+}
+
+// PolicyAcceptance records that an account accepted one version of a
+// policy, and from where
+type PolicyAcceptance struct {
+	ID         uint       `json:"-" gorm:"primaryKey"`
+	UserID     uint       `json:"-" gorm:"not null;uniqueIndex:idx_policy_acceptance"`
+	UserType   UserType   `json:"-" gorm:"not null;uniqueIndex:idx_policy_acceptance"`
+	Kind       PolicyKind `json:"kind" gorm:"not null;uniqueIndex:idx_policy_acceptance"`
+	Version    int        `json:"version" gorm:"not null;uniqueIndex:idx_policy_acceptance"`
+	IP         string     `json:"-" gorm:"size:45"`
+	AcceptedAt int64      `json:"accepted_at" gorm:"not null"`
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
+}
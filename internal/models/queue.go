@@ -0,0 +1,27 @@
+package models
+
+type QueueEntryStatus int
+
+const (
+	QueueEntryStatusWaiting  QueueEntryStatus = 1
+	QueueEntryStatusAdmitted QueueEntryStatus = 2 // May buy until ExpiresAt
+	QueueEntryStatusUsed     QueueEntryStatus = 3 // Bought with the admission
+	QueueEntryStatusExpired  QueueEntryStatus = 4 // Admitted but never bought in time
+)
+
+// QueueEntry is a buyer's place in an event's waiting room. Entries are
+// admitted in the order they joined, a few per minute, and purchases of
+// queued events need the token of an admitted entry.
+type QueueEntry struct {
+	ID         uint             `json:"id" gorm:"primaryKey"`
+	EventID    uint             `json:"event_id" gorm:"not null;index:idx_queue_event_status,priority:1"`
+	UserID     uint             `json:"user_id" gorm:"not null;index"`
+	Token      string           `json:"-" gorm:"uniqueIndex;size:64;not null"`
+	Status     QueueEntryStatus `json:"status" gorm:"default:1;index:idx_queue_event_status,priority:2"`
+	JoinedAt   int64            `json:"joined_at" gorm:"not null"` // Unix timestamp
+	AdmittedAt *int64           `json:"admitted_at"`
+	ExpiresAt  *int64           `json:"expires_at" gorm:"index"` // End of the admission window
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
+}
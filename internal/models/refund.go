@@ -0,0 +1,59 @@
+package models
+
+// Refund returns all or part of a customer payment. Its items add up to
+// Amount; SellerDebit is the seller's share of it, taken back with a
+// negative revenue row.
+type Refund struct {
+	ID          uint    `json:"id" gorm:"primaryKey"`
+	PaymentID   uint    `json:"payment_id" gorm:"not null;index"`
+	EventID     uint    `json:"event_id" gorm:"default:0"`
+	UserID      uint    `json:"user_id" gorm:"not null"`
+	Amount      float64 `json:"amount" gorm:"not null"`
+	SellerDebit float64 `json:"seller_debit" gorm:"default:0"`
+	Reason      string  `json:"reason" gorm:"type:text"`
+	RefundedBy  uint    `json:"refunded_by"`                // Admin who issued it
+	CreatedAt   int64   `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt   int64   `json:"updated_at" gorm:"not null"` // Unix timestamp
+
+	Items []RefundItem `json:"items" gorm:"foreignKey:RefundID"`
+}
+
+// RefundItem is one line of a refund: a returned ticket, an adjustment to
+// the amount, or a fee withheld (negative)
+type RefundItem struct {
+	ID                uint    `json:"id" gorm:"primaryKey"`
+	RefundID          uint    `json:"refund_id" gorm:"not null;index"`
+	PurchasedTicketID *uint   `json:"purchased_ticket_id"`
+	Description       string  `json:"description" gorm:"not null"`
+	Amount            float64 `json:"amount" gorm:"not null"`
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
+}
+
+type RefundRequestStatus int
+
+const (
+	RefundRequestStatusPending  RefundRequestStatus = 1
+	RefundRequestStatusApproved RefundRequestStatus = 2
+	RefundRequestStatusRejected RefundRequestStatus = 3
+	RefundRequestStatusClosed   RefundRequestStatus = 4 // Still undecided when the event took place
+)
+
+// RefundRequest is a customer asking for their order to be refunded. An
+// admin approves it, issuing a Refund, or rejects it.
+type RefundRequest struct {
+	ID         uint                `json:"id" gorm:"primaryKey"`
+	PaymentID  uint                `json:"payment_id" gorm:"not null;index"`
+	UserID     uint                `json:"user_id" gorm:"not null;index"`
+	EventID    uint                `json:"event_id" gorm:"not null;index"`
+	Reason     string              `json:"reason" gorm:"type:text"`
+	Status     RefundRequestStatus `json:"status" gorm:"default:1;index"`
+	Note       string              `json:"note" gorm:"type:text"`      // Why it was rejected or closed
+	RefundID   *uint               `json:"refund_id"`                  // Set once approved
+	CreatedAt  int64               `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt  int64               `json:"updated_at" gorm:"not null"` // Unix timestamp
+	ResolvedAt *int64              `json:"resolved_at"`                // Unix timestamp, nullable
+
+	Event Event `json:"-" gorm:"foreignKey:EventID"`
+}
@@ -0,0 +1,34 @@
+package models
+
+type SellerOnboardingStatus int
+
+const (
+	SellerOnboardingIncomplete    SellerOnboardingStatus = 1 // Signed up; documents not submitted yet
+	SellerOnboardingPendingReview SellerOnboardingStatus = 2
+	SellerOnboardingApproved      SellerOnboardingStatus = 3
+	SellerOnboardingRejected      SellerOnboardingStatus = 4 // May upload more documents and submit again
+)
+
+// Kinds of document a seller uploads for review
+const (
+	SellerDocumentIdentity             = "identity" // Required before submitting
+	SellerDocumentBusinessRegistration = "business_registration"
+	SellerDocumentTax                  = "tax"
+	SellerDocumentBankAccount          = "bank_account"
+	SellerDocumentOther                = "other"
+)
+
+// SellerDocument is a file a seller uploaded for their onboarding review
+type SellerDocument struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	SellerID    uint   `json:"seller_id" gorm:"not null;index"`
+	Kind        string `json:"kind" gorm:"size:32;not null"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type" gorm:"size:100"`
+	Size        int    `json:"size"`
+	Data        []byte `json:"-" gorm:"type:mediumblob"`
+	UploadedAt  int64  `json:"uploaded_at" gorm:"not null"` // Unix timestamp
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
+}
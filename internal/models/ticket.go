@@ -9,8 +9,31 @@ const (
 	TicketTypePremium TicketType = 3
 )
 
+// TicketGroup is a batch of identical tickets a seller put on sale together.
+// Tickets reference their group by ID, so editing a group's details keeps it
+// a single group and purchases only ever take tickets from the group asked for.
+type TicketGroup struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	EventID     uint       `json:"event_id" gorm:"not null;index"`
+	SaleID      uint       `json:"sale_id" gorm:"not null"`
+	Price       float64    `json:"price" gorm:"not null"` // List price
+	Type        TicketType `json:"type" gorm:"not null"`
+	IsVip       bool       `json:"is_vip" gorm:"default:false"`
+	Title       string     `json:"title" gorm:"not null"`
+	Description string     `json:"description" gorm:"type:text"`
+	Place       string     `json:"place" gorm:"not null"`
+	CreatedAt   int64      `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt   int64      `json:"updated_at" gorm:"not null"` // Unix timestamp
+
+	// Wheelchair-accessible or otherwise adapted seating
+	IsAccessible bool `json:"is_accessible" gorm:"default:false"`
+}
+
+// Ticket keeps a copy of its group's details, kept in sync while unsold, so
+// single tickets can be read without the group
 type Ticket struct {
 	ID          uint       `json:"id" gorm:"primaryKey"`
+	GroupID     uint       `json:"group_id" gorm:"index"`
 	Price       float64    `json:"price" gorm:"not null"`
 	IsHeld      bool       `json:"is_held" gorm:"default:false"`
 	IsSold      bool       `json:"is_sold" gorm:"default:false"`
@@ -22,6 +45,11 @@ type Ticket struct {
 	SaleID      uint       `json:"sale_id" gorm:"not null"`
 	EventID     uint       `json:"event_id" gorm:"not null"` // Added for easier querying
 
+	IsAccessible bool `json:"is_accessible" gorm:"default:false"`
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
+
 	// Relationships
 	Sale  Sale  `json:"sale" gorm:"foreignKey:SaleID"`
 	Event Event `json:"event" gorm:"foreignKey:EventID"`
@@ -38,15 +66,55 @@ type PurchasedTicket struct {
 	UserID      uint       `json:"user_id" gorm:"not null"`
 	TicketID    uint       `json:"ticket_id" gorm:"not null"`
 	IsUsed      bool       `json:"is_used" gorm:"default:false"`
-	UsedAt      *int64     `json:"used_at"` // Unix timestamp, nullable
+	UsedAt      *int64     `json:"used_at"`                 // Unix timestamp, nullable
+	PaymentID   *uint      `json:"payment_id" gorm:"index"` // Customer payment that bought the ticket
+
+	// Accessible seating and what the attendee asked the venue to prepare
+	IsAccessible         bool   `json:"is_accessible" gorm:"default:false"`
+	AccommodationRequest string `json:"accommodation_request,omitempty" gorm:"type:text"`
+
+	// Printed in the ticket's QR code and replaced whenever the ticket changes
+	// hands, so codes downloaded by an earlier owner no longer scan
+	QRSecret string `json:"-" gorm:"size:32"`
+
+	// Set when the payment is reversed by a lost chargeback
+	IsInvalidated bool   `json:"is_invalidated" gorm:"default:false"`
+	InvalidatedAt *int64 `json:"invalidated_at"` // Unix timestamp, nullable
+
+	// PurchasedAt is set when the row is created and survives transfers
+	PurchasedAt int64 `json:"purchased_at" gorm:"autoCreateTime;not null;index"` // Unix timestamp
+	UpdatedAt   int64 `json:"updated_at" gorm:"not null"`                        // Unix timestamp
 
 	// Relationships
 	User   User   `json:"user" gorm:"foreignKey:UserID"`
 	Ticket Ticket `json:"ticket" gorm:"foreignKey:TicketID"`
 }
 
+type GiftStatus int
+
+const (
+	GiftStatusPending   GiftStatus = 1 // Recipient has no account yet; the buyer holds the ticket
+	GiftStatusDelivered GiftStatus = 2
+)
+
+// TicketGift records that a purchased ticket was bought for someone else
+type TicketGift struct {
+	ID                uint       `json:"id" gorm:"primaryKey"`
+	PurchasedTicketID uint       `json:"purchased_ticket_id" gorm:"not null;uniqueIndex"`
+	SenderID          uint       `json:"sender_id" gorm:"not null"`
+	SenderName        string     `json:"sender_name" gorm:"not null"`
+	RecipientEmail    string     `json:"recipient_email" gorm:"not null;index"`
+	RecipientID       *uint      `json:"recipient_id"` // Nil until the recipient registers
+	Message           string     `json:"message" gorm:"type:text"`
+	Status            GiftStatus `json:"status" gorm:"default:1"`
+	CreatedAt         int64      `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt         int64      `json:"updated_at" gorm:"not null"` // Unix timestamp
+	DeliveredAt       *int64     `json:"delivered_at"`               // Unix timestamp, nullable
+}
+
 // GroupedTicket represents aggregated ticket data for display purposes
 type GroupedTicket struct {
+	GroupID         uint       `json:"group_id"` // Identifies the group; the other details are matched only when it is 0
 	Price           float64    `json:"price"`
 	Type            TicketType `json:"type"`
 	IsVip           bool       `json:"is_vip"`
@@ -59,4 +127,30 @@ type GroupedTicket struct {
 	AvailableAmount int        `json:"available_amount"`
 	SoldAmount      int        `json:"sold_amount"`
 	HeldAmount      int        `json:"held_amount"`
+	IsAccessible    bool       `json:"is_accessible"`
+	CurrentPrice    float64    `json:"current_price" gorm:"-"` // Price after applying the group's price tiers
+}
+
+// PriceTier is one step of a ticket group's pricing schedule (early bird,
+// regular, door...). A tier kicks in once its start time has passed or its
+// sold-count threshold is reached; the last tier that has kicked in, by
+// position, sets the price. A tier with neither trigger applies from the start.
+type PriceTier struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	EventID         uint       `json:"event_id" gorm:"not null;index"`
+	GroupID         uint       `json:"group_id" gorm:"index"`
+	SaleID          uint       `json:"sale_id" gorm:"not null"`
+	GroupPrice      float64    `json:"group_price" gorm:"not null"` // Group details as of when the schedule was set
+	Type            TicketType `json:"type" gorm:"not null"`
+	IsVip           bool       `json:"is_vip" gorm:"default:false"`
+	Title           string     `json:"title" gorm:"not null"`
+	Place           string     `json:"place" gorm:"not null"`
+	Name            string     `json:"name" gorm:"not null"`
+	Price           float64    `json:"price" gorm:"not null"`
+	Position        int        `json:"position" gorm:"not null"`
+	StartsAt        int64      `json:"starts_at" gorm:"default:0"`         // Unix timestamp, 0 = no time trigger
+	StartsAfterSold int        `json:"starts_after_sold" gorm:"default:0"` // 0 = no quantity trigger
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
 }
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+type TicketImportStatus int
+
+const (
+	TicketImportStatusPending    TicketImportStatus = 1
+	TicketImportStatusProcessing TicketImportStatus = 2
+	TicketImportStatusCompleted  TicketImportStatus = 3 // Every row was attempted; see Results for failures
+	TicketImportStatusFailed     TicketImportStatus = 4 // The file could not be processed at all
+)
+
+// TicketImport is a CSV of ticket groups a seller uploaded for an event. The
+// file is validated on upload and its rows are created in the background,
+// each one recorded in Results.
+type TicketImport struct {
+	ID             uint                `json:"id" gorm:"primaryKey"`
+	EventID        uint                `json:"event_id" gorm:"not null;index"`
+	SellerID       uint                `json:"seller_id" gorm:"not null"` // Who uploaded it; owner or event member
+	Status         TicketImportStatus  `json:"status" gorm:"default:1"`
+	FileName       string              `json:"file_name"`
+	Data           []byte              `json:"-" gorm:"type:mediumblob"` // The uploaded CSV
+	TotalRows      int                 `json:"total_rows"`
+	ImportedRows   int                 `json:"imported_rows"`
+	FailedRows     int                 `json:"failed_rows"`
+	TicketsCreated int                 `json:"tickets_created"`
+	Results        TicketImportResults `json:"results" gorm:"type:json"`
+	Error          string              `json:"error,omitempty" gorm:"type:text"`
+	CreatedAt      int64               `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt      int64               `json:"updated_at" gorm:"not null"` // Unix timestamp
+	CompletedAt    *int64              `json:"completed_at"`
+}
+
+// TicketImportResult is the outcome of one CSV row
+type TicketImportResult struct {
+	Line     int    `json:"line"` // Line in the file; the header is line 1
+	Title    string `json:"title"`
+	Place    string `json:"place"`
+	Quantity int    `json:"quantity"`
+	Created  bool   `json:"created"`
+	Error    string `json:"error,omitempty"`
+}
+
+type TicketImportResults []TicketImportResult
+
+// Value stores the results as JSON
+func (r TicketImportResults) Value() (driver.Value, error) {
+	if r == nil {
+		r = TicketImportResults{}
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (r *TicketImportResults) Scan(value interface{}) error {
+	*r = nil
+
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported ticket import results type %T", value)
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, r)
+}
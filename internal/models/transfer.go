@@ -17,6 +17,9 @@ type ActiveTicketTransfer struct {
 	PurchasedTicketID uint           `json:"purchased_ticket_id" gorm:"not null"`
 	Status            TransferStatus `json:"status" gorm:"default:1"`
 
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
+
 	// Relationships
 	FromUser        User            `json:"from_user" gorm:"foreignKey:FromUserID"`
 	ToUser          User            `json:"to_user" gorm:"foreignKey:ToUserID"`
@@ -31,8 +34,41 @@ type DoneTicketTransfer struct {
 	PurchasedTicketID uint  `json:"purchased_ticket_id" gorm:"not null"`
 	CompletedAt       int64 `json:"completed_at" gorm:"not null"` // Unix timestamp
 
+	// The accepted transfer; unique so a transfer completes at most once
+	ActiveTransferID *uint `json:"active_transfer_id" gorm:"uniqueIndex"`
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
+
 	// Relationships
 	FromUser        User            `json:"from_user" gorm:"foreignKey:FromUserID"`
 	ToUser          User            `json:"to_user" gorm:"foreignKey:ToUserID"`
 	PurchasedTicket PurchasedTicket `json:"purchased_ticket" gorm:"foreignKey:PurchasedTicketID"`
 }
+
+type ClaimLinkStatus int
+
+const (
+	ClaimLinkStatusOpen    ClaimLinkStatus = 1
+	ClaimLinkStatusClaimed ClaimLinkStatus = 2
+	ClaimLinkStatusRevoked ClaimLinkStatus = 3 // By the owner, or replaced by a newer link
+)
+
+// TicketClaimLink is a one-time URL the owner of a ticket can send to
+// anyone; whoever opens it first while signed in becomes the owner
+type TicketClaimLink struct {
+	ID                uint            `json:"id" gorm:"primaryKey"`
+	PurchasedTicketID uint            `json:"purchased_ticket_id" gorm:"not null;index"`
+	FromUserID        uint            `json:"from_user_id" gorm:"not null"`
+	Token             string          `json:"-" gorm:"not null;uniqueIndex;size:64"`
+	Status            ClaimLinkStatus `json:"status" gorm:"default:1"`
+	CreatedAt         int64           `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt         int64           `json:"updated_at" gorm:"not null"` // Unix timestamp
+	ExpiresAt         int64           `json:"expires_at" gorm:"not null"` // Unix timestamp
+	ClaimedByID       *uint           `json:"claimed_by_id"`
+	ClaimedAt         *int64          `json:"claimed_at"` // Unix timestamp, nullable
+
+	// Relationships
+	FromUser        User            `json:"-" gorm:"foreignKey:FromUserID"`
+	PurchasedTicket PurchasedTicket `json:"-" gorm:"foreignKey:PurchasedTicketID"`
+}
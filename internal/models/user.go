@@ -8,6 +8,20 @@ type User struct {
 	Name         string `json:"name" gorm:"not null"`
 	Surname      string `json:"surname" gorm:"not null"`
 
+	CalendarToken *string `json:"-" gorm:"uniqueIndex;size:64"` // Authenticates the iCal feed URL; nil until requested
+
+	Locale string `json:"locale" gorm:"size:8;default:'en'"` // Language of the user's notifications, "en" or "uk"
+
+	// Account deletion: personal data is anonymized once DeletionScheduledAt
+	// passes unless the user cancels first. Orders, payments and tickets are
+	// kept for bookkeeping but no longer identify the user.
+	DeletionRequestedAt *int64 `json:"-"`
+	DeletionScheduledAt *int64 `json:"-" gorm:"index"`
+	AnonymizedAt        *int64 `json:"-"`
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
+
 	// Relationships
 	PurchasedTickets []PurchasedTicket `json:"purchased_tickets,omitempty" gorm:"foreignKey:UserID"`
 	PaymentMethods   []PaymentMethod   `json:"payment_methods,omitempty" gorm:"foreignKey:UserID"`
@@ -22,6 +36,23 @@ type Seller struct {
 	Name         string `json:"name" gorm:"not null"`
 	Surname      string `json:"surname" gorm:"not null"`
 
+	// Public profile
+	DisplayName string `json:"display_name"` // Shown instead of name and surname when set
+	Bio         string `json:"bio" gorm:"type:text"`
+	LogoURL     string `json:"logo_url"`
+	Website     string `json:"website"`
+
+	// Sellers can't create events until an admin approves their onboarding.
+	// The column defaults to approved for sellers who signed up before it.
+	OnboardingStatus      SellerOnboardingStatus `json:"onboarding_status" gorm:"default:3"`
+	OnboardingSubmittedAt *int64                 `json:"onboarding_submitted_at"`
+	OnboardingReviewedBy  *uint                  `json:"-"`
+	OnboardingReviewedAt  *int64                 `json:"onboarding_reviewed_at"`
+	OnboardingNote        string                 `json:"onboarding_note,omitempty" gorm:"type:text"` // From the reviewer
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
+
 	// Relationships
 	Events []Event `json:"events,omitempty" gorm:"foreignKey:SellerID"`
 }
@@ -33,7 +64,10 @@ type Admin struct {
 	Email        string `json:"email" gorm:"unique;not null"`
 	Name         string `json:"name" gorm:"not null"`
 	Surname      string `json:"surname" gorm:"not null"`
-	AdminRole    int    `json:"admin_role" gorm:"default:1"` // 1=regular admin, 2=super admin
+	AdminRole    int    `json:"admin_role" gorm:"default:1"` // AdminRoleRegular or AdminRoleSuper
+
+	CreatedAt int64 `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64 `json:"updated_at" gorm:"not null"` // Unix timestamp
 }
 
 type UserType int
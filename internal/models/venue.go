@@ -0,0 +1,18 @@
+package models
+
+// Venue is a place sellers hold events at. Events reference it instead of
+// repeating the address, so every event there shows the same location and map.
+type Venue struct {
+	ID         uint     `json:"id" gorm:"primaryKey"`
+	SellerID   uint     `json:"seller_id" gorm:"not null;index"`
+	Name       string   `json:"name" gorm:"not null"`
+	Address    string   `json:"address" gorm:"not null"`
+	Latitude   *float64 `json:"latitude"`
+	Longitude  *float64 `json:"longitude"`
+	Capacity   int      `json:"capacity" gorm:"default:0"`  // 0 = not specified
+	SeatMapURL string   `json:"seat_map_url"`               // Seat map image or layout the frontend renders
+	CreatedAt  int64    `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt  int64    `json:"updated_at" gorm:"not null"` // Unix timestamp
+
+	Seller Seller `json:"-" gorm:"foreignKey:SellerID"`
+}
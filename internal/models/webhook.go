@@ -0,0 +1,29 @@
+package models
+
+type SellerWebhook struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	SellerID  uint   `json:"seller_id" gorm:"not null;index"`
+	URL       string `json:"url" gorm:"not null"`
+	Secret    string `json:"-" gorm:"not null"`       // HMAC key for the X-Webhook-Signature header
+	Events    string `json:"events" gorm:"type:text"` // Comma-separated outbox topics
+	IsActive  bool   `json:"is_active" gorm:"default:true"`
+	CreatedAt int64  `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt int64  `json:"updated_at" gorm:"not null"` // Unix timestamp
+
+	Seller Seller `json:"-" gorm:"foreignKey:SellerID"`
+}
+
+// WebhookDelivery is one attempt to call a seller webhook
+type WebhookDelivery struct {
+	ID              uint   `json:"id" gorm:"primaryKey"`
+	WebhookID       uint   `json:"webhook_id" gorm:"not null;index"`
+	OutboxMessageID uint   `json:"outbox_message_id" gorm:"not null"`
+	Topic           string `json:"topic" gorm:"not null"`
+	Attempt         int    `json:"attempt" gorm:"not null"`
+	StatusCode      int    `json:"status_code"`
+	Success         bool   `json:"success" gorm:"default:false"`
+	Error           string `json:"error" gorm:"type:text"`
+	DurationMs      int64  `json:"duration_ms"`
+	CreatedAt       int64  `json:"created_at" gorm:"not null"` // Unix timestamp
+	UpdatedAt       int64  `json:"updated_at" gorm:"not null"` // Unix timestamp
+}
@@ -0,0 +1,60 @@
+package moderation
+
+import (
+	"context"
+	"log"
+	"strings"
+)
+
+// Verdict is what a checker decided about a piece of text, ordered by
+// severity so the strictest verdict of several checkers wins
+type Verdict int
+
+const (
+	VerdictAllow Verdict = iota
+	VerdictFlag          // Publishable, but an admin should look at it
+	VerdictBlock         // Refused outright
+)
+
+type Result struct {
+	Verdict Verdict
+	Reasons []string
+}
+
+// Checker scans user-supplied text, e.g. against a local word list or an
+// external moderation API
+type Checker interface {
+	Name() string
+	Check(ctx context.Context, text string) (*Result, error)
+}
+
+// Moderator runs text through every configured checker. A checker that
+// fails flags the text for review instead of blocking it, so an outage of an
+// external service doesn't stop sellers from publishing.
+type Moderator struct {
+	checkers []Checker
+}
+
+func NewModerator(checkers ...Checker) *Moderator {
+	return &Moderator{checkers: checkers}
+}
+
+func (m *Moderator) Check(ctx context.Context, texts ...string) *Result {
+	text := strings.Join(texts, "\n")
+	combined := &Result{Verdict: VerdictAllow}
+
+	for _, checker := range m.checkers {
+		result, err := checker.Check(ctx, text)
+		if err != nil {
+			log.Printf("moderation: %s check failed: %v", checker.Name(), err)
+			result = &Result{Verdict: VerdictFlag, Reasons: []string{checker.Name() + " check unavailable"}}
+		}
+
+		if result.Verdict > combined.Verdict {
+			combined.Verdict = result.Verdict
+		}
+		combined.Reasons = append(combined.Reasons, result.Reasons...)
+	}
+
+	return combined
+}
@@ -0,0 +1,79 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RemoteChecker asks an external moderation API about the text. The API
+// receives {"text": "..."} and answers
+// {"verdict": "allow|flag|block", "reasons": ["..."]}.
+type RemoteChecker struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+func NewRemoteChecker(url, apiKey string, timeout time.Duration) *RemoteChecker {
+	return &RemoteChecker{
+		url:    url,
+		apiKey: apiKey,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *RemoteChecker) Name() string {
+	return "moderation API"
+}
+
+func (c *RemoteChecker) Check(ctx context.Context, text string) (*Result, error) {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var decoded struct {
+		Verdict string   `json:"verdict"`
+		Reasons []string `json:"reasons"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	result := &Result{Reasons: decoded.Reasons}
+	switch decoded.Verdict {
+	case "allow":
+		result.Verdict = VerdictAllow
+	case "flag":
+		result.Verdict = VerdictFlag
+	case "block":
+		result.Verdict = VerdictBlock
+	default:
+		return nil, fmt.Errorf("unknown verdict %q", decoded.Verdict)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,73 @@
+package moderation
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// DefaultBlockedWords is used when no block list is configured. It is
+// deliberately short; deployments are expected to supply their own list.
+var DefaultBlockedWords = []string{"fuck", "fucking", "shit", "cunt", "motherfucker"}
+
+// DefaultFlaggedWords catch common signs of ticket scams
+var DefaultFlaggedWords = []string{"wire transfer", "western union", "gift card", "crypto only", "whatsapp"}
+
+// WordListChecker matches whole words and phrases, ignoring case and
+// punctuation, so "Scunthorpe" doesn't trip a blocked word
+type WordListChecker struct {
+	blocked []string
+	flagged []string
+}
+
+func NewWordListChecker(blocked, flagged []string) *WordListChecker {
+	return &WordListChecker{
+		blocked: normalizeAll(blocked),
+		flagged: normalizeAll(flagged),
+	}
+}
+
+func (c *WordListChecker) Name() string {
+	return "word list"
+}
+
+func (c *WordListChecker) Check(ctx context.Context, text string) (*Result, error) {
+	padded := " " + normalize(text) + " "
+	result := &Result{Verdict: VerdictAllow}
+
+	for _, word := range c.blocked {
+		if strings.Contains(padded, " "+word+" ") {
+			result.Verdict = VerdictBlock
+			result.Reasons = append(result.Reasons, "contains blocked term \""+word+"\"")
+		}
+	}
+	for _, word := range c.flagged {
+		if strings.Contains(padded, " "+word+" ") {
+			if result.Verdict < VerdictFlag {
+				result.Verdict = VerdictFlag
+			}
+			result.Reasons = append(result.Reasons, "contains flagged term \""+word+"\"")
+		}
+	}
+
+	return result, nil
+}
+
+// normalize lowercases text and collapses everything but letters and digits
+// into single spaces
+func normalize(text string) string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+func normalizeAll(words []string) []string {
+	normalized := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = normalize(word); word != "" {
+			normalized = append(normalized, word)
+		}
+	}
+	return normalized
+}
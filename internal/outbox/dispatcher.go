@@ -0,0 +1,125 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+)
+
+// claimTimeout is how long a dispatcher holds the messages it claimed; a
+// batch must be delivered within it or another dispatcher may pick it up
+const claimTimeout = 5 * time.Minute
+
+// Handler delivers a single message. Returning an error schedules a retry.
+type Handler func(ctx context.Context, message *models.OutboxMessage) error
+
+// Dispatcher delivers pending outbox messages to the handlers registered for
+// their topic. It is driven by the job scheduler; each Dispatch call drains
+// one batch of due messages.
+type Dispatcher struct {
+	repo        repositories.OutboxRepository
+	batchSize   int
+	maxAttempts int
+	baseBackoff time.Duration
+
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewDispatcher(repo repositories.OutboxRepository, batchSize, maxAttempts int, baseBackoff time.Duration) *Dispatcher {
+	return &Dispatcher{
+		repo:        repo,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		baseBackoff: baseBackoff,
+		handlers:    make(map[string][]Handler),
+	}
+}
+
+// Subscribe adds a handler for topic. A message is only marked delivered once
+// every handler for its topic has succeeded, so handlers must be idempotent.
+func (d *Dispatcher) Subscribe(topic string, handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[topic] = append(d.handlers[topic], handler)
+}
+
+// Dispatch delivers one batch of due messages and reports how many succeeded.
+// The batch is claimed first, so dispatchers on other instances or an
+// overlapping tick don't deliver the same messages.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	now := time.Now()
+	messages, err := d.repo.ClaimDue(now.Unix(), now.Add(claimTimeout).Unix(), d.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load outbox messages: %w", err)
+	}
+
+	delivered := 0
+	for i := range messages {
+		if ctx.Err() != nil {
+			return delivered, ctx.Err()
+		}
+
+		message := &messages[i]
+		deliverErr := d.deliver(ctx, message)
+		d.recordAttempt(message, deliverErr)
+
+		save := d.repo.Update
+		if message.Status == models.OutboxStatusFailed {
+			save = d.repo.Bury // Keep it for admins to retry or discard
+		}
+		if err := save(message); err != nil {
+			return delivered, fmt.Errorf("failed to update outbox message %d: %w", message.ID, err)
+		}
+		if deliverErr == nil {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, message *models.OutboxMessage) error {
+	d.mu.RLock()
+	handlers := d.handlers[message.Topic]
+	d.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, message); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (d *Dispatcher) recordAttempt(message *models.OutboxMessage, err error) {
+	now := time.Now()
+	message.Attempts++
+
+	if err == nil {
+		deliveredAt := now.Unix()
+		message.Status = models.OutboxStatusDelivered
+		message.DeliveredAt = &deliveredAt
+		message.LastError = ""
+		return
+	}
+
+	message.LastError = err.Error()
+	if message.Attempts >= d.maxAttempts {
+		message.Status = models.OutboxStatusFailed
+		log.Printf("outbox: giving up on message %d (%s) after %d attempts: %v", message.ID, message.Topic, message.Attempts, err)
+		return
+	}
+
+	// Exponential backoff: base, 2*base, 4*base, ...
+	backoff := d.baseBackoff << (message.Attempts - 1)
+	message.NextAttemptAt = now.Add(backoff).Unix()
+}
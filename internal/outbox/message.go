@@ -0,0 +1,210 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"eticketing/internal/models"
+)
+
+type TicketSoldPayload struct {
+	EventID            uint    `json:"event_id"`
+	SellerID           uint    `json:"seller_id"`
+	UserID             uint    `json:"user_id"`
+	PaymentID          uint    `json:"payment_id"`
+	PurchasedTicketIDs []uint  `json:"purchased_ticket_ids"`
+	TotalAmount        float64 `json:"total_amount"`
+}
+
+type TicketGiftedPayload struct {
+	EventID            uint   `json:"event_id"`
+	EventTitle         string `json:"event_title"`
+	SenderID           uint   `json:"sender_id"`
+	SenderName         string `json:"sender_name"`
+	RecipientEmail     string `json:"recipient_email"`
+	Invitation         bool   `json:"invitation"` // Recipient must register to receive the tickets
+	Message            string `json:"message,omitempty"`
+	PurchasedTicketIDs []uint `json:"purchased_ticket_ids"`
+	Locale             string `json:"locale,omitempty"` // Recipient's language, the sender's for an invitation
+}
+
+type TransferAcceptedPayload struct {
+	TransferID        uint    `json:"transfer_id,omitempty"`
+	ClaimLinkID       uint    `json:"claim_link_id,omitempty"` // Set instead of TransferID for a claimed link
+	EventID           uint    `json:"event_id"`
+	PurchasedTicketID uint    `json:"purchased_ticket_id"`
+	FromUserID        uint    `json:"from_user_id"`
+	ToUserID          uint    `json:"to_user_id"`
+	Fee               float64 `json:"fee,omitempty"` // Transfer fee charged, whoever paid it
+	AcceptedAt        int64   `json:"accepted_at"`
+}
+
+type OrderRefundedPayload struct {
+	EventID   uint    `json:"event_id"`
+	SellerID  uint    `json:"seller_id"`
+	UserID    uint    `json:"user_id"`
+	PaymentID uint    `json:"payment_id"`
+	RefundID  uint    `json:"refund_id,omitempty"`
+	Amount    float64 `json:"amount"` // Refunded this time
+	Partial   bool    `json:"partial,omitempty"`
+
+	// Tickets refunded and invalidated; a full refund voids the whole order
+	PurchasedTicketIDs []uint `json:"purchased_ticket_ids,omitempty"`
+
+	// Per-method reversals when the order was paid with a split payment
+	Components []RefundComponent `json:"components,omitempty"`
+}
+
+type RefundComponent struct {
+	PaymentID   uint               `json:"payment_id"`
+	PaymentType models.PaymentType `json:"payment_type"`
+	Amount      float64            `json:"amount"`
+}
+
+type EventStatusPayload struct {
+	EventID  uint               `json:"event_id"`
+	SellerID uint               `json:"seller_id"`
+	Title    string             `json:"title"`
+	Status   models.EventStatus `json:"status"`
+	Reason   string             `json:"reason,omitempty"`
+}
+
+type EventPublishedPayload struct {
+	EventID         uint   `json:"event_id"`
+	SellerID        uint   `json:"seller_id"`
+	Title           string `json:"title"`
+	Date            int64  `json:"date"`
+	NotifyFollowers bool   `json:"notify_followers"`
+}
+
+type SaleCreatedPayload struct {
+	SaleID     uint   `json:"sale_id"`
+	EventID    uint   `json:"event_id"`
+	SellerID   uint   `json:"seller_id"`
+	EventTitle string `json:"event_title"`
+	StartDate  int64  `json:"start_date"`
+	EndDate    int64  `json:"end_date"`
+}
+
+// FollowerNoticePayload tells one follower about a seller's new event or sale
+type FollowerNoticePayload struct {
+	UserID     uint   `json:"user_id"`
+	Email      string `json:"email"`
+	SellerID   uint   `json:"seller_id"`
+	SellerName string `json:"seller_name"`
+	Reason     string `json:"reason"` // Topic of the message that triggered the notice
+	EventID    uint   `json:"event_id"`
+	EventTitle string `json:"event_title"`
+	EventDate  int64  `json:"event_date"`
+	SaleID     uint   `json:"sale_id,omitempty"`
+	SaleStart  int64  `json:"sale_start,omitempty"`
+	Locale     string `json:"locale,omitempty"`
+
+	// Dates as the recipient should read them, in the event's zone
+	Timezone       string `json:"timezone"`
+	EventDateLocal string `json:"event_date_local"`
+	SaleStartLocal string `json:"sale_start_local,omitempty"`
+}
+
+// LotteryResultPayload tells one entrant of a lottery sale how the draw went
+type LotteryResultPayload struct {
+	SaleID     uint   `json:"sale_id"`
+	EventID    uint   `json:"event_id"`
+	EventTitle string `json:"event_title"`
+	UserID     uint   `json:"user_id"`
+	Email      string `json:"email"`
+	Won        bool   `json:"won"`
+	PurchaseBy int64  `json:"purchase_by,omitempty"` // Winners buy before this Unix timestamp
+	Locale     string `json:"locale,omitempty"`
+}
+
+// OrderReviewedPayload tells a buyer whether their order held for a fraud
+// review may go ahead
+type OrderReviewedPayload struct {
+	OrderID    uint   `json:"order_id"`
+	EventID    uint   `json:"event_id"`
+	EventTitle string `json:"event_title"`
+	UserID     uint   `json:"user_id"`
+	Email      string `json:"email"`
+	Approved   bool   `json:"approved"`
+	PayBy      int64  `json:"pay_by,omitempty"` // Approved orders are paid before this Unix timestamp
+	Note       string `json:"note,omitempty"`
+	Locale     string `json:"locale,omitempty"`
+}
+
+// SellerReviewedPayload tells a seller whether their onboarding was
+// approved, so they can start creating events
+type SellerReviewedPayload struct {
+	SellerID uint   `json:"seller_id"`
+	Email    string `json:"email"`
+	Approved bool   `json:"approved"`
+	Note     string `json:"note,omitempty"`
+}
+
+type TicketImportPayload struct {
+	ImportID uint `json:"import_id"`
+	EventID  uint `json:"event_id"`
+}
+
+type DataExportPayload struct {
+	ExportID    uint   `json:"export_id"`
+	UserID      uint   `json:"user_id"`
+	Email       string `json:"email,omitempty"`
+	DownloadURL string `json:"download_url,omitempty"`
+	ExpiresAt   int64  `json:"expires_at,omitempty"`
+	Locale      string `json:"locale,omitempty"`
+}
+
+type DisputePayload struct {
+	DisputeID     uint                 `json:"dispute_id"`
+	EventID       uint                 `json:"event_id"`
+	SellerID      uint                 `json:"seller_id"`
+	PaymentID     uint                 `json:"payment_id"`
+	Amount        float64              `json:"amount"`
+	Reason        string               `json:"reason,omitempty"`
+	Status        models.DisputeStatus `json:"status"`
+	SellerDebited float64              `json:"seller_debited,omitempty"` // Revenue taken back from the seller on a lost dispute
+
+	InvalidatedTicketIDs []uint `json:"invalidated_ticket_ids,omitempty"`
+}
+
+// WebhookDeliveryPayload addresses a single seller webhook so each endpoint
+// is retried independently of the others subscribed to the same topic
+type WebhookDeliveryPayload struct {
+	WebhookID uint            `json:"webhook_id"`
+	SourceID  uint            `json:"source_id"` // Outbox message that triggered the call
+	Topic     string          `json:"topic"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// NewMessage builds a pending outbox message that is due immediately.
+// Callers persist it with OutboxRepository.WithTx inside their own transaction.
+func NewMessage(topic string, payload interface{}) (*models.OutboxMessage, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	return &models.OutboxMessage{
+		Topic:         topic,
+		Payload:       string(data),
+		Status:        models.OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// NewFanOutMessage is NewMessage for a message queued by a handler of
+// source for one recipient; storing it with CreateIfAbsent queues it once
+// however often the handler runs for source
+func NewFanOutMessage(topic string, payload interface{}, source *models.OutboxMessage, recipient string) (*models.OutboxMessage, error) {
+	message, err := NewMessage(topic, payload)
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("%s:%d:%s", topic, source.ID, recipient)
+	message.DedupeKey = &key
+	return message, nil
+}
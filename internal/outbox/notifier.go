@@ -0,0 +1,28 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"eticketing/internal/i18n"
+	"eticketing/internal/models"
+)
+
+// LogNotifier stands in for the email provider until one is integrated; it
+// writes the notification to the application log. Payloads that carry the
+// recipient's locale get their subject in that language.
+func LogNotifier(ctx context.Context, message *models.OutboxMessage) error {
+	var recipient struct {
+		Locale string `json:"locale"`
+	}
+	_ = json.Unmarshal([]byte(message.Payload), &recipient)
+
+	locale, ok := i18n.Parse(recipient.Locale)
+	if !ok {
+		locale = i18n.Default
+	}
+
+	log.Printf("notification [%s] %s: %s %s", locale, i18n.Subject(locale, message.Topic), message.Topic, message.Payload)
+	return nil
+}
@@ -0,0 +1,39 @@
+package payments
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Dispute statuses reported by providers, normalized from their own names
+const (
+	DisputeNeedsResponse = "needs_response"
+	DisputeUnderReview   = "under_review"
+	DisputeWon           = "won"
+	DisputeLost          = "lost"
+)
+
+// DisputeEvent is a chargeback notification from a provider webhook. The
+// provider reports the charge by the reference we passed to Charge, which is
+// our payment ID.
+type DisputeEvent struct {
+	ID        string  `json:"id" binding:"required"`
+	Reference string  `json:"reference" binding:"required"`
+	Amount    float64 `json:"amount" binding:"required,gt=0"`
+	Reason    string  `json:"reason"`
+	Status    string  `json:"status" binding:"required,oneof=needs_response under_review won lost"`
+}
+
+// VerifySignature checks a "sha256=<hex>" HMAC over "<timestamp>.<body>",
+// the same scheme we use to sign outgoing seller webhooks
+func VerifySignature(secret, timestamp string, body []byte, signature string) bool {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(expected), []byte(strings.TrimPrefix(signature, "sha256=")))
+}
@@ -0,0 +1,145 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/utils"
+)
+
+// mockInstruments mirrors the test tokens documented by Stripe and Braintree
+// so clients can use their sandbox SDKs against the mocked backend
+var mockInstruments = map[string]map[string]Instrument{
+	"stripe": {
+		"tok_visa":           {Type: models.PaymentTypeCard, Brand: "visa", Last4: "4242", ExpMonth: 12, ExpYear: 2034},
+		"tok_mastercard":     {Type: models.PaymentTypeCard, Brand: "mastercard", Last4: "4444", ExpMonth: 12, ExpYear: 2034},
+		"tok_amex":           {Type: models.PaymentTypeCard, Brand: "amex", Last4: "8431", ExpMonth: 12, ExpYear: 2034},
+		"tok_chargeDeclined": {Type: models.PaymentTypeCard, Brand: "visa", Last4: "0002", ExpMonth: 12, ExpYear: 2034},
+	},
+	"braintree": {
+		"fake-valid-visa-nonce":       {Type: models.PaymentTypeCard, Brand: "visa", Last4: "1881", ExpMonth: 12, ExpYear: 2034},
+		"fake-valid-mastercard-nonce": {Type: models.PaymentTypeCard, Brand: "mastercard", Last4: "4444", ExpMonth: 12, ExpYear: 2034},
+		"fake-paypal-billing-agreement-nonce": {
+			Type: models.PaymentTypePayPal, Brand: "paypal", Email: "jane.doe@paypal.com",
+		},
+		"fake-android-pay-nonce":          {Type: models.PaymentTypeGooglePay, Brand: "google_pay", Last4: "1111", Email: "jane.doe@gmail.com"},
+		"fake-apple-pay-visa-nonce":       {Type: models.PaymentTypeApplePay, Brand: "visa", Last4: "1881", ExpMonth: 12, ExpYear: 2034},
+		"fake-apple-pay-mastercard-nonce": {Type: models.PaymentTypeApplePay, Brand: "mastercard", Last4: "4444", ExpMonth: 12, ExpYear: 2034},
+	},
+}
+
+// MockGateway is the mock provider that charges payments made without a
+// stored payment method while payments are mocked. It takes any token.
+const MockGateway = "mock"
+
+// MockOptions makes a MockProvider's outcomes and timing controllable, so
+// tests and demos can rely on them
+type MockOptions struct {
+	ApprovalRate float64       // Share of charges approved, 0-1
+	Latency      time.Duration // Simulated provider round trip; 0 answers at once
+	Seed         uint64        // Non-zero makes the approve/decline sequence repeatable
+
+	// Test hooks; they default to the wall clock and a random source
+	Now  func() time.Time
+	Rand func() float64 // Values in [0, 1); takes precedence over Seed
+}
+
+// MockProvider accepts the provider's test tokens and approves charges at
+// the configured rate. Test tokens containing "Declined" always fail.
+type MockProvider struct {
+	name string
+	opts MockOptions
+}
+
+func NewMockProvider(name string, opts MockOptions) *MockProvider {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.Rand == nil {
+		opts.Rand = randomSource(opts.Seed)
+	}
+	return &MockProvider{name: name, opts: opts}
+}
+
+// randomSource returns a seeded generator, safe for concurrent charges, or
+// the crypto source when seed is zero
+func randomSource(seed uint64) func() float64 {
+	if seed == 0 {
+		return func() float64 {
+			value, _ := utils.CryptoFloat64()
+			return value
+		}
+	}
+
+	var mu sync.Mutex
+	source := rand.New(rand.NewPCG(seed, seed))
+	return func() float64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return source.Float64()
+	}
+}
+
+func (p *MockProvider) Name() string {
+	return p.name
+}
+
+func (p *MockProvider) Retrieve(ctx context.Context, token string) (*Instrument, error) {
+	instrument, ok := mockInstruments[p.name][token]
+	if !ok {
+		return nil, ErrUnknownToken
+	}
+	return &instrument, nil
+}
+
+func (p *MockProvider) Charge(ctx context.Context, token string, amount float64, reference string) (*ChargeResult, error) {
+	if _, ok := mockInstruments[p.name][token]; !ok && p.name != MockGateway {
+		return nil, ErrUnknownToken
+	}
+
+	if err := p.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	if strings.Contains(token, "Declined") || p.opts.Rand() >= p.opts.ApprovalRate {
+		return &ChargeResult{
+			Succeeded: false,
+			Message:   "Payment failed - insufficient funds or card declined",
+		}, nil
+	}
+
+	return &ChargeResult{
+		Succeeded:     true,
+		TransactionID: fmt.Sprintf("%s_%s_%d", strings.ToUpper(p.name), reference, p.opts.Now().Unix()),
+		Message:       "Payment processed successfully",
+	}, nil
+}
+
+// Refund accepts any transaction the mock could have charged
+func (p *MockProvider) Refund(ctx context.Context, transactionID string, amount float64) error {
+	if !strings.HasPrefix(transactionID, strings.ToUpper(p.name)+"_") {
+		return ErrUnknownTransaction
+	}
+	return p.wait(ctx)
+}
+
+// wait simulates the provider's round trip
+func (p *MockProvider) wait(ctx context.Context) error {
+	if p.opts.Latency <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(p.opts.Latency)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
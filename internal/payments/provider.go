@@ -0,0 +1,61 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"eticketing/internal/models"
+)
+
+var ErrUnknownToken = errors.New("payment token not recognised by provider")
+
+var ErrUnknownTransaction = errors.New("transaction not recognised by provider")
+
+// Instrument is what the provider tells us about a vaulted payment method.
+// Raw card data never reaches the backend; the client exchanges it with the
+// provider directly and sends us the resulting token.
+type Instrument struct {
+	Type     models.PaymentType
+	Brand    string
+	Last4    string
+	ExpMonth int
+	ExpYear  int
+	Email    string // Wallet account for PayPal / Google Pay
+}
+
+type ChargeResult struct {
+	Succeeded     bool
+	TransactionID string
+	Message       string
+}
+
+// Provider is a payment vault such as Stripe or Braintree
+type Provider interface {
+	Name() string
+	Retrieve(ctx context.Context, token string) (*Instrument, error)
+	Charge(ctx context.Context, token string, amount float64, reference string) (*ChargeResult, error)
+	// Refund returns amount of a completed charge to the customer
+	Refund(ctx context.Context, transactionID string, amount float64) error
+}
+
+// Registry looks providers up by the name clients send with their tokens
+type Registry struct {
+	providers map[string]Provider
+}
+
+func NewRegistry(providers ...Provider) *Registry {
+	registry := &Registry{providers: make(map[string]Provider)}
+	for _, provider := range providers {
+		registry.providers[provider.Name()] = provider
+	}
+	return registry
+}
+
+func (r *Registry) Get(name string) (Provider, error) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("payment provider %q is not configured", name)
+	}
+	return provider, nil
+}
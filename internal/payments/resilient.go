@@ -0,0 +1,172 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"eticketing/internal/utils"
+)
+
+// ErrProviderUnavailable means the provider could not be reached in time,
+// either after retrying or because its circuit breaker is open. Nothing is
+// known to have been charged; the caller should treat the charge as failed
+// and let the buyer retry.
+var ErrProviderUnavailable = errors.New("payment provider unavailable")
+
+var errCircuitOpen = errors.New("circuit breaker open")
+
+// ResilienceOptions tunes how a ResilientProvider guards provider calls
+type ResilienceOptions struct {
+	Timeout          time.Duration // Per attempt
+	Retries          int           // Extra attempts after the first
+	BackoffBase      time.Duration // Doubled per retry, with jitter
+	BreakerThreshold int           // Consecutive failures that open the circuit
+	BreakerCooldown  time.Duration // How long the circuit stays open before a trial call
+}
+
+// ResilientProvider wraps a Provider with per-attempt timeouts, retries with
+// jittered backoff and a circuit breaker, so a slow or failing gateway fails
+// fast instead of holding purchase requests open. Charges are retried with
+// the same reference, which providers use as the idempotency key.
+type ResilientProvider struct {
+	provider Provider
+	opts     ResilienceOptions
+	breaker  *breaker
+}
+
+func NewResilientProvider(provider Provider, opts ResilienceOptions) *ResilientProvider {
+	return &ResilientProvider{
+		provider: provider,
+		opts:     opts,
+		breaker:  &breaker{threshold: opts.BreakerThreshold, cooldown: opts.BreakerCooldown},
+	}
+}
+
+func (p *ResilientProvider) Name() string {
+	return p.provider.Name()
+}
+
+func (p *ResilientProvider) Retrieve(ctx context.Context, token string) (*Instrument, error) {
+	return guard(ctx, p, p.opts.Retries, func(ctx context.Context) (*Instrument, error) {
+		return p.provider.Retrieve(ctx, token)
+	})
+}
+
+func (p *ResilientProvider) Charge(ctx context.Context, token string, amount float64, reference string) (*ChargeResult, error) {
+	return guard(ctx, p, p.opts.Retries, func(ctx context.Context) (*ChargeResult, error) {
+		return p.provider.Charge(ctx, token, amount, reference)
+	})
+}
+
+// Refund is guarded like a charge but never retried. Refunds carry no
+// idempotency key, so a retry after a lost answer could refund twice.
+func (p *ResilientProvider) Refund(ctx context.Context, transactionID string, amount float64) error {
+	_, err := guard(ctx, p, 0, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, p.provider.Refund(ctx, transactionID, amount)
+	})
+	return err
+}
+
+func guard[T any](ctx context.Context, p *ResilientProvider, retries int, call func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	for attempt := 0; ; attempt++ {
+		if !p.breaker.allow() {
+			return zero, fmt.Errorf("%w: %s: %v", ErrProviderUnavailable, p.Name(), errCircuitOpen)
+		}
+
+		attemptCtx := ctx
+		cancel := context.CancelFunc(func() {})
+		if p.opts.Timeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, p.opts.Timeout)
+		}
+		result, err := call(attemptCtx)
+		cancel()
+
+		// An answer, even a refusal such as an unknown token, shows the
+		// provider is up
+		if err == nil || errors.Is(err, ErrUnknownToken) || errors.Is(err, ErrUnknownTransaction) {
+			p.breaker.record(true)
+			return result, err
+		}
+		if ctx.Err() != nil {
+			// The caller gave up; that says nothing about the provider
+			p.breaker.release()
+			return zero, ctx.Err()
+		}
+
+		p.breaker.record(false)
+		if attempt >= retries {
+			return zero, fmt.Errorf("%w: %s: %v", ErrProviderUnavailable, p.Name(), err)
+		}
+
+		select {
+		case <-time.After(backoff(p.opts.BackoffBase, attempt)):
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// backoff waits between half and all of base*2^attempt, so retries from
+// concurrent requests spread out instead of arriving together
+func backoff(base time.Duration, attempt int) time.Duration {
+	wait := base << attempt
+	jitter, _ := utils.CryptoFloat64()
+	return wait/2 + time.Duration(jitter*float64(wait/2))
+}
+
+// breaker opens after threshold consecutive failures. Once the cooldown has
+// passed a single trial call is let through: success closes the circuit,
+// failure keeps it open for another cooldown.
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func (b *breaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if time.Now().Before(b.openUntil) || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// release ends a trial call without a verdict
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *breaker) record(ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if ok {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
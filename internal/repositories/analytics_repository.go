@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type analyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepository(db *gorm.DB) AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+// CreateBatch stores events, skipping any whose SourceID was already
+// recorded so a redelivered outbox message isn't counted twice
+func (r *analyticsRepository) CreateBatch(events []models.AnalyticsEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&events).Error
+}
+
+// AnalyticsStepTotal aggregates one type of step for an event
+type AnalyticsStepTotal struct {
+	Type     models.AnalyticsEventType
+	Events   int64
+	Visitors int64
+	Quantity int64
+}
+
+func (r *analyticsRepository) SumByType(eventID uint, from, to int64) ([]AnalyticsStepTotal, error) {
+	var totals []AnalyticsStepTotal
+	err := r.db.Model(&models.AnalyticsEvent{}).
+		Select("type, COUNT(*) AS events, COUNT(DISTINCT visitor_id) AS visitors, COALESCE(SUM(quantity), 0) AS quantity").
+		Where("event_id = ? AND occurred_at BETWEEN ? AND ?", eventID, from, to).
+		Group("type").
+		Scan(&totals).Error
+	return totals, err
+}
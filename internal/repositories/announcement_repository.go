@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type announcementRepository struct {
+	db *gorm.DB
+}
+
+func NewAnnouncementRepository(db *gorm.DB) AnnouncementRepository {
+	return &announcementRepository{db: db}
+}
+
+func (r *announcementRepository) Create(announcement *models.Announcement) error {
+	return r.db.Create(announcement).Error
+}
+
+func (r *announcementRepository) Update(announcement *models.Announcement) error {
+	return r.db.Save(announcement).Error
+}
+
+func (r *announcementRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Announcement{}, id).Error
+}
+
+func (r *announcementRepository) GetByID(id uint) (*models.Announcement, error) {
+	var announcement models.Announcement
+	if err := r.db.First(&announcement, id).Error; err != nil {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+// List returns every announcement, latest to start first
+func (r *announcementRepository) List(limit, offset int) ([]models.Announcement, error) {
+	var announcements []models.Announcement
+	err := r.db.Order("starts_at DESC, id DESC").Limit(limit).Offset(offset).Find(&announcements).Error
+	return announcements, err
+}
+
+func (r *announcementRepository) Count() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Announcement{}).Count(&count).Error
+	return count, err
+}
+
+// ListActive returns the announcements for audiences running at the given
+// time, latest to start first
+func (r *announcementRepository) ListActive(audiences []models.AnnouncementAudience, at int64) ([]models.Announcement, error) {
+	var announcements []models.Announcement
+	err := r.db.Where("audience IN ? AND starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)", audiences, at, at).
+		Order("starts_at DESC, id DESC").
+		Find(&announcements).Error
+	return announcements, err
+}
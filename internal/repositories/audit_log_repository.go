@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+// AuditLogFilter narrows the audit log; zero values match everything
+type AuditLogFilter struct {
+	ActorID          uint
+	ActorType        models.UserType
+	ImpersonatedOnly bool
+}
+
+// IPAccount is an account seen making requests from an IP
+type IPAccount struct {
+	IP       string
+	UserID   uint
+	Requests int64
+	LastSeen int64 // Unix timestamp
+}
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+func (r *auditLogRepository) WithTx(tx *gorm.DB) AuditLogRepository {
+	return &auditLogRepository{db: tx}
+}
+
+func (r *auditLogRepository) Create(entry *models.AuditLog) error {
+	return r.db.Create(entry).Error
+}
+
+// List returns entries newest first
+func (r *auditLogRepository) List(filter AuditLogFilter, limit, offset int) ([]models.AuditLog, error) {
+	var entries []models.AuditLog
+	err := r.filtered(filter).
+		Order("id DESC").
+		Limit(limit).Offset(offset).
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *auditLogRepository) Count(filter AuditLogFilter) (int64, error) {
+	var count int64
+	err := r.filtered(filter).Model(&models.AuditLog{}).Count(&count).Error
+	return count, err
+}
+
+func (r *auditLogRepository) filtered(filter AuditLogFilter) *gorm.DB {
+	query := r.db
+	if filter.ActorID > 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.ActorType > 0 {
+		query = query.Where("actor_type = ?", filter.ActorType)
+	}
+	if filter.ImpersonatedOnly {
+		query = query.Where("impersonator_id IS NOT NULL")
+	}
+	return query
+}
+
+// ListSharedIPs returns the customer accounts behind the limit IPs that
+// the most accounts made requests from between from and to, counting only
+// IPs used by at least minAccounts of them. Impersonated requests are left
+// out, as they come from the admin.
+func (r *auditLogRepository) ListSharedIPs(from, to int64, minAccounts, limit int) ([]IPAccount, error) {
+	customerRequests := func() *gorm.DB {
+		return r.db.Model(&models.AuditLog{}).
+			Where("actor_type = ? AND impersonator_id IS NULL AND ip <> ''", models.UserTypeUser).
+			Where("created_at BETWEEN ? AND ?", from, to)
+	}
+
+	var ips []string
+	err := customerRequests().
+		Group("ip").
+		Having("COUNT(DISTINCT actor_id) >= ?", minAccounts).
+		Order("COUNT(DISTINCT actor_id) DESC").
+		Limit(limit).
+		Pluck("ip", &ips).Error
+	if err != nil || len(ips) == 0 {
+		return nil, err
+	}
+
+	var accounts []IPAccount
+	err = customerRequests().
+		Select("ip, actor_id AS user_id, COUNT(*) AS requests, MAX(created_at) AS last_seen").
+		Where("ip IN ?", ips).
+		Group("ip, actor_id").
+		Order("ip, actor_id").
+		Scan(&accounts).Error
+	return accounts, err
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type bulkOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewBulkOrderRepository(db *gorm.DB) BulkOrderRepository {
+	return &bulkOrderRepository{db: db}
+}
+
+func (r *bulkOrderRepository) Create(order *models.BulkOrder) error {
+	return r.db.Create(order).Error
+}
+
+func (r *bulkOrderRepository) Update(order *models.BulkOrder) error {
+	return r.db.Omit("Event").Save(order).Error
+}
+
+func (r *bulkOrderRepository) GetByID(id uint) (*models.BulkOrder, error) {
+	var order models.BulkOrder
+	err := r.db.Preload("Event").First(&order, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *bulkOrderRepository) ListByUser(userID uint) ([]models.BulkOrder, error) {
+	var orders []models.BulkOrder
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&orders).Error
+	return orders, err
+}
+
+func (r *bulkOrderRepository) ListByEvent(eventID uint) ([]models.BulkOrder, error) {
+	var orders []models.BulkOrder
+	err := r.db.Where("event_id = ?", eventID).Order("created_at DESC").Find(&orders).Error
+	return orders, err
+}
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type dataExportRepository struct {
+	db *gorm.DB
+}
+
+func NewDataExportRepository(db *gorm.DB) DataExportRepository {
+	return &dataExportRepository{db: db}
+}
+
+func (r *dataExportRepository) WithTx(tx *gorm.DB) DataExportRepository {
+	return &dataExportRepository{db: tx}
+}
+
+func (r *dataExportRepository) Create(export *models.DataExport) error {
+	return r.db.Create(export).Error
+}
+
+func (r *dataExportRepository) Update(export *models.DataExport) error {
+	return r.db.Save(export).Error
+}
+
+func (r *dataExportRepository) GetByID(id uint) (*models.DataExport, error) {
+	var export models.DataExport
+	err := r.db.First(&export, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *dataExportRepository) GetByToken(token string) (*models.DataExport, error) {
+	var export models.DataExport
+	err := r.db.Where("token = ?", token).First(&export).Error
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// GetLatestByUser returns the user's most recent export, without its archive
+func (r *dataExportRepository) GetLatestByUser(userID uint) (*models.DataExport, error) {
+	var export models.DataExport
+	err := r.db.Omit("Archive").Where("user_id = ?", userID).Order("id DESC").First(&export).Error
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *dataExportRepository) HasPending(userID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.DataExport{}).
+		Where("user_id = ? AND status = ?", userID, models.DataExportStatusPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// DeleteExpired removes exports whose download link has expired
+func (r *dataExportRepository) DeleteExpired(now int64) (int64, error) {
+	result := r.db.Where("expires_at < ?", now).Delete(&models.DataExport{})
+	return result.RowsAffected, result.Error
+}
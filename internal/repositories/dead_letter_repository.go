@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+// DeadLetterFilter narrows the dead letter list; zero values mean "no filter"
+type DeadLetterFilter struct {
+	Topic  string
+	Status models.DeadLetterStatus
+}
+
+type deadLetterRepository struct {
+	db *gorm.DB
+}
+
+func NewDeadLetterRepository(db *gorm.DB) DeadLetterRepository {
+	return &deadLetterRepository{db: db}
+}
+
+func (r *deadLetterRepository) WithTx(tx *gorm.DB) DeadLetterRepository {
+	return &deadLetterRepository{db: tx}
+}
+
+func (r *deadLetterRepository) GetByID(id uint) (*models.DeadLetter, error) {
+	var letter models.DeadLetter
+	err := r.db.First(&letter, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &letter, nil
+}
+
+func (r *deadLetterRepository) Update(letter *models.DeadLetter) error {
+	return r.db.Save(letter).Error
+}
+
+func (r *deadLetterRepository) List(filter DeadLetterFilter, limit, offset int) ([]models.DeadLetter, error) {
+	var letters []models.DeadLetter
+	err := r.filtered(filter).
+		Order("failed_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&letters).Error
+	return letters, err
+}
+
+func (r *deadLetterRepository) Count(filter DeadLetterFilter) (int64, error) {
+	var count int64
+	err := r.filtered(filter).Count(&count).Error
+	return count, err
+}
+
+func (r *deadLetterRepository) filtered(filter DeadLetterFilter) *gorm.DB {
+	query := r.db.Model(&models.DeadLetter{})
+	if filter.Topic != "" {
+		query = query.Where("topic = ?", filter.Topic)
+	}
+	if filter.Status != 0 {
+		query = query.Where("status = ?", filter.Status)
+	}
+	return query
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type denylistRepository struct {
+	db *gorm.DB
+}
+
+func NewDenylistRepository(db *gorm.DB) DenylistRepository {
+	return &denylistRepository{db: db}
+}
+
+func (r *denylistRepository) WithTx(tx *gorm.DB) DenylistRepository {
+	return &denylistRepository{db: tx}
+}
+
+func (r *denylistRepository) Create(entry *models.DenylistEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *denylistRepository) Update(entry *models.DenylistEntry) error {
+	return r.db.Save(entry).Error
+}
+
+func (r *denylistRepository) Delete(id uint) error {
+	return r.db.Delete(&models.DenylistEntry{}, id).Error
+}
+
+func (r *denylistRepository) GetByID(id uint) (*models.DenylistEntry, error) {
+	var entry models.DenylistEntry
+	if err := r.db.First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// List returns entries newest first, of one kind or, with kind 0, of all
+func (r *denylistRepository) List(kind models.DenylistKind, limit, offset int) ([]models.DenylistEntry, error) {
+	var entries []models.DenylistEntry
+	err := r.filter(kind).Order("id DESC").Limit(limit).Offset(offset).Find(&entries).Error
+	return entries, err
+}
+
+func (r *denylistRepository) Count(kind models.DenylistKind) (int64, error) {
+	var count int64
+	err := r.filter(kind).Count(&count).Error
+	return count, err
+}
+
+// FindMatches returns the entries of kind whose value is one of values
+func (r *denylistRepository) FindMatches(kind models.DenylistKind, values []string) ([]models.DenylistEntry, error) {
+	var entries []models.DenylistEntry
+	if len(values) == 0 {
+		return entries, nil
+	}
+	err := r.db.Where("kind = ? AND value IN ?", kind, values).Find(&entries).Error
+	return entries, err
+}
+
+func (r *denylistRepository) ListByKind(kind models.DenylistKind) ([]models.DenylistEntry, error) {
+	var entries []models.DenylistEntry
+	err := r.db.Where("kind = ?", kind).Find(&entries).Error
+	return entries, err
+}
+
+func (r *denylistRepository) filter(kind models.DenylistKind) *gorm.DB {
+	query := r.db.Model(&models.DenylistEntry{})
+	if kind > 0 {
+		query = query.Where("kind = ?", kind)
+	}
+	return query
+}
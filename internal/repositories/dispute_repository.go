@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type disputeRepository struct {
+	db *gorm.DB
+}
+
+func NewDisputeRepository(db *gorm.DB) DisputeRepository {
+	return &disputeRepository{db: db}
+}
+
+func (r *disputeRepository) WithTx(tx *gorm.DB) DisputeRepository {
+	return &disputeRepository{db: tx}
+}
+
+func (r *disputeRepository) Create(dispute *models.Dispute) error {
+	return r.db.Create(dispute).Error
+}
+
+func (r *disputeRepository) Update(dispute *models.Dispute) error {
+	return r.db.Save(dispute).Error
+}
+
+// UpdateStatusUnlessResolved moves a dispute that is not yet won or lost to
+// status. It reports false when the dispute was decided first.
+func (r *disputeRepository) UpdateStatusUnlessResolved(id uint, status models.DisputeStatus) (bool, error) {
+	result := r.db.Model(&models.Dispute{}).
+		Where("id = ? AND status NOT IN ?", id, []models.DisputeStatus{models.DisputeStatusWon, models.DisputeStatusLost}).
+		Update("status", status)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *disputeRepository) GetByID(id uint) (*models.Dispute, error) {
+	var dispute models.Dispute
+	err := r.db.First(&dispute, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &dispute, nil
+}
+
+func (r *disputeRepository) GetByProviderID(provider, providerDisputeID string) (*models.Dispute, error) {
+	var dispute models.Dispute
+	err := r.db.Where("provider = ? AND provider_dispute_id = ?", provider, providerDisputeID).First(&dispute).Error
+	if err != nil {
+		return nil, err
+	}
+	return &dispute, nil
+}
+
+// HasOpenForPayments reports whether any of the payments has a dispute that
+// hasn't been decided yet
+func (r *disputeRepository) HasOpenForPayments(paymentIDs []uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Dispute{}).
+		Where("payment_id IN ? AND status IN ?", paymentIDs, []models.DisputeStatus{models.DisputeStatusOpen, models.DisputeStatusUnderReview}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// List returns disputes newest first; a zero status matches every status
+func (r *disputeRepository) List(status models.DisputeStatus, limit, offset int) ([]models.Dispute, error) {
+	var disputes []models.Dispute
+	err := r.filtered(status).
+		Order("opened_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&disputes).Error
+	return disputes, err
+}
+
+func (r *disputeRepository) Count(status models.DisputeStatus) (int64, error) {
+	var count int64
+	err := r.filtered(status).Count(&count).Error
+	return count, err
+}
+
+func (r *disputeRepository) filtered(status models.DisputeStatus) *gorm.DB {
+	query := r.db.Model(&models.Dispute{})
+	if status != 0 {
+		query = query.Where("status = ?", status)
+	}
+	return query
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type eventMemberRepository struct {
+	db *gorm.DB
+}
+
+func NewEventMemberRepository(db *gorm.DB) EventMemberRepository {
+	return &eventMemberRepository{db: db}
+}
+
+func (r *eventMemberRepository) Create(member *models.EventMember) error {
+	return r.db.Create(member).Error
+}
+
+func (r *eventMemberRepository) Get(eventID, sellerID uint) (*models.EventMember, error) {
+	var member models.EventMember
+	err := r.db.Where("event_id = ? AND seller_id = ?", eventID, sellerID).First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *eventMemberRepository) Update(member *models.EventMember) error {
+	return r.db.Save(member).Error
+}
+
+// Delete removes a seller from an event and reports whether they were a member
+func (r *eventMemberRepository) Delete(eventID, sellerID uint) (bool, error) {
+	result := r.db.Where("event_id = ? AND seller_id = ?", eventID, sellerID).Delete(&models.EventMember{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *eventMemberRepository) ListByEvent(eventID uint) ([]models.EventMember, error) {
+	var members []models.EventMember
+	err := r.db.Preload("Seller").
+		Where("event_id = ?", eventID).
+		Order("created_at").
+		Find(&members).Error
+	return members, err
+}
+
+// ListBySeller returns the events a seller co-manages, soonest first
+func (r *eventMemberRepository) ListBySeller(sellerID uint) ([]models.EventMember, error) {
+	var members []models.EventMember
+	err := r.db.Preload("Event").
+		Joins("JOIN events ON events.id = event_members.event_id").
+		Where("event_members.seller_id = ?", sellerID).
+		Order("events.date").
+		Find(&members).Error
+	return members, err
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type eventReportRepository struct {
+	db *gorm.DB
+}
+
+func NewEventReportRepository(db *gorm.DB) EventReportRepository {
+	return &eventReportRepository{db: db}
+}
+
+func (r *eventReportRepository) Create(report *models.EventReport) error {
+	return r.db.Create(report).Error
+}
+
+func (r *eventReportRepository) Update(report *models.EventReport) error {
+	return r.db.Omit("Event").Save(report).Error
+}
+
+func (r *eventReportRepository) GetByID(id uint) (*models.EventReport, error) {
+	var report models.EventReport
+	err := r.db.Preload("Event").First(&report, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *eventReportRepository) HasOpenReport(eventID, reporterID uint, reporterType models.UserType) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.EventReport{}).
+		Where("event_id = ? AND reporter_id = ? AND reporter_type = ? AND status = ?",
+			eventID, reporterID, reporterType, models.EventReportStatusOpen).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// List returns reports oldest first, so the queue is worked in order; a zero
+// status matches every status
+func (r *eventReportRepository) List(status models.EventReportStatus, limit, offset int) ([]models.EventReport, error) {
+	var reports []models.EventReport
+	err := r.filtered(status).
+		Preload("Event").
+		Order("created_at").
+		Limit(limit).Offset(offset).
+		Find(&reports).Error
+	return reports, err
+}
+
+func (r *eventReportRepository) Count(status models.EventReportStatus) (int64, error) {
+	var count int64
+	err := r.filtered(status).Count(&count).Error
+	return count, err
+}
+
+// ResolveOpenByEvent closes every open report against an event, e.g. once
+// the event has been unpublished
+func (r *eventReportRepository) ResolveOpenByEvent(eventID uint, status models.EventReportStatus, adminID uint, note string, resolvedAt int64) (int64, error) {
+	result := r.db.Model(&models.EventReport{}).
+		Where("event_id = ? AND status = ?", eventID, models.EventReportStatusOpen).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"resolved_by": adminID,
+			"note":        note,
+			"resolved_at": resolvedAt,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *eventReportRepository) filtered(status models.EventReportStatus) *gorm.DB {
+	query := r.db.Model(&models.EventReport{})
+	if status != 0 {
+		query = query.Where("status = ?", status)
+	}
+	return query
+}
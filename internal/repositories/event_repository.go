@@ -2,10 +2,40 @@
 package repositories
 
 import (
+	"math"
+	"time"
+
 	"eticketing/internal/models"
 	"gorm.io/gorm"
 )
 
+// earthRadiusKm is the mean Earth radius used by the haversine distance
+const earthRadiusKm = 6371.0
+
+// NearbyEvent is an event with its distance from a search point
+type NearbyEvent struct {
+	Event      models.Event
+	DistanceKm float64
+}
+
+// EventExportFilter narrows the admin event export; zero values match all
+type EventExportFilter struct {
+	Status   models.EventStatus
+	SellerID uint
+}
+
+// EventExportRow is an event with its seller, ticket counts and revenue, as
+// exported for reporting
+type EventExportRow struct {
+	Event            models.Event // Seller preloaded
+	TicketsTotal     int64
+	TicketsSold      int64
+	TicketsCheckedIn int64
+	GrossRevenue     float64 // Completed customer payments
+	RefundedAmount   float64
+	SellerRevenue    float64 // Completed revenue rows credited to the seller
+}
+
 type eventRepository struct {
 	db *gorm.DB
 }
@@ -14,13 +44,17 @@ func NewEventRepository(db *gorm.DB) EventRepository {
 	return &eventRepository{db: db}
 }
 
+func (r *eventRepository) WithTx(tx *gorm.DB) EventRepository {
+	return &eventRepository{db: tx}
+}
+
 func (r *eventRepository) Create(event *models.Event) error {
 	return r.db.Create(event).Error
 }
 
 func (r *eventRepository) GetByID(id uint) (*models.Event, error) {
 	var event models.Event
-	err := r.db.Preload("Seller").Preload("Tickets").First(&event, id).Error
+	err := r.db.Preload("Seller").Preload("Venue").Preload("Tickets").First(&event, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -37,22 +71,147 @@ func (r *eventRepository) Delete(id uint) error {
 
 func (r *eventRepository) ListByStatus(status models.EventStatus, limit, offset int) ([]models.Event, error) {
 	var events []models.Event
-	err := r.db.Preload("Seller").Where("status = ?", status).Order("date").Limit(limit).Offset(offset).Find(&events).Error
+	err := r.db.Preload("Seller").Preload("Venue").Where("status = ?", status).Order("date").Limit(limit).Offset(offset).Find(&events).Error
+	return events, err
+}
+
+// ListPublished returns approved events whose scheduled publication, if
+// any, has happened, soonest first
+func (r *eventRepository) ListPublished(limit, offset int) ([]models.Event, error) {
+	var events []models.Event
+	err := r.db.Preload("Seller").Preload("Venue").
+		Where("status = ? AND publish_at IS NULL", models.EventStatusApproved).
+		Order("date").Limit(limit).Offset(offset).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *eventRepository) CountPublished() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Event{}).
+		Where("status = ? AND publish_at IS NULL", models.EventStatusApproved).
+		Count(&count).Error
+	return count, err
+}
+
+// ListDueForPublication returns approved events whose publish_at has passed
+func (r *eventRepository) ListDueForPublication(now int64, limit int) ([]models.Event, error) {
+	var events []models.Event
+	err := r.db.
+		Where("status = ? AND publish_at <= ?", models.EventStatusApproved, now).
+		Order("publish_at").Limit(limit).
+		Find(&events).Error
 	return events, err
 }
 
 func (r *eventRepository) ListByStatusReverse(status models.EventStatus, limit, offset int) ([]models.Event, error) {
 	var events []models.Event
-	err := r.db.Preload("Seller").Where("status = ?", status).Order("id DESC").Limit(limit).Offset(offset).Find(&events).Error
+	err := r.db.Preload("Seller").Preload("Venue").Where("status = ?", status).Order("id DESC").Limit(limit).Offset(offset).Find(&events).Error
 	return events, err
 }
 
 func (r *eventRepository) ListBySeller(sellerID uint, limit, offset int) ([]models.Event, error) {
 	var events []models.Event
-	err := r.db.Preload("Seller").Where("seller_id = ?", sellerID).Order("id DESC").Limit(limit).Offset(offset).Find(&events).Error
+	err := r.db.Preload("Seller").Preload("Venue").Where("seller_id = ?", sellerID).Order("id DESC").Limit(limit).Offset(offset).Find(&events).Error
+	return events, err
+}
+
+// ListUpcomingBySeller returns the seller's published events dated after
+// the given time, soonest first
+func (r *eventRepository) ListUpcomingBySeller(sellerID uint, after int64, limit int) ([]models.Event, error) {
+	var events []models.Event
+	err := r.db.Preload("Seller").Preload("Venue").
+		Where("seller_id = ? AND status = ? AND publish_at IS NULL AND date > ?", sellerID, models.EventStatusApproved, after).
+		Order("date").Limit(limit).
+		Find(&events).Error
 	return events, err
 }
 
+// ListPublishedByVenue returns the venue's published events, soonest first
+func (r *eventRepository) ListPublishedByVenue(venueID uint, limit, offset int) ([]models.Event, error) {
+	var events []models.Event
+	err := r.db.Preload("Seller").Preload("Venue").
+		Where("venue_id = ? AND status = ? AND publish_at IS NULL", venueID, models.EventStatusApproved).
+		Order("date").Limit(limit).Offset(offset).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *eventRepository) CountPublishedByVenue(venueID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Event{}).
+		Where("venue_id = ? AND status = ? AND publish_at IS NULL", venueID, models.EventStatusApproved).
+		Count(&count).Error
+	return count, err
+}
+
+// CountByVenue counts the venue's events; a zero status counts every status
+func (r *eventRepository) CountByVenue(venueID uint, status models.EventStatus) (int64, error) {
+	var count int64
+	query := r.db.Model(&models.Event{}).Where("venue_id = ?", venueID)
+	if status > 0 {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// ListNearby returns upcoming published events within radiusKm of a point,
+// closest first. Events without their own coordinates use their venue's.
+// A bounding box on latitude narrows the rows before the haversine distance
+// is computed.
+func (r *eventRepository) ListNearby(lat, lng, radiusKm float64, limit int) ([]NearbyEvent, error) {
+	eventLat := "COALESCE(events.latitude, venues.latitude)"
+	eventLng := "COALESCE(events.longitude, venues.longitude)"
+	distance := "? * 2 * ASIN(SQRT(POWER(SIN(RADIANS(" + eventLat + " - ?) / 2), 2) + " +
+		"COS(RADIANS(?)) * COS(RADIANS(" + eventLat + ")) * POWER(SIN(RADIANS(" + eventLng + " - ?) / 2), 2)))"
+	latDelta := radiusKm / (math.Pi * earthRadiusKm / 180)
+
+	candidates := r.db.Table("events").
+		Select("events.id AS id, "+distance+" AS distance_km", earthRadiusKm, lat, lat, lng).
+		Joins("LEFT JOIN venues ON venues.id = events.venue_id").
+		Where("events.status = ? AND events.publish_at IS NULL AND events.date > ?", models.EventStatusApproved, time.Now().Unix()).
+		Where(eventLat+" BETWEEN ? AND ?", lat-latDelta, lat+latDelta).
+		Where(eventLng + " IS NOT NULL")
+
+	var rows []struct {
+		ID         uint
+		DistanceKm float64
+	}
+	err := r.db.Table("(?) AS nearby", candidates).
+		Where("distance_km <= ?", radiusKm).
+		Order("distance_km").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+
+	var events []models.Event
+	if err := r.db.Preload("Seller").Preload("Venue").Where("id IN ?", ids).Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]models.Event, len(events))
+	for _, event := range events {
+		byID[event.ID] = event
+	}
+
+	nearby := make([]NearbyEvent, 0, len(rows))
+	for _, row := range rows {
+		if event, ok := byID[row.ID]; ok {
+			nearby = append(nearby, NearbyEvent{Event: event, DistanceKm: row.DistanceKm})
+		}
+	}
+
+	return nearby, nil
+}
+
 func (r *eventRepository) CountByStatus(status models.EventStatus) (int64, error) {
 	var count int64
 	err := r.db.Model(&models.Event{}).Where("status = ?", status).Count(&count).Error
@@ -71,6 +230,86 @@ func (r *eventRepository) CountBySellerAndStatus(sellerID uint, status models.Ev
 	return count, err
 }
 
+// ListForExport returns up to limit events with IDs above afterID, in ID
+// order, so callers can walk every event in batches without OFFSET
+func (r *eventRepository) ListForExport(filter EventExportFilter, afterID uint, limit int) ([]EventExportRow, error) {
+	query := r.db.Preload("Seller").Where("id > ?", afterID)
+	if filter.Status > 0 {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.SellerID > 0 {
+		query = query.Where("seller_id = ?", filter.SellerID)
+	}
+
+	var events []models.Event
+	if err := query.Order("id").Limit(limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+
+	var ticketCounts []struct {
+		EventID   uint
+		Total     int64
+		Sold      int64
+		CheckedIn int64
+	}
+	err := r.db.Model(&models.Ticket{}).
+		Select(`tickets.event_id AS event_id, COUNT(DISTINCT tickets.id) AS total,
+			COUNT(DISTINCT CASE WHEN tickets.is_sold THEN tickets.id END) AS sold,
+			COUNT(DISTINCT CASE WHEN purchased_tickets.is_used THEN tickets.id END) AS checked_in`).
+		Joins("LEFT JOIN purchased_tickets ON purchased_tickets.ticket_id = tickets.id").
+		Where("tickets.event_id IN ?", ids).
+		Group("tickets.event_id").
+		Scan(&ticketCounts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var revenues []struct {
+		EventID       uint
+		Gross         float64
+		Refunded      float64
+		SellerRevenue float64
+	}
+	err = r.db.Model(&models.Payment{}).
+		Select(`event_id,
+			COALESCE(SUM(CASE WHEN user_type = ? AND status = ? THEN amount ELSE 0 END), 0) AS gross,
+			COALESCE(SUM(CASE WHEN user_type = ? AND status = ? THEN amount ELSE refunded_amount END), 0) AS refunded,
+			COALESCE(SUM(CASE WHEN user_type = ? AND status = ? THEN amount ELSE 0 END), 0) AS seller_revenue`,
+			models.UserTypeUser, models.PaymentStatusCompleted,
+			models.UserTypeUser, models.PaymentStatusRefunded,
+			models.UserTypeSeller, models.PaymentStatusCompleted).
+		Where("event_id IN ? AND parent_payment_id IS NULL", ids).
+		Group("event_id").
+		Scan(&revenues).Error
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]EventExportRow, len(events))
+	index := make(map[uint]*EventExportRow, len(events))
+	for i := range events {
+		rows[i].Event = events[i]
+		index[events[i].ID] = &rows[i]
+	}
+	for _, count := range ticketCounts {
+		row := index[count.EventID]
+		row.TicketsTotal, row.TicketsSold, row.TicketsCheckedIn = count.Total, count.Sold, count.CheckedIn
+	}
+	for _, revenue := range revenues {
+		row := index[revenue.EventID]
+		row.GrossRevenue, row.RefundedAmount, row.SellerRevenue = revenue.Gross, revenue.Refunded, revenue.SellerRevenue
+	}
+	return rows, nil
+}
+
 func (r *eventRepository) CountEventsWithSoldTickets(sellerID uint) (int64, error) {
 	var count int64
 	err := r.db.Model(&models.Event{}).
@@ -83,7 +322,7 @@ func (r *eventRepository) CountEventsWithSoldTickets(sellerID uint) (int64, erro
 func (r *paymentRepository) GetTotalRevenueByUser(userID uint, userType models.UserType) (float64, error) {
 	var total float64
 	err := r.db.Model(&models.Payment{}).
-		Where("user_id = ? AND user_type = ? AND status = ?", userID, userType, models.PaymentStatusCompleted).
+		Where("user_id = ? AND user_type = ? AND status = ? AND parent_payment_id IS NULL", userID, userType, models.PaymentStatusCompleted).
 		Select("COALESCE(SUM(amount), 0)").Scan(&total).Error
 	return total, err
 }
@@ -91,7 +330,7 @@ func (r *paymentRepository) GetTotalRevenueByUser(userID uint, userType models.U
 func (r *paymentRepository) GetPendingRevenueByUser(userID uint, userType models.UserType) (float64, error) {
 	var total float64
 	err := r.db.Model(&models.Payment{}).
-		Where("user_id = ? AND user_type = ? AND status = ?", userID, userType, models.PaymentStatusPending).
+		Where("user_id = ? AND user_type = ? AND status = ? AND parent_payment_id IS NULL", userID, userType, models.PaymentStatusPending).
 		Select("COALESCE(SUM(amount), 0)").Scan(&total).Error
 	return total, err
 }
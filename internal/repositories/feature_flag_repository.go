@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type featureFlagRepository struct {
+	db *gorm.DB
+}
+
+func NewFeatureFlagRepository(db *gorm.DB) FeatureFlagRepository {
+	return &featureFlagRepository{db: db}
+}
+
+func (r *featureFlagRepository) Create(flag *models.FeatureFlag) error {
+	return r.db.Create(flag).Error
+}
+
+func (r *featureFlagRepository) Update(flag *models.FeatureFlag) error {
+	return r.db.Save(flag).Error
+}
+
+func (r *featureFlagRepository) Delete(id uint) error {
+	return r.db.Delete(&models.FeatureFlag{}, id).Error
+}
+
+func (r *featureFlagRepository) GetByID(id uint) (*models.FeatureFlag, error) {
+	var flag models.FeatureFlag
+	err := r.db.First(&flag, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
+
+func (r *featureFlagRepository) GetByKey(key string) (*models.FeatureFlag, error) {
+	var flag models.FeatureFlag
+	err := r.db.Where("`key` = ?", key).First(&flag).Error
+	if err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
+
+func (r *featureFlagRepository) List() ([]models.FeatureFlag, error) {
+	var flags []models.FeatureFlag
+	err := r.db.Order("`key` ASC").Find(&flags).Error
+	return flags, err
+}
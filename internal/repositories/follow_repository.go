@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type followRepository struct {
+	db *gorm.DB
+}
+
+func NewFollowRepository(db *gorm.DB) FollowRepository {
+	return &followRepository{db: db}
+}
+
+func (r *followRepository) Create(follow *models.Follow) error {
+	return r.db.Create(follow).Error
+}
+
+// Delete removes the user's follow of a seller and reports whether there
+// was one
+func (r *followRepository) Delete(userID, sellerID uint) (bool, error) {
+	result := r.db.Where("user_id = ? AND seller_id = ?", userID, sellerID).Delete(&models.Follow{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *followRepository) Exists(userID, sellerID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Follow{}).
+		Where("user_id = ? AND seller_id = ?", userID, sellerID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ListByUser returns the sellers a user follows, most recently followed first
+func (r *followRepository) ListByUser(userID uint, limit, offset int) ([]models.Follow, error) {
+	var follows []models.Follow
+	err := r.db.Preload("Seller").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&follows).Error
+	return follows, err
+}
+
+func (r *followRepository) CountByUser(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Follow{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// ListFollowers returns everyone following a seller, with their accounts
+func (r *followRepository) ListFollowers(sellerID uint) ([]models.Follow, error) {
+	var follows []models.Follow
+	err := r.db.Preload("User").Where("seller_id = ?", sellerID).Find(&follows).Error
+	return follows, err
+}
+
+func (r *followRepository) CountFollowers(sellerID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Follow{}).Where("seller_id = ?", sellerID).Count(&count).Error
+	return count, err
+}
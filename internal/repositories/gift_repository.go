@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type giftRepository struct {
+	db *gorm.DB
+}
+
+func NewGiftRepository(db *gorm.DB) GiftRepository {
+	return &giftRepository{db: db}
+}
+
+func (r *giftRepository) WithTx(tx *gorm.DB) GiftRepository {
+	return &giftRepository{db: tx}
+}
+
+func (r *giftRepository) Create(gift *models.TicketGift) error {
+	return r.db.Create(gift).Error
+}
+
+func (r *giftRepository) Update(gift *models.TicketGift) error {
+	return r.db.Save(gift).Error
+}
+
+func (r *giftRepository) GetByPurchasedTicket(purchasedTicketID uint) (*models.TicketGift, error) {
+	var gift models.TicketGift
+	err := r.db.Where("purchased_ticket_id = ?", purchasedTicketID).First(&gift).Error
+	if err != nil {
+		return nil, err
+	}
+	return &gift, nil
+}
+
+func (r *giftRepository) ListPendingByEmail(email string) ([]models.TicketGift, error) {
+	var gifts []models.TicketGift
+	err := r.db.Where("recipient_email = ? AND status = ?", email, models.GiftStatusPending).Find(&gifts).Error
+	return gifts, err
+}
@@ -1,8 +1,11 @@
 // internal/repositories/interfaces.go
 package repositories
 
+//go:generate go tool mockgen -source=interfaces.go -destination=mocks/repositories.go -package=mocks
+
 import (
 	"eticketing/internal/models"
+	"gorm.io/gorm"
 )
 
 type UserRepository interface {
@@ -10,13 +13,18 @@ type UserRepository interface {
 	GetByID(id uint) (*models.User, error)
 	GetByEmail(email string) (*models.User, error)
 	GetByUsername(username string) (*models.User, error)
+	GetByCalendarToken(token string) (*models.User, error)
 	Update(user *models.User) error
 	Delete(id uint) error
 	List(limit, offset int) ([]models.User, error)
+	ListByIDs(ids []uint) ([]models.User, error)
 	Count() (int64, error)
+	ListDueForAnonymization(now int64, limit int) ([]models.User, error)
+	Anonymize(userID uint, anonymizedAt int64) error
 }
 
 type SellerRepository interface {
+	WithTx(tx *gorm.DB) SellerRepository
 	Create(seller *models.Seller) error
 	GetByID(id uint) (*models.Seller, error)
 	GetByEmail(email string) (*models.Seller, error)
@@ -25,6 +33,25 @@ type SellerRepository interface {
 	Delete(id uint) error
 	List(limit, offset int) ([]models.Seller, error)
 	Count() (int64, error)
+	UpdateOnboarding(seller *models.Seller, from models.SellerOnboardingStatus) (bool, error)
+	ListByOnboardingStatus(status models.SellerOnboardingStatus, limit, offset int) ([]models.Seller, error)
+	CountByOnboardingStatus(status models.SellerOnboardingStatus) (int64, error)
+}
+
+type SellerDocumentRepository interface {
+	Create(document *models.SellerDocument) error
+	GetByID(id uint) (*models.SellerDocument, error)
+	ListBySeller(sellerID uint) ([]models.SellerDocument, error)
+	Delete(id uint) error
+}
+
+type EventMemberRepository interface {
+	Create(member *models.EventMember) error
+	Get(eventID, sellerID uint) (*models.EventMember, error)
+	Update(member *models.EventMember) error
+	Delete(eventID, sellerID uint) (bool, error)
+	ListByEvent(eventID uint) ([]models.EventMember, error)
+	ListBySeller(sellerID uint) ([]models.EventMember, error)
 }
 
 type AdminRepository interface {
@@ -39,75 +66,173 @@ type AdminRepository interface {
 }
 
 type EventRepository interface {
+	WithTx(tx *gorm.DB) EventRepository
 	Create(event *models.Event) error
 	GetByID(id uint) (*models.Event, error)
 	Update(event *models.Event) error
 	Delete(id uint) error
 	ListByStatus(status models.EventStatus, limit, offset int) ([]models.Event, error)
 	ListByStatusReverse(status models.EventStatus, limit, offset int) ([]models.Event, error)
+	ListPublished(limit, offset int) ([]models.Event, error)
+	CountPublished() (int64, error)
+	ListDueForPublication(now int64, limit int) ([]models.Event, error)
 	ListBySeller(sellerID uint, limit, offset int) ([]models.Event, error)
+	ListUpcomingBySeller(sellerID uint, after int64, limit int) ([]models.Event, error)
 	CountByStatus(status models.EventStatus) (int64, error)
 	CountBySellerAndStatus(sellerID uint, status models.EventStatus) (int64, error)
 	CountEventsWithSoldTickets(sellerID uint) (int64, error)
+	ListPublishedByVenue(venueID uint, limit, offset int) ([]models.Event, error)
+	CountPublishedByVenue(venueID uint) (int64, error)
+	CountByVenue(venueID uint, status models.EventStatus) (int64, error)
+	ListNearby(lat, lng, radiusKm float64, limit int) ([]NearbyEvent, error)
+	ListForExport(filter EventExportFilter, afterID uint, limit int) ([]EventExportRow, error)
+}
+
+type VenueRepository interface {
+	Create(venue *models.Venue) error
+	GetByID(id uint) (*models.Venue, error)
+	Update(venue *models.Venue) error
+	Delete(id uint) error
+	List(limit, offset int) ([]models.Venue, error)
+	Count() (int64, error)
+	ListBySeller(sellerID uint) ([]models.Venue, error)
 }
 
 type TicketRepository interface {
+	WithTx(tx *gorm.DB) TicketRepository
 	Create(ticket *models.Ticket) error
+	CreateWithinCapacity(eventID uint, tickets []models.Ticket) error
 	GetByID(id uint) (*models.Ticket, error)
-	GetByIDForUpdate(id uint) (*models.Ticket, error) // New method with locking
+	GetByIDForUpdate(id uint) (*models.Ticket, error) // Locks the row; call inside a transaction
 	Update(ticket *models.Ticket) error
+	SellHeld(ids []uint) (int64, error)
+	ReleaseHeld(ids []uint) error
 	Delete(id uint) error
 	ListByEvent(eventID uint) ([]models.Ticket, error)
 	ListAvailableByEvent(eventID uint) ([]models.Ticket, error)
 	CountAvailableByEvent(eventID uint) (int64, error)
+	CountByEvent(eventID uint) (int64, error)
 
 	// New methods for grouped ticket management
-	ListByGroupCriteria(eventID uint, price float64, ticketType models.TicketType, isVip bool, title, place string, saleID uint, includeSold bool) ([]models.Ticket, error)
-	ListGroupedByEvent(eventID uint) ([]models.GroupedTicket, error)
-	ListAvailableGroupedByEvent(eventID uint) ([]models.GroupedTicket, error)
+	ListByGroup(groupID uint, includeSold bool) ([]models.Ticket, error)
+	CountByGroup(groupID uint) (int64, error)
+	SyncUnsoldWithGroup(group *models.TicketGroup) error
+	ListGroupedByEvent(eventID uint, limit, offset int) ([]models.GroupedTicket, error)
+	CountGroupedByEvent(eventID uint) (int64, error)
+	ListAvailableGroupedByEvent(eventID uint, limit, offset int) ([]models.GroupedTicket, error)
+	CountAvailableGroupedByEvent(eventID uint) (int64, error)
 
-	// New method for locking available tickets during purchase
-	FindAndLockAvailableTickets(eventID uint, price float64, ticketType models.TicketType, isVip bool, title, place string, saleID uint, quantity int) ([]models.Ticket, error)
+	// Locking available tickets during purchase; call inside a transaction
+	FindAndLockAvailableTickets(groupID uint, quantity int) ([]models.Ticket, error)
 	GetSellerTicketStats(sellerID uint) (*TicketStats, error)
+	CountSoldByGroup(groupID uint) (int64, error)
+	LockAvailableByGroup(groupID uint, limit int) ([]models.Ticket, error)
+	DeleteByIDs(ids []uint) error
+	MoveToGroup(ids []uint, group *models.TicketGroup) error
+}
+
+type TicketGroupRepository interface {
+	WithTx(tx *gorm.DB) TicketGroupRepository
+	Create(group *models.TicketGroup) error
+	GetByID(id uint) (*models.TicketGroup, error)
+	Update(group *models.TicketGroup) error
+	Delete(id uint) error
+	FindByDetails(eventID, saleID uint, price float64, ticketType models.TicketType, isVip bool, title, place string) (*models.TicketGroup, error)
 }
 
 type PurchasedTicketRepository interface {
+	WithTx(tx *gorm.DB) PurchasedTicketRepository
 	Create(ticket *models.PurchasedTicket) error
 	GetByID(id uint) (*models.PurchasedTicket, error)
-	UpdateOwnership(ticketID uint, newUserID uint) error
+	UpdateOwnership(ticketID, fromUserID, newUserID uint) (bool, error)
+	RotateQRSecret(ticketID uint) (string, error)
 	ListByUser(userID uint) ([]models.PurchasedTicket, error)
 	CountByUser(userID uint) (int64, error)
+	ListOwned(userID uint, filter OwnedTicketFilter, limit, offset int) ([]models.PurchasedTicket, error)
+	CountOwned(userID uint, filter OwnedTicketFilter) (int64, error)
+	ListAttendeesByEvent(eventID uint, filter AttendeeFilter, limit, offset int) ([]models.PurchasedTicket, error)
+	ListAttendeesAfter(eventID uint, filter AttendeeFilter, afterID uint, limit int) ([]models.PurchasedTicket, error)
+	CountAttendeesByEvent(eventID uint, filter AttendeeFilter) (int64, error)
+	MarkUsed(id uint, usedAt int64) (bool, error)
+	ListByPayment(paymentID uint) ([]models.PurchasedTicket, error)
+	ListByPayments(paymentIDs []uint) ([]models.PurchasedTicket, error)
+	InvalidateByPayment(paymentID uint, invalidatedAt int64) (int64, error)
+	InvalidateTickets(paymentID uint, ticketIDs []uint, invalidatedAt int64) (int64, error)
+	CountSoldByHour(eventID uint, from, to int64) ([]SalesBucket, error)
 }
 
 type PaymentRepository interface {
+	WithTx(tx *gorm.DB) PaymentRepository
 	Create(payment *models.Payment) error
 	GetByID(id uint) (*models.Payment, error)
+	GetByTransactionID(transactionID string) (*models.Payment, error)
 	Update(payment *models.Payment) error
 	ListByUser(userID uint, limit, offset int) ([]models.Payment, error)
-	ListByUserAndType(userID uint, userType models.UserType, limit, offset int) ([]models.Payment, error) // Add this
+	ListByUserAndType(userID uint, userType models.UserType, filter PaymentFilter, limit, offset int) ([]models.Payment, error)
+	CountByUserAndType(userID uint, userType models.UserType, filter PaymentFilter) (int64, error)
 	GetTotalRevenue() (float64, error)
 	CountTransactions() (int64, error)
 	GetTotalRevenueByUser(userID uint, userType models.UserType) (float64, error)
 	GetPendingRevenueByUser(userID uint, userType models.UserType) (float64, error)
+	ListByParent(parentID uint) ([]models.Payment, error)
+	AddRefunded(id uint, amount float64) (bool, error)
+	CreateRefund(refund *models.Refund) error
+	ListRefunds(paymentID uint) ([]models.Refund, error)
+	FailPendingBefore(before int64) (int64, error)
+	SumByStatus(from, to int64) ([]PaymentStatusTotal, error)
+	SumByProvider(from, to int64) ([]PaymentProviderTotal, error)
+	SumRevenueByEvent(from, to int64) ([]EventRevenueTotal, error)
+	ClearClientInfoBefore(before int64) (int64, error)
+	CountByUserSince(userID uint, userType models.UserType, since int64) (int64, error)
+	CountByClientIPSince(clientIP string, since int64) (int64, error)
+	ListSharedDevices(from, to int64, minAccounts, limit int) ([]DeviceAccount, error)
 }
 
 type TransferRepository interface {
+	WithTx(tx *gorm.DB) TransferRepository
 	CreateActive(transfer *models.ActiveTicketTransfer) error
 	GetActiveByID(id uint) (*models.ActiveTicketTransfer, error)
-	UpdateActive(transfer *models.ActiveTicketTransfer) error
+	AcceptPending(id uint) (bool, error)
+	ReopenAccepted(id uint) error
+	RejectPending(id uint) (bool, error)
 	CreateDone(transfer *models.DoneTicketTransfer) error
-	ListActiveByUser(userID uint) ([]models.ActiveTicketTransfer, error)
-	ListDoneByUser(userID uint) ([]models.DoneTicketTransfer, error)
-	ListRejectedByUser(userID uint) ([]models.ActiveTicketTransfer, error)
+	ListActiveByUser(userID uint, limit, offset int) ([]models.ActiveTicketTransfer, error)
+	CountActiveByUser(userID uint) (int64, error)
+	ListDoneByUser(userID uint, limit, offset int) ([]models.DoneTicketTransfer, error)
+	CountDoneByUser(userID uint) (int64, error)
+	ListRejectedByUser(userID uint, limit, offset int) ([]models.ActiveTicketTransfer, error)
+	CountRejectedByUser(userID uint) (int64, error)
 	HasActiveTransferForTicket(ticketID uint) (bool, error)
+	CreateClaimLink(link *models.TicketClaimLink) error
+	GetClaimLinkByToken(token string) (*models.TicketClaimLink, error)
+	HasOpenClaimLink(ticketID uint, now int64) (bool, error)
+	RevokeClaimLinks(ticketID uint) (int64, error)
+	ClaimOpenLink(id, userID uint, now int64) (bool, error)
+	ReopenClaimLink(id, userID uint) error
+	CancelPendingBefore(before int64) (int64, error)
 }
 
 type SaleRepository interface {
+	WithTx(tx *gorm.DB) SaleRepository
 	Create(sale *models.Sale) error
 	GetByID(id uint) (*models.Sale, error)
 	Update(sale *models.Sale) error
 	Delete(id uint) error
 	ListByEvent(eventID uint) ([]models.Sale, error)
+	List(filter SaleFilter, limit, offset int) ([]models.Sale, error)
+	Count(filter SaleFilter) (int64, error)
+	CountTickets(saleIDs []uint) (map[uint]SaleTicketCount, error)
+	ListLotteriesToDraw(now int64, limit int) ([]models.Sale, error)
+	MarkLotteryDrawn(id uint, drawnAt int64) (bool, error)
+
+	// Per-group allocation caps
+	UpsertAllocation(allocation *models.SaleAllocation) error
+	GetAllocationByID(id uint) (*models.SaleAllocation, error)
+	DeleteAllocation(id uint) error
+	ListAllocations(saleID uint) ([]models.SaleAllocation, error)
+	FindAllocation(saleID, groupID uint) (*models.SaleAllocation, error)
+	ReserveAllocation(id uint, quantity int) (bool, error)
+	ReleaseAllocation(id uint, quantity int) error
 }
 
 type PaymentMethodRepository interface {
@@ -118,4 +243,220 @@ type PaymentMethodRepository interface {
 	ListByUser(userID uint) ([]models.PaymentMethod, error)
 	ClearDefaultForUser(userID uint) error
 	GetDefaultByUser(userID uint) (*models.PaymentMethod, error)
+	ListSharedCards(minAccounts, limit int) ([]models.PaymentMethod, error)
+}
+
+type OutboxRepository interface {
+	WithTx(tx *gorm.DB) OutboxRepository
+	Create(message *models.OutboxMessage) error
+	CreateIfAbsent(messages []*models.OutboxMessage) error
+	Update(message *models.OutboxMessage) error
+	// ClaimDue locks due messages for one dispatcher until claimedUntil
+	ClaimDue(now, claimedUntil int64, limit int) ([]models.OutboxMessage, error)
+	GetByID(id uint) (*models.OutboxMessage, error)
+	// Bury saves a message the dispatcher gave up on together with its dead letter
+	Bury(message *models.OutboxMessage) error
+}
+
+type DeadLetterRepository interface {
+	WithTx(tx *gorm.DB) DeadLetterRepository
+	GetByID(id uint) (*models.DeadLetter, error)
+	Update(letter *models.DeadLetter) error
+	List(filter DeadLetterFilter, limit, offset int) ([]models.DeadLetter, error)
+	Count(filter DeadLetterFilter) (int64, error)
+}
+
+type FeatureFlagRepository interface {
+	Create(flag *models.FeatureFlag) error
+	Update(flag *models.FeatureFlag) error
+	Delete(id uint) error
+	GetByID(id uint) (*models.FeatureFlag, error)
+	GetByKey(key string) (*models.FeatureFlag, error)
+	List() ([]models.FeatureFlag, error)
+}
+
+type AnnouncementRepository interface {
+	Create(announcement *models.Announcement) error
+	Update(announcement *models.Announcement) error
+	Delete(id uint) error
+	GetByID(id uint) (*models.Announcement, error)
+	List(limit, offset int) ([]models.Announcement, error)
+	Count() (int64, error)
+	ListActive(audiences []models.AnnouncementAudience, at int64) ([]models.Announcement, error)
+}
+
+type AnalyticsRepository interface {
+	CreateBatch(events []models.AnalyticsEvent) error
+	SumByType(eventID uint, from, to int64) ([]AnalyticsStepTotal, error)
+}
+
+type MaintenanceRepository interface {
+	Get() (*models.MaintenanceMode, error)
+	Save(mode *models.MaintenanceMode) error
+}
+
+type WebhookRepository interface {
+	Create(webhook *models.SellerWebhook) error
+	GetByID(id uint) (*models.SellerWebhook, error)
+	Delete(id uint) error
+	ListBySeller(sellerID uint) ([]models.SellerWebhook, error)
+	ListActiveBySeller(sellerID uint) ([]models.SellerWebhook, error)
+	CreateDelivery(delivery *models.WebhookDelivery) error
+	ListDeliveries(webhookID uint, limit, offset int) ([]models.WebhookDelivery, error)
+	CountDeliveries(webhookID uint) (int64, error)
+}
+
+type PriceTierRepository interface {
+	WithTx(tx *gorm.DB) PriceTierRepository
+	Create(tier *models.PriceTier) error
+	ListByGroup(groupID uint) ([]models.PriceTier, error)
+	ListByEvent(eventID uint) ([]models.PriceTier, error)
+	DeleteByGroup(groupID uint) error
+}
+
+type OrderRepository interface {
+	WithTx(tx *gorm.DB) OrderRepository
+	Create(order *models.Order) error
+	Update(order *models.Order) error
+	TransitionStatus(id uint, from, to models.OrderStatus) (bool, error)
+	GetByID(id uint) (*models.Order, error)
+	ListExpired(now int64, limit int) ([]models.Order, error)
+	ListByUser(userID uint) ([]models.Order, error)
+	ClearClientInfoBefore(before int64) (int64, error)
+	ListUnderReview(limit, offset int) ([]models.Order, error)
+	CountUnderReview() (int64, error)
+}
+
+type BulkOrderRepository interface {
+	Create(order *models.BulkOrder) error
+	Update(order *models.BulkOrder) error
+	GetByID(id uint) (*models.BulkOrder, error)
+	ListByUser(userID uint) ([]models.BulkOrder, error)
+	ListByEvent(eventID uint) ([]models.BulkOrder, error)
+}
+
+type GiftRepository interface {
+	WithTx(tx *gorm.DB) GiftRepository
+	Create(gift *models.TicketGift) error
+	Update(gift *models.TicketGift) error
+	GetByPurchasedTicket(purchasedTicketID uint) (*models.TicketGift, error)
+	ListPendingByEmail(email string) ([]models.TicketGift, error)
+}
+
+type EventReportRepository interface {
+	Create(report *models.EventReport) error
+	Update(report *models.EventReport) error
+	GetByID(id uint) (*models.EventReport, error)
+	HasOpenReport(eventID, reporterID uint, reporterType models.UserType) (bool, error)
+	List(status models.EventReportStatus, limit, offset int) ([]models.EventReport, error)
+	Count(status models.EventReportStatus) (int64, error)
+	ResolveOpenByEvent(eventID uint, status models.EventReportStatus, adminID uint, note string, resolvedAt int64) (int64, error)
+}
+
+type AuditLogRepository interface {
+	WithTx(tx *gorm.DB) AuditLogRepository
+	Create(entry *models.AuditLog) error
+	List(filter AuditLogFilter, limit, offset int) ([]models.AuditLog, error)
+	Count(filter AuditLogFilter) (int64, error)
+	ListSharedIPs(from, to int64, minAccounts, limit int) ([]IPAccount, error)
+}
+
+type DenylistRepository interface {
+	WithTx(tx *gorm.DB) DenylistRepository
+	Create(entry *models.DenylistEntry) error
+	Update(entry *models.DenylistEntry) error
+	Delete(id uint) error
+	GetByID(id uint) (*models.DenylistEntry, error)
+	List(kind models.DenylistKind, limit, offset int) ([]models.DenylistEntry, error)
+	Count(kind models.DenylistKind) (int64, error)
+	FindMatches(kind models.DenylistKind, values []string) ([]models.DenylistEntry, error)
+	ListByKind(kind models.DenylistKind) ([]models.DenylistEntry, error)
+}
+
+type PolicyRepository interface {
+	WithTx(tx *gorm.DB) PolicyRepository
+	CreateDocument(document *models.PolicyDocument) error
+	GetLatest(kind models.PolicyKind) (*models.PolicyDocument, error)
+	ListLatest() ([]models.PolicyDocument, error)
+	ListVersions(kind models.PolicyKind, limit, offset int) ([]models.PolicyDocument, error)
+	CountVersions(kind models.PolicyKind) (int64, error)
+	CreateAcceptances(acceptances []models.PolicyAcceptance) error
+	ListAcceptances(userID uint, userType models.UserType) ([]models.PolicyAcceptance, error)
+}
+
+type TicketImportRepository interface {
+	WithTx(tx *gorm.DB) TicketImportRepository
+	Create(ticketImport *models.TicketImport) error
+	Update(ticketImport *models.TicketImport) error
+	GetByID(id uint) (*models.TicketImport, error)
+	ListByEvent(eventID uint, limit int) ([]models.TicketImport, error)
+}
+
+type DataExportRepository interface {
+	WithTx(tx *gorm.DB) DataExportRepository
+	Create(export *models.DataExport) error
+	Update(export *models.DataExport) error
+	GetByID(id uint) (*models.DataExport, error)
+	GetByToken(token string) (*models.DataExport, error)
+	GetLatestByUser(userID uint) (*models.DataExport, error)
+	HasPending(userID uint) (bool, error)
+	DeleteExpired(now int64) (int64, error)
+}
+
+type FollowRepository interface {
+	Create(follow *models.Follow) error
+	Delete(userID, sellerID uint) (bool, error)
+	Exists(userID, sellerID uint) (bool, error)
+	ListByUser(userID uint, limit, offset int) ([]models.Follow, error)
+	CountByUser(userID uint) (int64, error)
+	ListFollowers(sellerID uint) ([]models.Follow, error)
+	CountFollowers(sellerID uint) (int64, error)
+}
+
+type RefundRequestRepository interface {
+	WithTx(tx *gorm.DB) RefundRequestRepository
+	Create(request *models.RefundRequest) error
+	Update(request *models.RefundRequest) error
+	GetByID(id uint) (*models.RefundRequest, error)
+	HasPendingForPayment(paymentID uint) (bool, error)
+	ListByUser(userID uint) ([]models.RefundRequest, error)
+	List(status models.RefundRequestStatus, limit, offset int) ([]models.RefundRequest, error)
+	Count(status models.RefundRequestStatus) (int64, error)
+	Resolve(id uint, status models.RefundRequestStatus, note string, refundID *uint, resolvedAt int64) (bool, error)
+	CloseForPastEvents(now int64, note string) (int64, error)
+}
+
+type DisputeRepository interface {
+	WithTx(tx *gorm.DB) DisputeRepository
+	Create(dispute *models.Dispute) error
+	Update(dispute *models.Dispute) error
+	UpdateStatusUnlessResolved(id uint, status models.DisputeStatus) (bool, error)
+	GetByID(id uint) (*models.Dispute, error)
+	GetByProviderID(provider, providerDisputeID string) (*models.Dispute, error)
+	HasOpenForPayments(paymentIDs []uint) (bool, error)
+	List(status models.DisputeStatus, limit, offset int) ([]models.Dispute, error)
+	Count(status models.DisputeStatus) (int64, error)
+}
+
+type LotteryRepository interface {
+	WithTx(tx *gorm.DB) LotteryRepository
+	Create(entry *models.LotteryEntry) error
+	GetBySaleAndUser(saleID, userID uint) (*models.LotteryEntry, error)
+	Delete(id uint) error
+	ListRegistered(saleID uint) ([]models.LotteryEntry, error)
+	CountBySale(saleID uint) (int64, error)
+	SetResults(saleID uint, winnerIDs []uint, purchaseBy int64) error
+	ClaimPurchase(saleID, userID uint, now int64) (bool, error)
+	ReopenPurchase(saleID, userID uint) error
+}
+
+type QueueRepository interface {
+	Create(entry *models.QueueEntry) error
+	GetByToken(token string) (*models.QueueEntry, error)
+	GetOpenByUser(eventID, userID uint) (*models.QueueEntry, error)
+	CountAhead(eventID, entryID uint) (int64, error)
+	ListWaitingEventIDs() ([]uint, error)
+	AdmitNext(eventID uint, limit int, now, expiresAt int64) (int64, error)
+	MarkUsed(id uint) (bool, error)
+	ExpireAdmissions(now int64) (int64, error)
 }
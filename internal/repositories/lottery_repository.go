@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type lotteryRepository struct {
+	db *gorm.DB
+}
+
+func NewLotteryRepository(db *gorm.DB) LotteryRepository {
+	return &lotteryRepository{db: db}
+}
+
+func (r *lotteryRepository) WithTx(tx *gorm.DB) LotteryRepository {
+	return &lotteryRepository{db: tx}
+}
+
+func (r *lotteryRepository) Create(entry *models.LotteryEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *lotteryRepository) GetBySaleAndUser(saleID, userID uint) (*models.LotteryEntry, error) {
+	var entry models.LotteryEntry
+	err := r.db.Where("sale_id = ? AND user_id = ?", saleID, userID).First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *lotteryRepository) Delete(id uint) error {
+	return r.db.Delete(&models.LotteryEntry{}, id).Error
+}
+
+// ListRegistered returns the sale's entries waiting for the draw, with
+// their users for the result notifications
+func (r *lotteryRepository) ListRegistered(saleID uint) ([]models.LotteryEntry, error) {
+	var entries []models.LotteryEntry
+	err := r.db.Preload("User").
+		Where("sale_id = ? AND status = ?", saleID, models.LotteryEntryStatusRegistered).
+		Order("id").Find(&entries).Error
+	return entries, err
+}
+
+func (r *lotteryRepository) CountBySale(saleID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.LotteryEntry{}).Where("sale_id = ?", saleID).Count(&count).Error
+	return count, err
+}
+
+// SetResults marks the winners, who may buy until purchaseBy, and the rest
+// of the sale's registered entries as lost
+func (r *lotteryRepository) SetResults(saleID uint, winnerIDs []uint, purchaseBy int64) error {
+	if len(winnerIDs) > 0 {
+		err := r.db.Model(&models.LotteryEntry{}).
+			Where("id IN ? AND status = ?", winnerIDs, models.LotteryEntryStatusRegistered).
+			Updates(map[string]interface{}{
+				"status":      models.LotteryEntryStatusWon,
+				"purchase_by": purchaseBy,
+			}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.db.Model(&models.LotteryEntry{}).
+		Where("sale_id = ? AND status = ?", saleID, models.LotteryEntryStatusRegistered).
+		Update("status", models.LotteryEntryStatusLost).Error
+}
+
+// ClaimPurchase uses up the user's win of the sale; false when the user
+// didn't win, already bought or let the right expire
+func (r *lotteryRepository) ClaimPurchase(saleID, userID uint, now int64) (bool, error) {
+	result := r.db.Model(&models.LotteryEntry{}).
+		Where("sale_id = ? AND user_id = ? AND status = ? AND purchase_by >= ?",
+			saleID, userID, models.LotteryEntryStatusWon, now).
+		Update("status", models.LotteryEntryStatusPurchased)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ReopenPurchase gives a win used up by a purchase that then fell through
+// back to the user
+func (r *lotteryRepository) ReopenPurchase(saleID, userID uint) error {
+	return r.db.Model(&models.LotteryEntry{}).
+		Where("sale_id = ? AND user_id = ? AND status = ?", saleID, userID, models.LotteryEntryStatusPurchased).
+		Update("status", models.LotteryEntryStatusWon).Error
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"errors"
+
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type maintenanceRepository struct {
+	db *gorm.DB
+}
+
+func NewMaintenanceRepository(db *gorm.DB) MaintenanceRepository {
+	return &maintenanceRepository{db: db}
+}
+
+// Get returns the maintenance switch, switched off when it was never set
+func (r *maintenanceRepository) Get() (*models.MaintenanceMode, error) {
+	var mode models.MaintenanceMode
+	err := r.db.First(&mode, models.MaintenanceModeID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.MaintenanceMode{ID: models.MaintenanceModeID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &mode, nil
+}
+
+func (r *maintenanceRepository) Save(mode *models.MaintenanceMode) error {
+	mode.ID = models.MaintenanceModeID
+	return r.db.Save(mode).Error
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type orderRepository struct {
+	db *gorm.DB
+}
+
+func NewOrderRepository(db *gorm.DB) OrderRepository {
+	return &orderRepository{db: db}
+}
+
+func (r *orderRepository) WithTx(tx *gorm.DB) OrderRepository {
+	return &orderRepository{db: tx}
+}
+
+func (r *orderRepository) Create(order *models.Order) error {
+	return r.db.Create(order).Error
+}
+
+func (r *orderRepository) Update(order *models.Order) error {
+	return r.db.Omit("Items").Save(order).Error
+}
+
+// TransitionStatus moves the order from status from to status to. It
+// reports false when the order had already left from, e.g. because a
+// concurrent review, payment or expiry got there first.
+func (r *orderRepository) TransitionStatus(id uint, from, to models.OrderStatus) (bool, error) {
+	result := r.db.Model(&models.Order{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *orderRepository) GetByID(id uint) (*models.Order, error) {
+	var order models.Order
+	err := r.db.Preload("Items.Ticket").First(&order, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ListExpired lists held orders past their hold, including ones whose
+// charge never settled
+func (r *orderRepository) ListExpired(now int64, limit int) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.Preload("Items.Ticket").
+		Where("status IN ? AND hold_expires_at < ?", []models.OrderStatus{models.OrderStatusAwaitingPayment, models.OrderStatusUnderReview, models.OrderStatusPaying}, now).
+		Order("hold_expires_at").
+		Limit(limit).
+		Find(&orders).Error
+	return orders, err
+}
+
+func (r *orderRepository) ListByUser(userID uint) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.Preload("Items").Where("user_id = ?", userID).Order("id DESC").Find(&orders).Error
+	return orders, err
+}
+
+// ClearClientInfoBefore blanks the IP and device fingerprint of orders
+// placed before the given Unix timestamp
+func (r *orderRepository) ClearClientInfoBefore(before int64) (int64, error) {
+	result := r.db.Model(&models.Order{}).
+		Where("created_at < ? AND (client_ip <> '' OR device_fingerprint <> '')", before).
+		Updates(map[string]interface{}{"client_ip": "", "device_fingerprint": ""})
+	return result.RowsAffected, result.Error
+}
+
+// ListUnderReview returns orders held for a fraud review, oldest first
+func (r *orderRepository) ListUnderReview(limit, offset int) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.db.Where("status = ?", models.OrderStatusUnderReview).
+		Order("created_at").
+		Limit(limit).
+		Offset(offset).
+		Find(&orders).Error
+	return orders, err
+}
+
+func (r *orderRepository) CountUnderReview() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Order{}).Where("status = ?", models.OrderStatusUnderReview).Count(&count).Error
+	return count, err
+}
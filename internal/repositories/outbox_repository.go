@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"time"
+
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) WithTx(tx *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: tx}
+}
+
+func (r *outboxRepository) Create(message *models.OutboxMessage) error {
+	return r.db.Create(message).Error
+}
+
+// CreateIfAbsent stores messages, skipping any whose DedupeKey was already
+// stored
+func (r *outboxRepository) CreateIfAbsent(messages []*models.OutboxMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&messages).Error
+}
+
+func (r *outboxRepository) Update(message *models.OutboxMessage) error {
+	return r.db.Save(message).Error
+}
+
+// ClaimDue locks a batch of due messages, skipping those another
+// dispatcher holds, and pushes their next attempt to claimedUntil so they
+// aren't picked up again while being delivered. Messages left claimed by a
+// dispatcher that died become due again at claimedUntil.
+func (r *outboxRepository) ClaimDue(now, claimedUntil int64, limit int) ([]models.OutboxMessage, error) {
+	var messages []models.OutboxMessage
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.OutboxStatusPending, now).
+			Order("id").
+			Limit(limit).
+			Find(&messages).Error
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(messages))
+		for i := range messages {
+			ids[i] = messages[i].ID
+			messages[i].NextAttemptAt = claimedUntil
+		}
+		return tx.Model(&models.OutboxMessage{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", claimedUntil).Error
+	})
+	return messages, err
+}
+
+func (r *outboxRepository) GetByID(id uint) (*models.OutboxMessage, error) {
+	var message models.OutboxMessage
+	err := r.db.First(&message, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+func (r *outboxRepository) Bury(message *models.OutboxMessage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(message).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.DeadLetter{
+			OutboxMessageID: message.ID,
+			Topic:           message.Topic,
+			Payload:         message.Payload,
+			Attempts:        message.Attempts,
+			LastError:       message.LastError,
+			Status:          models.DeadLetterStatusOpen,
+			FailedAt:        time.Now().Unix(),
+		}).Error
+	})
+}
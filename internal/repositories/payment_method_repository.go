@@ -55,3 +55,33 @@ func (r *paymentMethodRepository) GetDefaultByUser(userID uint) (*models.Payment
 	}
 	return &method, nil
 }
+
+// ListSharedCards returns the customer payment methods whose card token is
+// stored by at least minAccounts accounts, for the limit most shared tokens
+func (r *paymentMethodRepository) ListSharedCards(minAccounts, limit int) ([]models.PaymentMethod, error) {
+	var shared []struct {
+		Provider string
+		Token    string
+	}
+	err := r.db.Model(&models.PaymentMethod{}).
+		Select("provider, token").
+		Where("user_type = ? AND token <> ''", models.UserTypeUser).
+		Group("provider, token").
+		Having("COUNT(DISTINCT user_id) >= ?", minAccounts).
+		Order("COUNT(DISTINCT user_id) DESC").
+		Limit(limit).
+		Scan(&shared).Error
+	if err != nil || len(shared) == 0 {
+		return nil, err
+	}
+
+	pairs := make([][]interface{}, 0, len(shared))
+	for _, card := range shared {
+		pairs = append(pairs, []interface{}{card.Provider, card.Token})
+	}
+
+	var methods []models.PaymentMethod
+	err = r.db.Where("user_type = ? AND (provider, token) IN ?", models.UserTypeUser, pairs).
+		Order("id").Find(&methods).Error
+	return methods, err
+}
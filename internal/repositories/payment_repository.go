@@ -6,6 +6,44 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	PaymentDirectionIncoming = "incoming"
+	PaymentDirectionOutgoing = "outgoing"
+)
+
+const (
+	PaymentSortNewest     = "newest"
+	PaymentSortOldest     = "oldest"
+	PaymentSortAmountDesc = "amount_desc"
+	PaymentSortAmountAsc  = "amount_asc"
+)
+
+var paymentSortOrders = map[string]string{
+	PaymentSortNewest:     "date DESC, id DESC",
+	PaymentSortOldest:     "date ASC, id ASC",
+	PaymentSortAmountDesc: "amount DESC, id DESC",
+	PaymentSortAmountAsc:  "amount ASC, id ASC",
+}
+
+// PaymentFilter narrows a payment history listing. Zero values match
+// everything.
+type PaymentFilter struct {
+	Status    models.PaymentStatus
+	From      int64 // Unix timestamps, inclusive
+	To        int64
+	EventID   uint
+	Direction string // PaymentDirectionIncoming or PaymentDirectionOutgoing
+	MinAmount *float64
+	MaxAmount *float64
+	Sort      string // One of the PaymentSort values; newest first by default
+}
+
+// ValidPaymentSort reports whether sort names a supported ordering.
+func ValidPaymentSort(sort string) bool {
+	_, ok := paymentSortOrders[sort]
+	return ok
+}
+
 type paymentRepository struct {
 	db *gorm.DB
 }
@@ -14,13 +52,26 @@ func NewPaymentRepository(db *gorm.DB) PaymentRepository {
 	return &paymentRepository{db: db}
 }
 
+func (r *paymentRepository) WithTx(tx *gorm.DB) PaymentRepository {
+	return &paymentRepository{db: tx}
+}
+
 func (r *paymentRepository) Create(payment *models.Payment) error {
 	return r.db.Create(payment).Error
 }
 
 func (r *paymentRepository) GetByID(id uint) (*models.Payment, error) {
 	var payment models.Payment
-	err := r.db.Preload("User").First(&payment, id).Error
+	err := r.db.Preload("Event").First(&payment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (r *paymentRepository) GetByTransactionID(transactionID string) (*models.Payment, error) {
+	var payment models.Payment
+	err := r.db.Preload("Event").Where("transaction_id = ?", transactionID).First(&payment).Error
 	if err != nil {
 		return nil, err
 	}
@@ -31,19 +82,70 @@ func (r *paymentRepository) Update(payment *models.Payment) error {
 	return r.db.Save(payment).Error
 }
 
-func (r *paymentRepository) ListByUserAndType(userID uint, userType models.UserType, limit, offset int) ([]models.Payment, error) {
+func (r *paymentRepository) ListByUserAndType(userID uint, userType models.UserType, filter PaymentFilter, limit, offset int) ([]models.Payment, error) {
+	order, ok := paymentSortOrders[filter.Sort]
+	if !ok {
+		order = paymentSortOrders[PaymentSortNewest]
+	}
+
 	var payments []models.Payment
-	err := r.db.Where("user_id = ? AND user_type = ?", userID, userType).
-		Order("date DESC").
+	err := r.paymentListQuery(userID, userType, filter).
+		Order(order).
 		Limit(limit).Offset(offset).
 		Preload("Event").
 		Find(&payments).Error
 	return payments, err
 }
 
+func (r *paymentRepository) CountByUserAndType(userID uint, userType models.UserType, filter PaymentFilter) (int64, error) {
+	var count int64
+	err := r.paymentListQuery(userID, userType, filter).Count(&count).Error
+	return count, err
+}
+
+func (r *paymentRepository) paymentListQuery(userID uint, userType models.UserType, filter PaymentFilter) *gorm.DB {
+	query := r.db.Model(&models.Payment{}).
+		Where("user_id = ? AND user_type = ? AND parent_payment_id IS NULL", userID, userType)
+
+	if filter.Status != 0 {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.From > 0 {
+		query = query.Where("date >= ?", filter.From)
+	}
+	if filter.To > 0 {
+		query = query.Where("date <= ?", filter.To)
+	}
+	if filter.EventID != 0 {
+		query = query.Where("event_id = ?", filter.EventID)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("amount >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("amount <= ?", *filter.MaxAmount)
+	}
+
+	// Seller rows are revenue, except the negative debits left by refunds.
+	// Everything on a customer's account is money going out.
+	switch filter.Direction {
+	case PaymentDirectionIncoming:
+		if userType != models.UserTypeSeller {
+			return query.Where("1 = 0")
+		}
+		query = query.Where("amount >= 0")
+	case PaymentDirectionOutgoing:
+		if userType == models.UserTypeSeller {
+			query = query.Where("amount < 0")
+		}
+	}
+
+	return query
+}
+
 func (r *paymentRepository) ListByUser(userID uint, limit, offset int) ([]models.Payment, error) {
 	var payments []models.Payment
-	err := r.db.Where("user_id = ?", userID).
+	err := r.db.Where("user_id = ? AND parent_payment_id IS NULL", userID).
 		Order("date DESC").
 		Limit(limit).Offset(offset).
 		Preload("Event").
@@ -53,7 +155,7 @@ func (r *paymentRepository) ListByUser(userID uint, limit, offset int) ([]models
 
 func (r *paymentRepository) ListByUserType(userID uint, userType models.UserType, limit, offset int) ([]models.Payment, error) {
 	var payments []models.Payment
-	err := r.db.Where("user_id = ? AND user_type = ?", userID, userType).
+	err := r.db.Where("user_id = ? AND user_type = ? AND parent_payment_id IS NULL", userID, userType).
 		Order("date DESC").
 		Limit(limit).Offset(offset).
 		Preload("Event").
@@ -63,12 +165,187 @@ func (r *paymentRepository) ListByUserType(userID uint, userType models.UserType
 
 func (r *paymentRepository) GetTotalRevenue() (float64, error) {
 	var total float64
-	err := r.db.Model(&models.Payment{}).Where("status = ?", models.PaymentStatusCompleted).Select("COALESCE(SUM(amount), 0)").Scan(&total).Error
+	err := r.db.Model(&models.Payment{}).Where("status = ? AND parent_payment_id IS NULL", models.PaymentStatusCompleted).Select("COALESCE(SUM(amount), 0)").Scan(&total).Error
 	return total, err
 }
 
 func (r *paymentRepository) CountTransactions() (int64, error) {
 	var count int64
-	err := r.db.Model(&models.Payment{}).Count(&count).Error
+	err := r.db.Model(&models.Payment{}).Where("parent_payment_id IS NULL").Count(&count).Error
+	return count, err
+}
+
+func (r *paymentRepository) ListByParent(parentID uint) ([]models.Payment, error) {
+	var payments []models.Payment
+	err := r.db.Where("parent_payment_id = ?", parentID).Order("id").Find(&payments).Error
+	return payments, err
+}
+
+// AddRefunded adds amount to what a completed payment has refunded. It
+// reports false when that would exceed the payment, e.g. because a
+// concurrent refund got there first.
+func (r *paymentRepository) AddRefunded(id uint, amount float64) (bool, error) {
+	result := r.db.Model(&models.Payment{}).
+		Where("id = ? AND status = ? AND refunded_amount + ? <= amount + 0.005", id, models.PaymentStatusCompleted, amount).
+		Update("refunded_amount", gorm.Expr("refunded_amount + ?", amount))
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *paymentRepository) CreateRefund(refund *models.Refund) error {
+	return r.db.Create(refund).Error
+}
+
+func (r *paymentRepository) ListRefunds(paymentID uint) ([]models.Refund, error) {
+	var refunds []models.Refund
+	err := r.db.Preload("Items").Where("payment_id = ?", paymentID).Order("id").Find(&refunds).Error
+	return refunds, err
+}
+
+func (r *paymentRepository) FailPendingBefore(before int64) (int64, error) {
+	result := r.db.Model(&models.Payment{}).
+		Where("status = ? AND date < ? AND type <> ?", models.PaymentStatusPending, before, models.PaymentTypeInvoice).
+		Update("status", models.PaymentStatusFailed)
+	return result.RowsAffected, result.Error
+}
+
+// ClearClientInfoBefore blanks the IP and device fingerprint of payments
+// made before the given Unix timestamp
+func (r *paymentRepository) ClearClientInfoBefore(before int64) (int64, error) {
+	result := r.db.Model(&models.Payment{}).
+		Where("date < ? AND (client_ip <> '' OR device_fingerprint <> '')", before).
+		Updates(map[string]interface{}{"client_ip": "", "device_fingerprint": ""})
+	return result.RowsAffected, result.Error
+}
+
+// CountByUserSince counts the payment attempts of an account since the
+// given Unix timestamp, whatever their outcome
+func (r *paymentRepository) CountByUserSince(userID uint, userType models.UserType, since int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Payment{}).
+		Where("user_id = ? AND user_type = ? AND parent_payment_id IS NULL AND date >= ?", userID, userType, since).
+		Count(&count).Error
+	return count, err
+}
+
+// CountByClientIPSince counts the payment attempts made from an IP since the
+// given Unix timestamp, across accounts
+func (r *paymentRepository) CountByClientIPSince(clientIP string, since int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Payment{}).
+		Where("client_ip = ? AND parent_payment_id IS NULL AND date >= ?", clientIP, since).
+		Count(&count).Error
 	return count, err
 }
+
+// DeviceAccount is a customer account that paid from a device
+type DeviceAccount struct {
+	Fingerprint string
+	UserID      uint
+	Payments    int64
+	LastSeen    int64 // Unix timestamp
+}
+
+// ListSharedDevices returns the customer accounts behind the limit device
+// fingerprints that the most accounts paid from between from and to,
+// counting only devices used by at least minAccounts of them
+func (r *paymentRepository) ListSharedDevices(from, to int64, minAccounts, limit int) ([]DeviceAccount, error) {
+	customerPayments := func() *gorm.DB {
+		return r.db.Model(&models.Payment{}).
+			Where("user_type = ? AND parent_payment_id IS NULL AND device_fingerprint <> ''", models.UserTypeUser).
+			Where("date BETWEEN ? AND ?", from, to)
+	}
+
+	var fingerprints []string
+	err := customerPayments().
+		Group("device_fingerprint").
+		Having("COUNT(DISTINCT user_id) >= ?", minAccounts).
+		Order("COUNT(DISTINCT user_id) DESC").
+		Limit(limit).
+		Pluck("device_fingerprint", &fingerprints).Error
+	if err != nil || len(fingerprints) == 0 {
+		return nil, err
+	}
+
+	var accounts []DeviceAccount
+	err = customerPayments().
+		Select("device_fingerprint AS fingerprint, user_id, COUNT(*) AS payments, MAX(date) AS last_seen").
+		Where("device_fingerprint IN ?", fingerprints).
+		Group("device_fingerprint, user_id").
+		Order("device_fingerprint, user_id").
+		Scan(&accounts).Error
+	return accounts, err
+}
+
+// PaymentStatusTotal aggregates customer payments of one status
+type PaymentStatusTotal struct {
+	Status models.PaymentStatus
+	Count  int64
+	Amount float64
+}
+
+// PaymentProviderTotal aggregates completed customer charges by the provider
+// of the stored method used. Provider is empty for charges made without one.
+type PaymentProviderTotal struct {
+	Provider string
+	Type     models.PaymentType
+	Count    int64
+	Amount   float64
+}
+
+// EventRevenueTotal sets what customers paid for an event against the
+// revenue rows credited to its seller
+type EventRevenueTotal struct {
+	EventID        uint
+	EventTitle     string
+	SellerID       uint
+	CustomerAmount float64
+	SellerAmount   float64
+
+	// What the seller should have been credited for CustomerAmount, at the
+	// fee rate stored on each payment. A payment without one counts in full.
+	ExpectedSellerAmount float64
+}
+
+func (r *paymentRepository) SumByStatus(from, to int64) ([]PaymentStatusTotal, error) {
+	var totals []PaymentStatusTotal
+	err := r.db.Model(&models.Payment{}).
+		Select("status, COUNT(*) AS count, COALESCE(SUM(amount), 0) AS amount").
+		Where("user_type = ? AND parent_payment_id IS NULL AND date BETWEEN ? AND ?", models.UserTypeUser, from, to).
+		Group("status").
+		Order("status").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// SumByProvider counts split components rather than their parents, since
+// each component is charged on its own method
+func (r *paymentRepository) SumByProvider(from, to int64) ([]PaymentProviderTotal, error) {
+	var totals []PaymentProviderTotal
+	err := r.db.Model(&models.Payment{}).
+		Select("COALESCE(payment_methods.provider, '') AS provider, payments.type AS type, COUNT(*) AS count, COALESCE(SUM(payments.amount), 0) AS amount").
+		Joins("LEFT JOIN payment_methods ON payment_methods.id = payments.payment_method_id").
+		Where("payments.user_type = ? AND payments.status = ? AND payments.type <> ? AND payments.date BETWEEN ? AND ?",
+			models.UserTypeUser, models.PaymentStatusCompleted, models.PaymentTypeSplit, from, to).
+		Group("COALESCE(payment_methods.provider, ''), payments.type").
+		Order("provider, type").
+		Scan(&totals).Error
+	return totals, err
+}
+
+func (r *paymentRepository) SumRevenueByEvent(from, to int64) ([]EventRevenueTotal, error) {
+	var totals []EventRevenueTotal
+	err := r.db.Model(&models.Payment{}).
+		Select(`payments.event_id AS event_id, events.title AS event_title, events.seller_id AS seller_id,
+			COALESCE(SUM(CASE WHEN payments.user_type = ? THEN payments.amount - payments.refunded_amount ELSE 0 END), 0) AS customer_amount,
+			COALESCE(SUM(CASE WHEN payments.user_type = ? THEN payments.amount ELSE 0 END), 0) AS seller_amount,
+			COALESCE(SUM(CASE WHEN payments.user_type = ?
+				THEN (payments.amount - payments.refunded_amount) * (1 - COALESCE(payments.platform_fee_rate, 0)) ELSE 0 END), 0) AS expected_seller_amount`,
+			models.UserTypeUser, models.UserTypeSeller, models.UserTypeUser).
+		Joins("JOIN events ON events.id = payments.event_id").
+		Where("payments.status = ? AND payments.parent_payment_id IS NULL AND payments.date BETWEEN ? AND ?",
+			models.PaymentStatusCompleted, from, to).
+		Group("payments.event_id, events.title, events.seller_id").
+		Order("events.seller_id, payments.event_id").
+		Scan(&totals).Error
+	return totals, err
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type policyRepository struct {
+	db *gorm.DB
+}
+
+func NewPolicyRepository(db *gorm.DB) PolicyRepository {
+	return &policyRepository{db: db}
+}
+
+func (r *policyRepository) WithTx(tx *gorm.DB) PolicyRepository {
+	return &policyRepository{db: tx}
+}
+
+func (r *policyRepository) CreateDocument(document *models.PolicyDocument) error {
+	return r.db.Create(document).Error
+}
+
+func (r *policyRepository) GetLatest(kind models.PolicyKind) (*models.PolicyDocument, error) {
+	var document models.PolicyDocument
+	if err := r.db.Where("kind = ?", kind).Order("version DESC").First(&document).Error; err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+// ListLatest returns the version in force of every kind published so far
+func (r *policyRepository) ListLatest() ([]models.PolicyDocument, error) {
+	var documents []models.PolicyDocument
+	latest := r.db.Model(&models.PolicyDocument{}).Select("kind, MAX(version)").Group("kind")
+	err := r.db.Where("(kind, version) IN (?)", latest).Order("kind ASC").Find(&documents).Error
+	return documents, err
+}
+
+// ListVersions returns the versions of kind newest first, or of all kinds
+// with kind 0
+func (r *policyRepository) ListVersions(kind models.PolicyKind, limit, offset int) ([]models.PolicyDocument, error) {
+	var documents []models.PolicyDocument
+	err := r.filter(kind).Order("published_at DESC, id DESC").Limit(limit).Offset(offset).Find(&documents).Error
+	return documents, err
+}
+
+func (r *policyRepository) CountVersions(kind models.PolicyKind) (int64, error) {
+	var count int64
+	err := r.filter(kind).Count(&count).Error
+	return count, err
+}
+
+// CreateAcceptances records acceptances, skipping versions the account
+// had already accepted
+func (r *policyRepository) CreateAcceptances(acceptances []models.PolicyAcceptance) error {
+	if len(acceptances) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&acceptances).Error
+}
+
+func (r *policyRepository) ListAcceptances(userID uint, userType models.UserType) ([]models.PolicyAcceptance, error) {
+	var acceptances []models.PolicyAcceptance
+	err := r.db.Where("user_id = ? AND user_type = ?", userID, userType).
+		Order("accepted_at DESC").
+		Find(&acceptances).Error
+	return acceptances, err
+}
+
+func (r *policyRepository) filter(kind models.PolicyKind) *gorm.DB {
+	query := r.db.Model(&models.PolicyDocument{})
+	if kind > 0 {
+		query = query.Where("kind = ?", kind)
+	}
+	return query
+}
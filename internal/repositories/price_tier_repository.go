@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type priceTierRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceTierRepository(db *gorm.DB) PriceTierRepository {
+	return &priceTierRepository{db: db}
+}
+
+func (r *priceTierRepository) WithTx(tx *gorm.DB) PriceTierRepository {
+	return &priceTierRepository{db: tx}
+}
+
+func (r *priceTierRepository) Create(tier *models.PriceTier) error {
+	return r.db.Create(tier).Error
+}
+
+func (r *priceTierRepository) ListByGroup(groupID uint) ([]models.PriceTier, error) {
+	var tiers []models.PriceTier
+	err := r.db.Where("group_id = ?", groupID).Order("position").Find(&tiers).Error
+	return tiers, err
+}
+
+func (r *priceTierRepository) ListByEvent(eventID uint) ([]models.PriceTier, error) {
+	var tiers []models.PriceTier
+	err := r.db.Where("event_id = ?", eventID).Order("group_id, position").Find(&tiers).Error
+	return tiers, err
+}
+
+func (r *priceTierRepository) DeleteByGroup(groupID uint) error {
+	return r.db.Where("group_id = ?", groupID).Delete(&models.PriceTier{}).Error
+}
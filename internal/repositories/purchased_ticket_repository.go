@@ -2,11 +2,44 @@
 package repositories
 
 import (
-	"errors"
+	"time"
+
 	"eticketing/internal/models"
+	"eticketing/internal/utils"
 	"gorm.io/gorm"
 )
 
+// AttendeeFilter narrows the attendee list; zero values mean "no filter"
+type AttendeeFilter struct {
+	CheckedIn   *bool
+	Search      string // Matches buyer name, surname or email
+	TicketTitle string
+
+	// Only attendees on accessible seating or with an accommodation request
+	NeedsAccommodation bool
+}
+
+// Which of a user's tickets to list, by the date of their event
+const (
+	TicketsUpcoming = "upcoming"
+	TicketsPast     = "past"
+)
+
+// OwnedTicketFilter narrows the tickets a user holds. Zero values match
+// everything.
+type OwnedTicketFilter struct {
+	When    string // TicketsUpcoming or TicketsPast, relative to Now
+	Now     int64  // Unix timestamp
+	EventID uint
+	Used    *bool
+}
+
+// SalesBucket counts the tickets sold in the hour starting at Start
+type SalesBucket struct {
+	Start   int64
+	Tickets int64
+}
+
 type purchasedTicketRepository struct {
 	db *gorm.DB
 }
@@ -15,7 +48,18 @@ func NewPurchasedTicketRepository(db *gorm.DB) PurchasedTicketRepository {
 	return &purchasedTicketRepository{db: db}
 }
 
+func (r *purchasedTicketRepository) WithTx(tx *gorm.DB) PurchasedTicketRepository {
+	return &purchasedTicketRepository{db: tx}
+}
+
 func (r *purchasedTicketRepository) Create(ticket *models.PurchasedTicket) error {
+	if ticket.QRSecret == "" {
+		secret, err := utils.RandomHex(16)
+		if err != nil {
+			return err
+		}
+		ticket.QRSecret = secret
+	}
 	return r.db.Create(ticket).Error
 }
 
@@ -34,19 +78,196 @@ func (r *purchasedTicketRepository) ListByUser(userID uint) ([]models.PurchasedT
 	return tickets, err
 }
 
+// ListOwned returns the user's tickets, soonest event first, or latest
+// first when listing past events
+func (r *purchasedTicketRepository) ListOwned(userID uint, filter OwnedTicketFilter, limit, offset int) ([]models.PurchasedTicket, error) {
+	order := "events.date ASC, purchased_tickets.id ASC"
+	if filter.When == TicketsPast {
+		order = "events.date DESC, purchased_tickets.id DESC"
+	}
+
+	var tickets []models.PurchasedTicket
+	err := r.ownedQuery(userID, filter).
+		Preload("Ticket").Preload("Ticket.Event").
+		Order(order).
+		Limit(limit).Offset(offset).
+		Find(&tickets).Error
+	return tickets, err
+}
+
+func (r *purchasedTicketRepository) CountOwned(userID uint, filter OwnedTicketFilter) (int64, error) {
+	var count int64
+	err := r.ownedQuery(userID, filter).Count(&count).Error
+	return count, err
+}
+
+func (r *purchasedTicketRepository) ownedQuery(userID uint, filter OwnedTicketFilter) *gorm.DB {
+	query := r.db.Model(&models.PurchasedTicket{}).
+		Joins("JOIN tickets ON tickets.id = purchased_tickets.ticket_id").
+		Joins("JOIN events ON events.id = tickets.event_id").
+		Where("purchased_tickets.user_id = ?", userID)
+
+	switch filter.When {
+	case TicketsUpcoming:
+		query = query.Where("events.date >= ?", filter.Now)
+	case TicketsPast:
+		query = query.Where("events.date < ?", filter.Now)
+	}
+	if filter.EventID != 0 {
+		query = query.Where("tickets.event_id = ?", filter.EventID)
+	}
+	if filter.Used != nil {
+		query = query.Where("purchased_tickets.is_used = ?", *filter.Used)
+	}
+
+	return query
+}
+
 func (r *purchasedTicketRepository) CountByUser(userID uint) (int64, error) {
 	var count int64
 	err := r.db.Model(&models.PurchasedTicket{}).Where("user_id = ?", userID).Count(&count).Error
 	return count, err
 }
 
-func (r *purchasedTicketRepository) UpdateOwnership(ticketID uint, newUserID uint) error {
-	result := r.db.Exec("UPDATE purchased_tickets SET user_id = ? WHERE id = ?", newUserID, ticketID)
+// UpdateOwnership hands the ticket from fromUserID to newUserID and rotates
+// its QR secret, invalidating any PDF the previous owner downloaded. It
+// reports false when the ticket no longer belongs to fromUserID or has been
+// used or invalidated in the meantime.
+func (r *purchasedTicketRepository) UpdateOwnership(ticketID, fromUserID, newUserID uint) (bool, error) {
+	secret, err := utils.RandomHex(16)
+	if err != nil {
+		return false, err
+	}
+
+	result := r.db.Exec("UPDATE purchased_tickets SET user_id = ?, qr_secret = ?, updated_at = ? "+
+		"WHERE id = ? AND user_id = ? AND is_used = false AND is_invalidated = false",
+		newUserID, secret, time.Now().Unix(), ticketID, fromUserID)
+	return result.RowsAffected == 1, result.Error
+}
+
+// RotateQRSecret gives the ticket a new QR secret; tickets issued before
+// secrets existed get their first one this way
+func (r *purchasedTicketRepository) RotateQRSecret(ticketID uint) (string, error) {
+	secret, err := utils.RandomHex(16)
+	if err != nil {
+		return "", err
+	}
+	err = r.db.Model(&models.PurchasedTicket{}).Where("id = ?", ticketID).Update("qr_secret", secret).Error
+	return secret, err
+}
+
+func (r *purchasedTicketRepository) ListAttendeesByEvent(eventID uint, filter AttendeeFilter, limit, offset int) ([]models.PurchasedTicket, error) {
+	var tickets []models.PurchasedTicket
+	query := r.attendeeQuery(eventID, filter).
+		Preload("User").
+		Order("purchased_tickets.id")
+	if limit > 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+	err := query.Find(&tickets).Error
+	return tickets, err
+}
+
+// ListAttendeesAfter returns up to limit attendees with IDs above afterID, in
+// ID order, for walking a whole event in batches without OFFSET
+func (r *purchasedTicketRepository) ListAttendeesAfter(eventID uint, filter AttendeeFilter, afterID uint, limit int) ([]models.PurchasedTicket, error) {
+	var tickets []models.PurchasedTicket
+	err := r.attendeeQuery(eventID, filter).
+		Preload("User").
+		Where("purchased_tickets.id > ?", afterID).
+		Order("purchased_tickets.id").
+		Limit(limit).
+		Find(&tickets).Error
+	return tickets, err
+}
+
+func (r *purchasedTicketRepository) CountAttendeesByEvent(eventID uint, filter AttendeeFilter) (int64, error) {
+	var count int64
+	err := r.attendeeQuery(eventID, filter).Count(&count).Error
+	return count, err
+}
+
+// MarkUsed checks a ticket in. It only flips unused, valid tickets, so two
+// scanners racing on the same ticket can't both succeed.
+func (r *purchasedTicketRepository) MarkUsed(id uint, usedAt int64) (bool, error) {
+	result := r.db.Model(&models.PurchasedTicket{}).
+		Where("id = ? AND is_used = false AND is_invalidated = false", id).
+		Updates(map[string]interface{}{"is_used": true, "used_at": usedAt})
 	if result.Error != nil {
-		return result.Error
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *purchasedTicketRepository) attendeeQuery(eventID uint, filter AttendeeFilter) *gorm.DB {
+	query := r.db.Model(&models.PurchasedTicket{}).
+		Joins("JOIN tickets ON tickets.id = purchased_tickets.ticket_id").
+		Joins("JOIN users ON users.id = purchased_tickets.user_id").
+		Where("tickets.event_id = ?", eventID)
+
+	if filter.CheckedIn != nil {
+		query = query.Where("purchased_tickets.is_used = ?", *filter.CheckedIn)
 	}
-	if result.RowsAffected == 0 {
-		return errors.New("no rows updated")
+	if filter.TicketTitle != "" {
+		query = query.Where("purchased_tickets.title = ?", filter.TicketTitle)
 	}
-	return nil
+	if filter.NeedsAccommodation {
+		query = query.Where("purchased_tickets.is_accessible = true OR purchased_tickets.accommodation_request <> ''")
+	}
+	if filter.Search != "" {
+		like := "%" + filter.Search + "%"
+		query = query.Where("users.name LIKE ? OR users.surname LIKE ? OR users.email LIKE ?", like, like, like)
+	}
+
+	return query
+}
+
+func (r *purchasedTicketRepository) ListByPayment(paymentID uint) ([]models.PurchasedTicket, error) {
+	var tickets []models.PurchasedTicket
+	err := r.db.Where("payment_id = ?", paymentID).Order("id").Find(&tickets).Error
+	return tickets, err
+}
+
+func (r *purchasedTicketRepository) ListByPayments(paymentIDs []uint) ([]models.PurchasedTicket, error) {
+	var tickets []models.PurchasedTicket
+	if len(paymentIDs) == 0 {
+		return tickets, nil
+	}
+	err := r.db.Where("payment_id IN ?", paymentIDs).Order("id").Find(&tickets).Error
+	return tickets, err
+}
+
+// InvalidateTickets voids the given tickets bought with the payment that
+// are still valid and returns the number of tickets affected
+func (r *purchasedTicketRepository) InvalidateTickets(paymentID uint, ticketIDs []uint, invalidatedAt int64) (int64, error) {
+	result := r.db.Model(&models.PurchasedTicket{}).
+		Where("payment_id = ? AND id IN ? AND is_invalidated = false", paymentID, ticketIDs).
+		Updates(map[string]interface{}{"is_invalidated": true, "invalidated_at": invalidatedAt})
+	return result.RowsAffected, result.Error
+}
+
+// InvalidateByPayment voids every ticket bought with the payment that is
+// still valid and returns the number of tickets affected
+func (r *purchasedTicketRepository) InvalidateByPayment(paymentID uint, invalidatedAt int64) (int64, error) {
+	result := r.db.Model(&models.PurchasedTicket{}).
+		Where("payment_id = ? AND is_invalidated = false", paymentID).
+		Updates(map[string]interface{}{"is_invalidated": true, "invalidated_at": invalidatedAt})
+	return result.RowsAffected, result.Error
+}
+
+// CountSoldByHour counts the event's tickets sold between from and to by
+// the hour of their payment, leaving out hours without sales and tickets
+// reversed by a chargeback
+func (r *purchasedTicketRepository) CountSoldByHour(eventID uint, from, to int64) ([]SalesBucket, error) {
+	var buckets []SalesBucket
+	err := r.db.Model(&models.PurchasedTicket{}).
+		Select("FLOOR(payments.date / 3600) * 3600 AS start, COUNT(*) AS tickets").
+		Joins("JOIN tickets ON tickets.id = purchased_tickets.ticket_id").
+		Joins("JOIN payments ON payments.id = purchased_tickets.payment_id").
+		Where("tickets.event_id = ? AND purchased_tickets.is_invalidated = false", eventID).
+		Where("payments.date BETWEEN ? AND ?", from, to).
+		Group("start").
+		Order("start ASC").
+		Scan(&buckets).Error
+	return buckets, err
 }
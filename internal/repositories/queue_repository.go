@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type queueRepository struct {
+	db *gorm.DB
+}
+
+func NewQueueRepository(db *gorm.DB) QueueRepository {
+	return &queueRepository{db: db}
+}
+
+func (r *queueRepository) Create(entry *models.QueueEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *queueRepository) GetByToken(token string) (*models.QueueEntry, error) {
+	var entry models.QueueEntry
+	err := r.db.Where("token = ?", token).First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetOpenByUser returns the user's entry for the event that is still
+// waiting or admitted
+func (r *queueRepository) GetOpenByUser(eventID, userID uint) (*models.QueueEntry, error) {
+	var entry models.QueueEntry
+	err := r.db.Where("event_id = ? AND user_id = ? AND status IN ?", eventID, userID,
+		[]models.QueueEntryStatus{models.QueueEntryStatusWaiting, models.QueueEntryStatusAdmitted}).
+		Order("id DESC").First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// CountAhead counts the entries of the event still waiting that joined
+// before entryID
+func (r *queueRepository) CountAhead(eventID, entryID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.QueueEntry{}).
+		Where("event_id = ? AND status = ? AND id < ?", eventID, models.QueueEntryStatusWaiting, entryID).
+		Count(&count).Error
+	return count, err
+}
+
+// ListWaitingEventIDs returns the events with someone in the waiting room
+func (r *queueRepository) ListWaitingEventIDs() ([]uint, error) {
+	var eventIDs []uint
+	err := r.db.Model(&models.QueueEntry{}).
+		Where("status = ?", models.QueueEntryStatusWaiting).
+		Distinct().Pluck("event_id", &eventIDs).Error
+	return eventIDs, err
+}
+
+// AdmitNext admits the limit longest-waiting entries of the event until
+// expiresAt
+func (r *queueRepository) AdmitNext(eventID uint, limit int, now, expiresAt int64) (int64, error) {
+	var ids []uint
+	err := r.db.Model(&models.QueueEntry{}).
+		Where("event_id = ? AND status = ?", eventID, models.QueueEntryStatusWaiting).
+		Order("id").Limit(limit).Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	result := r.db.Model(&models.QueueEntry{}).
+		Where("id IN ? AND status = ?", ids, models.QueueEntryStatusWaiting).
+		Updates(map[string]interface{}{
+			"status":      models.QueueEntryStatusAdmitted,
+			"admitted_at": now,
+			"expires_at":  expiresAt,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// MarkUsed closes an admission once it bought tickets; false when it was
+// already used or expired
+func (r *queueRepository) MarkUsed(id uint) (bool, error) {
+	result := r.db.Model(&models.QueueEntry{}).
+		Where("id = ? AND status = ?", id, models.QueueEntryStatusAdmitted).
+		Update("status", models.QueueEntryStatusUsed)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ExpireAdmissions closes admissions whose window passed unused
+func (r *queueRepository) ExpireAdmissions(now int64) (int64, error) {
+	result := r.db.Model(&models.QueueEntry{}).
+		Where("status = ? AND expires_at < ?", models.QueueEntryStatusAdmitted, now).
+		Update("status", models.QueueEntryStatusExpired)
+	return result.RowsAffected, result.Error
+}
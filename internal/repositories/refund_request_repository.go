@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type refundRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewRefundRequestRepository(db *gorm.DB) RefundRequestRepository {
+	return &refundRequestRepository{db: db}
+}
+
+func (r *refundRequestRepository) WithTx(tx *gorm.DB) RefundRequestRepository {
+	return &refundRequestRepository{db: tx}
+}
+
+func (r *refundRequestRepository) Create(request *models.RefundRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *refundRequestRepository) Update(request *models.RefundRequest) error {
+	return r.db.Save(request).Error
+}
+
+func (r *refundRequestRepository) GetByID(id uint) (*models.RefundRequest, error) {
+	var request models.RefundRequest
+	err := r.db.First(&request, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *refundRequestRepository) HasPendingForPayment(paymentID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.RefundRequest{}).
+		Where("payment_id = ? AND status = ?", paymentID, models.RefundRequestStatusPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *refundRequestRepository) ListByUser(userID uint) ([]models.RefundRequest, error) {
+	var requests []models.RefundRequest
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&requests).Error
+	return requests, err
+}
+
+// List returns requests oldest first, so the queue is worked in order; a
+// zero status matches every status
+func (r *refundRequestRepository) List(status models.RefundRequestStatus, limit, offset int) ([]models.RefundRequest, error) {
+	var requests []models.RefundRequest
+	err := r.filtered(status).
+		Order("created_at").
+		Limit(limit).Offset(offset).
+		Find(&requests).Error
+	return requests, err
+}
+
+func (r *refundRequestRepository) Count(status models.RefundRequestStatus) (int64, error) {
+	var count int64
+	err := r.filtered(status).Count(&count).Error
+	return count, err
+}
+
+// Resolve moves a pending request to status. It reports false when the
+// request was no longer pending, e.g. a concurrent decision got there first.
+func (r *refundRequestRepository) Resolve(id uint, status models.RefundRequestStatus, note string, refundID *uint, resolvedAt int64) (bool, error) {
+	result := r.db.Model(&models.RefundRequest{}).
+		Where("id = ? AND status = ?", id, models.RefundRequestStatusPending).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"note":        note,
+			"refund_id":   refundID,
+			"resolved_at": resolvedAt,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// CloseForPastEvents closes pending requests for events that took place
+// before now and returns how many were closed
+func (r *refundRequestRepository) CloseForPastEvents(now int64, note string) (int64, error) {
+	result := r.db.Model(&models.RefundRequest{}).
+		Where("status = ? AND event_id IN (SELECT id FROM events WHERE date <= ?)", models.RefundRequestStatusPending, now).
+		Updates(map[string]interface{}{
+			"status":      models.RefundRequestStatusClosed,
+			"note":        note,
+			"resolved_at": now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *refundRequestRepository) filtered(status models.RefundRequestStatus) *gorm.DB {
+	query := r.db.Model(&models.RefundRequest{})
+	if status != 0 {
+		query = query.Where("status = ?", status)
+	}
+	return query
+}
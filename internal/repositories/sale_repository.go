@@ -3,8 +3,32 @@ package repositories
 import (
 	"eticketing/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const (
+	SaleStatusActive   = "active"
+	SaleStatusUpcoming = "upcoming"
+	SaleStatusExpired  = "expired"
+)
+
+// SaleFilter narrows the sales listing; zero values match everything.
+// Status is judged against Now.
+type SaleFilter struct {
+	SellerID uint
+	EventID  uint
+	Status   string
+	Now      int64
+}
+
+// SaleTicketCount is how many tickets were put up in a sale and how many of
+// them are sold
+type SaleTicketCount struct {
+	SaleID   uint
+	Attached int64
+	Sold     int64
+}
+
 type saleRepository struct {
 	db *gorm.DB
 }
@@ -13,6 +37,10 @@ func NewSaleRepository(db *gorm.DB) SaleRepository {
 	return &saleRepository{db: db}
 }
 
+func (r *saleRepository) WithTx(tx *gorm.DB) SaleRepository {
+	return &saleRepository{db: tx}
+}
+
 func (r *saleRepository) Create(sale *models.Sale) error {
 	return r.db.Create(sale).Error
 }
@@ -39,3 +67,144 @@ func (r *saleRepository) ListByEvent(eventID uint) ([]models.Sale, error) {
 	err := r.db.Where("event_id = ?", eventID).Order("start_date").Find(&sales).Error
 	return sales, err
 }
+
+// List returns sales soonest first, with their events
+func (r *saleRepository) List(filter SaleFilter, limit, offset int) ([]models.Sale, error) {
+	var sales []models.Sale
+	err := r.filtered(filter).
+		Preload("Event").
+		Order("sales.start_date, sales.id").
+		Limit(limit).Offset(offset).
+		Find(&sales).Error
+	return sales, err
+}
+
+func (r *saleRepository) Count(filter SaleFilter) (int64, error) {
+	var count int64
+	err := r.filtered(filter).Model(&models.Sale{}).Count(&count).Error
+	return count, err
+}
+
+func (r *saleRepository) filtered(filter SaleFilter) *gorm.DB {
+	query := r.db
+	if filter.SellerID > 0 {
+		query = query.Joins("JOIN events ON events.id = sales.event_id").
+			Where("events.seller_id = ?", filter.SellerID)
+	}
+	if filter.EventID > 0 {
+		query = query.Where("sales.event_id = ?", filter.EventID)
+	}
+	switch filter.Status {
+	case SaleStatusActive:
+		query = query.Where("sales.start_date <= ? AND sales.end_date >= ?", filter.Now, filter.Now)
+	case SaleStatusUpcoming:
+		query = query.Where("sales.start_date > ?", filter.Now)
+	case SaleStatusExpired:
+		query = query.Where("sales.end_date < ?", filter.Now)
+	}
+	return query
+}
+
+// CountTickets returns attached and sold ticket counts keyed by sale ID.
+// Sales without tickets are left out.
+func (r *saleRepository) CountTickets(saleIDs []uint) (map[uint]SaleTicketCount, error) {
+	counts := make(map[uint]SaleTicketCount)
+	if len(saleIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []SaleTicketCount
+	err := r.db.Model(&models.Ticket{}).
+		Select("sale_id, COUNT(*) AS attached, SUM(CASE WHEN is_sold THEN 1 ELSE 0 END) AS sold").
+		Where("sale_id IN ?", saleIDs).
+		Group("sale_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.SaleID] = row
+	}
+	return counts, nil
+}
+
+// ListLotteriesToDraw returns lottery sales whose registration closed
+// without a draw yet
+func (r *saleRepository) ListLotteriesToDraw(now int64, limit int) ([]models.Sale, error) {
+	var sales []models.Sale
+	err := r.db.Preload("Event").
+		Where("mode = ? AND start_date <= ? AND lottery_drawn_at IS NULL", models.SaleModeLottery, now).
+		Order("start_date").Limit(limit).Find(&sales).Error
+	return sales, err
+}
+
+// MarkLotteryDrawn records the draw; false when another run drew it first
+func (r *saleRepository) MarkLotteryDrawn(id uint, drawnAt int64) (bool, error) {
+	result := r.db.Model(&models.Sale{}).
+		Where("id = ? AND lottery_drawn_at IS NULL", id).
+		Update("lottery_drawn_at", drawnAt)
+	return result.RowsAffected > 0, result.Error
+}
+
+// UpsertAllocation creates the sale's cap for the allocation's group, or
+// sets the maximum of the one already there. An existing cap that has sold
+// more than the new maximum is left as it is.
+func (r *saleRepository) UpsertAllocation(allocation *models.SaleAllocation) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "sale_id"}, {Name: "group_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"max_quantity": gorm.Expr("IF(sold_quantity <= VALUES(max_quantity), VALUES(max_quantity), max_quantity)"),
+			"updated_at":   gorm.Expr("VALUES(updated_at)"),
+		}),
+	}).Create(allocation).Error
+}
+
+func (r *saleRepository) GetAllocationByID(id uint) (*models.SaleAllocation, error) {
+	var allocation models.SaleAllocation
+	err := r.db.First(&allocation, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &allocation, nil
+}
+
+func (r *saleRepository) DeleteAllocation(id uint) error {
+	return r.db.Delete(&models.SaleAllocation{}, id).Error
+}
+
+func (r *saleRepository) ListAllocations(saleID uint) ([]models.SaleAllocation, error) {
+	var allocations []models.SaleAllocation
+	err := r.db.Where("sale_id = ?", saleID).Order("id").Find(&allocations).Error
+	return allocations, err
+}
+
+// FindAllocation returns the sale's cap for a ticket group
+func (r *saleRepository) FindAllocation(saleID, groupID uint) (*models.SaleAllocation, error) {
+	var allocation models.SaleAllocation
+	err := r.db.Where("sale_id = ? AND group_id = ?", saleID, groupID).
+		First(&allocation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &allocation, nil
+}
+
+// ReserveAllocation atomically counts quantity against the cap. It reports
+// false, without changing anything, when the cap would be exceeded.
+func (r *saleRepository) ReserveAllocation(id uint, quantity int) (bool, error) {
+	result := r.db.Model(&models.SaleAllocation{}).
+		Where("id = ? AND sold_quantity + ? <= max_quantity", id, quantity).
+		Update("sold_quantity", gorm.Expr("sold_quantity + ?", quantity))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReleaseAllocation gives back a reservation when the purchase did not go through
+func (r *saleRepository) ReleaseAllocation(id uint, quantity int) error {
+	return r.db.Model(&models.SaleAllocation{}).
+		Where("id = ? AND sold_quantity >= ?", id, quantity).
+		Update("sold_quantity", gorm.Expr("sold_quantity - ?", quantity)).Error
+}
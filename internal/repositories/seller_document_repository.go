@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type sellerDocumentRepository struct {
+	db *gorm.DB
+}
+
+func NewSellerDocumentRepository(db *gorm.DB) SellerDocumentRepository {
+	return &sellerDocumentRepository{db: db}
+}
+
+func (r *sellerDocumentRepository) Create(document *models.SellerDocument) error {
+	return r.db.Create(document).Error
+}
+
+// GetByID returns the document with its file
+func (r *sellerDocumentRepository) GetByID(id uint) (*models.SellerDocument, error) {
+	var document models.SellerDocument
+	if err := r.db.First(&document, id).Error; err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+// ListBySeller returns the seller's documents without their files, oldest
+// first
+func (r *sellerDocumentRepository) ListBySeller(sellerID uint) ([]models.SellerDocument, error) {
+	var documents []models.SellerDocument
+	err := r.db.Omit("data").Where("seller_id = ?", sellerID).Order("id ASC").Find(&documents).Error
+	return documents, err
+}
+
+func (r *sellerDocumentRepository) Delete(id uint) error {
+	return r.db.Delete(&models.SellerDocument{}, id).Error
+}
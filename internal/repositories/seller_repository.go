@@ -14,6 +14,10 @@ func NewSellerRepository(db *gorm.DB) SellerRepository {
 	return &sellerRepository{db: db}
 }
 
+func (r *sellerRepository) WithTx(tx *gorm.DB) SellerRepository {
+	return &sellerRepository{db: tx}
+}
+
 func (r *sellerRepository) Create(seller *models.Seller) error {
 	return r.db.Create(seller).Error
 }
@@ -64,3 +68,35 @@ func (r *sellerRepository) Count() (int64, error) {
 	err := r.db.Model(&models.Seller{}).Count(&count).Error
 	return count, err
 }
+
+// UpdateOnboarding saves the seller's onboarding fields if their status is
+// still from, and reports whether it was
+func (r *sellerRepository) UpdateOnboarding(seller *models.Seller, from models.SellerOnboardingStatus) (bool, error) {
+	result := r.db.Model(&models.Seller{}).
+		Where("id = ? AND onboarding_status = ?", seller.ID, from).
+		Updates(map[string]interface{}{
+			"onboarding_status":       seller.OnboardingStatus,
+			"onboarding_submitted_at": seller.OnboardingSubmittedAt,
+			"onboarding_reviewed_by":  seller.OnboardingReviewedBy,
+			"onboarding_reviewed_at":  seller.OnboardingReviewedAt,
+			"onboarding_note":         seller.OnboardingNote,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// ListByOnboardingStatus returns sellers in status, longest waiting first
+func (r *sellerRepository) ListByOnboardingStatus(status models.SellerOnboardingStatus, limit, offset int) ([]models.Seller, error) {
+	var sellers []models.Seller
+	err := r.db.Where("onboarding_status = ?", status).
+		Order("onboarding_submitted_at ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&sellers).Error
+	return sellers, err
+}
+
+func (r *sellerRepository) CountByOnboardingStatus(status models.SellerOnboardingStatus) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Seller{}).Where("onboarding_status = ?", status).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type ticketGroupRepository struct {
+	db *gorm.DB
+}
+
+func NewTicketGroupRepository(db *gorm.DB) TicketGroupRepository {
+	return &ticketGroupRepository{db: db}
+}
+
+func (r *ticketGroupRepository) WithTx(tx *gorm.DB) TicketGroupRepository {
+	return &ticketGroupRepository{db: tx}
+}
+
+func (r *ticketGroupRepository) Create(group *models.TicketGroup) error {
+	return r.db.Create(group).Error
+}
+
+func (r *ticketGroupRepository) GetByID(id uint) (*models.TicketGroup, error) {
+	var group models.TicketGroup
+	err := r.db.First(&group, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *ticketGroupRepository) Update(group *models.TicketGroup) error {
+	return r.db.Save(group).Error
+}
+
+func (r *ticketGroupRepository) Delete(id uint) error {
+	return r.db.Delete(&models.TicketGroup{}, id).Error
+}
+
+// FindByDetails looks a group up by the details clients identified groups by
+// before groups had IDs. The description is not part of the match.
+func (r *ticketGroupRepository) FindByDetails(eventID, saleID uint, price float64, ticketType models.TicketType, isVip bool, title, place string) (*models.TicketGroup, error) {
+	var group models.TicketGroup
+	err := r.db.Where("event_id = ? AND sale_id = ? AND price = ? AND type = ? AND is_vip = ? AND title = ? AND place = ?",
+		eventID, saleID, price, ticketType, isVip, title, place).
+		Order("id").
+		First(&group).Error
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
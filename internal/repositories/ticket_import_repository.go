@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type ticketImportRepository struct {
+	db *gorm.DB
+}
+
+func NewTicketImportRepository(db *gorm.DB) TicketImportRepository {
+	return &ticketImportRepository{db: db}
+}
+
+func (r *ticketImportRepository) WithTx(tx *gorm.DB) TicketImportRepository {
+	return &ticketImportRepository{db: tx}
+}
+
+func (r *ticketImportRepository) Create(ticketImport *models.TicketImport) error {
+	return r.db.Create(ticketImport).Error
+}
+
+func (r *ticketImportRepository) Update(ticketImport *models.TicketImport) error {
+	return r.db.Save(ticketImport).Error
+}
+
+func (r *ticketImportRepository) GetByID(id uint) (*models.TicketImport, error) {
+	var ticketImport models.TicketImport
+	err := r.db.First(&ticketImport, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ticketImport, nil
+}
+
+// ListByEvent returns the event's most recent imports, without their files
+// and row results
+func (r *ticketImportRepository) ListByEvent(eventID uint, limit int) ([]models.TicketImport, error) {
+	var imports []models.TicketImport
+	err := r.db.Omit("Data", "Results").
+		Where("event_id = ?", eventID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&imports).Error
+	return imports, err
+}
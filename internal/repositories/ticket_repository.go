@@ -2,10 +2,15 @@
 package repositories
 
 import (
+	"errors"
+
 	"eticketing/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var ErrCapacityExceeded = errors.New("event capacity exceeded")
+
 type ticketRepository struct {
 	db *gorm.DB
 }
@@ -14,10 +19,46 @@ func NewTicketRepository(db *gorm.DB) TicketRepository {
 	return &ticketRepository{db: db}
 }
 
+func (r *ticketRepository) WithTx(tx *gorm.DB) TicketRepository {
+	return &ticketRepository{db: tx}
+}
+
 func (r *ticketRepository) Create(ticket *models.Ticket) error {
 	return r.db.Create(ticket).Error
 }
 
+// CreateWithinCapacity inserts tickets for one event unless the event would
+// then have more tickets than its capacity (0 means unlimited). The event row
+// is locked before counting so concurrent creations for the same event are
+// checked one after another; call it inside a transaction.
+func (r *ticketRepository) CreateWithinCapacity(eventID uint, tickets []models.Ticket) error {
+	var event models.Event
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "capacity").
+		First(&event, eventID).Error
+	if err != nil {
+		return err
+	}
+
+	if event.Capacity > 0 {
+		count, err := r.CountByEvent(eventID)
+		if err != nil {
+			return err
+		}
+		if int(count)+len(tickets) > event.Capacity {
+			return ErrCapacityExceeded
+		}
+	}
+
+	return r.db.Create(&tickets).Error
+}
+
+func (r *ticketRepository) CountByEvent(eventID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Ticket{}).Where("event_id = ?", eventID).Count(&count).Error
+	return count, err
+}
+
 func (r *ticketRepository) GetByID(id uint) (*models.Ticket, error) {
 	var ticket models.Ticket
 	err := r.db.Preload("Event").Preload("Sale").First(&ticket, id).Error
@@ -27,10 +68,12 @@ func (r *ticketRepository) GetByID(id uint) (*models.Ticket, error) {
 	return &ticket, nil
 }
 
+// GetByIDForUpdate loads a ticket and locks its row until the surrounding
+// transaction ends; call it inside a transaction
 func (r *ticketRepository) GetByIDForUpdate(id uint) (*models.Ticket, error) {
 	var ticket models.Ticket
-	err := r.db.Preload("Event").Preload("Sale").
-		Set("gorm:query_option", "FOR UPDATE").
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Event").Preload("Sale").
 		First(&ticket, id).Error
 	if err != nil {
 		return nil, err
@@ -38,29 +81,17 @@ func (r *ticketRepository) GetByIDForUpdate(id uint) (*models.Ticket, error) {
 	return &ticket, nil
 }
 
-func (r *ticketRepository) FindAndLockAvailableTickets(
-	eventID uint,
-	price float64,
-	ticketType models.TicketType,
-	isVip bool,
-	title, place string,
-	saleID uint,
-	quantity int,
-) ([]models.Ticket, error) {
+// FindAndLockAvailableTickets locks up to quantity of the group's tickets that
+// are neither sold nor held. Rows another buyer has locked are skipped rather
+// than waited on, so concurrent buyers get disjoint tickets; call it inside a
+// transaction.
+func (r *ticketRepository) FindAndLockAvailableTickets(groupID uint, quantity int) ([]models.Ticket, error) {
 	var tickets []models.Ticket
-
-	err := r.db.Transaction(func(tx *gorm.DB) error {
-		// Use raw SQL with FOR UPDATE to lock the rows
-		err := tx.
-			Where("event_id = ? AND price = ? AND type = ? AND is_vip = ? AND title = ? AND place = ? AND sale_id = ? AND is_sold = false AND is_held = false",
-				eventID, price, ticketType, isVip, title, place, saleID).
-			Set("gorm:query_option", "FOR UPDATE").
-			Limit(quantity).
-			Find(&tickets).Error
-
-		return err
-	})
-
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("group_id = ? AND is_sold = false AND is_held = false", groupID).
+		Order("id").
+		Limit(quantity).
+		Find(&tickets).Error
 	return tickets, err
 }
 
@@ -68,6 +99,23 @@ func (r *ticketRepository) Update(ticket *models.Ticket) error {
 	return r.db.Save(ticket).Error
 }
 
+// SellHeld marks held tickets sold and returns how many it changed. Tickets
+// released or sold meanwhile are left alone, so a short count means the
+// hold was lost.
+func (r *ticketRepository) SellHeld(ids []uint) (int64, error) {
+	result := r.db.Model(&models.Ticket{}).
+		Where("id IN ? AND is_held = true AND is_sold = false", ids).
+		Updates(map[string]interface{}{"is_sold": true, "is_held": false})
+	return result.RowsAffected, result.Error
+}
+
+// ReleaseHeld puts held tickets back on sale; sold ones stay sold
+func (r *ticketRepository) ReleaseHeld(ids []uint) error {
+	return r.db.Model(&models.Ticket{}).
+		Where("id IN ? AND is_sold = false", ids).
+		Update("is_held", false).Error
+}
+
 func (r *ticketRepository) Delete(id uint) error {
 	return r.db.Delete(&models.Ticket{}, id).Error
 }
@@ -90,10 +138,9 @@ func (r *ticketRepository) CountAvailableByEvent(eventID uint) (int64, error) {
 	return count, err
 }
 
-func (r *ticketRepository) ListByGroupCriteria(eventID uint, price float64, ticketType models.TicketType, isVip bool, title, place string, saleID uint, includeSold bool) ([]models.Ticket, error) {
+func (r *ticketRepository) ListByGroup(groupID uint, includeSold bool) ([]models.Ticket, error) {
 	var tickets []models.Ticket
-	query := r.db.Where("event_id = ? AND price = ? AND type = ? AND is_vip = ? AND title = ? AND place = ? AND sale_id = ?",
-		eventID, price, ticketType, isVip, title, place, saleID)
+	query := r.db.Where("group_id = ?", groupID)
 
 	if !includeSold {
 		query = query.Where("is_sold = false")
@@ -103,55 +150,92 @@ func (r *ticketRepository) ListByGroupCriteria(eventID uint, price float64, tick
 	return tickets, err
 }
 
-func (r *ticketRepository) ListGroupedByEvent(eventID uint) ([]models.GroupedTicket, error) {
-	var results []models.GroupedTicket
+func (r *ticketRepository) CountByGroup(groupID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Ticket{}).Where("group_id = ?", groupID).Count(&count).Error
+	return count, err
+}
 
-	err := r.db.Model(&models.Ticket{}).
-		Select(`
-			price, 
-			type, 
-			is_vip, 
-			title, 
-			description, 
-			place, 
-			sale_id, 
-			event_id,
-			COUNT(*) as total_amount,
-			COUNT(CASE WHEN is_sold = false AND is_held = false THEN 1 END) as available_amount,
-			COUNT(CASE WHEN is_sold = true THEN 1 END) as sold_amount,
-			COUNT(CASE WHEN is_held = true AND is_sold = false THEN 1 END) as held_amount
-		`).
-		Where("event_id = ?", eventID).
-		Group("price, type, is_vip, title, description, place, sale_id, event_id").
-		Scan(&results).Error
+// SyncUnsoldWithGroup copies the group's details onto its unsold tickets.
+// Sold tickets keep the details they were bought with.
+func (r *ticketRepository) SyncUnsoldWithGroup(group *models.TicketGroup) error {
+	return r.db.Model(&models.Ticket{}).
+		Where("group_id = ? AND is_sold = false", group.ID).
+		Updates(map[string]interface{}{
+			"price":         group.Price,
+			"type":          group.Type,
+			"is_vip":        group.IsVip,
+			"title":         group.Title,
+			"description":   group.Description,
+			"place":         group.Place,
+			"sale_id":       group.SaleID,
+			"is_accessible": group.IsAccessible,
+		}).Error
+}
 
+// The grouped listings take a limit of -1 to return every group
+
+func (r *ticketRepository) ListGroupedByEvent(eventID uint, limit, offset int) ([]models.GroupedTicket, error) {
+	var results []models.GroupedTicket
+	err := r.groupedQuery(eventID).Limit(limit).Offset(offset).Scan(&results).Error
 	return results, err
 }
 
-func (r *ticketRepository) ListAvailableGroupedByEvent(eventID uint) ([]models.GroupedTicket, error) {
+func (r *ticketRepository) CountGroupedByEvent(eventID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Ticket{}).
+		Joins("JOIN ticket_groups ON ticket_groups.id = tickets.group_id").
+		Where("tickets.event_id = ?", eventID).
+		Distinct("tickets.group_id").
+		Count(&count).Error
+	return count, err
+}
+
+func (r *ticketRepository) ListAvailableGroupedByEvent(eventID uint, limit, offset int) ([]models.GroupedTicket, error) {
 	var results []models.GroupedTicket
+	err := r.availableGroupedQuery(eventID).Limit(limit).Offset(offset).Scan(&results).Error
+	return results, err
+}
 
+func (r *ticketRepository) CountAvailableGroupedByEvent(eventID uint) (int64, error) {
+	var count int64
 	err := r.db.Model(&models.Ticket{}).
+		Joins("JOIN ticket_groups ON ticket_groups.id = tickets.group_id").
+		Where("tickets.event_id = ? AND tickets.is_sold = false AND tickets.is_held = false", eventID).
+		Distinct("tickets.group_id").
+		Count(&count).Error
+	return count, err
+}
+
+func (r *ticketRepository) availableGroupedQuery(eventID uint) *gorm.DB {
+	return r.groupedQuery(eventID).
+		Having("COUNT(CASE WHEN tickets.is_sold = false AND tickets.is_held = false THEN 1 END) > 0")
+}
+
+// groupedQuery aggregates the event's tickets per group, taking the details
+// from the group rather than from the tickets
+func (r *ticketRepository) groupedQuery(eventID uint) *gorm.DB {
+	return r.db.Model(&models.Ticket{}).
 		Select(`
-			price, 
-			type, 
-			is_vip, 
-			title, 
-			description, 
-			place, 
-			sale_id, 
-			event_id,
+			ticket_groups.id as group_id,
+			ticket_groups.price,
+			ticket_groups.type,
+			ticket_groups.is_vip,
+			ticket_groups.title,
+			ticket_groups.description,
+			ticket_groups.place,
+			ticket_groups.sale_id,
+			ticket_groups.event_id,
+			ticket_groups.is_accessible,
 			COUNT(*) as total_amount,
-			COUNT(CASE WHEN is_sold = false AND is_held = false THEN 1 END) as available_amount,
-			COUNT(CASE WHEN is_sold = true THEN 1 END) as sold_amount,
-			COUNT(CASE WHEN is_held = true AND is_sold = false THEN 1 END) as held_amount
+			COUNT(CASE WHEN tickets.is_sold = false AND tickets.is_held = false THEN 1 END) as available_amount,
+			COUNT(CASE WHEN tickets.is_sold = true THEN 1 END) as sold_amount,
+			COUNT(CASE WHEN tickets.is_held = true AND tickets.is_sold = false THEN 1 END) as held_amount
 		`).
-		Where("event_id = ?", eventID).
-		Group("price, type, is_vip, title, description, place, sale_id, event_id").
-		Having("COUNT(CASE WHEN is_sold = false AND is_held = false THEN 1 END) > 0").
-		Scan(&results).Error
-
-	return results, err
+		Joins("JOIN ticket_groups ON ticket_groups.id = tickets.group_id").
+		Where("tickets.event_id = ?", eventID).
+		Group("ticket_groups.id").
+		Order("ticket_groups.id")
 }
 
 func (r *ticketRepository) GetSellerTicketStats(sellerID uint) (*TicketStats, error) {
@@ -177,3 +261,40 @@ func (r *ticketRepository) GetSellerTicketStats(sellerID uint) (*TicketStats, er
 
 	return &stats, nil
 }
+
+func (r *ticketRepository) CountSoldByGroup(groupID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Ticket{}).
+		Where("group_id = ? AND is_sold = true", groupID).
+		Count(&count).Error
+	return count, err
+}
+
+// LockAvailableByGroup locks up to limit of the group's tickets that are
+// neither sold nor held; call it inside a transaction
+func (r *ticketRepository) LockAvailableByGroup(groupID uint, limit int) ([]models.Ticket, error) {
+	var tickets []models.Ticket
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("group_id = ? AND is_sold = false AND is_held = false", groupID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&tickets).Error
+	return tickets, err
+}
+
+// MoveToGroup puts unsold tickets into group, and so into its sale
+func (r *ticketRepository) MoveToGroup(ids []uint, group *models.TicketGroup) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.Ticket{}).
+		Where("id IN ? AND is_sold = false", ids).
+		Updates(map[string]interface{}{"group_id": group.ID, "sale_id": group.SaleID}).Error
+}
+
+func (r *ticketRepository) DeleteByIDs(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Delete(&models.Ticket{}, ids).Error
+}
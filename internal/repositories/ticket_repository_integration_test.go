@@ -0,0 +1,221 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"eticketing/internal/dbtest"
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+)
+
+func TestFindAndLockAvailableTicketsSkipsLockedRows(t *testing.T) {
+	db := dbtest.Open(t)
+	seller := dbtest.CreateSeller(t, db, "seller")
+	event := dbtest.CreateEvent(t, db, seller.ID)
+	sale := dbtest.CreateSale(t, db, event.ID)
+	group, tickets := dbtest.CreateGroup(t, db, sale, 10, 5)
+
+	// Neither a sold nor a held ticket is up for grabs
+	db.Model(&tickets[3]).Update("is_sold", true)
+	db.Model(&tickets[4]).Update("is_held", true)
+
+	repo := repositories.NewTicketRepository(db)
+
+	first := db.Begin()
+	defer first.Rollback()
+	locked, err := repo.WithTx(first).FindAndLockAvailableTickets(group.ID, 2)
+	if err != nil {
+		t.Fatalf("first lock: %v", err)
+	}
+	if len(locked) != 2 {
+		t.Fatalf("first buyer locked %d tickets, want 2", len(locked))
+	}
+
+	second := db.Begin()
+	defer second.Rollback()
+	rest, err := repo.WithTx(second).FindAndLockAvailableTickets(group.ID, 3)
+	if err != nil {
+		t.Fatalf("second lock: %v", err)
+	}
+	if len(rest) != 1 {
+		t.Fatalf("second buyer locked %d tickets, want the 1 left", len(rest))
+	}
+	for _, ticket := range locked {
+		if ticket.ID == rest[0].ID {
+			t.Fatalf("ticket %d was locked by both buyers", ticket.ID)
+		}
+	}
+}
+
+func TestGetByIDForUpdateWaitsForTheLockHolder(t *testing.T) {
+	db := dbtest.Open(t)
+	seller := dbtest.CreateSeller(t, db, "seller")
+	event := dbtest.CreateEvent(t, db, seller.ID)
+	sale := dbtest.CreateSale(t, db, event.ID)
+	_, tickets := dbtest.CreateGroup(t, db, sale, 10, 1)
+
+	repo := repositories.NewTicketRepository(db)
+
+	holder := db.Begin()
+	defer holder.Rollback()
+	if _, err := repo.WithTx(holder).GetByIDForUpdate(tickets[0].ID); err != nil {
+		t.Fatalf("lock: %v", err)
+	}
+
+	type result struct {
+		ticket *models.Ticket
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		waiter := db.Begin()
+		defer waiter.Rollback()
+		ticket, err := repo.WithTx(waiter).GetByIDForUpdate(tickets[0].ID)
+		done <- result{ticket, err}
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("second lock was granted while the first was held")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	if err := holder.Model(&tickets[0]).Update("is_held", true).Error; err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := holder.Commit().Error; err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	res := <-done
+	if res.err != nil {
+		t.Fatalf("second lock: %v", res.err)
+	}
+	if !res.ticket.IsHeld {
+		t.Error("second lock read the ticket from before the first transaction committed")
+	}
+}
+
+func TestCreateWithinCapacityUnderConcurrency(t *testing.T) {
+	const capacity, creators = 5, 12
+
+	db := dbtest.Open(t)
+	seller := dbtest.CreateSeller(t, db, "seller")
+	event := dbtest.CreateEvent(t, db, seller.ID)
+	sale := dbtest.CreateSale(t, db, event.ID)
+	db.Model(event).Update("capacity", capacity)
+
+	repo := repositories.NewTicketRepository(db)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, creators)
+	for i := 0; i < creators; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- db.Transaction(func(tx *gorm.DB) error {
+				return repo.WithTx(tx).CreateWithinCapacity(event.ID, []models.Ticket{{
+					Price:   10,
+					Type:    models.TicketTypeRegular,
+					Title:   "Standing",
+					Place:   "Floor",
+					SaleID:  sale.ID,
+					EventID: event.ID,
+				}})
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case errors.Is(err, repositories.ErrCapacityExceeded):
+		default:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if created != capacity {
+		t.Errorf("%d creations succeeded, want %d", created, capacity)
+	}
+
+	count, err := repo.CountByEvent(event.ID)
+	if err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if count != capacity {
+		t.Errorf("event has %d tickets, want %d", count, capacity)
+	}
+}
+
+func TestGroupedListings(t *testing.T) {
+	db := dbtest.Open(t)
+	seller := dbtest.CreateSeller(t, db, "seller")
+	event := dbtest.CreateEvent(t, db, seller.ID)
+	sale := dbtest.CreateSale(t, db, event.ID)
+
+	open, openTickets := dbtest.CreateGroup(t, db, sale, 10, 4)
+	db.Model(&openTickets[0]).Update("is_sold", true)
+	db.Model(&openTickets[1]).Update("is_held", true)
+
+	soldOut, soldOutTickets := dbtest.CreateGroup(t, db, sale, 20, 2)
+	db.Model(&models.Ticket{}).Where("id IN ?", []uint{soldOutTickets[0].ID, soldOutTickets[1].ID}).Update("is_sold", true)
+
+	// Details come from the group, not from the tickets' copies
+	db.Model(open).Update("title", "Renamed")
+
+	repo := repositories.NewTicketRepository(db)
+
+	grouped, err := repo.ListGroupedByEvent(event.ID, -1, 0)
+	if err != nil {
+		t.Fatalf("list grouped: %v", err)
+	}
+	if len(grouped) != 2 {
+		t.Fatalf("listed %d groups, want 2", len(grouped))
+	}
+
+	got := grouped[0]
+	if got.GroupID != open.ID || got.Title != "Renamed" {
+		t.Errorf("first group = %d %q, want %d %q", got.GroupID, got.Title, open.ID, "Renamed")
+	}
+	if got.TotalAmount != 4 || got.SoldAmount != 1 || got.HeldAmount != 1 || got.AvailableAmount != 2 {
+		t.Errorf("first group counts total=%d sold=%d held=%d available=%d, want 4/1/1/2",
+			got.TotalAmount, got.SoldAmount, got.HeldAmount, got.AvailableAmount)
+	}
+	if grouped[1].GroupID != soldOut.ID || grouped[1].AvailableAmount != 0 {
+		t.Errorf("second group = %d with %d available, want %d with 0", grouped[1].GroupID, grouped[1].AvailableAmount, soldOut.ID)
+	}
+
+	count, err := repo.CountGroupedByEvent(event.ID)
+	if err != nil || count != 2 {
+		t.Errorf("CountGroupedByEvent = %d, %v; want 2", count, err)
+	}
+
+	available, err := repo.ListAvailableGroupedByEvent(event.ID, -1, 0)
+	if err != nil {
+		t.Fatalf("list available: %v", err)
+	}
+	if len(available) != 1 || available[0].GroupID != open.ID {
+		t.Errorf("available groups = %+v, want only group %d", available, open.ID)
+	}
+
+	count, err = repo.CountAvailableGroupedByEvent(event.ID)
+	if err != nil || count != 1 {
+		t.Errorf("CountAvailableGroupedByEvent = %d, %v; want 1", count, err)
+	}
+
+	page, err := repo.ListGroupedByEvent(event.ID, 1, 1)
+	if err != nil || len(page) != 1 || page[0].GroupID != soldOut.ID {
+		t.Errorf("second page = %+v, %v; want group %d", page, err, soldOut.ID)
+	}
+}
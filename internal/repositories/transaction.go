@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+)
+
+// TransactionManager runs fn inside a database transaction. Repositories
+// join it through their WithTx method; returning an error rolls everything back.
+type TransactionManager interface {
+	WithTransaction(fn func(tx *gorm.DB) error) error
+}
+
+type transactionManager struct {
+	db *gorm.DB
+}
+
+func NewTransactionManager(db *gorm.DB) TransactionManager {
+	return &transactionManager{db: db}
+}
+
+func (m *transactionManager) WithTransaction(fn func(tx *gorm.DB) error) error {
+	return m.db.Transaction(fn)
+}
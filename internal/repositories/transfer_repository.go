@@ -14,42 +14,97 @@ func NewTransferRepository(db *gorm.DB) TransferRepository {
 	return &transferRepository{db: db}
 }
 
+func (r *transferRepository) WithTx(tx *gorm.DB) TransferRepository {
+	return &transferRepository{db: tx}
+}
+
 func (r *transferRepository) CreateActive(transfer *models.ActiveTicketTransfer) error {
 	return r.db.Create(transfer).Error
 }
 
 func (r *transferRepository) GetActiveByID(id uint) (*models.ActiveTicketTransfer, error) {
 	var transfer models.ActiveTicketTransfer
-	err := r.db.Preload("FromUser").Preload("ToUser").Preload("PurchasedTicket").First(&transfer, id).Error
+	err := r.db.Preload("FromUser").Preload("ToUser").Preload("PurchasedTicket.Ticket").First(&transfer, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &transfer, nil
 }
 
-func (r *transferRepository) UpdateActive(transfer *models.ActiveTicketTransfer) error {
-	return r.db.Save(transfer).Error
+// AcceptPending marks a pending transfer accepted. It reports false when
+// the transfer was no longer pending, e.g. a concurrent accept got there first.
+func (r *transferRepository) AcceptPending(id uint) (bool, error) {
+	result := r.db.Model(&models.ActiveTicketTransfer{}).
+		Where("id = ? AND status = ?", id, models.TransferStatusPending).
+		Update("status", models.TransferStatusAccepted)
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReopenAccepted puts an accepted transfer back to pending, for when it
+// couldn't be completed after all, e.g. because its fee was declined
+func (r *transferRepository) ReopenAccepted(id uint) error {
+	return r.db.Model(&models.ActiveTicketTransfer{}).
+		Where("id = ? AND status = ?", id, models.TransferStatusAccepted).
+		Update("status", models.TransferStatusPending).Error
+}
+
+// RejectPending marks a pending transfer rejected, reporting false when it
+// was accepted or closed first
+func (r *transferRepository) RejectPending(id uint) (bool, error) {
+	result := r.db.Model(&models.ActiveTicketTransfer{}).
+		Where("id = ? AND status = ?", id, models.TransferStatusPending).
+		Update("status", models.TransferStatusRejected)
+	return result.RowsAffected == 1, result.Error
 }
 
 func (r *transferRepository) CreateDone(transfer *models.DoneTicketTransfer) error {
 	return r.db.Create(transfer).Error
 }
 
-func (r *transferRepository) ListActiveByUser(userID uint) ([]models.ActiveTicketTransfer, error) {
+// The transfer lists below run newest first; a limit of -1 lifts the limit
+
+func (r *transferRepository) ListActiveByUser(userID uint, limit, offset int) ([]models.ActiveTicketTransfer, error) {
 	var transfers []models.ActiveTicketTransfer
-	err := r.db.Preload("FromUser").Preload("ToUser").Preload("PurchasedTicket").
-		Where("(from_user_id = ? OR to_user_id = ?) AND status = ?", userID, userID, models.TransferStatusPending).
+	err := r.activeByUser(userID).
+		Preload("FromUser").Preload("ToUser").Preload("PurchasedTicket").
+		Order("date DESC, id DESC").
+		Limit(limit).Offset(offset).
 		Find(&transfers).Error
 	return transfers, err
 }
 
-func (r *transferRepository) ListDoneByUser(userID uint) ([]models.DoneTicketTransfer, error) {
+func (r *transferRepository) CountActiveByUser(userID uint) (int64, error) {
+	var count int64
+	err := r.activeByUser(userID).Count(&count).Error
+	return count, err
+}
+
+func (r *transferRepository) activeByUser(userID uint) *gorm.DB {
+	return r.db.Model(&models.ActiveTicketTransfer{}).
+		Where("(from_user_id = ? OR to_user_id = ?) AND status = ?", userID, userID, models.TransferStatusPending)
+}
+
+func (r *transferRepository) ListDoneByUser(userID uint, limit, offset int) ([]models.DoneTicketTransfer, error) {
 	var transfers []models.DoneTicketTransfer
-	err := r.db.Preload("FromUser").Preload("ToUser").Preload("PurchasedTicket").
-		Where("from_user_id = ? OR to_user_id = ?", userID, userID).Find(&transfers).Error
+	err := r.doneByUser(userID).
+		Preload("FromUser").Preload("ToUser").Preload("PurchasedTicket").
+		Order("date DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&transfers).Error
 	return transfers, err
 }
 
+func (r *transferRepository) CountDoneByUser(userID uint) (int64, error) {
+	var count int64
+	err := r.doneByUser(userID).Count(&count).Error
+	return count, err
+}
+
+func (r *transferRepository) doneByUser(userID uint) *gorm.DB {
+	return r.db.Model(&models.DoneTicketTransfer{}).
+		Where("from_user_id = ? OR to_user_id = ?", userID, userID)
+}
+
 func (r *transferRepository) HasActiveTransferForTicket(ticketID uint) (bool, error) {
 	var count int64
 	err := r.db.Model(&models.ActiveTicketTransfer{}).
@@ -58,11 +113,89 @@ func (r *transferRepository) HasActiveTransferForTicket(ticketID uint) (bool, er
 	return count > 0, err
 }
 
-func (r *transferRepository) ListRejectedByUser(userID uint) ([]models.ActiveTicketTransfer, error) {
+func (r *transferRepository) ListRejectedByUser(userID uint, limit, offset int) ([]models.ActiveTicketTransfer, error) {
 	var transfers []models.ActiveTicketTransfer
-	err := r.db.Preload("FromUser").Preload("ToUser").Preload("PurchasedTicket").
-		Where("(from_user_id = ? OR to_user_id = ?) AND (status = ? OR status = ?)",
-			userID, userID, models.TransferStatusRejected, models.TransferStatusCancelled).
+	err := r.rejectedByUser(userID).
+		Preload("FromUser").Preload("ToUser").Preload("PurchasedTicket").
+		Order("date DESC, id DESC").
+		Limit(limit).Offset(offset).
 		Find(&transfers).Error
 	return transfers, err
 }
+
+func (r *transferRepository) CountRejectedByUser(userID uint) (int64, error) {
+	var count int64
+	err := r.rejectedByUser(userID).Count(&count).Error
+	return count, err
+}
+
+func (r *transferRepository) rejectedByUser(userID uint) *gorm.DB {
+	return r.db.Model(&models.ActiveTicketTransfer{}).
+		Where("(from_user_id = ? OR to_user_id = ?) AND (status = ? OR status = ?)",
+			userID, userID, models.TransferStatusRejected, models.TransferStatusCancelled)
+}
+
+func (r *transferRepository) CancelPendingBefore(before int64) (int64, error) {
+	result := r.db.Model(&models.ActiveTicketTransfer{}).
+		Where("status = ? AND date < ?", models.TransferStatusPending, before).
+		Update("status", models.TransferStatusCancelled)
+	return result.RowsAffected, result.Error
+}
+
+func (r *transferRepository) CreateClaimLink(link *models.TicketClaimLink) error {
+	return r.db.Create(link).Error
+}
+
+func (r *transferRepository) GetClaimLinkByToken(token string) (*models.TicketClaimLink, error) {
+	var link models.TicketClaimLink
+	err := r.db.Preload("FromUser").
+		Preload("PurchasedTicket.Ticket.Event").
+		Where("token = ?", token).
+		First(&link).Error
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *transferRepository) HasOpenClaimLink(ticketID uint, now int64) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.TicketClaimLink{}).
+		Where("purchased_ticket_id = ? AND status = ? AND expires_at > ?", ticketID, models.ClaimLinkStatusOpen, now).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// RevokeClaimLinks closes the ticket's open claim links and reports how
+// many there were
+func (r *transferRepository) RevokeClaimLinks(ticketID uint) (int64, error) {
+	result := r.db.Model(&models.TicketClaimLink{}).
+		Where("purchased_ticket_id = ? AND status = ?", ticketID, models.ClaimLinkStatusOpen).
+		Update("status", models.ClaimLinkStatusRevoked)
+	return result.RowsAffected, result.Error
+}
+
+// ClaimOpenLink marks an open, unexpired link claimed by userID. It reports
+// false when someone else claimed it first or it was revoked meanwhile.
+func (r *transferRepository) ClaimOpenLink(id, userID uint, now int64) (bool, error) {
+	result := r.db.Model(&models.TicketClaimLink{}).
+		Where("id = ? AND status = ? AND expires_at > ?", id, models.ClaimLinkStatusOpen, now).
+		Updates(map[string]interface{}{
+			"status":        models.ClaimLinkStatusClaimed,
+			"claimed_by_id": userID,
+			"claimed_at":    now,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReopenClaimLink opens a link userID claimed again, for when the claim
+// couldn't be completed after all, e.g. because its fee was declined
+func (r *transferRepository) ReopenClaimLink(id, userID uint) error {
+	return r.db.Model(&models.TicketClaimLink{}).
+		Where("id = ? AND status = ? AND claimed_by_id = ?", id, models.ClaimLinkStatusClaimed, userID).
+		Updates(map[string]interface{}{
+			"status":        models.ClaimLinkStatusOpen,
+			"claimed_by_id": nil,
+			"claimed_at":    nil,
+		}).Error
+}
@@ -0,0 +1,284 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"eticketing/internal/dbtest"
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+)
+
+// transferFixture is a ticket owned by from that to can be offered
+type transferFixture struct {
+	db       *gorm.DB
+	from, to *models.User
+	ticket   *models.PurchasedTicket
+}
+
+func newTransferFixture(t *testing.T) *transferFixture {
+	t.Helper()
+
+	db := dbtest.Open(t)
+	seller := dbtest.CreateSeller(t, db, "seller")
+	event := dbtest.CreateEvent(t, db, seller.ID)
+	sale := dbtest.CreateSale(t, db, event.ID)
+	_, tickets := dbtest.CreateGroup(t, db, sale, 10, 1)
+
+	from := dbtest.CreateUser(t, db, "sender")
+	return &transferFixture{
+		db:     db,
+		from:   from,
+		to:     dbtest.CreateUser(t, db, "recipient"),
+		ticket: dbtest.CreatePurchasedTicket(t, db, &tickets[0], from.ID),
+	}
+}
+
+func (f *transferFixture) offer(t *testing.T, status models.TransferStatus, date int64) *models.ActiveTicketTransfer {
+	t.Helper()
+
+	transfer := &models.ActiveTicketTransfer{
+		FromUserID:        f.from.ID,
+		ToUserID:          f.to.ID,
+		Date:              date,
+		PurchasedTicketID: f.ticket.ID,
+		Status:            status,
+	}
+	if err := repositories.NewTransferRepository(f.db).CreateActive(transfer); err != nil {
+		t.Fatalf("create transfer: %v", err)
+	}
+	return transfer
+}
+
+func TestAcceptPendingSucceedsOnce(t *testing.T) {
+	f := newTransferFixture(t)
+	transfer := f.offer(t, models.TransferStatusPending, time.Now().Unix())
+	repo := repositories.NewTransferRepository(f.db)
+
+	var wg sync.WaitGroup
+	accepted := make(chan bool, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := repo.AcceptPending(transfer.ID)
+			if err != nil {
+				t.Errorf("accept: %v", err)
+			}
+			accepted <- ok
+		}()
+	}
+	wg.Wait()
+	close(accepted)
+
+	wins := 0
+	for ok := range accepted {
+		if ok {
+			wins++
+		}
+	}
+	if wins != 1 {
+		t.Errorf("%d concurrent accepts succeeded, want 1", wins)
+	}
+
+	stored, err := repo.GetActiveByID(transfer.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if stored.Status != models.TransferStatusAccepted {
+		t.Errorf("status = %d, want accepted", stored.Status)
+	}
+}
+
+func TestCompletedTransferMovesOwnership(t *testing.T) {
+	f := newTransferFixture(t)
+	transfer := f.offer(t, models.TransferStatusPending, time.Now().Unix())
+	oldSecret := f.ticket.QRSecret
+
+	err := f.db.Transaction(func(tx *gorm.DB) error {
+		transfers := repositories.NewTransferRepository(f.db).WithTx(tx)
+		if ok, err := transfers.AcceptPending(transfer.ID); err != nil || !ok {
+			return fmt.Errorf("accept = %v, %v", ok, err)
+		}
+		if ok, err := repositories.NewPurchasedTicketRepository(f.db).WithTx(tx).UpdateOwnership(f.ticket.ID, f.from.ID, f.to.ID); err != nil || !ok {
+			return fmt.Errorf("update ownership = %v, %v", ok, err)
+		}
+		return transfers.CreateDone(&models.DoneTicketTransfer{
+			FromUserID:        f.from.ID,
+			ToUserID:          f.to.ID,
+			Date:              transfer.Date,
+			PurchasedTicketID: f.ticket.ID,
+			CompletedAt:       time.Now().Unix(),
+			ActiveTransferID:  &transfer.ID,
+		})
+	})
+	if err != nil {
+		t.Fatalf("complete transfer: %v", err)
+	}
+
+	ticket, err := repositories.NewPurchasedTicketRepository(f.db).GetByID(f.ticket.ID)
+	if err != nil {
+		t.Fatalf("get ticket: %v", err)
+	}
+	if ticket.UserID != f.to.ID {
+		t.Errorf("ticket owner = %d, want %d", ticket.UserID, f.to.ID)
+	}
+	if ticket.QRSecret == oldSecret {
+		t.Error("QR secret was not rotated")
+	}
+
+	// The old owner can no longer hand the ticket on
+	if ok, err := repositories.NewPurchasedTicketRepository(f.db).UpdateOwnership(f.ticket.ID, f.from.ID, f.from.ID); err != nil || ok {
+		t.Errorf("update ownership from the old owner = %v, %v; want false", ok, err)
+	}
+
+	// The same transfer can't be completed twice
+	err = repositories.NewTransferRepository(f.db).CreateDone(&models.DoneTicketTransfer{
+		FromUserID:        f.from.ID,
+		ToUserID:          f.to.ID,
+		Date:              transfer.Date,
+		PurchasedTicketID: f.ticket.ID,
+		CompletedAt:       time.Now().Unix(),
+		ActiveTransferID:  &transfer.ID,
+	})
+	if err == nil {
+		t.Error("second completion of the same transfer was stored")
+	}
+
+	repo := repositories.NewTransferRepository(f.db)
+	for _, userID := range []uint{f.from.ID, f.to.ID} {
+		done, err := repo.ListDoneByUser(userID, -1, 0)
+		if err != nil || len(done) != 1 {
+			t.Errorf("done transfers of user %d = %d, %v; want 1", userID, len(done), err)
+		}
+		if count, err := repo.CountActiveByUser(userID); err != nil || count != 0 {
+			t.Errorf("active transfers of user %d = %d, %v; want 0", userID, count, err)
+		}
+	}
+}
+
+func TestTransferListingsByStatus(t *testing.T) {
+	f := newTransferFixture(t)
+	now := time.Now().Unix()
+	pending := f.offer(t, models.TransferStatusPending, now)
+	f.offer(t, models.TransferStatusRejected, now-20)
+	f.offer(t, models.TransferStatusCancelled, now-10)
+
+	repo := repositories.NewTransferRepository(f.db)
+
+	active, err := repo.ListActiveByUser(f.to.ID, -1, 0)
+	if err != nil {
+		t.Fatalf("list active: %v", err)
+	}
+	if len(active) != 1 || active[0].ID != pending.ID {
+		t.Errorf("active transfers = %+v, want only %d", active, pending.ID)
+	}
+
+	has, err := repo.HasActiveTransferForTicket(f.ticket.ID)
+	if err != nil || !has {
+		t.Errorf("HasActiveTransferForTicket = %v, %v; want true", has, err)
+	}
+
+	rejected, err := repo.ListRejectedByUser(f.from.ID, -1, 0)
+	if err != nil {
+		t.Fatalf("list rejected: %v", err)
+	}
+	if len(rejected) != 2 || rejected[0].Status != models.TransferStatusCancelled {
+		t.Errorf("rejected transfers = %+v, want the cancelled one then the rejected one", rejected)
+	}
+	if count, err := repo.CountRejectedByUser(f.to.ID); err != nil || count != 2 {
+		t.Errorf("CountRejectedByUser = %d, %v; want 2", count, err)
+	}
+}
+
+func TestCancelPendingBefore(t *testing.T) {
+	f := newTransferFixture(t)
+	now := time.Now().Unix()
+	stale := f.offer(t, models.TransferStatusPending, now-3600)
+	fresh := f.offer(t, models.TransferStatusPending, now)
+
+	repo := repositories.NewTransferRepository(f.db)
+	cancelled, err := repo.CancelPendingBefore(now - 60)
+	if err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	if cancelled != 1 {
+		t.Errorf("cancelled %d transfers, want 1", cancelled)
+	}
+
+	for transfer, want := range map[uint]models.TransferStatus{
+		stale.ID: models.TransferStatusCancelled,
+		fresh.ID: models.TransferStatusPending,
+	} {
+		stored, err := repo.GetActiveByID(transfer)
+		if err != nil {
+			t.Fatalf("get %d: %v", transfer, err)
+		}
+		if stored.Status != want {
+			t.Errorf("transfer %d status = %d, want %d", transfer, stored.Status, want)
+		}
+	}
+}
+
+func TestClaimOpenLinkSucceedsOnce(t *testing.T) {
+	f := newTransferFixture(t)
+	now := time.Now().Unix()
+	repo := repositories.NewTransferRepository(f.db)
+
+	link := &models.TicketClaimLink{
+		PurchasedTicketID: f.ticket.ID,
+		FromUserID:        f.from.ID,
+		Token:             "open-link",
+		CreatedAt:         now,
+		ExpiresAt:         now + 3600,
+	}
+	if err := repo.CreateClaimLink(link); err != nil {
+		t.Fatalf("create link: %v", err)
+	}
+
+	other := dbtest.CreateUser(t, f.db, "other")
+	var wg sync.WaitGroup
+	claimed := make(chan uint, 2)
+	for _, userID := range []uint{f.to.ID, other.ID} {
+		wg.Add(1)
+		go func(userID uint) {
+			defer wg.Done()
+			ok, err := repo.ClaimOpenLink(link.ID, userID, now)
+			if err != nil {
+				t.Errorf("claim: %v", err)
+			}
+			if ok {
+				claimed <- userID
+			}
+		}(userID)
+	}
+	wg.Wait()
+	close(claimed)
+
+	if len(claimed) != 1 {
+		t.Fatalf("%d claims succeeded, want 1", len(claimed))
+	}
+	if open, err := repo.HasOpenClaimLink(f.ticket.ID, now); err != nil || open {
+		t.Errorf("HasOpenClaimLink after claim = %v, %v; want false", open, err)
+	}
+
+	expired := &models.TicketClaimLink{
+		PurchasedTicketID: f.ticket.ID,
+		FromUserID:        f.from.ID,
+		Token:             "expired-link",
+		CreatedAt:         now - 7200,
+		ExpiresAt:         now - 3600,
+	}
+	if err := repo.CreateClaimLink(expired); err != nil {
+		t.Fatalf("create expired link: %v", err)
+	}
+	if ok, err := repo.ClaimOpenLink(expired.ID, f.to.ID, now); err != nil || ok {
+		t.Errorf("claiming an expired link = %v, %v; want false", ok, err)
+	}
+}
@@ -2,6 +2,8 @@
 package repositories
 
 import (
+	"fmt"
+
 	"eticketing/internal/models"
 	"gorm.io/gorm"
 )
@@ -27,6 +29,15 @@ func (r *userRepository) GetByID(id uint) (*models.User, error) {
 	return &user, nil
 }
 
+func (r *userRepository) GetByCalendarToken(token string) (*models.User, error) {
+	var user models.User
+	err := r.db.Where("calendar_token = ?", token).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (r *userRepository) GetByEmail(email string) (*models.User, error) {
 	var user models.User
 	err := r.db.Where("email = ?", email).First(&user).Error
@@ -59,8 +70,108 @@ func (r *userRepository) List(limit, offset int) ([]models.User, error) {
 	return users, err
 }
 
+func (r *userRepository) ListByIDs(ids []uint) ([]models.User, error) {
+	var users []models.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
 func (r *userRepository) Count() (int64, error) {
 	var count int64
 	err := r.db.Model(&models.User{}).Count(&count).Error
 	return count, err
 }
+
+// ListDueForAnonymization returns accounts whose deletion grace period has
+// ended and that still hold personal data
+func (r *userRepository) ListDueForAnonymization(now int64, limit int) ([]models.User, error) {
+	var users []models.User
+	err := r.db.
+		Where("deletion_scheduled_at <= ? AND anonymized_at IS NULL", now).
+		Order("deletion_scheduled_at").
+		Limit(limit).
+		Find(&users).Error
+	return users, err
+}
+
+// Anonymize replaces the user's personal data with placeholders and removes
+// what only served the account: stored payment methods, follows, data
+// exports and pending transfers to or from the user. Orders, payments,
+// purchased tickets, gifts and completed transfers are kept for bookkeeping
+// with the client info, gift emails and messages and accommodation requests
+// on them cleared.
+func (r *userRepository) Anonymize(userID uint, anonymizedAt int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"username":       fmt.Sprintf("deleted-user-%d", userID),
+			"email":          fmt.Sprintf("deleted-user-%d@anonymized.invalid", userID),
+			"name":           "Deleted",
+			"surname":        "User",
+			"password_hash":  "", // Matches no password
+			"calendar_token": nil,
+			"anonymized_at":  anonymizedAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		// Sellers are numbered separately, so the same ID may be a seller's
+		err = tx.Where("user_id = ? AND user_type = ?", userID, models.UserTypeUser).
+			Delete(&models.PaymentMethod{}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.Follow{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.DataExport{}).Error; err != nil {
+			return err
+		}
+
+		err = tx.Model(&models.Payment{}).
+			Where("user_id = ? AND user_type = ?", userID, models.UserTypeUser).
+			Updates(map[string]interface{}{"client_ip": "", "device_fingerprint": ""}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&models.Order{}).Where("user_id = ?", userID).Updates(map[string]interface{}{
+			"client_ip":             "",
+			"device_fingerprint":    "",
+			"gift_email":            "",
+			"gift_message":          "",
+			"accommodation_request": "",
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&models.PurchasedTicket{}).Where("user_id = ?", userID).
+			Update("accommodation_request", "").Error
+		if err != nil {
+			return err
+		}
+
+		// Gifts name both sides: the sender by name, the recipient by email
+		err = tx.Model(&models.TicketGift{}).Where("sender_id = ?", userID).Updates(map[string]interface{}{
+			"sender_name":     "Deleted User",
+			"recipient_email": "",
+			"message":         "",
+		}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&models.TicketGift{}).Where("recipient_id = ?", userID).
+			Update("recipient_email", "").Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&models.ActiveTicketTransfer{}).
+			Where("(from_user_id = ? OR to_user_id = ?) AND status = ?", userID, userID, models.TransferStatusPending).
+			Update("status", models.TransferStatusCancelled).Error
+	})
+}
@@ -0,0 +1,113 @@
+//go:build integration
+
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"eticketing/internal/dbtest"
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+)
+
+func TestAnonymizeKeepsTheDataOfASellerWithTheSameID(t *testing.T) {
+	db := dbtest.Open(t)
+	user := dbtest.CreateUser(t, db, "buyer")
+	seller := dbtest.CreateSeller(t, db, "seller")
+	if user.ID != seller.ID {
+		t.Fatalf("user %d and seller %d should share an ID on empty tables", user.ID, seller.ID)
+	}
+
+	event := dbtest.CreateEvent(t, db, seller.ID)
+	sale := dbtest.CreateSale(t, db, event.ID)
+	_, tickets := dbtest.CreateGroup(t, db, sale, 10, 1)
+
+	for _, method := range []*models.PaymentMethod{
+		{Type: models.PaymentTypeCard, Token: "tok_user", UserID: user.ID, UserType: models.UserTypeUser},
+		{Type: models.PaymentTypeCard, Token: "tok_seller", UserID: seller.ID, UserType: models.UserTypeSeller},
+	} {
+		if err := db.Create(method).Error; err != nil {
+			t.Fatalf("create payment method: %v", err)
+		}
+	}
+
+	now := time.Now().Unix()
+	payments := []*models.Payment{
+		{UserID: user.ID, UserType: models.UserTypeUser, Date: now, Type: models.PaymentTypeCard, Amount: 10,
+			ClientIP: "203.0.113.7", DeviceFingerprint: "user-device"},
+		{UserID: seller.ID, UserType: models.UserTypeSeller, Date: now, Type: models.PaymentTypeCard, Amount: 9.5,
+			ClientIP: "198.51.100.1", DeviceFingerprint: "seller-device"},
+	}
+	for _, payment := range payments {
+		if err := db.Create(payment).Error; err != nil {
+			t.Fatalf("create payment: %v", err)
+		}
+	}
+
+	order := &models.Order{
+		UserID: user.ID, EventID: event.ID, SaleID: sale.ID, Price: 10, Type: models.TicketTypeRegular,
+		Title: "Standing", Place: "Floor", Quantity: 1, TotalAmount: 10, HoldExpiresAt: now,
+		GiftEmail: "friend@example.com", GiftMessage: "Enjoy", AccommodationRequest: "Wheelchair space",
+		ClientIP: "203.0.113.7", DeviceFingerprint: "user-device",
+	}
+	if err := db.Create(order).Error; err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+
+	purchased := dbtest.CreatePurchasedTicket(t, db, &tickets[0], user.ID)
+	db.Model(purchased).Update("accommodation_request", "Wheelchair space")
+
+	gift := &models.TicketGift{
+		PurchasedTicketID: purchased.ID,
+		SenderID:          user.ID,
+		SenderName:        "Test buyer",
+		RecipientEmail:    "friend@example.com",
+		Message:           "Enjoy",
+	}
+	if err := db.Create(gift).Error; err != nil {
+		t.Fatalf("create gift: %v", err)
+	}
+
+	if err := repositories.NewUserRepository(db).Anonymize(user.ID, now); err != nil {
+		t.Fatalf("anonymize: %v", err)
+	}
+
+	var methods []models.PaymentMethod
+	db.Order("id").Find(&methods)
+	if len(methods) != 1 || methods[0].UserType != models.UserTypeSeller {
+		t.Errorf("payment methods left = %+v, want only the seller's", methods)
+	}
+
+	var userPayment, sellerPayment models.Payment
+	db.First(&userPayment, payments[0].ID)
+	db.First(&sellerPayment, payments[1].ID)
+	if userPayment.ClientIP != "" || userPayment.DeviceFingerprint != "" {
+		t.Errorf("user payment client info = %q %q, want cleared", userPayment.ClientIP, userPayment.DeviceFingerprint)
+	}
+	if userPayment.Amount != 10 {
+		t.Errorf("user payment amount = %v, want it kept", userPayment.Amount)
+	}
+	if sellerPayment.ClientIP == "" || sellerPayment.DeviceFingerprint == "" {
+		t.Error("seller payment client info was cleared")
+	}
+
+	var storedOrder models.Order
+	db.First(&storedOrder, order.ID)
+	if storedOrder.GiftEmail != "" || storedOrder.GiftMessage != "" || storedOrder.AccommodationRequest != "" ||
+		storedOrder.ClientIP != "" || storedOrder.DeviceFingerprint != "" {
+		t.Errorf("order personal data not cleared: %+v", storedOrder)
+	}
+
+	var storedTicket models.PurchasedTicket
+	db.First(&storedTicket, purchased.ID)
+	if storedTicket.AccommodationRequest != "" {
+		t.Errorf("ticket accommodation request = %q, want cleared", storedTicket.AccommodationRequest)
+	}
+
+	var storedGift models.TicketGift
+	db.First(&storedGift, gift.ID)
+	if storedGift.RecipientEmail != "" || storedGift.Message != "" || storedGift.SenderName == "Test buyer" {
+		t.Errorf("gift personal data not cleared: %+v", storedGift)
+	}
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type venueRepository struct {
+	db *gorm.DB
+}
+
+func NewVenueRepository(db *gorm.DB) VenueRepository {
+	return &venueRepository{db: db}
+}
+
+func (r *venueRepository) Create(venue *models.Venue) error {
+	return r.db.Create(venue).Error
+}
+
+func (r *venueRepository) GetByID(id uint) (*models.Venue, error) {
+	var venue models.Venue
+	err := r.db.First(&venue, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &venue, nil
+}
+
+func (r *venueRepository) Update(venue *models.Venue) error {
+	return r.db.Save(venue).Error
+}
+
+func (r *venueRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Venue{}, id).Error
+}
+
+func (r *venueRepository) List(limit, offset int) ([]models.Venue, error) {
+	var venues []models.Venue
+	err := r.db.Order("name").Limit(limit).Offset(offset).Find(&venues).Error
+	return venues, err
+}
+
+func (r *venueRepository) Count() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Venue{}).Count(&count).Error
+	return count, err
+}
+
+func (r *venueRepository) ListBySeller(sellerID uint) ([]models.Venue, error) {
+	var venues []models.Venue
+	err := r.db.Where("seller_id = ?", sellerID).Order("name").Find(&venues).Error
+	return venues, err
+}
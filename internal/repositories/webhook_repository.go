@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"eticketing/internal/models"
+	"gorm.io/gorm"
+)
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) Create(webhook *models.SellerWebhook) error {
+	return r.db.Create(webhook).Error
+}
+
+func (r *webhookRepository) GetByID(id uint) (*models.SellerWebhook, error) {
+	var webhook models.SellerWebhook
+	err := r.db.First(&webhook, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepository) Delete(id uint) error {
+	return r.db.Delete(&models.SellerWebhook{}, id).Error
+}
+
+func (r *webhookRepository) ListBySeller(sellerID uint) ([]models.SellerWebhook, error) {
+	var webhooks []models.SellerWebhook
+	err := r.db.Where("seller_id = ?", sellerID).Order("id").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) ListActiveBySeller(sellerID uint) ([]models.SellerWebhook, error) {
+	var webhooks []models.SellerWebhook
+	err := r.db.Where("seller_id = ? AND is_active = true", sellerID).Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *webhookRepository) CreateDelivery(delivery *models.WebhookDelivery) error {
+	return r.db.Create(delivery).Error
+}
+
+func (r *webhookRepository) ListDeliveries(webhookID uint, limit, offset int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.Where("webhook_id = ?", webhookID).
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *webhookRepository) CountDeliveries(webhookID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.WebhookDelivery{}).Where("webhook_id = ?", webhookID).Count(&count).Error
+	return count, err
+}
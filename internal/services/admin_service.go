@@ -3,10 +3,15 @@ package services
 
 import (
 	"errors"
+	"io"
+	"strconv"
+	"time"
 
 	"eticketing/internal/models"
+	"eticketing/internal/outbox"
 	"eticketing/internal/repositories"
 	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
 	"gorm.io/gorm"
 )
 
@@ -16,6 +21,8 @@ type AdminService struct {
 	sellerRepo  repositories.SellerRepository
 	eventRepo   repositories.EventRepository
 	paymentRepo repositories.PaymentRepository
+	outboxRepo  repositories.OutboxRepository
+	txManager   repositories.TransactionManager
 }
 
 type AdminInfo struct {
@@ -43,12 +50,71 @@ type EventApprovalRequest struct {
 	Reason  string `json:"reason"`
 }
 
+// ReconciliationReport sums the customer payments of a period and checks
+// that every seller was credited their share of them
+type ReconciliationReport struct {
+	From       int64                  `json:"from"`
+	To         int64                  `json:"to"`
+	FeeRate    float64                `json:"fee_rate"`
+	ByStatus   []StatusTotal          `json:"by_status"`
+	ByProvider []ProviderTotal        `json:"by_provider"`
+	BySeller   []SellerReconciliation `json:"by_seller"`
+	Mismatches []EventReconciliation  `json:"mismatches"`
+	Totals     ReconciliationTotals   `json:"totals"`
+}
+
+type StatusTotal struct {
+	Status models.PaymentStatus `json:"status"`
+	Name   string               `json:"name"`
+	Count  int64                `json:"count"`
+	Amount float64              `json:"amount"`
+}
+
+type ProviderTotal struct {
+	Provider string             `json:"provider"` // "direct" for charges without a stored method
+	Type     models.PaymentType `json:"type"`
+	Count    int64              `json:"count"`
+	Amount   float64            `json:"amount"`
+}
+
+type SellerReconciliation struct {
+	SellerID        uint                  `json:"seller_id"`
+	SellerName      string                `json:"seller_name"`
+	CustomerAmount  float64               `json:"customer_amount"`
+	ExpectedRevenue float64               `json:"expected_revenue"`
+	SellerRevenue   float64               `json:"seller_revenue"`
+	PlatformFee     float64               `json:"platform_fee"`
+	Difference      float64               `json:"difference"`
+	Mismatch        bool                  `json:"mismatch"`
+	Events          []EventReconciliation `json:"events"`
+}
+
+type EventReconciliation struct {
+	EventID         uint    `json:"event_id"`
+	EventTitle      string  `json:"event_title"`
+	SellerID        uint    `json:"seller_id"`
+	CustomerAmount  float64 `json:"customer_amount"`
+	ExpectedRevenue float64 `json:"expected_revenue"`
+	SellerRevenue   float64 `json:"seller_revenue"`
+	Difference      float64 `json:"difference"` // Seller revenue minus expected revenue
+	Mismatch        bool    `json:"mismatch"`
+}
+
+type ReconciliationTotals struct {
+	CustomerAmount  float64 `json:"customer_amount"`
+	SellerRevenue   float64 `json:"seller_revenue"`
+	PlatformFee     float64 `json:"platform_fee"`
+	MismatchedCount int     `json:"mismatched_count"`
+}
+
 func NewAdminService(
 	adminRepo repositories.AdminRepository,
 	userRepo repositories.UserRepository,
 	sellerRepo repositories.SellerRepository,
 	eventRepo repositories.EventRepository,
 	paymentRepo repositories.PaymentRepository,
+	outboxRepo repositories.OutboxRepository,
+	txManager repositories.TransactionManager,
 ) *AdminService {
 	return &AdminService{
 		adminRepo:   adminRepo,
@@ -56,6 +122,8 @@ func NewAdminService(
 		sellerRepo:  sellerRepo,
 		eventRepo:   eventRepo,
 		paymentRepo: paymentRepo,
+		outboxRepo:  outboxRepo,
+		txManager:   txManager,
 	}
 }
 
@@ -63,9 +131,9 @@ func (s *AdminService) GetProfile(adminID uint) (*AdminInfo, error) {
 	admin, err := s.adminRepo.GetByID(adminID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("admin not found")
+			return nil, apperrors.NotFound("admin not found")
 		}
-		return nil, errors.New("failed to get admin profile")
+		return nil, apperrors.Internal("failed to get admin profile")
 	}
 
 	return &AdminInfo{
@@ -82,18 +150,18 @@ func (s *AdminService) GetProfile(adminID uint) (*AdminInfo, error) {
 func (s *AdminService) UpdateProfile(adminID uint, req *UpdateProfileRequest) (*AdminInfo, error) {
 	admin, err := s.adminRepo.GetByID(adminID)
 	if err != nil {
-		return nil, errors.New("admin not found")
+		return nil, apperrors.NotFound("admin not found")
 	}
 
 	// Validate username if provided
 	if req.Username != "" && req.Username != admin.Username {
 		if valid, validationErrors := utils.ValidateUsername(req.Username); !valid {
-			return nil, errors.New("username validation failed: " + validationErrors[0])
+			return nil, apperrors.Validation("username validation failed: " + validationErrors[0])
 		}
 
 		// Check if username is already taken
 		if existingAdmin, _ := s.adminRepo.GetByUsername(req.Username); existingAdmin != nil && existingAdmin.ID != adminID {
-			return nil, errors.New("username already taken")
+			return nil, apperrors.Conflict("username already taken")
 		}
 		admin.Username = utils.SanitizeString(req.Username)
 	}
@@ -107,7 +175,7 @@ func (s *AdminService) UpdateProfile(adminID uint, req *UpdateProfileRequest) (*
 	}
 
 	if err := s.adminRepo.Update(admin); err != nil {
-		return nil, errors.New("failed to update profile")
+		return nil, apperrors.Internal("failed to update profile")
 	}
 
 	return &AdminInfo{
@@ -124,28 +192,28 @@ func (s *AdminService) UpdateProfile(adminID uint, req *UpdateProfileRequest) (*
 func (s *AdminService) ChangePassword(adminID uint, req *ChangePasswordRequest) error {
 	admin, err := s.adminRepo.GetByID(adminID)
 	if err != nil {
-		return errors.New("admin not found")
+		return apperrors.NotFound("admin not found")
 	}
 
 	// Verify current password
 	if !utils.CheckPassword(req.CurrentPassword, admin.PasswordHash) {
-		return errors.New("current password is incorrect")
+		return apperrors.Validation("current password is incorrect")
 	}
 
 	// Validate new password
 	if valid, validationErrors := utils.ValidatePassword(req.NewPassword); !valid {
-		return errors.New("password validation failed: " + validationErrors[0])
+		return apperrors.Validation("password validation failed: " + validationErrors[0])
 	}
 
 	// Hash new password
 	hashedPassword, err := utils.HashPassword(req.NewPassword)
 	if err != nil {
-		return errors.New("failed to hash password")
+		return apperrors.Internal("failed to hash password")
 	}
 
 	admin.PasswordHash = hashedPassword
 	if err := s.adminRepo.Update(admin); err != nil {
-		return errors.New("failed to update password")
+		return apperrors.Internal("failed to update password")
 	}
 
 	return nil
@@ -155,39 +223,39 @@ func (s *AdminService) GetSystemStats() (*SystemStats, error) {
 	// Get user counts
 	totalUsers, err := s.userRepo.Count()
 	if err != nil {
-		return nil, errors.New("failed to count users")
+		return nil, apperrors.Internal("failed to count users")
 	}
 
 	totalSellers, err := s.sellerRepo.Count()
 	if err != nil {
-		return nil, errors.New("failed to count sellers")
+		return nil, apperrors.Internal("failed to count sellers")
 	}
 
 	totalAdmins, err := s.adminRepo.Count()
 	if err != nil {
-		return nil, errors.New("failed to count admins")
+		return nil, apperrors.Internal("failed to count admins")
 	}
 
 	// Get event counts
 	pendingEvents, err := s.eventRepo.CountByStatus(models.EventStatusPending)
 	if err != nil {
-		return nil, errors.New("failed to count pending events")
+		return nil, apperrors.Internal("failed to count pending events")
 	}
 
 	approvedEvents, err := s.eventRepo.CountByStatus(models.EventStatusApproved)
 	if err != nil {
-		return nil, errors.New("failed to count approved events")
+		return nil, apperrors.Internal("failed to count approved events")
 	}
 
 	// Get payment stats
 	totalRevenue, err := s.paymentRepo.GetTotalRevenue()
 	if err != nil {
-		return nil, errors.New("failed to get total revenue")
+		return nil, apperrors.Internal("failed to get total revenue")
 	}
 
 	totalTransactions, err := s.paymentRepo.CountTransactions()
 	if err != nil {
-		return nil, errors.New("failed to count transactions")
+		return nil, apperrors.Internal("failed to count transactions")
 	}
 
 	return &SystemStats{
@@ -205,12 +273,12 @@ func (s *AdminService) GetPendingEvents(page, limit int) (*utils.PaginatedRespon
 	offset := (page - 1) * limit
 	events, err := s.eventRepo.ListByStatus(models.EventStatusPending, limit, offset)
 	if err != nil {
-		return nil, errors.New("failed to retrieve pending events")
+		return nil, apperrors.Internal("failed to retrieve pending events")
 	}
 
 	total, err := s.eventRepo.CountByStatus(models.EventStatusPending)
 	if err != nil {
-		return nil, errors.New("failed to count pending events")
+		return nil, apperrors.Internal("failed to count pending events")
 	}
 
 	// Convert to response format
@@ -226,6 +294,12 @@ func (s *AdminService) GetPendingEvents(page, limit int) (*utils.PaginatedRespon
 			Status:      event.Status,
 			SellerID:    event.SellerID,
 			SellerName:  event.Seller.Name + " " + event.Seller.Surname,
+			Capacity:    event.Capacity,
+			Flagged:     event.Flagged,
+			FlagReasons: event.FlagReasons,
+			PublishAt:   event.PublishAt,
+			Timezone:    eventLocation(&event).String(),
+			DateISO:     eventTime(&event, event.Date).Format(time.RFC3339),
 		}
 		eventResponses = append(eventResponses, response)
 	}
@@ -243,16 +317,19 @@ func (s *AdminService) GetPendingEvents(page, limit int) (*utils.PaginatedRespon
 func (s *AdminService) ApproveEvent(eventID uint) error {
 	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
-		return errors.New("event not found")
+		return apperrors.NotFound("event not found")
 	}
 
 	if event.Status != models.EventStatusPending {
-		return errors.New("only pending events can be approved")
+		return apperrors.Validation("only pending events can be approved")
 	}
 
+	// Approving is the admin's review of anything moderation flagged
 	event.Status = models.EventStatusApproved
-	if err := s.eventRepo.Update(event); err != nil {
-		return errors.New("failed to approve event")
+	event.Flagged = false
+	event.FlagReasons = ""
+	if err := s.updateEventStatus(event, models.OutboxTopicEventApproved, ""); err != nil {
+		return apperrors.Internal("failed to approve event")
 	}
 
 	return nil
@@ -261,18 +338,308 @@ func (s *AdminService) ApproveEvent(eventID uint) error {
 func (s *AdminService) RejectEvent(eventID uint, reason string) error {
 	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
-		return errors.New("event not found")
+		return apperrors.NotFound("event not found")
 	}
 
 	if event.Status != models.EventStatusPending {
-		return errors.New("only pending events can be rejected")
+		return apperrors.Validation("only pending events can be rejected")
 	}
 
 	event.Status = models.EventStatusRejected
 	// TODO: Store rejection reason in event data or create separate table
-	if err := s.eventRepo.Update(event); err != nil {
-		return errors.New("failed to reject event")
+	if err := s.updateEventStatus(event, models.OutboxTopicEventRejected, reason); err != nil {
+		return apperrors.Internal("failed to reject event")
 	}
 
 	return nil
 }
+
+// SuspendEvent unpublishes an approved event while reports against it are
+// investigated. It drops out of listings and can't be bought from until it
+// is reinstated.
+func (s *AdminService) SuspendEvent(eventID uint, reason string) error {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return apperrors.NotFound("event not found")
+	}
+
+	if event.Status != models.EventStatusApproved {
+		return apperrors.Validation("only approved events can be unpublished")
+	}
+
+	event.Status = models.EventStatusSuspended
+	if err := s.updateEventStatus(event, models.OutboxTopicEventSuspended, reason); err != nil {
+		return apperrors.Internal("failed to unpublish event")
+	}
+
+	return nil
+}
+
+// ReinstateEvent publishes a suspended event again once the investigation
+// clears it
+func (s *AdminService) ReinstateEvent(eventID uint) error {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return apperrors.NotFound("event not found")
+	}
+
+	if event.Status != models.EventStatusSuspended {
+		return apperrors.Validation("only unpublished events can be reinstated")
+	}
+
+	event.Status = models.EventStatusApproved
+	if err := s.updateEventStatus(event, models.OutboxTopicEventApproved, "reinstated after review"); err != nil {
+		return apperrors.Internal("failed to reinstate event")
+	}
+
+	return nil
+}
+
+// updateEventStatus saves the event and queues the seller notification atomically
+func (s *AdminService) updateEventStatus(event *models.Event, topic, reason string) error {
+	message, err := outbox.NewMessage(topic, outbox.EventStatusPayload{
+		EventID:  event.ID,
+		SellerID: event.SellerID,
+		Title:    event.Title,
+		Status:   event.Status,
+		Reason:   reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.eventRepo.WithTx(tx).Update(event); err != nil {
+			return err
+		}
+		return s.outboxRepo.WithTx(tx).Create(message)
+	})
+}
+
+// GetReconciliationReport builds the payment reconciliation report for
+// payments dated between from and to (unix seconds, inclusive). An event is
+// flagged when its seller revenue rows differ from the completed customer
+// payments minus the platform fee by a cent or more. Invoices are credited to
+// the seller when settled, so an invoice paid after the period shows up as a
+// mismatch until the report covers both dates.
+func (s *AdminService) GetReconciliationReport(from, to int64) (*ReconciliationReport, error) {
+	if from > to {
+		return nil, apperrors.Validation("from must not be after to")
+	}
+
+	statusTotals, err := s.paymentRepo.SumByStatus(from, to)
+	if err != nil {
+		return nil, apperrors.Internal("failed to sum payments by status")
+	}
+
+	providerTotals, err := s.paymentRepo.SumByProvider(from, to)
+	if err != nil {
+		return nil, apperrors.Internal("failed to sum payments by provider")
+	}
+
+	eventTotals, err := s.paymentRepo.SumRevenueByEvent(from, to)
+	if err != nil {
+		return nil, apperrors.Internal("failed to sum seller revenue")
+	}
+
+	report := &ReconciliationReport{
+		From:       from,
+		To:         to,
+		FeeRate:    PlatformFeeRate(),
+		ByStatus:   []StatusTotal{},
+		ByProvider: []ProviderTotal{},
+		BySeller:   []SellerReconciliation{},
+		Mismatches: []EventReconciliation{},
+	}
+
+	for _, total := range statusTotals {
+		report.ByStatus = append(report.ByStatus, StatusTotal{
+			Status: total.Status,
+			Name:   paymentStatusName(total.Status),
+			Count:  total.Count,
+			Amount: roundCents(total.Amount),
+		})
+	}
+
+	for _, total := range providerTotals {
+		provider := total.Provider
+		if provider == "" {
+			provider = "direct"
+		}
+		report.ByProvider = append(report.ByProvider, ProviderTotal{
+			Provider: provider,
+			Type:     total.Type,
+			Count:    total.Count,
+			Amount:   roundCents(total.Amount),
+		})
+	}
+
+	// Rows come ordered by seller, so each seller's events are contiguous
+	for _, total := range eventTotals {
+		event := reconcileEvent(total)
+
+		if n := len(report.BySeller); n == 0 || report.BySeller[n-1].SellerID != event.SellerID {
+			report.BySeller = append(report.BySeller, SellerReconciliation{
+				SellerID:   event.SellerID,
+				SellerName: s.sellerName(event.SellerID),
+			})
+		}
+		seller := &report.BySeller[len(report.BySeller)-1]
+		seller.CustomerAmount = roundCents(seller.CustomerAmount + event.CustomerAmount)
+		seller.ExpectedRevenue = roundCents(seller.ExpectedRevenue + event.ExpectedRevenue)
+		seller.SellerRevenue = roundCents(seller.SellerRevenue + event.SellerRevenue)
+		seller.Difference = roundCents(seller.SellerRevenue - seller.ExpectedRevenue)
+		seller.PlatformFee = roundCents(seller.CustomerAmount - seller.SellerRevenue)
+		seller.Events = append(seller.Events, event)
+
+		if event.Mismatch {
+			seller.Mismatch = true
+			report.Mismatches = append(report.Mismatches, event)
+		}
+
+		report.Totals.CustomerAmount = roundCents(report.Totals.CustomerAmount + event.CustomerAmount)
+		report.Totals.SellerRevenue = roundCents(report.Totals.SellerRevenue + event.SellerRevenue)
+	}
+
+	report.Totals.PlatformFee = roundCents(report.Totals.CustomerAmount - report.Totals.SellerRevenue)
+	report.Totals.MismatchedCount = len(report.Mismatches)
+
+	return report, nil
+}
+
+// ExportReconciliationCSV writes the per-event lines of report to w as CSV
+func (s *AdminService) ExportReconciliationCSV(report *ReconciliationReport, w io.Writer) error {
+	stream := utils.NewCSVStream(w)
+	if err := stream.Write([]string{
+		"seller_id", "seller_name", "event_id", "event_title", "customer_amount",
+		"expected_revenue", "seller_revenue", "difference", "mismatch",
+	}); err != nil {
+		return err
+	}
+
+	for _, seller := range report.BySeller {
+		for _, event := range seller.Events {
+			if err := stream.Write([]string{
+				strconv.FormatUint(uint64(seller.SellerID), 10),
+				seller.SellerName,
+				strconv.FormatUint(uint64(event.EventID), 10),
+				event.EventTitle,
+				strconv.FormatFloat(event.CustomerAmount, 'f', 2, 64),
+				strconv.FormatFloat(event.ExpectedRevenue, 'f', 2, 64),
+				strconv.FormatFloat(event.SellerRevenue, 'f', 2, 64),
+				strconv.FormatFloat(event.Difference, 'f', 2, 64),
+				strconv.FormatBool(event.Mismatch),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return stream.Flush()
+}
+
+// eventExportBatchSize is how many events are loaded and written at a time
+const eventExportBatchSize = 500
+
+var eventStatusNames = map[models.EventStatus]string{
+	models.EventStatusPending:   "pending",
+	models.EventStatusApproved:  "approved",
+	models.EventStatusRejected:  "rejected",
+	models.EventStatusCancelled: "cancelled",
+	models.EventStatusSuspended: "suspended",
+}
+
+// ExportEventsCSV writes every event matching filter to w as CSV, with its
+// seller, ticket counts and revenue. Events are loaded in batches and
+// streamed out as they are written, so the export never holds more than one
+// batch in memory.
+func (s *AdminService) ExportEventsCSV(filter repositories.EventExportFilter, w io.Writer) error {
+	stream := utils.NewCSVStream(w)
+	var afterID uint
+	for {
+		rows, err := s.eventRepo.ListForExport(filter, afterID, eventExportBatchSize)
+		if err != nil {
+			return apperrors.Internal("failed to retrieve events")
+		}
+
+		// Written after the first batch loads, so a failing query can still
+		// be reported as an error response
+		if afterID == 0 {
+			if err := stream.Write([]string{
+				"event_id", "title", "status", "date", "seller_id", "seller_name", "seller_email",
+				"capacity", "tickets_total", "tickets_sold", "tickets_checked_in",
+				"gross_revenue", "refunded_amount", "seller_revenue",
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, row := range rows {
+			event := row.Event
+			if err := stream.Write([]string{
+				strconv.FormatUint(uint64(event.ID), 10),
+				event.Title,
+				eventStatusNames[event.Status],
+				eventTime(&event, event.Date).Format(time.RFC3339),
+				strconv.FormatUint(uint64(event.SellerID), 10),
+				event.Seller.Name + " " + event.Seller.Surname,
+				event.Seller.Email,
+				strconv.Itoa(event.Capacity),
+				strconv.FormatInt(row.TicketsTotal, 10),
+				strconv.FormatInt(row.TicketsSold, 10),
+				strconv.FormatInt(row.TicketsCheckedIn, 10),
+				strconv.FormatFloat(row.GrossRevenue, 'f', 2, 64),
+				strconv.FormatFloat(row.RefundedAmount, 'f', 2, 64),
+				strconv.FormatFloat(row.SellerRevenue, 'f', 2, 64),
+			}); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < eventExportBatchSize {
+			return stream.Flush()
+		}
+		afterID = rows[len(rows)-1].Event.ID
+	}
+}
+
+func (s *AdminService) sellerName(sellerID uint) string {
+	seller, err := s.sellerRepo.GetByID(sellerID)
+	if err != nil {
+		return ""
+	}
+	return seller.Name + " " + seller.Surname
+}
+
+func reconcileEvent(total repositories.EventRevenueTotal) EventReconciliation {
+	customerAmount := roundCents(total.CustomerAmount)
+	expected := roundCents(total.ExpectedSellerAmount)
+	sellerRevenue := roundCents(total.SellerAmount)
+	difference := roundCents(sellerRevenue - expected)
+
+	return EventReconciliation{
+		EventID:         total.EventID,
+		EventTitle:      total.EventTitle,
+		SellerID:        total.SellerID,
+		CustomerAmount:  customerAmount,
+		ExpectedRevenue: expected,
+		SellerRevenue:   sellerRevenue,
+		Difference:      difference,
+		Mismatch:        difference != 0,
+	}
+}
+
+func paymentStatusName(status models.PaymentStatus) string {
+	switch status {
+	case models.PaymentStatusPending:
+		return "pending"
+	case models.PaymentStatusCompleted:
+		return "completed"
+	case models.PaymentStatusFailed:
+		return "failed"
+	case models.PaymentStatusRefunded:
+		return "refunded"
+	default:
+		return "unknown"
+	}
+}
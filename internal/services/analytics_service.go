@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/outbox"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+)
+
+// analyticsMaxAge is how far back a client may date a tracked step; older
+// timestamps, like future ones, are replaced by the time it arrived
+const analyticsMaxAge = 24 * time.Hour
+
+// AnalyticsService records the anonymized steps visitors take from an event
+// page to a purchase, for the sellers' conversion funnels
+type AnalyticsService struct {
+	analyticsRepo repositories.AnalyticsRepository
+	eventRepo     repositories.EventRepository
+	access        *EventAccess
+	salt          []byte
+	enabled       bool
+}
+
+// FunnelStep is one stage of the funnel. DropOff is the share of the
+// previous stage's visitors that didn't reach this one.
+type FunnelStep struct {
+	Type     models.AnalyticsEventType `json:"type"`
+	Visitors int64                     `json:"visitors"`
+	Events   int64                     `json:"events"`
+	Tickets  int64                     `json:"tickets"`
+	DropOff  float64                   `json:"drop_off_percent"`
+}
+
+type FunnelReport struct {
+	EventID    uint         `json:"event_id"`
+	From       int64        `json:"from"`
+	To         int64        `json:"to"`
+	Steps      []FunnelStep `json:"steps"`
+	Conversion float64      `json:"conversion_percent"` // Purchasing visitors per viewing visitor
+}
+
+type TrackEventsRequest struct {
+	Events []TrackEventRequest `json:"events" binding:"required,min=1,max=50,dive"`
+}
+
+// TrackEventRequest is a step reported by a client. Purchases aren't
+// accepted here; the server records them itself.
+type TrackEventRequest struct {
+	Type       models.AnalyticsEventType `json:"type" binding:"required,oneof=view hold"`
+	EventID    uint                      `json:"event_id" binding:"required"`
+	SaleID     *uint                     `json:"sale_id"`
+	Quantity   int                       `json:"quantity" binding:"omitempty,min=1,max=100"`
+	SessionID  string                    `json:"session_id" binding:"max=64"` // Random per-browser ID; required when signed out
+	Source     string                    `json:"source" binding:"max=32"`
+	OccurredAt int64                     `json:"occurred_at" binding:"omitempty,unixtime"`
+}
+
+func NewAnalyticsService(analyticsRepo repositories.AnalyticsRepository, eventRepo repositories.EventRepository, access *EventAccess, salt string, enabled bool) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		eventRepo:     eventRepo,
+		access:        access,
+		salt:          []byte(salt),
+		enabled:       enabled,
+	}
+}
+
+// Track records the steps a client reported and returns how many were kept.
+// Signed-in visitors are identified by account, so their steps join up with
+// their purchases; others by the session ID they send.
+func (s *AnalyticsService) Track(req *TrackEventsRequest, userID uint) (int, error) {
+	if !s.enabled {
+		return 0, nil
+	}
+
+	now := time.Now()
+	known := map[uint]bool{}
+	events := make([]models.AnalyticsEvent, 0, len(req.Events))
+	for i, step := range req.Events {
+		visitorID := s.visitorID("user", userID)
+		if userID == 0 {
+			if step.SessionID == "" {
+				return 0, apperrors.Validationf("events[%d]: session_id is required when signed out", i)
+			}
+			visitorID = s.hashVisitor("session:" + step.SessionID)
+		}
+
+		exists, checked := known[step.EventID]
+		if !checked {
+			_, err := s.eventRepo.GetByID(step.EventID)
+			exists = err == nil
+			known[step.EventID] = exists
+		}
+		if !exists {
+			return 0, apperrors.Validationf("events[%d]: event %d not found", i, step.EventID)
+		}
+
+		occurredAt := step.OccurredAt
+		if occurredAt == 0 || occurredAt > now.Unix() || occurredAt < now.Add(-analyticsMaxAge).Unix() {
+			occurredAt = now.Unix()
+		}
+		quantity := step.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+
+		events = append(events, models.AnalyticsEvent{
+			Type:       step.Type,
+			EventID:    step.EventID,
+			SaleID:     step.SaleID,
+			VisitorID:  visitorID,
+			Quantity:   quantity,
+			Source:     step.Source,
+			OccurredAt: occurredAt,
+		})
+	}
+
+	if err := s.analyticsRepo.CreateBatch(events); err != nil {
+		return 0, apperrors.Internal("failed to record analytics events")
+	}
+	return len(events), nil
+}
+
+// GetFunnel reports how many visitors viewed the event, held tickets and
+// bought them between from and to. Each stage is counted on its own, so a
+// visitor who bought without a tracked view still counts as a purchase.
+func (s *AnalyticsService) GetFunnel(eventID, sellerID uint, from, to int64) (*FunnelReport, error) {
+	if from > to {
+		return nil, apperrors.Validation("from must not be after to")
+	}
+
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, apperrors.NotFound("event not found")
+	}
+	if !s.access.Allowed(event, sellerID, models.EventPermissionViewSales) {
+		return nil, apperrors.Forbidden("unauthorized to view analytics for this event")
+	}
+
+	totals, err := s.analyticsRepo.SumByType(eventID, from, to)
+	if err != nil {
+		return nil, apperrors.Internal("failed to build funnel")
+	}
+	byType := make(map[models.AnalyticsEventType]repositories.AnalyticsStepTotal, len(totals))
+	for _, total := range totals {
+		byType[total.Type] = total
+	}
+
+	report := &FunnelReport{EventID: eventID, From: from, To: to}
+	for i, stepType := range []models.AnalyticsEventType{models.AnalyticsEventView, models.AnalyticsEventHold, models.AnalyticsEventPurchase} {
+		total := byType[stepType]
+		step := FunnelStep{Type: stepType, Visitors: total.Visitors, Events: total.Events, Tickets: total.Quantity}
+		if i > 0 {
+			step.DropOff = dropOff(report.Steps[i-1].Visitors, step.Visitors)
+		}
+		report.Steps = append(report.Steps, step)
+	}
+	if views := report.Steps[0].Visitors; views > 0 {
+		report.Conversion = math.Round(float64(report.Steps[2].Visitors)/float64(views)*10000) / 100
+	}
+
+	return report, nil
+}
+
+// dropOff is the percentage lost between two stages, rounded to two
+// places. A later stage can outnumber an earlier one when steps weren't
+// tracked, which counts as no drop-off.
+func dropOff(previous, current int64) float64 {
+	if previous == 0 || current >= previous {
+		return 0
+	}
+	return math.Round(float64(previous-current)/float64(previous)*10000) / 100
+}
+
+// RecordPurchase is the ticket.sold outbox handler that adds the purchase
+// step. Redeliveries of the same message are ignored.
+func (s *AnalyticsService) RecordPurchase(ctx context.Context, message *models.OutboxMessage) error {
+	if !s.enabled {
+		return nil
+	}
+
+	var payload outbox.TicketSoldPayload
+	if err := json.Unmarshal([]byte(message.Payload), &payload); err != nil {
+		return fmt.Errorf("invalid payload for %s: %w", message.Topic, err)
+	}
+
+	sourceID := message.ID
+	return s.analyticsRepo.CreateBatch([]models.AnalyticsEvent{{
+		Type:       models.AnalyticsEventPurchase,
+		EventID:    payload.EventID,
+		VisitorID:  s.visitorID("user", payload.UserID),
+		Quantity:   len(payload.PurchasedTicketIDs),
+		Source:     "server",
+		SourceID:   &sourceID,
+		OccurredAt: message.CreatedAt,
+	}})
+}
+
+func (s *AnalyticsService) visitorID(kind string, id uint) string {
+	return s.hashVisitor(kind + ":" + strconv.FormatUint(uint64(id), 10))
+}
+
+// hashVisitor is a keyed hash, so visitor IDs can't be reversed by hashing
+// every user ID
+func (s *AnalyticsService) hashVisitor(identity string) string {
+	mac := hmac.New(sha256.New, s.salt)
+	mac.Write([]byte(identity))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+)
+
+// AnnouncementService manages the banners admins schedule for frontends
+type AnnouncementService struct {
+	announcementRepo repositories.AnnouncementRepository
+}
+
+type CreateAnnouncementRequest struct {
+	Title    string                      `json:"title" binding:"required,max=200"`
+	Message  string                      `json:"message" binding:"required,max=2000"`
+	Level    string                      `json:"level" binding:"omitempty,oneof=info warning critical"` // Defaults to info
+	Audience models.AnnouncementAudience `json:"audience" binding:"required,oneof=1 2 3"`               // 1 everyone, 2 users, 3 sellers
+	LinkURL  string                      `json:"link_url" binding:"omitempty,url,max=500"`
+	StartsAt *int64                      `json:"starts_at" binding:"omitempty,unixtime"` // Defaults to now
+	EndsAt   *int64                      `json:"ends_at" binding:"omitempty,unixtime"`   // Shown until removed when omitted
+}
+
+type UpdateAnnouncementRequest struct {
+	Title    *string                      `json:"title" binding:"omitempty,min=1,max=200"`
+	Message  *string                      `json:"message" binding:"omitempty,min=1,max=2000"`
+	Level    *string                      `json:"level" binding:"omitempty,oneof=info warning critical"`
+	Audience *models.AnnouncementAudience `json:"audience" binding:"omitempty,oneof=1 2 3"`
+	LinkURL  *string                      `json:"link_url" binding:"omitempty,max=500"` // "" removes the link
+	StartsAt *int64                       `json:"starts_at" binding:"omitempty,unixtime"`
+	EndsAt   *int64                       `json:"ends_at" binding:"omitempty,min=0"` // 0 removes the end
+}
+
+func NewAnnouncementService(announcementRepo repositories.AnnouncementRepository) *AnnouncementService {
+	return &AnnouncementService{announcementRepo: announcementRepo}
+}
+
+// Active returns the announcements running now for a caller of userType;
+// signed-out visitors (0) only get those for everyone. Admins get all.
+func (s *AnnouncementService) Active(userType models.UserType) ([]models.Announcement, error) {
+	audiences := []models.AnnouncementAudience{models.AnnouncementAudienceEveryone}
+	switch userType {
+	case models.UserTypeUser:
+		audiences = append(audiences, models.AnnouncementAudienceUsers)
+	case models.UserTypeSeller:
+		audiences = append(audiences, models.AnnouncementAudienceSellers)
+	case models.UserTypeAdmin:
+		audiences = append(audiences, models.AnnouncementAudienceUsers, models.AnnouncementAudienceSellers)
+	}
+
+	announcements, err := s.announcementRepo.ListActive(audiences, time.Now().Unix())
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve announcements")
+	}
+	return announcements, nil
+}
+
+func (s *AnnouncementService) List(limit, offset int) ([]models.Announcement, int64, error) {
+	announcements, err := s.announcementRepo.List(limit, offset)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to retrieve announcements")
+	}
+	total, err := s.announcementRepo.Count()
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to count announcements")
+	}
+	return announcements, total, nil
+}
+
+func (s *AnnouncementService) Create(req *CreateAnnouncementRequest, adminID uint) (*models.Announcement, error) {
+	now := time.Now().Unix()
+	announcement := &models.Announcement{
+		Title:     strings.TrimSpace(req.Title),
+		Message:   strings.TrimSpace(req.Message),
+		Level:     req.Level,
+		Audience:  req.Audience,
+		LinkURL:   req.LinkURL,
+		StartsAt:  now,
+		EndsAt:    req.EndsAt,
+		CreatedBy: adminID,
+		UpdatedBy: adminID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if announcement.Level == "" {
+		announcement.Level = models.AnnouncementLevelInfo
+	}
+	if req.StartsAt != nil {
+		announcement.StartsAt = *req.StartsAt
+	}
+	if err := validateAnnouncement(announcement); err != nil {
+		return nil, err
+	}
+
+	if err := s.announcementRepo.Create(announcement); err != nil {
+		return nil, apperrors.Internal("failed to create announcement")
+	}
+	return announcement, nil
+}
+
+func (s *AnnouncementService) Update(id uint, req *UpdateAnnouncementRequest, adminID uint) (*models.Announcement, error) {
+	announcement, err := s.announcementRepo.GetByID(id)
+	if err != nil {
+		return nil, apperrors.NotFound("announcement not found")
+	}
+
+	if req.Title != nil {
+		announcement.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Message != nil {
+		announcement.Message = strings.TrimSpace(*req.Message)
+	}
+	if req.Level != nil {
+		announcement.Level = *req.Level
+	}
+	if req.Audience != nil {
+		announcement.Audience = *req.Audience
+	}
+	if req.LinkURL != nil {
+		announcement.LinkURL = strings.TrimSpace(*req.LinkURL)
+	}
+	if req.StartsAt != nil {
+		announcement.StartsAt = *req.StartsAt
+	}
+	if req.EndsAt != nil {
+		announcement.EndsAt = req.EndsAt
+		if *req.EndsAt == 0 {
+			announcement.EndsAt = nil
+		}
+	}
+	if err := validateAnnouncement(announcement); err != nil {
+		return nil, err
+	}
+	announcement.UpdatedBy = adminID
+	announcement.UpdatedAt = time.Now().Unix()
+
+	if err := s.announcementRepo.Update(announcement); err != nil {
+		return nil, apperrors.Internal("failed to update announcement")
+	}
+	return announcement, nil
+}
+
+func (s *AnnouncementService) Delete(id uint) error {
+	if _, err := s.announcementRepo.GetByID(id); err != nil {
+		return apperrors.NotFound("announcement not found")
+	}
+	if err := s.announcementRepo.Delete(id); err != nil {
+		return apperrors.Internal("failed to delete announcement")
+	}
+	return nil
+}
+
+func validateAnnouncement(announcement *models.Announcement) error {
+	if announcement.Title == "" || announcement.Message == "" {
+		return apperrors.Validation("title and message are required")
+	}
+	if announcement.LinkURL != "" && !strings.HasPrefix(announcement.LinkURL, "https://") && !strings.HasPrefix(announcement.LinkURL, "http://") {
+		return apperrors.Validation("link_url must be an http or https URL")
+	}
+	if announcement.EndsAt != nil && *announcement.EndsAt <= announcement.StartsAt {
+		return apperrors.Validation("ends_at must be after starts_at")
+	}
+	return nil
+}
@@ -0,0 +1,327 @@
+// internal/services/attendee_service.go
+package services
+
+import (
+	"io"
+	"strconv"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+)
+
+type AttendeeService struct {
+	eventRepo           repositories.EventRepository
+	purchasedTicketRepo repositories.PurchasedTicketRepository
+	access              *EventAccess
+	codes               *TicketCodeSigner
+}
+
+type AttendeeResponse struct {
+	PurchasedTicketID uint    `json:"purchased_ticket_id"`
+	TicketID          uint    `json:"ticket_id"`
+	Title             string  `json:"title"`
+	Place             string  `json:"place"`
+	Price             float64 `json:"price"`
+	IsVip             bool    `json:"is_vip"`
+	UserID            uint    `json:"user_id"`
+	BuyerName         string  `json:"buyer_name"`
+	BuyerEmail        string  `json:"buyer_email"`
+	CheckedIn         bool    `json:"checked_in"`
+	CheckedInAt       *int64  `json:"checked_in_at"`
+
+	IsAccessible         bool   `json:"is_accessible"`
+	AccommodationRequest string `json:"accommodation_request,omitempty"`
+}
+
+// CheckInResponse is the checked-in attendee plus what door staff must do
+// before letting them in
+type CheckInResponse struct {
+	AttendeeResponse
+	IDCheckRequired bool `json:"id_check_required"` // Match photo ID against BuyerName before admitting
+
+	// Set for scanned codes. "static" codes come from a PDF and may be
+	// copies; staff can ask for the live code in the app.
+	CodeTrust TicketCodeTrust `json:"code_trust,omitempty"`
+}
+
+type CheckInByCodeRequest struct {
+	Code string `json:"code" binding:"required,max=200"` // Contents of the ticket's QR code
+}
+
+type AttendeeSummary struct {
+	Total     int64 `json:"total"`
+	CheckedIn int64 `json:"checked_in"`
+}
+
+// AccommodationReport lists the attendees the venue has to prepare for:
+// those on accessible seating and those who asked for an accommodation
+type AccommodationReport struct {
+	EventID         uint               `json:"event_id"`
+	AccessibleSeats int                `json:"accessible_seats"`
+	Requests        int                `json:"requests"`
+	Attendees       []AttendeeResponse `json:"attendees"`
+}
+
+func NewAttendeeService(eventRepo repositories.EventRepository, purchasedTicketRepo repositories.PurchasedTicketRepository, access *EventAccess, codes *TicketCodeSigner) *AttendeeService {
+	return &AttendeeService{
+		eventRepo:           eventRepo,
+		purchasedTicketRepo: purchasedTicketRepo,
+		codes:               codes,
+		access:              access,
+	}
+}
+
+func (s *AttendeeService) GetAttendees(eventID, sellerID uint, filter repositories.AttendeeFilter, page, limit int) (*utils.PaginatedResponse, error) {
+	if err := s.verifyEventOwnership(eventID, sellerID); err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * limit
+	tickets, err := s.purchasedTicketRepo.ListAttendeesByEvent(eventID, filter, limit, offset)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve attendees")
+	}
+
+	total, err := s.purchasedTicketRepo.CountAttendeesByEvent(eventID, filter)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count attendees")
+	}
+
+	attendees := make([]AttendeeResponse, 0, len(tickets))
+	for i := range tickets {
+		attendees = append(attendees, s.convertToResponse(&tickets[i]))
+	}
+
+	return &utils.PaginatedResponse{
+		Success:    true,
+		Message:    "Attendees retrieved successfully",
+		Data:       attendees,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, nil
+}
+
+// GetSummary returns headline numbers for the check-in dashboard
+func (s *AttendeeService) GetSummary(eventID, sellerID uint) (*AttendeeSummary, error) {
+	if err := s.verifyEventOwnership(eventID, sellerID); err != nil {
+		return nil, err
+	}
+
+	total, err := s.purchasedTicketRepo.CountAttendeesByEvent(eventID, repositories.AttendeeFilter{})
+	if err != nil {
+		return nil, apperrors.Internal("failed to count attendees")
+	}
+
+	checkedIn := true
+	inside, err := s.purchasedTicketRepo.CountAttendeesByEvent(eventID, repositories.AttendeeFilter{CheckedIn: &checkedIn})
+	if err != nil {
+		return nil, apperrors.Internal("failed to count checked-in attendees")
+	}
+
+	return &AttendeeSummary{Total: total, CheckedIn: inside}, nil
+}
+
+// GetAccommodationReport returns every attendee of the event needing an
+// accommodation, ordered like the attendee list
+func (s *AttendeeService) GetAccommodationReport(eventID, sellerID uint) (*AccommodationReport, error) {
+	if err := s.verifyEventOwnership(eventID, sellerID); err != nil {
+		return nil, err
+	}
+
+	tickets, err := s.purchasedTicketRepo.ListAttendeesByEvent(eventID, repositories.AttendeeFilter{NeedsAccommodation: true}, 0, 0)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve accommodation needs")
+	}
+
+	report := &AccommodationReport{
+		EventID:   eventID,
+		Attendees: make([]AttendeeResponse, 0, len(tickets)),
+	}
+	for i := range tickets {
+		attendee := s.convertToResponse(&tickets[i])
+		if attendee.IsAccessible {
+			report.AccessibleSeats++
+		}
+		if attendee.AccommodationRequest != "" {
+			report.Requests++
+		}
+		report.Attendees = append(report.Attendees, attendee)
+	}
+
+	return report, nil
+}
+
+// attendeeExportBatchSize is how many attendees are loaded at a time
+const attendeeExportBatchSize = 1000
+
+// ExportAttendeesCSV writes every attendee matching filter to w as CSV.
+// Attendees are loaded in batches and streamed out as they are written, so
+// an event of any size is exported without holding it all in memory.
+func (s *AttendeeService) ExportAttendeesCSV(eventID, sellerID uint, filter repositories.AttendeeFilter, w io.Writer) error {
+	if err := s.verifyEventOwnership(eventID, sellerID); err != nil {
+		return err
+	}
+
+	stream := utils.NewCSVStream(w)
+	var afterID uint
+	for {
+		tickets, err := s.purchasedTicketRepo.ListAttendeesAfter(eventID, filter, afterID, attendeeExportBatchSize)
+		if err != nil {
+			return apperrors.Internal("failed to retrieve attendees")
+		}
+
+		// The header goes out only once the first batch has loaded, so a
+		// failing query can still be reported as an error response
+		if afterID == 0 {
+			if err := stream.Write([]string{
+				"purchased_ticket_id", "ticket", "place", "price", "vip",
+				"buyer_name", "buyer_email", "checked_in", "checked_in_at",
+				"accessible", "accommodation_request",
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err := s.writeAttendeeRows(stream, tickets); err != nil {
+			return err
+		}
+		if len(tickets) < attendeeExportBatchSize {
+			return stream.Flush()
+		}
+		afterID = tickets[len(tickets)-1].ID
+	}
+}
+
+func (s *AttendeeService) writeAttendeeRows(stream *utils.CSVStream, tickets []models.PurchasedTicket) error {
+	for i := range tickets {
+		attendee := s.convertToResponse(&tickets[i])
+
+		checkedInAt := ""
+		if attendee.CheckedInAt != nil {
+			checkedInAt = time.Unix(*attendee.CheckedInAt, 0).UTC().Format(time.RFC3339)
+		}
+
+		if err := stream.Write([]string{
+			strconv.FormatUint(uint64(attendee.PurchasedTicketID), 10),
+			attendee.Title,
+			attendee.Place,
+			strconv.FormatFloat(attendee.Price, 'f', 2, 64),
+			strconv.FormatBool(attendee.IsVip),
+			attendee.BuyerName,
+			attendee.BuyerEmail,
+			strconv.FormatBool(attendee.CheckedIn),
+			checkedInAt,
+			strconv.FormatBool(attendee.IsAccessible),
+			attendee.AccommodationRequest,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AttendeeService) CheckIn(eventID, sellerID, purchasedTicketID uint) (*CheckInResponse, error) {
+	if err := s.verifyEventOwnership(eventID, sellerID); err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.purchasedTicketRepo.GetByID(purchasedTicketID)
+	if err != nil {
+		return nil, apperrors.NotFound("ticket not found")
+	}
+
+	return s.checkIn(eventID, ticket)
+}
+
+// CheckInByCode checks in the ticket a scanned QR code belongs to, either
+// the live code from the app or the static one from the PDF. Codes from
+// before the ticket last changed hands are refused.
+func (s *AttendeeService) CheckInByCode(eventID, sellerID uint, code string) (*CheckInResponse, error) {
+	if err := s.verifyEventOwnership(eventID, sellerID); err != nil {
+		return nil, err
+	}
+
+	ticketID, ok := ParseTicketCodeID(code)
+	if !ok {
+		return nil, apperrors.Validation("not a ticket code")
+	}
+
+	ticket, err := s.purchasedTicketRepo.GetByID(ticketID)
+	if err != nil {
+		return nil, apperrors.NotFound("ticket not found")
+	}
+
+	trust, err := s.codes.Verify(code, ticket, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.checkIn(eventID, ticket)
+	if err != nil {
+		return nil, err
+	}
+	response.CodeTrust = trust
+	return response, nil
+}
+
+func (s *AttendeeService) checkIn(eventID uint, ticket *models.PurchasedTicket) (*CheckInResponse, error) {
+	if ticket.Ticket.EventID != eventID {
+		return nil, apperrors.Validation("ticket does not belong to this event")
+	}
+
+	if ticket.IsInvalidated {
+		return nil, apperrors.Validation("ticket has been invalidated")
+	}
+
+	checkedIn, err := s.purchasedTicketRepo.MarkUsed(ticket.ID, time.Now().Unix())
+	if err != nil {
+		return nil, apperrors.Internal("failed to check in ticket")
+	}
+	if !checkedIn {
+		return nil, apperrors.Conflict("ticket has already been checked in")
+	}
+
+	ticket, err = s.purchasedTicketRepo.GetByID(ticket.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to reload ticket")
+	}
+
+	return &CheckInResponse{
+		AttendeeResponse: s.convertToResponse(ticket),
+		IDCheckRequired:  ticket.Ticket.Event.IDCheckRequired,
+	}, nil
+}
+
+func (s *AttendeeService) verifyEventOwnership(eventID, sellerID uint) error {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return apperrors.NotFound("event not found")
+	}
+
+	if !s.access.Allowed(event, sellerID, models.EventPermissionViewSales) {
+		return apperrors.Forbidden("unauthorized to view attendees for this event")
+	}
+
+	return nil
+}
+
+func (s *AttendeeService) convertToResponse(ticket *models.PurchasedTicket) AttendeeResponse {
+	return AttendeeResponse{
+		PurchasedTicketID: ticket.ID,
+		TicketID:          ticket.TicketID,
+		Title:             ticket.Title,
+		Place:             ticket.Place,
+		Price:             ticket.Price,
+		IsVip:             ticket.IsVip,
+		UserID:            ticket.UserID,
+		BuyerName:         TicketHolderName(ticket),
+		BuyerEmail:        ticket.User.Email,
+		CheckedIn:         ticket.IsUsed,
+		CheckedInAt:       ticket.UsedAt,
+
+		IsAccessible:         ticket.IsAccessible,
+		AccommodationRequest: ticket.AccommodationRequest,
+	}
+}
@@ -0,0 +1,120 @@
+// internal/services/audit_service.go
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+)
+
+type AuditService struct {
+	auditRepo  repositories.AuditLogRepository
+	adminRepo  repositories.AdminRepository
+	userRepo   repositories.UserRepository
+	sellerRepo repositories.SellerRepository
+	jwtManager *utils.JWTManager
+}
+
+type ImpersonationResponse struct {
+	AccessToken string          `json:"access_token"`
+	ExpiresAt   int64           `json:"expires_at"`
+	UserID      uint            `json:"user_id"`
+	UserType    models.UserType `json:"user_type"`
+	Username    string          `json:"username"`
+}
+
+func NewAuditService(
+	auditRepo repositories.AuditLogRepository,
+	adminRepo repositories.AdminRepository,
+	userRepo repositories.UserRepository,
+	sellerRepo repositories.SellerRepository,
+	jwtManager *utils.JWTManager,
+) *AuditService {
+	return &AuditService{
+		auditRepo:  auditRepo,
+		adminRepo:  adminRepo,
+		userRepo:   userRepo,
+		sellerRepo: sellerRepo,
+		jwtManager: jwtManager,
+	}
+}
+
+// Impersonate issues a super admin a short-lived token to act as a user or
+// seller. Issuing it is audited, and so is everything done with it.
+func (s *AuditService) Impersonate(adminID uint, userType models.UserType, targetID uint, ip string) (*ImpersonationResponse, error) {
+	admin, err := s.adminRepo.GetByID(adminID)
+	if err != nil {
+		return nil, apperrors.NotFound("admin not found")
+	}
+	if admin.AdminRole != models.AdminRoleSuper {
+		return nil, apperrors.Forbidden("only super admins can impersonate")
+	}
+
+	var username, email string
+	switch userType {
+	case models.UserTypeUser:
+		user, err := s.userRepo.GetByID(targetID)
+		if err != nil || user.AnonymizedAt != nil {
+			return nil, apperrors.NotFound("user not found")
+		}
+		username, email = user.Username, user.Email
+	case models.UserTypeSeller:
+		seller, err := s.sellerRepo.GetByID(targetID)
+		if err != nil {
+			return nil, apperrors.NotFound("seller not found")
+		}
+		username, email = seller.Username, seller.Email
+	default:
+		return nil, apperrors.Validation("only users and sellers can be impersonated")
+	}
+
+	token, expiresAt, err := s.jwtManager.GenerateImpersonationToken(targetID, username, email, userType, admin.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to generate impersonation token")
+	}
+
+	// The token is only handed out once its issue is on record
+	entry := &models.AuditLog{
+		ActorID:   admin.ID,
+		ActorType: models.UserTypeAdmin,
+		Action:    models.AuditActionImpersonationStarted,
+		Details:   fmt.Sprintf("type=%d id=%d until=%d", userType, targetID, expiresAt.Unix()),
+		IP:        ip,
+		CreatedAt: time.Now().Unix(),
+	}
+	if err := s.auditRepo.Create(entry); err != nil {
+		return nil, apperrors.Internal("failed to record impersonation")
+	}
+
+	return &ImpersonationResponse{
+		AccessToken: token,
+		ExpiresAt:   expiresAt.Unix(),
+		UserID:      targetID,
+		UserType:    userType,
+		Username:    username,
+	}, nil
+}
+
+func (s *AuditService) ListAuditLog(filter repositories.AuditLogFilter, page, limit int) (*utils.PaginatedResponse, error) {
+	offset := (page - 1) * limit
+	entries, err := s.auditRepo.List(filter, limit, offset)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve audit log")
+	}
+
+	total, err := s.auditRepo.Count(filter)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count audit log entries")
+	}
+
+	return &utils.PaginatedResponse{
+		Success:    true,
+		Message:    "Audit log retrieved successfully",
+		Data:       entries,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, nil
+}
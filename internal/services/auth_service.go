@@ -2,19 +2,25 @@ package services
 
 import (
 	"errors"
+	"log"
 	"time"
 
+	"eticketing/internal/i18n"
 	"eticketing/internal/models"
 	"eticketing/internal/repositories"
 	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
 	"gorm.io/gorm"
 )
 
 type AuthService struct {
-	userRepo   repositories.UserRepository
-	sellerRepo repositories.SellerRepository
-	adminRepo  repositories.AdminRepository
-	jwtManager *utils.JWTManager
+	userRepo    repositories.UserRepository
+	sellerRepo  repositories.SellerRepository
+	adminRepo   repositories.AdminRepository
+	giftService *GiftService
+	denylist    *DenylistService
+	policies    *PolicyService
+	jwtManager  *utils.JWTManager
 }
 
 type LoginRequest struct {
@@ -30,6 +36,12 @@ type RegisterRequest struct {
 	Name     string `json:"name" binding:"required"`
 	Surname  string `json:"surname" binding:"required"`
 	UserType int    `json:"user_type" binding:"required,oneof=1 2"` // Only user or seller can register
+	Locale   string `json:"locale" binding:"omitempty,oneof=en uk"` // Defaults to the request's Accept-Language
+	ClientIP string `json:"-"`                                      // Set by handler
+
+	// Accepts the terms of service and privacy policy in force; without
+	// it the account has to accept them before changing anything
+	AcceptPolicies bool `json:"accept_policies"`
 }
 
 type TokenResponse struct {
@@ -46,49 +58,62 @@ type UserInfo struct {
 	Name     string          `json:"name"`
 	Surname  string          `json:"surname"`
 	UserType models.UserType `json:"user_type"`
+	Locale   string          `json:"locale,omitempty"`
+
+	DeletionScheduledAt *int64 `json:"deletion_scheduled_at,omitempty"` // Set while the account is pending deletion
 }
 
 func NewAuthService(
 	userRepo repositories.UserRepository,
 	sellerRepo repositories.SellerRepository,
 	adminRepo repositories.AdminRepository,
+	giftService *GiftService,
+	denylist *DenylistService,
+	policies *PolicyService,
 	jwtManager *utils.JWTManager,
 ) *AuthService {
 	return &AuthService{
-		userRepo:   userRepo,
-		sellerRepo: sellerRepo,
-		adminRepo:  adminRepo,
-		jwtManager: jwtManager,
+		userRepo:    userRepo,
+		sellerRepo:  sellerRepo,
+		adminRepo:   adminRepo,
+		giftService: giftService,
+		denylist:    denylist,
+		policies:    policies,
+		jwtManager:  jwtManager,
 	}
 }
 
 func (s *AuthService) Register(req *RegisterRequest) (*TokenResponse, error) {
 	// Validate input
 	if !utils.ValidateEmail(req.Email) {
-		return nil, errors.New("invalid email format")
+		return nil, apperrors.Validation("invalid email format")
 	}
 
 	if valid, validationErrors := utils.ValidateUsername(req.Username); !valid {
-		return nil, errors.New("username validation failed: " + validationErrors[0])
+		return nil, apperrors.Validation("username validation failed: " + validationErrors[0])
 	}
 
 	if valid, validationErrors := utils.ValidatePassword(req.Password); !valid {
-		return nil, errors.New("password validation failed: " + validationErrors[0])
+		return nil, apperrors.Validation("password validation failed: " + validationErrors[0])
+	}
+
+	if err := s.denylist.CheckRegistration(req.Email, req.ClientIP); err != nil {
+		return nil, err
 	}
 
 	// Hash password
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
-		return nil, errors.New("failed to hash password")
+		return nil, apperrors.Internal("failed to hash password")
 	}
 
 	// Check if user already exists in any table
 	if req.UserType == 1 { // User
 		if existingUser, _ := s.userRepo.GetByEmail(req.Email); existingUser != nil {
-			return nil, errors.New("user with this email already exists")
+			return nil, apperrors.Conflict("user with this email already exists")
 		}
 		if existingUser, _ := s.userRepo.GetByUsername(req.Username); existingUser != nil {
-			return nil, errors.New("user with this username already exists")
+			return nil, apperrors.Conflict("user with this username already exists")
 		}
 
 		// Create user
@@ -98,20 +123,31 @@ func (s *AuthService) Register(req *RegisterRequest) (*TokenResponse, error) {
 			PasswordHash: hashedPassword,
 			Name:         utils.SanitizeString(req.Name),
 			Surname:      utils.SanitizeString(req.Surname),
+			Locale:       req.Locale,
+		}
+		if user.Locale == "" {
+			user.Locale = string(i18n.Default)
 		}
 
 		if err := s.userRepo.Create(user); err != nil {
-			return nil, errors.New("failed to create user")
+			return nil, apperrors.Internal("failed to create user")
+		}
+
+		// Tickets gifted to this email before the account existed; a failure
+		// here must not block registration, the gifts stay pending
+		if _, err := s.giftService.ClaimPendingGifts(user.ID, user.Email); err != nil {
+			log.Printf("Failed to claim pending gifts for user %d: %v", user.ID, err)
 		}
+		s.acceptPolicies(req, user.ID, models.UserTypeUser)
 
 		return s.generateTokenResponseForUser(user)
 
 	} else if req.UserType == 2 { // Seller
 		if existingSeller, _ := s.sellerRepo.GetByEmail(req.Email); existingSeller != nil {
-			return nil, errors.New("seller with this email already exists")
+			return nil, apperrors.Conflict("seller with this email already exists")
 		}
 		if existingSeller, _ := s.sellerRepo.GetByUsername(req.Username); existingSeller != nil {
-			return nil, errors.New("seller with this username already exists")
+			return nil, apperrors.Conflict("seller with this username already exists")
 		}
 
 		// Create seller
@@ -121,16 +157,30 @@ func (s *AuthService) Register(req *RegisterRequest) (*TokenResponse, error) {
 			PasswordHash: hashedPassword,
 			Name:         utils.SanitizeString(req.Name),
 			Surname:      utils.SanitizeString(req.Surname),
+
+			OnboardingStatus: models.SellerOnboardingIncomplete,
 		}
 
 		if err := s.sellerRepo.Create(seller); err != nil {
-			return nil, errors.New("failed to create seller")
+			return nil, apperrors.Internal("failed to create seller")
 		}
+		s.acceptPolicies(req, seller.ID, models.UserTypeSeller)
 
 		return s.generateTokenResponseForSeller(seller)
 	}
 
-	return nil, errors.New("invalid user type")
+	return nil, apperrors.Validation("invalid user type")
+}
+
+// acceptPolicies records the policies a new account accepted when signing
+// up. A failure must not block registration; the account is asked again.
+func (s *AuthService) acceptPolicies(req *RegisterRequest, userID uint, userType models.UserType) {
+	if !req.AcceptPolicies {
+		return
+	}
+	if err := s.policies.AcceptCurrent(userID, userType, req.ClientIP); err != nil {
+		log.Printf("Failed to record policy acceptance for %d/%d: %v", userType, userID, err)
+	}
 }
 
 func (s *AuthService) Login(req *LoginRequest) (*TokenResponse, error) {
@@ -139,13 +189,13 @@ func (s *AuthService) Login(req *LoginRequest) (*TokenResponse, error) {
 		user, err := s.userRepo.GetByEmail(req.Email)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("invalid email or password")
+				return nil, apperrors.Unauthorized("invalid email or password")
 			}
-			return nil, errors.New("failed to find user")
+			return nil, apperrors.Internal("failed to find user")
 		}
 
 		if !utils.CheckPassword(req.Password, user.PasswordHash) {
-			return nil, errors.New("invalid email or password")
+			return nil, apperrors.Unauthorized("invalid email or password")
 		}
 
 		return s.generateTokenResponseForUser(user)
@@ -154,13 +204,13 @@ func (s *AuthService) Login(req *LoginRequest) (*TokenResponse, error) {
 		seller, err := s.sellerRepo.GetByEmail(req.Email)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("invalid email or password")
+				return nil, apperrors.Unauthorized("invalid email or password")
 			}
-			return nil, errors.New("failed to find seller")
+			return nil, apperrors.Internal("failed to find seller")
 		}
 
 		if !utils.CheckPassword(req.Password, seller.PasswordHash) {
-			return nil, errors.New("invalid email or password")
+			return nil, apperrors.Unauthorized("invalid email or password")
 		}
 
 		return s.generateTokenResponseForSeller(seller)
@@ -169,19 +219,19 @@ func (s *AuthService) Login(req *LoginRequest) (*TokenResponse, error) {
 		admin, err := s.adminRepo.GetByEmail(req.Email)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, errors.New("invalid email or password")
+				return nil, apperrors.Unauthorized("invalid email or password")
 			}
-			return nil, errors.New("failed to find admin")
+			return nil, apperrors.Internal("failed to find admin")
 		}
 
 		if !utils.CheckPassword(req.Password, admin.PasswordHash) {
-			return nil, errors.New("invalid email or password")
+			return nil, apperrors.Unauthorized("invalid email or password")
 		}
 
 		return s.generateTokenResponseForAdmin(admin)
 
 	default:
-		return nil, errors.New("invalid user type")
+		return nil, apperrors.Validation("invalid user type")
 	}
 }
 
@@ -189,11 +239,11 @@ func (s *AuthService) RefreshToken(refreshToken string) (*TokenResponse, error)
 	// Validate refresh token
 	claims, err := s.jwtManager.ValidateToken(refreshToken)
 	if err != nil {
-		return nil, errors.New("invalid refresh token")
+		return nil, apperrors.Unauthorized("invalid refresh token")
 	}
 
 	if claims.Type != "refresh" {
-		return nil, errors.New("invalid token type")
+		return nil, apperrors.Unauthorized("invalid token type")
 	}
 
 	// Generate new tokens based on user type
@@ -201,26 +251,26 @@ func (s *AuthService) RefreshToken(refreshToken string) (*TokenResponse, error)
 	case models.UserTypeUser:
 		user, err := s.userRepo.GetByID(claims.UserID)
 		if err != nil {
-			return nil, errors.New("user not found")
+			return nil, apperrors.NotFound("user not found")
 		}
 		return s.generateTokenResponseForUser(user)
 
 	case models.UserTypeSeller:
 		seller, err := s.sellerRepo.GetByID(claims.UserID)
 		if err != nil {
-			return nil, errors.New("seller not found")
+			return nil, apperrors.NotFound("seller not found")
 		}
 		return s.generateTokenResponseForSeller(seller)
 
 	case models.UserTypeAdmin:
 		admin, err := s.adminRepo.GetByID(claims.UserID)
 		if err != nil {
-			return nil, errors.New("admin not found")
+			return nil, apperrors.NotFound("admin not found")
 		}
 		return s.generateTokenResponseForAdmin(admin)
 
 	default:
-		return nil, errors.New("invalid user type in token")
+		return nil, apperrors.Unauthorized("invalid user type in token")
 	}
 }
 
@@ -236,12 +286,12 @@ func (s *AuthService) generateTokenResponseForUser(user *models.User) (*TokenRes
 
 	accessToken, err := s.jwtManager.GenerateAccessToken(user.ID, user.Username, user.Email, models.UserTypeUser)
 	if err != nil {
-		return nil, errors.New("failed to generate access token")
+		return nil, apperrors.Internal("failed to generate access token")
 	}
 
 	refreshToken, err := s.jwtManager.GenerateRefreshToken(user.ID, user.Username, user.Email, models.UserTypeUser)
 	if err != nil {
-		return nil, errors.New("failed to generate refresh token")
+		return nil, apperrors.Internal("failed to generate refresh token")
 	}
 
 	return &TokenResponse{
@@ -264,12 +314,12 @@ func (s *AuthService) generateTokenResponseForSeller(seller *models.Seller) (*To
 
 	accessToken, err := s.jwtManager.GenerateAccessToken(seller.ID, seller.Username, seller.Email, models.UserTypeSeller)
 	if err != nil {
-		return nil, errors.New("failed to generate access token")
+		return nil, apperrors.Internal("failed to generate access token")
 	}
 
 	refreshToken, err := s.jwtManager.GenerateRefreshToken(seller.ID, seller.Username, seller.Email, models.UserTypeSeller)
 	if err != nil {
-		return nil, errors.New("failed to generate refresh token")
+		return nil, apperrors.Internal("failed to generate refresh token")
 	}
 
 	return &TokenResponse{
@@ -292,12 +342,12 @@ func (s *AuthService) generateTokenResponseForAdmin(admin *models.Admin) (*Token
 
 	accessToken, err := s.jwtManager.GenerateAccessToken(admin.ID, admin.Username, admin.Email, models.UserTypeAdmin)
 	if err != nil {
-		return nil, errors.New("failed to generate access token")
+		return nil, apperrors.Internal("failed to generate access token")
 	}
 
 	refreshToken, err := s.jwtManager.GenerateRefreshToken(admin.ID, admin.Username, admin.Email, models.UserTypeAdmin)
 	if err != nil {
-		return nil, errors.New("failed to generate refresh token")
+		return nil, apperrors.Internal("failed to generate refresh token")
 	}
 
 	return &TokenResponse{
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"eticketing/internal/config"
+	"eticketing/internal/models"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+)
+
+const testPassword = "Correct-horse1"
+
+// authFixture has one account of each type, all with testPassword
+type authFixture struct {
+	ctrl    *gomock.Controller
+	users   *userStore
+	sellers *sellerStore
+	admins  *adminStore
+	jwt     *utils.JWTManager
+}
+
+func newAuthFixture(t *testing.T, passwordHash string) *authFixture {
+	return &authFixture{
+		ctrl: gomock.NewController(t),
+		users: &userStore{users: map[uint]*models.User{
+			1: {ID: 1, Username: "buyer", Email: "buyer@example.com", PasswordHash: passwordHash},
+		}},
+		sellers: &sellerStore{sellers: map[uint]*models.Seller{
+			1: {ID: 1, Username: "seller", Email: "seller@example.com", PasswordHash: passwordHash},
+		}},
+		admins: &adminStore{admins: map[uint]*models.Admin{
+			1: {ID: 1, Username: "admin", Email: "admin@example.com", PasswordHash: passwordHash},
+		}},
+		jwt: utils.NewJWTManager(&config.JWTConfig{
+			Secret:          "test-secret",
+			AccessDuration:  time.Minute,
+			RefreshDuration: time.Hour,
+		}),
+	}
+}
+
+func (f *authFixture) authService(denylisted ...models.DenylistEntry) *AuthService {
+	gifts := NewGiftService((&giftStore{}).mock(f.ctrl), (&purchasedTicketStore{}).mock(f.ctrl), fakeTxManager{})
+	denylist := NewDenylistService((&denylistStore{entries: denylisted}).mock(f.ctrl), nil, nil, nil)
+	return NewAuthService(f.users.mock(f.ctrl), f.sellers.mock(f.ctrl), f.admins.mock(f.ctrl), gifts, denylist, nil, f.jwt)
+}
+
+func hashTestPassword(t *testing.T) string {
+	t.Helper()
+
+	hash, err := utils.HashPassword(testPassword)
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	return hash
+}
+
+func TestLogin(t *testing.T) {
+	hash := hashTestPassword(t)
+
+	tests := []struct {
+		name     string
+		req      LoginRequest
+		failWith error // Returned by every repository lookup
+		wantCode apperrors.Code
+	}{
+		{"user", LoginRequest{Email: "buyer@example.com", Password: testPassword, UserType: 1}, nil, ""},
+		{"seller", LoginRequest{Email: "seller@example.com", Password: testPassword, UserType: 2}, nil, ""},
+		{"admin", LoginRequest{Email: "admin@example.com", Password: testPassword, UserType: 3}, nil, ""},
+		{"user with wrong password", LoginRequest{Email: "buyer@example.com", Password: "Wrong-pass1", UserType: 1}, nil, apperrors.CodeUnauthorized},
+		{"seller with wrong password", LoginRequest{Email: "seller@example.com", Password: "Wrong-pass1", UserType: 2}, nil, apperrors.CodeUnauthorized},
+		{"admin with wrong password", LoginRequest{Email: "admin@example.com", Password: "Wrong-pass1", UserType: 3}, nil, apperrors.CodeUnauthorized},
+		{"unknown user", LoginRequest{Email: "nobody@example.com", Password: testPassword, UserType: 1}, nil, apperrors.CodeUnauthorized},
+		{"unknown seller", LoginRequest{Email: "buyer@example.com", Password: testPassword, UserType: 2}, nil, apperrors.CodeUnauthorized},
+		{"unknown admin", LoginRequest{Email: "buyer@example.com", Password: testPassword, UserType: 3}, nil, apperrors.CodeUnauthorized},
+		{"user lookup fails", LoginRequest{Email: "buyer@example.com", Password: testPassword, UserType: 1}, errStorage, apperrors.CodeInternal},
+		{"seller lookup fails", LoginRequest{Email: "seller@example.com", Password: testPassword, UserType: 2}, errStorage, apperrors.CodeInternal},
+		{"admin lookup fails", LoginRequest{Email: "admin@example.com", Password: testPassword, UserType: 3}, errStorage, apperrors.CodeInternal},
+		{"invalid user type", LoginRequest{Email: "buyer@example.com", Password: testPassword, UserType: 4}, nil, apperrors.CodeValidationFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAuthFixture(t, hash)
+			f.users.getErr, f.sellers.getErr, f.admins.getErr = tt.failWith, tt.failWith, tt.failWith
+
+			resp, err := f.authService().Login(&tt.req)
+			if code := apperrors.CodeOf(err); code != tt.wantCode {
+				t.Fatalf("error %v has code %q, want %q", err, code, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+
+			claims, err := f.jwt.ValidateToken(resp.AccessToken)
+			if err != nil {
+				t.Fatalf("access token doesn't validate: %v", err)
+			}
+			if claims.UserType != models.UserType(tt.req.UserType) || claims.Email != tt.req.Email || claims.Type != "access" {
+				t.Errorf("access token for %s type %d (%s), want %s type %d", claims.Email, claims.UserType, claims.Type,
+					tt.req.Email, tt.req.UserType)
+			}
+		})
+	}
+}
+
+func TestRegisterRefusals(t *testing.T) {
+	hash := hashTestPassword(t)
+
+	valid := RegisterRequest{
+		Username: "newcomer",
+		Email:    "newcomer@example.com",
+		Password: testPassword,
+		Name:     "New",
+		Surname:  "Comer",
+		UserType: 1,
+	}
+	tests := []struct {
+		name     string
+		change   func(req *RegisterRequest)
+		wantCode apperrors.Code
+	}{
+		{"invalid email", func(req *RegisterRequest) { req.Email = "not-an-email" }, apperrors.CodeValidationFailed},
+		{"invalid username", func(req *RegisterRequest) { req.Username = "no spaces" }, apperrors.CodeValidationFailed},
+		{"weak password", func(req *RegisterRequest) { req.Password = "short" }, apperrors.CodeValidationFailed},
+		{"denylisted email", func(req *RegisterRequest) { req.Email = "banned@example.org" }, apperrors.CodeForbidden},
+		{"denylisted domain", func(req *RegisterRequest) { req.Email = "someone@spam.test" }, apperrors.CodeForbidden},
+		{"user email taken", func(req *RegisterRequest) { req.Email = "buyer@example.com" }, apperrors.CodeConflict},
+		{"user username taken", func(req *RegisterRequest) { req.Username = "buyer" }, apperrors.CodeConflict},
+		{"seller email taken", func(req *RegisterRequest) {
+			req.UserType = 2
+			req.Email = "seller@example.com"
+		}, apperrors.CodeConflict},
+		{"seller username taken", func(req *RegisterRequest) {
+			req.UserType = 2
+			req.Username = "seller"
+		}, apperrors.CodeConflict},
+		{"admin", func(req *RegisterRequest) { req.UserType = 3 }, apperrors.CodeValidationFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAuthFixture(t, hash)
+			req := valid
+			tt.change(&req)
+
+			_, err := f.authService(
+				models.DenylistEntry{Kind: models.DenylistKindEmail, Value: "banned@example.org"},
+				models.DenylistEntry{Kind: models.DenylistKindDomain, Value: "spam.test"},
+			).Register(&req)
+			if code := apperrors.CodeOf(err); code != tt.wantCode {
+				t.Errorf("error %v has code %q, want %q", err, code, tt.wantCode)
+			}
+			if len(f.users.users) != 1 || len(f.sellers.sellers) != 1 {
+				t.Errorf("%d users and %d sellers, want no new accounts", len(f.users.users), len(f.sellers.sellers))
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	f := newAuthFixture(t, hashTestPassword(t))
+	authService := f.authService()
+
+	resp, err := authService.Register(&RegisterRequest{
+		Username: "newcomer",
+		Email:    "newcomer@example.com",
+		Password: testPassword,
+		Name:     " New ",
+		Surname:  "Comer",
+		UserType: 1,
+	})
+	if err != nil {
+		t.Fatalf("Register user: %v", err)
+	}
+	user := f.users.users[resp.User.ID]
+	if user == nil || user.Name != "New" || user.Locale == "" || !utils.CheckPassword(testPassword, user.PasswordHash) {
+		t.Errorf("stored user = %+v, want a sanitized user with a locale and the hashed password", user)
+	}
+
+	resp, err = authService.Register(&RegisterRequest{
+		Username: "organizer",
+		Email:    "organizer@example.com",
+		Password: testPassword,
+		Name:     "Org",
+		Surname:  "Anizer",
+		UserType: 2,
+	})
+	if err != nil {
+		t.Fatalf("Register seller: %v", err)
+	}
+	seller := f.sellers.sellers[resp.User.ID]
+	if seller == nil || seller.OnboardingStatus != models.SellerOnboardingIncomplete || resp.User.UserType != models.UserTypeSeller {
+		t.Errorf("stored seller = %+v, want one with onboarding incomplete", seller)
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	f := newAuthFixture(t, "")
+
+	refresh, err := f.jwt.GenerateRefreshToken(1, "seller", "seller@example.com", models.UserTypeSeller)
+	if err != nil {
+		t.Fatalf("GenerateRefreshToken: %v", err)
+	}
+	access, err := f.jwt.GenerateAccessToken(1, "seller", "seller@example.com", models.UserTypeSeller)
+	if err != nil {
+		t.Fatalf("GenerateAccessToken: %v", err)
+	}
+	orphaned, err := f.jwt.GenerateRefreshToken(9, "gone", "gone@example.com", models.UserTypeUser)
+	if err != nil {
+		t.Fatalf("GenerateRefreshToken: %v", err)
+	}
+	forged, err := utils.NewJWTManager(&config.JWTConfig{Secret: "other-secret", RefreshDuration: time.Hour}).
+		GenerateRefreshToken(1, "seller", "seller@example.com", models.UserTypeSeller)
+	if err != nil {
+		t.Fatalf("GenerateRefreshToken: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		wantCode apperrors.Code
+	}{
+		{"refresh token", refresh, ""},
+		{"access token", access, apperrors.CodeUnauthorized},
+		{"signed with another secret", forged, apperrors.CodeUnauthorized},
+		{"malformed", "not-a-token", apperrors.CodeUnauthorized},
+		{"deleted account", orphaned, apperrors.CodeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := f.authService().RefreshToken(tt.token)
+			if code := apperrors.CodeOf(err); code != tt.wantCode {
+				t.Fatalf("error %v has code %q, want %q", err, code, tt.wantCode)
+			}
+			if err == nil && (resp.User.ID != 1 || resp.User.UserType != models.UserTypeSeller) {
+				t.Errorf("refreshed for %+v, want seller 1", resp.User)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+// internal/services/bulk_order_service.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+)
+
+type BulkOrderService struct {
+	bulkOrderRepo            repositories.BulkOrderRepository
+	eventRepo                repositories.EventRepository
+	access                   *EventAccess
+	ticketService            *TicketService
+	paymentService           *PaymentService
+	defaultMaxQuantity       int
+	defaultApprovalThreshold int
+}
+
+// CreateBulkOrderRequest identifies the ticket group the same way as
+// PurchaseTicketFromGroupRequest; the quantity limit comes from the event
+type CreateBulkOrderRequest struct {
+	UserID           uint               `json:"-"` // Set by handler
+	EventID          uint               `json:"event_id" binding:"required"`
+	GroupID          uint               `json:"group_id"`
+	Price            float64            `json:"price" binding:"required_without=GroupID"`
+	Type             models.TicketType  `json:"type" binding:"required_without=GroupID"`
+	IsVip            bool               `json:"is_vip"`
+	Title            string             `json:"title" binding:"required_without=GroupID"`
+	Place            string             `json:"place" binding:"required_without=GroupID"`
+	SaleID           uint               `json:"sale_id" binding:"required_without=GroupID"`
+	Quantity         int                `json:"quantity" binding:"required,min=1"`
+	OrganizationName string             `json:"organization_name" binding:"required,max=255"`
+	PaymentMethod    models.PaymentType `json:"payment_method" binding:"required"`
+}
+
+type RejectBulkOrderRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+type BulkOrderResponse struct {
+	Order            *models.BulkOrder     `json:"order"`
+	PurchasedTickets []PurchasedTicketInfo `json:"purchased_tickets,omitempty"`
+}
+
+func NewBulkOrderService(
+	bulkOrderRepo repositories.BulkOrderRepository,
+	eventRepo repositories.EventRepository,
+	access *EventAccess,
+	ticketService *TicketService,
+	paymentService *PaymentService,
+	defaultMaxQuantity int,
+	defaultApprovalThreshold int,
+) *BulkOrderService {
+	return &BulkOrderService{
+		bulkOrderRepo:            bulkOrderRepo,
+		eventRepo:                eventRepo,
+		access:                   access,
+		ticketService:            ticketService,
+		paymentService:           paymentService,
+		defaultMaxQuantity:       defaultMaxQuantity,
+		defaultApprovalThreshold: defaultApprovalThreshold,
+	}
+}
+
+// CreateBulkOrder places an organization order. Orders above the event's
+// approval threshold wait for the seller; smaller ones are fulfilled at once.
+func (s *BulkOrderService) CreateBulkOrder(ctx context.Context, req *CreateBulkOrderRequest) (*BulkOrderResponse, error) {
+	event, err := s.eventRepo.GetByID(req.EventID)
+	if err != nil {
+		return nil, apperrors.NotFound("event not found")
+	}
+
+	if !eventOnSale(event, time.Now().Unix()) {
+		return nil, ErrEventNotOnSale
+	}
+
+	maxQuantity, approvalThreshold := s.limitsFor(event)
+	if req.Quantity > maxQuantity {
+		return nil, apperrors.Validationf("organization orders for this event are limited to %d tickets", maxQuantity)
+	}
+
+	group, err := s.ticketService.FindGroup(req.EventID, models.GroupedTicket{
+		GroupID: req.GroupID,
+		SaleID:  req.SaleID,
+		Price:   req.Price,
+		Type:    req.Type,
+		IsVip:   req.IsVip,
+		Title:   req.Title,
+		Place:   req.Place,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	order := &models.BulkOrder{
+		EventID:          req.EventID,
+		SaleID:           group.SaleID,
+		GroupID:          group.ID,
+		UserID:           req.UserID,
+		OrganizationName: req.OrganizationName,
+		Price:            group.Price,
+		Type:             group.Type,
+		IsVip:            group.IsVip,
+		Title:            group.Title,
+		Place:            group.Place,
+		Quantity:         req.Quantity,
+		PaymentMethod:    req.PaymentMethod,
+		Status:           models.BulkOrderStatusPendingApproval,
+		CreatedAt:        time.Now().Unix(),
+	}
+
+	if err := s.bulkOrderRepo.Create(order); err != nil {
+		return nil, apperrors.Internal("failed to create organization order")
+	}
+
+	if req.Quantity > approvalThreshold {
+		return &BulkOrderResponse{Order: order}, nil
+	}
+
+	return s.fulfil(ctx, order)
+}
+
+func (s *BulkOrderService) GetUserBulkOrders(userID uint) ([]models.BulkOrder, error) {
+	orders, err := s.bulkOrderRepo.ListByUser(userID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve organization orders")
+	}
+	return orders, nil
+}
+
+func (s *BulkOrderService) GetEventBulkOrders(eventID, sellerID uint) ([]models.BulkOrder, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, apperrors.NotFound("event not found")
+	}
+
+	if !s.access.Allowed(event, sellerID, models.EventPermissionViewSales) {
+		return nil, apperrors.Forbidden("unauthorized to view orders for this event")
+	}
+
+	orders, err := s.bulkOrderRepo.ListByEvent(eventID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve organization orders")
+	}
+	return orders, nil
+}
+
+// ApproveBulkOrder fulfils an order waiting for approval: tickets are
+// reserved and the buyer is charged (or invoiced) now
+func (s *BulkOrderService) ApproveBulkOrder(ctx context.Context, orderID, sellerID uint) (*BulkOrderResponse, error) {
+	order, err := s.getOwnedOrder(orderID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != models.BulkOrderStatusPendingApproval {
+		return nil, apperrors.Validation("order is not awaiting approval")
+	}
+
+	now := time.Now().Unix()
+	order.DecidedAt = &now
+
+	return s.fulfil(ctx, order)
+}
+
+func (s *BulkOrderService) RejectBulkOrder(orderID, sellerID uint, reason string) (*models.BulkOrder, error) {
+	order, err := s.getOwnedOrder(orderID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != models.BulkOrderStatusPendingApproval {
+		return nil, apperrors.Validation("order is not awaiting approval")
+	}
+
+	now := time.Now().Unix()
+	order.Status = models.BulkOrderStatusRejected
+	order.Note = reason
+	order.DecidedAt = &now
+
+	if err := s.bulkOrderRepo.Update(order); err != nil {
+		return nil, apperrors.Internal("failed to reject organization order")
+	}
+
+	return order, nil
+}
+
+// MarkInvoicePaid settles the invoice of a fulfilled order once the seller
+// has received the money
+func (s *BulkOrderService) MarkInvoicePaid(orderID, sellerID uint) (*models.BulkOrder, error) {
+	order, err := s.getOwnedOrder(orderID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != models.BulkOrderStatusInvoiced || order.PaymentID == nil {
+		return nil, apperrors.Validation("order has no outstanding invoice")
+	}
+
+	if err := s.paymentService.SettleInvoice(*order.PaymentID); err != nil {
+		return nil, err
+	}
+
+	order.Status = models.BulkOrderStatusCompleted
+	if err := s.bulkOrderRepo.Update(order); err != nil {
+		return nil, apperrors.Internal("failed to update organization order")
+	}
+
+	return order, nil
+}
+
+// fulfil runs the regular group purchase for the whole order and records
+// the outcome on it. A failed purchase is kept on the order, not returned.
+func (s *BulkOrderService) fulfil(ctx context.Context, order *models.BulkOrder) (*BulkOrderResponse, error) {
+	purchase, err := s.ticketService.PurchaseTicketFromGroup(ctx, &PurchaseTicketFromGroupRequest{
+		UserID:        order.UserID,
+		EventID:       order.EventID,
+		GroupID:       order.GroupID,
+		Price:         order.Price,
+		Type:          order.Type,
+		IsVip:         order.IsVip,
+		Title:         order.Title,
+		Place:         order.Place,
+		SaleID:        order.SaleID,
+		Quantity:      order.Quantity,
+		PaymentMethod: order.PaymentMethod,
+		BulkOrderID:   order.ID,
+	})
+	if err != nil {
+		order.Status = models.BulkOrderStatusFailed
+		order.Note = err.Error()
+	} else {
+		order.PaymentID = &purchase.PaymentInfo.PaymentID
+		order.TotalAmount = purchase.TotalAmount
+		order.Status = models.BulkOrderStatusCompleted
+		if order.PaymentMethod == models.PaymentTypeInvoice {
+			order.Status = models.BulkOrderStatusInvoiced
+			order.InvoiceNumber = fmt.Sprintf("INV-%d-%06d", order.EventID, order.ID)
+		}
+	}
+
+	if err := s.bulkOrderRepo.Update(order); err != nil {
+		return nil, apperrors.Internal("failed to update organization order")
+	}
+
+	response := &BulkOrderResponse{Order: order}
+	if purchase != nil {
+		response.PurchasedTickets = purchase.PurchasedTickets
+	}
+	return response, nil
+}
+
+func (s *BulkOrderService) getOwnedOrder(orderID, sellerID uint) (*models.BulkOrder, error) {
+	order, err := s.bulkOrderRepo.GetByID(orderID)
+	if err != nil {
+		return nil, apperrors.NotFound("organization order not found")
+	}
+
+	if !s.access.Allowed(&order.Event, sellerID, models.EventPermissionManageTickets) {
+		return nil, apperrors.Forbidden("unauthorized to manage this order")
+	}
+
+	return order, nil
+}
+
+func (s *BulkOrderService) limitsFor(event *models.Event) (maxQuantity, approvalThreshold int) {
+	maxQuantity = s.defaultMaxQuantity
+	if event.BulkMaxQuantity > 0 {
+		maxQuantity = event.BulkMaxQuantity
+	}
+
+	approvalThreshold = s.defaultApprovalThreshold
+	if event.BulkApprovalThreshold > 0 {
+		approvalThreshold = event.BulkApprovalThreshold
+	}
+
+	return maxQuantity, approvalThreshold
+}
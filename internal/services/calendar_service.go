@@ -0,0 +1,172 @@
+// internal/services/calendar_service.go
+package services
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+)
+
+// calendarEventDuration is how long feed entries last. Events only store a
+// start time, so calendars get a nominal slot instead of a zero-length entry.
+const calendarEventDuration = 2 * time.Hour
+
+const icsTimeLayout = "20060102T150405Z"
+
+type CalendarService struct {
+	purchasedTicketRepo repositories.PurchasedTicketRepository
+	userRepo            repositories.UserRepository
+	publicURL           string
+}
+
+func NewCalendarService(
+	purchasedTicketRepo repositories.PurchasedTicketRepository,
+	userRepo repositories.UserRepository,
+	publicURL string,
+) *CalendarService {
+	return &CalendarService{
+		purchasedTicketRepo: purchasedTicketRepo,
+		userRepo:            userRepo,
+		publicURL:           publicURL,
+	}
+}
+
+// FeedURL is the address calendar apps subscribe to: the feed at feedPath
+// on the configured public URL, never the host the request named
+func (s *CalendarService) FeedURL(feedPath, token string) string {
+	return strings.TrimRight(s.publicURL, "/") + feedPath + "?token=" + url.QueryEscape(token)
+}
+
+// GetFeedToken returns the token that authenticates the user's calendar feed
+// URL, creating it on first use. Rotating invalidates previously shared URLs.
+func (s *CalendarService) GetFeedToken(userID uint, rotate bool) (string, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return "", apperrors.NotFound("user not found")
+	}
+
+	if user.CalendarToken != nil && !rotate {
+		return *user.CalendarToken, nil
+	}
+
+	token, err := utils.RandomHex(24)
+	if err != nil {
+		return "", apperrors.Internal("failed to generate calendar token")
+	}
+
+	user.CalendarToken = &token
+	if err := s.userRepo.Update(user); err != nil {
+		return "", apperrors.Internal("failed to save calendar token")
+	}
+
+	return token, nil
+}
+
+// WriteFeed writes an iCalendar feed of the upcoming approved events the
+// token's owner holds valid tickets for
+func (s *CalendarService) WriteFeed(token string, w io.Writer) error {
+	if token == "" {
+		return apperrors.Unauthorized("invalid calendar token")
+	}
+
+	user, err := s.userRepo.GetByCalendarToken(token)
+	if err != nil {
+		return apperrors.Unauthorized("invalid calendar token")
+	}
+
+	tickets, err := s.purchasedTicketRepo.ListByUser(user.ID)
+	if err != nil {
+		return apperrors.Internal("failed to retrieve tickets")
+	}
+
+	now := time.Now().Unix()
+	events := make(map[uint]models.Event)
+	counts := make(map[uint]int)
+	for _, ticket := range tickets {
+		event := ticket.Ticket.Event
+		if ticket.IsInvalidated || event.Date < now || event.Status != models.EventStatusApproved {
+			continue
+		}
+		events[event.ID] = event
+		counts[event.ID]++
+	}
+
+	ordered := make([]models.Event, 0, len(events))
+	for _, event := range events {
+		ordered = append(ordered, event)
+	}
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].Date < ordered[j].Date })
+
+	stamp := time.Now().UTC().Format(icsTimeLayout)
+
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//eticketing//Tickets//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:My tickets")
+	for _, event := range ordered {
+		start := time.Unix(event.Date, 0).UTC()
+		description := fmt.Sprintf("%d ticket(s)", counts[event.ID])
+		if event.Description != "" {
+			description += "\n\n" + event.Description
+		}
+
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, fmt.Sprintf("UID:event-%d-user-%d@eticketing", event.ID, user.ID))
+		writeICSLine(&b, "DTSTAMP:"+stamp)
+		writeICSLine(&b, "DTSTART:"+start.Format(icsTimeLayout))
+		writeICSLine(&b, "DTEND:"+start.Add(calendarEventDuration).Format(icsTimeLayout))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText(event.Title))
+		writeICSLine(&b, "LOCATION:"+escapeICSText(event.Address))
+		writeICSLine(&b, "DESCRIPTION:"+escapeICSText(description))
+		writeICSLine(&b, "END:VEVENT")
+	}
+	writeICSLine(&b, "END:VCALENDAR")
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// escapeICSText escapes a TEXT value per RFC 5545 section 3.3.11
+func escapeICSText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(value)
+}
+
+// writeICSLine folds content lines longer than 75 octets, never splitting a
+// UTF-8 sequence, and terminates them with CRLF
+func writeICSLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Start(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // Continuation lines start with a space
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isUTF8Start(c byte) bool {
+	return c&0xC0 != 0x80
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"eticketing/internal/models"
+)
+
+func TestCalendarFeedListsApprovedEventsOnly(t *testing.T) {
+	token := "feedtoken"
+	users := &userStore{users: map[uint]*models.User{
+		buyerID: {ID: buyerID, CalendarToken: &token},
+	}}
+
+	upcoming := time.Now().Add(24 * time.Hour).Unix()
+	statuses := []models.EventStatus{
+		models.EventStatusApproved, models.EventStatusPending, models.EventStatusRejected,
+		models.EventStatusCancelled, models.EventStatusSuspended,
+	}
+	purchased := &purchasedTicketStore{tickets: map[uint]*models.PurchasedTicket{}}
+	for i, status := range statuses {
+		id := uint(i + 1)
+		purchased.tickets[id] = &models.PurchasedTicket{ID: id, UserID: buyerID, Ticket: models.Ticket{
+			Event: models.Event{ID: id, Title: "Event " + string(rune('A'+i)), Date: upcoming, Status: status},
+		}}
+	}
+
+	var feed strings.Builder
+	ctrl := gomock.NewController(t)
+	if err := NewCalendarService(purchased.mock(ctrl), users.mock(ctrl), "").WriteFeed(token, &feed); err != nil {
+		t.Fatalf("WriteFeed: %v", err)
+	}
+
+	if got := strings.Count(feed.String(), "BEGIN:VEVENT"); got != 1 {
+		t.Errorf("feed has %d events, want 1", got)
+	}
+	if !strings.Contains(feed.String(), "SUMMARY:Event A") {
+		t.Errorf("feed doesn't list the approved event:\n%s", feed.String())
+	}
+}
@@ -0,0 +1,379 @@
+// internal/services/data_export_service.go
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/outbox"
+	"eticketing/internal/repositories"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type DataExportService struct {
+	exportRepo          repositories.DataExportRepository
+	userRepo            repositories.UserRepository
+	orderRepo           repositories.OrderRepository
+	bulkOrderRepo       repositories.BulkOrderRepository
+	purchasedTicketRepo repositories.PurchasedTicketRepository
+	paymentRepo         repositories.PaymentRepository
+	paymentMethodRepo   repositories.PaymentMethodRepository
+	transferRepo        repositories.TransferRepository
+	followRepo          repositories.FollowRepository
+	outboxRepo          repositories.OutboxRepository
+	txManager           repositories.TransactionManager
+	publicURL           string
+	ttl                 time.Duration
+}
+
+type DataExportResponse struct {
+	ID          uint                    `json:"id"`
+	Status      models.DataExportStatus `json:"status"`
+	Size        int                     `json:"size"`
+	Error       string                  `json:"error,omitempty"`
+	CreatedAt   int64                   `json:"created_at"`
+	CompletedAt *int64                  `json:"completed_at"`
+	ExpiresAt   *int64                  `json:"expires_at"`
+	DownloadURL string                  `json:"download_url,omitempty"`
+}
+
+// exportedTransfer is a transfer as seen by the exporting user; the other
+// party is identified by username only
+type exportedTransfer struct {
+	ID                uint                  `json:"id"`
+	Direction         string                `json:"direction"` // "sent" or "received"
+	Counterparty      string                `json:"counterparty"`
+	PurchasedTicketID uint                  `json:"purchased_ticket_id"`
+	Date              int64                 `json:"date"`
+	Status            models.TransferStatus `json:"status"`
+	CompletedAt       int64                 `json:"completed_at,omitempty"`
+}
+
+func NewDataExportService(
+	exportRepo repositories.DataExportRepository,
+	userRepo repositories.UserRepository,
+	orderRepo repositories.OrderRepository,
+	bulkOrderRepo repositories.BulkOrderRepository,
+	purchasedTicketRepo repositories.PurchasedTicketRepository,
+	paymentRepo repositories.PaymentRepository,
+	paymentMethodRepo repositories.PaymentMethodRepository,
+	transferRepo repositories.TransferRepository,
+	followRepo repositories.FollowRepository,
+	outboxRepo repositories.OutboxRepository,
+	txManager repositories.TransactionManager,
+	publicURL string,
+	ttl time.Duration,
+) *DataExportService {
+	return &DataExportService{
+		exportRepo:          exportRepo,
+		userRepo:            userRepo,
+		orderRepo:           orderRepo,
+		bulkOrderRepo:       bulkOrderRepo,
+		purchasedTicketRepo: purchasedTicketRepo,
+		paymentRepo:         paymentRepo,
+		paymentMethodRepo:   paymentMethodRepo,
+		transferRepo:        transferRepo,
+		followRepo:          followRepo,
+		outboxRepo:          outboxRepo,
+		txManager:           txManager,
+		publicURL:           strings.TrimRight(publicURL, "/"),
+		ttl:                 ttl,
+	}
+}
+
+// RequestExport queues a new export of the user's data. The archive is
+// built in the background and the user is emailed a link when it is ready.
+func (s *DataExportService) RequestExport(userID uint) (*DataExportResponse, error) {
+	pending, err := s.exportRepo.HasPending(userID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to check data exports")
+	}
+	if pending {
+		return nil, apperrors.Conflict("a data export is already being prepared")
+	}
+
+	export := &models.DataExport{
+		UserID:    userID,
+		Status:    models.DataExportStatusPending,
+		CreatedAt: time.Now().Unix(),
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.exportRepo.WithTx(tx).Create(export); err != nil {
+			return err
+		}
+
+		message, err := outbox.NewMessage(models.OutboxTopicDataExportRequested, outbox.DataExportPayload{
+			ExportID: export.ID,
+			UserID:   userID,
+		})
+		if err != nil {
+			return err
+		}
+		return s.outboxRepo.WithTx(tx).Create(message)
+	})
+	if err != nil {
+		return nil, apperrors.Internal("failed to request data export")
+	}
+
+	return s.toResponse(export), nil
+}
+
+// GetLatestExport returns the state of the user's most recent export
+func (s *DataExportService) GetLatestExport(userID uint) (*DataExportResponse, error) {
+	export, err := s.exportRepo.GetLatestByUser(userID)
+	if err != nil {
+		return nil, apperrors.NotFound("no data export found")
+	}
+	return s.toResponse(export), nil
+}
+
+// GetArchive returns a ready archive by its download token
+func (s *DataExportService) GetArchive(token string) ([]byte, error) {
+	if token == "" {
+		return nil, apperrors.NotFound("data export not found")
+	}
+
+	export, err := s.exportRepo.GetByToken(token)
+	if err != nil || export.Status != models.DataExportStatusReady {
+		return nil, apperrors.NotFound("data export not found")
+	}
+	if export.ExpiresAt != nil && *export.ExpiresAt < time.Now().Unix() {
+		return nil, apperrors.Validation("data export has expired")
+	}
+
+	return export.Archive, nil
+}
+
+// Generate is the outbox handler for data_export.requested. It builds the
+// archive and queues the email with its download link. A failed build is
+// recorded on the export rather than retried, so the user can ask again.
+func (s *DataExportService) Generate(ctx context.Context, message *models.OutboxMessage) error {
+	var payload outbox.DataExportPayload
+	if err := json.Unmarshal([]byte(message.Payload), &payload); err != nil {
+		return fmt.Errorf("invalid payload for %s: %w", message.Topic, err)
+	}
+
+	export, err := s.exportRepo.GetByID(payload.ExportID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if export.Status != models.DataExportStatusPending {
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(export.UserID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	export.CompletedAt = &now
+
+	archive, buildErr := s.buildArchive(user)
+	if buildErr != nil {
+		export.Status = models.DataExportStatusFailed
+		export.Error = buildErr.Error()
+		return s.exportRepo.Update(export)
+	}
+
+	token, err := utils.RandomHex(32)
+	if err != nil {
+		return err
+	}
+	expiresAt := now + int64(s.ttl.Seconds())
+
+	export.Status = models.DataExportStatusReady
+	export.Archive = archive
+	export.Size = len(archive)
+	export.Token = &token
+	export.ExpiresAt = &expiresAt
+
+	ready, err := outbox.NewMessage(models.OutboxTopicDataExportReady, outbox.DataExportPayload{
+		ExportID:    export.ID,
+		UserID:      user.ID,
+		Email:       user.Email,
+		DownloadURL: s.downloadURL(token),
+		ExpiresAt:   expiresAt,
+		Locale:      user.Locale,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.exportRepo.WithTx(tx).Update(export); err != nil {
+			return err
+		}
+		return s.outboxRepo.WithTx(tx).Create(ready)
+	})
+}
+
+// PurgeExpired deletes exports whose download link has expired
+func (s *DataExportService) PurgeExpired() (int64, error) {
+	deleted, err := s.exportRepo.DeleteExpired(time.Now().Unix())
+	if err != nil {
+		return 0, apperrors.Internal("failed to delete expired data exports")
+	}
+	return deleted, nil
+}
+
+// buildArchive collects everything stored about the user into a ZIP of
+// JSON files, one per kind of record
+func (s *DataExportService) buildArchive(user *models.User) ([]byte, error) {
+	profile := map[string]interface{}{
+		"id":       user.ID,
+		"username": user.Username,
+		"email":    user.Email,
+		"name":     user.Name,
+		"surname":  user.Surname,
+	}
+
+	orders, err := s.orderRepo.ListByUser(user.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load orders")
+	}
+	bulkOrders, err := s.bulkOrderRepo.ListByUser(user.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load organization orders")
+	}
+	tickets, err := s.purchasedTicketRepo.ListByUser(user.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load tickets")
+	}
+	payments, err := s.paymentRepo.ListByUserAndType(user.ID, models.UserTypeUser, repositories.PaymentFilter{}, -1, 0) // -1 lifts the limit
+	if err != nil {
+		return nil, apperrors.Internal("failed to load payments")
+	}
+	paymentMethods, err := s.paymentMethodRepo.ListByUser(user.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load payment methods")
+	}
+	follows, err := s.followRepo.ListByUser(user.ID, -1, 0)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load followed sellers")
+	}
+	transfers, err := s.exportTransfers(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	following := make([]FollowedSeller, 0, len(follows))
+	for i := range follows {
+		following = append(following, *followedSeller(&follows[i]))
+	}
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", profile},
+		{"orders.json", orders},
+		{"organization_orders.json", bulkOrders},
+		{"tickets.json", tickets},
+		{"payments.json", payments},
+		{"payment_methods.json", paymentMethods},
+		{"transfers.json", transfers},
+		{"following.json", following},
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s *DataExportService) exportTransfers(userID uint) ([]exportedTransfer, error) {
+	active, err := s.transferRepo.ListActiveByUser(userID, -1, 0)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load transfers")
+	}
+	closed, err := s.transferRepo.ListRejectedByUser(userID, -1, 0)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load transfers")
+	}
+	done, err := s.transferRepo.ListDoneByUser(userID, -1, 0)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load transfers")
+	}
+
+	transfers := make([]exportedTransfer, 0, len(active)+len(closed)+len(done))
+	for _, transfer := range append(active, closed...) {
+		direction, counterparty := transferSide(userID, transfer.FromUserID, transfer.ToUser.Username, transfer.FromUser.Username)
+		transfers = append(transfers, exportedTransfer{
+			ID:                transfer.ID,
+			Direction:         direction,
+			Counterparty:      counterparty,
+			PurchasedTicketID: transfer.PurchasedTicketID,
+			Date:              transfer.Date,
+			Status:            transfer.Status,
+		})
+	}
+	for _, transfer := range done {
+		direction, counterparty := transferSide(userID, transfer.FromUserID, transfer.ToUser.Username, transfer.FromUser.Username)
+		transfers = append(transfers, exportedTransfer{
+			ID:                transfer.ID,
+			Direction:         direction,
+			Counterparty:      counterparty,
+			PurchasedTicketID: transfer.PurchasedTicketID,
+			Date:              transfer.Date,
+			Status:            models.TransferStatusAccepted,
+			CompletedAt:       transfer.CompletedAt,
+		})
+	}
+
+	return transfers, nil
+}
+
+func transferSide(userID, fromUserID uint, toUsername, fromUsername string) (direction, counterparty string) {
+	if fromUserID == userID {
+		return "sent", toUsername
+	}
+	return "received", fromUsername
+}
+
+func (s *DataExportService) downloadURL(token string) string {
+	return s.publicURL + "/api/v1/data-exports/" + token
+}
+
+func (s *DataExportService) toResponse(export *models.DataExport) *DataExportResponse {
+	response := &DataExportResponse{
+		ID:          export.ID,
+		Status:      export.Status,
+		Size:        export.Size,
+		Error:       export.Error,
+		CreatedAt:   export.CreatedAt,
+		CompletedAt: export.CompletedAt,
+		ExpiresAt:   export.ExpiresAt,
+	}
+	if export.Status == models.DataExportStatusReady && export.Token != nil {
+		response.DownloadURL = s.downloadURL(*export.Token)
+	}
+	return response
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// DeadLetterService lets admins work through the outbox messages (emails,
+// webhooks, follower notices, exports) the dispatcher gave up on
+type DeadLetterService struct {
+	deadLetterRepo repositories.DeadLetterRepository
+	outboxRepo     repositories.OutboxRepository
+	txManager      repositories.TransactionManager
+}
+
+func NewDeadLetterService(deadLetterRepo repositories.DeadLetterRepository, outboxRepo repositories.OutboxRepository, txManager repositories.TransactionManager) *DeadLetterService {
+	return &DeadLetterService{
+		deadLetterRepo: deadLetterRepo,
+		outboxRepo:     outboxRepo,
+		txManager:      txManager,
+	}
+}
+
+func (s *DeadLetterService) ListDeadLetters(filter repositories.DeadLetterFilter, page, limit int) (*utils.PaginatedResponse, error) {
+	offset := (page - 1) * limit
+	letters, err := s.deadLetterRepo.List(filter, limit, offset)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve dead letters")
+	}
+
+	total, err := s.deadLetterRepo.Count(filter)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count dead letters")
+	}
+
+	return &utils.PaginatedResponse{
+		Success:    true,
+		Message:    "Dead letters retrieved successfully",
+		Data:       letters,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, nil
+}
+
+func (s *DeadLetterService) GetDeadLetter(id uint) (*models.DeadLetter, error) {
+	letter, err := s.deadLetterRepo.GetByID(id)
+	if err != nil {
+		return nil, apperrors.NotFound("dead letter not found")
+	}
+	return letter, nil
+}
+
+// RetryDeadLetter puts the message back in the outbox with a fresh set of
+// attempts; the dispatcher picks it up on its next run
+func (s *DeadLetterService) RetryDeadLetter(id, adminID uint) (*models.DeadLetter, error) {
+	var letter *models.DeadLetter
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		var err error
+		letter, err = s.openDeadLetter(tx, id)
+		if err != nil {
+			return err
+		}
+
+		outboxRepo := s.outboxRepo.WithTx(tx)
+		message, err := outboxRepo.GetByID(letter.OutboxMessageID)
+		if err != nil {
+			return apperrors.NotFound("outbox message not found")
+		}
+
+		message.Status = models.OutboxStatusPending
+		message.Attempts = 0
+		message.NextAttemptAt = time.Now().Unix()
+		message.LastError = ""
+		if err := outboxRepo.Update(message); err != nil {
+			return err
+		}
+
+		return s.resolve(tx, letter, models.DeadLetterStatusRetried, adminID)
+	})
+	if err != nil {
+		if _, ok := apperrors.As(err); ok {
+			return nil, err
+		}
+		return nil, apperrors.Internal("failed to retry dead letter")
+	}
+
+	return letter, nil
+}
+
+// DiscardDeadLetter closes the dead letter without delivering the message
+func (s *DeadLetterService) DiscardDeadLetter(id, adminID uint) (*models.DeadLetter, error) {
+	var letter *models.DeadLetter
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		var err error
+		letter, err = s.openDeadLetter(tx, id)
+		if err != nil {
+			return err
+		}
+		return s.resolve(tx, letter, models.DeadLetterStatusDiscarded, adminID)
+	})
+	if err != nil {
+		if _, ok := apperrors.As(err); ok {
+			return nil, err
+		}
+		return nil, apperrors.Internal("failed to discard dead letter")
+	}
+
+	return letter, nil
+}
+
+func (s *DeadLetterService) openDeadLetter(tx *gorm.DB, id uint) (*models.DeadLetter, error) {
+	letter, err := s.deadLetterRepo.WithTx(tx).GetByID(id)
+	if err != nil {
+		return nil, apperrors.NotFound("dead letter not found")
+	}
+	if letter.Status != models.DeadLetterStatusOpen {
+		return nil, apperrors.Conflict("dead letter has already been retried or discarded")
+	}
+	return letter, nil
+}
+
+func (s *DeadLetterService) resolve(tx *gorm.DB, letter *models.DeadLetter, status models.DeadLetterStatus, adminID uint) error {
+	now := time.Now().Unix()
+	letter.Status = status
+	letter.ResolvedAt = &now
+	letter.ResolvedBy = &adminID
+	return s.deadLetterRepo.WithTx(tx).Update(letter)
+}
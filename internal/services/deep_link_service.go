@@ -0,0 +1,157 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+	"github.com/skip2/go-qrcode"
+)
+
+// DeepLinkService signs links that open a ticket in the mobile app, e.g.
+// from an email. The token names the ticket, its holder and an expiry; the
+// app sends it back to be checked before showing the ticket.
+type DeepLinkService struct {
+	purchasedTicketRepo repositories.PurchasedTicketRepository
+	key                 []byte
+	scheme              string
+	universalURL        string
+	ttl                 time.Duration
+}
+
+// TicketDeepLink carries the same token in both link styles
+type TicketDeepLink struct {
+	AppURL       string `json:"app_url"`       // Custom scheme, opens the app directly
+	UniversalURL string `json:"universal_url"` // Opens the app when installed, the website otherwise
+	Token        string `json:"token"`
+	ExpiresAt    int64  `json:"expires_at"`
+}
+
+// DeepLinkTicket is what the app shows once a link checks out
+type DeepLinkTicket struct {
+	TicketID   uint    `json:"ticket_id"`
+	Title      string  `json:"title"`
+	Place      string  `json:"place"`
+	Price      float64 `json:"price"`
+	IsUsed     bool    `json:"is_used"`
+	EventID    uint    `json:"event_id"`
+	EventTitle string  `json:"event_title"`
+	EventDate  int64   `json:"event_date"`
+}
+
+func NewDeepLinkService(
+	purchasedTicketRepo repositories.PurchasedTicketRepository,
+	signingKey, scheme, universalURL string,
+	ttl time.Duration,
+) *DeepLinkService {
+	return &DeepLinkService{
+		purchasedTicketRepo: purchasedTicketRepo,
+		key:                 []byte(signingKey),
+		scheme:              scheme,
+		universalURL:        strings.TrimRight(universalURL, "/"),
+		ttl:                 ttl,
+	}
+}
+
+// CreateTicketLink signs a link to one of the user's tickets
+func (s *DeepLinkService) CreateTicketLink(ticketID, userID uint, now time.Time) (*TicketDeepLink, error) {
+	ticket, err := s.holderTicket(ticketID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tickets issued before QR secrets existed get theirs now, or the link
+	// would break the first time the PDF is downloaded
+	if ticket.QRSecret == "" {
+		secret, err := s.purchasedTicketRepo.RotateQRSecret(ticket.ID)
+		if err != nil {
+			return nil, apperrors.Internal("failed to prepare ticket link")
+		}
+		ticket.QRSecret = secret
+	}
+
+	expiresAt := now.Add(s.ttl).Unix()
+	token := fmt.Sprintf("%d.%d.%s", ticket.ID, expiresAt, s.sign(ticket, expiresAt))
+
+	return &TicketDeepLink{
+		AppURL:       s.scheme + "://tickets/open?token=" + url.QueryEscape(token),
+		UniversalURL: s.universalURL + "/t/" + url.PathEscape(token),
+		Token:        token,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// QRCode renders the link's universal URL as a PNG, so it can be scanned
+// from another screen straight into the app
+func (link *TicketDeepLink) QRCode() ([]byte, error) {
+	return qrcode.Encode(link.UniversalURL, qrcode.Medium, 256)
+}
+
+// ResolveTicketLink checks a link's token and returns its ticket. Links
+// stop working once they expire, the ticket changes hands or its QR code
+// is rotated.
+func (s *DeepLinkService) ResolveTicketLink(token string, userID uint, now time.Time) (*DeepLinkTicket, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return nil, apperrors.Validation("not a ticket link")
+	}
+	ticketID, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return nil, apperrors.Validation("not a ticket link")
+	}
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, apperrors.Validation("not a ticket link")
+	}
+
+	ticket, err := s.holderTicket(uint(ticketID), userID)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(ticket, expiresAt))) {
+		return nil, apperrors.Validation("ticket link is no longer valid")
+	}
+	if now.Unix() > expiresAt {
+		return nil, apperrors.Validation("ticket link has expired; open the ticket from the app instead")
+	}
+
+	return &DeepLinkTicket{
+		TicketID:   ticket.ID,
+		Title:      ticket.Title,
+		Place:      ticket.Place,
+		Price:      ticket.Price,
+		IsUsed:     ticket.IsUsed,
+		EventID:    ticket.Ticket.EventID,
+		EventTitle: ticket.Ticket.Event.Title,
+		EventDate:  ticket.Ticket.Event.Date,
+	}, nil
+}
+
+// holderTicket loads a ticket the user still holds and can use
+func (s *DeepLinkService) holderTicket(ticketID, userID uint) (*models.PurchasedTicket, error) {
+	ticket, err := s.purchasedTicketRepo.GetByID(ticketID)
+	if err != nil {
+		return nil, apperrors.NotFound("ticket not found")
+	}
+	if ticket.UserID != userID {
+		return nil, apperrors.Forbidden("you can only open your own tickets")
+	}
+	if ticket.IsInvalidated {
+		return nil, apperrors.Forbidden("this ticket has been invalidated")
+	}
+	return ticket, nil
+}
+
+func (s *DeepLinkService) sign(ticket *models.PurchasedTicket, expiresAt int64) string {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "link:%d:%d:%d:%s", ticket.ID, ticket.UserID, expiresAt, ticket.QRSecret)
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// DenylistService manages the emails, email domains, cards and IP ranges
+// barred from registering and buying. Every change is audited.
+type DenylistService struct {
+	denylistRepo      repositories.DenylistRepository
+	paymentMethodRepo repositories.PaymentMethodRepository
+	auditRepo         repositories.AuditLogRepository
+	txManager         repositories.TransactionManager
+}
+
+// CreateDenylistEntryRequest adds an entry. Cards are best named by the
+// ID of a stored payment method, as admins never see provider tokens.
+type CreateDenylistEntryRequest struct {
+	Kind            models.DenylistKind `json:"kind" binding:"required,oneof=1 2 3 4"` // 1 email, 2 domain, 3 card, 4 IP range
+	Value           string              `json:"value" binding:"required_without=PaymentMethodID,max=255"`
+	PaymentMethodID uint                `json:"payment_method_id"` // Cards only
+	Reason          string              `json:"reason" binding:"max=500"`
+}
+
+type UpdateDenylistEntryRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+func NewDenylistService(
+	denylistRepo repositories.DenylistRepository,
+	paymentMethodRepo repositories.PaymentMethodRepository,
+	auditRepo repositories.AuditLogRepository,
+	txManager repositories.TransactionManager,
+) *DenylistService {
+	return &DenylistService{
+		denylistRepo:      denylistRepo,
+		paymentMethodRepo: paymentMethodRepo,
+		auditRepo:         auditRepo,
+		txManager:         txManager,
+	}
+}
+
+func (s *DenylistService) List(kind models.DenylistKind, limit, offset int) ([]models.DenylistEntry, int64, error) {
+	entries, err := s.denylistRepo.List(kind, limit, offset)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to load denylist")
+	}
+	total, err := s.denylistRepo.Count(kind)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to count denylist entries")
+	}
+	return entries, total, nil
+}
+
+func (s *DenylistService) Create(req *CreateDenylistEntryRequest, adminID uint, ip string) (*models.DenylistEntry, error) {
+	value, label, err := s.normalize(req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.denylistRepo.FindMatches(req.Kind, []string{value})
+	if err != nil {
+		return nil, apperrors.Internal("failed to check denylist")
+	}
+	if len(existing) > 0 {
+		return nil, apperrors.Conflict("this entry is already denylisted")
+	}
+
+	now := time.Now().Unix()
+	entry := &models.DenylistEntry{
+		Kind:      req.Kind,
+		Value:     value,
+		Label:     label,
+		Reason:    strings.TrimSpace(req.Reason),
+		CreatedBy: adminID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.denylistRepo.WithTx(tx).Create(entry); err != nil {
+			return err
+		}
+		return s.audit(tx, models.AuditActionDenylistAdded, entry, adminID, ip)
+	})
+	if err != nil {
+		return nil, apperrors.Internal("failed to add denylist entry")
+	}
+	return entry, nil
+}
+
+// Update changes an entry's reason; a different value is a new entry
+func (s *DenylistService) Update(id uint, req *UpdateDenylistEntryRequest, adminID uint, ip string) (*models.DenylistEntry, error) {
+	entry, err := s.denylistRepo.GetByID(id)
+	if err != nil {
+		return nil, apperrors.NotFound("denylist entry not found")
+	}
+
+	entry.Reason = strings.TrimSpace(req.Reason)
+	entry.UpdatedAt = time.Now().Unix()
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.denylistRepo.WithTx(tx).Update(entry); err != nil {
+			return err
+		}
+		return s.audit(tx, models.AuditActionDenylistUpdated, entry, adminID, ip)
+	})
+	if err != nil {
+		return nil, apperrors.Internal("failed to update denylist entry")
+	}
+	return entry, nil
+}
+
+func (s *DenylistService) Delete(id uint, adminID uint, ip string) error {
+	entry, err := s.denylistRepo.GetByID(id)
+	if err != nil {
+		return apperrors.NotFound("denylist entry not found")
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.denylistRepo.WithTx(tx).Delete(entry.ID); err != nil {
+			return err
+		}
+		return s.audit(tx, models.AuditActionDenylistRemoved, entry, adminID, ip)
+	})
+	if err != nil {
+		return apperrors.Internal("failed to remove denylist entry")
+	}
+	return nil
+}
+
+// CheckRegistration refuses sign-ups from a denylisted email, email domain
+// or IP
+func (s *DenylistService) CheckRegistration(email, ip string) error {
+	matches, err := s.Match(email, ip, nil)
+	if err != nil {
+		return err
+	}
+	if len(matches) > 0 {
+		return apperrors.Forbidden("registration is not available; contact support")
+	}
+	return nil
+}
+
+// Match returns why a buyer is denylisted, one reason per matching entry,
+// or nothing when they aren't. Satisfies fraud.Denylist.
+func (s *DenylistService) Match(email, ip string, cardTokens []string) ([]string, error) {
+	var reasons []string
+
+	if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+		entries, err := s.denylistRepo.FindMatches(models.DenylistKindEmail, []string{email})
+		if err != nil {
+			return nil, apperrors.Internal("failed to check denylist")
+		}
+		for range entries {
+			reasons = append(reasons, "email is denylisted")
+		}
+
+		if at := strings.LastIndex(email, "@"); at >= 0 {
+			entries, err := s.denylistRepo.FindMatches(models.DenylistKindDomain, []string{email[at+1:]})
+			if err != nil {
+				return nil, apperrors.Internal("failed to check denylist")
+			}
+			for _, entry := range entries {
+				reasons = append(reasons, "email domain "+entry.Value+" is denylisted")
+			}
+		}
+	}
+
+	if len(cardTokens) > 0 {
+		entries, err := s.denylistRepo.FindMatches(models.DenylistKindCard, cardTokens)
+		if err != nil {
+			return nil, apperrors.Internal("failed to check denylist")
+		}
+		for _, entry := range entries {
+			reasons = append(reasons, "card "+entry.Label+" is denylisted")
+		}
+	}
+
+	if parsed := net.ParseIP(ip); parsed != nil {
+		entries, err := s.denylistRepo.ListByKind(models.DenylistKindIPRange)
+		if err != nil {
+			return nil, apperrors.Internal("failed to check denylist")
+		}
+		for _, entry := range entries {
+			if _, network, err := net.ParseCIDR(entry.Value); err == nil && network.Contains(parsed) {
+				reasons = append(reasons, "IP is in denylisted range "+entry.Value)
+			}
+		}
+	}
+
+	return reasons, nil
+}
+
+// normalize returns the value an entry is matched on and how it is shown
+func (s *DenylistService) normalize(req *CreateDenylistEntryRequest) (value, label string, err error) {
+	value = strings.TrimSpace(req.Value)
+
+	switch req.Kind {
+	case models.DenylistKindEmail:
+		value = strings.ToLower(value)
+		if !utils.ValidateEmail(value) {
+			return "", "", apperrors.Validation("invalid email address")
+		}
+		return value, value, nil
+
+	case models.DenylistKindDomain:
+		value = strings.ToLower(strings.TrimPrefix(value, "@"))
+		if value == "" || strings.ContainsAny(value, "@ ") || !strings.Contains(value, ".") {
+			return "", "", apperrors.Validation("invalid email domain")
+		}
+		return value, value, nil
+
+	case models.DenylistKindCard:
+		if req.PaymentMethodID == 0 {
+			if value == "" {
+				return "", "", apperrors.Validation("name the card by payment_method_id or its token")
+			}
+			return value, "card token", nil
+		}
+		method, err := s.paymentMethodRepo.GetByID(req.PaymentMethodID)
+		if err != nil {
+			return "", "", apperrors.NotFound("payment method not found")
+		}
+		return method.Token, fmt.Sprintf("%s *%s", method.Brand, method.Last4), nil
+
+	case models.DenylistKindIPRange:
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return "", "", apperrors.Validation("invalid IP address or range")
+			}
+			if ip.To4() != nil {
+				value += "/32"
+			} else {
+				value += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return "", "", apperrors.Validation("invalid IP address or range")
+		}
+		return network.String(), network.String(), nil
+	}
+
+	return "", "", apperrors.Validation("unknown denylist kind")
+}
+
+func (s *DenylistService) audit(tx *gorm.DB, action string, entry *models.DenylistEntry, adminID uint, ip string) error {
+	return s.auditRepo.WithTx(tx).Create(&models.AuditLog{
+		ActorID:   adminID,
+		ActorType: models.UserTypeAdmin,
+		Action:    action,
+		Details:   fmt.Sprintf("entry=%d kind=%d label=%q reason=%q", entry.ID, entry.Kind, entry.Label, entry.Reason),
+		IP:        ip,
+		CreatedAt: time.Now().Unix(),
+	})
+}
@@ -0,0 +1,341 @@
+// internal/services/dispute_service.go
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/outbox"
+	"eticketing/internal/payments"
+	"eticketing/internal/repositories"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// webhookTolerance bounds the age of a signed provider webhook, so a captured
+// request can't be replayed later
+const webhookTolerance = 5 * time.Minute
+
+var providerDisputeStatuses = map[string]models.DisputeStatus{
+	payments.DisputeNeedsResponse: models.DisputeStatusOpen,
+	payments.DisputeUnderReview:   models.DisputeStatusUnderReview,
+	payments.DisputeWon:           models.DisputeStatusWon,
+	payments.DisputeLost:          models.DisputeStatusLost,
+}
+
+type DisputeService struct {
+	disputeRepo         repositories.DisputeRepository
+	paymentRepo         repositories.PaymentRepository
+	purchasedTicketRepo repositories.PurchasedTicketRepository
+	outboxRepo          repositories.OutboxRepository
+	txManager           repositories.TransactionManager
+	providers           *payments.Registry
+	webhookSecret       string
+}
+
+type UpdateDisputeRequest struct {
+	Status models.DisputeStatus `json:"status" binding:"required,min=1,max=4"`
+	Note   string               `json:"note" binding:"max=2000"`
+}
+
+func NewDisputeService(
+	disputeRepo repositories.DisputeRepository,
+	paymentRepo repositories.PaymentRepository,
+	purchasedTicketRepo repositories.PurchasedTicketRepository,
+	outboxRepo repositories.OutboxRepository,
+	txManager repositories.TransactionManager,
+	providers *payments.Registry,
+	webhookSecret string,
+) *DisputeService {
+	return &DisputeService{
+		disputeRepo:         disputeRepo,
+		paymentRepo:         paymentRepo,
+		purchasedTicketRepo: purchasedTicketRepo,
+		outboxRepo:          outboxRepo,
+		txManager:           txManager,
+		providers:           providers,
+		webhookSecret:       webhookSecret,
+	}
+}
+
+// VerifyWebhook authenticates a webhook call from a payment provider
+func (s *DisputeService) VerifyWebhook(provider, timestamp, signature string, body []byte) error {
+	if s.webhookSecret == "" {
+		return apperrors.Internal("payment webhooks are not configured")
+	}
+
+	if _, err := s.providers.Get(provider); err != nil {
+		return err
+	}
+
+	sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return apperrors.Unauthorized("invalid webhook timestamp")
+	}
+	if age := time.Since(time.Unix(sentAt, 0)); age > webhookTolerance || age < -webhookTolerance {
+		return apperrors.Unauthorized("webhook timestamp outside tolerance")
+	}
+
+	if !payments.VerifySignature(s.webhookSecret, timestamp, body, signature) {
+		return apperrors.Unauthorized("invalid webhook signature")
+	}
+
+	return nil
+}
+
+// HandleDisputeEvent opens or updates the dispute a provider reported.
+// Notifications for a dispute that is already decided are ignored, so
+// providers may redeliver them safely.
+func (s *DisputeService) HandleDisputeEvent(provider string, event *payments.DisputeEvent) (*models.Dispute, error) {
+	status := providerDisputeStatuses[event.Status]
+
+	dispute, err := s.disputeRepo.GetByProviderID(provider, event.ID)
+	if err == nil {
+		if s.isResolved(dispute) || dispute.Status == status {
+			return dispute, nil
+		}
+		err := s.transition(dispute, status, "")
+		if apperrors.CodeOf(err) == apperrors.CodeConflict {
+			// A concurrent delivery decided it first
+			return dispute, nil
+		}
+		return dispute, err
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.Internal("failed to look up dispute")
+	}
+
+	paymentID, err := strconv.ParseUint(event.Reference, 10, 32)
+	if err != nil {
+		return nil, apperrors.NotFound("unknown payment reference")
+	}
+
+	payment, err := s.paymentRepo.GetByID(uint(paymentID))
+	if err != nil || payment.UserType != models.UserTypeUser {
+		return nil, apperrors.NotFound("unknown payment reference")
+	}
+
+	now := time.Now().Unix()
+	dispute = &models.Dispute{
+		Provider:          provider,
+		ProviderDisputeID: event.ID,
+		PaymentID:         payment.ID,
+		UserID:            payment.UserID,
+		EventID:           payment.EventID,
+		SellerID:          payment.Event.SellerID,
+		Amount:            roundCents(event.Amount),
+		Reason:            event.Reason,
+		Status:            models.DisputeStatusOpen,
+		OpenedAt:          now,
+		UpdatedAt:         now,
+	}
+
+	if err := s.disputeRepo.Create(dispute); err != nil {
+		return nil, apperrors.Internal("failed to record dispute")
+	}
+
+	// Disputes can be reported for the first time already decided
+	if status != models.DisputeStatusOpen {
+		return dispute, s.transition(dispute, status, "")
+	}
+
+	return dispute, s.notify(dispute)
+}
+
+func (s *DisputeService) ListDisputes(status models.DisputeStatus, page, limit int) (*utils.PaginatedResponse, error) {
+	offset := (page - 1) * limit
+	disputes, err := s.disputeRepo.List(status, limit, offset)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve disputes")
+	}
+
+	total, err := s.disputeRepo.Count(status)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count disputes")
+	}
+
+	return &utils.PaginatedResponse{
+		Success:    true,
+		Message:    "Disputes retrieved successfully",
+		Data:       disputes,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, nil
+}
+
+func (s *DisputeService) GetDispute(disputeID uint) (*models.Dispute, error) {
+	dispute, err := s.disputeRepo.GetByID(disputeID)
+	if err != nil {
+		return nil, apperrors.NotFound("dispute not found")
+	}
+	return dispute, nil
+}
+
+// UpdateDispute lets admins track a dispute, e.g. mark it under review once
+// evidence is submitted, or record the outcome when the provider can't
+func (s *DisputeService) UpdateDispute(disputeID uint, req *UpdateDisputeRequest) (*models.Dispute, error) {
+	dispute, err := s.disputeRepo.GetByID(disputeID)
+	if err != nil {
+		return nil, apperrors.NotFound("dispute not found")
+	}
+
+	if s.isResolved(dispute) {
+		return nil, apperrors.Conflict("dispute is already resolved")
+	}
+
+	if err := s.transition(dispute, req.Status, req.Note); err != nil {
+		return nil, err
+	}
+
+	return dispute, nil
+}
+
+// transition moves a dispute to status. A lost dispute reverses the sale:
+// the charge is marked refunded, the tickets bought with it are invalidated
+// and the seller's share is taken back with a negative revenue row.
+func (s *DisputeService) transition(dispute *models.Dispute, status models.DisputeStatus, note string) error {
+	now := time.Now().Unix()
+	dispute.Status = status
+	dispute.UpdatedAt = now
+	if note != "" {
+		dispute.Note = note
+	}
+	if status == models.DisputeStatusWon || status == models.DisputeStatusLost {
+		dispute.ResolvedAt = &now
+	}
+
+	var sellerDebited float64
+	var invalidatedIDs []uint
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		disputeRepo := s.disputeRepo.WithTx(tx)
+
+		// Only the update that decides the dispute reverses the sale
+		updated, err := disputeRepo.UpdateStatusUnlessResolved(dispute.ID, status)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return apperrors.Conflict("dispute is already resolved")
+		}
+
+		if status == models.DisputeStatusLost {
+			debited, ticketIDs, err := s.reverseSale(tx, dispute, now)
+			if err != nil {
+				return err
+			}
+			sellerDebited, invalidatedIDs = debited, ticketIDs
+		}
+
+		if err := disputeRepo.Update(dispute); err != nil {
+			return err
+		}
+
+		message, err := s.buildMessage(dispute, sellerDebited, invalidatedIDs)
+		if err != nil {
+			return err
+		}
+		return s.outboxRepo.WithTx(tx).Create(message)
+	})
+	if err != nil {
+		if _, ok := apperrors.As(err); ok {
+			return err
+		}
+		return apperrors.Internal("failed to update dispute")
+	}
+
+	return nil
+}
+
+func (s *DisputeService) reverseSale(tx *gorm.DB, dispute *models.Dispute, now int64) (float64, []uint, error) {
+	paymentRepo := s.paymentRepo.WithTx(tx)
+	purchasedTicketRepo := s.purchasedTicketRepo.WithTx(tx)
+
+	payment, err := paymentRepo.GetByID(dispute.PaymentID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	payment.Status = models.PaymentStatusRefunded
+	if err := paymentRepo.Update(payment); err != nil {
+		return 0, nil, err
+	}
+
+	// Tickets point at the order's payment, which is the parent when the
+	// disputed charge was one part of a split payment
+	order := payment
+	if payment.ParentPaymentID != nil {
+		if order, err = paymentRepo.GetByID(*payment.ParentPaymentID); err != nil {
+			return 0, nil, err
+		}
+	}
+	orderPaymentID := order.ID
+
+	tickets, err := purchasedTicketRepo.ListByPayment(orderPaymentID)
+	if err != nil {
+		return 0, nil, err
+	}
+	if _, err := purchasedTicketRepo.InvalidateByPayment(orderPaymentID, now); err != nil {
+		return 0, nil, err
+	}
+
+	ticketIDs := make([]uint, 0, len(tickets))
+	for _, ticket := range tickets {
+		if !ticket.IsInvalidated {
+			ticketIDs = append(ticketIDs, ticket.ID)
+		}
+	}
+
+	if dispute.SellerID == 0 {
+		return 0, ticketIDs, nil
+	}
+
+	debited := roundCents(dispute.Amount * (1 - creditedFeeRate(order)))
+	adjustment := &models.Payment{
+		UserID:      dispute.SellerID,
+		UserType:    models.UserTypeSeller,
+		Date:        now,
+		Type:        models.PaymentTypeCard,
+		Amount:      -debited,
+		Status:      models.PaymentStatusCompleted,
+		Description: fmt.Sprintf("Chargeback on payment #%d", payment.ID),
+		EventID:     dispute.EventID,
+	}
+	if err := paymentRepo.Create(adjustment); err != nil {
+		return 0, nil, err
+	}
+	dispute.AdjustmentID = &adjustment.ID
+
+	return debited, ticketIDs, nil
+}
+
+func (s *DisputeService) notify(dispute *models.Dispute) error {
+	message, err := s.buildMessage(dispute, 0, nil)
+	if err != nil {
+		return err
+	}
+	if err := s.outboxRepo.Create(message); err != nil {
+		return apperrors.Internal("failed to queue dispute notification")
+	}
+	return nil
+}
+
+func (s *DisputeService) buildMessage(dispute *models.Dispute, sellerDebited float64, invalidatedIDs []uint) (*models.OutboxMessage, error) {
+	return outbox.NewMessage(models.OutboxTopicDisputeUpdated, outbox.DisputePayload{
+		DisputeID:            dispute.ID,
+		EventID:              dispute.EventID,
+		SellerID:             dispute.SellerID,
+		PaymentID:            dispute.PaymentID,
+		Amount:               dispute.Amount,
+		Reason:               dispute.Reason,
+		Status:               dispute.Status,
+		SellerDebited:        sellerDebited,
+		InvalidatedTicketIDs: invalidatedIDs,
+	})
+}
+
+func (s *DisputeService) isResolved(dispute *models.Dispute) bool {
+	return dispute.Status == models.DisputeStatusWon || dispute.Status == models.DisputeStatusLost
+}
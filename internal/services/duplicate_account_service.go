@@ -0,0 +1,228 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+)
+
+// Signals that tie customer accounts together in the duplicate report
+const (
+	DuplicateSignalCard   = "card"   // The same stored card token
+	DuplicateSignalIP     = "ip"     // Requests from the same IP
+	DuplicateSignalDevice = "device" // Payments from the same device fingerprint
+)
+
+// DuplicateAccountService finds customer accounts that are probably run by
+// the same person, such as scalpers buying past per-account limits or
+// promo abuse
+type DuplicateAccountService struct {
+	userRepo          repositories.UserRepository
+	paymentMethodRepo repositories.PaymentMethodRepository
+	paymentRepo       repositories.PaymentRepository
+	auditRepo         repositories.AuditLogRepository
+}
+
+// DuplicateAccountFilter sets the period IPs are compared over and how
+// many accounts an IP needs before it counts
+type DuplicateAccountFilter struct {
+	From          int64
+	To            int64
+	MinIPAccounts int
+	Limit         int // Most shared values per signal
+}
+
+// DuplicateAccountReport lists what accounts share, and the accounts with
+// the most links first
+type DuplicateAccountReport struct {
+	From     int64            `json:"from"`
+	To       int64            `json:"to"`
+	Groups   []AccountGroup   `json:"groups"`
+	Accounts []SuspectAccount `json:"accounts"`
+}
+
+// AccountGroup is a value shared by several accounts
+type AccountGroup struct {
+	Signal  string `json:"signal"`
+	Value   string `json:"value"` // The IP, the device fingerprint, or the card's brand and last digits; never the token
+	UserIDs []uint `json:"user_ids"`
+}
+
+type SuspectAccount struct {
+	UserID        uint     `json:"user_id"`
+	Username      string   `json:"username"`
+	Email         string   `json:"email"`
+	Signals       []string `json:"signals"`         // e.g. "card visa *4242", "ip 203.0.113.7"
+	LinkedUserIDs []uint   `json:"linked_user_ids"` // Accounts sharing any signal with this one
+}
+
+func NewDuplicateAccountService(
+	userRepo repositories.UserRepository,
+	paymentMethodRepo repositories.PaymentMethodRepository,
+	paymentRepo repositories.PaymentRepository,
+	auditRepo repositories.AuditLogRepository,
+) *DuplicateAccountService {
+	return &DuplicateAccountService{
+		userRepo:          userRepo,
+		paymentMethodRepo: paymentMethodRepo,
+		paymentRepo:       paymentRepo,
+		auditRepo:         auditRepo,
+	}
+}
+
+// GetReport builds the duplicate account report. Card tokens count however
+// old they are; IPs and devices only within the filter's period.
+func (s *DuplicateAccountService) GetReport(filter DuplicateAccountFilter) (*DuplicateAccountReport, error) {
+	if filter.To < filter.From {
+		return nil, apperrors.Validation("from must be before to")
+	}
+	if filter.MinIPAccounts < 2 {
+		return nil, apperrors.Validation("min_ip_accounts must be at least 2")
+	}
+
+	report := &DuplicateAccountReport{
+		From:     filter.From,
+		To:       filter.To,
+		Groups:   []AccountGroup{},
+		Accounts: []SuspectAccount{},
+	}
+
+	methods, err := s.paymentMethodRepo.ListSharedCards(2, filter.Limit)
+	if err != nil {
+		return nil, apperrors.Internal("failed to compare stored cards")
+	}
+	report.Groups = append(report.Groups, cardGroups(methods)...)
+
+	ipAccounts, err := s.auditRepo.ListSharedIPs(filter.From, filter.To, filter.MinIPAccounts, filter.Limit)
+	if err != nil {
+		return nil, apperrors.Internal("failed to compare request IPs")
+	}
+	report.Groups = append(report.Groups, ipGroups(ipAccounts)...)
+
+	deviceAccounts, err := s.paymentRepo.ListSharedDevices(filter.From, filter.To, 2, filter.Limit)
+	if err != nil {
+		return nil, apperrors.Internal("failed to compare payment devices")
+	}
+	report.Groups = append(report.Groups, deviceGroups(deviceAccounts)...)
+
+	if err := s.addAccounts(report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// addAccounts turns the report's groups into one entry per account
+func (s *DuplicateAccountService) addAccounts(report *DuplicateAccountReport) error {
+	accounts := make(map[uint]*SuspectAccount)
+	linked := make(map[uint]map[uint]bool)
+	var userIDs []uint
+
+	for _, group := range report.Groups {
+		for _, userID := range group.UserIDs {
+			account, ok := accounts[userID]
+			if !ok {
+				account = &SuspectAccount{UserID: userID}
+				accounts[userID] = account
+				linked[userID] = make(map[uint]bool)
+				userIDs = append(userIDs, userID)
+			}
+			account.Signals = append(account.Signals, group.Signal+" "+group.Value)
+			for _, otherID := range group.UserIDs {
+				if otherID != userID {
+					linked[userID][otherID] = true
+				}
+			}
+		}
+	}
+
+	users, err := s.userRepo.ListByIDs(userIDs)
+	if err != nil {
+		return apperrors.Internal("failed to load flagged accounts")
+	}
+	for _, user := range users {
+		account := accounts[user.ID]
+		account.Username = user.Username
+		account.Email = user.Email
+	}
+
+	for _, userID := range userIDs {
+		account := accounts[userID]
+		account.LinkedUserIDs = make([]uint, 0, len(linked[userID]))
+		for otherID := range linked[userID] {
+			account.LinkedUserIDs = append(account.LinkedUserIDs, otherID)
+		}
+		sort.Slice(account.LinkedUserIDs, func(i, j int) bool { return account.LinkedUserIDs[i] < account.LinkedUserIDs[j] })
+		report.Accounts = append(report.Accounts, *account)
+	}
+
+	sort.SliceStable(report.Accounts, func(i, j int) bool {
+		a, b := report.Accounts[i], report.Accounts[j]
+		if len(a.LinkedUserIDs) != len(b.LinkedUserIDs) {
+			return len(a.LinkedUserIDs) > len(b.LinkedUserIDs)
+		}
+		return len(a.Signals) > len(b.Signals)
+	})
+	return nil
+}
+
+// cardGroups groups stored cards by provider and token
+func cardGroups(methods []models.PaymentMethod) []AccountGroup {
+	var groups []AccountGroup
+	index := make(map[string]int)
+	seen := make(map[string]bool)
+
+	for _, method := range methods {
+		key := method.Provider + "\x00" + method.Token
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, AccountGroup{
+				Signal: DuplicateSignalCard,
+				Value:  fmt.Sprintf("%s *%s", method.Brand, method.Last4),
+			})
+		}
+		if userKey := fmt.Sprintf("%s\x00%d", key, method.UserID); !seen[userKey] {
+			seen[userKey] = true
+			groups[i].UserIDs = append(groups[i].UserIDs, method.UserID)
+		}
+	}
+	return groups
+}
+
+// ipGroups groups accounts by the IP they were seen from
+func ipGroups(accounts []repositories.IPAccount) []AccountGroup {
+	var groups []AccountGroup
+	index := make(map[string]int)
+
+	for _, account := range accounts {
+		i, ok := index[account.IP]
+		if !ok {
+			i = len(groups)
+			index[account.IP] = i
+			groups = append(groups, AccountGroup{Signal: DuplicateSignalIP, Value: account.IP})
+		}
+		groups[i].UserIDs = append(groups[i].UserIDs, account.UserID)
+	}
+	return groups
+}
+
+// deviceGroups groups accounts by the device they paid from
+func deviceGroups(accounts []repositories.DeviceAccount) []AccountGroup {
+	var groups []AccountGroup
+	index := make(map[string]int)
+
+	for _, account := range accounts {
+		i, ok := index[account.Fingerprint]
+		if !ok {
+			i = len(groups)
+			index[account.Fingerprint] = i
+			groups = append(groups, AccountGroup{Signal: DuplicateSignalDevice, Value: account.Fingerprint})
+		}
+		groups[i].UserIDs = append(groups[i].UserIDs, account.UserID)
+	}
+	return groups
+}
@@ -0,0 +1,17 @@
+package services
+
+import apperrors "eticketing/pkg/errors"
+
+// Errors on the purchase path carry codes so clients can react to them
+// without parsing messages
+var (
+	ErrSaleNotActive        = apperrors.New(apperrors.CodeSaleNotActive, "sale is not currently active")
+	ErrEventNotOnSale       = apperrors.New(apperrors.CodeEventNotOnSale, "event is not approved for ticket sales")
+	ErrTicketsSoldOut       = apperrors.New(apperrors.CodeTicketsSoldOut, "not enough tickets available")
+	ErrSaleAllocationSold   = apperrors.New(apperrors.CodeTicketsSoldOut, "sale allocation for this ticket group is sold out")
+	ErrTicketUnavailable    = apperrors.New(apperrors.CodeTicketUnavailable, "ticket is not available")
+	ErrOrderNotPayable      = apperrors.New(apperrors.CodeOrderNotPayable, "order is not awaiting payment")
+	ErrPaymentWindowExpired = apperrors.New(apperrors.CodePaymentWindowExpired, "payment window has expired and the tickets were released")
+	ErrNoPurchaseRight      = apperrors.New(apperrors.CodeNoPurchaseRight, "only drawn winners can buy in this lottery sale")
+	ErrPurchaseDeclined     = apperrors.New(apperrors.CodePurchaseDeclined, "this purchase was declined by our fraud checks")
+)
@@ -0,0 +1,270 @@
+// internal/services/event_member_service.go
+package services
+
+import (
+	"errors"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// eventPermissionNames are the names permissions go by in requests and
+// responses
+var eventPermissionNames = []struct {
+	permission models.EventPermission
+	name       string
+}{
+	{models.EventPermissionEditEvent, "edit_event"},
+	{models.EventPermissionManageTickets, "manage_tickets"},
+	{models.EventPermissionViewSales, "view_sales"},
+}
+
+// EventAccess decides what a seller may do with an event: anything when
+// they own it, and what their membership grants when they co-manage it
+type EventAccess struct {
+	memberRepo repositories.EventMemberRepository
+}
+
+func NewEventAccess(memberRepo repositories.EventMemberRepository) *EventAccess {
+	return &EventAccess{memberRepo: memberRepo}
+}
+
+func (a *EventAccess) Allowed(event *models.Event, sellerID uint, permission models.EventPermission) bool {
+	if event.SellerID == sellerID {
+		return true
+	}
+
+	member, err := a.memberRepo.Get(event.ID, sellerID)
+	if err != nil {
+		return false
+	}
+	return member.Permissions&permission != 0
+}
+
+type EventMemberService struct {
+	memberRepo repositories.EventMemberRepository
+	eventRepo  repositories.EventRepository
+	sellerRepo repositories.SellerRepository
+}
+
+type AddEventMemberRequest struct {
+	Email       string   `json:"email" binding:"required,email"` // The collaborator's seller account
+	Permissions []string `json:"permissions" binding:"required,min=1,dive,oneof=edit_event manage_tickets view_sales"`
+}
+
+type UpdateEventMemberRequest struct {
+	Permissions []string `json:"permissions" binding:"required,min=1,dive,oneof=edit_event manage_tickets view_sales"`
+}
+
+type EventMemberResponse struct {
+	SellerID    uint     `json:"seller_id"`
+	Username    string   `json:"username"`
+	Email       string   `json:"email"`
+	DisplayName string   `json:"display_name"`
+	Permissions []string `json:"permissions"`
+	InvitedBy   uint     `json:"invited_by"`
+	CreatedAt   int64    `json:"created_at"`
+}
+
+// SharedEventResponse is an event a seller co-manages
+type SharedEventResponse struct {
+	EventID     uint               `json:"event_id"`
+	Title       string             `json:"title"`
+	Date        int64              `json:"date"`
+	Status      models.EventStatus `json:"status"`
+	OwnerID     uint               `json:"owner_id"`
+	Permissions []string           `json:"permissions"`
+}
+
+func NewEventMemberService(
+	memberRepo repositories.EventMemberRepository,
+	eventRepo repositories.EventRepository,
+	sellerRepo repositories.SellerRepository,
+) *EventMemberService {
+	return &EventMemberService{
+		memberRepo: memberRepo,
+		eventRepo:  eventRepo,
+		sellerRepo: sellerRepo,
+	}
+}
+
+// AddMember lets the event's owner bring in another seller
+func (s *EventMemberService) AddMember(eventID, ownerID uint, req *AddEventMemberRequest) (*EventMemberResponse, error) {
+	if _, err := s.getOwnedEvent(eventID, ownerID); err != nil {
+		return nil, err
+	}
+
+	seller, err := s.sellerRepo.GetByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound("seller not found")
+		}
+		return nil, apperrors.Internal("failed to find seller")
+	}
+	if seller.ID == ownerID {
+		return nil, apperrors.Validation("the event owner is already a manager")
+	}
+
+	if _, err := s.memberRepo.Get(eventID, seller.ID); err == nil {
+		return nil, apperrors.Conflict("seller already co-manages this event")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.Internal("failed to check membership")
+	}
+
+	member := &models.EventMember{
+		EventID:     eventID,
+		SellerID:    seller.ID,
+		Permissions: parseEventPermissions(req.Permissions),
+		InvitedBy:   ownerID,
+		CreatedAt:   time.Now().Unix(),
+	}
+	if err := s.memberRepo.Create(member); err != nil {
+		return nil, apperrors.Internal("failed to add event member")
+	}
+	member.Seller = *seller
+
+	response := eventMemberToResponse(member)
+	return &response, nil
+}
+
+func (s *EventMemberService) UpdateMember(eventID, memberID, ownerID uint, req *UpdateEventMemberRequest) (*EventMemberResponse, error) {
+	if _, err := s.getOwnedEvent(eventID, ownerID); err != nil {
+		return nil, err
+	}
+
+	member, err := s.memberRepo.Get(eventID, memberID)
+	if err != nil {
+		return nil, apperrors.NotFound("event member not found")
+	}
+
+	member.Permissions = parseEventPermissions(req.Permissions)
+	if err := s.memberRepo.Update(member); err != nil {
+		return nil, apperrors.Internal("failed to update event member")
+	}
+
+	if seller, err := s.sellerRepo.GetByID(memberID); err == nil {
+		member.Seller = *seller
+	}
+
+	response := eventMemberToResponse(member)
+	return &response, nil
+}
+
+// RemoveMember takes a seller off an event. The owner can remove anyone;
+// a member can only remove themselves.
+func (s *EventMemberService) RemoveMember(eventID, memberID, sellerID uint) error {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return apperrors.NotFound("event not found")
+	}
+	if event.SellerID != sellerID && memberID != sellerID {
+		return apperrors.Forbidden("unauthorized to manage members of this event")
+	}
+
+	removed, err := s.memberRepo.Delete(eventID, memberID)
+	if err != nil {
+		return apperrors.Internal("failed to remove event member")
+	}
+	if !removed {
+		return apperrors.NotFound("event member not found")
+	}
+
+	return nil
+}
+
+// ListMembers is visible to the owner and to every member
+func (s *EventMemberService) ListMembers(eventID, sellerID uint) ([]EventMemberResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, apperrors.NotFound("event not found")
+	}
+
+	members, err := s.memberRepo.ListByEvent(eventID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve event members")
+	}
+
+	isMember := event.SellerID == sellerID
+	responses := make([]EventMemberResponse, 0, len(members))
+	for i := range members {
+		if members[i].SellerID == sellerID {
+			isMember = true
+		}
+		responses = append(responses, eventMemberToResponse(&members[i]))
+	}
+	if !isMember {
+		return nil, apperrors.Forbidden("unauthorized to view members of this event")
+	}
+
+	return responses, nil
+}
+
+// ListSharedEvents lists the events the seller co-manages but doesn't own
+func (s *EventMemberService) ListSharedEvents(sellerID uint) ([]SharedEventResponse, error) {
+	members, err := s.memberRepo.ListBySeller(sellerID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve shared events")
+	}
+
+	responses := make([]SharedEventResponse, 0, len(members))
+	for _, member := range members {
+		responses = append(responses, SharedEventResponse{
+			EventID:     member.EventID,
+			Title:       member.Event.Title,
+			Date:        member.Event.Date,
+			Status:      member.Event.Status,
+			OwnerID:     member.Event.SellerID,
+			Permissions: eventPermissionList(member.Permissions),
+		})
+	}
+
+	return responses, nil
+}
+
+func (s *EventMemberService) getOwnedEvent(eventID, sellerID uint) (*models.Event, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, apperrors.NotFound("event not found")
+	}
+	if event.SellerID != sellerID {
+		return nil, apperrors.Forbidden("unauthorized to manage members of this event")
+	}
+	return event, nil
+}
+
+func eventMemberToResponse(member *models.EventMember) EventMemberResponse {
+	return EventMemberResponse{
+		SellerID:    member.SellerID,
+		Username:    member.Seller.Username,
+		Email:       member.Seller.Email,
+		DisplayName: member.Seller.DisplayName,
+		Permissions: eventPermissionList(member.Permissions),
+		InvitedBy:   member.InvitedBy,
+		CreatedAt:   member.CreatedAt,
+	}
+}
+
+func parseEventPermissions(names []string) models.EventPermission {
+	var permissions models.EventPermission
+	for _, name := range names {
+		for _, p := range eventPermissionNames {
+			if p.name == name {
+				permissions |= p.permission
+			}
+		}
+	}
+	return permissions
+}
+
+func eventPermissionList(permissions models.EventPermission) []string {
+	names := []string{}
+	for _, p := range eventPermissionNames {
+		if permissions&p.permission != 0 {
+			names = append(names, p.name)
+		}
+	}
+	return names
+}
@@ -2,60 +2,235 @@
 package services
 
 import (
-	"errors"
+	"context"
+	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"eticketing/internal/models"
+	"eticketing/internal/moderation"
+	"eticketing/internal/outbox"
 	"eticketing/internal/repositories"
 	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
 )
 
 type EventService struct {
-	eventRepo  repositories.EventRepository
-	ticketRepo repositories.TicketRepository
+	eventRepo      repositories.EventRepository
+	access         *EventAccess
+	ticketRepo     repositories.TicketRepository
+	venueRepo      repositories.VenueRepository
+	saleRepo       repositories.SaleRepository
+	outboxRepo     repositories.OutboxRepository
+	txManager      repositories.TransactionManager
+	pricingService *PricingService
+	moderator      *moderation.Moderator
 }
 
 type CreateEventRequest struct {
-	Title       string `json:"title" binding:"required"`
-	Description string `json:"description" binding:"required"`
-	Date        int64  `json:"date" binding:"required"`
-	Address     string `json:"address" binding:"required"`
-	Data        string `json:"data"`
-	SellerID    uint   `json:"-"` // Set by handler
+	Title       string               `json:"title" binding:"required"`
+	Description string               `json:"description" binding:"required"`
+	Date        int64                `json:"date" binding:"required,unixtime"`
+	Address     string               `json:"address" binding:"required_without=VenueID"` // Defaults to the venue's address
+	Data        *EventDetailsRequest `json:"data"`
+	SellerID    uint                 `json:"-"`                        // Set by handler
+	Capacity    int                  `json:"capacity" binding:"min=0"` // 0 = unlimited, or the venue's capacity
+	VenueID     *uint                `json:"venue_id"`
+
+	// Map position; defaults to the venue's when omitted
+	Latitude  *float64 `json:"latitude" binding:"required_with=Longitude,omitempty,latitude"`
+	Longitude *float64 `json:"longitude" binding:"required_with=Latitude,omitempty,longitude"`
+
+	// Organization order limits; 0 uses the platform defaults
+	BulkMaxQuantity       int `json:"bulk_max_quantity" binding:"min=0"`
+	BulkApprovalThreshold int `json:"bulk_approval_threshold" binding:"min=0"`
+
+	// Charged on every accepted transfer; the recipient pays unless the
+	// payer is 2 (sender)
+	TransferFee      float64                 `json:"transfer_fee" binding:"min=0"`
+	TransferFeePayer models.TransferFeePayer `json:"transfer_fee_payer" binding:"omitempty,oneof=1 2"`
+
+	// Photo ID must match the ticket holder's name at the door
+	IDCheckRequired bool `json:"id_check_required"`
+
+	// Refund requests close this many hours before the event; 0 uses the
+	// platform default
+	RefundCutoffHours int `json:"refund_cutoff_hours" binding:"min=0,max=8760"`
+
+	// Sends buyers through a waiting room; the rate defaults to the
+	// platform's when 0
+	QueueEnabled        bool `json:"queue_enabled"`
+	QueueAdmitPerMinute int  `json:"queue_admit_per_minute" binding:"min=0,max=10000"`
+
+	// IANA zone such as "Europe/Kyiv"; defaults to UTC
+	Timezone string `json:"timezone" binding:"omitempty,timezone"`
+
+	// Keeps the event out of public listings until this time once approved
+	PublishAt       *int64 `json:"publish_at" binding:"omitempty,unixtime"`
+	NotifyFollowers bool   `json:"notify_followers"`
+
+	// Creates the event's sale along with it; both are created or neither is
+	DefaultSale *DefaultSaleRequest `json:"default_sale"`
+}
+
+// EventDetailsRequest is the structured part of an event page. Every field
+// is optional.
+type EventDetailsRequest struct {
+	Lineup      []string           `json:"lineup" binding:"max=50,dive,required,max=200"`
+	AgeLimit    *int               `json:"age_limit" binding:"omitempty,min=0,max=99"`
+	DoorsOpenAt *int64             `json:"doors_open_at" binding:"omitempty,unixtime"`
+	Links       []EventLinkRequest `json:"links" binding:"max=20,dive"`
+}
+
+type EventLinkRequest struct {
+	Label string `json:"label" binding:"required,max=100"`
+	URL   string `json:"url" binding:"required,http_url,max=500"`
+}
+
+func (r *EventDetailsRequest) toModel() models.EventDetails {
+	details := models.EventDetails{
+		AgeLimit:    r.AgeLimit,
+		DoorsOpenAt: r.DoorsOpenAt,
+	}
+	for _, name := range r.Lineup {
+		details.Lineup = append(details.Lineup, utils.SanitizeString(name))
+	}
+	for _, link := range r.Links {
+		details.Links = append(details.Links, models.EventLink{
+			Label: utils.SanitizeString(link.Label),
+			URL:   link.URL,
+		})
+	}
+	return details
+}
+
+// DefaultSaleRequest is a sale created with its event. Omitted dates run the
+// sale from creation until the event starts.
+type DefaultSaleRequest struct {
+	StartDate int64 `json:"start_date" binding:"omitempty,unixtime"`
+	EndDate   int64 `json:"end_date" binding:"omitempty,unixtime"`
 }
 
 type UpdateEventRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Date        int64  `json:"date"`
-	Address     string `json:"address"`
-	Data        string `json:"data"`
+	Title       string               `json:"title"`
+	Description string               `json:"description"`
+	Date        int64                `json:"date" binding:"omitempty,unixtime"`
+	Address     string               `json:"address"`
+	Data        *EventDetailsRequest `json:"data"` // Replaces the stored details as a whole
+	Capacity    *int                 `json:"capacity" binding:"omitempty,min=0"`
+	VenueID     *uint                `json:"venue_id"` // 0 detaches the event from its venue
+
+	Latitude  *float64 `json:"latitude" binding:"required_with=Longitude,omitempty,latitude"`
+	Longitude *float64 `json:"longitude" binding:"required_with=Latitude,omitempty,longitude"`
+
+	BulkMaxQuantity       *int `json:"bulk_max_quantity" binding:"omitempty,min=0"`
+	BulkApprovalThreshold *int `json:"bulk_approval_threshold" binding:"omitempty,min=0"`
+
+	TransferFee      *float64                 `json:"transfer_fee" binding:"omitempty,min=0"`
+	TransferFeePayer *models.TransferFeePayer `json:"transfer_fee_payer" binding:"omitempty,oneof=1 2"`
+
+	IDCheckRequired *bool `json:"id_check_required"`
+
+	RefundCutoffHours *int `json:"refund_cutoff_hours" binding:"omitempty,min=0,max=8760"`
+
+	QueueEnabled        *bool `json:"queue_enabled"`
+	QueueAdmitPerMinute *int  `json:"queue_admit_per_minute" binding:"omitempty,min=0,max=10000"`
+
+	Timezone string `json:"timezone" binding:"omitempty,timezone"`
+
+	PublishAt       *int64 `json:"publish_at" binding:"omitempty,min=0"` // 0 publishes as soon as the event is approved
+	NotifyFollowers *bool  `json:"notify_followers"`
 }
 
 type EventResponse struct {
-	ID               uint               `json:"id"`
-	Title            string             `json:"title"`
-	Description      string             `json:"description"`
-	Date             int64              `json:"date"`
-	Address          string             `json:"address"`
-	Data             string             `json:"data"`
-	Status           models.EventStatus `json:"status"`
-	SellerID         uint               `json:"seller_id"`
-	SellerName       string             `json:"seller_name"`
-	AvailableTickets int64              `json:"available_tickets"`
-}
-
-func NewEventService(eventRepo repositories.EventRepository, ticketRepo repositories.TicketRepository) *EventService {
+	ID               uint                `json:"id"`
+	Title            string              `json:"title"`
+	Description      string              `json:"description"`
+	Date             int64               `json:"date"`
+	Address          string              `json:"address"`
+	Data             models.EventDetails `json:"data"`
+	Status           models.EventStatus  `json:"status"`
+	SellerID         uint                `json:"seller_id"`
+	SellerName       string              `json:"seller_name"`
+	AvailableTickets int64               `json:"available_tickets"`
+	Capacity         int                 `json:"capacity"`
+	VenueID          *uint               `json:"venue_id"`
+	Venue            *models.Venue       `json:"venue,omitempty"`
+	Latitude         *float64            `json:"latitude"`
+	Longitude        *float64            `json:"longitude"`
+	DistanceKm       *float64            `json:"distance_km,omitempty"` // Set by nearby search
+	Flagged          bool                `json:"flagged"`
+	FlagReasons      string              `json:"flag_reasons,omitempty"`
+	PublishAt        *int64              `json:"publish_at"`
+	NotifyFollowers  bool                `json:"notify_followers"`
+
+	BulkMaxQuantity       int `json:"bulk_max_quantity"`
+	BulkApprovalThreshold int `json:"bulk_approval_threshold"`
+
+	TransferFee      float64                 `json:"transfer_fee"`
+	TransferFeePayer models.TransferFeePayer `json:"transfer_fee_payer"`
+
+	IDCheckRequired bool `json:"id_check_required"`
+
+	RefundCutoffHours int `json:"refund_cutoff_hours"`
+
+	QueueEnabled        bool `json:"queue_enabled"`
+	QueueAdmitPerMinute int  `json:"queue_admit_per_minute"`
+
+	// Date in the event's zone as ISO 8601 with its UTC offset
+	Timezone string `json:"timezone"`
+	DateISO  string `json:"date_iso"`
+
+	DefaultSale *SaleWindow `json:"default_sale,omitempty"` // Set when created with the event
+}
+
+// EventDetailResponse is a single event with everything an event card needs:
+// the price range, sale schedule and whether anything is left to buy
+type EventDetailResponse struct {
+	EventResponse
+	MinPrice   *float64    `json:"min_price"` // Current prices of available tickets; nil when none are
+	MaxPrice   *float64    `json:"max_price"`
+	ActiveSale *SaleWindow `json:"active_sale"`
+	NextSale   *SaleWindow `json:"next_sale"`
+	IsSoldOut  bool        `json:"is_sold_out"`
+}
+
+type SaleWindow struct {
+	ID        uint  `json:"id"`
+	StartDate int64 `json:"start_date"`
+	EndDate   int64 `json:"end_date"`
+}
+
+func NewEventService(
+	eventRepo repositories.EventRepository,
+	access *EventAccess,
+	ticketRepo repositories.TicketRepository,
+	venueRepo repositories.VenueRepository,
+	saleRepo repositories.SaleRepository,
+	outboxRepo repositories.OutboxRepository,
+	txManager repositories.TransactionManager,
+	pricingService *PricingService,
+	moderator *moderation.Moderator,
+) *EventService {
 	return &EventService{
-		eventRepo:  eventRepo,
-		ticketRepo: ticketRepo,
+		eventRepo:      eventRepo,
+		access:         access,
+		ticketRepo:     ticketRepo,
+		venueRepo:      venueRepo,
+		saleRepo:       saleRepo,
+		outboxRepo:     outboxRepo,
+		txManager:      txManager,
+		pricingService: pricingService,
+		moderator:      moderator,
 	}
 }
 
-func (s *EventService) CreateEvent(req *CreateEventRequest) (*EventResponse, error) {
+func (s *EventService) CreateEvent(ctx context.Context, req *CreateEventRequest) (*EventResponse, error) {
 	// Validate event date is in the future
 	if req.Date <= time.Now().Unix() {
-		return nil, errors.New("event date must be in the future")
+		return nil, apperrors.Validation("event date must be in the future")
 	}
 
 	event := &models.Event{
@@ -63,28 +238,121 @@ func (s *EventService) CreateEvent(req *CreateEventRequest) (*EventResponse, err
 		Description: utils.SanitizeString(req.Description),
 		Date:        req.Date,
 		Address:     utils.SanitizeString(req.Address),
-		Data:        req.Data,
 		SellerID:    req.SellerID,
 		Status:      models.EventStatusPending,
+		Capacity:    req.Capacity,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+
+		BulkMaxQuantity:       req.BulkMaxQuantity,
+		BulkApprovalThreshold: req.BulkApprovalThreshold,
+		NotifyFollowers:       req.NotifyFollowers,
+		TransferFee:           roundCents(req.TransferFee),
+		TransferFeePayer:      req.TransferFeePayer,
+		IDCheckRequired:       req.IDCheckRequired,
+		RefundCutoffHours:     req.RefundCutoffHours,
+		Timezone:              req.Timezone,
+		QueueEnabled:          req.QueueEnabled,
+		QueueAdmitPerMinute:   req.QueueAdmitPerMinute,
+	}
+	if event.Timezone == "" {
+		event.Timezone = defaultEventTimezone
+	}
+	if event.TransferFeePayer == 0 {
+		event.TransferFeePayer = models.TransferFeePayerRecipient
 	}
 
-	if err := s.eventRepo.Create(event); err != nil {
-		return nil, errors.New("failed to create event")
+	if req.Data != nil {
+		event.Data = req.Data.toModel()
+		if err := validateEventDetails(&event.Data, event.Date); err != nil {
+			return nil, err
+		}
 	}
 
-	return s.eventToResponse(event), nil
+	if req.PublishAt != nil {
+		if err := s.schedulePublication(event, *req.PublishAt); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.VenueID != nil {
+		if err := s.attachVenue(event, *req.VenueID); err != nil {
+			return nil, err
+		}
+		if err := s.checkVenueCapacity(event); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.moderate(ctx, event); err != nil {
+		return nil, err
+	}
+
+	if req.DefaultSale == nil {
+		if err := s.eventRepo.Create(event); err != nil {
+			return nil, apperrors.Internal("failed to create event")
+		}
+		return s.eventToResponse(event), nil
+	}
+
+	sale, err := s.newDefaultSale(event, req.DefaultSale)
+	if err != nil {
+		return nil, err
+	}
+
+	// The sale opens once the event is approved, so followers are told about
+	// the event rather than about this sale
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.eventRepo.WithTx(tx).Create(event); err != nil {
+			return err
+		}
+		sale.EventID = event.ID
+		return s.saleRepo.WithTx(tx).Create(sale)
+	})
+	if err != nil {
+		return nil, apperrors.Internal("failed to create event and sale")
+	}
+
+	response := s.eventToResponse(event)
+	response.DefaultSale = &SaleWindow{ID: sale.ID, StartDate: sale.StartDate, EndDate: sale.EndDate}
+	return response, nil
+}
+
+// newDefaultSale validates the sale requested alongside a new event
+func (s *EventService) newDefaultSale(event *models.Event, req *DefaultSaleRequest) (*models.Sale, error) {
+	now := time.Now().Unix()
+
+	startDate := req.StartDate
+	if startDate == 0 {
+		startDate = now
+	} else if startDate <= now {
+		return nil, apperrors.Validation("sale start date must be in the future")
+	}
+
+	endDate := req.EndDate
+	if endDate == 0 {
+		endDate = event.Date
+	}
+	if endDate <= startDate {
+		return nil, apperrors.Validation("sale end date must be after start date")
+	}
+	if endDate > event.Date {
+		return nil, apperrors.Validation("sale cannot end after the event starts")
+	}
+
+	return &models.Sale{StartDate: startDate, EndDate: endDate}, nil
 }
 
 func (s *EventService) GetEvents(page, limit int) (*utils.PaginatedResponse, error) {
 	offset := (page - 1) * limit
-	events, err := s.eventRepo.ListByStatus(models.EventStatusApproved, limit, offset)
+	events, err := s.eventRepo.ListPublished(limit, offset)
 	if err != nil {
-		return nil, errors.New("failed to retrieve events")
+		return nil, apperrors.Internal("failed to retrieve events")
 	}
 
-	total, err := s.eventRepo.CountByStatus(models.EventStatusApproved)
+	total, err := s.eventRepo.CountPublished()
 	if err != nil {
-		return nil, errors.New("failed to count events")
+		return nil, apperrors.Internal("failed to count events")
 	}
 
 	var eventResponses []EventResponse
@@ -109,12 +377,12 @@ func (s *EventService) GetEventsByStatus(status models.EventStatus, page, limit
 	offset := (page - 1) * limit
 	events, err := s.eventRepo.ListByStatusReverse(status, limit, offset)
 	if err != nil {
-		return nil, errors.New("failed to retrieve events")
+		return nil, apperrors.Internal("failed to retrieve events")
 	}
 
 	total, err := s.eventRepo.CountByStatus(status)
 	if err != nil {
-		return nil, errors.New("failed to count events")
+		return nil, apperrors.Internal("failed to count events")
 	}
 
 	var eventResponses []EventResponse
@@ -139,7 +407,7 @@ func (s *EventService) GetEventsBySeller(sellerID uint, page, limit int) (*utils
 	offset := (page - 1) * limit
 	events, err := s.eventRepo.ListBySeller(sellerID, limit, offset)
 	if err != nil {
-		return nil, errors.New("failed to retrieve seller events")
+		return nil, apperrors.Internal("failed to retrieve seller events")
 	}
 
 	// Count total events for seller
@@ -165,28 +433,79 @@ func (s *EventService) GetEventsBySeller(sellerID uint, page, limit int) (*utils
 	}, nil
 }
 
-func (s *EventService) GetEventByID(eventID uint) (*EventResponse, error) {
+func (s *EventService) GetEventByID(eventID uint) (*EventDetailResponse, error) {
 	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
-		return nil, errors.New("event not found")
+		return nil, apperrors.NotFound("event not found")
 	}
 
 	availableTickets, _ := s.ticketRepo.CountAvailableByEvent(event.ID)
-	response := s.eventToResponse(event)
+	response := &EventDetailResponse{EventResponse: *s.eventToResponse(event)}
 	response.AvailableTickets = availableTickets
 
+	groups, err := s.ticketRepo.ListAvailableGroupedByEvent(event.ID, -1, 0)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve tickets")
+	}
+	if err := s.pricingService.ApplyCurrentPrices(groups); err != nil {
+		return nil, apperrors.Internal("failed to price tickets")
+	}
+	for _, group := range groups {
+		price := group.CurrentPrice
+		if response.MinPrice == nil || price < *response.MinPrice {
+			response.MinPrice = &price
+		}
+		if response.MaxPrice == nil || price > *response.MaxPrice {
+			response.MaxPrice = &price
+		}
+	}
+
+	// An event without tickets yet isn't sold out, just not on sale
+	if availableTickets == 0 {
+		totalTickets, err := s.ticketRepo.CountByEvent(event.ID)
+		if err != nil {
+			return nil, apperrors.Internal("failed to count tickets")
+		}
+		response.IsSoldOut = totalTickets > 0
+	}
+
+	sales, err := s.saleRepo.ListByEvent(event.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve sales")
+	}
+	response.ActiveSale, response.NextSale = saleSchedule(sales, time.Now().Unix())
+
 	return response, nil
 }
 
-func (s *EventService) UpdateEvent(eventID, sellerID uint, req *UpdateEventRequest) (*EventResponse, error) {
+// saleSchedule picks the sale running at now and the earliest one still to
+// start. When sales overlap, the one ending soonest counts as active.
+func saleSchedule(sales []models.Sale, now int64) (active, next *SaleWindow) {
+	for _, sale := range sales {
+		window := &SaleWindow{ID: sale.ID, StartDate: sale.StartDate, EndDate: sale.EndDate}
+		switch {
+		case sale.StartDate <= now && now < sale.EndDate:
+			if active == nil || sale.EndDate < active.EndDate {
+				active = window
+			}
+		case sale.StartDate > now:
+			if next == nil || sale.StartDate < next.StartDate {
+				next = window
+			}
+		}
+	}
+	return active, next
+}
+
+func (s *EventService) UpdateEvent(ctx context.Context, eventID, sellerID uint, req *UpdateEventRequest) (*EventResponse, error) {
 	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
-		return nil, errors.New("event not found")
+		return nil, apperrors.NotFound("event not found")
 	}
 
-	// Check if seller owns the event
-	if event.SellerID != sellerID {
-		return nil, errors.New("unauthorized to update this event")
+	// Check if seller owns or co-manages the event
+	if !s.access.Allowed(event, sellerID, models.EventPermissionEditEvent) {
+		return nil, apperrors.Forbidden("unauthorized to update this event")
 	}
 
 	// Update fields if provided
@@ -198,19 +517,98 @@ func (s *EventService) UpdateEvent(eventID, sellerID uint, req *UpdateEventReque
 	}
 	if req.Date != 0 {
 		if req.Date <= time.Now().Unix() {
-			return nil, errors.New("event date must be in the future")
+			return nil, apperrors.Validation("event date must be in the future")
 		}
 		event.Date = req.Date
 	}
 	if req.Address != "" {
 		event.Address = utils.SanitizeString(req.Address)
 	}
-	if req.Data != "" {
-		event.Data = req.Data
+	if req.Data != nil {
+		event.Data = req.Data.toModel()
+	}
+	if req.Data != nil || req.Date != 0 {
+		if err := validateEventDetails(&event.Data, event.Date); err != nil {
+			return nil, err
+		}
+	}
+	if req.VenueID != nil {
+		if *req.VenueID == 0 {
+			event.VenueID = nil
+			event.Venue = nil
+		} else if err := s.attachVenue(event, *req.VenueID); err != nil {
+			return nil, err
+		}
+	}
+	if req.Latitude != nil && req.Longitude != nil {
+		event.Latitude = req.Latitude
+		event.Longitude = req.Longitude
+	}
+	if req.Capacity != nil {
+		if *req.Capacity > 0 {
+			created, err := s.ticketRepo.CountByEvent(eventID)
+			if err != nil {
+				return nil, apperrors.Internal("failed to count event tickets")
+			}
+			if created > int64(*req.Capacity) {
+				return nil, apperrors.Validationf("capacity cannot be lower than the %d tickets already created", created)
+			}
+		}
+		event.Capacity = *req.Capacity
+	}
+	if err := s.checkVenueCapacity(event); err != nil {
+		return nil, err
+	}
+	if req.BulkMaxQuantity != nil {
+		event.BulkMaxQuantity = *req.BulkMaxQuantity
+	}
+	if req.BulkApprovalThreshold != nil {
+		event.BulkApprovalThreshold = *req.BulkApprovalThreshold
+	}
+	if req.TransferFee != nil {
+		event.TransferFee = roundCents(*req.TransferFee)
+	}
+	if req.TransferFeePayer != nil {
+		event.TransferFeePayer = *req.TransferFeePayer
+	}
+	if req.NotifyFollowers != nil {
+		event.NotifyFollowers = *req.NotifyFollowers
+	}
+	if req.IDCheckRequired != nil {
+		event.IDCheckRequired = *req.IDCheckRequired
+	}
+	if req.RefundCutoffHours != nil {
+		event.RefundCutoffHours = *req.RefundCutoffHours
+	}
+	if req.QueueEnabled != nil {
+		event.QueueEnabled = *req.QueueEnabled
+	}
+	if req.QueueAdmitPerMinute != nil {
+		event.QueueAdmitPerMinute = *req.QueueAdmitPerMinute
+	}
+	if req.Timezone != "" {
+		event.Timezone = req.Timezone
+	}
+	if req.PublishAt != nil {
+		if event.Status == models.EventStatusApproved && event.PublishAt == nil {
+			return nil, apperrors.Conflict("event is already published")
+		}
+		if *req.PublishAt == 0 {
+			event.PublishAt = nil
+		} else if err := s.schedulePublication(event, *req.PublishAt); err != nil {
+			return nil, err
+		}
+	} else if req.Date != 0 && event.PublishAt != nil && *event.PublishAt >= event.Date {
+		return nil, apperrors.Validation("publication time must be before the event date")
+	}
+	if req.Title != "" || req.Description != "" {
+		if err := s.moderate(ctx, event); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := s.eventRepo.Update(event); err != nil {
-		return nil, errors.New("failed to update event")
+		return nil, apperrors.Internal("failed to update event")
 	}
 
 	return s.eventToResponse(event), nil
@@ -219,26 +617,238 @@ func (s *EventService) UpdateEvent(eventID, sellerID uint, req *UpdateEventReque
 func (s *EventService) DeleteEvent(eventID, sellerID uint) error {
 	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
-		return errors.New("event not found")
+		return apperrors.NotFound("event not found")
 	}
 
 	if event.SellerID != sellerID {
-		return errors.New("unauthorized to delete this event")
+		return apperrors.Forbidden("unauthorized to delete this event")
 	}
 
 	// Check if event has sold tickets
 	// TODO: Add logic to prevent deletion if tickets are sold
 
 	if err := s.eventRepo.Delete(eventID); err != nil {
-		return errors.New("failed to delete event")
+		return apperrors.Internal("failed to delete event")
+	}
+
+	return nil
+}
+
+// GetEventsByVenue lists the published events held at a venue
+func (s *EventService) GetEventsByVenue(venueID uint, page, limit int) (*utils.PaginatedResponse, error) {
+	if _, err := s.venueRepo.GetByID(venueID); err != nil {
+		return nil, apperrors.NotFound("venue not found")
+	}
+
+	offset := (page - 1) * limit
+	events, err := s.eventRepo.ListPublishedByVenue(venueID, limit, offset)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve venue events")
+	}
+
+	total, err := s.eventRepo.CountPublishedByVenue(venueID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count venue events")
+	}
+
+	eventResponses := make([]EventResponse, 0, len(events))
+	for _, event := range events {
+		availableTickets, _ := s.ticketRepo.CountAvailableByEvent(event.ID)
+		response := s.eventToResponse(&event)
+		response.AvailableTickets = availableTickets
+		eventResponses = append(eventResponses, *response)
+	}
+
+	return &utils.PaginatedResponse{
+		Success:    true,
+		Message:    "Venue events retrieved successfully",
+		Data:       eventResponses,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, nil
+}
+
+// GetUpcomingEventsBySeller lists the seller's next published events for their
+// public profile
+func (s *EventService) GetUpcomingEventsBySeller(sellerID uint, limit int) ([]EventResponse, error) {
+	events, err := s.eventRepo.ListUpcomingBySeller(sellerID, time.Now().Unix(), limit)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve seller events")
+	}
+
+	eventResponses := make([]EventResponse, 0, len(events))
+	for _, event := range events {
+		availableTickets, _ := s.ticketRepo.CountAvailableByEvent(event.ID)
+		response := s.eventToResponse(&event)
+		response.AvailableTickets = availableTickets
+		eventResponses = append(eventResponses, *response)
+	}
+
+	return eventResponses, nil
+}
+
+// GetNearbyEvents finds upcoming published events within radiusKm of a point
+func (s *EventService) GetNearbyEvents(lat, lng, radiusKm float64, limit int) ([]EventResponse, error) {
+	nearby, err := s.eventRepo.ListNearby(lat, lng, radiusKm, limit)
+	if err != nil {
+		return nil, apperrors.Internal("failed to search nearby events")
+	}
+
+	eventResponses := make([]EventResponse, 0, len(nearby))
+	for i := range nearby {
+		availableTickets, _ := s.ticketRepo.CountAvailableByEvent(nearby[i].Event.ID)
+		response := s.eventToResponse(&nearby[i].Event)
+		response.AvailableTickets = availableTickets
+		distance := math.Round(nearby[i].DistanceKm*100) / 100
+		response.DistanceKm = &distance
+		eventResponses = append(eventResponses, *response)
+	}
+
+	return eventResponses, nil
+}
+
+// attachVenue points the event at one of the seller's venues. The venue's
+// address and capacity fill in whatever the event doesn't set itself.
+// PublishScheduledEvents publishes approved events whose publish_at has
+// passed and queues an event.published message for each
+func (s *EventService) PublishScheduledEvents(limit int) (int, error) {
+	events, err := s.eventRepo.ListDueForPublication(time.Now().Unix(), limit)
+	if err != nil {
+		return 0, apperrors.Internal("failed to find events due for publication")
+	}
+
+	published := 0
+	for i := range events {
+		event := &events[i]
+		message, err := outbox.NewMessage(models.OutboxTopicEventPublished, outbox.EventPublishedPayload{
+			EventID:         event.ID,
+			SellerID:        event.SellerID,
+			Title:           event.Title,
+			Date:            event.Date,
+			NotifyFollowers: event.NotifyFollowers,
+		})
+		if err != nil {
+			return published, err
+		}
+
+		event.PublishAt = nil
+		err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+			if err := s.eventRepo.WithTx(tx).Update(event); err != nil {
+				return err
+			}
+			return s.outboxRepo.WithTx(tx).Create(message)
+		})
+		if err != nil {
+			return published, fmt.Errorf("failed to publish event %d: %w", event.ID, err)
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// schedulePublication sets when an event becomes publicly listed, which has
+// to be in the future and before the event itself
+func (s *EventService) schedulePublication(event *models.Event, publishAt int64) error {
+	if publishAt <= time.Now().Unix() {
+		return apperrors.Validation("publication time must be in the future")
+	}
+	if publishAt >= event.Date {
+		return apperrors.Validation("publication time must be before the event date")
+	}
+	event.PublishAt = &publishAt
+	return nil
+}
+
+// defaultEventTimezone is the zone of events created without one
+const defaultEventTimezone = "UTC"
+
+// eventLocation is the zone the event takes place in. Events stored before
+// zones were recorded, or with a zone the server doesn't know, use UTC.
+func eventLocation(event *models.Event) *time.Location {
+	if event.Timezone == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(event.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// eventTime is the Unix timestamp ts as a wall clock time at the event
+func eventTime(event *models.Event, ts int64) time.Time {
+	return time.Unix(ts, 0).In(eventLocation(event))
+}
+
+// validateEventDetails checks the rules binding tags can't express: doors
+// open on the day of the event, before it starts
+func validateEventDetails(details *models.EventDetails, eventDate int64) error {
+	if details.DoorsOpenAt == nil {
+		return nil
+	}
+	if *details.DoorsOpenAt > eventDate {
+		return apperrors.Validation("doors_open_at must not be after the event starts")
+	}
+	if eventDate-*details.DoorsOpenAt > int64(24*time.Hour/time.Second) {
+		return apperrors.Validation("doors_open_at must be within 24 hours before the event")
+	}
+	return nil
+}
+
+func (s *EventService) attachVenue(event *models.Event, venueID uint) error {
+	venue, err := s.venueRepo.GetByID(venueID)
+	if err != nil {
+		return apperrors.NotFound("venue not found")
+	}
+	if venue.SellerID != event.SellerID {
+		return apperrors.Forbidden("venue belongs to another seller")
+	}
+
+	event.VenueID = &venue.ID
+	event.Venue = venue
+	if event.Address == "" {
+		event.Address = venue.Address
+	}
+	if event.Capacity == 0 {
+		event.Capacity = venue.Capacity
+	}
+
+	return nil
+}
+
+func (s *EventService) checkVenueCapacity(event *models.Event) error {
+	if event.Venue != nil && event.Venue.Capacity > 0 && event.Capacity > event.Venue.Capacity {
+		return apperrors.Validationf("capacity cannot exceed the venue capacity of %d", event.Venue.Capacity)
+	}
+	return nil
+}
+
+// moderate scans the event's title and description. Blocked content is
+// refused; flagged content sends a published event back to pending so an
+// admin reviews it before it is listed again.
+func (s *EventService) moderate(ctx context.Context, event *models.Event) error {
+	result := s.moderator.Check(ctx, event.Title, event.Description)
+
+	switch result.Verdict {
+	case moderation.VerdictBlock:
+		return apperrors.Validation("event content violates the content policy: " + strings.Join(result.Reasons, "; "))
+	case moderation.VerdictFlag:
+		event.Flagged = true
+		event.FlagReasons = strings.Join(result.Reasons, "; ")
+		if event.Status == models.EventStatusApproved {
+			event.Status = models.EventStatusPending
+		}
+	default:
+		event.Flagged = false
+		event.FlagReasons = ""
 	}
 
 	return nil
 }
 
 func (s *EventService) eventToResponse(event *models.Event) *EventResponse {
-	sellerName := ""
-	if event.Seller.Name != "" {
+	sellerName := event.Seller.DisplayName
+	if sellerName == "" && event.Seller.Name != "" {
 		sellerName = event.Seller.Name + " " + event.Seller.Surname
 	}
 
@@ -252,5 +862,25 @@ func (s *EventService) eventToResponse(event *models.Event) *EventResponse {
 		Status:      event.Status,
 		SellerID:    event.SellerID,
 		SellerName:  sellerName,
+		Capacity:    event.Capacity,
+		VenueID:     event.VenueID,
+		Venue:       event.Venue,
+		Latitude:    event.Latitude,
+		Longitude:   event.Longitude,
+		Flagged:     event.Flagged,
+		FlagReasons: event.FlagReasons,
+		PublishAt:   event.PublishAt,
+
+		NotifyFollowers:       event.NotifyFollowers,
+		BulkMaxQuantity:       event.BulkMaxQuantity,
+		BulkApprovalThreshold: event.BulkApprovalThreshold,
+		TransferFee:           event.TransferFee,
+		TransferFeePayer:      event.TransferFeePayer,
+		IDCheckRequired:       event.IDCheckRequired,
+		RefundCutoffHours:     event.RefundCutoffHours,
+		QueueEnabled:          event.QueueEnabled,
+		QueueAdmitPerMinute:   event.QueueAdmitPerMinute,
+		Timezone:              eventLocation(event).String(),
+		DateISO:               eventTime(event, event.Date).Format(time.RFC3339),
 	}
 }
@@ -0,0 +1,829 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+
+	"eticketing/internal/models"
+	"eticketing/internal/payments"
+	"eticketing/internal/repositories/mocks"
+)
+
+// The stores below keep records in memory, and each store's mock method
+// wires a generated repository mock (see go generate in
+// internal/repositories) to them. Only the methods the services under test
+// call are expected; any other call fails the test. WithTx returns the same
+// mock, so writes made in a transaction are visible at once and aren't
+// rolled back.
+
+var errStorage = errors.New("connection lost")
+
+// fakeTxManager runs transactions against a database with no connection;
+// the mocks never touch it
+type fakeTxManager struct{}
+
+func (fakeTxManager) WithTransaction(fn func(tx *gorm.DB) error) error {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{})
+	if err != nil {
+		return err
+	}
+	return fn(db)
+}
+
+type userStore struct {
+	users  map[uint]*models.User
+	getErr error
+}
+
+func (s *userStore) GetByID(id uint) (*models.User, error) {
+	if user, ok := s.users[id]; ok {
+		return user, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *userStore) GetByEmail(email string) (*models.User, error) {
+	if s.getErr != nil {
+		return nil, s.getErr
+	}
+	for _, user := range s.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *userStore) GetByCalendarToken(token string) (*models.User, error) {
+	for _, user := range s.users {
+		if user.CalendarToken != nil && *user.CalendarToken == token {
+			return user, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *userStore) GetByUsername(username string) (*models.User, error) {
+	for _, user := range s.users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *userStore) Create(user *models.User) error {
+	if s.users == nil {
+		s.users = make(map[uint]*models.User)
+	}
+	user.ID = uint(len(s.users) + 1)
+	s.users[user.ID] = user
+	return nil
+}
+
+// mock serves the user repository from the store
+func (s *userStore) mock(ctrl *gomock.Controller) *mocks.MockUserRepository {
+	repo := mocks.NewMockUserRepository(ctrl)
+	repo.EXPECT().GetByID(gomock.Any()).DoAndReturn(s.GetByID).AnyTimes()
+	repo.EXPECT().GetByEmail(gomock.Any()).DoAndReturn(s.GetByEmail).AnyTimes()
+	repo.EXPECT().GetByCalendarToken(gomock.Any()).DoAndReturn(s.GetByCalendarToken).AnyTimes()
+	repo.EXPECT().GetByUsername(gomock.Any()).DoAndReturn(s.GetByUsername).AnyTimes()
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(s.Create).AnyTimes()
+	return repo
+}
+
+type sellerStore struct {
+	sellers map[uint]*models.Seller
+	getErr  error
+}
+
+func (s *sellerStore) GetByID(id uint) (*models.Seller, error) {
+	if seller, ok := s.sellers[id]; ok {
+		return seller, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *sellerStore) GetByEmail(email string) (*models.Seller, error) {
+	if s.getErr != nil {
+		return nil, s.getErr
+	}
+	for _, seller := range s.sellers {
+		if seller.Email == email {
+			return seller, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *sellerStore) GetByUsername(username string) (*models.Seller, error) {
+	for _, seller := range s.sellers {
+		if seller.Username == username {
+			return seller, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *sellerStore) Create(seller *models.Seller) error {
+	if s.sellers == nil {
+		s.sellers = make(map[uint]*models.Seller)
+	}
+	seller.ID = uint(len(s.sellers) + 1)
+	s.sellers[seller.ID] = seller
+	return nil
+}
+
+// mock serves the seller repository from the store
+func (s *sellerStore) mock(ctrl *gomock.Controller) *mocks.MockSellerRepository {
+	repo := mocks.NewMockSellerRepository(ctrl)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo).AnyTimes()
+	repo.EXPECT().GetByID(gomock.Any()).DoAndReturn(s.GetByID).AnyTimes()
+	repo.EXPECT().GetByEmail(gomock.Any()).DoAndReturn(s.GetByEmail).AnyTimes()
+	repo.EXPECT().GetByUsername(gomock.Any()).DoAndReturn(s.GetByUsername).AnyTimes()
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(s.Create).AnyTimes()
+	return repo
+}
+
+type adminStore struct {
+	admins map[uint]*models.Admin
+	getErr error
+}
+
+func (s *adminStore) GetByID(id uint) (*models.Admin, error) {
+	if admin, ok := s.admins[id]; ok {
+		return admin, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *adminStore) GetByEmail(email string) (*models.Admin, error) {
+	if s.getErr != nil {
+		return nil, s.getErr
+	}
+	for _, admin := range s.admins {
+		if admin.Email == email {
+			return admin, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// mock serves the admin repository from the store
+func (s *adminStore) mock(ctrl *gomock.Controller) *mocks.MockAdminRepository {
+	repo := mocks.NewMockAdminRepository(ctrl)
+	repo.EXPECT().GetByID(gomock.Any()).DoAndReturn(s.GetByID).AnyTimes()
+	repo.EXPECT().GetByEmail(gomock.Any()).DoAndReturn(s.GetByEmail).AnyTimes()
+	return repo
+}
+
+type denylistStore struct {
+	entries []models.DenylistEntry
+}
+
+func (s *denylistStore) FindMatches(kind models.DenylistKind, values []string) ([]models.DenylistEntry, error) {
+	var matches []models.DenylistEntry
+	for _, entry := range s.entries {
+		for _, value := range values {
+			if entry.Kind == kind && strings.EqualFold(entry.Value, value) {
+				matches = append(matches, entry)
+			}
+		}
+	}
+	return matches, nil
+}
+
+// mock serves the denylist repository from the store
+func (s *denylistStore) mock(ctrl *gomock.Controller) *mocks.MockDenylistRepository {
+	repo := mocks.NewMockDenylistRepository(ctrl)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo).AnyTimes()
+	repo.EXPECT().FindMatches(gomock.Any(), gomock.Any()).DoAndReturn(s.FindMatches).AnyTimes()
+	return repo
+}
+
+type giftStore struct {
+}
+
+func (s *giftStore) ListPendingByEmail(email string) ([]models.TicketGift, error) {
+	return nil, nil
+}
+
+// mock serves the gift repository from the store
+func (s *giftStore) mock(ctrl *gomock.Controller) *mocks.MockGiftRepository {
+	repo := mocks.NewMockGiftRepository(ctrl)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo).AnyTimes()
+	repo.EXPECT().ListPendingByEmail(gomock.Any()).DoAndReturn(s.ListPendingByEmail).AnyTimes()
+	return repo
+}
+
+type eventStore struct {
+	events map[uint]*models.Event
+}
+
+func (s *eventStore) GetByID(id uint) (*models.Event, error) {
+	if event, ok := s.events[id]; ok {
+		return event, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// mock serves the event repository from the store
+func (s *eventStore) mock(ctrl *gomock.Controller) *mocks.MockEventRepository {
+	repo := mocks.NewMockEventRepository(ctrl)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo).AnyTimes()
+	repo.EXPECT().GetByID(gomock.Any()).DoAndReturn(s.GetByID).AnyTimes()
+	return repo
+}
+
+type ticketGroupStore struct {
+	groups map[uint]*models.TicketGroup
+}
+
+func (s *ticketGroupStore) GetByID(id uint) (*models.TicketGroup, error) {
+	if group, ok := s.groups[id]; ok {
+		return group, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// mock serves the ticket group repository from the store
+func (s *ticketGroupStore) mock(ctrl *gomock.Controller) *mocks.MockTicketGroupRepository {
+	repo := mocks.NewMockTicketGroupRepository(ctrl)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo).AnyTimes()
+	repo.EXPECT().GetByID(gomock.Any()).DoAndReturn(s.GetByID).AnyTimes()
+	return repo
+}
+
+type saleStore struct {
+	sales map[uint]*models.Sale
+}
+
+func (s *saleStore) GetByID(id uint) (*models.Sale, error) {
+	if sale, ok := s.sales[id]; ok {
+		return sale, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *saleStore) FindAllocation(saleID, groupID uint) (*models.SaleAllocation, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ticketStore holds the unsold tickets of every group
+
+// mock serves the sale repository from the store
+func (s *saleStore) mock(ctrl *gomock.Controller) *mocks.MockSaleRepository {
+	repo := mocks.NewMockSaleRepository(ctrl)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo).AnyTimes()
+	repo.EXPECT().GetByID(gomock.Any()).DoAndReturn(s.GetByID).AnyTimes()
+	repo.EXPECT().FindAllocation(gomock.Any(), gomock.Any()).DoAndReturn(s.FindAllocation).AnyTimes()
+	return repo
+}
+
+type ticketStore struct {
+	tickets map[uint]*models.Ticket
+}
+
+func (s *ticketStore) FindAndLockAvailableTickets(groupID uint, quantity int) ([]models.Ticket, error) {
+	var available []models.Ticket
+	for id := uint(1); id <= uint(len(s.tickets)) && len(available) < quantity; id++ {
+		ticket := s.tickets[id]
+		if ticket.GroupID == groupID && !ticket.IsSold && !ticket.IsHeld {
+			available = append(available, *ticket)
+		}
+	}
+	return available, nil
+}
+
+func (s *ticketStore) Update(ticket *models.Ticket) error {
+	copied := *ticket
+	s.tickets[ticket.ID] = &copied
+	return nil
+}
+
+func (s *ticketStore) GetByID(id uint) (*models.Ticket, error) {
+	if ticket, ok := s.tickets[id]; ok {
+		copied := *ticket
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *ticketStore) GetByIDForUpdate(id uint) (*models.Ticket, error) {
+	return s.GetByID(id)
+}
+
+func (s *ticketStore) ReleaseHeld(ids []uint) error {
+	for _, id := range ids {
+		if ticket := s.tickets[id]; !ticket.IsSold {
+			ticket.IsHeld = false
+		}
+	}
+	return nil
+}
+
+func (s *ticketStore) SellHeld(ids []uint) (int64, error) {
+	var sold int64
+	for _, id := range ids {
+		if ticket := s.tickets[id]; ticket.IsHeld && !ticket.IsSold {
+			ticket.IsHeld, ticket.IsSold = false, true
+			sold++
+		}
+	}
+	return sold, nil
+}
+
+// mock serves the ticket repository from the store
+func (s *ticketStore) mock(ctrl *gomock.Controller) *mocks.MockTicketRepository {
+	repo := mocks.NewMockTicketRepository(ctrl)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo).AnyTimes()
+	repo.EXPECT().FindAndLockAvailableTickets(gomock.Any(), gomock.Any()).DoAndReturn(s.FindAndLockAvailableTickets).AnyTimes()
+	repo.EXPECT().Update(gomock.Any()).DoAndReturn(s.Update).AnyTimes()
+	repo.EXPECT().GetByID(gomock.Any()).DoAndReturn(s.GetByID).AnyTimes()
+	repo.EXPECT().GetByIDForUpdate(gomock.Any()).DoAndReturn(s.GetByIDForUpdate).AnyTimes()
+	repo.EXPECT().ReleaseHeld(gomock.Any()).DoAndReturn(s.ReleaseHeld).AnyTimes()
+	repo.EXPECT().SellHeld(gomock.Any()).DoAndReturn(s.SellHeld).AnyTimes()
+	return repo
+}
+
+type priceTierStore struct {
+}
+
+func (s *priceTierStore) ListByGroup(groupID uint) ([]models.PriceTier, error) {
+	return nil, nil
+}
+
+// mock serves the price tier repository from the store
+func (s *priceTierStore) mock(ctrl *gomock.Controller) *mocks.MockPriceTierRepository {
+	repo := mocks.NewMockPriceTierRepository(ctrl)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo).AnyTimes()
+	repo.EXPECT().ListByGroup(gomock.Any()).DoAndReturn(s.ListByGroup).AnyTimes()
+	return repo
+}
+
+type purchasedTicketStore struct {
+	tickets     map[uint]*models.PurchasedTicket
+	invalidated []uint
+	raced       bool // InvalidateTickets finds the tickets already invalidated
+}
+
+func (s *purchasedTicketStore) Create(ticket *models.PurchasedTicket) error {
+	if s.tickets == nil {
+		s.tickets = make(map[uint]*models.PurchasedTicket)
+	}
+	ticket.ID = uint(len(s.tickets) + 1)
+	s.tickets[ticket.ID] = ticket
+	return nil
+}
+
+func (s *purchasedTicketStore) GetByID(id uint) (*models.PurchasedTicket, error) {
+	if ticket, ok := s.tickets[id]; ok {
+		return ticket, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *purchasedTicketStore) UpdateOwnership(ticketID, fromUserID, newUserID uint) (bool, error) {
+	ticket, ok := s.tickets[ticketID]
+	if !ok || ticket.UserID != fromUserID || ticket.IsUsed || ticket.IsInvalidated {
+		return false, nil
+	}
+	ticket.UserID = newUserID
+	return true, nil
+}
+
+func (s *purchasedTicketStore) ListByUser(userID uint) ([]models.PurchasedTicket, error) {
+	var tickets []models.PurchasedTicket
+	for id := uint(1); id <= uint(len(s.tickets)); id++ {
+		if ticket := s.tickets[id]; ticket.UserID == userID {
+			tickets = append(tickets, *ticket)
+		}
+	}
+	return tickets, nil
+}
+
+func (s *purchasedTicketStore) ListByPayment(paymentID uint) ([]models.PurchasedTicket, error) {
+	var tickets []models.PurchasedTicket
+	for _, ticket := range s.tickets {
+		if ticket.PaymentID != nil && *ticket.PaymentID == paymentID {
+			tickets = append(tickets, *ticket)
+		}
+	}
+	return tickets, nil
+}
+
+func (s *purchasedTicketStore) InvalidateByPayment(paymentID uint, invalidatedAt int64) (int64, error) {
+	var count int64
+	for _, ticket := range s.tickets {
+		if ticket.PaymentID != nil && *ticket.PaymentID == paymentID && !ticket.IsInvalidated {
+			ticket.IsInvalidated = true
+			s.invalidated = append(s.invalidated, ticket.ID)
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *purchasedTicketStore) InvalidateTickets(paymentID uint, ticketIDs []uint, invalidatedAt int64) (int64, error) {
+	if s.raced {
+		return 0, nil
+	}
+	var count int64
+	for _, id := range ticketIDs {
+		if ticket := s.tickets[id]; !ticket.IsInvalidated {
+			ticket.IsInvalidated = true
+			s.invalidated = append(s.invalidated, id)
+			count++
+		}
+	}
+	return count, nil
+}
+
+// mock serves the purchased ticket repository from the store
+func (s *purchasedTicketStore) mock(ctrl *gomock.Controller) *mocks.MockPurchasedTicketRepository {
+	repo := mocks.NewMockPurchasedTicketRepository(ctrl)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo).AnyTimes()
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(s.Create).AnyTimes()
+	repo.EXPECT().GetByID(gomock.Any()).DoAndReturn(s.GetByID).AnyTimes()
+	repo.EXPECT().UpdateOwnership(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(s.UpdateOwnership).AnyTimes()
+	repo.EXPECT().ListByUser(gomock.Any()).DoAndReturn(s.ListByUser).AnyTimes()
+	repo.EXPECT().ListByPayment(gomock.Any()).DoAndReturn(s.ListByPayment).AnyTimes()
+	repo.EXPECT().InvalidateByPayment(gomock.Any(), gomock.Any()).DoAndReturn(s.InvalidateByPayment).AnyTimes()
+	repo.EXPECT().InvalidateTickets(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(s.InvalidateTickets).AnyTimes()
+	return repo
+}
+
+type paymentStore struct {
+	payments  map[uint]*models.Payment
+	refunds   []*models.Refund
+	raced     bool // AddRefunded loses to a concurrent refund
+	updateErr error
+}
+
+func (s *paymentStore) Create(payment *models.Payment) error {
+	if s.payments == nil {
+		s.payments = make(map[uint]*models.Payment)
+	}
+	payment.ID = uint(len(s.payments) + 1)
+	copied := *payment
+	s.payments[payment.ID] = &copied
+	return nil
+}
+
+func (s *paymentStore) Update(payment *models.Payment) error {
+	if s.updateErr != nil {
+		return s.updateErr
+	}
+	copied := *payment
+	s.payments[payment.ID] = &copied
+	return nil
+}
+
+func (s *paymentStore) GetByID(id uint) (*models.Payment, error) {
+	if payment, ok := s.payments[id]; ok {
+		copied := *payment
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *paymentStore) ListByParent(parentID uint) ([]models.Payment, error) {
+	var components []models.Payment
+	for id := uint(1); id <= uint(len(s.payments)); id++ {
+		payment := s.payments[id]
+		if payment.ParentPaymentID != nil && *payment.ParentPaymentID == parentID {
+			components = append(components, *payment)
+		}
+	}
+	return components, nil
+}
+
+func (s *paymentStore) AddRefunded(id uint, amount float64) (bool, error) {
+	if s.raced {
+		return false, nil
+	}
+	s.payments[id].RefundedAmount += amount
+	return true, nil
+}
+
+func (s *paymentStore) CreateRefund(refund *models.Refund) error {
+	refund.ID = uint(len(s.refunds) + 1)
+	s.refunds = append(s.refunds, refund)
+	return nil
+}
+
+// sellerPayments lists the payments crediting or debiting sellers
+func (s *paymentStore) sellerPayments() []*models.Payment {
+	var credits []*models.Payment
+	for id := uint(1); id <= uint(len(s.payments)); id++ {
+		if payment := s.payments[id]; payment.UserType == models.UserTypeSeller {
+			credits = append(credits, payment)
+		}
+	}
+	return credits
+}
+
+// mock serves the payment repository from the store
+func (s *paymentStore) mock(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+	repo := mocks.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo).AnyTimes()
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(s.Create).AnyTimes()
+	repo.EXPECT().Update(gomock.Any()).DoAndReturn(s.Update).AnyTimes()
+	repo.EXPECT().GetByID(gomock.Any()).DoAndReturn(s.GetByID).AnyTimes()
+	repo.EXPECT().ListByParent(gomock.Any()).DoAndReturn(s.ListByParent).AnyTimes()
+	repo.EXPECT().AddRefunded(gomock.Any(), gomock.Any()).DoAndReturn(s.AddRefunded).AnyTimes()
+	repo.EXPECT().CreateRefund(gomock.Any()).DoAndReturn(s.CreateRefund).AnyTimes()
+	return repo
+}
+
+type paymentMethodStore struct {
+	methods map[uint]*models.PaymentMethod
+}
+
+func (s *paymentMethodStore) GetByID(id uint) (*models.PaymentMethod, error) {
+	if method, ok := s.methods[id]; ok {
+		return method, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *paymentMethodStore) GetDefaultByUser(userID uint) (*models.PaymentMethod, error) {
+	for _, method := range s.methods {
+		if method.UserID == userID && method.IsDefault {
+			return method, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// mock serves the payment method repository from the store
+func (s *paymentMethodStore) mock(ctrl *gomock.Controller) *mocks.MockPaymentMethodRepository {
+	repo := mocks.NewMockPaymentMethodRepository(ctrl)
+	repo.EXPECT().GetByID(gomock.Any()).DoAndReturn(s.GetByID).AnyTimes()
+	repo.EXPECT().GetDefaultByUser(gomock.Any()).DoAndReturn(s.GetDefaultByUser).AnyTimes()
+	return repo
+}
+
+type outboxStore struct {
+	messages []*models.OutboxMessage
+}
+
+func (s *outboxStore) Create(message *models.OutboxMessage) error {
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+func (s *outboxStore) topics() []string {
+	var topics []string
+	for _, message := range s.messages {
+		topics = append(topics, message.Topic)
+	}
+	return topics
+}
+
+// orderStore keeps its own copy of every order; GetByID loads the
+// held tickets from tickets
+
+// mock serves the outbox repository from the store
+func (s *outboxStore) mock(ctrl *gomock.Controller) *mocks.MockOutboxRepository {
+	repo := mocks.NewMockOutboxRepository(ctrl)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo).AnyTimes()
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(s.Create).AnyTimes()
+	return repo
+}
+
+type orderStore struct {
+	orders  []*models.Order
+	tickets *ticketStore
+}
+
+func (s *orderStore) Create(order *models.Order) error {
+	order.ID = uint(len(s.orders) + 1)
+	copied := *order
+	s.orders = append(s.orders, &copied)
+	return nil
+}
+
+func (s *orderStore) GetByID(id uint) (*models.Order, error) {
+	if id == 0 || int(id) > len(s.orders) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	order := *s.orders[id-1]
+	order.Items = append([]models.OrderItem(nil), order.Items...)
+	for i := range order.Items {
+		order.Items[i].Ticket = *s.tickets.tickets[order.Items[i].TicketID]
+	}
+	return &order, nil
+}
+
+func (s *orderStore) Update(order *models.Order) error {
+	copied := *order
+	s.orders[order.ID-1] = &copied
+	return nil
+}
+
+func (s *orderStore) ListExpired(now int64, limit int) ([]models.Order, error) {
+	var expired []models.Order
+	for _, stored := range s.orders {
+		held := stored.Status == models.OrderStatusAwaitingPayment || stored.Status == models.OrderStatusUnderReview ||
+			stored.Status == models.OrderStatusPaying
+		if held && stored.HoldExpiresAt < now && len(expired) < limit {
+			order, _ := s.GetByID(stored.ID)
+			expired = append(expired, *order)
+		}
+	}
+	return expired, nil
+}
+
+func (s *orderStore) TransitionStatus(id uint, from, to models.OrderStatus) (bool, error) {
+	order := s.orders[id-1]
+	if order.Status != from {
+		return false, nil
+	}
+	order.Status = to
+	return true, nil
+}
+
+// mock serves the order repository from the store
+func (s *orderStore) mock(ctrl *gomock.Controller) *mocks.MockOrderRepository {
+	repo := mocks.NewMockOrderRepository(ctrl)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo).AnyTimes()
+	repo.EXPECT().Create(gomock.Any()).DoAndReturn(s.Create).AnyTimes()
+	repo.EXPECT().GetByID(gomock.Any()).DoAndReturn(s.GetByID).AnyTimes()
+	repo.EXPECT().Update(gomock.Any()).DoAndReturn(s.Update).AnyTimes()
+	repo.EXPECT().ListExpired(gomock.Any(), gomock.Any()).DoAndReturn(s.ListExpired).AnyTimes()
+	repo.EXPECT().TransitionStatus(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(s.TransitionStatus).AnyTimes()
+	return repo
+}
+
+type transferStore struct {
+	active        map[uint]*models.ActiveTicketTransfer
+	done          []*models.DoneTicketTransfer
+	hasActive     bool
+	hasClaimLink  bool
+	acceptedFirst bool // Another request accepted the transfer first
+	links         map[uint]*models.TicketClaimLink
+}
+
+func (s *transferStore) GetActiveByID(id uint) (*models.ActiveTicketTransfer, error) {
+	if transfer, ok := s.active[id]; ok {
+		copied := *transfer
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *transferStore) CreateActive(transfer *models.ActiveTicketTransfer) error {
+	if s.active == nil {
+		s.active = make(map[uint]*models.ActiveTicketTransfer)
+	}
+	transfer.ID = uint(len(s.active) + 1)
+	s.active[transfer.ID] = transfer
+	return nil
+}
+
+func (s *transferStore) AcceptPending(id uint) (bool, error) {
+	return s.closePending(id, models.TransferStatusAccepted), nil
+}
+
+func (s *transferStore) ReopenAccepted(id uint) error {
+	if transfer := s.active[id]; transfer.Status == models.TransferStatusAccepted {
+		transfer.Status = models.TransferStatusPending
+	}
+	return nil
+}
+
+func (s *transferStore) RejectPending(id uint) (bool, error) {
+	return s.closePending(id, models.TransferStatusRejected), nil
+}
+
+// closePending moves a pending transfer to status; acceptedFirst plays a
+// concurrent accept that got there first
+func (s *transferStore) closePending(id uint, status models.TransferStatus) bool {
+	transfer := s.active[id]
+	if s.acceptedFirst || transfer.Status != models.TransferStatusPending {
+		return false
+	}
+	transfer.Status = status
+	return true
+}
+
+func (s *transferStore) CreateDone(transfer *models.DoneTicketTransfer) error {
+	s.done = append(s.done, transfer)
+	return nil
+}
+
+func (s *transferStore) HasActiveTransferForTicket(ticketID uint) (bool, error) {
+	return s.hasActive, nil
+}
+
+func (s *transferStore) CreateClaimLink(link *models.TicketClaimLink) error {
+	if s.links == nil {
+		s.links = make(map[uint]*models.TicketClaimLink)
+	}
+	link.ID = uint(len(s.links) + 1)
+	s.links[link.ID] = link
+	return nil
+}
+
+func (s *transferStore) GetClaimLinkByToken(token string) (*models.TicketClaimLink, error) {
+	for _, link := range s.links {
+		if link.Token == token {
+			copied := *link
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (s *transferStore) RevokeClaimLinks(ticketID uint) (int64, error) {
+	var revoked int64
+	for _, link := range s.links {
+		if link.PurchasedTicketID == ticketID && link.Status == models.ClaimLinkStatusOpen {
+			link.Status = models.ClaimLinkStatusRevoked
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+func (s *transferStore) ClaimOpenLink(id, userID uint, now int64) (bool, error) {
+	link := s.links[id]
+	if link.Status != models.ClaimLinkStatusOpen || link.ExpiresAt <= now {
+		return false, nil
+	}
+	link.Status = models.ClaimLinkStatusClaimed
+	link.ClaimedByID = &userID
+	return true, nil
+}
+
+func (s *transferStore) ReopenClaimLink(id, userID uint) error {
+	if link := s.links[id]; link.Status == models.ClaimLinkStatusClaimed && *link.ClaimedByID == userID {
+		link.Status = models.ClaimLinkStatusOpen
+		link.ClaimedByID = nil
+	}
+	return nil
+}
+
+func (s *transferStore) HasOpenClaimLink(ticketID uint, now int64) (bool, error) {
+	return s.hasClaimLink, nil
+}
+
+// fakeProvider approves charges unless the token is "decline", and
+// records the refunds it is asked for
+
+// mock serves the transfer repository from the store
+func (s *transferStore) mock(ctrl *gomock.Controller) *mocks.MockTransferRepository {
+	repo := mocks.NewMockTransferRepository(ctrl)
+	repo.EXPECT().WithTx(gomock.Any()).Return(repo).AnyTimes()
+	repo.EXPECT().GetActiveByID(gomock.Any()).DoAndReturn(s.GetActiveByID).AnyTimes()
+	repo.EXPECT().CreateActive(gomock.Any()).DoAndReturn(s.CreateActive).AnyTimes()
+	repo.EXPECT().AcceptPending(gomock.Any()).DoAndReturn(s.AcceptPending).AnyTimes()
+	repo.EXPECT().ReopenAccepted(gomock.Any()).DoAndReturn(s.ReopenAccepted).AnyTimes()
+	repo.EXPECT().RejectPending(gomock.Any()).DoAndReturn(s.RejectPending).AnyTimes()
+	repo.EXPECT().CreateDone(gomock.Any()).DoAndReturn(s.CreateDone).AnyTimes()
+	repo.EXPECT().HasActiveTransferForTicket(gomock.Any()).DoAndReturn(s.HasActiveTransferForTicket).AnyTimes()
+	repo.EXPECT().CreateClaimLink(gomock.Any()).DoAndReturn(s.CreateClaimLink).AnyTimes()
+	repo.EXPECT().GetClaimLinkByToken(gomock.Any()).DoAndReturn(s.GetClaimLinkByToken).AnyTimes()
+	repo.EXPECT().RevokeClaimLinks(gomock.Any()).DoAndReturn(s.RevokeClaimLinks).AnyTimes()
+	repo.EXPECT().ClaimOpenLink(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(s.ClaimOpenLink).AnyTimes()
+	repo.EXPECT().ReopenClaimLink(gomock.Any(), gomock.Any()).DoAndReturn(s.ReopenClaimLink).AnyTimes()
+	repo.EXPECT().HasOpenClaimLink(gomock.Any(), gomock.Any()).DoAndReturn(s.HasOpenClaimLink).AnyTimes()
+	return repo
+}
+
+type fakeProvider struct {
+	refunded map[string]float64
+	charges  int
+	onCharge func() // Runs while a charge is in flight
+}
+
+func (p *fakeProvider) Name() string { return "fake" }
+
+func (p *fakeProvider) Retrieve(ctx context.Context, token string) (*payments.Instrument, error) {
+	return nil, payments.ErrUnknownToken
+}
+
+func (p *fakeProvider) Charge(ctx context.Context, token string, amount float64, reference string) (*payments.ChargeResult, error) {
+	p.charges++
+	if p.onCharge != nil {
+		p.onCharge()
+	}
+	if token == "decline" {
+		return &payments.ChargeResult{Message: "card declined"}, nil
+	}
+	return &payments.ChargeResult{Succeeded: true, TransactionID: "fake_" + reference, Message: "approved"}, nil
+}
+
+func (p *fakeProvider) Refund(ctx context.Context, transactionID string, amount float64) error {
+	if p.refunded == nil {
+		p.refunded = make(map[string]float64)
+	}
+	p.refunded[transactionID] += amount
+	return nil
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"errors"
+	"hash/fnv"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// featureFlagCacheTTL is how long flags are served from memory. Changes made
+// through this instance apply at once; other instances see them within it.
+const featureFlagCacheTTL = 30 * time.Second
+
+// FeatureFlagService decides whether a capability is on for a user and lets
+// admins manage the flags that control it
+type FeatureFlagService struct {
+	flagRepo    repositories.FeatureFlagRepository
+	environment string
+
+	mutex    sync.RWMutex
+	flags    map[string]models.FeatureFlag
+	loadedAt time.Time
+}
+
+type CreateFeatureFlagRequest struct {
+	Key            string   `json:"key" binding:"required,max=64"`
+	Description    string   `json:"description" binding:"max=500"`
+	Enabled        bool     `json:"enabled"`
+	RolloutPercent *int     `json:"rollout_percent" binding:"omitempty,min=0,max=100"` // Defaults to 100
+	Environments   []string `json:"environments" binding:"max=20,dive,required,max=32"`
+}
+
+type UpdateFeatureFlagRequest struct {
+	Description    *string  `json:"description" binding:"omitempty,max=500"`
+	Enabled        *bool    `json:"enabled"`
+	RolloutPercent *int     `json:"rollout_percent" binding:"omitempty,min=0,max=100"`
+	Environments   []string `json:"environments" binding:"omitempty,max=20,dive,required,max=32"` // Replaces the list; [] clears it
+}
+
+func NewFeatureFlagService(flagRepo repositories.FeatureFlagRepository, environment string) *FeatureFlagService {
+	return &FeatureFlagService{
+		flagRepo:    flagRepo,
+		environment: environment,
+	}
+}
+
+// Enabled reports whether the feature is on for userID. Features without a
+// flag are on. Anonymous callers (userID 0) only get fully rolled out flags.
+func (s *FeatureFlagService) Enabled(key string, userID uint) bool {
+	flag, ok := s.current()[key]
+	if !ok {
+		return true
+	}
+	if !flag.Enabled || !s.appliesHere(&flag) {
+		return false
+	}
+	if flag.RolloutPercent >= 100 {
+		return true
+	}
+	if userID == 0 {
+		return false
+	}
+	return rolloutBucket(key, userID) < flag.RolloutPercent
+}
+
+// Features reports every known feature and every flagged one as on or off
+// for userID, so clients can hide what the user can't use
+func (s *FeatureFlagService) Features(userID uint) map[string]bool {
+	keys := []string{models.FeatureTransfers, models.FeatureGifts, models.FeatureBulkOrders}
+	for key := range s.current() {
+		keys = append(keys, key)
+	}
+
+	features := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		features[key] = s.Enabled(key, userID)
+	}
+	return features
+}
+
+// Require returns a FEATURE_DISABLED error when the feature is off for userID
+func (s *FeatureFlagService) Require(key string, userID uint) error {
+	if s.Enabled(key, userID) {
+		return nil
+	}
+	return apperrors.Forbidden("this feature is currently unavailable").WithCode(apperrors.CodeFeatureDisabled)
+}
+
+func (s *FeatureFlagService) ListFlags() ([]models.FeatureFlag, error) {
+	flags, err := s.flagRepo.List()
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve feature flags")
+	}
+	return flags, nil
+}
+
+func (s *FeatureFlagService) CreateFlag(req *CreateFeatureFlagRequest, adminID uint) (*models.FeatureFlag, error) {
+	key := strings.ToLower(strings.TrimSpace(req.Key))
+	if !validFlagKey(key) {
+		return nil, apperrors.Validation("key may only contain letters, digits, '_', '-' and '.'")
+	}
+	if _, err := s.flagRepo.GetByKey(key); err == nil {
+		return nil, apperrors.Conflict("feature flag already exists")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.Internal("failed to check feature flag")
+	}
+
+	now := time.Now().Unix()
+	flag := &models.FeatureFlag{
+		Key:            key,
+		Description:    req.Description,
+		Enabled:        req.Enabled,
+		RolloutPercent: 100,
+		Environments:   joinEnvironments(req.Environments),
+		UpdatedBy:      adminID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if req.RolloutPercent != nil {
+		flag.RolloutPercent = *req.RolloutPercent
+	}
+
+	if err := s.flagRepo.Create(flag); err != nil {
+		return nil, apperrors.Internal("failed to create feature flag")
+	}
+	s.invalidate()
+	return flag, nil
+}
+
+func (s *FeatureFlagService) UpdateFlag(id uint, req *UpdateFeatureFlagRequest, adminID uint) (*models.FeatureFlag, error) {
+	flag, err := s.flagRepo.GetByID(id)
+	if err != nil {
+		return nil, apperrors.NotFound("feature flag not found")
+	}
+
+	if req.Description != nil {
+		flag.Description = *req.Description
+	}
+	if req.Enabled != nil {
+		flag.Enabled = *req.Enabled
+	}
+	if req.RolloutPercent != nil {
+		flag.RolloutPercent = *req.RolloutPercent
+	}
+	if req.Environments != nil {
+		flag.Environments = joinEnvironments(req.Environments)
+	}
+	flag.UpdatedBy = adminID
+	flag.UpdatedAt = time.Now().Unix()
+
+	if err := s.flagRepo.Update(flag); err != nil {
+		return nil, apperrors.Internal("failed to update feature flag")
+	}
+	s.invalidate()
+	return flag, nil
+}
+
+// DeleteFlag removes the flag, which turns its feature back on everywhere
+func (s *FeatureFlagService) DeleteFlag(id uint) error {
+	if _, err := s.flagRepo.GetByID(id); err != nil {
+		return apperrors.NotFound("feature flag not found")
+	}
+	if err := s.flagRepo.Delete(id); err != nil {
+		return apperrors.Internal("failed to delete feature flag")
+	}
+	s.invalidate()
+	return nil
+}
+
+// current returns the cached flags by key, loading them when stale. The map
+// is replaced, never modified, so callers may read it without the lock.
+func (s *FeatureFlagService) current() map[string]models.FeatureFlag {
+	s.mutex.RLock()
+	flags, loadedAt := s.flags, s.loadedAt
+	s.mutex.RUnlock()
+	if flags != nil && time.Since(loadedAt) < featureFlagCacheTTL {
+		return flags
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.flags == nil || time.Since(s.loadedAt) >= featureFlagCacheTTL {
+		s.reload()
+	}
+	return s.flags
+}
+
+// reload refreshes the cached flags. When the database can't be reached the
+// previous flags stay in use; with none loaded yet every feature is on.
+// Callers hold the write lock.
+func (s *FeatureFlagService) reload() {
+	flags, err := s.flagRepo.List()
+	s.loadedAt = time.Now()
+	if err != nil {
+		log.Printf("feature flags: failed to load flags: %v", err)
+		if s.flags == nil {
+			s.flags = map[string]models.FeatureFlag{}
+		}
+		return
+	}
+
+	s.flags = make(map[string]models.FeatureFlag, len(flags))
+	for _, flag := range flags {
+		s.flags[flag.Key] = flag
+	}
+}
+
+// Refresh drops the cached flags so the next check reads them again,
+// picking up changes made through other instances
+func (s *FeatureFlagService) Refresh() {
+	s.invalidate()
+}
+
+func (s *FeatureFlagService) invalidate() {
+	s.mutex.Lock()
+	s.flags = nil
+	s.mutex.Unlock()
+}
+
+func (s *FeatureFlagService) appliesHere(flag *models.FeatureFlag) bool {
+	if flag.Environments == "" {
+		return true
+	}
+	for _, environment := range strings.Split(flag.Environments, ",") {
+		if strings.EqualFold(environment, s.environment) {
+			return true
+		}
+	}
+	return false
+}
+
+// rolloutBucket places a user in 0-99 for a flag. The key is part of the
+// hash so the same users aren't always first to get every feature.
+func rolloutBucket(key string, userID uint) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	hash.Write([]byte{':', byte(userID >> 24), byte(userID >> 16), byte(userID >> 8), byte(userID)})
+	return int(hash.Sum32() % 100)
+}
+
+func validFlagKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' && r != '-' && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+func joinEnvironments(environments []string) string {
+	names := make([]string, 0, len(environments))
+	for _, environment := range environments {
+		if name := strings.ToLower(strings.TrimSpace(environment)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ",")
+}
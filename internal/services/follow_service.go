@@ -0,0 +1,196 @@
+// internal/services/follow_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/outbox"
+	"eticketing/internal/repositories"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type FollowService struct {
+	followRepo repositories.FollowRepository
+	sellerRepo repositories.SellerRepository
+	eventRepo  repositories.EventRepository
+	outboxRepo repositories.OutboxRepository
+}
+
+type FollowedSeller struct {
+	SellerID    uint   `json:"seller_id"`
+	DisplayName string `json:"display_name"`
+	LogoURL     string `json:"logo_url"`
+	FollowedAt  int64  `json:"followed_at"`
+}
+
+func NewFollowService(
+	followRepo repositories.FollowRepository,
+	sellerRepo repositories.SellerRepository,
+	eventRepo repositories.EventRepository,
+	outboxRepo repositories.OutboxRepository,
+) *FollowService {
+	return &FollowService{
+		followRepo: followRepo,
+		sellerRepo: sellerRepo,
+		eventRepo:  eventRepo,
+		outboxRepo: outboxRepo,
+	}
+}
+
+func (s *FollowService) Follow(userID, sellerID uint) (*FollowedSeller, error) {
+	seller, err := s.sellerRepo.GetByID(sellerID)
+	if err != nil {
+		return nil, apperrors.NotFound("seller not found")
+	}
+
+	following, err := s.followRepo.Exists(userID, sellerID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to check follow")
+	}
+	if following {
+		return nil, apperrors.Conflict("already following this seller")
+	}
+
+	follow := &models.Follow{
+		UserID:    userID,
+		SellerID:  sellerID,
+		CreatedAt: time.Now().Unix(),
+	}
+	if err := s.followRepo.Create(follow); err != nil {
+		return nil, apperrors.Internal("failed to follow seller")
+	}
+
+	follow.Seller = *seller
+	return followedSeller(follow), nil
+}
+
+func (s *FollowService) Unfollow(userID, sellerID uint) error {
+	deleted, err := s.followRepo.Delete(userID, sellerID)
+	if err != nil {
+		return apperrors.Internal("failed to unfollow seller")
+	}
+	if !deleted {
+		return apperrors.Validation("not following this seller")
+	}
+	return nil
+}
+
+func (s *FollowService) ListFollowing(userID uint, page, limit int) (*utils.PaginatedResponse, error) {
+	offset := (page - 1) * limit
+	follows, err := s.followRepo.ListByUser(userID, limit, offset)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve followed sellers")
+	}
+
+	total, err := s.followRepo.CountByUser(userID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count followed sellers")
+	}
+
+	sellers := make([]FollowedSeller, 0, len(follows))
+	for i := range follows {
+		sellers = append(sellers, *followedSeller(&follows[i]))
+	}
+
+	return &utils.PaginatedResponse{
+		Success:    true,
+		Message:    "Followed sellers retrieved successfully",
+		Data:       sellers,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, nil
+}
+
+// FanOut is the outbox handler that turns a seller's event.approved,
+// event.published and sale.created messages into one follower.notice per
+// follower. Only events that are publicly listed are announced, and new
+// events only when the seller asked for followers to be notified.
+func (s *FollowService) FanOut(ctx context.Context, message *models.OutboxMessage) error {
+	var source struct {
+		EventID uint  `json:"event_id"`
+		SaleID  uint  `json:"sale_id"`
+		Start   int64 `json:"start_date"`
+	}
+	if err := json.Unmarshal([]byte(message.Payload), &source); err != nil {
+		return fmt.Errorf("invalid payload for %s: %w", message.Topic, err)
+	}
+
+	event, err := s.eventRepo.GetByID(source.EventID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if event.Status != models.EventStatusApproved || event.PublishAt != nil {
+		return nil
+	}
+	if message.Topic != models.OutboxTopicSaleCreated && !event.NotifyFollowers {
+		return nil
+	}
+
+	followers, err := s.followRepo.ListFollowers(event.SellerID)
+	if err != nil || len(followers) == 0 {
+		return err
+	}
+
+	sellerName := event.Seller.DisplayName
+	if sellerName == "" {
+		sellerName = event.Seller.Name + " " + event.Seller.Surname
+	}
+	var saleStartLocal string
+	if source.Start != 0 {
+		saleStartLocal = eventTime(event, source.Start).Format(time.RFC3339)
+	}
+
+	notices := make([]*models.OutboxMessage, 0, len(followers))
+	for _, follow := range followers {
+		notice, err := outbox.NewFanOutMessage(models.OutboxTopicFollowerNotice, outbox.FollowerNoticePayload{
+			UserID:     follow.UserID,
+			Email:      follow.User.Email,
+			SellerID:   event.SellerID,
+			SellerName: sellerName,
+			Reason:     message.Topic,
+			EventID:    event.ID,
+			EventTitle: event.Title,
+			EventDate:  event.Date,
+			SaleID:     source.SaleID,
+			SaleStart:  source.Start,
+			Locale:     follow.User.Locale,
+
+			Timezone:       eventLocation(event).String(),
+			EventDateLocal: eventTime(event, event.Date).Format(time.RFC3339),
+			SaleStartLocal: saleStartLocal,
+		}, message, strconv.FormatUint(uint64(follow.UserID), 10))
+		if err != nil {
+			return err
+		}
+		notices = append(notices, notice)
+	}
+
+	// Followers already notified by an earlier run for this message, retried
+	// because another handler of the topic failed, are skipped
+	return s.outboxRepo.CreateIfAbsent(notices)
+}
+
+func followedSeller(follow *models.Follow) *FollowedSeller {
+	displayName := follow.Seller.DisplayName
+	if displayName == "" {
+		displayName = follow.Seller.Name + " " + follow.Seller.Surname
+	}
+
+	return &FollowedSeller{
+		SellerID:    follow.SellerID,
+		DisplayName: displayName,
+		LogoURL:     follow.Seller.LogoURL,
+		FollowedAt:  follow.CreatedAt,
+	}
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/outbox"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// FraudReviewService is the admin queue of orders the fraud checks held.
+// Approving an order lets the buyer pay for it within the payment grace
+// window; rejecting it releases the tickets. Either way the buyer is told.
+type FraudReviewService struct {
+	orderRepo     repositories.OrderRepository
+	eventRepo     repositories.EventRepository
+	userRepo      repositories.UserRepository
+	outboxRepo    repositories.OutboxRepository
+	txManager     repositories.TransactionManager
+	ticketService *TicketService
+	paymentGrace  time.Duration
+}
+
+// FraudReviewItem is an order waiting for review, with why it was held
+type FraudReviewItem struct {
+	OrderID       uint     `json:"order_id"`
+	UserID        uint     `json:"user_id"`
+	EventID       uint     `json:"event_id"`
+	Title         string   `json:"title"`
+	Quantity      int      `json:"quantity"`
+	TotalAmount   float64  `json:"total_amount"`
+	GiftEmail     string   `json:"gift_recipient_email,omitempty"`
+	ClientIP      string   `json:"client_ip,omitempty"`
+	Reasons       []string `json:"reasons"`
+	CreatedAt     int64    `json:"created_at"`
+	HoldExpiresAt int64    `json:"hold_expires_at"` // Released unreviewed after this
+}
+
+type ReviewDecisionRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
+
+func NewFraudReviewService(
+	orderRepo repositories.OrderRepository,
+	eventRepo repositories.EventRepository,
+	userRepo repositories.UserRepository,
+	outboxRepo repositories.OutboxRepository,
+	txManager repositories.TransactionManager,
+	ticketService *TicketService,
+	paymentGrace time.Duration,
+) *FraudReviewService {
+	return &FraudReviewService{
+		orderRepo:     orderRepo,
+		eventRepo:     eventRepo,
+		userRepo:      userRepo,
+		outboxRepo:    outboxRepo,
+		txManager:     txManager,
+		ticketService: ticketService,
+		paymentGrace:  paymentGrace,
+	}
+}
+
+// ListPending returns the orders waiting for review, oldest first
+func (s *FraudReviewService) ListPending(limit, offset int) ([]FraudReviewItem, int64, error) {
+	orders, err := s.orderRepo.ListUnderReview(limit, offset)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to load orders under review")
+	}
+	total, err := s.orderRepo.CountUnderReview()
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to count orders under review")
+	}
+
+	items := make([]FraudReviewItem, 0, len(orders))
+	for _, order := range orders {
+		items = append(items, FraudReviewItem{
+			OrderID:       order.ID,
+			UserID:        order.UserID,
+			EventID:       order.EventID,
+			Title:         order.Title,
+			Quantity:      order.Quantity,
+			TotalAmount:   order.TotalAmount,
+			GiftEmail:     order.GiftEmail,
+			ClientIP:      order.ClientIP,
+			Reasons:       strings.Split(order.FraudReasons, "; "),
+			CreatedAt:     order.CreatedAt,
+			HoldExpiresAt: order.HoldExpiresAt,
+		})
+	}
+	return items, total, nil
+}
+
+// Approve lets the buyer pay for the order, from now until the payment
+// grace window has passed
+func (s *FraudReviewService) Approve(orderID, adminID uint, req *ReviewDecisionRequest) (*models.Order, error) {
+	order, err := s.pendingOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		orderRepo := s.orderRepo.WithTx(tx)
+		approved, err := orderRepo.TransitionStatus(order.ID, models.OrderStatusUnderReview, models.OrderStatusAwaitingPayment)
+		if err != nil {
+			return err
+		}
+		if !approved {
+			// Another admin decided, or the hold ran out, first
+			return apperrors.Conflict("order is no longer waiting for review")
+		}
+
+		order.Status = models.OrderStatusAwaitingPayment
+		order.HoldExpiresAt = now.Add(s.paymentGrace).Unix()
+		s.recordDecision(order, adminID, now.Unix(), req.Note)
+		if err := orderRepo.Update(order); err != nil {
+			return err
+		}
+		return s.notify(tx, order, true)
+	})
+	if err != nil {
+		if _, ok := apperrors.As(err); ok {
+			return nil, err
+		}
+		return nil, apperrors.Internal("failed to approve order")
+	}
+	return order, nil
+}
+
+// Reject refuses the order and releases its tickets
+func (s *FraudReviewService) Reject(orderID, adminID uint, req *ReviewDecisionRequest) (*models.Order, error) {
+	order, err := s.pendingOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordDecision(order, adminID, time.Now().Unix(), req.Note)
+	err = s.ticketService.releaseOrder(order, models.OrderStatusRejected, func(tx *gorm.DB) error {
+		return s.notify(tx, order, false)
+	})
+	if err != nil {
+		if apperrors.CodeOf(err) == apperrors.CodeConflict {
+			return nil, apperrors.Conflict("order is no longer waiting for review")
+		}
+		return nil, apperrors.Internal("failed to reject order")
+	}
+	return order, nil
+}
+
+func (s *FraudReviewService) pendingOrder(orderID uint) (*models.Order, error) {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+	if order.Status != models.OrderStatusUnderReview {
+		return nil, apperrors.Conflict("order is not waiting for review")
+	}
+	return order, nil
+}
+
+func (s *FraudReviewService) recordDecision(order *models.Order, adminID uint, at int64, note string) {
+	order.ReviewedBy = &adminID
+	order.ReviewedAt = &at
+	order.ReviewNote = strings.TrimSpace(note)
+}
+
+// notify queues the buyer's notice of the decision within tx
+func (s *FraudReviewService) notify(tx *gorm.DB, order *models.Order, approved bool) error {
+	buyer, err := s.userRepo.GetByID(order.UserID)
+	if err != nil {
+		return err
+	}
+	event, err := s.eventRepo.GetByID(order.EventID)
+	if err != nil {
+		return err
+	}
+
+	payload := outbox.OrderReviewedPayload{
+		OrderID:    order.ID,
+		EventID:    order.EventID,
+		EventTitle: event.Title,
+		UserID:     buyer.ID,
+		Email:      buyer.Email,
+		Approved:   approved,
+		Note:       order.ReviewNote,
+		Locale:     buyer.Locale,
+	}
+	if approved {
+		payload.PayBy = order.HoldExpiresAt
+	}
+
+	message, err := outbox.NewMessage(models.OutboxTopicOrderReviewed, payload)
+	if err != nil {
+		return err
+	}
+	return s.outboxRepo.WithTx(tx).Create(message)
+}
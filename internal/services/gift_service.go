@@ -0,0 +1,97 @@
+// internal/services/gift_service.go
+package services
+
+import (
+	"errors"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type GiftService struct {
+	giftRepo            repositories.GiftRepository
+	purchasedTicketRepo repositories.PurchasedTicketRepository
+	txManager           repositories.TransactionManager
+}
+
+type GiftInfo struct {
+	RecipientEmail string            `json:"recipient_email"`
+	Message        string            `json:"message,omitempty"`
+	Status         models.GiftStatus `json:"status"`
+}
+
+func NewGiftService(
+	giftRepo repositories.GiftRepository,
+	purchasedTicketRepo repositories.PurchasedTicketRepository,
+	txManager repositories.TransactionManager,
+) *GiftService {
+	return &GiftService{
+		giftRepo:            giftRepo,
+		purchasedTicketRepo: purchasedTicketRepo,
+		txManager:           txManager,
+	}
+}
+
+// ClaimPendingGifts hands tickets gifted to email over to the newly
+// registered user. Tickets the buyer has since given away are skipped.
+func (s *GiftService) ClaimPendingGifts(userID uint, email string) (int, error) {
+	gifts, err := s.giftRepo.ListPendingByEmail(email)
+	if err != nil {
+		return 0, apperrors.Internal("failed to look up pending gifts")
+	}
+
+	claimed := 0
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		giftRepo := s.giftRepo.WithTx(tx)
+		purchasedTicketRepo := s.purchasedTicketRepo.WithTx(tx)
+		now := time.Now().Unix()
+
+		for i := range gifts {
+			gift := &gifts[i]
+
+			ticket, err := purchasedTicketRepo.GetByID(gift.PurchasedTicketID)
+			if err != nil || ticket.UserID != gift.SenderID {
+				continue
+			}
+
+			moved, err := purchasedTicketRepo.UpdateOwnership(ticket.ID, gift.SenderID, userID)
+			if err != nil {
+				return err
+			}
+			if !moved {
+				continue
+			}
+
+			gift.RecipientID = &userID
+			gift.Status = models.GiftStatusDelivered
+			gift.DeliveredAt = &now
+			if err := giftRepo.Update(gift); err != nil {
+				return err
+			}
+			claimed++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, apperrors.Internal("failed to claim pending gifts")
+	}
+
+	return claimed, nil
+}
+
+// GetGiftForTicket returns the gift attached to a purchased ticket, or nil
+// if the ticket was not bought as a gift
+func (s *GiftService) GetGiftForTicket(purchasedTicketID uint) (*models.TicketGift, error) {
+	gift, err := s.giftRepo.GetByPurchasedTicket(purchasedTicketID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, apperrors.Internal("failed to load gift")
+	}
+	return gift, nil
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"log"
+	"math/big"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/outbox"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// LotteryService runs lottery sales. Buyers register until the sale
+// starts, the lottery_draw job then picks the winners at random, and only
+// winners may buy, once each, within the claim window.
+type LotteryService struct {
+	lotteryRepo repositories.LotteryRepository
+	saleRepo    repositories.SaleRepository
+	outboxRepo  repositories.OutboxRepository
+	txManager   repositories.TransactionManager
+	claimWindow time.Duration
+}
+
+// LotteryEntryResponse is where the user's registration for a lottery
+// sale stands
+type LotteryEntryResponse struct {
+	SaleID     uint                      `json:"sale_id"`
+	EventID    uint                      `json:"event_id"`
+	Status     models.LotteryEntryStatus `json:"status"`
+	DrawAt     int64                     `json:"draw_at"`               // When registration closes and winners are drawn
+	PurchaseBy *int64                    `json:"purchase_by,omitempty"` // Winners buy before this Unix timestamp
+}
+
+func NewLotteryService(
+	lotteryRepo repositories.LotteryRepository,
+	saleRepo repositories.SaleRepository,
+	outboxRepo repositories.OutboxRepository,
+	txManager repositories.TransactionManager,
+	claimWindow time.Duration,
+) *LotteryService {
+	return &LotteryService{
+		lotteryRepo: lotteryRepo,
+		saleRepo:    saleRepo,
+		outboxRepo:  outboxRepo,
+		txManager:   txManager,
+		claimWindow: claimWindow,
+	}
+}
+
+// Register enters the user in a lottery sale's draw
+func (s *LotteryService) Register(saleID, userID uint) (*LotteryEntryResponse, error) {
+	sale, err := s.openLottery(saleID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.lotteryRepo.GetBySaleAndUser(saleID, userID)
+	if err == nil {
+		return nil, apperrors.Conflict("already registered for this lottery")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.Internal("failed to check lottery registration")
+	}
+
+	entry := &models.LotteryEntry{
+		SaleID:    saleID,
+		UserID:    userID,
+		Status:    models.LotteryEntryStatusRegistered,
+		CreatedAt: time.Now().Unix(),
+	}
+	if err := s.lotteryRepo.Create(entry); err != nil {
+		return nil, apperrors.Internal("failed to register for the lottery")
+	}
+
+	return entryResponse(entry, sale), nil
+}
+
+// Withdraw takes the user out of a draw that hasn't happened yet
+func (s *LotteryService) Withdraw(saleID, userID uint) error {
+	if _, err := s.openLottery(saleID); err != nil {
+		return err
+	}
+
+	entry, err := s.lotteryRepo.GetBySaleAndUser(saleID, userID)
+	if err != nil {
+		return apperrors.NotFound("not registered for this lottery")
+	}
+	if err := s.lotteryRepo.Delete(entry.ID); err != nil {
+		return apperrors.Internal("failed to withdraw from the lottery")
+	}
+	return nil
+}
+
+// GetEntry returns the user's registration for a lottery sale
+func (s *LotteryService) GetEntry(saleID, userID uint) (*LotteryEntryResponse, error) {
+	sale, err := s.saleRepo.GetByID(saleID)
+	if err != nil {
+		return nil, apperrors.NotFound("sale not found")
+	}
+
+	entry, err := s.lotteryRepo.GetBySaleAndUser(saleID, userID)
+	if err != nil {
+		return nil, apperrors.NotFound("not registered for this lottery")
+	}
+	return entryResponse(entry, sale), nil
+}
+
+// DrawDue draws every lottery sale whose registration has closed, up to
+// limit of them, and returns how many were drawn
+func (s *LotteryService) DrawDue(limit int) (int, error) {
+	now := time.Now()
+	sales, err := s.saleRepo.ListLotteriesToDraw(now.Unix(), limit)
+	if err != nil {
+		return 0, apperrors.Internal("failed to list lotteries to draw")
+	}
+
+	drawn := 0
+	for i := range sales {
+		if err := s.draw(&sales[i], now); err != nil {
+			log.Printf("lottery: failed to draw sale %d: %v", sales[i].ID, err)
+			continue
+		}
+		drawn++
+	}
+	return drawn, nil
+}
+
+// draw picks the sale's winners and tells every entrant the result. The
+// sale is marked drawn in the same transaction, so a draw never runs twice.
+func (s *LotteryService) draw(sale *models.Sale, now time.Time) error {
+	purchaseBy := now.Add(s.claimWindow).Unix()
+	if purchaseBy > sale.EndDate {
+		purchaseBy = sale.EndDate
+	}
+
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		marked, err := s.saleRepo.WithTx(tx).MarkLotteryDrawn(sale.ID, now.Unix())
+		if err != nil || !marked {
+			return err
+		}
+
+		lotteryRepo := s.lotteryRepo.WithTx(tx)
+		entries, err := lotteryRepo.ListRegistered(sale.ID)
+		if err != nil {
+			return err
+		}
+		if err := shuffleEntries(entries); err != nil {
+			return err
+		}
+
+		winners := sale.LotteryWinners
+		if winners > len(entries) {
+			winners = len(entries)
+		}
+		winnerIDs := make([]uint, 0, winners)
+		for _, entry := range entries[:winners] {
+			winnerIDs = append(winnerIDs, entry.ID)
+		}
+		if err := lotteryRepo.SetResults(sale.ID, winnerIDs, purchaseBy); err != nil {
+			return err
+		}
+
+		outboxRepo := s.outboxRepo.WithTx(tx)
+		for i, entry := range entries {
+			payload := outbox.LotteryResultPayload{
+				SaleID:     sale.ID,
+				EventID:    sale.EventID,
+				EventTitle: sale.Event.Title,
+				UserID:     entry.UserID,
+				Email:      entry.User.Email,
+				Won:        i < winners,
+				Locale:     entry.User.Locale,
+			}
+			if payload.Won {
+				payload.PurchaseBy = purchaseBy
+			}
+			message, err := outbox.NewMessage(models.OutboxTopicLotteryResult, payload)
+			if err != nil {
+				return err
+			}
+			if err := outboxRepo.Create(message); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// openLottery returns a lottery sale still taking registrations
+func (s *LotteryService) openLottery(saleID uint) (*models.Sale, error) {
+	sale, err := s.saleRepo.GetByID(saleID)
+	if err != nil {
+		return nil, apperrors.NotFound("sale not found")
+	}
+	if sale.Mode != models.SaleModeLottery {
+		return nil, apperrors.Validation("this sale is not a lottery")
+	}
+	if sale.LotteryDrawnAt != nil || time.Now().Unix() >= sale.StartDate {
+		return nil, apperrors.Validation("lottery registration has closed")
+	}
+	if !eventOnSale(&sale.Event, time.Now().Unix()) {
+		return nil, ErrEventNotOnSale
+	}
+	return sale, nil
+}
+
+// shuffleEntries puts entries in a uniformly random order with a
+// Fisher-Yates shuffle over crypto/rand, so draws can't be predicted
+func shuffleEntries(entries []models.LotteryEntry) error {
+	for i := len(entries) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return err
+		}
+		j := int(n.Int64())
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return nil
+}
+
+func entryResponse(entry *models.LotteryEntry, sale *models.Sale) *LotteryEntryResponse {
+	return &LotteryEntryResponse{
+		SaleID:     entry.SaleID,
+		EventID:    sale.EventID,
+		Status:     entry.Status,
+		DrawAt:     sale.StartDate,
+		PurchaseBy: entry.PurchaseBy,
+	}
+}
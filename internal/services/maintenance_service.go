@@ -0,0 +1,91 @@
+package services
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+)
+
+// maintenanceCacheTTL is how long the switch is served from memory. It is
+// checked on every request, so other instances follow a change within it.
+const maintenanceCacheTTL = 5 * time.Second
+
+// MaintenanceService holds the maintenance switch admins flip during
+// migrations and other work that can't run alongside live traffic
+type MaintenanceService struct {
+	maintenanceRepo repositories.MaintenanceRepository
+
+	mutex    sync.RWMutex
+	mode     models.MaintenanceMode
+	loadedAt time.Time
+}
+
+type UpdateMaintenanceRequest struct {
+	Enabled  bool   `json:"enabled"`
+	ReadOnly bool   `json:"read_only"` // Keep serving reads; refuse only changes
+	Message  string `json:"message" binding:"max=500"`
+	EndsAt   *int64 `json:"ends_at" binding:"omitempty,unixtime"`
+}
+
+func NewMaintenanceService(maintenanceRepo repositories.MaintenanceRepository) *MaintenanceService {
+	return &MaintenanceService{maintenanceRepo: maintenanceRepo}
+}
+
+// Current returns the maintenance switch. When it can't be read the last
+// known state is kept, which is off until it has been read once.
+func (s *MaintenanceService) Current() models.MaintenanceMode {
+	s.mutex.RLock()
+	mode, loadedAt := s.mode, s.loadedAt
+	s.mutex.RUnlock()
+	if time.Since(loadedAt) < maintenanceCacheTTL {
+		return mode
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if time.Since(s.loadedAt) >= maintenanceCacheTTL {
+		s.loadedAt = time.Now()
+		stored, err := s.maintenanceRepo.Get()
+		if err != nil {
+			log.Printf("maintenance: failed to load switch: %v", err)
+		} else {
+			s.mode = *stored
+		}
+	}
+	return s.mode
+}
+
+func (s *MaintenanceService) Update(req *UpdateMaintenanceRequest, adminID uint) (*models.MaintenanceMode, error) {
+	mode := &models.MaintenanceMode{
+		Enabled:   req.Enabled,
+		ReadOnly:  req.ReadOnly,
+		Message:   req.Message,
+		EndsAt:    req.EndsAt,
+		UpdatedBy: adminID,
+		UpdatedAt: time.Now().Unix(),
+	}
+	if !mode.Enabled {
+		mode.ReadOnly = false
+		mode.EndsAt = nil
+	}
+
+	if err := s.maintenanceRepo.Save(mode); err != nil {
+		return nil, apperrors.Internal("failed to update maintenance mode")
+	}
+
+	s.mutex.Lock()
+	s.mode = *mode
+	s.loadedAt = time.Now()
+	s.mutex.Unlock()
+
+	if mode.Enabled {
+		log.Printf("maintenance: enabled by admin %d (read-only: %t)", adminID, mode.ReadOnly)
+	} else {
+		log.Printf("maintenance: disabled by admin %d", adminID)
+	}
+	return mode, nil
+}
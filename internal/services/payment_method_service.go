@@ -2,41 +2,30 @@
 package services
 
 import (
-	"crypto/rand"
-	"encoding/hex"
-	"encoding/json"
+	"context"
 	"errors"
+	"fmt"
 
 	"eticketing/internal/models"
+	"eticketing/internal/payments"
 	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
 )
 
 type PaymentMethodService struct {
 	paymentMethodRepo repositories.PaymentMethodRepository
+	providers         *payments.Registry
 }
 
+// CreatePaymentMethodRequest carries the token the client obtained by
+// sending card or wallet details straight to the provider (Stripe.js,
+// Braintree Drop-in). Raw card data is never accepted by the backend.
 type CreatePaymentMethodRequest struct {
-	UserID      uint                    `json:"-"` // Set by handler
-	Type        models.PaymentType      `json:"type" binding:"required"`
-	PaymentData CreatePaymentMethodData `json:"payment_data" binding:"required"`
-	IsDefault   bool                    `json:"is_default"`
-}
-
-type CreatePaymentMethodData struct {
-	// For Credit Card
-	CardNumber string `json:"card_number,omitempty"`
-	ExpiryDate string `json:"expiry_date,omitempty"`
-	CVV        string `json:"cvv,omitempty"`
-	CardHolder string `json:"card_holder,omitempty"`
-
-	// For PayPal
-	PayPalEmail string `json:"paypal_email,omitempty"`
-
-	// For Apple Pay
-	AppleID string `json:"apple_id,omitempty"`
-
-	// For Google Pay
-	GoogleEmail string `json:"google_email,omitempty"`
+	UserID    uint               `json:"-"` // Set by handler
+	Type      models.PaymentType `json:"type" binding:"required"`
+	Provider  string             `json:"provider" binding:"required,oneof=stripe braintree"`
+	Token     string             `json:"token" binding:"required,max=255"`
+	IsDefault bool               `json:"is_default"`
 }
 
 type UpdatePaymentMethodRequest struct {
@@ -48,55 +37,67 @@ type PaymentMethodResponse struct {
 	ID         uint               `json:"id"`
 	Type       models.PaymentType `json:"type"`
 	TypeName   string             `json:"type_name"`
+	Provider   string             `json:"provider"`
+	Brand      string             `json:"brand,omitempty"`
 	MaskedData map[string]string  `json:"masked_data"`
-	Token      string             `json:"token"`
 	IsDefault  bool               `json:"is_default"`
 	Nickname   string             `json:"nickname,omitempty"`
 }
 
-func NewPaymentMethodService(paymentMethodRepo repositories.PaymentMethodRepository) *PaymentMethodService {
+func NewPaymentMethodService(paymentMethodRepo repositories.PaymentMethodRepository, providers *payments.Registry) *PaymentMethodService {
 	return &PaymentMethodService{
 		paymentMethodRepo: paymentMethodRepo,
+		providers:         providers,
 	}
 }
 
-func (s *PaymentMethodService) CreatePaymentMethod(req *CreatePaymentMethodRequest) (*PaymentMethodResponse, error) {
-	// Validate payment data based on type
-	if err := s.validatePaymentData(req.Type, req.PaymentData); err != nil {
+func (s *PaymentMethodService) CreatePaymentMethod(ctx context.Context, req *CreatePaymentMethodRequest) (*PaymentMethodResponse, error) {
+	provider, err := s.providers.Get(req.Provider)
+	if err != nil {
 		return nil, err
 	}
 
-	// Generate mock token
-	token, err := s.generateMockToken()
+	// Card brand, last digits and expiry come from the provider, not the client
+	instrument, err := provider.Retrieve(ctx, req.Token)
 	if err != nil {
-		return nil, errors.New("failed to generate payment token")
+		if errors.Is(err, payments.ErrUnknownToken) {
+			return nil, apperrors.Validation("invalid payment token")
+		}
+		if errors.Is(err, payments.ErrProviderUnavailable) {
+			return nil, apperrors.Internal("payment provider is unavailable, please retry shortly")
+		}
+		return nil, apperrors.Internal("failed to verify payment token with provider")
 	}
 
-	// Convert payment data to JSON
-	dataJSON, err := json.Marshal(req.PaymentData)
-	if err != nil {
-		return nil, errors.New("failed to process payment data")
+	if err := s.validateInstrument(req.Type, instrument); err != nil {
+		return nil, err
 	}
 
 	// If this is the first payment method, make it default
 	if req.IsDefault {
 		err := s.paymentMethodRepo.ClearDefaultForUser(req.UserID)
 		if err != nil {
-			return nil, errors.New("failed to clear existing default")
+			return nil, apperrors.Internal("failed to clear existing default")
 		}
 	}
 
 	// Create payment method
 	paymentMethod := &models.PaymentMethod{
 		Type:      req.Type,
-		Token:     token,
-		Data:      string(dataJSON),
+		Provider:  provider.Name(),
+		Token:     req.Token,
+		Brand:     instrument.Brand,
+		Last4:     instrument.Last4,
+		ExpMonth:  instrument.ExpMonth,
+		ExpYear:   instrument.ExpYear,
+		Email:     instrument.Email,
 		UserID:    req.UserID,
+		UserType:  models.UserTypeUser,
 		IsDefault: req.IsDefault,
 	}
 
 	if err := s.paymentMethodRepo.Create(paymentMethod); err != nil {
-		return nil, errors.New("failed to create payment method")
+		return nil, apperrors.Internal("failed to create payment method")
 	}
 
 	return s.buildPaymentMethodResponse(paymentMethod), nil
@@ -105,7 +106,7 @@ func (s *PaymentMethodService) CreatePaymentMethod(req *CreatePaymentMethodReque
 func (s *PaymentMethodService) GetUserPaymentMethods(userID uint) ([]PaymentMethodResponse, error) {
 	methods, err := s.paymentMethodRepo.ListByUser(userID)
 	if err != nil {
-		return nil, errors.New("failed to retrieve payment methods")
+		return nil, apperrors.Internal("failed to retrieve payment methods")
 	}
 
 	var responses []PaymentMethodResponse
@@ -119,11 +120,11 @@ func (s *PaymentMethodService) GetUserPaymentMethods(userID uint) ([]PaymentMeth
 func (s *PaymentMethodService) GetPaymentMethod(methodID, userID uint) (*PaymentMethodResponse, error) {
 	method, err := s.paymentMethodRepo.GetByID(methodID)
 	if err != nil {
-		return nil, errors.New("payment method not found")
+		return nil, apperrors.NotFound("payment method not found")
 	}
 
 	if method.UserID != userID {
-		return nil, errors.New("unauthorized to access this payment method")
+		return nil, apperrors.Forbidden("unauthorized to access this payment method")
 	}
 
 	return s.buildPaymentMethodResponse(method), nil
@@ -132,23 +133,23 @@ func (s *PaymentMethodService) GetPaymentMethod(methodID, userID uint) (*Payment
 func (s *PaymentMethodService) UpdatePaymentMethod(methodID, userID uint, req *UpdatePaymentMethodRequest) error {
 	method, err := s.paymentMethodRepo.GetByID(methodID)
 	if err != nil {
-		return errors.New("payment method not found")
+		return apperrors.NotFound("payment method not found")
 	}
 
 	if method.UserID != userID {
-		return errors.New("unauthorized to update this payment method")
+		return apperrors.Forbidden("unauthorized to update this payment method")
 	}
 
 	if req.IsDefault != nil && *req.IsDefault {
 		err := s.paymentMethodRepo.ClearDefaultForUser(userID)
 		if err != nil {
-			return errors.New("failed to clear existing default")
+			return apperrors.Internal("failed to clear existing default")
 		}
 		method.IsDefault = true
 	}
 
 	if err := s.paymentMethodRepo.Update(method); err != nil {
-		return errors.New("failed to update payment method")
+		return apperrors.Internal("failed to update payment method")
 	}
 
 	return nil
@@ -157,15 +158,15 @@ func (s *PaymentMethodService) UpdatePaymentMethod(methodID, userID uint, req *U
 func (s *PaymentMethodService) DeletePaymentMethod(methodID, userID uint) error {
 	method, err := s.paymentMethodRepo.GetByID(methodID)
 	if err != nil {
-		return errors.New("payment method not found")
+		return apperrors.NotFound("payment method not found")
 	}
 
 	if method.UserID != userID {
-		return errors.New("unauthorized to delete this payment method")
+		return apperrors.Forbidden("unauthorized to delete this payment method")
 	}
 
 	if err := s.paymentMethodRepo.Delete(methodID); err != nil {
-		return errors.New("failed to delete payment method")
+		return apperrors.Internal("failed to delete payment method")
 	}
 
 	return nil
@@ -174,66 +175,50 @@ func (s *PaymentMethodService) DeletePaymentMethod(methodID, userID uint) error
 func (s *PaymentMethodService) SetDefaultPaymentMethod(methodID, userID uint) error {
 	method, err := s.paymentMethodRepo.GetByID(methodID)
 	if err != nil {
-		return errors.New("payment method not found")
+		return apperrors.NotFound("payment method not found")
 	}
 
 	if method.UserID != userID {
-		return errors.New("unauthorized to modify this payment method")
+		return apperrors.Forbidden("unauthorized to modify this payment method")
 	}
 
 	// Clear existing default
 	err = s.paymentMethodRepo.ClearDefaultForUser(userID)
 	if err != nil {
-		return errors.New("failed to clear existing default")
+		return apperrors.Internal("failed to clear existing default")
 	}
 
 	// Set new default
 	method.IsDefault = true
 	if err := s.paymentMethodRepo.Update(method); err != nil {
-		return errors.New("failed to set default payment method")
+		return apperrors.Internal("failed to set default payment method")
 	}
 
 	return nil
 }
 
-func (s *PaymentMethodService) validatePaymentData(paymentType models.PaymentType, data CreatePaymentMethodData) error {
+// validateInstrument checks the vaulted instrument is the kind the client
+// claims, so a PayPal token can't be stored as a card
+func (s *PaymentMethodService) validateInstrument(paymentType models.PaymentType, instrument *payments.Instrument) error {
 	switch paymentType {
-	case models.PaymentTypeCard:
-		if data.CardNumber == "" || data.ExpiryDate == "" || data.CVV == "" || data.CardHolder == "" {
-			return errors.New("card number, expiry date, CVV, and card holder are required for credit card")
-		}
-	case models.PaymentTypePayPal:
-		if data.PayPalEmail == "" {
-			return errors.New("PayPal email is required")
-		}
-	case models.PaymentTypeGooglePay:
-		if data.GoogleEmail == "" {
-			return errors.New("Google email is required")
-		}
+	case models.PaymentTypeCard, models.PaymentTypePayPal, models.PaymentTypeGooglePay, models.PaymentTypeApplePay:
 	default:
-		return errors.New("unsupported payment type")
+		return apperrors.Validation("unsupported payment type")
 	}
-	return nil
-}
 
-func (s *PaymentMethodService) generateMockToken() (string, error) {
-	bytes := make([]byte, 32)
-	_, err := rand.Read(bytes)
-	if err != nil {
-		return "", err
+	if instrument.Type != paymentType {
+		return apperrors.Validation("payment token does not match the selected payment type")
 	}
-	return "mock_" + hex.EncodeToString(bytes)[:32], nil
+	return nil
 }
 
 func (s *PaymentMethodService) buildPaymentMethodResponse(method *models.PaymentMethod) *PaymentMethodResponse {
-	var data CreatePaymentMethodData
-	_ = json.Unmarshal([]byte(method.Data), &data)
-
 	response := &PaymentMethodResponse{
 		ID:         method.ID,
 		Type:       method.Type,
 		TypeName:   s.getPaymentTypeName(method.Type),
-		Token:      method.Token,
+		Provider:   method.Provider,
+		Brand:      method.Brand,
 		IsDefault:  method.IsDefault,
 		MaskedData: make(map[string]string),
 	}
@@ -241,13 +226,17 @@ func (s *PaymentMethodService) buildPaymentMethodResponse(method *models.Payment
 	// Create masked data based on type
 	switch method.Type {
 	case models.PaymentTypeCard:
-		response.MaskedData["card_number"] = s.maskCardNumber(data.CardNumber)
-		response.MaskedData["card_holder"] = data.CardHolder
-		response.MaskedData["expiry_date"] = data.ExpiryDate
+		response.MaskedData["card_number"] = s.maskCardNumber(method.Last4)
+		if method.ExpMonth > 0 {
+			response.MaskedData["expiry_date"] = fmt.Sprintf("%02d/%02d", method.ExpMonth, method.ExpYear%100)
+		}
 	case models.PaymentTypePayPal:
-		response.MaskedData["email"] = s.maskEmail(data.PayPalEmail)
+		response.MaskedData["email"] = s.maskEmail(method.Email)
 	case models.PaymentTypeGooglePay:
-		response.MaskedData["email"] = s.maskEmail(data.GoogleEmail)
+		response.MaskedData["email"] = s.maskEmail(method.Email)
+	case models.PaymentTypeApplePay:
+		// Apple Pay exposes the device account number, not the card's
+		response.MaskedData["device_account"] = s.maskCardNumber(method.Last4)
 	}
 
 	return response
@@ -263,16 +252,22 @@ func (s *PaymentMethodService) getPaymentTypeName(paymentType models.PaymentType
 		return "Google Pay"
 	case models.PaymentTypeStripe:
 		return "Stripe"
+	case models.PaymentTypeApplePay:
+		return "Apple Pay"
+	case models.PaymentTypeInvoice:
+		return "Invoice"
+	case models.PaymentTypeSplit:
+		return "Split Payment"
 	default:
 		return "Unknown"
 	}
 }
 
-func (s *PaymentMethodService) maskCardNumber(cardNumber string) string {
-	if len(cardNumber) < 4 {
+func (s *PaymentMethodService) maskCardNumber(last4 string) string {
+	if len(last4) != 4 {
 		return "****"
 	}
-	return "**** **** **** " + cardNumber[len(cardNumber)-4:]
+	return "**** **** **** " + last4
 }
 
 func (s *PaymentMethodService) maskEmail(email string) string {
@@ -2,20 +2,63 @@
 package services
 
 import (
+	"context"
 	"errors"
-	"eticketing/internal/utils"
 	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"eticketing/internal/models"
+	"eticketing/internal/outbox"
+	"eticketing/internal/payments"
 	"eticketing/internal/repositories"
+	"eticketing/internal/tracing"
+	apperrors "eticketing/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
 )
 
+// platformFeeRate is the share of each customer payment kept by the
+// platform; the seller is credited the rest. It holds a float64 and is
+// replaced when the configuration is reloaded.
+var platformFeeRate atomic.Value
+
+func init() {
+	platformFeeRate.Store(0.05)
+}
+
+// PlatformFeeRate is the fee rate new payments are split with
+func PlatformFeeRate() float64 {
+	return platformFeeRate.Load().(float64)
+}
+
+func SetPlatformFeeRate(rate float64) {
+	platformFeeRate.Store(rate)
+}
+
+// creditedFeeRate is the fee rate the seller was credited at for payment.
+// Payments that never credited a seller fall back to the current rate.
+func creditedFeeRate(payment *models.Payment) float64 {
+	if payment.PlatformFeeRate != nil {
+		return *payment.PlatformFeeRate
+	}
+	return PlatformFeeRate()
+}
+
 type PaymentService struct {
-	paymentRepo repositories.PaymentRepository
-	eventRepo   repositories.EventRepository
-	sellerRepo  repositories.SellerRepository
-	mockMode    bool
+	paymentRepo         repositories.PaymentRepository
+	paymentMethodRepo   repositories.PaymentMethodRepository
+	purchasedTicketRepo repositories.PurchasedTicketRepository
+	providers           *payments.Registry
+	eventRepo           repositories.EventRepository
+	eventAccess         *EventAccess
+	sellerRepo          repositories.SellerRepository
+	outboxRepo          repositories.OutboxRepository
+	txManager           repositories.TransactionManager
+	mockMode            bool
 }
 
 type PaymentRequest struct {
@@ -25,6 +68,22 @@ type PaymentRequest struct {
 	PaymentMethod models.PaymentType `json:"payment_method"`
 	Description   string             `json:"description"`
 	EventID       uint               `json:"event_id,omitempty"`
+	Splits        []PaymentSplit     `json:"splits,omitempty"` // Pay from several stored methods instead of PaymentMethod
+
+	// Stored method to charge; takes precedence over PaymentMethod
+	PaymentMethodID  uint `json:"payment_method_id,omitempty"`
+	UseDefaultMethod bool `json:"use_default_method,omitempty"`
+
+	// Recorded on the payment for fraud investigations
+	ClientIP          string `json:"-"`
+	DeviceFingerprint string `json:"-"`
+}
+
+// PaymentSplit charges part of an order to a stored payment method. At most
+// one split may leave Amount at 0 to cover whatever the others don't.
+type PaymentSplit struct {
+	PaymentMethodID uint    `json:"payment_method_id" binding:"required"`
+	Amount          float64 `json:"amount" binding:"min=0"`
 }
 
 type PaymentResponse struct {
@@ -33,6 +92,34 @@ type PaymentResponse struct {
 	Amount        float64              `json:"amount"`
 	TransactionID string               `json:"transaction_id"`
 	Message       string               `json:"message"`
+	Components    []PaymentResponse    `json:"components,omitempty"`
+
+	// Filled in when a payment is looked up on its own
+	Provider       string              `json:"provider,omitempty"`
+	Date           int64               `json:"date,omitempty"`
+	Type           models.PaymentType  `json:"type,omitempty"`
+	RefundedAmount float64             `json:"refunded_amount,omitempty"`
+	Event          *PaymentEventInfo   `json:"event,omitempty"`
+	Tickets        []PaymentTicketInfo `json:"tickets,omitempty"` // What the payment bought
+
+	// Shown to admins only, while retained
+	ClientIP          string `json:"client_ip,omitempty"`
+	DeviceFingerprint string `json:"device_fingerprint,omitempty"`
+}
+
+type PaymentEventInfo struct {
+	ID    uint   `json:"id"`
+	Title string `json:"title"`
+	Date  int64  `json:"date"`
+}
+
+type PaymentTicketInfo struct {
+	ID            uint    `json:"id"`
+	Title         string  `json:"title"`
+	Place         string  `json:"place"`
+	Price         float64 `json:"price"`
+	IsUsed        bool    `json:"is_used"`
+	IsInvalidated bool    `json:"is_invalidated"`
 }
 
 type PaymentInfo struct {
@@ -47,66 +134,467 @@ type PaymentInfo struct {
 	PaymentType string               `json:"payment_type"` // "incoming" or "outgoing"
 }
 
-func NewPaymentService(paymentRepo repositories.PaymentRepository, eventRepo repositories.EventRepository, sellerRepo repositories.SellerRepository, mockMode bool) *PaymentService {
+func NewPaymentService(
+	paymentRepo repositories.PaymentRepository,
+	paymentMethodRepo repositories.PaymentMethodRepository,
+	purchasedTicketRepo repositories.PurchasedTicketRepository,
+	providers *payments.Registry,
+	eventRepo repositories.EventRepository,
+	eventAccess *EventAccess,
+	sellerRepo repositories.SellerRepository,
+	outboxRepo repositories.OutboxRepository,
+	txManager repositories.TransactionManager,
+	mockMode bool,
+) *PaymentService {
 	return &PaymentService{
-		paymentRepo: paymentRepo,
-		eventRepo:   eventRepo,
-		sellerRepo:  sellerRepo,
-		mockMode:    mockMode,
+		paymentRepo:         paymentRepo,
+		paymentMethodRepo:   paymentMethodRepo,
+		purchasedTicketRepo: purchasedTicketRepo,
+		providers:           providers,
+		eventRepo:           eventRepo,
+		eventAccess:         eventAccess,
+		sellerRepo:          sellerRepo,
+		outboxRepo:          outboxRepo,
+		txManager:           txManager,
+		mockMode:            mockMode,
 	}
 }
 
-func (s *PaymentService) ProcessPayment(req *PaymentRequest) (*PaymentResponse, error) {
+func (s *PaymentService) ProcessPayment(ctx context.Context, req *PaymentRequest) (resp *PaymentResponse, err error) {
+	ctx, span := tracing.StartSpan(ctx, "PaymentService.ProcessPayment",
+		attribute.Float64("payment.amount", req.Amount),
+		attribute.Int("payment.method", int(req.PaymentMethod)),
+		attribute.Bool("payment.mocked", s.mockMode),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	if req.Amount <= 0 {
-		return nil, errors.New("payment amount must be greater than 0")
+		return nil, apperrors.Validation("payment amount must be greater than 0")
+	}
+
+	if len(req.Splits) > 0 {
+		return s.processSplitPayment(ctx, req)
+	}
+
+	// A stored method (picked explicitly or the user's default) is charged
+	// through its provider token; otherwise the bare payment type is used
+	method, err := s.resolveStoredMethod(req)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentType := req.PaymentMethod
+	var paymentMethodID *uint
+	if method != nil {
+		paymentType = method.Type
+		paymentMethodID = &method.ID
+	}
+
+	switch paymentType {
+	case models.PaymentTypeCard, models.PaymentTypePayPal, models.PaymentTypeGooglePay,
+		models.PaymentTypeApplePay, models.PaymentTypeStripe, models.PaymentTypeInvoice:
+	case 0:
+		return nil, apperrors.Validation("no payment method selected")
+	default:
+		return nil, apperrors.Validation("unsupported payment method")
 	}
 
 	// Create customer payment record
 	customerPayment := &models.Payment{
+		UserID:          req.UserID,
+		UserType:        req.UserType,
+		Date:            time.Now().Unix(),
+		Type:            paymentType,
+		Amount:          req.Amount,
+		Status:          models.PaymentStatusPending,
+		Description:     req.Description,
+		EventID:         req.EventID,
+		PaymentMethodID: paymentMethodID,
+
+		ClientIP:          req.ClientIP,
+		DeviceFingerprint: req.DeviceFingerprint,
+	}
+
+	if err := s.paymentRepo.Create(customerPayment); err != nil {
+		return nil, apperrors.Internal("failed to create payment record")
+	}
+
+	// Invoices stay pending until the seller confirms the transfer arrived
+	if paymentType == models.PaymentTypeInvoice {
+		return &PaymentResponse{
+			PaymentID: customerPayment.ID,
+			Status:    models.PaymentStatusPending,
+			Amount:    customerPayment.Amount,
+			Message:   "Invoice issued",
+		}, nil
+	}
+
+	var response *PaymentResponse
+	switch {
+	case method != nil:
+		response, err = s.chargeProvider(ctx, customerPayment, method.Provider, method.Token)
+	case s.mockMode:
+		// Bare payment types are charged through the mock gateway
+		response, err = s.chargeProvider(ctx, customerPayment, payments.MockGateway, "")
+	default:
+		return nil, apperrors.Internal("real payment processing not implemented")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// If payment successful and event_id provided, create seller payment
+	if response.Status == models.PaymentStatusCompleted && req.EventID > 0 {
+		if err := s.creditSeller(ctx, customerPayment, []*models.Payment{customerPayment}); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// resolveStoredMethod returns the stored payment method to charge, or nil
+// when the request only names a payment type. Asking for the default when
+// none is set falls back to the payment type if one was given.
+func (s *PaymentService) resolveStoredMethod(req *PaymentRequest) (*models.PaymentMethod, error) {
+	if req.PaymentMethodID > 0 {
+		method, err := s.paymentMethodRepo.GetByID(req.PaymentMethodID)
+		if err != nil || method.UserID != req.UserID || method.UserType != req.UserType {
+			return nil, apperrors.NotFound("payment method not found")
+		}
+		return method, nil
+	}
+
+	if !req.UseDefaultMethod {
+		return nil, nil
+	}
+
+	method, err := s.paymentMethodRepo.GetDefaultByUser(req.UserID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.Internal("failed to load default payment method")
+		}
+		if req.PaymentMethod == 0 {
+			return nil, apperrors.Validation("no default payment method set; choose a payment method")
+		}
+		return nil, nil
+	}
+
+	return method, nil
+}
+
+// StoredMethods returns the stored payment methods req would charge, so
+// they can be checked before the charge
+func (s *PaymentService) StoredMethods(req *PaymentRequest) ([]models.PaymentMethod, error) {
+	if len(req.Splits) > 0 {
+		methods, _, err := s.resolveSplits(req)
+		if err != nil {
+			return nil, err
+		}
+		stored := make([]models.PaymentMethod, len(methods))
+		for i, method := range methods {
+			stored[i] = *method
+		}
+		return stored, nil
+	}
+
+	method, err := s.resolveStoredMethod(req)
+	if err != nil || method == nil {
+		return nil, err
+	}
+	return []models.PaymentMethod{*method}, nil
+}
+
+// processSplitPayment charges each split as a component payment under one
+// parent record. If any component fails, the ones already charged are
+// reversed and the parent fails as a whole.
+func (s *PaymentService) processSplitPayment(ctx context.Context, req *PaymentRequest) (*PaymentResponse, error) {
+	methods, amounts, err := s.resolveSplits(req)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := &models.Payment{
 		UserID:      req.UserID,
 		UserType:    req.UserType,
 		Date:        time.Now().Unix(),
-		Type:        req.PaymentMethod,
+		Type:        models.PaymentTypeSplit,
 		Amount:      req.Amount,
 		Status:      models.PaymentStatusPending,
 		Description: req.Description,
 		EventID:     req.EventID,
+
+		ClientIP:          req.ClientIP,
+		DeviceFingerprint: req.DeviceFingerprint,
 	}
 
-	if err := s.paymentRepo.Create(customerPayment); err != nil {
-		return nil, errors.New("failed to create payment record")
+	if err := s.paymentRepo.Create(parent); err != nil {
+		return nil, apperrors.Internal("failed to create payment record")
 	}
 
-	// Process payment (mocked)
-	if s.mockMode {
-		response, err := s.processMockPayment(customerPayment)
+	var charged []*models.Payment
+	var components []PaymentResponse
+	for i, method := range methods {
+		component := &models.Payment{
+			UserID:          req.UserID,
+			UserType:        req.UserType,
+			Date:            parent.Date,
+			Type:            method.Type,
+			Amount:          amounts[i],
+			Status:          models.PaymentStatusPending,
+			Description:     req.Description,
+			EventID:         req.EventID,
+			ParentPaymentID: &parent.ID,
+			PaymentMethodID: &method.ID,
+
+			ClientIP:          req.ClientIP,
+			DeviceFingerprint: req.DeviceFingerprint,
+		}
+
+		if err := s.paymentRepo.Create(component); err != nil {
+			return nil, apperrors.Internal("failed to create payment record")
+		}
+
+		response, err := s.chargeProvider(ctx, component, method.Provider, method.Token)
 		if err != nil {
 			return nil, err
 		}
+		components = append(components, *response)
 
-		// If payment successful and event_id provided, create seller payment
-		if response.Status == models.PaymentStatusCompleted && req.EventID > 0 {
-			err = s.createSellerPayment(req.EventID, req.Amount, req.Description)
-			if err != nil {
-				fmt.Printf("Failed to create seller payment: %v\n", err)
+		if response.Status != models.PaymentStatusCompleted {
+			return s.failSplitPayment(ctx, parent, charged, components, response.Message)
+		}
+		charged = append(charged, component)
+	}
+
+	parent.Status = models.PaymentStatusCompleted
+	if err := s.paymentRepo.Update(parent); err != nil {
+		return nil, apperrors.Internal("failed to update payment status")
+	}
+
+	if req.EventID > 0 {
+		if err := s.creditSeller(ctx, parent, charged); err != nil {
+			return nil, err
+		}
+	}
+
+	return &PaymentResponse{
+		PaymentID:  parent.ID,
+		Status:     models.PaymentStatusCompleted,
+		Amount:     parent.Amount,
+		Message:    "Payment processed successfully",
+		Components: components,
+	}, nil
+}
+
+func (s *PaymentService) failSplitPayment(ctx context.Context, parent *models.Payment, charged []*models.Payment, components []PaymentResponse, reason string) (*PaymentResponse, error) {
+	if err := s.reverseCharges(ctx, charged); err != nil {
+		return nil, apperrors.Internal("failed to reverse component payment")
+	}
+
+	parent.Status = models.PaymentStatusFailed
+	if err := s.paymentRepo.Update(parent); err != nil {
+		return nil, apperrors.Internal("failed to update payment status")
+	}
+
+	return &PaymentResponse{
+		PaymentID:  parent.ID,
+		Status:     models.PaymentStatusFailed,
+		Amount:     parent.Amount,
+		Message:    "Split payment failed: " + reason,
+		Components: components,
+	}, nil
+}
+
+// resolveSplits loads the stored methods behind each split and works out
+// how much each one is charged
+func (s *PaymentService) resolveSplits(req *PaymentRequest) ([]*models.PaymentMethod, []float64, error) {
+	if len(req.Splits) < 2 {
+		return nil, nil, apperrors.Validation("a split payment needs at least two payment methods")
+	}
+
+	methods := make([]*models.PaymentMethod, len(req.Splits))
+	amounts := make([]float64, len(req.Splits))
+	seen := make(map[uint]bool)
+	remainderIndex := -1
+	specified := 0.0
+
+	for i, split := range req.Splits {
+		if seen[split.PaymentMethodID] {
+			return nil, nil, apperrors.Validation("each payment method can only be used once per payment")
+		}
+		seen[split.PaymentMethodID] = true
+
+		method, err := s.paymentMethodRepo.GetByID(split.PaymentMethodID)
+		if err != nil || method.UserID != req.UserID || method.UserType != req.UserType {
+			return nil, nil, apperrors.NotFound("payment method not found")
+		}
+		methods[i] = method
+
+		if split.Amount == 0 {
+			if remainderIndex >= 0 {
+				return nil, nil, apperrors.Validation("only one split may omit its amount")
 			}
+			remainderIndex = i
+			continue
 		}
 
-		return response, nil
+		amounts[i] = roundCents(split.Amount)
+		specified += amounts[i]
+	}
+
+	specified = roundCents(specified)
+	total := roundCents(req.Amount)
+
+	if remainderIndex >= 0 {
+		remainder := roundCents(total - specified)
+		if remainder <= 0 {
+			return nil, nil, apperrors.Validation("split amounts exceed the order total")
+		}
+		amounts[remainderIndex] = remainder
+	} else if specified != total {
+		return nil, nil, apperrors.Validationf("split amounts must add up to the order total of %.2f", total)
 	}
 
-	return nil, errors.New("real payment processing not implemented")
+	return methods, amounts, nil
 }
 
-func (s *PaymentService) createSellerPayment(eventID uint, amount float64, description string) error {
+// creditSeller credits the seller for a completed payment. If that fails,
+// the charges behind the payment are refunded and the error is returned,
+// so the purchase fails without leaving the customer charged.
+func (s *PaymentService) creditSeller(ctx context.Context, payment *models.Payment, charges []*models.Payment) error {
+	err := s.createSellerPayment(s.paymentRepo, payment)
+	if err == nil {
+		return nil
+	}
+
+	if err := s.reverseCharges(ctx, charges); err != nil {
+		log.Printf("Failed to record the reversal of payment %d: %v", payment.ID, err)
+	}
+	if payment.Status != models.PaymentStatusRefunded {
+		payment.Status = models.PaymentStatusRefunded
+		if err := s.paymentRepo.Update(payment); err != nil {
+			log.Printf("Failed to mark payment %d refunded: %v", payment.ID, err)
+		}
+	}
+	return apperrors.Internal("failed to credit the seller: " + err.Error())
+}
+
+// reverseCharges refunds charged payments through their providers and
+// marks them refunded. A charge the provider won't refund is logged and
+// stays completed, so it can be refunded by hand.
+func (s *PaymentService) reverseCharges(ctx context.Context, charges []*models.Payment) error {
+	for _, charge := range charges {
+		provider, err := s.providers.Get(charge.Provider)
+		if err == nil {
+			err = provider.Refund(ctx, charge.TransactionID, charge.Amount)
+		}
+		if err != nil {
+			log.Printf("Failed to refund charge %q of payment %d: %v", charge.TransactionID, charge.ID, err)
+			continue
+		}
+
+		charge.Status = models.PaymentStatusRefunded
+		charge.RefundedAmount = charge.Amount
+		if err := s.paymentRepo.Update(charge); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReversePayment gives back a completed payment whose purchase fell through
+// after the charge, e.g. because the ticket was gone by the time it was to
+// be handed over. Its charges are refunded at their providers and the
+// seller's credit is taken back. A charge the provider won't refund leaves
+// the payment completed, so it can be refunded by hand.
+func (s *PaymentService) ReversePayment(ctx context.Context, paymentID uint) error {
+	payment, err := s.paymentRepo.GetByID(paymentID)
+	if err != nil {
+		return apperrors.NotFound("payment not found")
+	}
+	if payment.Status != models.PaymentStatusCompleted {
+		return nil
+	}
+
+	charges := []*models.Payment{payment}
+	if payment.Type == models.PaymentTypeSplit {
+		components, err := s.paymentRepo.ListByParent(payment.ID)
+		if err != nil {
+			return apperrors.Internal("failed to load component payments")
+		}
+		charges = charges[:0]
+		for i := range components {
+			if components[i].Status == models.PaymentStatusCompleted {
+				charges = append(charges, &components[i])
+			}
+		}
+	}
+
+	if err := s.reverseCharges(ctx, charges); err != nil {
+		return apperrors.Internal("failed to record the reversal")
+	}
+	for _, charge := range charges {
+		if charge.Status != models.PaymentStatusRefunded {
+			return apperrors.Internal(fmt.Sprintf("payment %d could not be refunded and needs a manual refund", payment.ID))
+		}
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		paymentRepo := s.paymentRepo.WithTx(tx)
+
+		payment.Status = models.PaymentStatusRefunded
+		payment.RefundedAmount = payment.Amount
+		if err := paymentRepo.Update(payment); err != nil {
+			return err
+		}
+
+		// Only a credited payment has a fee rate recorded
+		if payment.EventID == 0 || payment.PlatformFeeRate == nil {
+			return nil
+		}
+		event, err := s.eventRepo.GetByID(payment.EventID)
+		if err != nil {
+			return err
+		}
+		return paymentRepo.Create(&models.Payment{
+			UserID:      event.SellerID,
+			UserType:    models.UserTypeSeller,
+			Date:        time.Now().Unix(),
+			Type:        models.PaymentTypeCard,
+			Amount:      -roundCents(payment.Amount * (1 - creditedFeeRate(payment))),
+			Status:      models.PaymentStatusCompleted,
+			Description: fmt.Sprintf("Reversal of payment #%d", payment.ID),
+			EventID:     payment.EventID,
+		})
+	})
+	if err != nil {
+		return apperrors.Internal("failed to record the reversal")
+	}
+
+	return nil
+}
+
+// createSellerPayment credits the seller of the payment's event with their
+// share and records the fee rate on the payment
+func (s *PaymentService) createSellerPayment(paymentRepo repositories.PaymentRepository, payment *models.Payment) error {
 	// Get event to find seller
-	event, err := s.eventRepo.GetByID(eventID)
+	event, err := s.eventRepo.GetByID(payment.EventID)
 	if err != nil {
 		return err
 	}
 
-	// Calculate seller fee (e.g., 95% to seller, 5% platform fee)
-	sellerAmount := amount * 0.95
+	rate := PlatformFeeRate()
+	payment.PlatformFeeRate = &rate
+	if err := paymentRepo.Update(payment); err != nil {
+		return err
+	}
+
+	// Calculate seller share (95% to seller, 5% platform fee)
+	sellerAmount := payment.Amount * (1 - rate)
 
 	// Create seller payment record
 	sellerPayment := &models.Payment{
@@ -116,20 +604,38 @@ func (s *PaymentService) createSellerPayment(eventID uint, amount float64, descr
 		Type:        models.PaymentTypeCard,
 		Amount:      sellerAmount,
 		Status:      models.PaymentStatusCompleted,
-		Description: fmt.Sprintf("Revenue from: %s", description),
-		EventID:     eventID,
+		Description: fmt.Sprintf("Revenue from: %s", payment.Description),
+		EventID:     payment.EventID,
 	}
 
-	return s.paymentRepo.Create(sellerPayment)
+	return paymentRepo.Create(sellerPayment)
 }
 
-func (s *PaymentService) GetUserPayments(userID uint, userType models.UserType, limit, offset int) ([]PaymentInfo, error) {
-	payments, err := s.paymentRepo.ListByUserAndType(userID, userType, limit, offset)
+func (s *PaymentService) GetUserPayments(userID uint, userType models.UserType, filter repositories.PaymentFilter, limit, offset int) ([]PaymentInfo, int64, error) {
+	if filter.Sort != "" && !repositories.ValidPaymentSort(filter.Sort) {
+		return nil, 0, apperrors.Validationf("unknown sort: %s", filter.Sort)
+	}
+	if filter.Direction != "" && filter.Direction != repositories.PaymentDirectionIncoming && filter.Direction != repositories.PaymentDirectionOutgoing {
+		return nil, 0, apperrors.Validationf("unknown direction: %s", filter.Direction)
+	}
+	if filter.From > 0 && filter.To > 0 && filter.From > filter.To {
+		return nil, 0, apperrors.Validation("from must not be after to")
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return nil, 0, apperrors.Validation("min_amount must not exceed max_amount")
+	}
+
+	payments, err := s.paymentRepo.ListByUserAndType(userID, userType, filter, limit, offset)
 	if err != nil {
-		return nil, errors.New("failed to retrieve payments")
+		return nil, 0, apperrors.Internal("failed to retrieve payments")
 	}
 
-	var paymentInfos []PaymentInfo
+	total, err := s.paymentRepo.CountByUserAndType(userID, userType, filter)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to count payments")
+	}
+
+	paymentInfos := make([]PaymentInfo, 0, len(payments))
 	for _, payment := range payments {
 		paymentInfo := PaymentInfo{
 			ID:          payment.ID,
@@ -139,7 +645,7 @@ func (s *PaymentService) GetUserPayments(userID uint, userType models.UserType,
 			Amount:      payment.Amount,
 			Status:      payment.Status,
 			Description: payment.Description,
-			PaymentType: s.getPaymentDirectionForUser(payment.UserType, userType),
+			PaymentType: s.getPaymentDirectionForUser(&payment, userType),
 		}
 
 		// Add event title if available
@@ -152,13 +658,13 @@ func (s *PaymentService) GetUserPayments(userID uint, userType models.UserType,
 		paymentInfos = append(paymentInfos, paymentInfo)
 	}
 
-	return paymentInfos, nil
+	return paymentInfos, total, nil
 }
 
 func (s *PaymentService) GetSellerPayments(sellerID uint, limit, offset int) ([]PaymentInfo, error) {
 	payments, err := s.paymentRepo.ListByUser(sellerID, limit, offset)
 	if err != nil {
-		return nil, errors.New("failed to retrieve seller payments")
+		return nil, apperrors.Internal("failed to retrieve seller payments")
 	}
 
 	var paymentInfos []PaymentInfo
@@ -187,80 +693,528 @@ func (s *PaymentService) GetSellerPayments(sellerID uint, limit, offset int) ([]
 	return paymentInfos, nil
 }
 
-func (s *PaymentService) processMockPayment(payment *models.Payment) (*PaymentResponse, error) {
-	// Simulate payment processing delay
-	time.Sleep(time.Millisecond * 500)
+// chargeProvider charges payment through the named provider, with the
+// token of a stored payment method or none for the mock gateway
+func (s *PaymentService) chargeProvider(ctx context.Context, payment *models.Payment, providerName, token string) (*PaymentResponse, error) {
+	ctx, span := tracing.StartSpan(ctx, "PaymentProvider.Charge",
+		attribute.Int("payment.id", int(payment.ID)),
+		attribute.String("payment.provider", providerName),
+	)
+	defer span.End()
+
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return nil, err
+	}
 
-	// Randomly succeed or fail (90% success rate for demo)
-	randomNum, _ := utils.CryptoFloat64()
-	success := randomNum < 0.9
+	result, err := provider.Charge(ctx, token, payment.Amount, strconv.FormatUint(uint64(payment.ID), 10))
+	if errors.Is(err, payments.ErrProviderUnavailable) {
+		// Fail the charge like a decline, so purchases hold the tickets
+		// for a retry instead of erroring out
+		tracing.RecordError(span, err)
+		result = &payments.ChargeResult{Message: "Payment provider is unavailable, please retry shortly"}
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		return nil, apperrors.Internal("payment provider error: " + err.Error())
+	}
 
-	if success {
+	payment.Status = models.PaymentStatusFailed
+	if result.Succeeded {
 		payment.Status = models.PaymentStatusCompleted
-		transactionID := fmt.Sprintf("MOCK_%d_%d", payment.ID, time.Now().Unix())
+	}
+	payment.Provider = providerName
+	payment.TransactionID = result.TransactionID
 
-		if err := s.paymentRepo.Update(payment); err != nil {
-			return nil, errors.New("failed to update payment status")
+	if err := s.paymentRepo.Update(payment); err != nil {
+		return nil, apperrors.Internal("failed to update payment status")
+	}
+
+	return &PaymentResponse{
+		PaymentID:     payment.ID,
+		Status:        payment.Status,
+		Amount:        payment.Amount,
+		TransactionID: result.TransactionID,
+		Message:       result.Message,
+	}, nil
+}
+
+// GetPaymentStatus returns a payment with its event and the tickets it
+// bought. Only the payer, a seller who can view the event's sales, or an
+// admin may see it.
+func (s *PaymentService) GetPaymentStatus(paymentID, viewerID uint, viewerType models.UserType) (*PaymentResponse, error) {
+	payment, err := s.paymentRepo.GetByID(paymentID)
+	if err != nil {
+		return nil, apperrors.NotFound("payment not found")
+	}
+
+	if !s.canViewPayment(payment, viewerID, viewerType) {
+		return nil, apperrors.Forbidden("unauthorized to view this payment")
+	}
+
+	response, err := s.paymentDetails(payment)
+	if err != nil {
+		return nil, err
+	}
+	if viewerType == models.UserTypeAdmin {
+		response.ClientIP = payment.ClientIP
+		response.DeviceFingerprint = payment.DeviceFingerprint
+	}
+	return response, nil
+}
+
+// FindByTransaction looks a payment up by the reference its provider
+// returned, for support staff chasing a charge on a bank statement
+func (s *PaymentService) FindByTransaction(transactionID string) (*PaymentResponse, error) {
+	payment, err := s.paymentRepo.GetByTransactionID(transactionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound("payment not found")
 		}
+		return nil, apperrors.Internal("failed to look up payment")
+	}
 
-		return &PaymentResponse{
-			PaymentID:     payment.ID,
-			Status:        models.PaymentStatusCompleted,
-			Amount:        payment.Amount,
-			TransactionID: transactionID,
-			Message:       "Payment processed successfully",
-		}, nil
-	} else {
-		payment.Status = models.PaymentStatusFailed
+	response, err := s.paymentDetails(payment)
+	if err != nil {
+		return nil, err
+	}
+	response.ClientIP = payment.ClientIP
+	response.DeviceFingerprint = payment.DeviceFingerprint
+	return response, nil
+}
 
-		if err := s.paymentRepo.Update(payment); err != nil {
-			return nil, errors.New("failed to update payment status")
+func (s *PaymentService) paymentDetails(payment *models.Payment) (*PaymentResponse, error) {
+	response := &PaymentResponse{
+		PaymentID:      payment.ID,
+		Status:         payment.Status,
+		Amount:         payment.Amount,
+		TransactionID:  payment.TransactionID,
+		Provider:       payment.Provider,
+		Message:        fmt.Sprintf("Payment is %d", payment.Status),
+		Date:           payment.Date,
+		Type:           payment.Type,
+		RefundedAmount: payment.RefundedAmount,
+	}
+
+	if payment.EventID > 0 && payment.Event.ID > 0 {
+		response.Event = &PaymentEventInfo{
+			ID:    payment.Event.ID,
+			Title: payment.Event.Title,
+			Date:  payment.Event.Date,
 		}
+	}
 
-		return &PaymentResponse{
-			PaymentID: payment.ID,
-			Status:    models.PaymentStatusFailed,
-			Amount:    payment.Amount,
-			Message:   "Payment failed - insufficient funds or card declined",
-		}, nil
+	tickets, err := s.purchasedTicketRepo.ListByPayment(payment.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load purchased tickets")
+	}
+	for _, ticket := range tickets {
+		response.Tickets = append(response.Tickets, PaymentTicketInfo{
+			ID:            ticket.ID,
+			Title:         ticket.Title,
+			Place:         ticket.Place,
+			Price:         ticket.Price,
+			IsUsed:        ticket.IsUsed,
+			IsInvalidated: ticket.IsInvalidated,
+		})
+	}
+
+	if payment.Type == models.PaymentTypeSplit {
+		components, err := s.paymentRepo.ListByParent(payment.ID)
+		if err != nil {
+			return nil, apperrors.Internal("failed to load component payments")
+		}
+		for _, component := range components {
+			response.Components = append(response.Components, PaymentResponse{
+				PaymentID:     component.ID,
+				Status:        component.Status,
+				Amount:        component.Amount,
+				TransactionID: component.TransactionID,
+				Provider:      component.Provider,
+				Message:       fmt.Sprintf("Payment is %d", component.Status),
+			})
+		}
+	}
+
+	return response, nil
+}
+
+func (s *PaymentService) canViewPayment(payment *models.Payment, viewerID uint, viewerType models.UserType) bool {
+	switch {
+	case viewerType == models.UserTypeAdmin:
+		return true
+	case payment.UserID == viewerID && payment.UserType == viewerType:
+		return true
+	case viewerType == models.UserTypeSeller && payment.EventID > 0:
+		return s.eventAccess.Allowed(&payment.Event, viewerID, models.EventPermissionViewSales)
 	}
+
+	// Component payments of a split belong to whoever made the parent
+	if payment.ParentPaymentID != nil {
+		parent, err := s.paymentRepo.GetByID(*payment.ParentPaymentID)
+		return err == nil && s.canViewPayment(parent, viewerID, viewerType)
+	}
+	return false
+}
+
+// RefundRequest says what to give back. Without tickets or an amount the
+// rest of the payment is refunded and every ticket it bought is voided.
+type RefundRequest struct {
+	PurchasedTicketIDs []uint   `json:"purchased_ticket_ids" binding:"omitempty,max=100"` // Tickets to refund; they are invalidated
+	Amount             *float64 `json:"amount" binding:"omitempty,gt=0"`                  // Overrides the tickets' price, or refunds just this much
+	WithheldFee        float64  `json:"withheld_fee" binding:"min=0"`                     // Kept back, e.g. a booking fee
+	Reason             string   `json:"reason" binding:"max=500"`
+}
+
+// RefundPayment returns all or part of a customer payment. The seller is
+// debited their share of what is refunded, and the payment is marked
+// refunded once nothing is left of it. Once the refund is recorded, the
+// money goes back through the providers that took it; each component of a
+// split payment gives back its share.
+func (s *PaymentService) RefundPayment(ctx context.Context, paymentID, adminID uint, req *RefundRequest) (*models.Refund, error) {
+	return s.refundPayment(ctx, paymentID, adminID, req, nil)
 }
 
-func (s *PaymentService) GetPaymentStatus(paymentID uint) (*PaymentResponse, error) {
+// refundPayment is RefundPayment; within, if set, runs in the refund's
+// transaction once the refund is stored, and failing it undoes the refund
+func (s *PaymentService) refundPayment(ctx context.Context, paymentID, adminID uint, req *RefundRequest, within func(tx *gorm.DB, refund *models.Refund) error) (*models.Refund, error) {
 	payment, err := s.paymentRepo.GetByID(paymentID)
 	if err != nil {
-		return nil, errors.New("payment not found")
+		return nil, apperrors.NotFound("payment not found")
 	}
 
-	return &PaymentResponse{
-		PaymentID: payment.ID,
-		Status:    payment.Status,
-		Amount:    payment.Amount,
-		Message:   fmt.Sprintf("Payment is %d", payment.Status),
-	}, nil
+	if payment.Status != models.PaymentStatusCompleted {
+		return nil, apperrors.Validation("can only refund completed payments")
+	}
+
+	if payment.ParentPaymentID != nil {
+		return nil, apperrors.Validation("refund the parent of a split payment instead")
+	}
+
+	if payment.UserType != models.UserTypeUser {
+		return nil, apperrors.Validation("only customer payments can be refunded")
+	}
+
+	remaining := roundCents(payment.Amount - payment.RefundedAmount)
+	now := time.Now().Unix()
+	refund := &models.Refund{
+		PaymentID:  payment.ID,
+		EventID:    payment.EventID,
+		UserID:     payment.UserID,
+		Reason:     req.Reason,
+		RefundedBy: adminID,
+		CreatedAt:  now,
+	}
+
+	// One line per returned ticket, then any adjustment and withheld fee
+	var ticketIDs []uint
+	if len(req.PurchasedTicketIDs) > 0 {
+		tickets, err := s.purchasedTicketRepo.ListByPayment(payment.ID)
+		if err != nil {
+			return nil, apperrors.Internal("failed to load the order's tickets")
+		}
+		bought := make(map[uint]*models.PurchasedTicket, len(tickets))
+		for i := range tickets {
+			bought[tickets[i].ID] = &tickets[i]
+		}
+		for _, id := range req.PurchasedTicketIDs {
+			ticket, ok := bought[id]
+			if !ok {
+				return nil, apperrors.Validationf("ticket %d was not bought with this payment", id)
+			}
+			if ticket.IsInvalidated {
+				return nil, apperrors.Validationf("ticket %d has already been refunded or invalidated", id)
+			}
+			if ticket.IsUsed {
+				return nil, apperrors.Validationf("ticket %d has already been used", id)
+			}
+			ticketID := ticket.ID
+			refund.Items = append(refund.Items, models.RefundItem{
+				PurchasedTicketID: &ticketID,
+				Description:       fmt.Sprintf("Ticket: %s", ticket.Title),
+				Amount:            ticket.Price,
+			})
+			refund.Amount += ticket.Price
+			ticketIDs = append(ticketIDs, ticket.ID)
+			delete(bought, id)
+		}
+	}
+
+	if req.Amount != nil || len(ticketIDs) == 0 {
+		target := remaining
+		if req.Amount != nil {
+			target = roundCents(*req.Amount)
+		}
+		if adjustment := roundCents(target - refund.Amount); adjustment != 0 {
+			refund.Items = append(refund.Items, models.RefundItem{Description: "Adjustment", Amount: adjustment})
+		}
+		refund.Amount = target
+	}
+
+	if req.WithheldFee > 0 {
+		refund.Items = append(refund.Items, models.RefundItem{Description: "Withheld fee", Amount: -roundCents(req.WithheldFee)})
+		refund.Amount -= req.WithheldFee
+	}
+
+	refund.Amount = roundCents(refund.Amount)
+	if refund.Amount <= 0 {
+		return nil, apperrors.Validation("nothing left to refund after the withheld fee")
+	}
+	if refund.Amount > remaining {
+		return nil, apperrors.Validationf("refund exceeds the %.2f left on this payment", remaining)
+	}
+
+	if payment.EventID > 0 {
+		refund.SellerDebit = roundCents(refund.Amount * (1 - creditedFeeRate(payment)))
+	}
+
+	// Whether this refund empties the payment is read from the row it
+	// updates, since another refund may have landed since it was loaded.
+	// The components' shares are worked out after that row is locked.
+	var full bool
+	var components []models.Payment
+	var shares []outbox.RefundComponent
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		paymentRepo := s.paymentRepo.WithTx(tx)
+		purchasedTicketRepo := s.purchasedTicketRepo.WithTx(tx)
+
+		added, err := paymentRepo.AddRefunded(payment.ID, refund.Amount)
+		if err != nil {
+			return err
+		}
+		if !added {
+			return apperrors.Conflict("payment was refunded concurrently; reload and try again")
+		}
+
+		updated, err := paymentRepo.GetByID(payment.ID)
+		if err != nil {
+			return err
+		}
+		full = roundCents(updated.Amount-updated.RefundedAmount) <= 0
+
+		if full {
+			updated.Status = models.PaymentStatusRefunded
+			updated.RefundedAmount = updated.Amount
+			if err := paymentRepo.Update(updated); err != nil {
+				return err
+			}
+		}
+
+		if components, err = paymentRepo.ListByParent(payment.ID); err != nil {
+			return err
+		}
+		shares = s.allocateRefund(refund.Amount, payment.Amount, components)
+		for i := range components {
+			component := &components[i]
+			if full {
+				// The last refund takes whatever each component has left
+				shares[i].Amount = roundCents(component.Amount - component.RefundedAmount)
+				component.Status = models.PaymentStatusRefunded
+				component.RefundedAmount = component.Amount
+				if err := paymentRepo.Update(component); err != nil {
+					return err
+				}
+				continue
+			}
+			added, err := paymentRepo.AddRefunded(component.ID, shares[i].Amount)
+			if err != nil {
+				return err
+			}
+			if !added {
+				return apperrors.Conflict("a component payment was refunded concurrently; reload and try again")
+			}
+		}
+
+		if len(ticketIDs) > 0 {
+			invalidated, err := purchasedTicketRepo.InvalidateTickets(payment.ID, ticketIDs, now)
+			if err != nil {
+				return err
+			}
+			if invalidated != int64(len(ticketIDs)) {
+				return apperrors.Conflict("tickets were refunded or invalidated concurrently; reload and try again")
+			}
+		}
+
+		// A full refund voids whatever tickets the order still has
+		if full {
+			if _, err := purchasedTicketRepo.InvalidateByPayment(payment.ID, now); err != nil {
+				return err
+			}
+		}
+
+		if refund.SellerDebit > 0 {
+			if err := paymentRepo.Create(&models.Payment{
+				UserID:      payment.Event.SellerID,
+				UserType:    models.UserTypeSeller,
+				Date:        now,
+				Type:        models.PaymentTypeCard,
+				Amount:      -refund.SellerDebit,
+				Status:      models.PaymentStatusCompleted,
+				Description: fmt.Sprintf("Refund on payment #%d", payment.ID),
+				EventID:     payment.EventID,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err := paymentRepo.CreateRefund(refund); err != nil {
+			return err
+		}
+		if within != nil {
+			if err := within(tx, refund); err != nil {
+				return err
+			}
+		}
+
+		message, err := outbox.NewMessage(models.OutboxTopicOrderRefunded, outbox.OrderRefundedPayload{
+			EventID:            payment.EventID,
+			SellerID:           payment.Event.SellerID,
+			UserID:             payment.UserID,
+			PaymentID:          payment.ID,
+			RefundID:           refund.ID,
+			Amount:             refund.Amount,
+			Partial:            !full,
+			PurchasedTicketIDs: ticketIDs,
+			Components:         shares,
+		})
+		if err != nil {
+			return err
+		}
+		return s.outboxRepo.WithTx(tx).Create(message)
+	})
+	if err != nil {
+		if _, ok := apperrors.As(err); ok {
+			return nil, err
+		}
+		return nil, apperrors.Internal("failed to process refund")
+	}
+
+	if len(components) == 0 {
+		s.refundCharge(ctx, payment, refund.Amount)
+	}
+	for i := range components {
+		s.refundCharge(ctx, &components[i], shares[i].Amount)
+	}
+
+	return refund, nil
 }
 
-func (s *PaymentService) RefundPayment(paymentID uint) error {
+// refundCharge gives amount of a charge back through its provider. The
+// refund is already recorded, so one the provider refuses is logged to be
+// made by hand, as are refunds of charges without a provider, e.g. invoices.
+func (s *PaymentService) refundCharge(ctx context.Context, charge *models.Payment, amount float64) {
+	provider, err := s.providers.Get(charge.Provider)
+	if err == nil {
+		err = provider.Refund(ctx, charge.TransactionID, amount)
+	}
+	if err != nil {
+		log.Printf("Refund of %.2f on payment %d needs to be made by hand: %v", amount, charge.ID, err)
+	}
+}
+
+// GetRefunds lists a payment's refunds with their line items, oldest first
+func (s *PaymentService) GetRefunds(paymentID uint) ([]models.Refund, error) {
+	if _, err := s.paymentRepo.GetByID(paymentID); err != nil {
+		return nil, apperrors.NotFound("payment not found")
+	}
+
+	refunds, err := s.paymentRepo.ListRefunds(paymentID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve refunds")
+	}
+	return refunds, nil
+}
+
+// SettleInvoice marks a pending invoice payment as paid and credits the seller
+func (s *PaymentService) SettleInvoice(paymentID uint) error {
 	payment, err := s.paymentRepo.GetByID(paymentID)
 	if err != nil {
-		return errors.New("payment not found")
+		return apperrors.NotFound("payment not found")
 	}
 
-	if payment.Status != models.PaymentStatusCompleted {
-		return errors.New("can only refund completed payments")
+	if payment.Type != models.PaymentTypeInvoice {
+		return apperrors.Validation("payment is not an invoice")
+	}
+	if payment.Status != models.PaymentStatusPending {
+		return apperrors.Validation("invoice is not awaiting payment")
 	}
 
-	payment.Status = models.PaymentStatusRefunded
-	if err := s.paymentRepo.Update(payment); err != nil {
-		return errors.New("failed to process refund")
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		paymentRepo := s.paymentRepo.WithTx(tx)
+
+		payment.Status = models.PaymentStatusCompleted
+		if err := paymentRepo.Update(payment); err != nil {
+			return err
+		}
+
+		if payment.EventID > 0 {
+			return s.createSellerPayment(paymentRepo, payment)
+		}
+		return nil
+	})
+	if err != nil {
+		return apperrors.Internal("failed to settle invoice")
 	}
 
 	return nil
 }
 
-func (s *PaymentService) getPaymentDirectionForUser(paymentUserType, requestUserType models.UserType) string {
-	if paymentUserType == models.UserTypeSeller && requestUserType == models.UserTypeSeller {
-		return "incoming" // Seller viewing their revenue
+// ExpireStalePayments fails payments left pending longer than ttl, e.g. when
+// the process died between creating the record and hearing from the provider
+func (s *PaymentService) ExpireStalePayments(ttl time.Duration) (int64, error) {
+	cutoff := time.Now().Add(-ttl).Unix()
+
+	expired, err := s.paymentRepo.FailPendingBefore(cutoff)
+	if err != nil {
+		return 0, apperrors.Internal("failed to expire stale payments")
 	}
-	return "outgoing" // User viewing their purchases
+
+	return expired, nil
+}
+
+// ClearClientInfo blanks the IP and device fingerprint of payments older
+// than retention
+func (s *PaymentService) ClearClientInfo(retention time.Duration) (int64, error) {
+	cleared, err := s.paymentRepo.ClearClientInfoBefore(time.Now().Add(-retention).Unix())
+	if err != nil {
+		return 0, apperrors.Internal("failed to clear payment client info")
+	}
+	return cleared, nil
+}
+
+// allocateRefund spreads refundAmount over the components of a split payment
+// in proportion to what each was charged. Rounding leftovers go to the last
+// component so the parts always add up to refundAmount.
+func (s *PaymentService) allocateRefund(refundAmount, paidAmount float64, components []models.Payment) []outbox.RefundComponent {
+	if len(components) == 0 || paidAmount <= 0 {
+		return nil
+	}
+
+	allocated := 0.0
+	refunds := make([]outbox.RefundComponent, len(components))
+	for i, component := range components {
+		amount := roundCents(refundAmount * component.Amount / paidAmount)
+		if i == len(components)-1 {
+			amount = roundCents(refundAmount - allocated)
+		}
+		allocated += amount
+
+		refunds[i] = outbox.RefundComponent{
+			PaymentID:   component.ID,
+			PaymentType: component.Type,
+			Amount:      amount,
+		}
+	}
+
+	return refunds
+}
+
+func (s *PaymentService) getPaymentDirectionForUser(payment *models.Payment, requestUserType models.UserType) string {
+	if payment.UserType == models.UserTypeSeller && requestUserType == models.UserTypeSeller && payment.Amount >= 0 {
+		return repositories.PaymentDirectionIncoming // Seller viewing their revenue
+	}
+	return repositories.PaymentDirectionOutgoing // User viewing their purchases, or a seller's refund debit
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
 }
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"eticketing/internal/models"
+	"eticketing/internal/payments"
+	apperrors "eticketing/pkg/errors"
+)
+
+// refundFixture has a completed 100.00 payment for two 50.00 tickets,
+// credited to the seller at a 10% fee
+type refundFixture struct {
+	ctrl        *gomock.Controller
+	paymentRepo *paymentStore
+	purchased   *purchasedTicketStore
+	methods     *paymentMethodStore
+	events      *eventStore
+	outbox      *outboxStore
+	provider    *fakeProvider
+}
+
+func newRefundFixture(t *testing.T) *refundFixture {
+	rate := 0.1
+	paymentID := uint(1)
+	event := models.Event{ID: 1, SellerID: sellerID, Title: "Concert"}
+	return &refundFixture{
+		ctrl: gomock.NewController(t),
+		paymentRepo: &paymentStore{payments: map[uint]*models.Payment{
+			1: {ID: 1, UserID: buyerID, UserType: models.UserTypeUser, Type: models.PaymentTypeCard, Amount: 100,
+				Status: models.PaymentStatusCompleted, EventID: event.ID, Event: event, PlatformFeeRate: &rate,
+				Provider: "fake", TransactionID: "fake_1"},
+		}},
+		purchased: &purchasedTicketStore{tickets: map[uint]*models.PurchasedTicket{
+			1: {ID: 1, UserID: buyerID, Title: "Standing", Price: 50, PaymentID: &paymentID},
+			2: {ID: 2, UserID: buyerID, Title: "Standing", Price: 50, PaymentID: &paymentID},
+		}},
+		methods: &paymentMethodStore{methods: map[uint]*models.PaymentMethod{
+			1: {ID: 1, UserID: buyerID, UserType: models.UserTypeUser, Type: models.PaymentTypeCard, Provider: "fake", Token: "card"},
+			2: {ID: 2, UserID: buyerID, UserType: models.UserTypeUser, Type: models.PaymentTypeCard, Provider: "fake", Token: "decline"},
+		}},
+		events:   &eventStore{events: map[uint]*models.Event{1: &event}},
+		outbox:   &outboxStore{},
+		provider: &fakeProvider{},
+	}
+}
+
+func (f *refundFixture) paymentService() *PaymentService {
+	return NewPaymentService(f.paymentRepo.mock(f.ctrl), f.methods.mock(f.ctrl), f.purchased.mock(f.ctrl),
+		payments.NewRegistry(f.provider), f.events.mock(f.ctrl), nil, nil, f.outbox.mock(f.ctrl), fakeTxManager{}, false)
+}
+
+func TestRefundPaymentRefusals(t *testing.T) {
+	amount := func(v float64) *float64 { return &v }
+	parentID := uint(7)
+
+	tests := []struct {
+		name      string
+		paymentID uint
+		setup     func(f *refundFixture)
+		req       RefundRequest
+		wantCode  apperrors.Code
+	}{
+		{"missing payment", 9, nil, RefundRequest{}, apperrors.CodeNotFound},
+		{"pending payment", 1, func(f *refundFixture) {
+			f.paymentRepo.payments[1].Status = models.PaymentStatusPending
+		}, RefundRequest{}, apperrors.CodeValidationFailed},
+		{"split component", 1, func(f *refundFixture) {
+			f.paymentRepo.payments[1].ParentPaymentID = &parentID
+		}, RefundRequest{}, apperrors.CodeValidationFailed},
+		{"seller payment", 1, func(f *refundFixture) {
+			f.paymentRepo.payments[1].UserType = models.UserTypeSeller
+		}, RefundRequest{}, apperrors.CodeValidationFailed},
+		{"ticket from another payment", 1, nil, RefundRequest{PurchasedTicketIDs: []uint{9}}, apperrors.CodeValidationFailed},
+		{"ticket already refunded", 1, func(f *refundFixture) {
+			f.purchased.tickets[1].IsInvalidated = true
+		}, RefundRequest{PurchasedTicketIDs: []uint{1}}, apperrors.CodeValidationFailed},
+		{"used ticket", 1, func(f *refundFixture) {
+			f.purchased.tickets[1].IsUsed = true
+		}, RefundRequest{PurchasedTicketIDs: []uint{1}}, apperrors.CodeValidationFailed},
+		{"more than was paid", 1, nil, RefundRequest{Amount: amount(150)}, apperrors.CodeValidationFailed},
+		{"more than is left", 1, func(f *refundFixture) {
+			f.paymentRepo.payments[1].RefundedAmount = 80
+		}, RefundRequest{Amount: amount(30)}, apperrors.CodeValidationFailed},
+		{"withheld fee leaves nothing", 1, nil, RefundRequest{Amount: amount(10), WithheldFee: 10}, apperrors.CodeValidationFailed},
+		{"refunded concurrently", 1, func(f *refundFixture) { f.paymentRepo.raced = true }, RefundRequest{}, apperrors.CodeConflict},
+		{"ticket refunded concurrently", 1, func(f *refundFixture) {
+			f.purchased.raced = true
+		}, RefundRequest{PurchasedTicketIDs: []uint{1}}, apperrors.CodeConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newRefundFixture(t)
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+
+			_, err := f.paymentService().RefundPayment(context.Background(), tt.paymentID, 1, &tt.req)
+			if code := apperrors.CodeOf(err); code != tt.wantCode {
+				t.Errorf("error %v has code %q, want %q", err, code, tt.wantCode)
+			}
+			if len(f.paymentRepo.refunds) != 0 || len(f.paymentRepo.payments) != 1 {
+				t.Errorf("recorded %d refunds and %d new payments, want none",
+					len(f.paymentRepo.refunds), len(f.paymentRepo.payments)-1)
+			}
+			if len(f.purchased.invalidated) != 0 {
+				t.Errorf("tickets %v invalidated, want none", f.purchased.invalidated)
+			}
+		})
+	}
+}
+
+func TestRefundPayment(t *testing.T) {
+	// The payment was credited at 10%; the current rate must not be used
+	withFeeRate(t, 0.05)
+
+	tests := []struct {
+		name            string
+		req             RefundRequest
+		wantAmount      float64
+		wantDebit       float64
+		wantStatus      models.PaymentStatus
+		wantInvalidated int
+	}{
+		{"everything", RefundRequest{}, 100, 90, models.PaymentStatusRefunded, 2},
+		{"one ticket", RefundRequest{PurchasedTicketIDs: []uint{1}}, 50, 45, models.PaymentStatusCompleted, 1},
+		{"one ticket less a fee", RefundRequest{PurchasedTicketIDs: []uint{2}, WithheldFee: 5}, 45, 40.5, models.PaymentStatusCompleted, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newRefundFixture(t)
+
+			refund, err := f.paymentService().RefundPayment(context.Background(), 1, 1, &tt.req)
+			if err != nil {
+				t.Fatalf("RefundPayment: %v", err)
+			}
+
+			if refund.Amount != tt.wantAmount || refund.SellerDebit != tt.wantDebit {
+				t.Errorf("refunded %.2f debiting the seller %.2f, want %.2f and %.2f",
+					refund.Amount, refund.SellerDebit, tt.wantAmount, tt.wantDebit)
+			}
+			debits := f.paymentRepo.sellerPayments()
+			if len(debits) != 1 || debits[0].UserID != sellerID || debits[0].Amount != -tt.wantDebit {
+				t.Errorf("seller debits = %+v, want one of -%.2f to seller %d", debits, tt.wantDebit, sellerID)
+			}
+
+			payment := f.paymentRepo.payments[1]
+			if payment.Status != tt.wantStatus || payment.RefundedAmount != tt.wantAmount {
+				t.Errorf("payment status %d with %.2f refunded, want %d with %.2f",
+					payment.Status, payment.RefundedAmount, tt.wantStatus, tt.wantAmount)
+			}
+			if len(f.purchased.invalidated) != tt.wantInvalidated {
+				t.Errorf("tickets %v invalidated, want %d", f.purchased.invalidated, tt.wantInvalidated)
+			}
+			if got := f.provider.refunded["fake_1"]; got != tt.wantAmount || len(f.provider.refunded) != 1 {
+				t.Errorf("provider refunds = %v, want %.2f of fake_1", f.provider.refunded, tt.wantAmount)
+			}
+			if topics := f.outbox.topics(); len(topics) != 1 || topics[0] != models.OutboxTopicOrderRefunded {
+				t.Errorf("outbox topics = %v, want [%s]", topics, models.OutboxTopicOrderRefunded)
+			}
+		})
+	}
+}
+
+// A split payment is refunded through each component's provider, in
+// proportion to what each was charged, until the last refund takes the rest
+func TestRefundSplitPayment(t *testing.T) {
+	f := newRefundFixture(t)
+	parent := f.paymentRepo.payments[1]
+	parent.Type = models.PaymentTypeSplit
+	parent.Provider, parent.TransactionID = "", ""
+	f.paymentRepo.payments[2] = &models.Payment{ID: 2, UserID: buyerID, UserType: models.UserTypeUser, Type: models.PaymentTypeCard,
+		Amount: 30, Status: models.PaymentStatusCompleted, ParentPaymentID: &parent.ID, Provider: "fake", TransactionID: "fake_2"}
+	f.paymentRepo.payments[3] = &models.Payment{ID: 3, UserID: buyerID, UserType: models.UserTypeUser, Type: models.PaymentTypeCard,
+		Amount: 70, Status: models.PaymentStatusCompleted, ParentPaymentID: &parent.ID, Provider: "fake", TransactionID: "fake_3"}
+
+	service := f.paymentService()
+	if _, err := service.RefundPayment(context.Background(), 1, 1, &RefundRequest{PurchasedTicketIDs: []uint{1}}); err != nil {
+		t.Fatalf("RefundPayment of one ticket: %v", err)
+	}
+	if got := f.provider.refunded; got["fake_2"] != 15 || got["fake_3"] != 35 {
+		t.Errorf("provider refunds = %v, want 15.00 of fake_2 and 35.00 of fake_3", got)
+	}
+	if a, b := f.paymentRepo.payments[2], f.paymentRepo.payments[3]; a.RefundedAmount != 15 || b.RefundedAmount != 35 {
+		t.Errorf("components refunded %.2f and %.2f, want 15.00 and 35.00", a.RefundedAmount, b.RefundedAmount)
+	}
+
+	if _, err := service.RefundPayment(context.Background(), 1, 1, &RefundRequest{}); err != nil {
+		t.Fatalf("RefundPayment of the rest: %v", err)
+	}
+	if got := f.provider.refunded; got["fake_2"] != 30 || got["fake_3"] != 70 {
+		t.Errorf("provider refunds = %v, want fake_2 and fake_3 refunded in full", got)
+	}
+	for _, id := range []uint{1, 2, 3} {
+		if payment := f.paymentRepo.payments[id]; payment.Status != models.PaymentStatusRefunded || payment.RefundedAmount != payment.Amount {
+			t.Errorf("payment %d status %d with %.2f refunded, want refunded in full", id, payment.Status, payment.RefundedAmount)
+		}
+	}
+}
+
+func TestSplitPaymentDeclineRefundsCharges(t *testing.T) {
+	f := newRefundFixture(t)
+	f.paymentRepo.payments = nil
+
+	resp, err := f.paymentService().ProcessPayment(context.Background(), &PaymentRequest{
+		UserID:   buyerID,
+		UserType: models.UserTypeUser,
+		Amount:   100,
+		EventID:  1,
+		Splits:   []PaymentSplit{{PaymentMethodID: 1, Amount: 30}, {PaymentMethodID: 2}},
+	})
+	if err != nil {
+		t.Fatalf("ProcessPayment: %v", err)
+	}
+
+	if resp.Status != models.PaymentStatusFailed {
+		t.Errorf("payment status = %d, want failed", resp.Status)
+	}
+	charged := f.paymentRepo.payments[resp.Components[0].PaymentID]
+	if f.provider.refunded[charged.TransactionID] != 30 {
+		t.Errorf("provider refunds = %v, want 30.00 of %s", f.provider.refunded, charged.TransactionID)
+	}
+	if charged.Status != models.PaymentStatusRefunded || charged.RefundedAmount != 30 {
+		t.Errorf("charged component status %d with %.2f refunded, want refunded in full", charged.Status, charged.RefundedAmount)
+	}
+	if credits := f.paymentRepo.sellerPayments(); len(credits) != 0 {
+		t.Errorf("seller credited %+v for a failed payment", credits)
+	}
+}
+
+func TestProcessPaymentRefundsWhenSellerCreditFails(t *testing.T) {
+	f := newRefundFixture(t)
+	f.paymentRepo.payments = nil
+
+	_, err := f.paymentService().ProcessPayment(context.Background(), &PaymentRequest{
+		UserID:          buyerID,
+		UserType:        models.UserTypeUser,
+		Amount:          40,
+		EventID:         9, // Missing, so the seller can't be credited
+		PaymentMethodID: 1,
+	})
+	if code := apperrors.CodeOf(err); code != apperrors.CodeInternal {
+		t.Fatalf("error %v has code %q, want %q", err, code, apperrors.CodeInternal)
+	}
+
+	payment := f.paymentRepo.payments[1]
+	if f.provider.refunded[payment.TransactionID] != 40 {
+		t.Errorf("provider refunds = %v, want 40.00 of %s", f.provider.refunded, payment.TransactionID)
+	}
+	if payment.Status != models.PaymentStatusRefunded {
+		t.Errorf("payment status = %d, want refunded", payment.Status)
+	}
+}
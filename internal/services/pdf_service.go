@@ -4,23 +4,111 @@ package services
 import (
 	"bytes"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"eticketing/internal/models"
+	"eticketing/internal/repositories"
 	"github.com/go-pdf/fpdf"
 	"github.com/skip2/go-qrcode"
 )
 
-type PDFService struct{}
+type PDFService struct {
+	paymentRepo repositories.PaymentRepository
+	disputeRepo repositories.DisputeRepository
+}
 
 type TicketPDFData struct {
 	PurchasedTicket *models.PurchasedTicket
 	Event           *models.Event
-	QRCodeURL       string
+	QRPayload       string // See TicketQRPayload
+	GiftFrom        string // Set when the ticket was bought as a gift
+	GiftMessage     string
+
+	// Set for a watermarked preview without a QR code, issued while the
+	// order isn't settled; says why the ticket isn't valid yet
+	PreviewReason string
+}
+
+// qrPrefix marks codes issued by this system, so scanners can tell them from
+// unrelated QR codes
+const qrPrefix = "ETKT"
+
+// TicketQRPayload is what a ticket's QR code encodes: its ID and current QR
+// secret. Check-in accepts the code only while the secret is current.
+func TicketQRPayload(ticket *models.PurchasedTicket) string {
+	return fmt.Sprintf("%s:%d:%s", qrPrefix, ticket.ID, ticket.QRSecret)
+}
+
+// TicketHolderName is the name printed on a ticket and checked against photo
+// ID at events that require it. GetByID preloads the owner.
+func TicketHolderName(ticket *models.PurchasedTicket) string {
+	return strings.TrimSpace(ticket.User.Name + " " + ticket.User.Surname)
+}
+
+// ParseTicketQRPayload splits a scanned code into the ticket ID and secret
+func ParseTicketQRPayload(code string) (uint, string, bool) {
+	parts := strings.Split(strings.TrimSpace(code), ":")
+	if len(parts) != 3 || parts[0] != qrPrefix || parts[2] == "" {
+		return 0, "", false
+	}
+	id, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return 0, "", false
+	}
+	return uint(id), parts[2], true
 }
 
-func NewPDFService() *PDFService {
-	return &PDFService{}
+func NewPDFService(paymentRepo repositories.PaymentRepository, disputeRepo repositories.DisputeRepository) *PDFService {
+	return &PDFService{
+		paymentRepo: paymentRepo,
+		disputeRepo: disputeRepo,
+	}
+}
+
+// PreviewReason decides between the final ticket and a preview. It returns
+// why the ticket only gets a preview, or "" when its order is completed, not
+// refunded and has no chargeback in progress.
+func (s *PDFService) PreviewReason(ticket *models.PurchasedTicket) (string, error) {
+	if ticket.PaymentID == nil {
+		return "", nil
+	}
+
+	payment, err := s.paymentRepo.GetByID(*ticket.PaymentID)
+	if err != nil {
+		return "", err
+	}
+
+	switch payment.Status {
+	case models.PaymentStatusPending:
+		return "Payment for this order has not been completed yet", nil
+	case models.PaymentStatusFailed:
+		return "Payment for this order failed", nil
+	case models.PaymentStatusRefunded:
+		return "This order has been refunded", nil
+	}
+
+	// Chargebacks are raised against the charge itself, which for a split
+	// payment is one of its components
+	paymentIDs := []uint{payment.ID}
+	components, err := s.paymentRepo.ListByParent(payment.ID)
+	if err != nil {
+		return "", err
+	}
+	for _, component := range components {
+		paymentIDs = append(paymentIDs, component.ID)
+	}
+
+	disputed, err := s.disputeRepo.HasOpenForPayments(paymentIDs)
+	if err != nil {
+		return "", err
+	}
+	if disputed {
+		return "A refund for this order is pending", nil
+	}
+
+	return "", nil
 }
 
 func (s *PDFService) GenerateTicketPDF(data *TicketPDFData) ([]byte, error) {
@@ -65,6 +153,13 @@ func (s *PDFService) GenerateTicketPDF(data *TicketPDFData) ([]byte, error) {
 	pdf.Cell(130, 6, fmt.Sprintf("#%d", data.PurchasedTicket.ID))
 	pdf.Ln(8)
 
+	// Ticket holder; printed so a resold ticket doesn't pass as the buyer's
+	pdf.SetFont("Arial", "B", 11)
+	pdf.Cell(40, 6, "Ticket Holder:")
+	pdf.SetFont("Arial", "", 11)
+	pdf.Cell(130, 6, TicketHolderName(data.PurchasedTicket))
+	pdf.Ln(8)
+
 	// Ticket Type
 	pdf.SetFont("Arial", "B", 11)
 	pdf.Cell(40, 6, "Type:")
@@ -95,7 +190,40 @@ func (s *PDFService) GenerateTicketPDF(data *TicketPDFData) ([]byte, error) {
 	pdf.Cell(40, 6, "Price:")
 	pdf.SetFont("Arial", "", 11)
 	pdf.Cell(130, 6, fmt.Sprintf("$%.2f", data.PurchasedTicket.Price))
-	pdf.Ln(15)
+	pdf.Ln(8)
+
+	if data.Event.IDCheckRequired {
+		pdf.SetFont("Arial", "B", 11)
+		pdf.SetTextColor(192, 57, 43)
+		pdf.Cell(170, 6, "Photo ID matching the ticket holder's name is required for entry.")
+		pdf.SetTextColor(0, 0, 0)
+	}
+	pdf.Ln(7)
+
+	// Gift Section
+	if data.GiftFrom != "" {
+		pdf.SetFont("Arial", "B", 14)
+		pdf.SetTextColor(52, 73, 94)
+		pdf.Cell(170, 8, "GIFT")
+		pdf.Ln(8)
+
+		pdf.SetDrawColor(52, 73, 94)
+		pdf.Line(20, pdf.GetY(), 190, pdf.GetY())
+		pdf.Ln(12)
+
+		pdf.SetTextColor(0, 0, 0)
+		pdf.SetFont("Arial", "B", 11)
+		pdf.Cell(40, 6, "From:")
+		pdf.SetFont("Arial", "", 11)
+		pdf.Cell(130, 6, data.GiftFrom)
+		pdf.Ln(8)
+
+		if data.GiftMessage != "" {
+			pdf.SetFont("Arial", "I", 11)
+			pdf.MultiCell(170, 6, data.GiftMessage, "", "L", false)
+		}
+		pdf.Ln(10)
+	}
 
 	// Event Information Section
 	pdf.SetFont("Arial", "B", 14)
@@ -111,7 +239,7 @@ func (s *PDFService) GenerateTicketPDF(data *TicketPDFData) ([]byte, error) {
 	pdf.SetTextColor(0, 0, 0)
 
 	// Event Date
-	eventDate := time.Unix(data.Event.Date, 0)
+	eventDate := eventTime(data.Event, data.Event.Date)
 	pdf.SetFont("Arial", "B", 11)
 	pdf.Cell(40, 6, "Date:")
 	pdf.SetFont("Arial", "", 11)
@@ -122,7 +250,7 @@ func (s *PDFService) GenerateTicketPDF(data *TicketPDFData) ([]byte, error) {
 	pdf.SetFont("Arial", "B", 11)
 	pdf.Cell(40, 6, "Time:")
 	pdf.SetFont("Arial", "", 11)
-	pdf.Cell(130, 6, eventDate.Format("3:04 PM"))
+	pdf.Cell(130, 6, eventDate.Format("3:04 PM MST"))
 	pdf.Ln(8)
 
 	// Event Location
@@ -158,34 +286,45 @@ func (s *PDFService) GenerateTicketPDF(data *TicketPDFData) ([]byte, error) {
 		pdf.Ln(5)
 	}
 
-	// QR Code Section
-	pdf.Ln(10)
-	pdf.SetFont("Arial", "B", 14)
-	pdf.SetTextColor(52, 73, 94)
-	pdf.Cell(170, 8, "QR CODE")
-	pdf.Ln(8) // Increased from 2 to 8 for consistency
-
-	pdf.SetDrawColor(52, 73, 94)
-	pdf.Line(20, pdf.GetY(), 190, pdf.GetY())
-	pdf.Ln(12) // Increased from 10 to 12 for consistency
+	// QR Code Section; previews get no code, so they can't be scanned in
+	if data.PreviewReason != "" {
+		s.drawPreviewNotice(pdf, data.PreviewReason)
+	} else {
+		pdf.Ln(10)
+		pdf.SetFont("Arial", "B", 14)
+		pdf.SetTextColor(52, 73, 94)
+		pdf.Cell(170, 8, "QR CODE")
+		pdf.Ln(8) // Increased from 2 to 8 for consistency
+
+		pdf.SetDrawColor(52, 73, 94)
+		pdf.Line(20, pdf.GetY(), 190, pdf.GetY())
+		pdf.Ln(12) // Increased from 10 to 12 for consistency
+
+		// Generate QR code
+		qrCode, err := qrcode.Encode(data.QRPayload, qrcode.Medium, 256)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate QR code: %v", err)
+		}
 
-	// Generate QR code
-	qrCode, err := qrcode.Encode(data.QRCodeURL, qrcode.Medium, 256)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate QR code: %v", err)
-	}
+		// Add QR code to PDF
+		qrReader := bytes.NewReader(qrCode)
+		pdf.RegisterImageReader("qr", "PNG", qrReader)
 
-	// Add QR code to PDF
-	qrReader := bytes.NewReader(qrCode)
-	pdf.RegisterImageReader("qr", "PNG", qrReader)
+		// Center the QR code
+		qrSize := 50.0
+		pageWidth := 210.0 // A4 width in mm
+		qrX := (pageWidth - qrSize) / 2
 
-	// Center the QR code
-	qrSize := 50.0
-	pageWidth := 210.0 // A4 width in mm
-	qrX := (pageWidth - qrSize) / 2
+		pdf.Image("qr", qrX, pdf.GetY(), qrSize, qrSize, false, "PNG", 0, "")
+		pdf.Ln(52)
 
-	pdf.Image("qr", qrX, pdf.GetY(), qrSize, qrSize, false, "PNG", 0, "")
-	pdf.Ln(60) // Increased from 55 to 60 for more space after QR code
+		// The printed code never changes, so copies of it work too; the
+		// door flags it as lower trust than the app's rotating code
+		pdf.SetFont("Arial", "I", 9)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(170, 5, "Backup code. Where possible, show the live code in the app at the door.", "", 0, "C", false, 0, "")
+		pdf.Ln(8)
+	}
 
 	// QR Code instruction
 	pdf.SetFont("Arial", "", 10)
@@ -205,16 +344,50 @@ func (s *PDFService) GenerateTicketPDF(data *TicketPDFData) ([]byte, error) {
 	pdf.Cell(85, 4, fmt.Sprintf("Generated on: %s", time.Now().Format("Jan 2, 2006 at 3:04 PM")))
 	pdf.Cell(85, 4, "E-Ticketing System")
 
+	if data.PreviewReason != "" {
+		s.drawWatermark(pdf)
+	}
+
 	// Return PDF as bytes
 	var buf bytes.Buffer
-	err = pdf.Output(&buf)
-	if err != nil {
+	if err := pdf.Output(&buf); err != nil {
 		return nil, fmt.Errorf("failed to generate PDF: %v", err)
 	}
 
 	return buf.Bytes(), nil
 }
 
+// drawPreviewNotice takes the QR code's place on a preview
+func (s *PDFService) drawPreviewNotice(pdf *fpdf.Fpdf, reason string) {
+	pdf.Ln(10)
+	pdf.SetFont("Arial", "B", 14)
+	pdf.SetTextColor(192, 57, 43)
+	pdf.Cell(170, 8, "PREVIEW - NOT VALID FOR ENTRY")
+	pdf.Ln(8)
+
+	pdf.SetDrawColor(192, 57, 43)
+	pdf.Line(20, pdf.GetY(), 190, pdf.GetY())
+	pdf.Ln(12)
+
+	pdf.SetFont("Arial", "", 11)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.MultiCell(170, 6, reason+". The final ticket with its entry code can be downloaded once the order is settled.", "", "L", false)
+	pdf.Ln(10)
+}
+
+// drawWatermark stamps "NOT VALID FOR ENTRY" diagonally across the page
+func (s *PDFService) drawWatermark(pdf *fpdf.Fpdf) {
+	pdf.SetFont("Arial", "B", 48)
+	pdf.SetTextColor(192, 57, 43)
+	pdf.SetAlpha(0.2, "Normal")
+	pdf.TransformBegin()
+	pdf.TransformRotate(45, 105, 148)
+	text := "NOT VALID FOR ENTRY"
+	pdf.Text(105-pdf.GetStringWidth(text)/2, 148, text)
+	pdf.TransformEnd()
+	pdf.SetAlpha(1, "Normal")
+}
+
 func (s *PDFService) getTicketTypeText(ticketType models.TicketType) string {
 	switch ticketType {
 	case models.TicketTypeRegular:
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// policyCacheTTL is how long the policies in force are served from memory.
+// They are checked on every change an account makes, so other instances
+// start asking for a newly published version within it.
+const policyCacheTTL = 30 * time.Second
+
+// PolicyService publishes versions of the terms of service and privacy
+// policy and records which of them each account accepted
+type PolicyService struct {
+	policyRepo repositories.PolicyRepository
+	auditRepo  repositories.AuditLogRepository
+	txManager  repositories.TransactionManager
+
+	mutex    sync.RWMutex
+	current  []models.PolicyDocument
+	loadedAt time.Time
+}
+
+type PublishPolicyRequest struct {
+	Kind    models.PolicyKind `json:"kind" binding:"required,oneof=1 2"` // 1 terms of service, 2 privacy policy
+	Title   string            `json:"title" binding:"required,max=200"`
+	Content string            `json:"content" binding:"required"`
+	Summary string            `json:"summary" binding:"max=2000"` // What changed, shown when asking to accept again
+}
+
+// AcceptPoliciesRequest names the versions the account was shown, so a
+// version published in the meantime isn't accepted unseen
+type AcceptPoliciesRequest struct {
+	Policies []PolicyVersion `json:"policies" binding:"required,min=1,dive"`
+}
+
+type PolicyVersion struct {
+	Kind    models.PolicyKind `json:"kind" binding:"required,oneof=1 2"`
+	Version int               `json:"version" binding:"required,min=1"`
+}
+
+// PolicyStatus is where an account stands with one policy in force
+type PolicyStatus struct {
+	Kind            models.PolicyKind `json:"kind"`
+	Title           string            `json:"title"`
+	Version         int               `json:"version"` // In force
+	PublishedAt     int64             `json:"published_at"`
+	Summary         string            `json:"summary,omitempty"`
+	Accepted        bool              `json:"accepted"`                   // The version in force
+	AcceptedVersion int               `json:"accepted_version,omitempty"` // Latest accepted, possibly older
+	AcceptedAt      *int64            `json:"accepted_at,omitempty"`
+}
+
+func NewPolicyService(
+	policyRepo repositories.PolicyRepository,
+	auditRepo repositories.AuditLogRepository,
+	txManager repositories.TransactionManager,
+) *PolicyService {
+	return &PolicyService{
+		policyRepo: policyRepo,
+		auditRepo:  auditRepo,
+		txManager:  txManager,
+	}
+}
+
+// Current returns the version in force of each policy. When they can't be
+// read the last known ones are kept, which is none until they have been
+// read once.
+func (s *PolicyService) Current() []models.PolicyDocument {
+	s.mutex.RLock()
+	current, loadedAt := s.current, s.loadedAt
+	s.mutex.RUnlock()
+	if time.Since(loadedAt) < policyCacheTTL {
+		return current
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if time.Since(s.loadedAt) >= policyCacheTTL {
+		s.loadedAt = time.Now()
+		documents, err := s.policyRepo.ListLatest()
+		if err != nil {
+			log.Printf("policies: failed to load current versions: %v", err)
+		} else {
+			s.current = documents
+		}
+	}
+	return s.current
+}
+
+func (s *PolicyService) ListVersions(kind models.PolicyKind, limit, offset int) ([]models.PolicyDocument, int64, error) {
+	documents, err := s.policyRepo.ListVersions(kind, limit, offset)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to load policies")
+	}
+	total, err := s.policyRepo.CountVersions(kind)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to count policies")
+	}
+	return documents, total, nil
+}
+
+// Publish puts a new version of a policy in force. Every account has to
+// accept it before it can change anything again.
+func (s *PolicyService) Publish(req *PublishPolicyRequest, adminID uint, ip string) (*models.PolicyDocument, error) {
+	version := 1
+	latest, err := s.policyRepo.GetLatest(req.Kind)
+	if err == nil {
+		version = latest.Version + 1
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.Internal("failed to load policy")
+	}
+
+	document := &models.PolicyDocument{
+		Kind:        req.Kind,
+		Version:     version,
+		Title:       strings.TrimSpace(req.Title),
+		Content:     req.Content,
+		Summary:     strings.TrimSpace(req.Summary),
+		PublishedBy: adminID,
+		PublishedAt: time.Now().Unix(),
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.policyRepo.WithTx(tx).CreateDocument(document); err != nil {
+			return err
+		}
+		return s.auditRepo.WithTx(tx).Create(&models.AuditLog{
+			ActorID:   adminID,
+			ActorType: models.UserTypeAdmin,
+			Action:    models.AuditActionPolicyPublished,
+			Details:   fmt.Sprintf("policy=%d kind=%d version=%d title=%q", document.ID, document.Kind, document.Version, document.Title),
+			IP:        ip,
+			CreatedAt: document.PublishedAt,
+		})
+	})
+	if err != nil {
+		return nil, apperrors.Internal("failed to publish policy")
+	}
+
+	s.mutex.Lock()
+	s.loadedAt = time.Time{}
+	s.mutex.Unlock()
+
+	log.Printf("policies: admin %d published version %d of policy kind %d", adminID, document.Version, document.Kind)
+	return document, nil
+}
+
+// Status returns, for each policy in force, whether the account accepted it
+func (s *PolicyService) Status(userID uint, userType models.UserType) ([]PolicyStatus, error) {
+	return s.status(s.Current(), userID, userType)
+}
+
+func (s *PolicyService) status(current []models.PolicyDocument, userID uint, userType models.UserType) ([]PolicyStatus, error) {
+	acceptances, err := s.policyRepo.ListAcceptances(userID, userType)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load policy acceptances")
+	}
+
+	statuses := make([]PolicyStatus, 0, len(current))
+	for _, document := range current {
+		status := PolicyStatus{
+			Kind:        document.Kind,
+			Title:       document.Title,
+			Version:     document.Version,
+			PublishedAt: document.PublishedAt,
+			Summary:     document.Summary,
+		}
+		for _, acceptance := range acceptances {
+			if acceptance.Kind == document.Kind && acceptance.Version > status.AcceptedVersion {
+				acceptedAt := acceptance.AcceptedAt
+				status.AcceptedVersion = acceptance.Version
+				status.AcceptedAt = &acceptedAt
+			}
+		}
+		status.Accepted = status.AcceptedVersion >= document.Version
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Pending returns the policies in force the account hasn't accepted yet
+func (s *PolicyService) Pending(userID uint, userType models.UserType) ([]models.PolicyDocument, error) {
+	current := s.Current()
+	if len(current) == 0 {
+		return nil, nil
+	}
+
+	statuses, err := s.status(current, userID, userType)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []models.PolicyDocument
+	for i, status := range statuses {
+		if !status.Accepted {
+			pending = append(pending, current[i])
+		}
+	}
+	return pending, nil
+}
+
+// Accept records that the account accepted the named versions, which
+// must be the ones in force
+func (s *PolicyService) Accept(userID uint, userType models.UserType, req *AcceptPoliciesRequest, ip string) ([]PolicyStatus, error) {
+	now := time.Now().Unix()
+	acceptances := make([]models.PolicyAcceptance, 0, len(req.Policies))
+	for _, accepted := range req.Policies {
+		// Read past the cache, which may not have a version just published
+		latest, err := s.policyRepo.GetLatest(accepted.Kind)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperrors.NotFound("policy not found")
+			}
+			return nil, apperrors.Internal("failed to load policy")
+		}
+		if accepted.Version != latest.Version {
+			return nil, apperrors.Conflict(fmt.Sprintf("version %d of %q is in force; review it and accept again", latest.Version, latest.Title))
+		}
+
+		acceptances = append(acceptances, models.PolicyAcceptance{
+			UserID:     userID,
+			UserType:   userType,
+			Kind:       latest.Kind,
+			Version:    latest.Version,
+			IP:         ip,
+			AcceptedAt: now,
+		})
+	}
+
+	if err := s.policyRepo.CreateAcceptances(acceptances); err != nil {
+		return nil, apperrors.Internal("failed to record policy acceptance")
+	}
+	return s.Status(userID, userType)
+}
+
+// AcceptCurrent records that a new account accepted every policy in force
+// when it signed up
+func (s *PolicyService) AcceptCurrent(userID uint, userType models.UserType, ip string) error {
+	now := time.Now().Unix()
+	var acceptances []models.PolicyAcceptance
+	for _, document := range s.Current() {
+		acceptances = append(acceptances, models.PolicyAcceptance{
+			UserID:     userID,
+			UserType:   userType,
+			Kind:       document.Kind,
+			Version:    document.Version,
+			IP:         ip,
+			AcceptedAt: now,
+		})
+	}
+
+	if err := s.policyRepo.CreateAcceptances(acceptances); err != nil {
+		return apperrors.Internal("failed to record policy acceptance")
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+// internal/services/pricing_service.go
+package services
+
+import (
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type PricingService struct {
+	priceTierRepo   repositories.PriceTierRepository
+	ticketRepo      repositories.TicketRepository
+	ticketGroupRepo repositories.TicketGroupRepository
+	eventRepo       repositories.EventRepository
+	access          *EventAccess
+	txManager       repositories.TransactionManager
+}
+
+type PriceTierRequest struct {
+	Name            string  `json:"name" binding:"required"`
+	Price           float64 `json:"price" binding:"min=0"`
+	StartsAt        int64   `json:"starts_at" binding:"omitempty,unixtime"`
+	StartsAfterSold int     `json:"starts_after_sold" binding:"min=0"`
+}
+
+// SetPriceTiersRequest replaces the pricing schedule of one ticket group.
+// The group is identified the same way as in UpdateTickets; an empty Tiers
+// list removes the schedule so the list price applies again.
+type SetPriceTiersRequest struct {
+	GroupID uint               `json:"group_id"`
+	Price   float64            `json:"price" binding:"min=0"`
+	Type    models.TicketType  `json:"type" binding:"required_without=GroupID"`
+	IsVip   bool               `json:"is_vip"`
+	Title   string             `json:"title" binding:"required_without=GroupID"`
+	Place   string             `json:"place" binding:"required_without=GroupID"`
+	SaleID  uint               `json:"sale_id" binding:"required_without=GroupID"`
+	Tiers   []PriceTierRequest `json:"tiers" binding:"dive"`
+}
+
+type PriceTierResponse struct {
+	ID              uint    `json:"id"`
+	Name            string  `json:"name"`
+	Price           float64 `json:"price"`
+	Position        int     `json:"position"`
+	StartsAt        int64   `json:"starts_at"`
+	StartsAfterSold int     `json:"starts_after_sold"`
+	IsCurrent       bool    `json:"is_current"`
+}
+
+type GroupPricingResponse struct {
+	GroupID      uint                `json:"group_id"`
+	SaleID       uint                `json:"sale_id"`
+	Price        float64             `json:"price"` // List price of the group
+	Type         models.TicketType   `json:"type"`
+	IsVip        bool                `json:"is_vip"`
+	Title        string              `json:"title"`
+	Place        string              `json:"place"`
+	CurrentPrice float64             `json:"current_price"`
+	Tiers        []PriceTierResponse `json:"tiers"`
+}
+
+func NewPricingService(
+	priceTierRepo repositories.PriceTierRepository,
+	ticketRepo repositories.TicketRepository,
+	ticketGroupRepo repositories.TicketGroupRepository,
+	eventRepo repositories.EventRepository,
+	access *EventAccess,
+	txManager repositories.TransactionManager,
+) *PricingService {
+	return &PricingService{
+		priceTierRepo:   priceTierRepo,
+		ticketRepo:      ticketRepo,
+		ticketGroupRepo: ticketGroupRepo,
+		eventRepo:       eventRepo,
+		access:          access,
+		txManager:       txManager,
+	}
+}
+
+func (s *PricingService) SetPriceTiers(eventID, sellerID uint, req *SetPriceTiersRequest) (*GroupPricingResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, apperrors.NotFound("event not found")
+	}
+	if !s.access.Allowed(event, sellerID, models.EventPermissionManageTickets) {
+		return nil, apperrors.Forbidden("unauthorized to set prices for this event")
+	}
+
+	group, err := findTicketGroup(s.ticketGroupRepo, eventID, models.GroupedTicket{
+		GroupID: req.GroupID,
+		SaleID:  req.SaleID,
+		Price:   req.Price,
+		Type:    req.Type,
+		IsVip:   req.IsVip,
+		Title:   req.Title,
+		Place:   req.Place,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		priceTierRepo := s.priceTierRepo.WithTx(tx)
+		if err := priceTierRepo.DeleteByGroup(group.ID); err != nil {
+			return err
+		}
+
+		for i, tierReq := range req.Tiers {
+			tier := &models.PriceTier{
+				EventID:         eventID,
+				GroupID:         group.ID,
+				SaleID:          group.SaleID,
+				GroupPrice:      group.Price,
+				Type:            group.Type,
+				IsVip:           group.IsVip,
+				Title:           group.Title,
+				Place:           group.Place,
+				Name:            tierReq.Name,
+				Price:           tierReq.Price,
+				Position:        i + 1,
+				StartsAt:        tierReq.StartsAt,
+				StartsAfterSold: tierReq.StartsAfterSold,
+			}
+			if err := priceTierRepo.Create(tier); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, apperrors.Internal("failed to save price tiers")
+	}
+
+	tiers, err := s.priceTierRepo.ListByGroup(group.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve price tiers")
+	}
+
+	return s.groupPricing(groupedTicketOf(group), tiers)
+}
+
+// GetEventPricing lists the pricing schedule of every tiered group in the event
+func (s *PricingService) GetEventPricing(eventID uint) ([]GroupPricingResponse, error) {
+	tiers, err := s.priceTierRepo.ListByEvent(eventID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve price tiers")
+	}
+
+	var responses []GroupPricingResponse
+	for start := 0; start < len(tiers); {
+		groupID := tiers[start].GroupID
+
+		end := start + 1
+		for end < len(tiers) && tiers[end].GroupID == groupID {
+			end++
+		}
+
+		group, err := s.ticketGroupRepo.GetByID(groupID)
+		if err != nil {
+			start = end // Schedule left over from a removed group
+			continue
+		}
+
+		response, err := s.groupPricing(groupedTicketOf(group), tiers[start:end])
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *response)
+		start = end
+	}
+
+	return responses, nil
+}
+
+// QuotePrices returns the price of each of the next quantity tickets sold
+// from the group. A purchase that crosses a sold-count threshold pays the
+// new tier's price for the tickets past the threshold.
+func (s *PricingService) QuotePrices(group models.GroupedTicket, quantity int) ([]float64, error) {
+	tiers, err := s.priceTierRepo.ListByGroup(group.GroupID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve price tiers")
+	}
+
+	prices := make([]float64, quantity)
+	if len(tiers) == 0 {
+		for i := range prices {
+			prices[i] = group.Price
+		}
+		return prices, nil
+	}
+
+	sold, err := s.ticketRepo.CountSoldByGroup(group.GroupID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count sold tickets")
+	}
+
+	now := time.Now().Unix()
+	for i := range prices {
+		prices[i] = s.currentPrice(group.Price, tiers, int(sold)+i, now)
+	}
+
+	return prices, nil
+}
+
+// ApplyCurrentPrices fills CurrentPrice on grouped tickets for display
+func (s *PricingService) ApplyCurrentPrices(groups []models.GroupedTicket) error {
+	for i := range groups {
+		prices, err := s.QuotePrices(groups[i], 1)
+		if err != nil {
+			return err
+		}
+		groups[i].CurrentPrice = prices[0]
+	}
+	return nil
+}
+
+func (s *PricingService) groupPricing(group models.GroupedTicket, tiers []models.PriceTier) (*GroupPricingResponse, error) {
+	sold, err := s.ticketRepo.CountSoldByGroup(group.GroupID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count sold tickets")
+	}
+
+	now := time.Now().Unix()
+	current := s.currentTier(tiers, int(sold), now)
+
+	response := &GroupPricingResponse{
+		GroupID:      group.GroupID,
+		SaleID:       group.SaleID,
+		Price:        group.Price,
+		Type:         group.Type,
+		IsVip:        group.IsVip,
+		Title:        group.Title,
+		Place:        group.Place,
+		CurrentPrice: group.Price,
+		Tiers:        []PriceTierResponse{},
+	}
+
+	for i := range tiers {
+		tier := &tiers[i]
+		isCurrent := current == tier
+		if isCurrent {
+			response.CurrentPrice = tier.Price
+		}
+
+		response.Tiers = append(response.Tiers, PriceTierResponse{
+			ID:              tier.ID,
+			Name:            tier.Name,
+			Price:           tier.Price,
+			Position:        tier.Position,
+			StartsAt:        tier.StartsAt,
+			StartsAfterSold: tier.StartsAfterSold,
+			IsCurrent:       isCurrent,
+		})
+	}
+
+	return response, nil
+}
+
+func (s *PricingService) currentPrice(listPrice float64, tiers []models.PriceTier, sold int, now int64) float64 {
+	if tier := s.currentTier(tiers, sold, now); tier != nil {
+		return tier.Price
+	}
+	return listPrice
+}
+
+// currentTier returns the last tier (tiers are ordered by position) that has
+// kicked in, or nil if none has and the list price applies
+func (s *PricingService) currentTier(tiers []models.PriceTier, sold int, now int64) *models.PriceTier {
+	var current *models.PriceTier
+	for i := range tiers {
+		tier := &tiers[i]
+
+		hasTimeTrigger := tier.StartsAt > 0
+		hasSoldTrigger := tier.StartsAfterSold > 0
+
+		started := !hasTimeTrigger && !hasSoldTrigger
+		if hasTimeTrigger && now >= tier.StartsAt {
+			started = true
+		}
+		if hasSoldTrigger && sold >= tier.StartsAfterSold {
+			started = true
+		}
+
+		if started {
+			current = tier
+		}
+	}
+	return current
+}
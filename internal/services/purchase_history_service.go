@@ -0,0 +1,193 @@
+package services
+
+import (
+	"fmt"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+)
+
+// Refund states of an order in the purchase history
+const (
+	RefundStateNone      = "none"
+	RefundStateRequested = "requested"
+	RefundStateRejected  = "rejected"
+	RefundStatePartial   = "partially_refunded"
+	RefundStateRefunded  = "refunded"
+)
+
+// PurchaseHistoryService puts a customer's orders together with the tickets
+// they bought and where their refunds stand, grouped by event
+type PurchaseHistoryService struct {
+	paymentRepo         repositories.PaymentRepository
+	purchasedTicketRepo repositories.PurchasedTicketRepository
+	refundRequestRepo   repositories.RefundRequestRepository
+	publicURL           string
+}
+
+// EventPurchases is everything the customer bought for one event. Totals
+// leave out orders whose charge never went through.
+type EventPurchases struct {
+	EventID       uint            `json:"event_id"` // 0 for standalone charges
+	EventTitle    string          `json:"event_title,omitempty"`
+	EventDate     int64           `json:"event_date,omitempty"`
+	TotalPaid     float64         `json:"total_paid"`
+	TotalRefunded float64         `json:"total_refunded"`
+	ValidTickets  int             `json:"valid_tickets"` // Still held by the customer and not voided
+	Orders        []PurchaseOrder `json:"orders"`
+}
+
+type PurchaseOrder struct {
+	PaymentID      uint                 `json:"payment_id"`
+	Date           int64                `json:"date"`
+	Amount         float64              `json:"amount"`
+	Status         models.PaymentStatus `json:"status"`
+	RefundedAmount float64              `json:"refunded_amount"`
+	RefundState    string               `json:"refund_state"`
+	ReceiptURL     string               `json:"receipt_url"`
+	Tickets        []PurchasedItem      `json:"tickets"`
+}
+
+type PurchasedItem struct {
+	ID            uint    `json:"id"`
+	Title         string  `json:"title"`
+	Place         string  `json:"place"`
+	Price         float64 `json:"price"`
+	IsUsed        bool    `json:"is_used"`
+	IsInvalidated bool    `json:"is_invalidated"`
+	PurchasedAt   int64   `json:"purchased_at"`
+	Transferred   bool    `json:"transferred"`            // Now held by someone else
+	DownloadURL   string  `json:"download_url,omitempty"` // While the customer still holds it
+}
+
+func NewPurchaseHistoryService(
+	paymentRepo repositories.PaymentRepository,
+	purchasedTicketRepo repositories.PurchasedTicketRepository,
+	refundRequestRepo repositories.RefundRequestRepository,
+	publicURL string,
+) *PurchaseHistoryService {
+	return &PurchaseHistoryService{
+		paymentRepo:         paymentRepo,
+		purchasedTicketRepo: purchasedTicketRepo,
+		refundRequestRepo:   refundRequestRepo,
+		publicURL:           publicURL,
+	}
+}
+
+// GetPurchases returns the user's orders grouped by event, the event with
+// the most recent order first
+func (s *PurchaseHistoryService) GetPurchases(userID uint) ([]EventPurchases, error) {
+	payments, err := s.paymentRepo.ListByUserAndType(userID, models.UserTypeUser, repositories.PaymentFilter{}, -1, 0) // -1 lifts the limit
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve payments")
+	}
+
+	paymentIDs := make([]uint, 0, len(payments))
+	for _, payment := range payments {
+		paymentIDs = append(paymentIDs, payment.ID)
+	}
+
+	tickets, err := s.purchasedTicketRepo.ListByPayments(paymentIDs)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve tickets")
+	}
+	ticketsByPayment := make(map[uint][]models.PurchasedTicket)
+	for _, ticket := range tickets {
+		ticketsByPayment[*ticket.PaymentID] = append(ticketsByPayment[*ticket.PaymentID], ticket)
+	}
+
+	// Requests come newest first; the latest one per order decides its state
+	requests, err := s.refundRequestRepo.ListByUser(userID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve refund requests")
+	}
+	latestRequest := make(map[uint]models.RefundRequestStatus)
+	for _, request := range requests {
+		if _, ok := latestRequest[request.PaymentID]; !ok {
+			latestRequest[request.PaymentID] = request.Status
+		}
+	}
+
+	groups := make([]EventPurchases, 0)
+	groupIndex := make(map[uint]int)
+	for _, payment := range payments {
+		index, ok := groupIndex[payment.EventID]
+		if !ok {
+			index = len(groups)
+			groupIndex[payment.EventID] = index
+			groups = append(groups, EventPurchases{
+				EventID:    payment.EventID,
+				EventTitle: payment.Event.Title,
+				EventDate:  payment.Event.Date,
+				Orders:     []PurchaseOrder{},
+			})
+		}
+		group := &groups[index]
+
+		order := PurchaseOrder{
+			PaymentID:      payment.ID,
+			Date:           payment.Date,
+			Amount:         payment.Amount,
+			Status:         payment.Status,
+			RefundedAmount: payment.RefundedAmount,
+			RefundState:    refundState(&payment, latestRequest[payment.ID]),
+			ReceiptURL:     fmt.Sprintf("%s/api/v1/payments/%d", s.publicURL, payment.ID),
+			Tickets:        []PurchasedItem{},
+		}
+
+		for _, ticket := range ticketsByPayment[payment.ID] {
+			item := PurchasedItem{
+				ID:            ticket.ID,
+				Title:         ticket.Title,
+				Place:         ticket.Place,
+				Price:         ticket.Price,
+				IsUsed:        ticket.IsUsed,
+				IsInvalidated: ticket.IsInvalidated,
+				PurchasedAt:   ticket.PurchasedAt,
+				Transferred:   ticket.UserID != userID,
+			}
+			if !item.Transferred {
+				item.DownloadURL = fmt.Sprintf("%s/api/v1/tickets/%d/download", s.publicURL, ticket.ID)
+				if !ticket.IsInvalidated {
+					group.ValidTickets++
+				}
+			}
+			order.Tickets = append(order.Tickets, item)
+		}
+
+		if payment.Status == models.PaymentStatusCompleted || payment.Status == models.PaymentStatusRefunded {
+			group.TotalPaid = roundCents(group.TotalPaid + payment.Amount)
+			group.TotalRefunded = roundCents(group.TotalRefunded + refundedTotal(&payment))
+		}
+
+		group.Orders = append(group.Orders, order)
+	}
+
+	return groups, nil
+}
+
+// refundState says where an order's refund stands. Money already returned
+// outranks an open or rejected request.
+func refundState(payment *models.Payment, request models.RefundRequestStatus) string {
+	switch {
+	case payment.Status == models.PaymentStatusRefunded:
+		return RefundStateRefunded
+	case payment.RefundedAmount > 0:
+		return RefundStatePartial
+	case request == models.RefundRequestStatusPending:
+		return RefundStateRequested
+	case request == models.RefundRequestStatusRejected:
+		return RefundStateRejected
+	}
+	return RefundStateNone
+}
+
+// refundedTotal is what went back to the customer; payments refunded
+// before partial refunds existed carry no refunded amount
+func refundedTotal(payment *models.Payment) float64 {
+	if payment.Status == models.PaymentStatusRefunded && payment.RefundedAmount == 0 {
+		return payment.Amount
+	}
+	return payment.RefundedAmount
+}
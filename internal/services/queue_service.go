@@ -0,0 +1,227 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// QueueService runs the waiting rooms of high-demand sales. Buyers join
+// and get a token, the queue_dispense job admits a few of them a minute,
+// and purchases of queued events need an admitted token.
+type QueueService struct {
+	queueRepo       repositories.QueueRepository
+	eventRepo       repositories.EventRepository
+	ticketRepo      repositories.TicketRepository
+	admitPerMinute  int
+	admissionWindow time.Duration
+}
+
+// QueueStatus is a buyer's place in the waiting room
+type QueueStatus struct {
+	Token         string                  `json:"token"`
+	EventID       uint                    `json:"event_id"`
+	Status        models.QueueEntryStatus `json:"status"`
+	Position      int64                   `json:"position,omitempty"`       // 1 is next in line; while waiting
+	EstimatedWait int64                   `json:"estimated_wait,omitempty"` // Seconds, while waiting
+	AdmittedUntil *int64                  `json:"admitted_until,omitempty"` // Buy before this Unix timestamp
+}
+
+func NewQueueService(
+	queueRepo repositories.QueueRepository,
+	eventRepo repositories.EventRepository,
+	ticketRepo repositories.TicketRepository,
+	admitPerMinute int,
+	admissionWindow time.Duration,
+) *QueueService {
+	return &QueueService{
+		queueRepo:       queueRepo,
+		eventRepo:       eventRepo,
+		ticketRepo:      ticketRepo,
+		admitPerMinute:  admitPerMinute,
+		admissionWindow: admissionWindow,
+	}
+}
+
+// Join puts the user in the event's waiting room. Joining again returns
+// the place the user already holds rather than a new one at the back.
+func (s *QueueService) Join(eventID, userID uint) (*QueueStatus, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, apperrors.NotFound("event not found")
+	}
+	if !event.QueueEnabled {
+		return nil, apperrors.Validation("this event has no waiting room; buy tickets directly")
+	}
+
+	entry, err := s.queueRepo.GetOpenByUser(eventID, userID)
+	if err == nil {
+		return s.status(entry, event)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperrors.Internal("failed to check the waiting room")
+	}
+
+	token, err := utils.RandomHex(32)
+	if err != nil {
+		return nil, apperrors.Internal("failed to generate queue token")
+	}
+	entry = &models.QueueEntry{
+		EventID:  eventID,
+		UserID:   userID,
+		Token:    token,
+		Status:   models.QueueEntryStatusWaiting,
+		JoinedAt: time.Now().Unix(),
+	}
+	if err := s.queueRepo.Create(entry); err != nil {
+		return nil, apperrors.Internal("failed to join the waiting room")
+	}
+
+	return s.status(entry, event)
+}
+
+// GetStatus returns where the user's queue token stands
+func (s *QueueService) GetStatus(token string, userID uint) (*QueueStatus, error) {
+	entry, err := s.queueRepo.GetByToken(token)
+	if err != nil || entry.UserID != userID {
+		return nil, apperrors.NotFound("queue token not found")
+	}
+
+	event, err := s.eventRepo.GetByID(entry.EventID)
+	if err != nil {
+		return nil, apperrors.NotFound("event not found")
+	}
+	return s.status(entry, event)
+}
+
+// AdmitPurchase lets a purchase through when the event has no waiting room
+// or token is the user's admission to it, reporting whether it went through
+// the waiting room. Legacy purchases name only the ticket, so its event is
+// looked up when eventID is 0.
+func (s *QueueService) AdmitPurchase(eventID, ticketID, userID uint, token string) (bool, error) {
+	if eventID == 0 {
+		ticket, err := s.ticketRepo.GetByID(ticketID)
+		if err != nil {
+			return false, nil // Left for the purchase itself to reject
+		}
+		eventID = ticket.EventID
+	}
+
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil || !event.QueueEnabled {
+		return false, nil
+	}
+
+	if token == "" {
+		return true, admissionRequired("this sale has a waiting room; join the queue and wait to be admitted")
+	}
+	entry, err := s.queueRepo.GetByToken(token)
+	if err != nil || entry.UserID != userID || entry.EventID != eventID {
+		return true, admissionRequired("queue token is not valid for this event")
+	}
+
+	switch entry.Status {
+	case models.QueueEntryStatusWaiting:
+		return true, admissionRequired("you have not been admitted yet; keep your place in the queue")
+	case models.QueueEntryStatusUsed:
+		return true, admissionRequired("this admission has already been used; join the queue again")
+	}
+	if entry.Status != models.QueueEntryStatusAdmitted || entry.ExpiresAt == nil || *entry.ExpiresAt < time.Now().Unix() {
+		return true, admissionRequired("your admission has expired; join the queue again")
+	}
+	return true, nil
+}
+
+// CompletePurchase closes the admission token bought with
+func (s *QueueService) CompletePurchase(token string) error {
+	entry, err := s.queueRepo.GetByToken(token)
+	if err != nil {
+		return nil
+	}
+	if _, err := s.queueRepo.MarkUsed(entry.ID); err != nil {
+		return apperrors.Internal("failed to close queue admission")
+	}
+	return nil
+}
+
+// Dispense admits the next buyers of every waiting room, as many as each
+// event's rate allows over interval, the time since the last run
+func (s *QueueService) Dispense(interval time.Duration) (int64, error) {
+	eventIDs, err := s.queueRepo.ListWaitingEventIDs()
+	if err != nil {
+		return 0, apperrors.Internal("failed to list waiting rooms")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.admissionWindow).Unix()
+
+	var admitted int64
+	for _, eventID := range eventIDs {
+		event, err := s.eventRepo.GetByID(eventID)
+		if err != nil {
+			continue
+		}
+		limit := int(math.Ceil(float64(s.rate(event)) * interval.Minutes()))
+		if limit < 1 {
+			limit = 1
+		}
+
+		count, err := s.queueRepo.AdmitNext(eventID, limit, now.Unix(), expiresAt)
+		if err != nil {
+			return admitted, apperrors.Internal("failed to admit queued buyers")
+		}
+		admitted += count
+	}
+	return admitted, nil
+}
+
+// ExpireAdmissions closes admissions nobody bought with in time
+func (s *QueueService) ExpireAdmissions() (int64, error) {
+	expired, err := s.queueRepo.ExpireAdmissions(time.Now().Unix())
+	if err != nil {
+		return 0, apperrors.Internal("failed to expire queue admissions")
+	}
+	return expired, nil
+}
+
+func (s *QueueService) status(entry *models.QueueEntry, event *models.Event) (*QueueStatus, error) {
+	status := &QueueStatus{
+		Token:   entry.Token,
+		EventID: entry.EventID,
+		Status:  entry.Status,
+	}
+
+	switch entry.Status {
+	case models.QueueEntryStatusWaiting:
+		ahead, err := s.queueRepo.CountAhead(entry.EventID, entry.ID)
+		if err != nil {
+			return nil, apperrors.Internal("failed to check the waiting room")
+		}
+		status.Position = ahead + 1
+		status.EstimatedWait = int64(math.Ceil(float64(status.Position)/float64(s.rate(event)))) * 60
+	case models.QueueEntryStatusAdmitted:
+		status.AdmittedUntil = entry.ExpiresAt
+	}
+	return status, nil
+}
+
+// rate is how many buyers the event's waiting room admits a minute
+func (s *QueueService) rate(event *models.Event) int {
+	if event.QueueAdmitPerMinute > 0 {
+		return event.QueueAdmitPerMinute
+	}
+	if s.admitPerMinute > 0 {
+		return s.admitPerMinute
+	}
+	return 1
+}
+
+func admissionRequired(message string) error {
+	return apperrors.Forbidden(message).WithCode(apperrors.CodeQueueAdmissionRequired)
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// refundRequestClosedNote is left on requests the refund_requests job
+// closes
+const refundRequestClosedNote = "closed automatically: the event has taken place"
+
+type RefundRequestService struct {
+	refundRequestRepo repositories.RefundRequestRepository
+	paymentRepo       repositories.PaymentRepository
+	paymentService    *PaymentService
+	defaultCutoff     time.Duration
+}
+
+type CreateRefundRequestRequest struct {
+	PaymentID uint   `json:"payment_id" binding:"required"`
+	Reason    string `json:"reason" binding:"required,max=1000"`
+}
+
+type RejectRefundRequestRequest struct {
+	Note string `json:"note" binding:"required,max=1000"`
+}
+
+func NewRefundRequestService(
+	refundRequestRepo repositories.RefundRequestRepository,
+	paymentRepo repositories.PaymentRepository,
+	paymentService *PaymentService,
+	defaultCutoff time.Duration,
+) *RefundRequestService {
+	return &RefundRequestService{
+		refundRequestRepo: refundRequestRepo,
+		paymentRepo:       paymentRepo,
+		paymentService:    paymentService,
+		defaultCutoff:     defaultCutoff,
+	}
+}
+
+// RefundDeadline is when refund requests for the event close
+func (s *RefundRequestService) RefundDeadline(event *models.Event) int64 {
+	cutoff := s.defaultCutoff
+	if event.RefundCutoffHours > 0 {
+		cutoff = time.Duration(event.RefundCutoffHours) * time.Hour
+	}
+	return event.Date - int64(cutoff.Seconds())
+}
+
+// CreateRequest asks for a refund of one of the user's orders. Requests
+// are refused once the event's refund window has closed.
+func (s *RefundRequestService) CreateRequest(userID uint, req *CreateRefundRequestRequest) (*models.RefundRequest, error) {
+	payment, err := s.paymentRepo.GetByID(req.PaymentID)
+	if err != nil {
+		return nil, apperrors.NotFound("payment not found")
+	}
+
+	if payment.UserID != userID || payment.UserType != models.UserTypeUser {
+		return nil, apperrors.Forbidden("unauthorized to request a refund for this payment")
+	}
+
+	if payment.ParentPaymentID != nil {
+		return nil, apperrors.Validation("request a refund of the whole order instead")
+	}
+
+	if payment.EventID == 0 {
+		return nil, apperrors.Validation("only ticket orders can be refunded on request")
+	}
+
+	if payment.Status != models.PaymentStatusCompleted {
+		return nil, apperrors.Validation("only completed payments can be refunded")
+	}
+
+	now := time.Now().Unix()
+	if deadline := s.RefundDeadline(&payment.Event); now >= deadline {
+		return nil, apperrors.Validationf("refund requests for this event closed at %s",
+			eventTime(&payment.Event, deadline).Format(time.RFC3339)).WithCode(apperrors.CodeRefundWindowClosed)
+	}
+
+	pending, err := s.refundRequestRepo.HasPendingForPayment(payment.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to check existing refund requests")
+	}
+	if pending {
+		return nil, apperrors.Conflict("a refund request for this payment is already pending")
+	}
+
+	request := &models.RefundRequest{
+		PaymentID: payment.ID,
+		UserID:    userID,
+		EventID:   payment.EventID,
+		Reason:    req.Reason,
+		Status:    models.RefundRequestStatusPending,
+		CreatedAt: now,
+	}
+	if err := s.refundRequestRepo.Create(request); err != nil {
+		return nil, apperrors.Internal("failed to create refund request")
+	}
+
+	return request, nil
+}
+
+func (s *RefundRequestService) GetMyRequests(userID uint) ([]models.RefundRequest, error) {
+	requests, err := s.refundRequestRepo.ListByUser(userID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve refund requests")
+	}
+	return requests, nil
+}
+
+func (s *RefundRequestService) ListRequests(status models.RefundRequestStatus, page, limit int) (*utils.PaginatedResponse, error) {
+	offset := (page - 1) * limit
+	requests, err := s.refundRequestRepo.List(status, limit, offset)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve refund requests")
+	}
+
+	total, err := s.refundRequestRepo.Count(status)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count refund requests")
+	}
+
+	return &utils.PaginatedResponse{
+		Success:    true,
+		Message:    "Refund requests retrieved successfully",
+		Data:       requests,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, nil
+}
+
+// ApproveRequest issues the refund described by req, as for a direct
+// refund, and closes the request in the same transaction, so of two
+// concurrent approvals only one refunds. Admins may still approve after
+// the window has closed.
+func (s *RefundRequestService) ApproveRequest(ctx context.Context, requestID, adminID uint, req *RefundRequest) (*models.Refund, error) {
+	request, err := s.refundRequestRepo.GetByID(requestID)
+	if err != nil {
+		return nil, apperrors.NotFound("refund request not found")
+	}
+
+	if request.Status != models.RefundRequestStatusPending {
+		return nil, apperrors.Conflict("refund request has already been decided")
+	}
+
+	if req.Reason == "" {
+		req.Reason = request.Reason
+	}
+	return s.paymentService.refundPayment(ctx, request.PaymentID, adminID, req, func(tx *gorm.DB, refund *models.Refund) error {
+		resolved, err := s.refundRequestRepo.WithTx(tx).Resolve(request.ID, models.RefundRequestStatusApproved, "", &refund.ID, time.Now().Unix())
+		if err != nil {
+			return err
+		}
+		if !resolved {
+			return apperrors.Conflict("refund request has already been decided")
+		}
+		return nil
+	})
+}
+
+func (s *RefundRequestService) RejectRequest(requestID uint, req *RejectRefundRequestRequest) error {
+	resolved, err := s.refundRequestRepo.Resolve(requestID, models.RefundRequestStatusRejected, req.Note, nil, time.Now().Unix())
+	if err != nil {
+		return apperrors.Internal("failed to reject refund request")
+	}
+	if !resolved {
+		if _, err := s.refundRequestRepo.GetByID(requestID); err != nil {
+			return apperrors.NotFound("refund request not found")
+		}
+		return apperrors.Conflict("refund request has already been decided")
+	}
+	return nil
+}
+
+// ClosePastRequests closes requests still pending for events that have
+// taken place. Events only have a start time, so that is when they count
+// as over.
+func (s *RefundRequestService) ClosePastRequests() (int64, error) {
+	closed, err := s.refundRequestRepo.CloseForPastEvents(time.Now().Unix(), refundRequestClosedNote)
+	if err != nil {
+		return 0, apperrors.Internal("failed to close refund requests")
+	}
+	return closed, nil
+}
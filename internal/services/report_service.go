@@ -0,0 +1,121 @@
+// internal/services/report_service.go
+package services
+
+import (
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+)
+
+type ReportService struct {
+	reportRepo repositories.EventReportRepository
+	eventRepo  repositories.EventRepository
+}
+
+type ReportEventRequest struct {
+	Reason  string `json:"reason" binding:"required,oneof=fraud inappropriate misleading other"`
+	Details string `json:"details" binding:"max=2000"`
+}
+
+type ResolveReportRequest struct {
+	Status models.EventReportStatus `json:"status" binding:"required,oneof=2 3"`
+	Note   string                   `json:"note" binding:"max=2000"`
+}
+
+func NewReportService(reportRepo repositories.EventReportRepository, eventRepo repositories.EventRepository) *ReportService {
+	return &ReportService{
+		reportRepo: reportRepo,
+		eventRepo:  eventRepo,
+	}
+}
+
+// ReportEvent flags an event for admin review. A reporter can have one open
+// report per event, so repeated clicks don't flood the queue.
+func (s *ReportService) ReportEvent(eventID, reporterID uint, reporterType models.UserType, req *ReportEventRequest) (*models.EventReport, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, apperrors.NotFound("event not found")
+	}
+
+	if reporterType == models.UserTypeSeller && event.SellerID == reporterID {
+		return nil, apperrors.Validation("cannot report your own event")
+	}
+
+	open, err := s.reportRepo.HasOpenReport(eventID, reporterID, reporterType)
+	if err != nil {
+		return nil, apperrors.Internal("failed to check existing reports")
+	}
+	if open {
+		return nil, apperrors.Conflict("you have already reported this event")
+	}
+
+	report := &models.EventReport{
+		EventID:      eventID,
+		ReporterID:   reporterID,
+		ReporterType: reporterType,
+		Reason:       req.Reason,
+		Details:      utils.SanitizeString(req.Details),
+		Status:       models.EventReportStatusOpen,
+		CreatedAt:    time.Now().Unix(),
+	}
+
+	if err := s.reportRepo.Create(report); err != nil {
+		return nil, apperrors.Internal("failed to submit report")
+	}
+
+	return report, nil
+}
+
+func (s *ReportService) ListReports(status models.EventReportStatus, page, limit int) (*utils.PaginatedResponse, error) {
+	offset := (page - 1) * limit
+	reports, err := s.reportRepo.List(status, limit, offset)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve reports")
+	}
+
+	total, err := s.reportRepo.Count(status)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count reports")
+	}
+
+	return &utils.PaginatedResponse{
+		Success:    true,
+		Message:    "Reports retrieved successfully",
+		Data:       reports,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, nil
+}
+
+// ResolveReport closes a report. Acting on a report also closes the other
+// open reports against the same event, since the action covers them too.
+func (s *ReportService) ResolveReport(reportID, adminID uint, req *ResolveReportRequest) (*models.EventReport, error) {
+	report, err := s.reportRepo.GetByID(reportID)
+	if err != nil {
+		return nil, apperrors.NotFound("report not found")
+	}
+
+	if report.Status != models.EventReportStatusOpen {
+		return nil, apperrors.Conflict("report is already resolved")
+	}
+
+	now := time.Now().Unix()
+	report.Status = req.Status
+	report.Note = req.Note
+	report.ResolvedBy = &adminID
+	report.ResolvedAt = &now
+
+	if err := s.reportRepo.Update(report); err != nil {
+		return nil, apperrors.Internal("failed to resolve report")
+	}
+
+	if req.Status == models.EventReportStatusActioned {
+		if _, err := s.reportRepo.ResolveOpenByEvent(report.EventID, req.Status, adminID, req.Note, now); err != nil {
+			return nil, apperrors.Internal("failed to resolve related reports")
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"eticketing/internal/config"
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+)
+
+// RateLimitSetter is satisfied by middleware.RateLimiter
+type RateLimitSetter interface {
+	SetLimits(rate time.Duration, capacity int)
+}
+
+// RuntimeConfigService reloads the settings that can change without a
+// restart (rate limits, the platform fee rate) and refreshes feature flags.
+// Every reload that changes something is recorded on the audit log.
+type RuntimeConfigService struct {
+	envFile      string
+	rateLimiter  RateLimitSetter
+	featureFlags *FeatureFlagService
+	auditRepo    repositories.AuditLogRepository
+
+	mutex   sync.Mutex
+	current config.Runtime
+}
+
+// RuntimeSettings is the reloadable configuration as shown to admins
+type RuntimeSettings struct {
+	RateLimitBurst  int     `json:"rate_limit_burst"`
+	RateLimitRefill string  `json:"rate_limit_refill"`
+	PlatformFeeRate float64 `json:"platform_fee_rate"`
+}
+
+type ConfigChange struct {
+	Setting string `json:"setting"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+}
+
+type ConfigReloadResult struct {
+	Settings RuntimeSettings `json:"settings"`
+	Changes  []ConfigChange  `json:"changes"`
+}
+
+// NewRuntimeConfigService applies current, the configuration the server
+// started with, and reloads from envFile afterwards
+func NewRuntimeConfigService(
+	envFile string,
+	current config.Runtime,
+	rateLimiter RateLimitSetter,
+	featureFlags *FeatureFlagService,
+	auditRepo repositories.AuditLogRepository,
+) *RuntimeConfigService {
+	s := &RuntimeConfigService{
+		envFile:      envFile,
+		rateLimiter:  rateLimiter,
+		featureFlags: featureFlags,
+		auditRepo:    auditRepo,
+	}
+	s.apply(current)
+	return s
+}
+
+func (s *RuntimeConfigService) Settings() RuntimeSettings {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return runtimeSettings(s.current)
+}
+
+// Reload reads the configuration again and applies it. An invalid value
+// fails the whole reload and the running settings are kept. actorID is 0
+// for a reload triggered by SIGHUP.
+func (s *RuntimeConfigService) Reload(actorID uint, ip string) (*ConfigReloadResult, error) {
+	next, err := config.ReloadRuntime(s.envFile)
+	if err != nil {
+		return nil, apperrors.Validationf("configuration was not reloaded: %v", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	changes := diffRuntime(s.current, next)
+	s.apply(next)
+	s.featureFlags.Refresh()
+
+	if len(changes) > 0 {
+		details := make([]string, 0, len(changes))
+		for _, change := range changes {
+			details = append(details, fmt.Sprintf("%s=%s->%s", change.Setting, change.From, change.To))
+		}
+
+		entry := &models.AuditLog{
+			ActorID:   actorID,
+			ActorType: models.UserTypeAdmin,
+			Action:    models.AuditActionConfigReloaded,
+			Details:   strings.Join(details, " "),
+			IP:        ip,
+			CreatedAt: time.Now().Unix(),
+		}
+		if actorID == 0 {
+			entry.Path = "SIGHUP"
+		}
+		if err := s.auditRepo.Create(entry); err != nil {
+			log.Printf("config: failed to record reload: %v", err)
+		}
+	}
+
+	return &ConfigReloadResult{
+		Settings: runtimeSettings(next),
+		Changes:  changes,
+	}, nil
+}
+
+// apply puts settings into effect; callers hold the mutex once the service
+// is running
+func (s *RuntimeConfigService) apply(settings config.Runtime) {
+	s.rateLimiter.SetLimits(settings.RateLimitRefill, settings.RateLimitBurst)
+	SetPlatformFeeRate(settings.PlatformFeeRate)
+	s.current = settings
+}
+
+func diffRuntime(from, to config.Runtime) []ConfigChange {
+	changes := []ConfigChange{}
+	if from.RateLimitBurst != to.RateLimitBurst {
+		changes = append(changes, ConfigChange{"rate_limit_burst", fmt.Sprint(from.RateLimitBurst), fmt.Sprint(to.RateLimitBurst)})
+	}
+	if from.RateLimitRefill != to.RateLimitRefill {
+		changes = append(changes, ConfigChange{"rate_limit_refill", from.RateLimitRefill.String(), to.RateLimitRefill.String()})
+	}
+	if from.PlatformFeeRate != to.PlatformFeeRate {
+		changes = append(changes, ConfigChange{"platform_fee_rate", fmt.Sprint(from.PlatformFeeRate), fmt.Sprint(to.PlatformFeeRate)})
+	}
+	return changes
+}
+
+func runtimeSettings(settings config.Runtime) RuntimeSettings {
+	return RuntimeSettings{
+		RateLimitBurst:  settings.RateLimitBurst,
+		RateLimitRefill: settings.RateLimitRefill.String(),
+		PlatformFeeRate: settings.PlatformFeeRate,
+	}
+}
@@ -1,36 +1,80 @@
 package services
 
 import (
-	"errors"
+	"fmt"
 	"time"
 
 	"eticketing/internal/models"
+	"eticketing/internal/outbox"
 	"eticketing/internal/repositories"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
 )
 
 type SaleService struct {
-	saleRepo  repositories.SaleRepository
-	eventRepo repositories.EventRepository
+	saleRepo        repositories.SaleRepository
+	lotteryRepo     repositories.LotteryRepository
+	eventRepo       repositories.EventRepository
+	ticketGroupRepo repositories.TicketGroupRepository
+	outboxRepo      repositories.OutboxRepository
+	auditRepo       repositories.AuditLogRepository
+	access          *EventAccess
+	txManager       repositories.TransactionManager
 }
 
 type CreateSaleRequest struct {
-	StartDate int64 `json:"start_date" binding:"required"`
-	EndDate   int64 `json:"end_date" binding:"required"`
+	StartDate int64 `json:"start_date" binding:"required,unixtime"`
+	EndDate   int64 `json:"end_date" binding:"required,unixtime,gtfield=StartDate"`
 	EventID   uint  `json:"event_id" binding:"required"`
+
+	// A lottery takes registrations until StartDate and draws
+	// LotteryWinners buyers instead of selling to whoever comes first
+	Mode           models.SaleMode `json:"mode" binding:"omitempty,oneof=1 2"`
+	LotteryWinners int             `json:"lottery_winners" binding:"required_if=Mode 2,min=0"`
 }
 
 type UpdateSaleRequest struct {
-	StartDate int64 `json:"start_date"`
-	EndDate   int64 `json:"end_date"`
+	StartDate      int64 `json:"start_date" binding:"omitempty,unixtime"`
+	EndDate        int64 `json:"end_date" binding:"omitempty,unixtime"`
+	LotteryWinners int   `json:"lottery_winners" binding:"omitempty,min=1"` // Lottery sales only
+}
+
+// SaleAllocationRequest names the ticket group by group_id, or by its
+// details as before groups had IDs
+type SaleAllocationRequest struct {
+	GroupID     uint              `json:"group_id"`
+	Price       float64           `json:"price" binding:"min=0"`
+	Type        models.TicketType `json:"type" binding:"required_without=GroupID"`
+	IsVip       bool              `json:"is_vip"`
+	Title       string            `json:"title" binding:"required_without=GroupID"`
+	Place       string            `json:"place" binding:"required_without=GroupID"`
+	MaxQuantity int               `json:"max_quantity" binding:"required,min=1"`
+}
+
+type SaleAllocationResponse struct {
+	ID           uint              `json:"id"`
+	GroupID      *uint             `json:"group_id"`
+	Price        float64           `json:"price"`
+	Type         models.TicketType `json:"type"`
+	IsVip        bool              `json:"is_vip"`
+	Title        string            `json:"title"`
+	Place        string            `json:"place"`
+	MaxQuantity  int               `json:"max_quantity"`
+	SoldQuantity int               `json:"sold_quantity"`
+	Remaining    int               `json:"remaining"`
 }
 
 type SaleResponse struct {
-	ID        uint  `json:"id"`
-	StartDate int64 `json:"start_date"`
-	EndDate   int64 `json:"end_date"`
-	EventID   uint  `json:"event_id"`
-	IsActive  bool  `json:"is_active"`
-	EventInfo struct {
+	ID          uint                     `json:"id"`
+	StartDate   int64                    `json:"start_date"`
+	EndDate     int64                    `json:"end_date"`
+	EventID     uint                     `json:"event_id"`
+	IsActive    bool                     `json:"is_active"`
+	Mode        models.SaleMode          `json:"mode"`
+	Lottery     *LotteryInfo             `json:"lottery,omitempty"`
+	Allocations []SaleAllocationResponse `json:"allocations,omitempty"`
+	EventInfo   struct {
 		Title       string `json:"title"`
 		Description string `json:"description"`
 		Date        int64  `json:"date"`
@@ -38,10 +82,41 @@ type SaleResponse struct {
 	} `json:"event_info,omitempty"`
 }
 
-func NewSaleService(saleRepo repositories.SaleRepository, eventRepo repositories.EventRepository) *SaleService {
+// LotteryInfo describes the draw of a lottery sale
+type LotteryInfo struct {
+	Winners       int    `json:"winners"`
+	Registrations int64  `json:"registrations"`
+	DrawnAt       *int64 `json:"drawn_at"`
+}
+
+// SellerSaleResponse is a sale in the seller's listing, with how far its
+// tickets have sold
+type SellerSaleResponse struct {
+	SaleResponse
+	Status          string `json:"status"` // active, upcoming or expired
+	TicketsAttached int64  `json:"tickets_attached"`
+	TicketsSold     int64  `json:"tickets_sold"`
+}
+
+func NewSaleService(
+	saleRepo repositories.SaleRepository,
+	lotteryRepo repositories.LotteryRepository,
+	eventRepo repositories.EventRepository,
+	ticketGroupRepo repositories.TicketGroupRepository,
+	outboxRepo repositories.OutboxRepository,
+	auditRepo repositories.AuditLogRepository,
+	access *EventAccess,
+	txManager repositories.TransactionManager,
+) *SaleService {
 	return &SaleService{
-		saleRepo:  saleRepo,
-		eventRepo: eventRepo,
+		saleRepo:        saleRepo,
+		lotteryRepo:     lotteryRepo,
+		eventRepo:       eventRepo,
+		ticketGroupRepo: ticketGroupRepo,
+		outboxRepo:      outboxRepo,
+		auditRepo:       auditRepo,
+		access:          access,
+		txManager:       txManager,
 	}
 }
 
@@ -49,35 +124,35 @@ func (s *SaleService) CreateSale(req *CreateSaleRequest, sellerID uint) (*SaleRe
 	// Validate dates
 	now := time.Now().Unix()
 	if req.StartDate <= now {
-		return nil, errors.New("sale start date must be in the future")
+		return nil, apperrors.Validation("sale start date must be in the future")
 	}
 	if req.EndDate <= req.StartDate {
-		return nil, errors.New("sale end date must be after start date")
+		return nil, apperrors.Validation("sale end date must be after start date")
 	}
 
-	// Verify event exists and belongs to seller
+	// Verify event exists and the seller may sell tickets for it
 	event, err := s.eventRepo.GetByID(req.EventID)
 	if err != nil {
-		return nil, errors.New("event not found")
+		return nil, apperrors.NotFound("event not found")
 	}
-	if event.SellerID != sellerID {
-		return nil, errors.New("unauthorized to create sale for this event")
+	if !s.access.Allowed(event, sellerID, models.EventPermissionManageTickets) {
+		return nil, apperrors.Forbidden("unauthorized to create sale for this event")
 	}
 
 	// Check if event is approved
 	if event.Status != models.EventStatusApproved {
-		return nil, errors.New("can only create sales for approved events")
+		return nil, apperrors.Validation("can only create sales for approved events")
 	}
 
 	// Check for overlapping sales
 	existingSales, err := s.saleRepo.ListByEvent(req.EventID)
 	if err != nil {
-		return nil, errors.New("failed to check existing sales")
+		return nil, apperrors.Internal("failed to check existing sales")
 	}
 
 	for _, existingSale := range existingSales {
 		if s.datesOverlap(req.StartDate, req.EndDate, existingSale.StartDate, existingSale.EndDate) {
-			return nil, errors.New("sale dates overlap with existing sale")
+			return nil, apperrors.Conflict("sale dates overlap with existing sale")
 		}
 	}
 
@@ -85,10 +160,37 @@ func (s *SaleService) CreateSale(req *CreateSaleRequest, sellerID uint) (*SaleRe
 		StartDate: req.StartDate,
 		EndDate:   req.EndDate,
 		EventID:   req.EventID,
+		Mode:      models.SaleModeFirstCome,
 	}
+	if req.Mode == models.SaleModeLottery {
+		if req.LotteryWinners < 1 {
+			return nil, apperrors.Validation("a lottery sale needs at least one winner")
+		}
+		sale.Mode = models.SaleModeLottery
+		sale.LotteryWinners = req.LotteryWinners
+	}
+
+	// Followers and webhooks hear about the sale once it is committed
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.saleRepo.WithTx(tx).Create(sale); err != nil {
+			return err
+		}
 
-	if err := s.saleRepo.Create(sale); err != nil {
-		return nil, errors.New("failed to create sale")
+		message, err := outbox.NewMessage(models.OutboxTopicSaleCreated, outbox.SaleCreatedPayload{
+			SaleID:     sale.ID,
+			EventID:    event.ID,
+			SellerID:   event.SellerID,
+			EventTitle: event.Title,
+			StartDate:  sale.StartDate,
+			EndDate:    sale.EndDate,
+		})
+		if err != nil {
+			return err
+		}
+		return s.outboxRepo.WithTx(tx).Create(message)
+	})
+	if err != nil {
+		return nil, apperrors.Internal("failed to create sale")
 	}
 
 	return s.saleToResponse(sale, event), nil
@@ -98,12 +200,12 @@ func (s *SaleService) GetSalesByEvent(eventID uint) ([]SaleResponse, error) {
 	// Verify event exists
 	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
-		return nil, errors.New("event not found")
+		return nil, apperrors.NotFound("event not found")
 	}
 
 	sales, err := s.saleRepo.ListByEvent(eventID)
 	if err != nil {
-		return nil, errors.New("failed to retrieve sales")
+		return nil, apperrors.Internal("failed to retrieve sales")
 	}
 
 	var saleResponses []SaleResponse
@@ -115,39 +217,277 @@ func (s *SaleService) GetSalesByEvent(eventID uint) ([]SaleResponse, error) {
 	return saleResponses, nil
 }
 
+// GetSellerSales lists the sales of all the seller's events
+func (s *SaleService) GetSellerSales(sellerID uint, eventID uint, status string, page, limit int) (*utils.PaginatedResponse, error) {
+	switch status {
+	case "", repositories.SaleStatusActive, repositories.SaleStatusUpcoming, repositories.SaleStatusExpired:
+	default:
+		return nil, apperrors.Validation("status must be active, upcoming or expired")
+	}
+
+	filter := repositories.SaleFilter{
+		SellerID: sellerID,
+		EventID:  eventID,
+		Status:   status,
+		Now:      time.Now().Unix(),
+	}
+
+	offset := (page - 1) * limit
+	sales, err := s.saleRepo.List(filter, limit, offset)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve sales")
+	}
+
+	total, err := s.saleRepo.Count(filter)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count sales")
+	}
+
+	saleIDs := make([]uint, len(sales))
+	for i := range sales {
+		saleIDs[i] = sales[i].ID
+	}
+	counts, err := s.saleRepo.CountTickets(saleIDs)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count sale tickets")
+	}
+
+	responses := make([]SellerSaleResponse, 0, len(sales))
+	for i := range sales {
+		sale := &sales[i]
+		responses = append(responses, SellerSaleResponse{
+			SaleResponse:    *s.saleToResponse(sale, &sale.Event),
+			Status:          s.saleStatus(sale, filter.Now),
+			TicketsAttached: counts[sale.ID].Attached,
+			TicketsSold:     counts[sale.ID].Sold,
+		})
+	}
+
+	return &utils.PaginatedResponse{
+		Success:    true,
+		Message:    "Sales retrieved successfully",
+		Data:       responses,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, nil
+}
+
 func (s *SaleService) GetSaleByID(saleID uint) (*SaleResponse, error) {
 	sale, err := s.saleRepo.GetByID(saleID)
 	if err != nil {
-		return nil, errors.New("sale not found")
+		return nil, apperrors.NotFound("sale not found")
 	}
 
 	event, err := s.eventRepo.GetByID(sale.EventID)
 	if err != nil {
-		return nil, errors.New("event not found")
+		return nil, apperrors.NotFound("event not found")
 	}
 
 	return s.saleToResponse(sale, event), nil
 }
 
 func (s *SaleService) UpdateSale(saleID, sellerID uint, req *UpdateSaleRequest) (*SaleResponse, error) {
+	sale, event, err := s.getSaleWithEvent(saleID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.access.Allowed(event, sellerID, models.EventPermissionManageTickets) {
+		return nil, apperrors.Forbidden("unauthorized to update this sale")
+	}
+
+	if err := s.applySaleUpdate(sale, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.saleRepo.Update(sale); err != nil {
+		return nil, apperrors.Internal("failed to update sale")
+	}
+
+	return s.saleToResponse(sale, event), nil
+}
+
+// AdminUpdateSale reschedules any seller's sale. The same date rules apply
+// as for the seller; the change and the old window are put on the audit log
+// in the same transaction.
+func (s *SaleService) AdminUpdateSale(saleID, adminID uint, ip string, req *UpdateSaleRequest) (*SaleResponse, error) {
+	sale, event, err := s.getSaleWithEvent(saleID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldStart, oldEnd := sale.StartDate, sale.EndDate
+	if err := s.applySaleUpdate(sale, req); err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.saleRepo.WithTx(tx).Update(sale); err != nil {
+			return apperrors.Internal("failed to update sale")
+		}
+		return s.auditRepo.WithTx(tx).Create(&models.AuditLog{
+			ActorID:   adminID,
+			ActorType: models.UserTypeAdmin,
+			Action:    models.AuditActionSaleUpdatedByAdmin,
+			Details: fmt.Sprintf("sale=%d event=%d seller=%d start=%d->%d end=%d->%d",
+				sale.ID, event.ID, event.SellerID, oldStart, sale.StartDate, oldEnd, sale.EndDate),
+			IP:        ip,
+			CreatedAt: time.Now().Unix(),
+		})
+	})
+	if err != nil {
+		if _, ok := apperrors.As(err); ok {
+			return nil, err
+		}
+		return nil, apperrors.Internal("failed to record sale update")
+	}
+
+	return s.saleToResponse(sale, event), nil
+}
+
+func (s *SaleService) DeleteSale(saleID, sellerID uint) error {
+	sale, event, err := s.getSaleWithEvent(saleID)
+	if err != nil {
+		return err
+	}
+	if !s.access.Allowed(event, sellerID, models.EventPermissionManageTickets) {
+		return apperrors.Forbidden("unauthorized to delete this sale")
+	}
+
+	if err := s.checkSaleDeletable(sale); err != nil {
+		return err
+	}
+
+	if err := s.saleRepo.Delete(saleID); err != nil {
+		return apperrors.Internal("failed to delete sale")
+	}
+
+	return nil
+}
+
+// AdminDeleteSale removes any seller's sale, recording the deleted window on
+// the audit log in the same transaction
+func (s *SaleService) AdminDeleteSale(saleID, adminID uint, ip string) error {
+	sale, event, err := s.getSaleWithEvent(saleID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkSaleDeletable(sale); err != nil {
+		return err
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.saleRepo.WithTx(tx).Delete(saleID); err != nil {
+			return apperrors.Internal("failed to delete sale")
+		}
+		return s.auditRepo.WithTx(tx).Create(&models.AuditLog{
+			ActorID:   adminID,
+			ActorType: models.UserTypeAdmin,
+			Action:    models.AuditActionSaleDeletedByAdmin,
+			Details: fmt.Sprintf("sale=%d event=%d seller=%d start=%d end=%d",
+				sale.ID, event.ID, event.SellerID, sale.StartDate, sale.EndDate),
+			IP:        ip,
+			CreatedAt: time.Now().Unix(),
+		})
+	})
+	if err != nil {
+		if _, ok := apperrors.As(err); ok {
+			return err
+		}
+		return apperrors.Internal("failed to record sale deletion")
+	}
+
+	return nil
+}
+
+// SetAllocation creates or replaces the cap for one ticket group in a sale
+func (s *SaleService) SetAllocation(saleID, sellerID uint, req *SaleAllocationRequest) (*SaleAllocationResponse, error) {
+	sale, err := s.getOwnedSale(saleID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := findTicketGroup(s.ticketGroupRepo, sale.EventID, models.GroupedTicket{
+		GroupID: req.GroupID,
+		SaleID:  saleID,
+		Price:   req.Price,
+		Type:    req.Type,
+		IsVip:   req.IsVip,
+		Title:   req.Title,
+		Place:   req.Place,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if group.SaleID != saleID {
+		return nil, apperrors.Validation("ticket group is not sold in this sale")
+	}
+
+	// One statement, so concurrent calls for the same group can't create two caps
+	err = s.saleRepo.UpsertAllocation(&models.SaleAllocation{
+		SaleID:      saleID,
+		GroupID:     &group.ID,
+		Price:       group.Price,
+		Type:        group.Type,
+		IsVip:       group.IsVip,
+		Title:       group.Title,
+		Place:       group.Place,
+		MaxQuantity: req.MaxQuantity,
+	})
+	if err != nil {
+		return nil, apperrors.Internal("failed to save allocation")
+	}
+
+	allocation, err := s.saleRepo.FindAllocation(saleID, group.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load allocation")
+	}
+	if allocation.MaxQuantity != req.MaxQuantity {
+		return nil, apperrors.Conflict("allocation cannot be lower than the number of tickets already sold")
+	}
+
+	return s.allocationToResponse(allocation), nil
+}
+
+func (s *SaleService) DeleteAllocation(saleID, allocationID, sellerID uint) error {
+	if _, err := s.getOwnedSale(saleID, sellerID); err != nil {
+		return err
+	}
+
+	allocation, err := s.saleRepo.GetAllocationByID(allocationID)
+	if err != nil || allocation.SaleID != saleID {
+		return apperrors.NotFound("allocation not found")
+	}
+
+	if err := s.saleRepo.DeleteAllocation(allocationID); err != nil {
+		return apperrors.Internal("failed to delete allocation")
+	}
+
+	return nil
+}
+
+// Helper functions
+
+func (s *SaleService) getSaleWithEvent(saleID uint) (*models.Sale, *models.Event, error) {
 	sale, err := s.saleRepo.GetByID(saleID)
 	if err != nil {
-		return nil, errors.New("sale not found")
+		return nil, nil, apperrors.NotFound("sale not found")
 	}
 
-	// Verify seller owns the event
 	event, err := s.eventRepo.GetByID(sale.EventID)
 	if err != nil {
-		return nil, errors.New("event not found")
-	}
-	if event.SellerID != sellerID {
-		return nil, errors.New("unauthorized to update this sale")
+		return nil, nil, apperrors.NotFound("event not found")
 	}
 
+	return sale, event, nil
+}
+
+// applySaleUpdate validates the new window against the clock and the
+// event's other sales and applies it to sale
+func (s *SaleService) applySaleUpdate(sale *models.Sale, req *UpdateSaleRequest) error {
 	// Check if sale is already active
 	now := time.Now().Unix()
 	if s.isSaleActive(sale, now) {
-		return nil, errors.New("cannot update active sale")
+		return apperrors.Validation("cannot update active sale")
 	}
 
 	// Validate new dates if provided
@@ -156,7 +496,7 @@ func (s *SaleService) UpdateSale(saleID, sellerID uint, req *UpdateSaleRequest)
 
 	if req.StartDate != 0 {
 		if req.StartDate <= now {
-			return nil, errors.New("sale start date must be in the future")
+			return apperrors.Validation("sale start date must be in the future")
 		}
 		startDate = req.StartDate
 	}
@@ -166,68 +506,76 @@ func (s *SaleService) UpdateSale(saleID, sellerID uint, req *UpdateSaleRequest)
 	}
 
 	if endDate <= startDate {
-		return nil, errors.New("sale end date must be after start date")
+		return apperrors.Validation("sale end date must be after start date")
 	}
 
 	// Check for overlapping sales (excluding current sale)
 	existingSales, err := s.saleRepo.ListByEvent(sale.EventID)
 	if err != nil {
-		return nil, errors.New("failed to check existing sales")
+		return apperrors.Internal("failed to check existing sales")
 	}
 
 	for _, existingSale := range existingSales {
-		if existingSale.ID != saleID && s.datesOverlap(startDate, endDate, existingSale.StartDate, existingSale.EndDate) {
-			return nil, errors.New("sale dates overlap with existing sale")
+		if existingSale.ID != sale.ID && s.datesOverlap(startDate, endDate, existingSale.StartDate, existingSale.EndDate) {
+			return apperrors.Conflict("sale dates overlap with existing sale")
 		}
 	}
 
-	// Update fields
-	if req.StartDate != 0 {
-		sale.StartDate = req.StartDate
-	}
-	if req.EndDate != 0 {
-		sale.EndDate = req.EndDate
-	}
-
-	if err := s.saleRepo.Update(sale); err != nil {
-		return nil, errors.New("failed to update sale")
+	if req.LotteryWinners != 0 {
+		if sale.Mode != models.SaleModeLottery {
+			return apperrors.Validation("only lottery sales have winners")
+		}
+		sale.LotteryWinners = req.LotteryWinners
 	}
 
-	return s.saleToResponse(sale, event), nil
+	sale.StartDate = startDate
+	sale.EndDate = endDate
+	return nil
 }
 
-func (s *SaleService) DeleteSale(saleID, sellerID uint) error {
-	sale, err := s.saleRepo.GetByID(saleID)
-	if err != nil {
-		return errors.New("sale not found")
-	}
-
-	// Verify seller owns the event
-	event, err := s.eventRepo.GetByID(sale.EventID)
-	if err != nil {
-		return errors.New("event not found")
-	}
-	if event.SellerID != sellerID {
-		return errors.New("unauthorized to delete this sale")
-	}
-
+func (s *SaleService) checkSaleDeletable(sale *models.Sale) error {
 	// Check if sale is already active
-	now := time.Now().Unix()
-	if s.isSaleActive(sale, now) {
-		return errors.New("cannot delete active sale")
+	if s.isSaleActive(sale, time.Now().Unix()) {
+		return apperrors.Validation("cannot delete active sale")
 	}
 
 	// TODO: Check if any tickets are sold for this sale
 	// This would require checking the Ticket model for sold tickets with this SaleID
 
-	if err := s.saleRepo.Delete(saleID); err != nil {
-		return errors.New("failed to delete sale")
+	return nil
+}
+
+func (s *SaleService) getOwnedSale(saleID, sellerID uint) (*models.Sale, error) {
+	sale, event, err := s.getSaleWithEvent(saleID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.access.Allowed(event, sellerID, models.EventPermissionManageTickets) {
+		return nil, apperrors.Forbidden("unauthorized to manage this sale")
 	}
 
-	return nil
+	return sale, nil
 }
 
-// Helper functions
+func (s *SaleService) allocationToResponse(allocation *models.SaleAllocation) *SaleAllocationResponse {
+	remaining := allocation.MaxQuantity - allocation.SoldQuantity
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return &SaleAllocationResponse{
+		ID:           allocation.ID,
+		GroupID:      allocation.GroupID,
+		Price:        allocation.Price,
+		Type:         allocation.Type,
+		IsVip:        allocation.IsVip,
+		Title:        allocation.Title,
+		Place:        allocation.Place,
+		MaxQuantity:  allocation.MaxQuantity,
+		SoldQuantity: allocation.SoldQuantity,
+		Remaining:    remaining,
+	}
+}
 
 func (s *SaleService) saleToResponse(sale *models.Sale, event *models.Event) *SaleResponse {
 	now := time.Now().Unix()
@@ -238,6 +586,23 @@ func (s *SaleService) saleToResponse(sale *models.Sale, event *models.Event) *Sa
 		EndDate:   sale.EndDate,
 		EventID:   sale.EventID,
 		IsActive:  s.isSaleActive(sale, now),
+		Mode:      sale.Mode,
+	}
+
+	if sale.Mode == models.SaleModeLottery {
+		response.Lottery = &LotteryInfo{
+			Winners: sale.LotteryWinners,
+			DrawnAt: sale.LotteryDrawnAt,
+		}
+		if count, err := s.lotteryRepo.CountBySale(sale.ID); err == nil {
+			response.Lottery.Registrations = count
+		}
+	}
+
+	if allocations, err := s.saleRepo.ListAllocations(sale.ID); err == nil {
+		for i := range allocations {
+			response.Allocations = append(response.Allocations, *s.allocationToResponse(&allocations[i]))
+		}
 	}
 
 	if event != nil {
@@ -254,6 +619,17 @@ func (s *SaleService) isSaleActive(sale *models.Sale, currentTime int64) bool {
 	return currentTime >= sale.StartDate && currentTime <= sale.EndDate
 }
 
+func (s *SaleService) saleStatus(sale *models.Sale, currentTime int64) string {
+	switch {
+	case currentTime < sale.StartDate:
+		return repositories.SaleStatusUpcoming
+	case currentTime > sale.EndDate:
+		return repositories.SaleStatusExpired
+	default:
+		return repositories.SaleStatusActive
+	}
+}
+
 func (s *SaleService) datesOverlap(start1, end1, start2, end2 int64) bool {
 	return start1 < end2 && start2 < end1
 }
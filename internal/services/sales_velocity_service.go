@@ -0,0 +1,225 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+)
+
+// Bucket sizes of a sales velocity report
+const (
+	SalesIntervalHour = "hour"
+	SalesIntervalDay  = "day"
+)
+
+// salesRateWindow is how far back the sales rate behind the sellout
+// projection looks
+const salesRateWindow = 72 * time.Hour
+
+// maxHourlySalesBuckets caps hourly reports to the last 31 days; longer
+// sales are best read by day
+const maxHourlySalesBuckets = 31 * 24
+
+// SalesVelocityService reports how fast an event's tickets sell and when
+// they are on course to sell out
+type SalesVelocityService struct {
+	eventRepo           repositories.EventRepository
+	access              *EventAccess
+	ticketRepo          repositories.TicketRepository
+	purchasedTicketRepo repositories.PurchasedTicketRepository
+	saleRepo            repositories.SaleRepository
+}
+
+type SalesVelocityReport struct {
+	EventID   uint   `json:"event_id"`
+	Interval  string `json:"interval"`
+	SaleStart int64  `json:"sale_start"` // Earliest sale start; 0 without sales
+	SaleEnd   int64  `json:"sale_end"`   // Latest sale end
+
+	Capacity    int     `json:"capacity"` // Tickets put on sale
+	Sold        int     `json:"sold"`
+	Held        int     `json:"held"` // In a buyer's checkout or awaiting payment
+	Remaining   int     `json:"remaining"`
+	SoldPercent float64 `json:"sold_percent"`
+
+	Buckets []SalesVelocityBucket `json:"buckets"`
+
+	RatePerHour           float64 `json:"rate_per_hour"`        // Over the last 72 hours of the sale
+	ProjectedSelloutAt    *int64  `json:"projected_sellout_at"` // Nil when sold out or nothing sold lately
+	SellsOutBeforeSaleEnd bool    `json:"sells_out_before_sale_end"`
+
+	Groups []TicketGroupFill `json:"groups"` // How full each ticket group is
+}
+
+// SalesVelocityBucket is the tickets sold in one hour or day, starting at
+// Start in the event's timezone
+type SalesVelocityBucket struct {
+	Start      int64 `json:"start"`
+	Tickets    int   `json:"tickets"`
+	Cumulative int   `json:"cumulative"` // Sold since the sale started
+}
+
+type TicketGroupFill struct {
+	GroupID     uint    `json:"group_id"`
+	Title       string  `json:"title"`
+	Place       string  `json:"place"`
+	Total       int     `json:"total"`
+	Sold        int     `json:"sold"`
+	Held        int     `json:"held"`
+	SoldPercent float64 `json:"sold_percent"`
+}
+
+func NewSalesVelocityService(
+	eventRepo repositories.EventRepository,
+	access *EventAccess,
+	ticketRepo repositories.TicketRepository,
+	purchasedTicketRepo repositories.PurchasedTicketRepository,
+	saleRepo repositories.SaleRepository,
+) *SalesVelocityService {
+	return &SalesVelocityService{
+		eventRepo:           eventRepo,
+		access:              access,
+		ticketRepo:          ticketRepo,
+		purchasedTicketRepo: purchasedTicketRepo,
+		saleRepo:            saleRepo,
+	}
+}
+
+// GetSalesVelocity counts the event's tickets sold per hour or day since
+// its sale started, by payment time, and projects when the rest sell out
+// at the recent rate. Hourly reports cover at most the last 31 days.
+func (s *SalesVelocityService) GetSalesVelocity(eventID, sellerID uint, interval string) (*SalesVelocityReport, error) {
+	if interval != SalesIntervalHour && interval != SalesIntervalDay {
+		return nil, apperrors.Validation("interval must be hour or day")
+	}
+
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, apperrors.NotFound("event not found")
+	}
+	if !s.access.Allowed(event, sellerID, models.EventPermissionViewSales) {
+		return nil, apperrors.Forbidden("unauthorized to view sales for this event")
+	}
+
+	sales, err := s.saleRepo.ListByEvent(eventID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load sales")
+	}
+	groups, err := s.ticketRepo.ListGroupedByEvent(eventID, math.MaxInt32, 0)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load tickets")
+	}
+
+	report := &SalesVelocityReport{
+		EventID:  eventID,
+		Interval: interval,
+		Buckets:  []SalesVelocityBucket{},
+		Groups:   make([]TicketGroupFill, 0, len(groups)),
+	}
+	for _, sale := range sales {
+		if report.SaleStart == 0 || sale.StartDate < report.SaleStart {
+			report.SaleStart = sale.StartDate
+		}
+		if sale.EndDate > report.SaleEnd {
+			report.SaleEnd = sale.EndDate
+		}
+	}
+	for _, group := range groups {
+		report.Capacity += group.TotalAmount
+		report.Sold += group.SoldAmount
+		report.Held += group.HeldAmount
+		report.Remaining += group.AvailableAmount
+		report.Groups = append(report.Groups, TicketGroupFill{
+			GroupID:     group.GroupID,
+			Title:       group.Title,
+			Place:       group.Place,
+			Total:       group.TotalAmount,
+			Sold:        group.SoldAmount,
+			Held:        group.HeldAmount,
+			SoldPercent: percentOf(group.SoldAmount, group.TotalAmount),
+		})
+	}
+	report.SoldPercent = percentOf(report.Sold, report.Capacity)
+
+	now := time.Now().Unix()
+	if report.SaleStart == 0 || report.SaleStart > now {
+		return report, nil
+	}
+
+	hours, err := s.purchasedTicketRepo.CountSoldByHour(eventID, report.SaleStart, now)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count ticket sales")
+	}
+
+	report.Buckets = salesBuckets(hours, interval, eventLocation(event), report.SaleStart, now)
+	s.project(report, hours, now)
+	return report, nil
+}
+
+// project sets the recent sales rate and the sellout time it leads to
+func (s *SalesVelocityService) project(report *SalesVelocityReport, hours []repositories.SalesBucket, now int64) {
+	windowStart := now - int64(salesRateWindow.Seconds())
+	if report.SaleStart > windowStart {
+		windowStart = report.SaleStart
+	}
+
+	var recent int64
+	for _, hour := range hours {
+		if hour.Start+3600 > windowStart {
+			recent += hour.Tickets
+		}
+	}
+	windowHours := math.Max(float64(now-windowStart)/3600, 1)
+	report.RatePerHour = math.Round(float64(recent)/windowHours*100) / 100
+
+	if report.Remaining == 0 || recent == 0 {
+		return
+	}
+	sellout := now + int64(float64(report.Remaining)/(float64(recent)/windowHours)*3600)
+	report.ProjectedSelloutAt = &sellout
+	report.SellsOutBeforeSaleEnd = sellout <= report.SaleEnd
+}
+
+// salesBuckets spreads hourly sales over consecutive hours or days from
+// the sale start to now, days running midnight to midnight at the event
+func salesBuckets(hours []repositories.SalesBucket, interval string, location *time.Location, from, to int64) []SalesVelocityBucket {
+	start := time.Unix(from, 0).In(location)
+	step := func(t time.Time) time.Time { return t.Add(time.Hour) }
+	if interval == SalesIntervalDay {
+		start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, location)
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	} else {
+		start = start.Truncate(time.Hour)
+		if earliest := time.Unix(to, 0).Add(-maxHourlySalesBuckets * time.Hour).Truncate(time.Hour); start.Before(earliest) {
+			start = earliest
+		}
+	}
+
+	var buckets []SalesVelocityBucket
+	cumulative, next := 0, 0
+	for ; next < len(hours) && hours[next].Start < start.Unix(); next++ {
+		cumulative += int(hours[next].Tickets)
+	}
+	for bucketStart := start; bucketStart.Unix() <= to; bucketStart = step(bucketStart) {
+		end := step(bucketStart).Unix()
+		bucket := SalesVelocityBucket{Start: bucketStart.Unix()}
+		for ; next < len(hours) && hours[next].Start < end; next++ {
+			bucket.Tickets += int(hours[next].Tickets)
+		}
+		cumulative += bucket.Tickets
+		bucket.Cumulative = cumulative
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
+
+// percentOf is part as a percentage of whole, rounded to two places
+func percentOf(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(whole)*10000) / 100
+}
@@ -0,0 +1,336 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/outbox"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// maxSellerDocumentBytes caps one uploaded document; requests are also
+// bound by MAX_UPLOAD_BYTES
+const maxSellerDocumentBytes = 10 << 20
+
+// sellerDocumentTypes are the file types accepted, by sniffed content
+var sellerDocumentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+var sellerDocumentKinds = map[string]bool{
+	models.SellerDocumentIdentity:             true,
+	models.SellerDocumentBusinessRegistration: true,
+	models.SellerDocumentTax:                  true,
+	models.SellerDocumentBankAccount:          true,
+	models.SellerDocumentOther:                true,
+}
+
+// SellerOnboardingService takes new sellers from sign-up through document
+// upload and admin review. Sellers can't create events until approved.
+type SellerOnboardingService struct {
+	sellerRepo   repositories.SellerRepository
+	documentRepo repositories.SellerDocumentRepository
+	auditRepo    repositories.AuditLogRepository
+	outboxRepo   repositories.OutboxRepository
+	txManager    repositories.TransactionManager
+}
+
+// SellerOnboarding is where a seller stands in onboarding
+type SellerOnboarding struct {
+	SellerID    uint                          `json:"seller_id"`
+	Username    string                        `json:"username"`
+	Email       string                        `json:"email"`
+	Name        string                        `json:"name"`
+	Surname     string                        `json:"surname"`
+	Status      models.SellerOnboardingStatus `json:"status"`
+	SubmittedAt *int64                        `json:"submitted_at"`
+	ReviewedAt  *int64                        `json:"reviewed_at"`
+	Note        string                        `json:"note,omitempty"` // From the reviewer
+	Documents   []models.SellerDocument       `json:"documents,omitempty"`
+}
+
+type ReviewSellerRequest struct {
+	Note string `json:"note" binding:"max=1000"` // Shown to the seller
+}
+
+func NewSellerOnboardingService(
+	sellerRepo repositories.SellerRepository,
+	documentRepo repositories.SellerDocumentRepository,
+	auditRepo repositories.AuditLogRepository,
+	outboxRepo repositories.OutboxRepository,
+	txManager repositories.TransactionManager,
+) *SellerOnboardingService {
+	return &SellerOnboardingService{
+		sellerRepo:   sellerRepo,
+		documentRepo: documentRepo,
+		auditRepo:    auditRepo,
+		outboxRepo:   outboxRepo,
+		txManager:    txManager,
+	}
+}
+
+// Get returns the seller's onboarding with their documents
+func (s *SellerOnboardingService) Get(sellerID uint) (*SellerOnboarding, error) {
+	seller, err := s.sellerRepo.GetByID(sellerID)
+	if err != nil {
+		return nil, apperrors.NotFound("seller not found")
+	}
+
+	documents, err := s.documentRepo.ListBySeller(sellerID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load seller documents")
+	}
+
+	onboarding := toSellerOnboarding(seller)
+	onboarding.Documents = documents
+	return onboarding, nil
+}
+
+// UploadDocument adds a PDF, JPEG or PNG document while the seller hasn't
+// submitted, or after a rejection
+func (s *SellerOnboardingService) UploadDocument(sellerID uint, kind, fileName string, data []byte) (*models.SellerDocument, error) {
+	if !sellerDocumentKinds[kind] {
+		return nil, apperrors.Validation("kind must be identity, business_registration, tax, bank_account or other")
+	}
+	if len(data) == 0 {
+		return nil, apperrors.Validation("the document is empty")
+	}
+	if len(data) > maxSellerDocumentBytes {
+		return nil, apperrors.Validation(fmt.Sprintf("documents may be at most %d bytes", maxSellerDocumentBytes))
+	}
+	contentType := http.DetectContentType(data)
+	if !sellerDocumentTypes[contentType] {
+		return nil, apperrors.Validation("documents must be PDF, JPEG or PNG files")
+	}
+
+	if err := s.requireEditable(sellerID); err != nil {
+		return nil, err
+	}
+
+	document := &models.SellerDocument{
+		SellerID:    sellerID,
+		Kind:        kind,
+		FileName:    filepath.Base(strings.TrimSpace(fileName)),
+		ContentType: contentType,
+		Size:        len(data),
+		Data:        data,
+		UploadedAt:  time.Now().Unix(),
+	}
+	if err := s.documentRepo.Create(document); err != nil {
+		return nil, apperrors.Internal("failed to save document")
+	}
+	return document, nil
+}
+
+func (s *SellerOnboardingService) DeleteDocument(sellerID, documentID uint) error {
+	document, err := s.documentRepo.GetByID(documentID)
+	if err != nil || document.SellerID != sellerID {
+		return apperrors.NotFound("document not found")
+	}
+	if err := s.requireEditable(sellerID); err != nil {
+		return err
+	}
+
+	if err := s.documentRepo.Delete(document.ID); err != nil {
+		return apperrors.Internal("failed to delete document")
+	}
+	return nil
+}
+
+// Submit sends the seller's documents for review. An identity document is
+// required.
+func (s *SellerOnboardingService) Submit(sellerID uint) (*SellerOnboarding, error) {
+	seller, err := s.sellerRepo.GetByID(sellerID)
+	if err != nil {
+		return nil, apperrors.NotFound("seller not found")
+	}
+	if !editableOnboarding(seller.OnboardingStatus) {
+		return nil, apperrors.Conflict("onboarding has already been submitted")
+	}
+
+	documents, err := s.documentRepo.ListBySeller(sellerID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load seller documents")
+	}
+	hasIdentity := false
+	for _, document := range documents {
+		if document.Kind == models.SellerDocumentIdentity {
+			hasIdentity = true
+		}
+	}
+	if !hasIdentity {
+		return nil, apperrors.Validation("upload an identity document before submitting")
+	}
+
+	from := seller.OnboardingStatus
+	now := time.Now().Unix()
+	seller.OnboardingStatus = models.SellerOnboardingPendingReview
+	seller.OnboardingSubmittedAt = &now
+	seller.OnboardingNote = ""
+
+	updated, err := s.sellerRepo.UpdateOnboarding(seller, from)
+	if err != nil {
+		return nil, apperrors.Internal("failed to submit onboarding")
+	}
+	if !updated {
+		return nil, apperrors.Conflict("onboarding has already been submitted")
+	}
+
+	onboarding := toSellerOnboarding(seller)
+	onboarding.Documents = documents
+	return onboarding, nil
+}
+
+// List returns the sellers in an onboarding status, longest waiting first
+func (s *SellerOnboardingService) List(status models.SellerOnboardingStatus, limit, offset int) ([]SellerOnboarding, int64, error) {
+	sellers, err := s.sellerRepo.ListByOnboardingStatus(status, limit, offset)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to load sellers")
+	}
+	total, err := s.sellerRepo.CountByOnboardingStatus(status)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to count sellers")
+	}
+
+	onboardings := make([]SellerOnboarding, 0, len(sellers))
+	for i := range sellers {
+		onboardings = append(onboardings, *toSellerOnboarding(&sellers[i]))
+	}
+	return onboardings, total, nil
+}
+
+// GetDocument returns one of the seller's documents with its file
+func (s *SellerOnboardingService) GetDocument(sellerID, documentID uint) (*models.SellerDocument, error) {
+	document, err := s.documentRepo.GetByID(documentID)
+	if err != nil || document.SellerID != sellerID {
+		return nil, apperrors.NotFound("document not found")
+	}
+	return document, nil
+}
+
+// Approve lets a seller awaiting review create events
+func (s *SellerOnboardingService) Approve(sellerID uint, req *ReviewSellerRequest, adminID uint, ip string) (*SellerOnboarding, error) {
+	return s.review(sellerID, true, req.Note, adminID, ip)
+}
+
+// Reject sends a seller awaiting review back to uploading documents; the
+// note tells them why
+func (s *SellerOnboardingService) Reject(sellerID uint, req *ReviewSellerRequest, adminID uint, ip string) (*SellerOnboarding, error) {
+	if strings.TrimSpace(req.Note) == "" {
+		return nil, apperrors.Validation("a note explaining the rejection is required")
+	}
+	return s.review(sellerID, false, req.Note, adminID, ip)
+}
+
+// RequireApproved refuses sellers whose onboarding hasn't been approved.
+// Satisfies middleware.SellerApproval.
+func (s *SellerOnboardingService) RequireApproved(sellerID uint) error {
+	seller, err := s.sellerRepo.GetByID(sellerID)
+	if err != nil {
+		return apperrors.NotFound("seller not found")
+	}
+	if seller.OnboardingStatus != models.SellerOnboardingApproved {
+		return apperrors.Forbidden("your seller account must be approved before you can create events").
+			WithCode(apperrors.CodeSellerNotApproved)
+	}
+	return nil
+}
+
+func (s *SellerOnboardingService) review(sellerID uint, approved bool, note string, adminID uint, ip string) (*SellerOnboarding, error) {
+	seller, err := s.sellerRepo.GetByID(sellerID)
+	if err != nil {
+		return nil, apperrors.NotFound("seller not found")
+	}
+	if seller.OnboardingStatus != models.SellerOnboardingPendingReview {
+		return nil, apperrors.Conflict("seller is not awaiting review")
+	}
+
+	now := time.Now().Unix()
+	seller.OnboardingStatus = models.SellerOnboardingRejected
+	action := models.AuditActionSellerRejected
+	if approved {
+		seller.OnboardingStatus = models.SellerOnboardingApproved
+		action = models.AuditActionSellerApproved
+	}
+	seller.OnboardingReviewedBy = &adminID
+	seller.OnboardingReviewedAt = &now
+	seller.OnboardingNote = strings.TrimSpace(note)
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		updated, err := s.sellerRepo.WithTx(tx).UpdateOnboarding(seller, models.SellerOnboardingPendingReview)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return apperrors.Conflict("seller is not awaiting review")
+		}
+
+		if err := s.auditRepo.WithTx(tx).Create(&models.AuditLog{
+			ActorID:   adminID,
+			ActorType: models.UserTypeAdmin,
+			Action:    action,
+			Details:   fmt.Sprintf("seller=%d note=%q", seller.ID, seller.OnboardingNote),
+			IP:        ip,
+			CreatedAt: now,
+		}); err != nil {
+			return err
+		}
+
+		message, err := outbox.NewMessage(models.OutboxTopicSellerReviewed, outbox.SellerReviewedPayload{
+			SellerID: seller.ID,
+			Email:    seller.Email,
+			Approved: approved,
+			Note:     seller.OnboardingNote,
+		})
+		if err != nil {
+			return err
+		}
+		return s.outboxRepo.WithTx(tx).Create(message)
+	})
+	if err != nil {
+		if _, ok := apperrors.As(err); ok {
+			return nil, err
+		}
+		return nil, apperrors.Internal("failed to review seller")
+	}
+
+	return toSellerOnboarding(seller), nil
+}
+
+// requireEditable refuses document changes once the seller has submitted
+func (s *SellerOnboardingService) requireEditable(sellerID uint) error {
+	seller, err := s.sellerRepo.GetByID(sellerID)
+	if err != nil {
+		return apperrors.NotFound("seller not found")
+	}
+	if !editableOnboarding(seller.OnboardingStatus) {
+		return apperrors.Conflict("documents can't be changed once onboarding has been submitted")
+	}
+	return nil
+}
+
+func editableOnboarding(status models.SellerOnboardingStatus) bool {
+	return status == models.SellerOnboardingIncomplete || status == models.SellerOnboardingRejected
+}
+
+func toSellerOnboarding(seller *models.Seller) *SellerOnboarding {
+	return &SellerOnboarding{
+		SellerID:    seller.ID,
+		Username:    seller.Username,
+		Email:       seller.Email,
+		Name:        seller.Name,
+		Surname:     seller.Surname,
+		Status:      seller.OnboardingStatus,
+		SubmittedAt: seller.OnboardingSubmittedAt,
+		ReviewedAt:  seller.OnboardingReviewedAt,
+		Note:        seller.OnboardingNote,
+	}
+}
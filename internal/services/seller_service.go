@@ -7,6 +7,7 @@ import (
 	"eticketing/internal/models"
 	"eticketing/internal/repositories"
 	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
 	"gorm.io/gorm"
 )
 
@@ -17,12 +18,37 @@ type SellerService struct {
 	ticketRepo  repositories.TicketRepository  // Add ticket repo
 }
 type SellerInfo struct {
-	ID       uint            `json:"id"`
-	Username string          `json:"username"`
-	Email    string          `json:"email"`
-	Name     string          `json:"name"`
-	Surname  string          `json:"surname"`
-	UserType models.UserType `json:"user_type"`
+	ID          uint            `json:"id"`
+	Username    string          `json:"username"`
+	Email       string          `json:"email"`
+	Name        string          `json:"name"`
+	Surname     string          `json:"surname"`
+	UserType    models.UserType `json:"user_type"`
+	DisplayName string          `json:"display_name"`
+	Bio         string          `json:"bio"`
+	LogoURL     string          `json:"logo_url"`
+	Website     string          `json:"website"`
+}
+
+// UpdateSellerProfileRequest adds the public profile fields to the account
+// fields; an empty string clears a public field
+type UpdateSellerProfileRequest struct {
+	UpdateProfileRequest
+	DisplayName *string `json:"display_name" binding:"omitempty,max=100"`
+	Bio         *string `json:"bio" binding:"omitempty,max=2000"`
+	LogoURL     *string `json:"logo_url" binding:"omitempty,url"`
+	Website     *string `json:"website" binding:"omitempty,url"`
+}
+
+// SellerPublicProfile is what buyers see of a seller; it leaves out contact
+// and account details
+type SellerPublicProfile struct {
+	ID             uint            `json:"id"`
+	DisplayName    string          `json:"display_name"`
+	Bio            string          `json:"bio"`
+	LogoURL        string          `json:"logo_url"`
+	Website        string          `json:"website"`
+	UpcomingEvents []EventResponse `json:"upcoming_events"`
 }
 
 type SellerStats struct {
@@ -62,36 +88,29 @@ func (s *SellerService) GetProfile(sellerID uint) (*SellerInfo, error) {
 	seller, err := s.sellerRepo.GetByID(sellerID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("seller not found")
+			return nil, apperrors.NotFound("seller not found")
 		}
-		return nil, errors.New("failed to get seller profile")
+		return nil, apperrors.Internal("failed to get seller profile")
 	}
 
-	return &SellerInfo{
-		ID:       seller.ID,
-		Username: seller.Username,
-		Email:    seller.Email,
-		Name:     seller.Name,
-		Surname:  seller.Surname,
-		UserType: models.UserTypeSeller,
-	}, nil
+	return s.sellerToInfo(seller), nil
 }
 
-func (s *SellerService) UpdateProfile(sellerID uint, req *UpdateProfileRequest) (*SellerInfo, error) {
+func (s *SellerService) UpdateProfile(sellerID uint, req *UpdateSellerProfileRequest) (*SellerInfo, error) {
 	seller, err := s.sellerRepo.GetByID(sellerID)
 	if err != nil {
-		return nil, errors.New("seller not found")
+		return nil, apperrors.NotFound("seller not found")
 	}
 
 	// Validate username if provided
 	if req.Username != "" && req.Username != seller.Username {
 		if valid, validationErrors := utils.ValidateUsername(req.Username); !valid {
-			return nil, errors.New("username validation failed: " + validationErrors[0])
+			return nil, apperrors.Validation("username validation failed: " + validationErrors[0])
 		}
 
 		// Check if username is already taken
 		if existingSeller, _ := s.sellerRepo.GetByUsername(req.Username); existingSeller != nil && existingSeller.ID != sellerID {
-			return nil, errors.New("username already taken")
+			return nil, apperrors.Conflict("username already taken")
 		}
 		seller.Username = utils.SanitizeString(req.Username)
 	}
@@ -103,46 +122,88 @@ func (s *SellerService) UpdateProfile(sellerID uint, req *UpdateProfileRequest)
 	if req.Surname != "" {
 		seller.Surname = utils.SanitizeString(req.Surname)
 	}
+	if req.DisplayName != nil {
+		seller.DisplayName = utils.SanitizeString(*req.DisplayName)
+	}
+	if req.Bio != nil {
+		seller.Bio = utils.SanitizeString(*req.Bio)
+	}
+	if req.LogoURL != nil {
+		seller.LogoURL = *req.LogoURL
+	}
+	if req.Website != nil {
+		seller.Website = *req.Website
+	}
 
 	if err := s.sellerRepo.Update(seller); err != nil {
-		return nil, errors.New("failed to update profile")
+		return nil, apperrors.Internal("failed to update profile")
 	}
 
-	return &SellerInfo{
-		ID:       seller.ID,
-		Username: seller.Username,
-		Email:    seller.Email,
-		Name:     seller.Name,
-		Surname:  seller.Surname,
-		UserType: models.UserTypeSeller,
+	return s.sellerToInfo(seller), nil
+}
+
+// GetPublicProfile returns the seller's public profile. Upcoming events are
+// filled in by the caller from the event service.
+func (s *SellerService) GetPublicProfile(sellerID uint) (*SellerPublicProfile, error) {
+	seller, err := s.sellerRepo.GetByID(sellerID)
+	if err != nil {
+		return nil, apperrors.NotFound("seller not found")
+	}
+
+	displayName := seller.DisplayName
+	if displayName == "" {
+		displayName = seller.Name + " " + seller.Surname
+	}
+
+	return &SellerPublicProfile{
+		ID:          seller.ID,
+		DisplayName: displayName,
+		Bio:         seller.Bio,
+		LogoURL:     seller.LogoURL,
+		Website:     seller.Website,
 	}, nil
 }
 
+func (s *SellerService) sellerToInfo(seller *models.Seller) *SellerInfo {
+	return &SellerInfo{
+		ID:          seller.ID,
+		Username:    seller.Username,
+		Email:       seller.Email,
+		Name:        seller.Name,
+		Surname:     seller.Surname,
+		UserType:    models.UserTypeSeller,
+		DisplayName: seller.DisplayName,
+		Bio:         seller.Bio,
+		LogoURL:     seller.LogoURL,
+		Website:     seller.Website,
+	}
+}
+
 func (s *SellerService) ChangePassword(sellerID uint, req *ChangePasswordRequest) error {
 	seller, err := s.sellerRepo.GetByID(sellerID)
 	if err != nil {
-		return errors.New("seller not found")
+		return apperrors.NotFound("seller not found")
 	}
 
 	// Verify current password
 	if !utils.CheckPassword(req.CurrentPassword, seller.PasswordHash) {
-		return errors.New("current password is incorrect")
+		return apperrors.Validation("current password is incorrect")
 	}
 
 	// Validate new password
 	if valid, validationErrors := utils.ValidatePassword(req.NewPassword); !valid {
-		return errors.New("password validation failed: " + validationErrors[0])
+		return apperrors.Validation("password validation failed: " + validationErrors[0])
 	}
 
 	// Hash new password
 	hashedPassword, err := utils.HashPassword(req.NewPassword)
 	if err != nil {
-		return errors.New("failed to hash password")
+		return apperrors.Internal("failed to hash password")
 	}
 
 	seller.PasswordHash = hashedPassword
 	if err := s.sellerRepo.Update(seller); err != nil {
-		return errors.New("failed to update password")
+		return apperrors.Internal("failed to update password")
 	}
 
 	return nil
@@ -152,40 +213,40 @@ func (s *SellerService) GetSellerStats(sellerID uint) (*SellerStatsResponse, err
 	// Get event counts by status
 	totalEvents, err := s.eventRepo.CountBySellerAndStatus(sellerID, 0) // 0 = all statuses
 	if err != nil {
-		return nil, errors.New("failed to get total events count")
+		return nil, apperrors.Internal("failed to get total events count")
 	}
 
 	approvedEvents, err := s.eventRepo.CountBySellerAndStatus(sellerID, models.EventStatusApproved)
 	if err != nil {
-		return nil, errors.New("failed to get approved events count")
+		return nil, apperrors.Internal("failed to get approved events count")
 	}
 
 	pendingEvents, err := s.eventRepo.CountBySellerAndStatus(sellerID, models.EventStatusPending)
 	if err != nil {
-		return nil, errors.New("failed to get pending events count")
+		return nil, apperrors.Internal("failed to get pending events count")
 	}
 
 	rejectedEvents, err := s.eventRepo.CountBySellerAndStatus(sellerID, models.EventStatusRejected)
 	if err != nil {
-		return nil, errors.New("failed to get rejected events count")
+		return nil, apperrors.Internal("failed to get rejected events count")
 	}
 
 	// Get revenue from payments
 	totalRevenue, err := s.paymentRepo.GetTotalRevenueByUser(sellerID, models.UserTypeSeller)
 	if err != nil {
-		return nil, errors.New("failed to get total revenue")
+		return nil, apperrors.Internal("failed to get total revenue")
 	}
 
 	// Get ticket statistics
 	ticketStats, err := s.ticketRepo.GetSellerTicketStats(sellerID)
 	if err != nil {
-		return nil, errors.New("failed to get ticket statistics")
+		return nil, apperrors.Internal("failed to get ticket statistics")
 	}
 
 	// Count events with sold tickets
 	eventsSold, err := s.eventRepo.CountEventsWithSoldTickets(sellerID)
 	if err != nil {
-		return nil, errors.New("failed to get events sold count")
+		return nil, apperrors.Internal("failed to get events sold count")
 	}
 
 	// Get pending revenue (from pending events)
@@ -213,11 +274,11 @@ func (s *SellerService) DeleteAccount(sellerID uint) error {
 
 	seller, err := s.sellerRepo.GetByID(sellerID)
 	if err != nil {
-		return errors.New("seller not found")
+		return apperrors.NotFound("seller not found")
 	}
 
 	if err := s.sellerRepo.Delete(seller.ID); err != nil {
-		return errors.New("failed to delete account")
+		return apperrors.Internal("failed to delete account")
 	}
 
 	return nil
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"eticketing/internal/models"
+	apperrors "eticketing/pkg/errors"
+)
+
+// liveQRPrefix marks the rotating codes shown in the app, as opposed to the
+// static code printed on the PDF (qrPrefix)
+const liveQRPrefix = "ETKL"
+
+// TicketCodeTrust tells the door staff how much a scanned code proves. A
+// live code was on the owner's phone moments ago; a static code may be a
+// copy of a PDF or a screenshot.
+type TicketCodeTrust string
+
+const (
+	TicketCodeTrustLive   TicketCodeTrust = "live"
+	TicketCodeTrustStatic TicketCodeTrust = "static"
+)
+
+type LiveTicketCode struct {
+	Payload   string `json:"payload"`
+	ExpiresAt int64  `json:"expires_at"` // Fetch a new code by then
+}
+
+// TicketCodeSigner mints and checks live codes. A code covers one period
+// and is signed with the server key over the ticket's QR secret, so it
+// expires on its own and dies with the secret when the ticket changes
+// hands.
+type TicketCodeSigner struct {
+	key    []byte
+	period int64 // Seconds
+}
+
+// NewTicketCodeSigner rotates codes every period, truncated to whole
+// seconds with a minimum of one; 0 or less uses 30 seconds
+func NewTicketCodeSigner(key string, period time.Duration) *TicketCodeSigner {
+	if period <= 0 {
+		period = 30 * time.Second
+	}
+	seconds := int64(period / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return &TicketCodeSigner{key: []byte(key), period: seconds}
+}
+
+// Live returns the ticket's code for the current period
+func (s *TicketCodeSigner) Live(ticket *models.PurchasedTicket, now time.Time) *LiveTicketCode {
+	step := now.Unix() / s.period
+	return &LiveTicketCode{
+		Payload:   fmt.Sprintf("%s:%d:%d:%s", liveQRPrefix, ticket.ID, step, s.sign(ticket, step)),
+		ExpiresAt: (step + 1) * s.period,
+	}
+}
+
+// Verify checks a scanned code against its ticket. Live codes from the
+// previous period are still accepted, so one that rotated while being
+// scanned doesn't fail.
+func (s *TicketCodeSigner) Verify(code string, ticket *models.PurchasedTicket, now time.Time) (TicketCodeTrust, error) {
+	if ticket.QRSecret == "" {
+		return "", apperrors.Validation("ticket code is no longer valid; the ticket has been transferred")
+	}
+
+	if _, secret, ok := ParseTicketQRPayload(code); ok {
+		if subtle.ConstantTimeCompare([]byte(secret), []byte(ticket.QRSecret)) != 1 {
+			return "", apperrors.Validation("ticket code is no longer valid; the ticket has been transferred")
+		}
+		return TicketCodeTrustStatic, nil
+	}
+
+	parts := strings.Split(strings.TrimSpace(code), ":")
+	if len(parts) != 4 || parts[0] != liveQRPrefix {
+		return "", apperrors.Validation("not a ticket code")
+	}
+	step, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return "", apperrors.Validation("not a ticket code")
+	}
+
+	current := now.Unix() / s.period
+	if step > current || step < current-1 {
+		return "", apperrors.Validation("ticket code has expired; ask the holder to refresh it")
+	}
+	if !hmac.Equal([]byte(parts[3]), []byte(s.sign(ticket, step))) {
+		return "", apperrors.Validation("ticket code is no longer valid")
+	}
+	return TicketCodeTrustLive, nil
+}
+
+// ParseTicketCodeID returns the ticket ID from a static or live code
+func ParseTicketCodeID(code string) (uint, bool) {
+	if id, _, ok := ParseTicketQRPayload(code); ok {
+		return id, true
+	}
+
+	parts := strings.Split(strings.TrimSpace(code), ":")
+	if len(parts) != 4 || parts[0] != liveQRPrefix {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (s *TicketCodeSigner) sign(ticket *models.PurchasedTicket, step int64) string {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "%d:%d:%s", ticket.ID, step, ticket.QRSecret)
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
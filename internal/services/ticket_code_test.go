@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"eticketing/internal/models"
+)
+
+func TestTicketCodeSignerPeriods(t *testing.T) {
+	ticket := &models.PurchasedTicket{ID: 7, QRSecret: "secret"}
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		name   string
+		period time.Duration
+		want   int64 // Seconds
+	}{
+		{"unset", 0, 30},
+		{"negative", -time.Second, 30},
+		{"under a second", 500 * time.Millisecond, 1},
+		{"fractional seconds", 1500 * time.Millisecond, 1},
+		{"whole seconds", 45 * time.Second, 45},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer := NewTicketCodeSigner("key", tt.period)
+
+			code := signer.Live(ticket, now)
+			if got := code.ExpiresAt - now.Unix()/tt.want*tt.want; got != tt.want {
+				t.Errorf("code lasts %ds, want %ds", got, tt.want)
+			}
+
+			trust, err := signer.Verify(code.Payload, ticket, now)
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if trust != TicketCodeTrustLive {
+				t.Errorf("trust = %q, want %q", trust, TicketCodeTrustLive)
+			}
+		})
+	}
+}
+
+func TestTicketCodeSignerAcceptsOnlyTheLastTwoPeriods(t *testing.T) {
+	ticket := &models.PurchasedTicket{ID: 7, QRSecret: "secret"}
+	signer := NewTicketCodeSigner("key", 30*time.Second)
+	issued := time.Unix(1_700_000_010, 0)
+	code := signer.Live(ticket, issued)
+
+	if _, err := signer.Verify(code.Payload, ticket, issued.Add(30*time.Second)); err != nil {
+		t.Errorf("code from the previous period was refused: %v", err)
+	}
+	if _, err := signer.Verify(code.Payload, ticket, issued.Add(90*time.Second)); err == nil {
+		t.Error("code from two periods ago was accepted")
+	}
+	if _, err := signer.Verify(code.Payload, ticket, issued.Add(-30*time.Second)); err == nil {
+		t.Error("code from a future period was accepted")
+	}
+
+	transferred := &models.PurchasedTicket{ID: 7, QRSecret: "rotated"}
+	if _, err := signer.Verify(code.Payload, transferred, issued); err == nil {
+		t.Error("code signed over the previous owner's secret was accepted")
+	}
+}
@@ -0,0 +1,430 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/outbox"
+	"eticketing/internal/repositories"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	maxTicketImportRows     = 1000
+	recentTicketImportLimit = 20
+)
+
+// Columns a ticket import must have; is_vip, description and is_accessible
+// are optional. Column order doesn't matter.
+var requiredTicketImportColumns = []string{"price", "type", "title", "place", "quantity", "sale"}
+
+type TicketImportService struct {
+	importRepo    repositories.TicketImportRepository
+	eventRepo     repositories.EventRepository
+	saleRepo      repositories.SaleRepository
+	access        *EventAccess
+	ticketService *TicketService
+	outboxRepo    repositories.OutboxRepository
+	txManager     repositories.TransactionManager
+}
+
+// TicketImportRow is one parsed CSV row and what is wrong with it, if anything
+type TicketImportRow struct {
+	Line         int               `json:"line"` // Line in the file; the header is line 1
+	Price        float64           `json:"price"`
+	Type         models.TicketType `json:"type"`
+	IsVip        bool              `json:"is_vip"`
+	Title        string            `json:"title"`
+	Description  string            `json:"description,omitempty"`
+	Place        string            `json:"place"`
+	Quantity     int               `json:"quantity"`
+	SaleID       uint              `json:"sale_id"`
+	IsAccessible bool              `json:"is_accessible"`
+	Errors       []string          `json:"errors,omitempty"`
+}
+
+// TicketImportPreview is the validation result of an uploaded file. Nothing
+// is imported while any row is invalid.
+type TicketImportPreview struct {
+	Rows         []TicketImportRow `json:"rows"`
+	ValidRows    int               `json:"valid_rows"`
+	InvalidRows  int               `json:"invalid_rows"`
+	TotalTickets int               `json:"total_tickets"` // Tickets the file would create
+}
+
+func NewTicketImportService(
+	importRepo repositories.TicketImportRepository,
+	eventRepo repositories.EventRepository,
+	saleRepo repositories.SaleRepository,
+	access *EventAccess,
+	ticketService *TicketService,
+	outboxRepo repositories.OutboxRepository,
+	txManager repositories.TransactionManager,
+) *TicketImportService {
+	return &TicketImportService{
+		importRepo:    importRepo,
+		eventRepo:     eventRepo,
+		saleRepo:      saleRepo,
+		access:        access,
+		ticketService: ticketService,
+		outboxRepo:    outboxRepo,
+		txManager:     txManager,
+	}
+}
+
+// Preview validates a CSV without importing it
+func (s *TicketImportService) Preview(eventID, sellerID uint, data []byte) (*TicketImportPreview, error) {
+	if _, err := s.authorize(eventID, sellerID); err != nil {
+		return nil, err
+	}
+	return s.validate(eventID, data)
+}
+
+// Import validates a CSV and, when every row is valid, queues it to be
+// created in the background. An invalid file is returned with its preview
+// so the seller can see which rows to fix.
+func (s *TicketImportService) Import(eventID, sellerID uint, fileName string, data []byte) (*models.TicketImport, *TicketImportPreview, error) {
+	if _, err := s.authorize(eventID, sellerID); err != nil {
+		return nil, nil, err
+	}
+
+	preview, err := s.validate(eventID, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if preview.InvalidRows > 0 {
+		return nil, preview, apperrors.Validationf("%d row(s) have errors; nothing was imported", preview.InvalidRows)
+	}
+
+	ticketImport := &models.TicketImport{
+		EventID:   eventID,
+		SellerID:  sellerID,
+		Status:    models.TicketImportStatusPending,
+		FileName:  fileName,
+		Data:      data,
+		TotalRows: len(preview.Rows),
+		CreatedAt: time.Now().Unix(),
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.importRepo.WithTx(tx).Create(ticketImport); err != nil {
+			return err
+		}
+
+		message, err := outbox.NewMessage(models.OutboxTopicTicketImportQueued, outbox.TicketImportPayload{
+			ImportID: ticketImport.ID,
+			EventID:  eventID,
+		})
+		if err != nil {
+			return err
+		}
+		return s.outboxRepo.WithTx(tx).Create(message)
+	})
+	if err != nil {
+		return nil, nil, apperrors.Internal("failed to queue ticket import")
+	}
+
+	return ticketImport, preview, nil
+}
+
+// GetImport returns an import with its per-row report
+func (s *TicketImportService) GetImport(eventID, sellerID, importID uint) (*models.TicketImport, error) {
+	if _, err := s.authorize(eventID, sellerID); err != nil {
+		return nil, err
+	}
+
+	ticketImport, err := s.importRepo.GetByID(importID)
+	if err != nil || ticketImport.EventID != eventID {
+		return nil, apperrors.NotFound("ticket import not found")
+	}
+	return ticketImport, nil
+}
+
+// ListImports returns the event's most recent imports without their reports
+func (s *TicketImportService) ListImports(eventID, sellerID uint) ([]models.TicketImport, error) {
+	if _, err := s.authorize(eventID, sellerID); err != nil {
+		return nil, err
+	}
+
+	imports, err := s.importRepo.ListByEvent(eventID, recentTicketImportLimit)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve ticket imports")
+	}
+	return imports, nil
+}
+
+// Process is the outbox handler for ticket_import.queued. Each row is
+// created on its own, so one row failing (the sale was deleted since the
+// upload, the event ran out of capacity) doesn't undo the others; the
+// outcome of every row is kept in the import's results.
+func (s *TicketImportService) Process(ctx context.Context, message *models.OutboxMessage) error {
+	var payload outbox.TicketImportPayload
+	if err := json.Unmarshal([]byte(message.Payload), &payload); err != nil {
+		return fmt.Errorf("invalid payload for %s: %w", message.Topic, err)
+	}
+
+	ticketImport, err := s.importRepo.GetByID(payload.ImportID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// Rows aren't idempotent, so an import is only ever started once
+	if ticketImport.Status != models.TicketImportStatusPending {
+		return nil
+	}
+
+	ticketImport.Status = models.TicketImportStatusProcessing
+	if err := s.importRepo.Update(ticketImport); err != nil {
+		return err
+	}
+
+	rows, parseErr := parseTicketImport(ticketImport.Data)
+	now := time.Now().Unix()
+	ticketImport.CompletedAt = &now
+	if parseErr != nil {
+		ticketImport.Status = models.TicketImportStatusFailed
+		ticketImport.Error = parseErr.Error()
+		return s.importRepo.Update(ticketImport)
+	}
+
+	results := make(models.TicketImportResults, 0, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		result := models.TicketImportResult{
+			Line:     row.Line,
+			Title:    row.Title,
+			Place:    row.Place,
+			Quantity: row.Quantity,
+		}
+
+		if err := s.ticketService.CreateTickets(row.toRequest(ticketImport.EventID), ticketImport.SellerID); err != nil {
+			result.Error = err.Error()
+			ticketImport.FailedRows++
+		} else {
+			result.Created = true
+			ticketImport.ImportedRows++
+			ticketImport.TicketsCreated += row.Quantity
+		}
+		results = append(results, result)
+	}
+
+	now = time.Now().Unix()
+	ticketImport.Status = models.TicketImportStatusCompleted
+	ticketImport.Results = results
+	ticketImport.CompletedAt = &now
+	return s.importRepo.Update(ticketImport)
+}
+
+func (s *TicketImportService) authorize(eventID, sellerID uint) (*models.Event, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, apperrors.NotFound("event not found")
+	}
+	if !s.access.Allowed(event, sellerID, models.EventPermissionManageTickets) {
+		return nil, apperrors.Forbidden("unauthorized to import tickets for this event")
+	}
+	return event, nil
+}
+
+// validate parses the file and checks every row, including that its sale
+// belongs to the event
+func (s *TicketImportService) validate(eventID uint, data []byte) (*TicketImportPreview, error) {
+	rows, err := parseTicketImport(data)
+	if err != nil {
+		return nil, apperrors.Validation(err.Error())
+	}
+
+	sales, err := s.saleRepo.ListByEvent(eventID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to load the event's sales")
+	}
+	saleIDs := make(map[uint]bool, len(sales))
+	for _, sale := range sales {
+		saleIDs[sale.ID] = true
+	}
+
+	preview := &TicketImportPreview{Rows: rows}
+	for i := range preview.Rows {
+		row := &preview.Rows[i]
+		if row.SaleID != 0 && !saleIDs[row.SaleID] {
+			row.Errors = append(row.Errors, fmt.Sprintf("sale %d does not belong to this event", row.SaleID))
+		}
+
+		if len(row.Errors) > 0 {
+			preview.InvalidRows++
+			continue
+		}
+		preview.ValidRows++
+		preview.TotalTickets += row.Quantity
+	}
+	return preview, nil
+}
+
+func (r *TicketImportRow) toRequest(eventID uint) *CreateTicketRequest {
+	return &CreateTicketRequest{
+		Price:        r.Price,
+		Type:         r.Type,
+		IsVip:        r.IsVip,
+		Title:        r.Title,
+		Description:  r.Description,
+		Place:        r.Place,
+		SaleID:       r.SaleID,
+		EventID:      eventID,
+		Amount:       r.Quantity,
+		IsAccessible: r.IsAccessible,
+	}
+}
+
+// parseTicketImport reads the rows of a ticket import CSV. Problems with the
+// file as a whole are returned as an error; problems with a single row are
+// recorded on that row.
+func parseTicketImport(data []byte) ([]TicketImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))) // Spreadsheets often add a BOM
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %v", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredTicketImportColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q; required columns are %s", name, strings.Join(requiredTicketImportColumns, ", "))
+		}
+	}
+
+	var rows []TicketImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if isBlankRecord(record) {
+			continue
+		}
+		if len(rows) == maxTicketImportRows {
+			return nil, fmt.Errorf("file has more than %d rows", maxTicketImportRows)
+		}
+
+		rows = append(rows, parseTicketImportRow(line, record, columns))
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("file has no rows")
+	}
+	return rows, nil
+}
+
+func parseTicketImportRow(line int, record []string, columns map[string]int) TicketImportRow {
+	row := TicketImportRow{Line: line}
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	fail := func(format string, args ...interface{}) {
+		row.Errors = append(row.Errors, fmt.Sprintf(format, args...))
+	}
+
+	if price, err := strconv.ParseFloat(field("price"), 64); err != nil || price < 0 {
+		fail("price must be a number of at least 0")
+	} else {
+		row.Price = roundCents(price)
+	}
+
+	if ticketType, ok := parseTicketImportType(field("type")); ok {
+		row.Type = ticketType
+	} else {
+		fail("type must be regular, vip, premium or 1-3")
+	}
+
+	row.Title = field("title")
+	if row.Title == "" {
+		fail("title is required")
+	}
+	row.Place = field("place")
+	if row.Place == "" {
+		fail("place is required")
+	}
+	row.Description = field("description")
+
+	if quantity, err := strconv.Atoi(field("quantity")); err != nil || quantity < 1 || quantity > 1000 {
+		fail("quantity must be a whole number from 1 to 1000")
+	} else {
+		row.Quantity = quantity
+	}
+
+	if saleID, err := strconv.ParseUint(field("sale"), 10, 32); err != nil || saleID == 0 {
+		fail("sale must be a sale ID")
+	} else {
+		row.SaleID = uint(saleID)
+	}
+
+	var ok bool
+	if row.IsVip, ok = parseTicketImportBool(field("is_vip")); !ok {
+		fail("is_vip must be true or false")
+	}
+	if row.IsAccessible, ok = parseTicketImportBool(field("is_accessible")); !ok {
+		fail("is_accessible must be true or false")
+	}
+
+	return row
+}
+
+func parseTicketImportType(value string) (models.TicketType, bool) {
+	switch strings.ToLower(value) {
+	case "1", "regular":
+		return models.TicketTypeRegular, true
+	case "2", "vip":
+		return models.TicketTypeVIP, true
+	case "3", "premium":
+		return models.TicketTypePremium, true
+	}
+	return 0, false
+}
+
+// parseTicketImportBool reads an optional flag; empty means false
+func parseTicketImportBool(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "", "0", "false", "no":
+		return false, true
+	case "1", "true", "yes":
+		return true, true
+	}
+	return false, false
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -2,18 +2,42 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"eticketing/internal/fraud"
 	"eticketing/internal/models"
+	"eticketing/internal/outbox"
 	"eticketing/internal/repositories"
-	"time"
+	"eticketing/internal/tracing"
+	apperrors "eticketing/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
 )
 
 type TicketService struct {
 	ticketRepo          repositories.TicketRepository
+	ticketGroupRepo     repositories.TicketGroupRepository
 	purchasedTicketRepo repositories.PurchasedTicketRepository
 	eventRepo           repositories.EventRepository
+	access              *EventAccess
 	saleRepo            repositories.SaleRepository
+	lotteryRepo         repositories.LotteryRepository
+	userRepo            repositories.UserRepository
+	giftRepo            repositories.GiftRepository
 	paymentService      *PaymentService
+	pricingService      *PricingService
+	featureFlags        *FeatureFlagService
+	screener            *fraud.Screener
+	orderRepo           repositories.OrderRepository
+	outboxRepo          repositories.OutboxRepository
+	txManager           repositories.TransactionManager
+	paymentGrace        time.Duration
+	reviewWindow        time.Duration
 }
 
 type GroupedTicket = models.GroupedTicket
@@ -28,6 +52,33 @@ type CreateTicketRequest struct {
 	SaleID      uint              `json:"sale_id" binding:"required"`
 	EventID     uint              `json:"event_id" binding:"required"`
 	Amount      int               `json:"amount" binding:"required,min=1,max=1000"`
+
+	IsAccessible bool `json:"is_accessible"` // Wheelchair-accessible or otherwise adapted seating
+}
+
+// AdjustTicketQuantityRequest adds tickets to a group when Change is
+// positive and removes that many unsold ones when it is negative
+type AdjustTicketQuantityRequest struct {
+	Change int `json:"change" binding:"required,min=-1000,max=1000"`
+}
+
+// MoveTicketGroupRequest names the sale a group's unsold tickets move to
+type MoveTicketGroupRequest struct {
+	SaleID uint `json:"sale_id" binding:"required"`
+}
+
+type TicketGroupMoveResponse struct {
+	FromGroupID uint `json:"from_group_id"`
+	ToGroupID   uint `json:"to_group_id"`
+	SaleID      uint `json:"sale_id"`
+	Moved       int  `json:"moved"`
+}
+
+type TicketQuantityResponse struct {
+	GroupID   uint  `json:"group_id"`
+	Total     int64 `json:"total"`
+	Sold      int64 `json:"sold"`
+	Available int64 `json:"available"`
 }
 
 type UpdateTicketRequest struct {
@@ -38,33 +89,73 @@ type UpdateTicketRequest struct {
 	Description *string            `json:"description"`
 	Place       *string            `json:"place"`
 	SaleID      *uint              `json:"sale_id"`
+
+	IsAccessible *bool `json:"is_accessible"`
 }
 
+// PurchaseTicketFromGroupRequest identifies the group by group_id. Clients
+// that predate group IDs may send the group's details instead.
 type PurchaseTicketFromGroupRequest struct {
 	UserID        uint               `json:"-"` // Set by handler
 	EventID       uint               `json:"event_id" binding:"required"`
-	Price         float64            `json:"price" binding:"required"`
-	Type          models.TicketType  `json:"type" binding:"required"`
+	GroupID       uint               `json:"group_id"`
+	Price         float64            `json:"price" binding:"required_without=GroupID"`
+	Type          models.TicketType  `json:"type" binding:"required_without=GroupID"`
 	IsVip         bool               `json:"is_vip"`
-	Title         string             `json:"title" binding:"required"`
+	Title         string             `json:"title" binding:"required_without=GroupID"`
 	Description   string             `json:"description"`
-	Place         string             `json:"place" binding:"required"`
-	SaleID        uint               `json:"sale_id" binding:"required"`
+	Place         string             `json:"place" binding:"required_without=GroupID"`
+	SaleID        uint               `json:"sale_id" binding:"required_without=GroupID"`
 	Quantity      int                `json:"quantity" binding:"required,min=1,max=10"`
-	PaymentMethod models.PaymentType `json:"payment_method" binding:"required"`
+	PaymentMethod models.PaymentType `json:"payment_method" binding:"required_without_all=PaymentSplits PaymentMethodID UseDefaultPaymentMethod"`
+	PaymentSplits []PaymentSplit     `json:"payment_splits" binding:"omitempty,min=2,max=2,dive"` // Pay from two stored methods
+
+	// Stored payment method to charge instead of a bare payment type
+	PaymentMethodID         uint `json:"payment_method_id"`
+	UseDefaultPaymentMethod bool `json:"use_default_payment_method"`
+
+	// Optional: buy the tickets for someone else
+	GiftRecipientEmail string `json:"gift_recipient_email" binding:"omitempty,email"`
+	GiftMessage        string `json:"gift_message" binding:"max=500"`
+
+	// Optional: what the venue should prepare for the attendee (wheelchair
+	// space, companion seat, step-free route...)
+	AccommodationRequest string `json:"accommodation_request" binding:"max=500"`
+
+	ClientIP          string `json:"-"` // Set by handler
+	DeviceFingerprint string `json:"-"` // Set by handler from X-Device-Fingerprint, if sent
+
+	BulkOrderID uint `json:"-"` // Set when fulfilling an organization order
 }
 
 type PurchaseTicketRequest struct {
 	UserID        uint               `json:"-"` // Set by handler
 	TicketID      uint               `json:"ticket_id" binding:"required"`
 	Quantity      int                `json:"quantity" binding:"required,min=1,max=10"`
-	PaymentMethod models.PaymentType `json:"payment_method" binding:"required"`
+	PaymentMethod models.PaymentType `json:"payment_method" binding:"required_without_all=PaymentMethodID UseDefaultPaymentMethod"`
+
+	PaymentMethodID         uint `json:"payment_method_id"`
+	UseDefaultPaymentMethod bool `json:"use_default_payment_method"`
+
+	ClientIP          string `json:"-"` // Set by handler
+	DeviceFingerprint string `json:"-"` // Set by handler
 }
 
 type PurchaseTicketResponse struct {
 	PurchasedTickets []PurchasedTicketInfo `json:"purchased_tickets"`
 	PaymentInfo      *PaymentResponse      `json:"payment_info"`
 	TotalAmount      float64               `json:"total_amount"`
+	Gift             *GiftInfo             `json:"gift,omitempty"`
+	PendingOrder     *models.Order         `json:"pending_order,omitempty"` // Set when the charge failed and the tickets are held for a retry
+}
+
+type RetryPaymentRequest struct {
+	PaymentMethod           models.PaymentType `json:"payment_method" binding:"required_without_all=PaymentMethodID UseDefaultPaymentMethod"`
+	PaymentMethodID         uint               `json:"payment_method_id"`
+	UseDefaultPaymentMethod bool               `json:"use_default_payment_method"`
+
+	ClientIP          string `json:"-"` // Set by handler
+	DeviceFingerprint string `json:"-"` // Set by handler
 }
 
 type PurchasedTicketInfo struct {
@@ -78,6 +169,9 @@ type PurchasedTicketInfo struct {
 	EventDate   int64   `json:"event_date"`
 	EventID     uint    `json:"event_id"` // Add this field
 	IsUsed      bool    `json:"is_used"`
+	PurchasedAt int64   `json:"purchased_at"` // Unix timestamp; 0 for tickets bought before it was recorded
+
+	IsInvalidated bool `json:"is_invalidated,omitempty"` // Payment was reversed by a chargeback
 }
 
 type TransferTicketRequest struct {
@@ -88,58 +182,181 @@ type TransferTicketRequest struct {
 
 func NewTicketService(
 	ticketRepo repositories.TicketRepository,
+	ticketGroupRepo repositories.TicketGroupRepository,
 	purchasedTicketRepo repositories.PurchasedTicketRepository,
 	eventRepo repositories.EventRepository,
+	access *EventAccess,
 	saleRepo repositories.SaleRepository,
+	lotteryRepo repositories.LotteryRepository,
+	userRepo repositories.UserRepository,
+	giftRepo repositories.GiftRepository,
 	paymentService *PaymentService,
+	pricingService *PricingService,
+	featureFlags *FeatureFlagService,
+	screener *fraud.Screener,
+	orderRepo repositories.OrderRepository,
+	outboxRepo repositories.OutboxRepository,
+	txManager repositories.TransactionManager,
+	paymentGrace time.Duration,
+	reviewWindow time.Duration,
 ) *TicketService {
 	return &TicketService{
 		ticketRepo:          ticketRepo,
+		ticketGroupRepo:     ticketGroupRepo,
 		purchasedTicketRepo: purchasedTicketRepo,
 		eventRepo:           eventRepo,
+		access:              access,
 		saleRepo:            saleRepo,
+		lotteryRepo:         lotteryRepo,
+		userRepo:            userRepo,
+		giftRepo:            giftRepo,
 		paymentService:      paymentService,
+		pricingService:      pricingService,
+		featureFlags:        featureFlags,
+		screener:            screener,
+		orderRepo:           orderRepo,
+		outboxRepo:          outboxRepo,
+		txManager:           txManager,
+		paymentGrace:        paymentGrace,
+		reviewWindow:        reviewWindow,
+	}
+}
+
+func (r *PurchaseTicketFromGroupRequest) groupKey() models.GroupedTicket {
+	return models.GroupedTicket{
+		GroupID: r.GroupID,
+		EventID: r.EventID,
+		SaleID:  r.SaleID,
+		Price:   r.Price,
+		Type:    r.Type,
+		IsVip:   r.IsVip,
+		Title:   r.Title,
+		Place:   r.Place,
+	}
+}
+
+// useGroup replaces the client-sent group details with the stored ones
+func (r *PurchaseTicketFromGroupRequest) useGroup(group *models.TicketGroup) {
+	r.GroupID = group.ID
+	r.SaleID = group.SaleID
+	r.Price = group.Price
+	r.Type = group.Type
+	r.IsVip = group.IsVip
+	r.Title = group.Title
+	r.Description = group.Description
+	r.Place = group.Place
+}
+
+// FindGroup resolves the ticket group a request refers to: by ID when one is
+// given, otherwise by the details clients matched groups on before groups
+// had IDs
+func (s *TicketService) FindGroup(eventID uint, key models.GroupedTicket) (*models.TicketGroup, error) {
+	return findTicketGroup(s.ticketGroupRepo, eventID, key)
+}
+
+func findTicketGroup(ticketGroupRepo repositories.TicketGroupRepository, eventID uint, key models.GroupedTicket) (*models.TicketGroup, error) {
+	var group *models.TicketGroup
+	var err error
+	if key.GroupID != 0 {
+		group, err = ticketGroupRepo.GetByID(key.GroupID)
+	} else {
+		group, err = ticketGroupRepo.FindByDetails(eventID, key.SaleID, key.Price, key.Type, key.IsVip, key.Title, key.Place)
+	}
+	if err != nil || group.EventID != eventID {
+		return nil, apperrors.NotFound("ticket group not found")
+	}
+	return group, nil
+}
+
+// groupedTicketOf keys pricing lookups by the group
+func groupedTicketOf(group *models.TicketGroup) models.GroupedTicket {
+	return models.GroupedTicket{
+		GroupID:     group.ID,
+		EventID:     group.EventID,
+		SaleID:      group.SaleID,
+		Price:       group.Price,
+		Type:        group.Type,
+		IsVip:       group.IsVip,
+		Title:       group.Title,
+		Description: group.Description,
+		Place:       group.Place,
+
+		IsAccessible: group.IsAccessible,
 	}
 }
 
-// New method for purchasing from grouped tickets with locking
-func (s *TicketService) PurchaseTicketFromGroup(req *PurchaseTicketFromGroupRequest) (*PurchaseTicketResponse, error) {
+// PurchaseTicketFromGroup buys tickets from a group. The tickets are locked
+// and held on an order in a short transaction, charged for once it has
+// committed, and issued in a second one; a charge whose tickets can't be
+// issued is refunded. Rows other buyers have locked are skipped, so
+// concurrent purchases take disjoint tickets instead of queueing.
+func (s *TicketService) PurchaseTicketFromGroup(ctx context.Context, req *PurchaseTicketFromGroupRequest) (resp *PurchaseTicketResponse, err error) {
+	ctx, span := tracing.StartSpan(ctx, "TicketService.PurchaseTicketFromGroup",
+		attribute.Int("event.id", int(req.EventID)),
+		attribute.Int("sale.id", int(req.SaleID)),
+		attribute.Int("ticket.quantity", req.Quantity),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	if req.PaymentMethod == models.PaymentTypeInvoice && req.BulkOrderID == 0 {
+		return nil, apperrors.Validation("invoice payment is only available for organization orders")
+	}
+
+	group, err := s.FindGroup(req.EventID, req.groupKey())
+	if err != nil {
+		return nil, err
+	}
+	req.useGroup(group)
+
 	// Validate sale is active
 	sale, err := s.saleRepo.GetByID(req.SaleID)
 	if err != nil {
-		return nil, errors.New("sale not found")
+		return nil, apperrors.NotFound("sale not found")
 	}
 
 	now := time.Now().Unix()
 	if now < sale.StartDate || now > sale.EndDate {
-		return nil, errors.New("sale is not currently active")
+		return nil, ErrSaleNotActive
+	}
+
+	// Organization orders are approved on their own and skip the draw
+	lottery := sale.Mode == models.SaleModeLottery && req.BulkOrderID == 0
+	if lottery {
+		if err := s.checkPurchaseRight(sale, req.UserID, now); err != nil {
+			return nil, err
+		}
 	}
 
 	// Validate event
 	event, err := s.eventRepo.GetByID(req.EventID)
 	if err != nil {
-		return nil, errors.New("event not found")
+		return nil, apperrors.NotFound("event not found")
 	}
 
-	if event.Status != models.EventStatusApproved {
-		return nil, errors.New("event is not approved for ticket sales")
+	if !eventOnSale(event, now) {
+		return nil, ErrEventNotOnSale
 	}
 
-	// Begin transaction with locking
-	availableTickets, err := s.ticketRepo.FindAndLockAvailableTickets(
-		req.EventID, req.Price, req.Type, req.IsVip,
-		req.Title, req.Place, req.SaleID, req.Quantity,
-	)
+	// Resolve the gift recipient before any money moves
+	buyer, recipient, err := s.resolveGift(req)
 	if err != nil {
-		return nil, errors.New("failed to lock tickets: " + err.Error())
+		return nil, err
 	}
 
-	if len(availableTickets) < req.Quantity {
-		return nil, errors.New("not enough tickets available")
+	// Price the tickets server-side from the group's tier schedule; the
+	// client-sent price only identifies the group
+	prices, err := s.pricingService.QuotePrices(groupedTicketOf(group), req.Quantity)
+	if err != nil {
+		return nil, err
 	}
 
-	// Calculate total amount
-	totalAmount := req.Price * float64(req.Quantity)
+	totalAmount := 0.0
+	for _, price := range prices {
+		totalAmount += price
+	}
 
 	// Process payment
 	paymentReq := &PaymentRequest{
@@ -149,65 +366,873 @@ func (s *TicketService) PurchaseTicketFromGroup(req *PurchaseTicketFromGroupRequ
 		PaymentMethod: req.PaymentMethod,
 		Description:   "Ticket purchase for " + req.Title + " - " + event.Title,
 		EventID:       sale.EventID,
+		Splits:        req.PaymentSplits,
+
+		PaymentMethodID:  req.PaymentMethodID,
+		UseDefaultMethod: req.UseDefaultPaymentMethod,
+
+		ClientIP:          req.ClientIP,
+		DeviceFingerprint: req.DeviceFingerprint,
 	}
 
-	paymentResponse, err := s.paymentService.ProcessPayment(paymentReq)
+	// Organization orders are approved by the seller instead
+	status := models.OrderStatusPaying
+	var reasons []string
+	if req.BulkOrderID == 0 {
+		screening, err := s.screenPurchase(ctx, paymentReq, req.Quantity, req.GiftRecipientEmail)
+		if err != nil {
+			return nil, err
+		}
+		switch screening.Decision {
+		case fraud.DecisionDecline:
+			return nil, ErrPurchaseDeclined
+		case fraud.DecisionReview:
+			status = models.OrderStatusUnderReview
+			reasons = screening.Reasons
+		}
+	}
+
+	order, tickets, err := s.holdTickets(ctx, req, lottery, prices, status, reasons, func(ticketRepo repositories.TicketRepository) ([]models.Ticket, error) {
+		_, lockSpan := tracing.StartSpan(ctx, "TicketRepository.FindAndLockAvailableTickets")
+		tickets, err := ticketRepo.FindAndLockAvailableTickets(req.GroupID, req.Quantity)
+		tracing.RecordError(lockSpan, err)
+		lockSpan.End()
+		if err != nil {
+			return nil, apperrors.Internal("failed to lock tickets: " + err.Error())
+		}
+
+		if len(tickets) < req.Quantity {
+			return nil, ErrTicketsSoldOut
+		}
+		return tickets, nil
+	})
 	if err != nil {
-		return nil, errors.New("payment processing failed: " + err.Error())
+		return nil, err
+	}
+	if status == models.OrderStatusUnderReview {
+		return &PurchaseTicketResponse{
+			TotalAmount:  totalAmount,
+			PendingOrder: order,
+		}, nil
 	}
 
-	if paymentResponse.Status != models.PaymentStatusCompleted {
-		return nil, errors.New("payment failed: " + paymentResponse.Message)
+	paymentResponse, err := s.paymentService.ProcessPayment(ctx, paymentReq)
+	if err != nil {
+		s.abandonOrder(order, lottery)
+		return nil, fmt.Errorf("payment processing failed: %w", err)
+	}
+	order.LastPaymentID = &paymentResponse.PaymentID
+
+	// Invoices are issued as pending and settled later by the seller
+	invoiced := req.PaymentMethod == models.PaymentTypeInvoice && paymentResponse.Status == models.PaymentStatusPending
+	if paymentResponse.Status != models.PaymentStatusCompleted && !invoiced {
+		// Organization orders record their own failures; regular buyers
+		// keep the tickets held so they can retry the charge
+		if req.BulkOrderID != 0 {
+			s.abandonOrder(order, false)
+			return nil, apperrors.PaymentRequired("payment failed: " + paymentResponse.Message)
+		}
+
+		if err := s.moveOrder(order, models.OrderStatusAwaitingPayment, nil); err != nil {
+			return nil, err
+		}
+		return &PurchaseTicketResponse{
+			PaymentInfo:  paymentResponse,
+			TotalAmount:  totalAmount,
+			PendingOrder: order,
+		}, nil
 	}
 
-	// Mark tickets as sold and create purchased ticket records
+	resp, err = s.completePurchase(ctx, event, req, buyer, recipient, tickets, prices, paymentResponse, order)
+	if err != nil {
+		s.abandonOrder(order, lottery)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// holdTickets locks the tickets lock picks, counts them against the sale's
+// allocation and the buyer's lottery win, and holds them on a new order
+// with status, all in one short transaction. Nothing slow, like the fraud
+// checks or the charge, runs while the rows are locked.
+func (s *TicketService) holdTickets(
+	ctx context.Context,
+	req *PurchaseTicketFromGroupRequest,
+	lottery bool,
+	prices []float64,
+	status models.OrderStatus,
+	reasons []string,
+	lock func(ticketRepo repositories.TicketRepository) ([]models.Ticket, error),
+) (order *models.Order, tickets []models.Ticket, err error) {
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		// Queries carry the request context, so they are traced and any slow
+		// ones are logged with the request ID
+		tx = tx.WithContext(ctx)
+
+		var err error
+		tickets, err = lock(s.ticketRepo.WithTx(tx))
+		if err != nil {
+			return err
+		}
+
+		// Count the purchase against the sale's cap for this group, if any
+		allocation, err := s.reserveSaleAllocation(tx, req.SaleID, req.GroupID, req.Quantity)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if lottery {
+			if err := s.claimPurchaseRight(tx, req.SaleID, req.UserID, now.Unix()); err != nil {
+				return err
+			}
+		}
+
+		totalAmount := 0.0
+		for _, price := range prices {
+			totalAmount += price
+		}
+
+		// Orders nobody reviews are released once the review window has
+		// passed, the others once the payment grace window has
+		order = newOrder(req, totalAmount, allocation)
+		order.Status = status
+		order.HoldExpiresAt = now.Add(s.paymentGrace).Unix()
+		if status == models.OrderStatusUnderReview {
+			order.FraudReasons = strings.Join(reasons, "; ")
+			order.HoldExpiresAt = now.Add(s.reviewWindow).Unix()
+		}
+
+		if err := s.holdOrder(tx, order, tickets, prices); err != nil {
+			return apperrors.Internal("failed to hold the tickets")
+		}
+		return nil
+	})
+	return order, tickets, err
+}
+
+// abandonOrder releases a purchase's order whose charge didn't go through
+// and gives back the lottery win it used up, as if it never happened
+func (s *TicketService) abandonOrder(order *models.Order, lottery bool) {
+	err := s.releaseOrder(order, models.OrderStatusFailed, func(tx *gorm.DB) error {
+		if !lottery {
+			return nil
+		}
+		return s.lotteryRepo.WithTx(tx).ReopenPurchase(order.SaleID, order.UserID)
+	})
+	if err != nil {
+		log.Printf("Failed to release order %d: %v", order.ID, err)
+	}
+}
+
+// eventOnSale reports whether the event's tickets can be bought: it must be
+// approved, not suspended, and past its publication time if it has one
+func eventOnSale(event *models.Event, now int64) bool {
+	return event.Status == models.EventStatusApproved && (event.PublishAt == nil || *event.PublishAt <= now)
+}
+
+// resolveGift looks up the buyer and recipient of a gift purchase. Both are
+// nil for a regular purchase; recipient is nil when they have no account yet.
+func (s *TicketService) resolveGift(req *PurchaseTicketFromGroupRequest) (buyer, recipient *models.User, err error) {
+	if req.GiftRecipientEmail == "" {
+		return nil, nil, nil
+	}
+	if err := s.featureFlags.Require(models.FeatureGifts, req.UserID); err != nil {
+		return nil, nil, err
+	}
+
+	buyer, err = s.userRepo.GetByID(req.UserID)
+	if err != nil {
+		return nil, nil, apperrors.NotFound("buyer not found")
+	}
+	if strings.EqualFold(buyer.Email, req.GiftRecipientEmail) {
+		return nil, nil, apperrors.Validation("cannot gift tickets to yourself")
+	}
+	recipient, _ = s.userRepo.GetByEmail(req.GiftRecipientEmail)
+
+	return buyer, recipient, nil
+}
+
+// completePurchase marks the paid tickets of an order as sold, issues
+// purchased tickets and closes the order in one transaction. The charge is
+// refunded if that fails, e.g. because the order expired while it was paid.
+func (s *TicketService) completePurchase(
+	ctx context.Context,
+	event *models.Event,
+	req *PurchaseTicketFromGroupRequest,
+	buyer, recipient *models.User,
+	tickets []models.Ticket,
+	prices []float64,
+	paymentResponse *PaymentResponse,
+	order *models.Order,
+) (*PurchaseTicketResponse, error) {
 	var purchasedTickets []PurchasedTicketInfo
-	for i := 0; i < req.Quantity; i++ {
-		ticket := &availableTickets[i]
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		var err error
+		purchasedTickets, err = s.recordPurchase(ctx, tx, event, req, buyer, recipient, tickets, prices, paymentResponse, order)
+		return err
+	})
+	if err != nil {
+		// The charge went through but the tickets can't be issued
+		if err := s.paymentService.ReversePayment(ctx, paymentResponse.PaymentID); err != nil {
+			log.Printf("Failed to refund payment %d of order %d: %v", paymentResponse.PaymentID, order.ID, err)
+		}
+		return nil, err
+	}
 
-		// Mark as sold
+	return s.purchaseResponse(req, buyer, recipient, purchasedTickets, prices, paymentResponse), nil
+}
+
+// recordPurchase marks the paid tickets of order as sold, issues purchased
+// tickets and closes the order within tx
+func (s *TicketService) recordPurchase(
+	ctx context.Context,
+	tx *gorm.DB,
+	event *models.Event,
+	req *PurchaseTicketFromGroupRequest,
+	buyer, recipient *models.User,
+	tickets []models.Ticket,
+	prices []float64,
+	paymentResponse *PaymentResponse,
+	order *models.Order,
+) ([]PurchasedTicketInfo, error) {
+	// Mark tickets as sold and create purchased ticket records
+	_, writeSpan := tracing.StartSpan(ctx, "TicketService.recordPurchasedTickets")
+	defer writeSpan.End()
+
+	// Tickets go straight to a registered recipient; otherwise the buyer
+	// holds them until the recipient signs up and claims the gift
+	ownerID := req.UserID
+	if recipient != nil {
+		ownerID = recipient.ID
+	}
+
+	totalAmount := 0.0
+	for _, price := range prices {
+		totalAmount += price
+	}
+
+	ticketRepo := s.ticketRepo.WithTx(tx)
+	purchasedTicketRepo := s.purchasedTicketRepo.WithTx(tx)
+
+	// The order is only paid while it is still claimed for this charge and
+	// still holds its tickets; the expiry job may have released both
+	paid, err := s.orderRepo.WithTx(tx).TransitionStatus(order.ID, models.OrderStatusPaying, models.OrderStatusPaid)
+	if err != nil {
+		return nil, apperrors.Internal("failed to close order")
+	}
+	if !paid {
+		return nil, apperrors.Conflict("order was released while it was being paid")
+	}
+
+	ids := make([]uint, len(tickets))
+	for i := range tickets {
+		ids[i] = tickets[i].ID
+	}
+	sold, err := ticketRepo.SellHeld(ids)
+	if err != nil {
+		return nil, apperrors.Internal("failed to update ticket status")
+	}
+	if sold != int64(len(ids)) {
+		return nil, apperrors.Conflict("order's tickets were released while it was being paid")
+	}
+
+	var purchasedTickets []PurchasedTicketInfo
+	var purchasedTicketIDs []uint
+	for i := range tickets {
+		ticket := &tickets[i]
 		ticket.IsSold = true
-		if err := s.ticketRepo.Update(ticket); err != nil {
-			// TODO: Implement rollback mechanism
-			return nil, errors.New("failed to update ticket status")
-		}
+		ticket.IsHeld = false
 
 		// Create purchased ticket record
 		purchasedTicket := &models.PurchasedTicket{
-			Price:       ticket.Price,
+			Price:       prices[i],
 			Type:        ticket.Type,
 			IsVip:       ticket.IsVip,
 			Title:       ticket.Title,
 			Description: ticket.Description,
 			Place:       ticket.Place,
-			UserID:      req.UserID,
+			UserID:      ownerID,
 			TicketID:    ticket.ID,
+			PaymentID:   &paymentResponse.PaymentID,
+
+			IsAccessible:         ticket.IsAccessible,
+			AccommodationRequest: req.AccommodationRequest,
 		}
 
-		if err := s.purchasedTicketRepo.Create(purchasedTicket); err != nil {
-			// TODO: Implement rollback mechanism
-			return nil, errors.New("failed to create purchased ticket record")
+		if err := purchasedTicketRepo.Create(purchasedTicket); err != nil {
+			return nil, apperrors.Internal("failed to create purchased ticket record")
 		}
 
+		purchasedTicketIDs = append(purchasedTicketIDs, purchasedTicket.ID)
 		purchasedTickets = append(purchasedTickets, PurchasedTicketInfo{
 			ID:          purchasedTicket.ID,
 			TicketID:    ticket.ID,
 			Title:       ticket.Title,
 			Description: ticket.Description,
 			Place:       ticket.Place,
-			Price:       ticket.Price,
+			Price:       prices[i],
 			EventTitle:  event.Title,
 			EventDate:   event.Date,
 			EventID:     event.ID, // Add this line
 			IsUsed:      false,
+			PurchasedAt: purchasedTicket.PurchasedAt,
 		})
 	}
 
-	return &PurchaseTicketResponse{
+	if buyer != nil {
+		if err := s.recordGift(tx, event, buyer, recipient, req, purchasedTicketIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	completedAt := time.Now().Unix()
+	order.Status = models.OrderStatusPaid
+	order.CompletedAt = &completedAt
+	if err := s.orderRepo.WithTx(tx).Update(order); err != nil {
+		return nil, apperrors.Internal("failed to close order")
+	}
+
+	if err := s.enqueueTicketSold(tx, event, req.UserID, paymentResponse, purchasedTicketIDs, totalAmount); err != nil {
+		return nil, err
+	}
+
+	return purchasedTickets, nil
+}
+
+func (s *TicketService) purchaseResponse(
+	req *PurchaseTicketFromGroupRequest,
+	buyer, recipient *models.User,
+	purchasedTickets []PurchasedTicketInfo,
+	prices []float64,
+	paymentResponse *PaymentResponse,
+) *PurchaseTicketResponse {
+	totalAmount := 0.0
+	for _, price := range prices {
+		totalAmount += price
+	}
+
+	response := &PurchaseTicketResponse{
 		PurchasedTickets: purchasedTickets,
 		PaymentInfo:      paymentResponse,
 		TotalAmount:      totalAmount,
-	}, nil
+	}
+
+	if buyer != nil {
+		status := models.GiftStatusPending
+		if recipient != nil {
+			status = models.GiftStatusDelivered
+		}
+		response.Gift = &GiftInfo{
+			RecipientEmail: req.GiftRecipientEmail,
+			Message:        req.GiftMessage,
+			Status:         status,
+		}
+	}
+
+	return response
+}
+
+// newOrder keeps a group purchase request on an order, so it can be
+// finished later exactly as requested
+func newOrder(req *PurchaseTicketFromGroupRequest, totalAmount float64, allocation *models.SaleAllocation) *models.Order {
+	order := &models.Order{
+		UserID:      req.UserID,
+		EventID:     req.EventID,
+		SaleID:      req.SaleID,
+		GroupID:     req.GroupID,
+		Price:       req.Price,
+		Type:        req.Type,
+		IsVip:       req.IsVip,
+		Title:       req.Title,
+		Place:       req.Place,
+		Quantity:    req.Quantity,
+		TotalAmount: totalAmount,
+		GiftEmail:   req.GiftRecipientEmail,
+		GiftMessage: req.GiftMessage,
+		Attempts:    1,
+		CreatedAt:   time.Now().Unix(),
+
+		AccommodationRequest: req.AccommodationRequest,
+
+		ClientIP:          req.ClientIP,
+		DeviceFingerprint: req.DeviceFingerprint,
+	}
+	if allocation != nil {
+		order.AllocationID = &allocation.ID
+	}
+	return order
+}
+
+// holdOrder records order within tx and marks its tickets held
+func (s *TicketService) holdOrder(tx *gorm.DB, order *models.Order, tickets []models.Ticket, prices []float64) error {
+	for i := range tickets {
+		order.Items = append(order.Items, models.OrderItem{
+			TicketID: tickets[i].ID,
+			Price:    prices[i],
+		})
+	}
+
+	ticketRepo := s.ticketRepo.WithTx(tx)
+	for i := range tickets {
+		tickets[i].IsHeld = true
+		if err := ticketRepo.Update(&tickets[i]); err != nil {
+			return err
+		}
+	}
+	return s.orderRepo.WithTx(tx).Create(order)
+}
+
+// moveOrder moves a held order from its current status to status and saves
+// it with the changes apply makes, if any. It fails with a conflict when the
+// order has left its status in the meantime.
+func (s *TicketService) moveOrder(order *models.Order, status models.OrderStatus, apply func()) error {
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		orderRepo := s.orderRepo.WithTx(tx)
+		moved, err := orderRepo.TransitionStatus(order.ID, order.Status, status)
+		if err != nil {
+			return apperrors.Internal("failed to update order")
+		}
+		if !moved {
+			return apperrors.Conflict("order was paid, reviewed or released meanwhile")
+		}
+
+		order.Status = status
+		if apply != nil {
+			apply()
+		}
+		if err := orderRepo.Update(order); err != nil {
+			return apperrors.Internal("failed to update order")
+		}
+		return nil
+	})
+}
+
+// reopenOrder puts an order whose charge didn't go through back to awaiting
+// payment, so the buyer can try again while the hold lasts. Orders the expiry
+// job released meanwhile stay released.
+func (s *TicketService) reopenOrder(order *models.Order) {
+	reopened, err := s.orderRepo.TransitionStatus(order.ID, models.OrderStatusPaying, models.OrderStatusAwaitingPayment)
+	if err != nil {
+		log.Printf("Failed to reopen order %d: %v", order.ID, err)
+	}
+	if reopened {
+		order.Status = models.OrderStatusAwaitingPayment
+	}
+}
+
+// RetryOrderPayment charges an order awaiting payment again, with the same
+// or a different payment method, while its tickets are still held
+func (s *TicketService) RetryOrderPayment(ctx context.Context, orderID, userID uint, req *RetryPaymentRequest) (*PurchaseTicketResponse, error) {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+
+	if order.UserID != userID {
+		return nil, apperrors.Forbidden("unauthorized to pay for this order")
+	}
+
+	if order.Status != models.OrderStatusAwaitingPayment {
+		return nil, ErrOrderNotPayable
+	}
+
+	if time.Now().Unix() > order.HoldExpiresAt {
+		err := s.expireOrder(order)
+		if apperrors.CodeOf(err) == apperrors.CodeConflict {
+			return nil, ErrOrderNotPayable
+		}
+		if err != nil {
+			return nil, apperrors.Internal("failed to release expired order")
+		}
+		return nil, ErrPaymentWindowExpired
+	}
+
+	// The tickets are only sold while the event and its sale still are
+	sale, err := s.saleRepo.GetByID(order.SaleID)
+	if err != nil {
+		return nil, apperrors.NotFound("sale not found")
+	}
+	now := time.Now().Unix()
+	if now < sale.StartDate || now > sale.EndDate {
+		return nil, ErrSaleNotActive
+	}
+
+	event, err := s.eventRepo.GetByID(order.EventID)
+	if err != nil {
+		return nil, apperrors.NotFound("event not found")
+	}
+	if !eventOnSale(event, now) {
+		return nil, ErrEventNotOnSale
+	}
+
+	purchaseReq := &PurchaseTicketFromGroupRequest{
+		UserID:             order.UserID,
+		EventID:            order.EventID,
+		GroupID:            order.GroupID,
+		Price:              order.Price,
+		Type:               order.Type,
+		IsVip:              order.IsVip,
+		Title:              order.Title,
+		Place:              order.Place,
+		SaleID:             order.SaleID,
+		Quantity:           order.Quantity,
+		GiftRecipientEmail: order.GiftEmail,
+		GiftMessage:        order.GiftMessage,
+
+		AccommodationRequest: order.AccommodationRequest,
+	}
+
+	buyer, recipient, err := s.resolveGift(purchaseReq)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentReq := &PaymentRequest{
+		UserID:        order.UserID,
+		UserType:      models.UserTypeUser,
+		Amount:        order.TotalAmount,
+		PaymentMethod: req.PaymentMethod,
+		Description:   "Ticket purchase for " + order.Title + " - " + event.Title,
+		EventID:       order.EventID,
+
+		PaymentMethodID:  req.PaymentMethodID,
+		UseDefaultMethod: req.UseDefaultPaymentMethod,
+
+		ClientIP:          req.ClientIP,
+		DeviceFingerprint: req.DeviceFingerprint,
+	}
+
+	// Orders an admin approved aren't checked again
+	if order.ReviewedAt == nil {
+		screening, err := s.screenPurchase(ctx, paymentReq, order.Quantity, order.GiftEmail)
+		if err != nil {
+			return nil, err
+		}
+		switch screening.Decision {
+		case fraud.DecisionDecline:
+			return nil, ErrPurchaseDeclined
+		case fraud.DecisionReview:
+			if err := s.moveOrder(order, models.OrderStatusUnderReview, func() {
+				order.FraudReasons = strings.Join(screening.Reasons, "; ")
+				order.HoldExpiresAt = time.Now().Add(s.reviewWindow).Unix()
+			}); err != nil {
+				return nil, err
+			}
+			return &PurchaseTicketResponse{
+				TotalAmount:  order.TotalAmount,
+				PendingOrder: order,
+			}, nil
+		}
+	}
+
+	// Claim the order for this charge, so a concurrent retry or the expiry
+	// job can't take it while the provider is being called
+	claimed, err := s.orderRepo.TransitionStatus(order.ID, models.OrderStatusAwaitingPayment, models.OrderStatusPaying)
+	if err != nil {
+		return nil, apperrors.Internal("failed to claim order")
+	}
+	if !claimed {
+		return nil, ErrOrderNotPayable
+	}
+	order.Status = models.OrderStatusPaying
+
+	paymentResponse, err := s.paymentService.ProcessPayment(ctx, paymentReq)
+	if err != nil {
+		s.reopenOrder(order)
+		return nil, fmt.Errorf("payment processing failed: %w", err)
+	}
+
+	order.Attempts++
+	order.LastPaymentID = &paymentResponse.PaymentID
+
+	if paymentResponse.Status != models.PaymentStatusCompleted {
+		if err := s.moveOrder(order, models.OrderStatusAwaitingPayment, nil); err != nil {
+			return nil, err
+		}
+		return &PurchaseTicketResponse{
+			PaymentInfo:  paymentResponse,
+			TotalAmount:  order.TotalAmount,
+			PendingOrder: order,
+		}, nil
+	}
+
+	tickets := make([]models.Ticket, len(order.Items))
+	prices := make([]float64, len(order.Items))
+	for i, item := range order.Items {
+		tickets[i] = item.Ticket
+		prices[i] = item.Price
+	}
+
+	resp, err := s.completePurchase(ctx, event, purchaseReq, buyer, recipient, tickets, prices, paymentResponse, order)
+	if err != nil {
+		s.reopenOrder(order)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *TicketService) GetOrder(orderID, userID uint) (*models.Order, error) {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil {
+		return nil, apperrors.NotFound("order not found")
+	}
+
+	if order.UserID != userID {
+		return nil, apperrors.Forbidden("unauthorized to view this order")
+	}
+
+	return order, nil
+}
+
+// ExpireHeldOrders releases the tickets of orders whose payment grace window
+// has passed
+func (s *TicketService) ExpireHeldOrders(limit int) (int, error) {
+	orders, err := s.orderRepo.ListExpired(time.Now().Unix(), limit)
+	if err != nil {
+		return 0, apperrors.Internal("failed to load expired orders")
+	}
+
+	expired := 0
+	for i := range orders {
+		err := s.expireOrder(&orders[i])
+		if apperrors.CodeOf(err) == apperrors.CodeConflict {
+			// Paid or reviewed since it was listed
+			continue
+		}
+		if err != nil {
+			return expired, apperrors.Internal("failed to expire order")
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// ClearOrderClientInfo blanks the IP and device fingerprint of orders
+// older than retention
+func (s *TicketService) ClearOrderClientInfo(retention time.Duration) (int64, error) {
+	cleared, err := s.orderRepo.ClearClientInfoBefore(time.Now().Add(-retention).Unix())
+	if err != nil {
+		return 0, apperrors.Internal("failed to clear order client info")
+	}
+	return cleared, nil
+}
+
+func (s *TicketService) expireOrder(order *models.Order) error {
+	return s.releaseOrder(order, models.OrderStatusExpired, nil)
+}
+
+// releaseOrder closes a held order with status and releases its tickets.
+// within, if set, runs in the same transaction. It fails with a conflict
+// when the order has left the status it was loaded with in the meantime.
+func (s *TicketService) releaseOrder(order *models.Order, status models.OrderStatus, within func(tx *gorm.DB) error) error {
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		orderRepo := s.orderRepo.WithTx(tx)
+		closed, err := orderRepo.TransitionStatus(order.ID, order.Status, status)
+		if err != nil {
+			return err
+		}
+		if !closed {
+			return apperrors.Conflict("order was paid, reviewed or released meanwhile")
+		}
+
+		ids := make([]uint, len(order.Items))
+		for i := range order.Items {
+			ids[i] = order.Items[i].TicketID
+		}
+		if err := s.ticketRepo.WithTx(tx).ReleaseHeld(ids); err != nil {
+			return err
+		}
+
+		order.Status = status
+		if err := orderRepo.Update(order); err != nil {
+			return err
+		}
+		if within != nil {
+			return within(tx)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if order.AllocationID != nil {
+		return s.saleRepo.ReleaseAllocation(*order.AllocationID, order.Quantity)
+	}
+	return nil
+}
+
+// screenPurchase runs a purchase past the fraud checks before it is charged
+func (s *TicketService) screenPurchase(ctx context.Context, paymentReq *PaymentRequest, quantity int, giftEmail string) (*fraud.Result, error) {
+	buyer, err := s.userRepo.GetByID(paymentReq.UserID)
+	if err != nil {
+		return nil, apperrors.NotFound("buyer not found")
+	}
+
+	methods, err := s.paymentService.StoredMethods(paymentReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.screener.Check(ctx, &fraud.Purchase{
+		UserID:             buyer.ID,
+		Email:              buyer.Email,
+		EventID:            paymentReq.EventID,
+		Amount:             paymentReq.Amount,
+		Quantity:           quantity,
+		ClientIP:           paymentReq.ClientIP,
+		DeviceFingerprint:  paymentReq.DeviceFingerprint,
+		GiftRecipientEmail: giftEmail,
+		PaymentMethods:     methods,
+	}), nil
+}
+
+// recordGift stores a gift record per ticket and queues the recipient's
+// notification (an invitation if they have no account yet)
+func (s *TicketService) recordGift(tx *gorm.DB, event *models.Event, buyer, recipient *models.User, req *PurchaseTicketFromGroupRequest, purchasedTicketIDs []uint) error {
+	giftRepo := s.giftRepo.WithTx(tx)
+	now := time.Now().Unix()
+	senderName := buyer.Name + " " + buyer.Surname
+	locale := buyer.Locale
+	if recipient != nil {
+		locale = recipient.Locale
+	}
+
+	for _, purchasedTicketID := range purchasedTicketIDs {
+		gift := &models.TicketGift{
+			PurchasedTicketID: purchasedTicketID,
+			SenderID:          buyer.ID,
+			SenderName:        senderName,
+			RecipientEmail:    req.GiftRecipientEmail,
+			Message:           req.GiftMessage,
+			Status:            models.GiftStatusPending,
+			CreatedAt:         now,
+		}
+		if recipient != nil {
+			gift.RecipientID = &recipient.ID
+			gift.Status = models.GiftStatusDelivered
+			gift.DeliveredAt = &now
+		}
+
+		if err := giftRepo.Create(gift); err != nil {
+			return apperrors.Internal("failed to record gift")
+		}
+	}
+
+	message, err := outbox.NewMessage(models.OutboxTopicTicketGifted, outbox.TicketGiftedPayload{
+		EventID:            event.ID,
+		EventTitle:         event.Title,
+		SenderID:           buyer.ID,
+		SenderName:         senderName,
+		RecipientEmail:     req.GiftRecipientEmail,
+		Invitation:         recipient == nil,
+		Message:            req.GiftMessage,
+		PurchasedTicketIDs: purchasedTicketIDs,
+		Locale:             locale,
+	})
+	if err != nil {
+		return apperrors.Internal("failed to build gift notification")
+	}
+
+	if err := s.outboxRepo.WithTx(tx).Create(message); err != nil {
+		return apperrors.Internal("failed to queue gift notification")
+	}
+
+	return nil
+}
+
+// checkPurchaseRight explains why the user may not buy in a lottery sale,
+// if they may not
+func (s *TicketService) checkPurchaseRight(sale *models.Sale, userID uint, now int64) error {
+	if sale.LotteryDrawnAt == nil {
+		return apperrors.New(apperrors.CodeNoPurchaseRight, "the lottery has not been drawn yet")
+	}
+
+	entry, err := s.lotteryRepo.GetBySaleAndUser(sale.ID, userID)
+	if err != nil {
+		return ErrNoPurchaseRight
+	}
+	switch entry.Status {
+	case models.LotteryEntryStatusWon:
+		if entry.PurchaseBy != nil && *entry.PurchaseBy < now {
+			return apperrors.New(apperrors.CodeNoPurchaseRight, "your right to buy in this lottery has expired")
+		}
+		return nil
+	case models.LotteryEntryStatusPurchased:
+		return apperrors.New(apperrors.CodeNoPurchaseRight, "you have already bought with your lottery win")
+	}
+	return ErrNoPurchaseRight
+}
+
+// claimPurchaseRight uses up the user's lottery win along with the
+// purchase, so a win buys only once even with concurrent requests
+func (s *TicketService) claimPurchaseRight(tx *gorm.DB, saleID, userID uint, now int64) error {
+	claimed, err := s.lotteryRepo.WithTx(tx).ClaimPurchase(saleID, userID, now)
+	if err != nil {
+		return apperrors.Internal("failed to check lottery entry")
+	}
+	if !claimed {
+		return ErrNoPurchaseRight
+	}
+	return nil
+}
+
+// releaseSaleAllocation gives back a reservation made within tx when the
+// transaction commits without selling the tickets
+func (s *TicketService) releaseSaleAllocation(tx *gorm.DB, allocation *models.SaleAllocation, quantity int) error {
+	if allocation == nil {
+		return nil
+	}
+	if err := s.saleRepo.WithTx(tx).ReleaseAllocation(allocation.ID, quantity); err != nil {
+		return apperrors.Internal("failed to release sale allocation")
+	}
+	return nil
+}
+
+// reserveSaleAllocation counts quantity against the sale's cap for the ticket
+// group within tx. It returns nil when the sale has no cap for the group.
+func (s *TicketService) reserveSaleAllocation(tx *gorm.DB, saleID, groupID uint, quantity int) (*models.SaleAllocation, error) {
+	saleRepo := s.saleRepo.WithTx(tx)
+	allocation, err := saleRepo.FindAllocation(saleID, groupID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, apperrors.Internal("failed to check sale allocation")
+	}
+
+	reserved, err := saleRepo.ReserveAllocation(allocation.ID, quantity)
+	if err != nil {
+		return nil, apperrors.Internal("failed to reserve sale allocation")
+	}
+	if !reserved {
+		return nil, ErrSaleAllocationSold
+	}
+
+	return allocation, nil
+}
+
+// enqueueTicketSold records the ticket.sold notification in the purchase transaction
+func (s *TicketService) enqueueTicketSold(tx *gorm.DB, event *models.Event, userID uint, payment *PaymentResponse, purchasedTicketIDs []uint, totalAmount float64) error {
+	message, err := outbox.NewMessage(models.OutboxTopicTicketSold, outbox.TicketSoldPayload{
+		EventID:            event.ID,
+		SellerID:           event.SellerID,
+		UserID:             userID,
+		PaymentID:          payment.PaymentID,
+		PurchasedTicketIDs: purchasedTicketIDs,
+		TotalAmount:        totalAmount,
+	})
+	if err != nil {
+		return apperrors.Internal("failed to build purchase notification")
+	}
+
+	if err := s.outboxRepo.WithTx(tx).Create(message); err != nil {
+		return apperrors.Internal("failed to queue purchase notification")
+	}
+
+	return nil
 }
 
 // Existing methods...
@@ -216,99 +1241,150 @@ func (s *TicketService) CreateTickets(req *CreateTicketRequest, sellerID uint) e
 	// Verify event exists and belongs to seller
 	event, err := s.eventRepo.GetByID(req.EventID)
 	if err != nil {
-		return errors.New("event not found")
+		return apperrors.NotFound("event not found")
 	}
-	if event.SellerID != sellerID {
-		return errors.New("unauthorized to create tickets for this event")
+	if !s.access.Allowed(event, sellerID, models.EventPermissionManageTickets) {
+		return apperrors.Forbidden("unauthorized to create tickets for this event")
 	}
 
 	// Verify sale exists and belongs to this event
 	sale, err := s.saleRepo.GetByID(req.SaleID)
 	if err != nil {
-		return errors.New("sale not found")
+		return apperrors.NotFound("sale not found")
 	}
 	if sale.EventID != req.EventID {
-		return errors.New("sale does not belong to this event")
+		return apperrors.Validation("sale does not belong to this event")
 	}
 
-	// Create the specified amount of tickets
-	for i := 0; i < req.Amount; i++ {
-		ticket := &models.Ticket{
-			Price:       req.Price,
-			Type:        req.Type,
-			IsVip:       req.IsVip,
-			Title:       req.Title,
-			Description: req.Description,
-			Place:       req.Place,
-			SaleID:      req.SaleID,
-			EventID:     req.EventID,
-			IsSold:      false,
-			IsHeld:      false,
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		// Tickets with the same details as an existing group add to its stock
+		ticketGroupRepo := s.ticketGroupRepo.WithTx(tx)
+		group, err := ticketGroupRepo.FindByDetails(req.EventID, req.SaleID, req.Price, req.Type, req.IsVip, req.Title, req.Place)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			group = &models.TicketGroup{
+				EventID:     req.EventID,
+				SaleID:      req.SaleID,
+				Price:       req.Price,
+				Type:        req.Type,
+				IsVip:       req.IsVip,
+				Title:       req.Title,
+				Description: req.Description,
+				Place:       req.Place,
+				CreatedAt:   time.Now().Unix(),
+
+				IsAccessible: req.IsAccessible,
+			}
+			err = ticketGroupRepo.Create(group)
+		}
+		if err != nil {
+			return err
 		}
 
-		if err := s.ticketRepo.Create(ticket); err != nil {
-			return errors.New("failed to create tickets")
+		// Create the specified amount of tickets
+		tickets := make([]models.Ticket, req.Amount)
+		for i := range tickets {
+			tickets[i] = models.Ticket{
+				GroupID:     group.ID,
+				Price:       group.Price,
+				Type:        group.Type,
+				IsVip:       group.IsVip,
+				Title:       group.Title,
+				Description: group.Description,
+				Place:       group.Place,
+				SaleID:      group.SaleID,
+				EventID:     group.EventID,
+				IsSold:      false,
+				IsHeld:      false,
+
+				IsAccessible: group.IsAccessible,
+			}
 		}
+
+		return s.ticketRepo.WithTx(tx).CreateWithinCapacity(req.EventID, tickets)
+	})
+	if errors.Is(err, repositories.ErrCapacityExceeded) {
+		return apperrors.Validationf("creating %d tickets would exceed the event capacity of %d", req.Amount, event.Capacity)
+	}
+	if err != nil {
+		return apperrors.Internal("failed to create tickets")
 	}
 
 	return nil
 }
 
+// UpdateTickets edits a ticket group. The group's unsold tickets take the
+// new details; sold tickets keep the ones they were bought with.
 func (s *TicketService) UpdateTickets(eventID uint, sellerID uint, oldTicket GroupedTicket, req *UpdateTicketRequest) error {
 	// Verify event belongs to seller
 	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
-		return errors.New("event not found")
+		return apperrors.NotFound("event not found")
 	}
-	if event.SellerID != sellerID {
-		return errors.New("unauthorized to update tickets for this event")
+	if !s.access.Allowed(event, sellerID, models.EventPermissionManageTickets) {
+		return apperrors.Forbidden("unauthorized to update tickets for this event")
 	}
 
-	// Find all tickets matching the old criteria (unsold only)
-	tickets, err := s.ticketRepo.ListByGroupCriteria(eventID, oldTicket.Price, oldTicket.Type, oldTicket.IsVip, oldTicket.Title, oldTicket.Place, oldTicket.SaleID, false)
+	group, err := s.FindGroup(eventID, oldTicket)
 	if err != nil {
-		return errors.New("failed to find tickets to update")
+		return err
 	}
 
-	if len(tickets) == 0 {
-		return errors.New("no unsold tickets found matching criteria")
+	unsold, err := s.ticketRepo.ListByGroup(group.ID, false)
+	if err != nil {
+		return apperrors.Internal("failed to find tickets to update")
 	}
 
-	// Update each ticket
-	for _, ticket := range tickets {
-		if req.Price != nil {
-			ticket.Price = *req.Price
-		}
-		if req.Type != nil {
-			ticket.Type = *req.Type
-		}
-		if req.IsVip != nil {
-			ticket.IsVip = *req.IsVip
-		}
-		if req.Title != nil {
-			ticket.Title = *req.Title
-		}
-		if req.Description != nil {
-			ticket.Description = *req.Description
-		}
-		if req.Place != nil {
-			ticket.Place = *req.Place
+	if len(unsold) == 0 {
+		return apperrors.Validation("no unsold tickets found matching criteria")
+	}
+
+	if req.Price != nil {
+		group.Price = *req.Price
+	}
+	if req.Type != nil {
+		group.Type = *req.Type
+	}
+	if req.IsVip != nil {
+		group.IsVip = *req.IsVip
+	}
+	if req.Title != nil {
+		group.Title = *req.Title
+	}
+	if req.Description != nil {
+		group.Description = *req.Description
+	}
+	if req.Place != nil {
+		group.Place = *req.Place
+	}
+	if req.IsAccessible != nil {
+		group.IsAccessible = *req.IsAccessible
+	}
+	if req.SaleID != nil {
+		// Verify new sale belongs to this event
+		sale, err := s.saleRepo.GetByID(*req.SaleID)
+		if err != nil {
+			return apperrors.NotFound("sale not found")
 		}
-		if req.SaleID != nil {
-			// Verify new sale belongs to this event
-			sale, err := s.saleRepo.GetByID(*req.SaleID)
-			if err != nil {
-				return errors.New("sale not found")
-			}
-			if sale.EventID != eventID {
-				return errors.New("sale does not belong to this event")
-			}
-			ticket.SaleID = *req.SaleID
+		if sale.EventID != eventID {
+			return apperrors.Validation("sale does not belong to this event")
 		}
+		group.SaleID = *req.SaleID
+	}
 
-		if err := s.ticketRepo.Update(&ticket); err != nil {
-			return errors.New("failed to update tickets")
+	// Older clients find groups by their details, which must stay unique
+	existing, err := s.ticketGroupRepo.FindByDetails(eventID, group.SaleID, group.Price, group.Type, group.IsVip, group.Title, group.Place)
+	if err == nil && existing.ID != group.ID {
+		return apperrors.Conflict("another ticket group already has these details")
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.ticketGroupRepo.WithTx(tx).Update(group); err != nil {
+			return err
 		}
+		return s.ticketRepo.WithTx(tx).SyncUnsoldWithGroup(group)
+	})
+	if err != nil {
+		return apperrors.Internal("failed to update tickets")
 	}
 
 	return nil
@@ -318,159 +1394,418 @@ func (s *TicketService) DeleteTickets(eventID uint, sellerID uint, groupedTicket
 	// Verify event belongs to seller
 	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
-		return errors.New("event not found")
+		return apperrors.NotFound("event not found")
 	}
-	if event.SellerID != sellerID {
-		return errors.New("unauthorized to delete tickets for this event")
+	if !s.access.Allowed(event, sellerID, models.EventPermissionManageTickets) {
+		return apperrors.Forbidden("unauthorized to delete tickets for this event")
 	}
 
-	// Find all tickets matching the criteria (unsold only)
-	tickets, err := s.ticketRepo.ListByGroupCriteria(eventID, groupedTicket.Price, groupedTicket.Type, groupedTicket.IsVip, groupedTicket.Title, groupedTicket.Place, groupedTicket.SaleID, false)
+	group, err := s.FindGroup(eventID, groupedTicket)
 	if err != nil {
-		return errors.New("failed to find tickets to delete")
+		return err
+	}
+
+	// Find all tickets of the group (unsold only)
+	tickets, err := s.ticketRepo.ListByGroup(group.ID, false)
+	if err != nil {
+		return apperrors.Internal("failed to find tickets to delete")
 	}
 
 	if len(tickets) == 0 {
-		return errors.New("no unsold tickets found matching criteria")
+		return apperrors.Validation("no unsold tickets found matching criteria")
 	}
 
 	// Delete each ticket
 	for _, ticket := range tickets {
 		if err := s.ticketRepo.Delete(ticket.ID); err != nil {
-			return errors.New("failed to delete tickets")
+			return apperrors.Internal("failed to delete tickets")
 		}
 	}
 
 	return nil
 }
 
-func (s *TicketService) GetGroupedTicketsByEvent(eventID uint) ([]GroupedTicket, error) {
-	groupedTickets, err := s.ticketRepo.ListGroupedByEvent(eventID)
+// AdjustTicketQuantity changes a ticket group's stock. Removals take only
+// tickets that are neither sold nor held by a pending order, and either all
+// of them are removed or none are.
+func (s *TicketService) AdjustTicketQuantity(groupID, sellerID uint, req *AdjustTicketQuantityRequest) (*TicketQuantityResponse, error) {
+	group, err := s.ticketGroupRepo.GetByID(groupID)
+	if err != nil {
+		return nil, apperrors.NotFound("ticket group not found")
+	}
+
+	event, err := s.eventRepo.GetByID(group.EventID)
+	if err != nil {
+		return nil, apperrors.NotFound("event not found")
+	}
+	if !s.access.Allowed(event, sellerID, models.EventPermissionManageTickets) {
+		return nil, apperrors.Forbidden("unauthorized to change tickets for this event")
+	}
+
+	var notEnough bool
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		ticketRepo := s.ticketRepo.WithTx(tx)
+
+		if req.Change > 0 {
+			tickets := make([]models.Ticket, req.Change)
+			for i := range tickets {
+				tickets[i] = models.Ticket{
+					GroupID:     group.ID,
+					Price:       group.Price,
+					Type:        group.Type,
+					IsVip:       group.IsVip,
+					Title:       group.Title,
+					Description: group.Description,
+					Place:       group.Place,
+					SaleID:      group.SaleID,
+					EventID:     group.EventID,
+
+					IsAccessible: group.IsAccessible,
+				}
+			}
+			return ticketRepo.CreateWithinCapacity(group.EventID, tickets)
+		}
+
+		remove := -req.Change
+		tickets, err := ticketRepo.LockAvailableByGroup(group.ID, remove)
+		if err != nil {
+			return err
+		}
+		if len(tickets) < remove {
+			notEnough = true
+			return apperrors.Validation("not enough available tickets")
+		}
+
+		ids := make([]uint, len(tickets))
+		for i, ticket := range tickets {
+			ids[i] = ticket.ID
+		}
+		return ticketRepo.DeleteByIDs(ids)
+	})
+	if errors.Is(err, repositories.ErrCapacityExceeded) {
+		return nil, apperrors.Validationf("adding %d tickets would exceed the event capacity of %d", req.Change, event.Capacity)
+	}
+	if notEnough {
+		return nil, apperrors.Validationf("fewer than %d unsold tickets are available to remove", -req.Change)
+	}
 	if err != nil {
-		return nil, errors.New("failed to retrieve grouped tickets")
+		return nil, apperrors.Internal("failed to change ticket quantity")
 	}
 
-	return groupedTickets, nil
+	total, err := s.ticketRepo.CountByGroup(group.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count tickets")
+	}
+	sold, err := s.ticketRepo.CountSoldByGroup(group.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count tickets")
+	}
+	available, err := s.ticketRepo.ListByGroup(group.ID, false)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count tickets")
+	}
+
+	unheld := int64(0)
+	for _, ticket := range available {
+		if !ticket.IsHeld {
+			unheld++
+		}
+	}
+
+	return &TicketQuantityResponse{
+		GroupID:   group.ID,
+		Total:     total,
+		Sold:      sold,
+		Available: unheld,
+	}, nil
 }
 
-func (s *TicketService) GetAvailableGroupedTicketsByEvent(eventID uint) ([]GroupedTicket, error) {
-	groupedTickets, err := s.ticketRepo.ListAvailableGroupedByEvent(eventID)
+// MoveTicketGroup moves a group's unsold tickets into another sale of the
+// same event, e.g. what is left of an early-bird sale into the general one.
+// They join the target sale's matching group, which is created if needed.
+// Sold tickets and tickets held for a pending payment stay where they are.
+func (s *TicketService) MoveTicketGroup(groupID, sellerID uint, req *MoveTicketGroupRequest) (*TicketGroupMoveResponse, error) {
+	group, err := s.ticketGroupRepo.GetByID(groupID)
 	if err != nil {
-		return nil, errors.New("failed to retrieve available grouped tickets")
+		return nil, apperrors.NotFound("ticket group not found")
 	}
 
-	return groupedTickets, nil
+	event, err := s.eventRepo.GetByID(group.EventID)
+	if err != nil {
+		return nil, apperrors.NotFound("event not found")
+	}
+	if !s.access.Allowed(event, sellerID, models.EventPermissionManageTickets) {
+		return nil, apperrors.Forbidden("unauthorized to change tickets for this event")
+	}
+
+	if req.SaleID == group.SaleID {
+		return nil, apperrors.Validation("tickets are already in this sale")
+	}
+
+	sale, err := s.saleRepo.GetByID(req.SaleID)
+	if err != nil {
+		return nil, apperrors.NotFound("sale not found")
+	}
+	if sale.EventID != group.EventID {
+		return nil, apperrors.Validation("target sale belongs to a different event")
+	}
+	if sale.EndDate < time.Now().Unix() {
+		return nil, apperrors.Validation("cannot move tickets into a sale that has ended")
+	}
+
+	var target *models.TicketGroup
+	var moved int
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		ticketRepo := s.ticketRepo.WithTx(tx)
+		groupRepo := s.ticketGroupRepo.WithTx(tx)
+
+		// -1 lifts the limit: every unsold, unheld ticket moves
+		tickets, err := ticketRepo.LockAvailableByGroup(group.ID, -1)
+		if err != nil {
+			return err
+		}
+		if len(tickets) == 0 {
+			return apperrors.Validation("no unsold tickets to move")
+		}
+
+		target, err = groupRepo.FindByDetails(group.EventID, sale.ID, group.Price, group.Type, group.IsVip, group.Title, group.Place)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			target = &models.TicketGroup{
+				EventID:     group.EventID,
+				SaleID:      sale.ID,
+				Price:       group.Price,
+				Type:        group.Type,
+				IsVip:       group.IsVip,
+				Title:       group.Title,
+				Description: group.Description,
+				Place:       group.Place,
+				CreatedAt:   time.Now().Unix(),
+
+				IsAccessible: group.IsAccessible,
+			}
+			err = groupRepo.Create(target)
+		}
+		if err != nil {
+			return err
+		}
+
+		ids := make([]uint, len(tickets))
+		for i, ticket := range tickets {
+			ids[i] = ticket.ID
+		}
+		moved = len(ids)
+		return ticketRepo.MoveToGroup(ids, target)
+	})
+	if err != nil {
+		if _, ok := apperrors.As(err); ok {
+			return nil, err
+		}
+		return nil, apperrors.Internal("failed to move tickets")
+	}
+
+	return &TicketGroupMoveResponse{
+		FromGroupID: group.ID,
+		ToGroupID:   target.ID,
+		SaleID:      sale.ID,
+		Moved:       moved,
+	}, nil
 }
 
-// Legacy methods for backward compatibility
+func (s *TicketService) GetGroupedTicketsByEvent(eventID uint, limit, offset int) ([]GroupedTicket, int64, error) {
+	groupedTickets, err := s.ticketRepo.ListGroupedByEvent(eventID, limit, offset)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to retrieve grouped tickets")
+	}
 
-func (s *TicketService) PurchaseTicket(req *PurchaseTicketRequest) (*PurchaseTicketResponse, error) {
-	// Get ticket information with locking
-	ticket, err := s.ticketRepo.GetByIDForUpdate(req.TicketID)
+	total, err := s.ticketRepo.CountGroupedByEvent(eventID)
 	if err != nil {
-		return nil, errors.New("ticket not found")
+		return nil, 0, apperrors.Internal("failed to count grouped tickets")
 	}
 
+	if err := s.pricingService.ApplyCurrentPrices(groupedTickets); err != nil {
+		return nil, 0, err
+	}
+
+	return groupedTickets, total, nil
+}
+
+func (s *TicketService) GetAvailableGroupedTicketsByEvent(eventID uint, limit, offset int) ([]GroupedTicket, int64, error) {
+	groupedTickets, err := s.ticketRepo.ListAvailableGroupedByEvent(eventID, limit, offset)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to retrieve available grouped tickets")
+	}
+
+	total, err := s.ticketRepo.CountAvailableGroupedByEvent(eventID)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to count available grouped tickets")
+	}
+
+	if err := s.pricingService.ApplyCurrentPrices(groupedTickets); err != nil {
+		return nil, 0, err
+	}
+
+	return groupedTickets, total, nil
+}
+
+// Legacy methods for backward compatibility
+
+func (s *TicketService) PurchaseTicket(ctx context.Context, req *PurchaseTicketRequest) (resp *PurchaseTicketResponse, err error) {
+	ctx, span := tracing.StartSpan(ctx, "TicketService.PurchaseTicket",
+		attribute.Int("ticket.id", int(req.TicketID)),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	if req.PaymentMethod == models.PaymentTypeInvoice {
+		return nil, apperrors.Validation("invoice payment is only available for organization orders")
+	}
+
+	// Check if enough tickets are available (for quantity > 1, we'd need to implement bulk purchase)
+	if req.Quantity > 1 {
+		return nil, apperrors.Validation("bulk purchase not implemented for individual tickets")
+	}
+
+	ticket, err := s.ticketRepo.GetByID(req.TicketID)
+	if err != nil {
+		return nil, apperrors.NotFound("ticket not found")
+	}
 	if ticket.IsSold || ticket.IsHeld {
-		return nil, errors.New("ticket is not available")
+		return nil, ErrTicketUnavailable
 	}
 
 	// Check if sale is active
 	sale, err := s.saleRepo.GetByID(ticket.SaleID)
 	if err != nil {
-		return nil, errors.New("sale not found")
+		return nil, apperrors.NotFound("sale not found")
 	}
 
 	now := time.Now().Unix()
+	if !eventOnSale(&ticket.Event, now) {
+		return nil, ErrEventNotOnSale
+	}
 	if now < sale.StartDate || now > sale.EndDate {
-		return nil, errors.New("sale is not currently active")
+		return nil, ErrSaleNotActive
 	}
-
-	// Check if enough tickets are available (for quantity > 1, we'd need to implement bulk purchase)
-	if req.Quantity > 1 {
-		return nil, errors.New("bulk purchase not implemented for individual tickets")
+	lottery := sale.Mode == models.SaleModeLottery
+	if lottery {
+		if err := s.checkPurchaseRight(sale, req.UserID, now); err != nil {
+			return nil, err
+		}
 	}
 
-	// Calculate total amount
-	totalAmount := ticket.Price * float64(req.Quantity)
+	prices, err := s.pricingService.QuotePrices(models.GroupedTicket{
+		GroupID: ticket.GroupID,
+		EventID: ticket.EventID,
+		SaleID:  ticket.SaleID,
+		Price:   ticket.Price,
+		Type:    ticket.Type,
+		IsVip:   ticket.IsVip,
+		Title:   ticket.Title,
+		Place:   ticket.Place,
+	}, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	totalAmount := prices[0]
 
 	// Process payment
 	paymentReq := &PaymentRequest{
 		UserID:        req.UserID,
+		UserType:      models.UserTypeUser,
 		Amount:        totalAmount,
 		PaymentMethod: req.PaymentMethod,
 		Description:   "Ticket purchase for " + ticket.Title,
 		EventID:       sale.EventID,
+
+		PaymentMethodID:  req.PaymentMethodID,
+		UseDefaultMethod: req.UseDefaultPaymentMethod,
+
+		ClientIP:          req.ClientIP,
+		DeviceFingerprint: req.DeviceFingerprint,
 	}
 
-	paymentResponse, err := s.paymentService.ProcessPayment(paymentReq)
+	// Legacy purchases aren't held for review, so a review declines them too
+	screening, err := s.screenPurchase(ctx, paymentReq, req.Quantity, "")
 	if err != nil {
-		return nil, errors.New("payment processing failed: " + err.Error())
+		return nil, err
+	}
+	if screening.Decision != fraud.DecisionAllow {
+		return nil, ErrPurchaseDeclined
 	}
 
-	if paymentResponse.Status != models.PaymentStatusCompleted {
-		return nil, errors.New("payment failed: " + paymentResponse.Message)
+	// The ticket is held on an order like a group purchase, so the rest of
+	// the purchase is the same
+	groupReq := &PurchaseTicketFromGroupRequest{
+		UserID:   req.UserID,
+		EventID:  ticket.EventID,
+		GroupID:  ticket.GroupID,
+		Price:    ticket.Price,
+		Type:     ticket.Type,
+		IsVip:    ticket.IsVip,
+		Title:    ticket.Title,
+		Place:    ticket.Place,
+		SaleID:   ticket.SaleID,
+		Quantity: req.Quantity,
+
+		ClientIP:          req.ClientIP,
+		DeviceFingerprint: req.DeviceFingerprint,
+	}
+	order, tickets, err := s.holdTickets(ctx, groupReq, lottery, prices, models.OrderStatusPaying, nil, func(ticketRepo repositories.TicketRepository) ([]models.Ticket, error) {
+		locked, err := ticketRepo.GetByIDForUpdate(req.TicketID)
+		if err != nil {
+			return nil, apperrors.NotFound("ticket not found")
+		}
+		if locked.IsSold || locked.IsHeld {
+			return nil, ErrTicketUnavailable
+		}
+		return []models.Ticket{*locked}, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Mark ticket as sold
-	ticket.IsSold = true
-	if err := s.ticketRepo.Update(ticket); err != nil {
-		// TODO: Refund payment here
-		return nil, errors.New("failed to update ticket status")
+	paymentResponse, err := s.paymentService.ProcessPayment(ctx, paymentReq)
+	if err != nil {
+		s.abandonOrder(order, lottery)
+		return nil, fmt.Errorf("payment processing failed: %w", err)
 	}
+	order.LastPaymentID = &paymentResponse.PaymentID
 
-	// Create purchased ticket record
-	purchasedTicket := &models.PurchasedTicket{
-		Price:       ticket.Price,
-		Type:        ticket.Type,
-		IsVip:       ticket.IsVip,
-		Title:       ticket.Title,
-		Description: ticket.Description,
-		Place:       ticket.Place,
-		UserID:      req.UserID,
-		TicketID:    ticket.ID,
-	}
-
-	if err := s.purchasedTicketRepo.Create(purchasedTicket); err != nil {
-		return nil, errors.New("failed to create purchased ticket record")
-	}
-
-	// Get event info for response
-	event, _ := s.eventRepo.GetByID(ticket.EventID)
-	eventTitle := ""
-	eventDate := int64(0)
-	if event != nil {
-		eventTitle = event.Title
-		eventDate = event.Date
-	}
-
-	return &PurchaseTicketResponse{
-		PurchasedTickets: []PurchasedTicketInfo{
-			{
-				ID:          purchasedTicket.ID,
-				TicketID:    ticket.ID,
-				Title:       ticket.Title,
-				Description: ticket.Description,
-				Place:       ticket.Place,
-				Price:       ticket.Price,
-				EventTitle:  eventTitle,
-				EventDate:   eventDate,
-				EventID:     ticket.EventID, // Add this line
-				IsUsed:      false,
-			},
-		},
-		PaymentInfo: paymentResponse,
-		TotalAmount: totalAmount,
-	}, nil
+	// Legacy purchases aren't held for a retry either
+	if paymentResponse.Status != models.PaymentStatusCompleted {
+		s.abandonOrder(order, lottery)
+		return nil, apperrors.PaymentRequired("payment failed: " + paymentResponse.Message)
+	}
+
+	resp, err = s.completePurchase(ctx, &ticket.Event, groupReq, nil, nil, tickets, prices, paymentResponse, order)
+	if err != nil {
+		s.abandonOrder(order, lottery)
+		return nil, err
+	}
+	return resp, nil
 }
 
-func (s *TicketService) GetUserTickets(userID uint) ([]PurchasedTicketInfo, error) {
-	tickets, err := s.purchasedTicketRepo.ListByUser(userID)
+// GetUserTickets returns a page of the tickets the user holds, soonest
+// event first, or most recent first for past events
+func (s *TicketService) GetUserTickets(userID uint, filter repositories.OwnedTicketFilter, limit, offset int) ([]PurchasedTicketInfo, int64, error) {
+	if filter.When != "" && filter.When != repositories.TicketsUpcoming && filter.When != repositories.TicketsPast {
+		return nil, 0, apperrors.Validationf("unknown when: %s", filter.When)
+	}
+	filter.Now = time.Now().Unix()
+
+	tickets, err := s.purchasedTicketRepo.ListOwned(userID, filter, limit, offset)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to retrieve user tickets")
+	}
+
+	total, err := s.purchasedTicketRepo.CountOwned(userID, filter)
 	if err != nil {
-		return nil, errors.New("failed to retrieve user tickets")
+		return nil, 0, apperrors.Internal("failed to count user tickets")
 	}
 
-	var ticketInfos []PurchasedTicketInfo
+	ticketInfos := make([]PurchasedTicketInfo, 0, len(tickets))
 	for _, ticket := range tickets {
 		eventTitle := ""
 		eventDate := int64(0)
@@ -490,16 +1825,19 @@ func (s *TicketService) GetUserTickets(userID uint) ([]PurchasedTicketInfo, erro
 			EventDate:   eventDate,
 			EventID:     ticket.Ticket.EventID, // Add this line
 			IsUsed:      ticket.IsUsed,
+			PurchasedAt: ticket.PurchasedAt,
+
+			IsInvalidated: ticket.IsInvalidated,
 		})
 	}
 
-	return ticketInfos, nil
+	return ticketInfos, total, nil
 }
 
 func (s *TicketService) GetEventTickets(eventID uint) ([]models.Ticket, error) {
 	tickets, err := s.ticketRepo.ListAvailableByEvent(eventID)
 	if err != nil {
-		return nil, errors.New("failed to retrieve event tickets")
+		return nil, apperrors.Internal("failed to retrieve event tickets")
 	}
 
 	return tickets, nil
@@ -509,21 +1847,25 @@ func (s *TicketService) TransferTicket(req *TransferTicketRequest) error {
 	// Get purchased ticket
 	purchasedTicket, err := s.purchasedTicketRepo.GetByID(req.PurchasedTicketID)
 	if err != nil {
-		return errors.New("purchased ticket not found")
+		return apperrors.NotFound("purchased ticket not found")
 	}
 
 	// Check if user owns the ticket
 	if purchasedTicket.UserID != req.FromUserID {
-		return errors.New("unauthorized to transfer this ticket")
+		return apperrors.Forbidden("unauthorized to transfer this ticket")
 	}
 
 	if purchasedTicket.IsUsed {
-		return errors.New("cannot transfer used ticket")
+		return apperrors.Validation("cannot transfer used ticket")
+	}
+
+	if purchasedTicket.IsInvalidated {
+		return apperrors.Validation("cannot transfer invalidated ticket")
 	}
 
 	// Note: This is a simplified implementation
 	// The full transfer logic is now handled by TransferService
 	// This method could be deprecated in favor of TransferService.InitiateTransfer
 
-	return errors.New("use /api/v1/transfers endpoints for ticket transfers")
+	return apperrors.Validation("use /api/v1/transfers endpoints for ticket transfers")
 }
@@ -0,0 +1,117 @@
+//go:build integration
+
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"eticketing/internal/dbtest"
+	"eticketing/internal/fraud"
+	"eticketing/internal/models"
+	"eticketing/internal/payments"
+	"eticketing/internal/repositories"
+	"eticketing/internal/services"
+)
+
+// newTicketService wires the purchase path to the test database, with a
+// mock gateway that approves every charge and no fraud checks
+func newTicketService(db *gorm.DB) *services.TicketService {
+	eventRepo := repositories.NewEventRepository(db)
+	ticketRepo := repositories.NewTicketRepository(db)
+	ticketGroupRepo := repositories.NewTicketGroupRepository(db)
+	purchasedTicketRepo := repositories.NewPurchasedTicketRepository(db)
+	paymentRepo := repositories.NewPaymentRepository(db)
+	outboxRepo := repositories.NewOutboxRepository(db)
+	txManager := repositories.NewTransactionManager(db)
+	eventAccess := services.NewEventAccess(repositories.NewEventMemberRepository(db))
+
+	providers := payments.NewRegistry(payments.NewMockProvider(payments.MockGateway, payments.MockOptions{ApprovalRate: 1}))
+	paymentService := services.NewPaymentService(paymentRepo, repositories.NewPaymentMethodRepository(db), purchasedTicketRepo,
+		providers, eventRepo, eventAccess, repositories.NewSellerRepository(db), outboxRepo, txManager, true)
+	pricingService := services.NewPricingService(repositories.NewPriceTierRepository(db), ticketRepo, ticketGroupRepo,
+		eventRepo, eventAccess, txManager)
+
+	return services.NewTicketService(ticketRepo, ticketGroupRepo, purchasedTicketRepo, eventRepo, eventAccess,
+		repositories.NewSaleRepository(db), repositories.NewLotteryRepository(db), repositories.NewUserRepository(db),
+		repositories.NewGiftRepository(db), paymentService, pricingService, nil, fraud.NewScreener(),
+		repositories.NewOrderRepository(db), outboxRepo, txManager, time.Hour, time.Hour)
+}
+
+func TestConcurrentPurchasesDontOversell(t *testing.T) {
+	const (
+		capacity = 3
+		buyers   = 12
+	)
+
+	db := dbtest.Open(t)
+	seller := dbtest.CreateSeller(t, db, "seller")
+	event := dbtest.CreateEvent(t, db, seller.ID)
+	sale := dbtest.CreateSale(t, db, event.ID)
+	group, _ := dbtest.CreateGroup(t, db, sale, 10, capacity)
+
+	users := make([]*models.User, buyers)
+	for i := range users {
+		users[i] = dbtest.CreateUser(t, db, fmt.Sprintf("buyer%d", i))
+	}
+
+	ticketService := newTicketService(db)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		bought  int
+		soldOut int
+	)
+	start := make(chan struct{})
+	for _, user := range users {
+		wg.Add(1)
+		go func(userID uint) {
+			defer wg.Done()
+			<-start
+
+			resp, err := ticketService.PurchaseTicketFromGroup(context.Background(), &services.PurchaseTicketFromGroupRequest{
+				UserID:        userID,
+				EventID:       event.ID,
+				GroupID:       group.ID,
+				Quantity:      1,
+				PaymentMethod: models.PaymentTypeCard,
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case errors.Is(err, services.ErrTicketsSoldOut):
+				soldOut++
+			case err != nil:
+				t.Errorf("purchase by user %d: %v", userID, err)
+			case len(resp.PurchasedTickets) != 1:
+				t.Errorf("purchase by user %d issued %d tickets, want 1", userID, len(resp.PurchasedTickets))
+			default:
+				bought++
+			}
+		}(user.ID)
+	}
+	close(start)
+	wg.Wait()
+
+	if bought != capacity || soldOut != buyers-capacity {
+		t.Errorf("%d purchases succeeded and %d sold out, want %d and %d", bought, soldOut, capacity, buyers-capacity)
+	}
+
+	var purchased, sold, charged int64
+	db.Model(&models.PurchasedTicket{}).Count(&purchased)
+	db.Model(&models.Ticket{}).Where("group_id = ? AND is_sold = ?", group.ID, true).Count(&sold)
+	db.Model(&models.Payment{}).
+		Where("user_type = ? AND status = ?", models.UserTypeUser, models.PaymentStatusCompleted).
+		Count(&charged)
+	if purchased != capacity || sold != capacity || charged != capacity {
+		t.Errorf("%d tickets issued, %d sold and %d payments charged; want %d of each", purchased, sold, charged, capacity)
+	}
+}
@@ -0,0 +1,481 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"eticketing/internal/fraud"
+	"eticketing/internal/models"
+	"eticketing/internal/payments"
+	apperrors "eticketing/pkg/errors"
+)
+
+const (
+	buyerID  = 10
+	sellerID = 50
+)
+
+// purchaseFixture is an approved event on sale with one group of two
+// 20.00 tickets, and a buyer with a card that charges and one that doesn't
+type purchaseFixture struct {
+	ctrl            *gomock.Controller
+	events          *eventStore
+	groups          *ticketGroupStore
+	sales           *saleStore
+	tickets         *ticketStore
+	purchased       *purchasedTicketStore
+	paymentRepo     *paymentStore
+	outbox          *outboxStore
+	orders          *orderStore
+	provider        *fakeProvider
+	paymentService  *PaymentService
+	fraudCheckers   []fraud.Checker
+	purchaseRequest PurchaseTicketFromGroupRequest
+}
+
+func newPurchaseFixture(t *testing.T) *purchaseFixture {
+	now := time.Now().Unix()
+	f := &purchaseFixture{
+		ctrl: gomock.NewController(t),
+		events: &eventStore{events: map[uint]*models.Event{
+			1: {ID: 1, SellerID: sellerID, Title: "Concert", Status: models.EventStatusApproved},
+			2: {ID: 2, SellerID: sellerID, Title: "Play", Status: models.EventStatusApproved},
+		}},
+		groups: &ticketGroupStore{groups: map[uint]*models.TicketGroup{
+			1: {ID: 1, EventID: 1, SaleID: 1, Price: 20, Title: "Standing", Place: "Floor"},
+			2: {ID: 2, EventID: 2, SaleID: 2, Price: 20, Title: "Stalls", Place: "Row A"},
+		}},
+		sales: &saleStore{sales: map[uint]*models.Sale{
+			1: {ID: 1, EventID: 1, StartDate: now - 3600, EndDate: now + 3600},
+		}},
+		tickets: &ticketStore{tickets: map[uint]*models.Ticket{
+			1: {ID: 1, EventID: 1, GroupID: 1, SaleID: 1, Price: 20, Title: "Standing"},
+			2: {ID: 2, EventID: 1, GroupID: 1, SaleID: 1, Price: 20, Title: "Standing"},
+		}},
+		purchased:   &purchasedTicketStore{},
+		paymentRepo: &paymentStore{},
+		outbox:      &outboxStore{},
+		orders:      &orderStore{},
+		provider:    &fakeProvider{},
+		purchaseRequest: PurchaseTicketFromGroupRequest{
+			UserID:          buyerID,
+			EventID:         1,
+			GroupID:         1,
+			Quantity:        2,
+			PaymentMethodID: 1,
+		},
+	}
+
+	methods := &paymentMethodStore{methods: map[uint]*models.PaymentMethod{
+		1: {ID: 1, UserID: buyerID, UserType: models.UserTypeUser, Type: models.PaymentTypeCard, Provider: "fake", Token: "card"},
+		2: {ID: 2, UserID: buyerID, UserType: models.UserTypeUser, Type: models.PaymentTypeCard, Provider: "fake", Token: "decline"},
+	}}
+	f.orders.tickets = f.tickets
+	f.paymentService = NewPaymentService(f.paymentRepo.mock(f.ctrl), methods.mock(f.ctrl), f.purchased.mock(f.ctrl),
+		payments.NewRegistry(f.provider), f.events.mock(f.ctrl), nil, nil, f.outbox.mock(f.ctrl), fakeTxManager{}, false)
+	return f
+}
+
+func (f *purchaseFixture) ticketService() *TicketService {
+	users := &userStore{users: map[uint]*models.User{
+		buyerID: {ID: buyerID, Email: "buyer@example.com"},
+	}}
+	tickets, groups, events := f.tickets.mock(f.ctrl), f.groups.mock(f.ctrl), f.events.mock(f.ctrl)
+	pricing := NewPricingService((&priceTierStore{}).mock(f.ctrl), tickets, groups, events, nil, fakeTxManager{})
+
+	return NewTicketService(tickets, groups, f.purchased.mock(f.ctrl), events, nil, f.sales.mock(f.ctrl), nil,
+		users.mock(f.ctrl), (&giftStore{}).mock(f.ctrl), f.paymentService, pricing, nil,
+		fraud.NewScreener(f.fraudCheckers...), f.orders.mock(f.ctrl), f.outbox.mock(f.ctrl), fakeTxManager{},
+		time.Hour, time.Hour)
+}
+
+// withFeeRate sets the platform fee rate for the rest of the test
+func withFeeRate(t *testing.T, rate float64) {
+	previous := PlatformFeeRate()
+	SetPlatformFeeRate(rate)
+	t.Cleanup(func() { SetPlatformFeeRate(previous) })
+}
+
+type declineAll struct{}
+
+func (declineAll) Name() string { return "decline-all" }
+
+func (declineAll) Check(ctx context.Context, purchase *fraud.Purchase) (*fraud.Result, error) {
+	return &fraud.Result{Decision: fraud.DecisionDecline, Reasons: []string{"test"}}, nil
+}
+
+func TestPurchaseTicketFromGroupRefusals(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(f *purchaseFixture)
+		wantCode apperrors.Code
+	}{
+		{"missing group", func(f *purchaseFixture) { f.purchaseRequest.GroupID = 9 }, apperrors.CodeNotFound},
+		{"group of another event", func(f *purchaseFixture) { f.purchaseRequest.GroupID = 2 }, apperrors.CodeNotFound},
+		{"missing sale", func(f *purchaseFixture) { delete(f.sales.sales, 1) }, apperrors.CodeNotFound},
+		{"sale not started", func(f *purchaseFixture) { f.sales.sales[1].StartDate = time.Now().Unix() + 60 }, apperrors.CodeSaleNotActive},
+		{"sale ended", func(f *purchaseFixture) { f.sales.sales[1].EndDate = time.Now().Unix() - 60 }, apperrors.CodeSaleNotActive},
+		{"event pending approval", func(f *purchaseFixture) { f.events.events[1].Status = models.EventStatusPending }, apperrors.CodeEventNotOnSale},
+		{"event suspended", func(f *purchaseFixture) { f.events.events[1].Status = models.EventStatusSuspended }, apperrors.CodeEventNotOnSale},
+		{"event not yet published", func(f *purchaseFixture) {
+			publishAt := time.Now().Unix() + 3600
+			f.events.events[1].PublishAt = &publishAt
+		}, apperrors.CodeEventNotOnSale},
+		{"sold out", func(f *purchaseFixture) { f.purchaseRequest.Quantity = 3 }, apperrors.CodeTicketsSoldOut},
+		{"invoice outside an organization order", func(f *purchaseFixture) {
+			f.purchaseRequest.PaymentMethodID = 0
+			f.purchaseRequest.PaymentMethod = models.PaymentTypeInvoice
+		}, apperrors.CodeValidationFailed},
+		{"declined by fraud checks", func(f *purchaseFixture) { f.fraudCheckers = []fraud.Checker{declineAll{}} }, apperrors.CodePurchaseDeclined},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPurchaseFixture(t)
+			tt.setup(f)
+
+			resp, err := f.ticketService().PurchaseTicketFromGroup(context.Background(), &f.purchaseRequest)
+			if err == nil {
+				t.Fatalf("purchase succeeded with %d tickets, want %s", len(resp.PurchasedTickets), tt.wantCode)
+			}
+			if code := apperrors.CodeOf(err); code != tt.wantCode {
+				t.Errorf("error %q has code %q, want %q", err, code, tt.wantCode)
+			}
+
+			if f.provider.charges != 0 {
+				t.Errorf("%d charges made, want none", f.provider.charges)
+			}
+			if len(f.purchased.tickets) != 0 {
+				t.Errorf("%d tickets issued, want none", len(f.purchased.tickets))
+			}
+			for _, ticket := range f.tickets.tickets {
+				if ticket.IsHeld {
+					t.Errorf("ticket %d left held", ticket.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestPurchaseTicketFromGroup(t *testing.T) {
+	withFeeRate(t, 0.1)
+	f := newPurchaseFixture(t)
+
+	resp, err := f.ticketService().PurchaseTicketFromGroup(context.Background(), &f.purchaseRequest)
+	if err != nil {
+		t.Fatalf("PurchaseTicketFromGroup: %v", err)
+	}
+
+	if resp.TotalAmount != 40 || resp.PaymentInfo.Status != models.PaymentStatusCompleted {
+		t.Errorf("charged %.2f with status %d, want 40.00 completed", resp.TotalAmount, resp.PaymentInfo.Status)
+	}
+	if len(resp.PurchasedTickets) != 2 {
+		t.Fatalf("%d tickets issued, want 2", len(resp.PurchasedTickets))
+	}
+	for _, ticket := range f.tickets.tickets {
+		if !ticket.IsSold {
+			t.Errorf("ticket %d isn't marked sold", ticket.ID)
+		}
+	}
+	for _, ticket := range f.purchased.tickets {
+		if ticket.UserID != buyerID || ticket.PaymentID == nil || *ticket.PaymentID != resp.PaymentInfo.PaymentID {
+			t.Errorf("purchased ticket %d belongs to user %d via payment %v, want user %d via payment %d",
+				ticket.ID, ticket.UserID, ticket.PaymentID, buyerID, resp.PaymentInfo.PaymentID)
+		}
+	}
+
+	payment := f.paymentRepo.payments[resp.PaymentInfo.PaymentID]
+	if payment.PlatformFeeRate == nil || *payment.PlatformFeeRate != 0.1 {
+		t.Errorf("payment fee rate = %v, want 0.1", payment.PlatformFeeRate)
+	}
+	credits := f.paymentRepo.sellerPayments()
+	if len(credits) != 1 || credits[0].UserID != sellerID || roundCents(credits[0].Amount) != 36 {
+		t.Errorf("seller credits = %+v, want one of 36.00 to seller %d", credits, sellerID)
+	}
+
+	if topics := f.outbox.topics(); len(topics) != 1 || topics[0] != models.OutboxTopicTicketSold {
+		t.Errorf("outbox topics = %v, want [%s]", topics, models.OutboxTopicTicketSold)
+	}
+}
+
+func TestPurchaseTicketFromGroupDeclinedCharge(t *testing.T) {
+	f := newPurchaseFixture(t)
+	f.purchaseRequest.PaymentMethodID = 2
+
+	resp, err := f.ticketService().PurchaseTicketFromGroup(context.Background(), &f.purchaseRequest)
+	if err != nil {
+		t.Fatalf("PurchaseTicketFromGroup: %v", err)
+	}
+
+	if resp.PendingOrder == nil || resp.PendingOrder.Status != models.OrderStatusAwaitingPayment {
+		t.Fatalf("pending order = %+v, want one awaiting payment", resp.PendingOrder)
+	}
+	if resp.PaymentInfo.Status != models.PaymentStatusFailed {
+		t.Errorf("payment status = %d, want failed", resp.PaymentInfo.Status)
+	}
+	for _, ticket := range f.tickets.tickets {
+		if ticket.IsSold || !ticket.IsHeld {
+			t.Errorf("ticket %d sold=%t held=%t, want held for the retry", ticket.ID, ticket.IsSold, ticket.IsHeld)
+		}
+	}
+	if len(f.purchased.tickets) != 0 {
+		t.Errorf("%d tickets issued, want none", len(f.purchased.tickets))
+	}
+	if credits := f.paymentRepo.sellerPayments(); len(credits) != 0 {
+		t.Errorf("seller credited %+v for a declined charge", credits)
+	}
+}
+
+func TestPurchaseTicketFromGroupChargesOutsideTheHold(t *testing.T) {
+	f := newPurchaseFixture(t)
+
+	// The tickets are held on a committed order before the provider is called
+	f.provider.onCharge = func() {
+		if len(f.orders.orders) != 1 || f.orders.orders[0].Status != models.OrderStatusPaying {
+			t.Errorf("orders during the charge = %+v, want one being paid", f.orders.orders)
+		}
+		for _, ticket := range f.tickets.tickets {
+			if !ticket.IsHeld {
+				t.Errorf("ticket %d isn't held during the charge", ticket.ID)
+			}
+		}
+	}
+
+	if _, err := f.ticketService().PurchaseTicketFromGroup(context.Background(), &f.purchaseRequest); err != nil {
+		t.Fatalf("PurchaseTicketFromGroup: %v", err)
+	}
+	if stored := f.orders.orders[0]; stored.Status != models.OrderStatusPaid || stored.CompletedAt == nil {
+		t.Errorf("order status = %d, want paid", stored.Status)
+	}
+}
+
+func TestPurchaseTicketFromGroupRefundsUnissuedCharge(t *testing.T) {
+	f := newPurchaseFixture(t)
+	service := f.ticketService()
+
+	// The expiry job releases the order while its charge is in flight
+	f.provider.onCharge = func() {
+		f.orders.orders[0].HoldExpiresAt = time.Now().Unix() - 1
+		if _, err := service.ExpireHeldOrders(10); err != nil {
+			t.Errorf("ExpireHeldOrders: %v", err)
+		}
+	}
+
+	_, err := service.PurchaseTicketFromGroup(context.Background(), &f.purchaseRequest)
+	if code := apperrors.CodeOf(err); code != apperrors.CodeConflict {
+		t.Fatalf("purchase failed with %v, want %s", err, apperrors.CodeConflict)
+	}
+
+	if len(f.purchased.tickets) != 0 {
+		t.Errorf("%d tickets issued, want none", len(f.purchased.tickets))
+	}
+	if len(f.provider.refunded) != 1 {
+		t.Errorf("refunds = %v, want the charge refunded", f.provider.refunded)
+	}
+	for _, payment := range f.paymentRepo.payments {
+		if payment.UserType == models.UserTypeUser && payment.Status != models.PaymentStatusRefunded {
+			t.Errorf("payment %d has status %d, want refunded", payment.ID, payment.Status)
+		}
+	}
+	for _, ticket := range f.tickets.tickets {
+		if ticket.IsSold || ticket.IsHeld {
+			t.Errorf("ticket %d sold=%t held=%t, want released", ticket.ID, ticket.IsSold, ticket.IsHeld)
+		}
+	}
+}
+
+func TestPurchaseTicketFromGroupOrganizationDecline(t *testing.T) {
+	f := newPurchaseFixture(t)
+	f.purchaseRequest.PaymentMethodID = 2
+	f.purchaseRequest.BulkOrderID = 1
+
+	_, err := f.ticketService().PurchaseTicketFromGroup(context.Background(), &f.purchaseRequest)
+	if code := apperrors.CodeOf(err); code != apperrors.CodePaymentDeclined {
+		t.Fatalf("purchase failed with %v, want %s", err, apperrors.CodePaymentDeclined)
+	}
+
+	if stored := f.orders.orders[0]; stored.Status != models.OrderStatusFailed {
+		t.Errorf("order status = %d, want failed", stored.Status)
+	}
+	for _, ticket := range f.tickets.tickets {
+		if ticket.IsSold || ticket.IsHeld {
+			t.Errorf("ticket %d sold=%t held=%t, want released", ticket.ID, ticket.IsSold, ticket.IsHeld)
+		}
+	}
+}
+
+func TestPurchaseTicketDeclined(t *testing.T) {
+	f := newPurchaseFixture(t)
+	f.tickets.tickets[1].Event = *f.events.events[1]
+
+	_, err := f.ticketService().PurchaseTicket(context.Background(), &PurchaseTicketRequest{
+		TicketID:        1,
+		UserID:          buyerID,
+		Quantity:        1,
+		PaymentMethodID: 2,
+	})
+	if code := apperrors.CodeOf(err); code != apperrors.CodePaymentDeclined {
+		t.Fatalf("purchase failed with %v, want %s", err, apperrors.CodePaymentDeclined)
+	}
+
+	if ticket := f.tickets.tickets[1]; ticket.IsSold || ticket.IsHeld {
+		t.Errorf("ticket sold=%t held=%t, want released", ticket.IsSold, ticket.IsHeld)
+	}
+	if stored := f.orders.orders[0]; stored.Status != models.OrderStatusFailed {
+		t.Errorf("order status = %d, want failed", stored.Status)
+	}
+}
+
+// declinedOrder buys the fixture's tickets on the declining card, leaving
+// them held on an order awaiting payment
+func (f *purchaseFixture) declinedOrder(t *testing.T) *models.Order {
+	t.Helper()
+	f.purchaseRequest.PaymentMethodID = 2
+	resp, err := f.ticketService().PurchaseTicketFromGroup(context.Background(), &f.purchaseRequest)
+	if err != nil {
+		t.Fatalf("PurchaseTicketFromGroup: %v", err)
+	}
+	if resp.PendingOrder == nil {
+		t.Fatal("declined purchase left no pending order")
+	}
+	return resp.PendingOrder
+}
+
+func TestRetryOrderPayment(t *testing.T) {
+	f := newPurchaseFixture(t)
+	order := f.declinedOrder(t)
+
+	resp, err := f.ticketService().RetryOrderPayment(context.Background(), order.ID, buyerID, &RetryPaymentRequest{PaymentMethodID: 1})
+	if err != nil {
+		t.Fatalf("RetryOrderPayment: %v", err)
+	}
+
+	if len(resp.PurchasedTickets) != 2 || resp.PaymentInfo.Status != models.PaymentStatusCompleted {
+		t.Fatalf("retry issued %d tickets with payment status %d, want 2 completed", len(resp.PurchasedTickets), resp.PaymentInfo.Status)
+	}
+	for _, ticket := range f.tickets.tickets {
+		if !ticket.IsSold || ticket.IsHeld {
+			t.Errorf("ticket %d sold=%t held=%t, want sold", ticket.ID, ticket.IsSold, ticket.IsHeld)
+		}
+	}
+	if stored := f.orders.orders[0]; stored.Status != models.OrderStatusPaid || stored.Attempts != 2 {
+		t.Errorf("order status %d after %d attempts, want paid after 2", stored.Status, stored.Attempts)
+	}
+}
+
+func TestRetryOrderPaymentRefusals(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(f *purchaseFixture)
+		wantCode apperrors.Code
+	}{
+		{"sale ended", func(f *purchaseFixture) { f.sales.sales[1].EndDate = time.Now().Unix() - 60 }, apperrors.CodeSaleNotActive},
+		{"event suspended", func(f *purchaseFixture) { f.events.events[1].Status = models.EventStatusSuspended }, apperrors.CodeEventNotOnSale},
+		{"event unpublished", func(f *purchaseFixture) {
+			publishAt := time.Now().Unix() + 3600
+			f.events.events[1].PublishAt = &publishAt
+		}, apperrors.CodeEventNotOnSale},
+		{"another buyer's order", func(f *purchaseFixture) { f.orders.orders[0].UserID = buyerID + 1 }, apperrors.CodeForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPurchaseFixture(t)
+			order := f.declinedOrder(t)
+			tt.setup(f)
+
+			chargesBefore := f.provider.charges
+			_, err := f.ticketService().RetryOrderPayment(context.Background(), order.ID, buyerID, &RetryPaymentRequest{PaymentMethodID: 1})
+			if code := apperrors.CodeOf(err); code != tt.wantCode {
+				t.Fatalf("retry failed with %v, want %s", err, tt.wantCode)
+			}
+
+			if charges := f.provider.charges - chargesBefore; charges != 0 {
+				t.Errorf("%d charges made, want none", charges)
+			}
+			if stored := f.orders.orders[0]; stored.Status != models.OrderStatusAwaitingPayment {
+				t.Errorf("order status = %d, want still awaiting payment", stored.Status)
+			}
+		})
+	}
+}
+
+func TestRetryOrderPaymentDeclinedAgain(t *testing.T) {
+	f := newPurchaseFixture(t)
+	order := f.declinedOrder(t)
+
+	resp, err := f.ticketService().RetryOrderPayment(context.Background(), order.ID, buyerID, &RetryPaymentRequest{PaymentMethodID: 2})
+	if err != nil {
+		t.Fatalf("RetryOrderPayment: %v", err)
+	}
+
+	if resp.PendingOrder == nil || resp.PaymentInfo.Status != models.PaymentStatusFailed {
+		t.Fatalf("retry returned %+v, want the order pending after a failed charge", resp)
+	}
+	if stored := f.orders.orders[0]; stored.Status != models.OrderStatusAwaitingPayment || stored.Attempts != 2 {
+		t.Errorf("order status %d after %d attempts, want awaiting payment after 2", stored.Status, stored.Attempts)
+	}
+}
+
+func TestRetryOrderPaymentConcurrentRetry(t *testing.T) {
+	f := newPurchaseFixture(t)
+	order := f.declinedOrder(t)
+	service := f.ticketService()
+
+	// A second retry arrives while the first one's charge is in flight
+	var concurrentErr error
+	f.provider.onCharge = func() {
+		f.provider.onCharge = nil
+		_, concurrentErr = service.RetryOrderPayment(context.Background(), order.ID, buyerID, &RetryPaymentRequest{PaymentMethodID: 1})
+	}
+
+	chargesBefore := f.provider.charges
+	if _, err := service.RetryOrderPayment(context.Background(), order.ID, buyerID, &RetryPaymentRequest{PaymentMethodID: 1}); err != nil {
+		t.Fatalf("RetryOrderPayment: %v", err)
+	}
+
+	if code := apperrors.CodeOf(concurrentErr); code != apperrors.CodeOrderNotPayable {
+		t.Errorf("concurrent retry failed with %v, want %s", concurrentErr, apperrors.CodeOrderNotPayable)
+	}
+	if charges := f.provider.charges - chargesBefore; charges != 1 {
+		t.Errorf("%d charges made, want 1", charges)
+	}
+	if len(f.purchased.tickets) != 2 {
+		t.Errorf("%d tickets issued, want 2", len(f.purchased.tickets))
+	}
+}
+
+func TestRetryOrderPaymentExpiredMidCharge(t *testing.T) {
+	f := newPurchaseFixture(t)
+	order := f.declinedOrder(t)
+	service := f.ticketService()
+
+	// The expiry job releases the order while its charge is in flight
+	f.provider.onCharge = func() {
+		f.orders.orders[0].HoldExpiresAt = time.Now().Unix() - 1
+		if _, err := service.ExpireHeldOrders(10); err != nil {
+			t.Errorf("ExpireHeldOrders: %v", err)
+		}
+	}
+
+	_, err := service.RetryOrderPayment(context.Background(), order.ID, buyerID, &RetryPaymentRequest{PaymentMethodID: 1})
+	if code := apperrors.CodeOf(err); code != apperrors.CodeConflict {
+		t.Fatalf("retry failed with %v, want %s", err, apperrors.CodeConflict)
+	}
+
+	if len(f.purchased.tickets) != 0 {
+		t.Errorf("%d tickets issued, want none", len(f.purchased.tickets))
+	}
+	for _, ticket := range f.tickets.tickets {
+		if ticket.IsSold || ticket.IsHeld {
+			t.Errorf("ticket %d sold=%t held=%t, want released", ticket.ID, ticket.IsSold, ticket.IsHeld)
+		}
+	}
+	if len(f.provider.refunded) != 1 {
+		t.Errorf("refunds = %v, want the charge refunded", f.provider.refunded)
+	}
+	if stored := f.orders.orders[0]; stored.Status != models.OrderStatusExpired {
+		t.Errorf("order status = %d, want expired", stored.Status)
+	}
+}
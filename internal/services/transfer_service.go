@@ -2,11 +2,20 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
 	"time"
 
 	"eticketing/internal/models"
+	"eticketing/internal/outbox"
 	"eticketing/internal/repositories"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
 	"gorm.io/gorm"
 )
 
@@ -14,14 +23,60 @@ type TransferService struct {
 	transferRepo        repositories.TransferRepository
 	purchasedTicketRepo repositories.PurchasedTicketRepository
 	userRepo            repositories.UserRepository
+	eventRepo           repositories.EventRepository
+	paymentService      *PaymentService
+	outboxRepo          repositories.OutboxRepository
+	txManager           repositories.TransactionManager
+	publicURL           string
 }
 
+// claimLinkTTL is how long a claim link stays open if nobody claims it
+const claimLinkTTL = 7 * 24 * time.Hour
+
 type InitiateTransferRequest struct {
 	FromUserID        uint   `json:"-"` // Set by handler
 	ToUserEmail       string `json:"to_user_email" binding:"required,email"`
 	PurchasedTicketID uint   `json:"purchased_ticket_id" binding:"required"`
 }
 
+// AcceptTransferRequest says how the recipient pays the event's transfer
+// fee. It is ignored when the event charges none or the sender pays it.
+type AcceptTransferRequest struct {
+	PaymentMethod           models.PaymentType `json:"payment_method"`
+	PaymentMethodID         uint               `json:"payment_method_id"`
+	UseDefaultPaymentMethod bool               `json:"use_default_payment_method"`
+}
+
+type AcceptTransferResponse struct {
+	TransferID uint             `json:"transfer_id"`
+	FeePayment *PaymentResponse `json:"fee_payment,omitempty"`
+}
+
+type ClaimLinkResponse struct {
+	ID        uint   `json:"id"`
+	URL       string `json:"url"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// ClaimLinkPreview is what anyone holding a claim link sees before claiming
+type ClaimLinkPreview struct {
+	Status           models.ClaimLinkStatus  `json:"status"`
+	ExpiresAt        int64                   `json:"expires_at"`
+	FromName         string                  `json:"from_name"`
+	EventID          uint                    `json:"event_id"`
+	EventTitle       string                  `json:"event_title"`
+	EventDate        int64                   `json:"event_date"`
+	TicketTitle      string                  `json:"ticket_title"`
+	Place            string                  `json:"place"`
+	TransferFee      float64                 `json:"transfer_fee"`
+	TransferFeePayer models.TransferFeePayer `json:"transfer_fee_payer"`
+}
+
+type ClaimTicketResponse struct {
+	PurchasedTicketID uint             `json:"purchased_ticket_id"`
+	FeePayment        *PaymentResponse `json:"fee_payment,omitempty"`
+}
+
 type TransferResponse struct {
 	ID         uint                  `json:"id"`
 	FromUser   UserInfo              `json:"from_user"`
@@ -35,11 +90,21 @@ func NewTransferService(
 	transferRepo repositories.TransferRepository,
 	purchasedTicketRepo repositories.PurchasedTicketRepository,
 	userRepo repositories.UserRepository,
+	eventRepo repositories.EventRepository,
+	paymentService *PaymentService,
+	outboxRepo repositories.OutboxRepository,
+	txManager repositories.TransactionManager,
+	publicURL string,
 ) *TransferService {
 	return &TransferService{
 		transferRepo:        transferRepo,
 		purchasedTicketRepo: purchasedTicketRepo,
 		userRepo:            userRepo,
+		eventRepo:           eventRepo,
+		paymentService:      paymentService,
+		outboxRepo:          outboxRepo,
+		txManager:           txManager,
+		publicURL:           publicURL,
 	}
 }
 
@@ -47,39 +112,51 @@ func (s *TransferService) InitiateTransfer(req *InitiateTransferRequest) (*Trans
 	// Get purchased ticket
 	purchasedTicket, err := s.purchasedTicketRepo.GetByID(req.PurchasedTicketID)
 	if err != nil {
-		return nil, errors.New("purchased ticket not found")
+		return nil, apperrors.NotFound("purchased ticket not found")
 	}
 
 	// Check if user owns the ticket
 	if purchasedTicket.UserID != req.FromUserID {
-		return nil, errors.New("unauthorized to transfer this ticket")
+		return nil, apperrors.Forbidden("unauthorized to transfer this ticket")
 	}
 
 	if purchasedTicket.IsUsed {
-		return nil, errors.New("cannot transfer used ticket")
+		return nil, apperrors.Validation("cannot transfer used ticket")
+	}
+
+	if purchasedTicket.IsInvalidated {
+		return nil, apperrors.Validation("cannot transfer invalidated ticket")
 	}
 
 	// Check if ticket already has active transfer
 	hasActiveTransfer, err := s.transferRepo.HasActiveTransferForTicket(req.PurchasedTicketID)
 	if err != nil {
-		return nil, errors.New("failed to check existing transfers")
+		return nil, apperrors.Internal("failed to check existing transfers")
 	}
 	if hasActiveTransfer {
-		return nil, errors.New("ticket already has an active transfer")
+		return nil, apperrors.Conflict("ticket already has an active transfer")
+	}
+
+	hasClaimLink, err := s.transferRepo.HasOpenClaimLink(req.PurchasedTicketID, time.Now().Unix())
+	if err != nil {
+		return nil, apperrors.Internal("failed to check claim links")
+	}
+	if hasClaimLink {
+		return nil, apperrors.Conflict("ticket has an open claim link; revoke it first")
 	}
 
 	// Find recipient user by email
 	toUser, err := s.userRepo.GetByEmail(req.ToUserEmail)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("recipient user not found")
+			return nil, apperrors.NotFound("recipient user not found")
 		}
-		return nil, errors.New("failed to find recipient user")
+		return nil, apperrors.Internal("failed to find recipient user")
 	}
 
 	// Check if trying to transfer to self
 	if toUser.ID == req.FromUserID {
-		return nil, errors.New("cannot transfer ticket to yourself")
+		return nil, apperrors.Validation("cannot transfer ticket to yourself")
 	}
 
 	// Create active transfer
@@ -92,7 +169,7 @@ func (s *TransferService) InitiateTransfer(req *InitiateTransferRequest) (*Trans
 	}
 
 	if err := s.transferRepo.CreateActive(transfer); err != nil {
-		return nil, errors.New("failed to create transfer request")
+		return nil, apperrors.Internal("failed to create transfer request")
 	}
 
 	// Get from user info
@@ -124,19 +201,25 @@ func (s *TransferService) InitiateTransfer(req *InitiateTransferRequest) (*Trans
 			Place:       purchasedTicket.Place,
 			Price:       purchasedTicket.Price,
 			IsUsed:      purchasedTicket.IsUsed,
+			PurchasedAt: purchasedTicket.PurchasedAt,
 		},
 		Status: transfer.Status,
 		Date:   transfer.Date,
 	}, nil
 }
 
-func (s *TransferService) GetActiveTransfers(userID uint) ([]TransferResponse, error) {
-	transfers, err := s.transferRepo.ListActiveByUser(userID)
+func (s *TransferService) GetActiveTransfers(userID uint, limit, offset int) ([]TransferResponse, int64, error) {
+	transfers, err := s.transferRepo.ListActiveByUser(userID, limit, offset)
 	if err != nil {
-		return nil, errors.New("failed to retrieve active transfers")
+		return nil, 0, apperrors.Internal("failed to retrieve active transfers")
 	}
 
-	var responses []TransferResponse
+	total, err := s.transferRepo.CountActiveByUser(userID)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to count active transfers")
+	}
+
+	responses := make([]TransferResponse, 0, len(transfers))
 	for _, transfer := range transfers {
 		response := TransferResponse{
 			ID: transfer.ID,
@@ -164,6 +247,7 @@ func (s *TransferService) GetActiveTransfers(userID uint) ([]TransferResponse, e
 				Place:       transfer.PurchasedTicket.Place,
 				Price:       transfer.PurchasedTicket.Price,
 				IsUsed:      transfer.PurchasedTicket.IsUsed,
+				PurchasedAt: transfer.PurchasedTicket.PurchasedAt,
 			},
 			Status: transfer.Status,
 			Date:   transfer.Date,
@@ -171,96 +255,221 @@ func (s *TransferService) GetActiveTransfers(userID uint) ([]TransferResponse, e
 		responses = append(responses, response)
 	}
 
-	return responses, nil
+	return responses, total, nil
 }
 
-func (s *TransferService) AcceptTransfer(transferID, userID uint) error {
+func (s *TransferService) AcceptTransfer(ctx context.Context, transferID, userID uint, req *AcceptTransferRequest) (*AcceptTransferResponse, error) {
 	transfer, err := s.transferRepo.GetActiveByID(transferID)
 	if err != nil {
-		return errors.New("transfer not found")
+		return nil, apperrors.NotFound("transfer not found")
 	}
 
 	// Check if user is the recipient
 	if transfer.ToUserID != userID {
-		return errors.New("unauthorized to accept this transfer")
+		return nil, apperrors.Forbidden("unauthorized to accept this transfer")
 	}
 
 	if transfer.Status != models.TransferStatusPending {
-		return errors.New("transfer is not in pending status")
+		return nil, apperrors.Validation("transfer is not in pending status")
 	}
 
-	// Update transfer status
-	transfer.Status = models.TransferStatusAccepted
-	if err := s.transferRepo.UpdateActive(transfer); err != nil {
-		return errors.New("failed to update transfer status")
+	// The transfer is claimed before the fee is charged, and the fee is
+	// charged outside the transaction that moves the ticket: a rollback
+	// can't take back a charge, so a failed move refunds it instead. A
+	// declined fee or failed move puts the transfer back to pending.
+	accepted, err := s.transferRepo.AcceptPending(transfer.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to accept transfer")
+	}
+	if !accepted {
+		return nil, apperrors.Conflict("transfer has already been accepted or closed")
 	}
 
-	// Update ticket ownership
-	purchasedTicket, err := s.purchasedTicketRepo.GetByID(transfer.PurchasedTicketID)
+	response, err := s.completeTransfer(ctx, transfer, req)
 	if err != nil {
-		return errors.New("failed to find purchased ticket")
+		if reopenErr := s.transferRepo.ReopenAccepted(transfer.ID); reopenErr != nil {
+			log.Printf("Failed to reopen transfer %d: %v", transfer.ID, reopenErr)
+		}
+		if _, ok := apperrors.As(err); ok {
+			return nil, err
+		}
+		return nil, apperrors.Internal("failed to accept transfer")
 	}
 
-	purchasedTicket.UserID = transfer.ToUserID
-	if err := s.purchasedTicketRepo.UpdateOwnership(transfer.PurchasedTicketID, transfer.ToUserID); err != nil {
-		return errors.New("failed to transfer ticket ownership")
+	return response, nil
+}
+
+// completeTransfer charges the fee of a claimed transfer, then moves the
+// ticket and records the completed transfer. The fee is refunded if the
+// ticket can't be moved.
+func (s *TransferService) completeTransfer(ctx context.Context, transfer *models.ActiveTicketTransfer, req *AcceptTransferRequest) (*AcceptTransferResponse, error) {
+	if transfer.PurchasedTicket.UserID != transfer.FromUserID {
+		return nil, apperrors.Conflict("ticket no longer belongs to the sender")
 	}
 
-	// Create done transfer record
-	doneTransfer := &models.DoneTicketTransfer{
-		FromUserID:        transfer.FromUserID,
-		ToUserID:          transfer.ToUserID,
-		Date:              transfer.Date,
-		PurchasedTicketID: transfer.PurchasedTicketID,
-		CompletedAt:       time.Now().Unix(),
+	fee, err := s.chargeTransferFee(ctx, transfer, req)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.transferRepo.CreateDone(doneTransfer); err != nil {
-		// Log error but don't fail the transfer
-		// The main transfer is already complete
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		tx = tx.WithContext(ctx)
+
+		moved, err := s.purchasedTicketRepo.WithTx(tx).UpdateOwnership(transfer.PurchasedTicketID, transfer.FromUserID, transfer.ToUserID)
+		if err != nil {
+			return err
+		}
+		if !moved {
+			return apperrors.Conflict("ticket no longer belongs to the sender or can no longer be transferred")
+		}
+
+		now := time.Now().Unix()
+		activeTransferID := transfer.ID
+		if err := s.transferRepo.WithTx(tx).CreateDone(&models.DoneTicketTransfer{
+			FromUserID:        transfer.FromUserID,
+			ToUserID:          transfer.ToUserID,
+			Date:              transfer.Date,
+			PurchasedTicketID: transfer.PurchasedTicketID,
+			CompletedAt:       now,
+			ActiveTransferID:  &activeTransferID,
+		}); err != nil {
+			return err
+		}
+
+		payload := outbox.TransferAcceptedPayload{
+			TransferID:        transfer.ID,
+			EventID:           transfer.PurchasedTicket.Ticket.EventID,
+			PurchasedTicketID: transfer.PurchasedTicketID,
+			FromUserID:        transfer.FromUserID,
+			ToUserID:          transfer.ToUserID,
+			AcceptedAt:        now,
+		}
+		if fee != nil {
+			payload.Fee = fee.Amount
+		}
+		message, err := outbox.NewMessage(models.OutboxTopicTransferAccepted, payload)
+		if err != nil {
+			return err
+		}
+		return s.outboxRepo.WithTx(tx).Create(message)
+	})
+	if err != nil {
+		s.refundTransferFee(ctx, fee)
+		return nil, err
 	}
 
-	return nil
+	return &AcceptTransferResponse{TransferID: transfer.ID, FeePayment: fee}, nil
+}
+
+// refundTransferFee gives back a fee charged for a transfer that then
+// couldn't be completed
+func (s *TransferService) refundTransferFee(ctx context.Context, fee *PaymentResponse) {
+	if fee == nil {
+		return
+	}
+	if err := s.paymentService.ReversePayment(ctx, fee.PaymentID); err != nil {
+		log.Printf("Failed to refund transfer fee payment %d: %v", fee.PaymentID, err)
+	}
+}
+
+// chargeTransferFee charges the event's transfer fee to whoever the event
+// says pays it. The sender is charged on their default stored method since
+// they aren't around when the transfer is accepted. Like a ticket sale, the
+// fee is split between the seller and the platform.
+func (s *TransferService) chargeTransferFee(ctx context.Context, transfer *models.ActiveTicketTransfer, req *AcceptTransferRequest) (*PaymentResponse, error) {
+	event, err := s.eventRepo.GetByID(transfer.PurchasedTicket.Ticket.EventID)
+	if err != nil {
+		return nil, apperrors.NotFound("event not found")
+	}
+	if event.TransferFee <= 0 {
+		return nil, nil
+	}
+
+	payment := &PaymentRequest{
+		UserType:    models.UserTypeUser,
+		Amount:      event.TransferFee,
+		Description: fmt.Sprintf("Transfer fee: %s", event.Title),
+		EventID:     event.ID,
+	}
+	if event.TransferFeePayer == models.TransferFeePayerSender {
+		payment.UserID = transfer.FromUserID
+		payment.UseDefaultMethod = true
+	} else {
+		if req.PaymentMethod == models.PaymentTypeInvoice {
+			return nil, apperrors.Validation("transfer fees cannot be paid by invoice")
+		}
+		payment.UserID = transfer.ToUserID
+		payment.PaymentMethod = req.PaymentMethod
+		payment.PaymentMethodID = req.PaymentMethodID
+		payment.UseDefaultMethod = req.UseDefaultPaymentMethod
+	}
+
+	response, err := s.paymentService.ProcessPayment(ctx, payment)
+	if err != nil {
+		if event.TransferFeePayer == models.TransferFeePayerSender && apperrors.CodeOf(err) == apperrors.CodeValidationFailed {
+			return nil, apperrors.Validation("the sender has no default payment method to pay the transfer fee")
+		}
+		return nil, err
+	}
+	if response.Status != models.PaymentStatusCompleted {
+		return nil, apperrors.PaymentRequired("transfer fee payment failed: " + response.Message)
+	}
+
+	return response, nil
 }
 
 func (s *TransferService) RejectTransfer(transferID, userID uint) error {
 	transfer, err := s.transferRepo.GetActiveByID(transferID)
 	if err != nil {
-		return errors.New("transfer not found")
+		return apperrors.NotFound("transfer not found")
 	}
 
 	// Check if user is the recipient
 	if transfer.ToUserID != userID {
-		return errors.New("unauthorized to reject this transfer")
+		return apperrors.Forbidden("unauthorized to reject this transfer")
 	}
 
 	if transfer.Status != models.TransferStatusPending {
-		return errors.New("transfer is not in pending status")
+		return apperrors.Validation("transfer is not in pending status")
 	}
 
-	// Update transfer status
-	transfer.Status = models.TransferStatusRejected
-	if err := s.transferRepo.UpdateActive(transfer); err != nil {
-		return errors.New("failed to update transfer status")
+	rejected, err := s.transferRepo.RejectPending(transfer.ID)
+	if err != nil {
+		return apperrors.Internal("failed to update transfer status")
+	}
+	if !rejected {
+		return apperrors.Conflict("transfer has already been accepted or closed")
 	}
 
 	return nil
 }
 
-func (s *TransferService) GetTransferHistory(userID uint) ([]TransferHistoryResponse, error) {
+// GetTransferHistory returns a page of the user's completed, rejected and
+// cancelled transfers, newest first. The history spans two tables, so the
+// page is cut from the newest offset+limit rows of each.
+func (s *TransferService) GetTransferHistory(userID uint, limit, offset int) ([]TransferHistoryResponse, int64, error) {
 	// Get completed transfers from DoneTicketTransfer table
-	doneTransfers, err := s.transferRepo.ListDoneByUser(userID)
+	doneTransfers, err := s.transferRepo.ListDoneByUser(userID, offset+limit, 0)
 	if err != nil {
-		return nil, errors.New("failed to retrieve transfer history")
+		return nil, 0, apperrors.Internal("failed to retrieve transfer history")
 	}
 
 	// Get rejected/cancelled transfers from ActiveTicketTransfer table
-	rejectedTransfers, err := s.transferRepo.ListRejectedByUser(userID)
+	rejectedTransfers, err := s.transferRepo.ListRejectedByUser(userID, offset+limit, 0)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to retrieve rejected transfers")
+	}
+
+	doneCount, err := s.transferRepo.CountDoneByUser(userID)
 	if err != nil {
-		return nil, errors.New("failed to retrieve rejected transfers")
+		return nil, 0, apperrors.Internal("failed to count transfer history")
+	}
+	rejectedCount, err := s.transferRepo.CountRejectedByUser(userID)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to count rejected transfers")
 	}
 
-	var responses []TransferHistoryResponse
+	responses := make([]TransferHistoryResponse, 0, len(doneTransfers)+len(rejectedTransfers))
 
 	// Add completed transfers
 	for _, transfer := range doneTransfers {
@@ -290,6 +499,7 @@ func (s *TransferService) GetTransferHistory(userID uint) ([]TransferHistoryResp
 				Place:       transfer.PurchasedTicket.Place,
 				Price:       transfer.PurchasedTicket.Price,
 				IsUsed:      transfer.PurchasedTicket.IsUsed,
+				PurchasedAt: transfer.PurchasedTicket.PurchasedAt,
 			},
 			Date:        transfer.Date,
 			CompletedAt: transfer.CompletedAt,
@@ -300,41 +510,88 @@ func (s *TransferService) GetTransferHistory(userID uint) ([]TransferHistoryResp
 
 	// Add rejected/cancelled transfers
 	for _, transfer := range rejectedTransfers {
-		response := TransferHistoryResponse{
-			ID: transfer.ID,
-			FromUser: UserInfo{
-				ID:       transfer.FromUser.ID,
-				Username: transfer.FromUser.Username,
-				Email:    transfer.FromUser.Email,
-				Name:     transfer.FromUser.Name,
-				Surname:  transfer.FromUser.Surname,
-				UserType: models.UserTypeUser,
-			},
-			ToUser: UserInfo{
-				ID:       transfer.ToUser.ID,
-				Username: transfer.ToUser.Username,
-				Email:    transfer.ToUser.Email,
-				Name:     transfer.ToUser.Name,
-				Surname:  transfer.ToUser.Surname,
-				UserType: models.UserTypeUser,
-			},
-			TicketInfo: PurchasedTicketInfo{
-				ID:          transfer.PurchasedTicket.ID,
-				TicketID:    transfer.PurchasedTicket.TicketID,
-				Title:       transfer.PurchasedTicket.Title,
-				Description: transfer.PurchasedTicket.Description,
-				Place:       transfer.PurchasedTicket.Place,
-				Price:       transfer.PurchasedTicket.Price,
-				IsUsed:      transfer.PurchasedTicket.IsUsed,
-			},
-			Date:        transfer.Date,
-			CompletedAt: time.Now().Unix(), // Use current time for rejected
-			Status:      transfer.Status,
-		}
-		responses = append(responses, response)
+		responses = append(responses, s.closedTransferToHistory(&transfer))
 	}
 
-	return responses, nil
+	sort.SliceStable(responses, func(i, j int) bool {
+		return responses[i].Date > responses[j].Date
+	})
+	if offset >= len(responses) {
+		return []TransferHistoryResponse{}, doneCount + rejectedCount, nil
+	}
+	if end := offset + limit; end < len(responses) {
+		responses = responses[:end]
+	}
+
+	return responses[offset:], doneCount + rejectedCount, nil
+}
+
+// GetRejectedTransfers lists the user's transfers, sent or received, that the
+// recipient declined or that were cancelled before being accepted
+func (s *TransferService) GetRejectedTransfers(userID uint, limit, offset int) ([]TransferHistoryResponse, int64, error) {
+	transfers, err := s.transferRepo.ListRejectedByUser(userID, limit, offset)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to retrieve rejected transfers")
+	}
+
+	total, err := s.transferRepo.CountRejectedByUser(userID)
+	if err != nil {
+		return nil, 0, apperrors.Internal("failed to count rejected transfers")
+	}
+
+	responses := make([]TransferHistoryResponse, 0, len(transfers))
+	for _, transfer := range transfers {
+		responses = append(responses, s.closedTransferToHistory(&transfer))
+	}
+
+	return responses, total, nil
+}
+
+func (s *TransferService) closedTransferToHistory(transfer *models.ActiveTicketTransfer) TransferHistoryResponse {
+	return TransferHistoryResponse{
+		ID: transfer.ID,
+		FromUser: UserInfo{
+			ID:       transfer.FromUser.ID,
+			Username: transfer.FromUser.Username,
+			Email:    transfer.FromUser.Email,
+			Name:     transfer.FromUser.Name,
+			Surname:  transfer.FromUser.Surname,
+			UserType: models.UserTypeUser,
+		},
+		ToUser: UserInfo{
+			ID:       transfer.ToUser.ID,
+			Username: transfer.ToUser.Username,
+			Email:    transfer.ToUser.Email,
+			Name:     transfer.ToUser.Name,
+			Surname:  transfer.ToUser.Surname,
+			UserType: models.UserTypeUser,
+		},
+		TicketInfo: PurchasedTicketInfo{
+			ID:          transfer.PurchasedTicket.ID,
+			TicketID:    transfer.PurchasedTicket.TicketID,
+			Title:       transfer.PurchasedTicket.Title,
+			Description: transfer.PurchasedTicket.Description,
+			Place:       transfer.PurchasedTicket.Place,
+			Price:       transfer.PurchasedTicket.Price,
+			IsUsed:      transfer.PurchasedTicket.IsUsed,
+			PurchasedAt: transfer.PurchasedTicket.PurchasedAt,
+		},
+		Date:        transfer.Date,
+		CompletedAt: time.Now().Unix(), // Use current time for rejected
+		Status:      transfer.Status,
+	}
+}
+
+// ExpireStaleTransfers cancels pending transfers the recipient hasn't acted on within ttl
+func (s *TransferService) ExpireStaleTransfers(ttl time.Duration) (int64, error) {
+	cutoff := time.Now().Add(-ttl).Unix()
+
+	expired, err := s.transferRepo.CancelPendingBefore(cutoff)
+	if err != nil {
+		return 0, apperrors.Internal("failed to expire stale transfers")
+	}
+
+	return expired, nil
 }
 
 type TransferHistoryResponse struct {
@@ -346,3 +603,234 @@ type TransferHistoryResponse struct {
 	CompletedAt int64                 `json:"completed_at"`
 	Status      models.TransferStatus `json:"status"` // Add this field
 }
+
+// CreateClaimLink opens a one-time link the owner can send to anyone. Any
+// link the ticket already had stops working. linksPath is where the API
+// serves claim links, e.g. /api/v2/claim-links.
+func (s *TransferService) CreateClaimLink(purchasedTicketID, userID uint, linksPath string) (*ClaimLinkResponse, error) {
+	purchasedTicket, err := s.purchasedTicketRepo.GetByID(purchasedTicketID)
+	if err != nil {
+		return nil, apperrors.NotFound("purchased ticket not found")
+	}
+
+	if purchasedTicket.UserID != userID {
+		return nil, apperrors.Forbidden("unauthorized to transfer this ticket")
+	}
+
+	if purchasedTicket.IsUsed {
+		return nil, apperrors.Validation("cannot transfer used ticket")
+	}
+
+	if purchasedTicket.IsInvalidated {
+		return nil, apperrors.Validation("cannot transfer invalidated ticket")
+	}
+
+	hasActiveTransfer, err := s.transferRepo.HasActiveTransferForTicket(purchasedTicketID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to check existing transfers")
+	}
+	if hasActiveTransfer {
+		return nil, apperrors.Conflict("ticket already has an active transfer")
+	}
+
+	token, err := utils.RandomHex(24)
+	if err != nil {
+		return nil, apperrors.Internal("failed to generate claim link")
+	}
+
+	now := time.Now()
+	link := &models.TicketClaimLink{
+		PurchasedTicketID: purchasedTicketID,
+		FromUserID:        userID,
+		Token:             token,
+		Status:            models.ClaimLinkStatusOpen,
+		CreatedAt:         now.Unix(),
+		ExpiresAt:         now.Add(claimLinkTTL).Unix(),
+	}
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		transferRepo := s.transferRepo.WithTx(tx)
+		if _, err := transferRepo.RevokeClaimLinks(purchasedTicketID); err != nil {
+			return err
+		}
+		return transferRepo.CreateClaimLink(link)
+	})
+	if err != nil {
+		return nil, apperrors.Internal("failed to create claim link")
+	}
+
+	return &ClaimLinkResponse{
+		ID:        link.ID,
+		URL:       strings.TrimRight(s.publicURL, "/") + path.Join(linksPath, token),
+		ExpiresAt: link.ExpiresAt,
+	}, nil
+}
+
+// RevokeClaimLink closes the ticket's open claim link
+func (s *TransferService) RevokeClaimLink(purchasedTicketID, userID uint) error {
+	purchasedTicket, err := s.purchasedTicketRepo.GetByID(purchasedTicketID)
+	if err != nil {
+		return apperrors.NotFound("purchased ticket not found")
+	}
+
+	if purchasedTicket.UserID != userID {
+		return apperrors.Forbidden("unauthorized to manage this ticket")
+	}
+
+	revoked, err := s.transferRepo.RevokeClaimLinks(purchasedTicketID)
+	if err != nil {
+		return apperrors.Internal("failed to revoke claim link")
+	}
+	if revoked == 0 {
+		return apperrors.NotFound("ticket has no open claim link")
+	}
+
+	return nil
+}
+
+// GetClaimLink describes the ticket behind a claim link, including any
+// transfer fee the claimer would pay
+func (s *TransferService) GetClaimLink(token string) (*ClaimLinkPreview, error) {
+	link, err := s.transferRepo.GetClaimLinkByToken(token)
+	if err != nil {
+		return nil, apperrors.NotFound("claim link not found")
+	}
+
+	event := link.PurchasedTicket.Ticket.Event
+	return &ClaimLinkPreview{
+		Status:           link.Status,
+		ExpiresAt:        link.ExpiresAt,
+		FromName:         link.FromUser.Name,
+		EventID:          event.ID,
+		EventTitle:       event.Title,
+		EventDate:        event.Date,
+		TicketTitle:      link.PurchasedTicket.Title,
+		Place:            link.PurchasedTicket.Place,
+		TransferFee:      event.TransferFee,
+		TransferFeePayer: event.TransferFeePayer,
+	}, nil
+}
+
+// ClaimTicket makes the signed-in user the owner of the ticket behind a
+// claim link. Like accepting a transfer, the link is used up before any
+// transfer fee is charged, and a fee charged for a ticket that then can't
+// be moved is refunded. A declined fee or failed move opens the link again.
+func (s *TransferService) ClaimTicket(ctx context.Context, token string, userID uint, req *AcceptTransferRequest) (*ClaimTicketResponse, error) {
+	link, err := s.transferRepo.GetClaimLinkByToken(token)
+	if err != nil {
+		return nil, apperrors.NotFound("claim link not found")
+	}
+
+	if link.Status != models.ClaimLinkStatusOpen {
+		return nil, apperrors.Conflict("claim link has already been used or revoked")
+	}
+
+	now := time.Now().Unix()
+	if link.ExpiresAt <= now {
+		return nil, apperrors.Validation("claim link has expired")
+	}
+
+	if link.FromUserID == userID {
+		return nil, apperrors.Validation("cannot claim your own ticket")
+	}
+
+	hasActiveTransfer, err := s.transferRepo.HasActiveTransferForTicket(link.PurchasedTicketID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to check existing transfers")
+	}
+	if hasActiveTransfer {
+		return nil, apperrors.Conflict("ticket has a transfer in progress")
+	}
+
+	claimed, err := s.transferRepo.ClaimOpenLink(link.ID, userID, now)
+	if err != nil {
+		return nil, apperrors.Internal("failed to claim ticket")
+	}
+	if !claimed {
+		return nil, apperrors.Conflict("claim link has already been used or revoked")
+	}
+
+	response, err := s.completeClaim(ctx, link, userID, now, req)
+	if err != nil {
+		if reopenErr := s.transferRepo.ReopenClaimLink(link.ID, userID); reopenErr != nil {
+			log.Printf("Failed to reopen claim link %d: %v", link.ID, reopenErr)
+		}
+		if _, ok := apperrors.As(err); ok {
+			return nil, err
+		}
+		return nil, apperrors.Internal("failed to claim ticket")
+	}
+
+	return response, nil
+}
+
+// completeClaim charges the fee of a claimed link, then moves the ticket
+// and records the completed transfer. The fee is refunded if the ticket
+// can't be moved.
+func (s *TransferService) completeClaim(ctx context.Context, link *models.TicketClaimLink, userID uint, now int64, req *AcceptTransferRequest) (*ClaimTicketResponse, error) {
+	purchasedTicket, err := s.purchasedTicketRepo.GetByID(link.PurchasedTicketID)
+	if err != nil {
+		return nil, err
+	}
+	if purchasedTicket.UserID != link.FromUserID {
+		return nil, apperrors.Conflict("ticket no longer belongs to the sender")
+	}
+	if purchasedTicket.IsUsed || purchasedTicket.IsInvalidated {
+		return nil, apperrors.Validation("ticket can no longer be transferred")
+	}
+
+	// The fee rules are the same as for a transfer by email
+	fee, err := s.chargeTransferFee(ctx, &models.ActiveTicketTransfer{
+		FromUserID:        link.FromUserID,
+		ToUserID:          userID,
+		PurchasedTicketID: link.PurchasedTicketID,
+		PurchasedTicket:   *purchasedTicket,
+	}, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		tx = tx.WithContext(ctx)
+
+		moved, err := s.purchasedTicketRepo.WithTx(tx).UpdateOwnership(link.PurchasedTicketID, link.FromUserID, userID)
+		if err != nil {
+			return err
+		}
+		if !moved {
+			return apperrors.Conflict("ticket no longer belongs to the sender or can no longer be transferred")
+		}
+
+		if err := s.transferRepo.WithTx(tx).CreateDone(&models.DoneTicketTransfer{
+			FromUserID:        link.FromUserID,
+			ToUserID:          userID,
+			Date:              link.CreatedAt,
+			PurchasedTicketID: link.PurchasedTicketID,
+			CompletedAt:       now,
+		}); err != nil {
+			return err
+		}
+
+		payload := outbox.TransferAcceptedPayload{
+			ClaimLinkID:       link.ID,
+			EventID:           purchasedTicket.Ticket.EventID,
+			PurchasedTicketID: link.PurchasedTicketID,
+			FromUserID:        link.FromUserID,
+			ToUserID:          userID,
+			AcceptedAt:        now,
+		}
+		if fee != nil {
+			payload.Fee = fee.Amount
+		}
+		message, err := outbox.NewMessage(models.OutboxTopicTransferAccepted, payload)
+		if err != nil {
+			return err
+		}
+		return s.outboxRepo.WithTx(tx).Create(message)
+	})
+	if err != nil {
+		s.refundTransferFee(ctx, fee)
+		return nil, err
+	}
+
+	return &ClaimTicketResponse{PurchasedTicketID: link.PurchasedTicketID, FeePayment: fee}, nil
+}
@@ -0,0 +1,361 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"eticketing/internal/models"
+	"eticketing/internal/payments"
+	apperrors "eticketing/pkg/errors"
+)
+
+const (
+	senderID    = 10
+	recipientID = 11
+)
+
+// transferFixture has the sender's purchased ticket 1 for event 1 and a
+// pending transfer 1 of it to the recipient
+type transferFixture struct {
+	ctrl        *gomock.Controller
+	transfers   *transferStore
+	purchased   *purchasedTicketStore
+	users       *userStore
+	events      *eventStore
+	paymentRepo *paymentStore
+	outbox      *outboxStore
+	provider    *fakeProvider
+}
+
+func newTransferFixture(t *testing.T) *transferFixture {
+	ticket := &models.PurchasedTicket{ID: 1, UserID: senderID, TicketID: 1, Ticket: models.Ticket{ID: 1, EventID: 1}}
+	return &transferFixture{
+		ctrl: gomock.NewController(t),
+		transfers: &transferStore{active: map[uint]*models.ActiveTicketTransfer{
+			1: {ID: 1, FromUserID: senderID, ToUserID: recipientID, PurchasedTicketID: 1,
+				Status: models.TransferStatusPending, PurchasedTicket: *ticket},
+		}},
+		purchased: &purchasedTicketStore{tickets: map[uint]*models.PurchasedTicket{1: ticket}},
+		users: &userStore{users: map[uint]*models.User{
+			senderID:    {ID: senderID, Email: "sender@example.com"},
+			recipientID: {ID: recipientID, Email: "recipient@example.com"},
+		}},
+		events: &eventStore{events: map[uint]*models.Event{
+			1: {ID: 1, SellerID: sellerID, Title: "Concert", Status: models.EventStatusApproved},
+		}},
+		paymentRepo: &paymentStore{},
+		outbox:      &outboxStore{},
+		provider:    &fakeProvider{},
+	}
+}
+
+func (f *transferFixture) transferService() *TransferService {
+	methods := &paymentMethodStore{methods: map[uint]*models.PaymentMethod{
+		1: {ID: 1, UserID: recipientID, UserType: models.UserTypeUser, Type: models.PaymentTypeCard, Provider: "fake", Token: "card"},
+		2: {ID: 2, UserID: recipientID, UserType: models.UserTypeUser, Type: models.PaymentTypeCard, Provider: "fake", Token: "decline"},
+	}}
+	purchased, events, outbox := f.purchased.mock(f.ctrl), f.events.mock(f.ctrl), f.outbox.mock(f.ctrl)
+	paymentService := NewPaymentService(f.paymentRepo.mock(f.ctrl), methods.mock(f.ctrl), purchased,
+		payments.NewRegistry(f.provider), events, nil, nil, outbox, fakeTxManager{}, false)
+
+	return NewTransferService(f.transfers.mock(f.ctrl), purchased, f.users.mock(f.ctrl), events, paymentService,
+		outbox, fakeTxManager{}, "https://tickets.example.com")
+}
+
+func TestInitiateTransferRefusals(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(f *transferFixture, req *InitiateTransferRequest)
+		wantCode apperrors.Code
+	}{
+		{"missing ticket", func(f *transferFixture, req *InitiateTransferRequest) { req.PurchasedTicketID = 9 }, apperrors.CodeNotFound},
+		{"someone else's ticket", func(f *transferFixture, req *InitiateTransferRequest) { req.FromUserID = recipientID }, apperrors.CodeForbidden},
+		{"used ticket", func(f *transferFixture, req *InitiateTransferRequest) { f.purchased.tickets[1].IsUsed = true }, apperrors.CodeValidationFailed},
+		{"invalidated ticket", func(f *transferFixture, req *InitiateTransferRequest) { f.purchased.tickets[1].IsInvalidated = true }, apperrors.CodeValidationFailed},
+		{"transfer already pending", func(f *transferFixture, req *InitiateTransferRequest) { f.transfers.hasActive = true }, apperrors.CodeConflict},
+		{"open claim link", func(f *transferFixture, req *InitiateTransferRequest) { f.transfers.hasClaimLink = true }, apperrors.CodeConflict},
+		{"unknown recipient", func(f *transferFixture, req *InitiateTransferRequest) { req.ToUserEmail = "nobody@example.com" }, apperrors.CodeNotFound},
+		{"recipient lookup fails", func(f *transferFixture, req *InitiateTransferRequest) { f.users.getErr = errStorage }, apperrors.CodeInternal},
+		{"to the sender", func(f *transferFixture, req *InitiateTransferRequest) { req.ToUserEmail = "sender@example.com" }, apperrors.CodeValidationFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTransferFixture(t)
+			delete(f.transfers.active, 1)
+			req := &InitiateTransferRequest{FromUserID: senderID, ToUserEmail: "recipient@example.com", PurchasedTicketID: 1}
+			tt.setup(f, req)
+
+			_, err := f.transferService().InitiateTransfer(req)
+			if code := apperrors.CodeOf(err); code != tt.wantCode {
+				t.Errorf("error %v has code %q, want %q", err, code, tt.wantCode)
+			}
+			if len(f.transfers.active) != 0 {
+				t.Errorf("%d transfers created, want none", len(f.transfers.active))
+			}
+		})
+	}
+}
+
+func TestInitiateTransfer(t *testing.T) {
+	f := newTransferFixture(t)
+	delete(f.transfers.active, 1)
+
+	resp, err := f.transferService().InitiateTransfer(&InitiateTransferRequest{
+		FromUserID:        senderID,
+		ToUserEmail:       "recipient@example.com",
+		PurchasedTicketID: 1,
+	})
+	if err != nil {
+		t.Fatalf("InitiateTransfer: %v", err)
+	}
+
+	transfer := f.transfers.active[resp.ID]
+	if transfer == nil || transfer.Status != models.TransferStatusPending || transfer.ToUserID != recipientID {
+		t.Errorf("transfer = %+v, want pending to user %d", transfer, recipientID)
+	}
+	if f.purchased.tickets[1].UserID != senderID {
+		t.Errorf("ticket moved to user %d before the transfer was accepted", f.purchased.tickets[1].UserID)
+	}
+}
+
+func TestAcceptTransferRefusals(t *testing.T) {
+	tests := []struct {
+		name       string
+		transferID uint
+		userID     uint
+		setup      func(f *transferFixture)
+		wantCode   apperrors.Code
+		// keepsStatus is set where the transfer is not pending to begin with
+		keepsStatus bool
+	}{
+		{"missing transfer", 9, recipientID, nil, apperrors.CodeNotFound, false},
+		{"not the recipient", 1, senderID, nil, apperrors.CodeForbidden, false},
+		{"already rejected", 1, recipientID, func(f *transferFixture) {
+			f.transfers.active[1].Status = models.TransferStatusRejected
+		}, apperrors.CodeValidationFailed, true},
+		{"accepted concurrently", 1, recipientID, func(f *transferFixture) { f.transfers.acceptedFirst = true }, apperrors.CodeConflict, false},
+		{"sender no longer owns the ticket", 1, recipientID, func(f *transferFixture) {
+			f.transfers.active[1].PurchasedTicket.UserID = 12
+		}, apperrors.CodeConflict, false},
+		{"ticket used since the transfer was offered", 1, recipientID, func(f *transferFixture) {
+			f.purchased.tickets[1].IsUsed = true
+		}, apperrors.CodeConflict, false},
+		{"fee declined", 1, recipientID, func(f *transferFixture) { f.events.events[1].TransferFee = 5 }, apperrors.CodePaymentDeclined, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTransferFixture(t)
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+
+			_, err := f.transferService().AcceptTransfer(context.Background(), tt.transferID, tt.userID,
+				&AcceptTransferRequest{PaymentMethodID: 2})
+			if code := apperrors.CodeOf(err); code != tt.wantCode {
+				t.Errorf("error %v has code %q, want %q", err, code, tt.wantCode)
+			}
+			if owner := f.purchased.tickets[1].UserID; owner != senderID {
+				t.Errorf("ticket moved to user %d, want it left with the sender", owner)
+			}
+			if len(f.transfers.done) != 0 || len(f.outbox.messages) != 0 {
+				t.Errorf("recorded %d completed transfers and %d messages, want none", len(f.transfers.done), len(f.outbox.messages))
+			}
+			if transfer := f.transfers.active[1]; !tt.keepsStatus && transfer.Status != models.TransferStatusPending {
+				t.Errorf("transfer status = %d, want it back to pending", transfer.Status)
+			}
+		})
+	}
+}
+
+// A fee charged for a transfer whose ticket can't be moved any more is
+// refunded and the seller's credit taken back
+func TestAcceptTransferRefundsFeeWhenTicketCantMove(t *testing.T) {
+	f := newTransferFixture(t)
+	f.events.events[1].TransferFee = 5
+	f.purchased.tickets[1].IsUsed = true
+
+	_, err := f.transferService().AcceptTransfer(context.Background(), 1, recipientID, &AcceptTransferRequest{PaymentMethodID: 1})
+	if code := apperrors.CodeOf(err); code != apperrors.CodeConflict {
+		t.Fatalf("error %v has code %q, want %q", err, code, apperrors.CodeConflict)
+	}
+
+	if f.provider.charges != 1 || len(f.provider.refunded) != 1 {
+		t.Errorf("%d charges and refunds %v, want the one charge refunded", f.provider.charges, f.provider.refunded)
+	}
+	fee := f.paymentRepo.payments[1]
+	if fee.Status != models.PaymentStatusRefunded || fee.RefundedAmount != 5 {
+		t.Errorf("fee payment = %+v, want refunded in full", fee)
+	}
+	var credited float64
+	for _, payment := range f.paymentRepo.sellerPayments() {
+		credited += payment.Amount
+	}
+	if credited != 0 {
+		t.Errorf("seller is left with %.2f from a refunded fee, want 0", credited)
+	}
+	if status := f.transfers.active[1].Status; status != models.TransferStatusPending {
+		t.Errorf("transfer status = %d, want it back to pending", status)
+	}
+}
+
+func TestAcceptTransfer(t *testing.T) {
+	tests := []struct {
+		name    string
+		fee     float64
+		wantFee float64
+	}{
+		{"free", 0, 0},
+		{"recipient pays the fee", 5, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTransferFixture(t)
+			f.events.events[1].TransferFee = tt.fee
+
+			resp, err := f.transferService().AcceptTransfer(context.Background(), 1, recipientID,
+				&AcceptTransferRequest{PaymentMethodID: 1})
+			if err != nil {
+				t.Fatalf("AcceptTransfer: %v", err)
+			}
+
+			if owner := f.purchased.tickets[1].UserID; owner != recipientID {
+				t.Errorf("ticket owner = %d, want %d", owner, recipientID)
+			}
+			if len(f.transfers.done) != 1 || f.transfers.active[1].Status != models.TransferStatusAccepted {
+				t.Errorf("transfer status %d with %d completed records, want accepted with one",
+					f.transfers.active[1].Status, len(f.transfers.done))
+			}
+			if topics := f.outbox.topics(); len(topics) != 1 || topics[0] != models.OutboxTopicTransferAccepted {
+				t.Errorf("outbox topics = %v, want [%s]", topics, models.OutboxTopicTransferAccepted)
+			}
+
+			var fee float64
+			if resp.FeePayment != nil {
+				fee = resp.FeePayment.Amount
+			}
+			if fee != tt.wantFee {
+				t.Errorf("fee charged = %.2f, want %.2f", fee, tt.wantFee)
+			}
+			if credits := f.paymentRepo.sellerPayments(); (len(credits) > 0) != (tt.wantFee > 0) {
+				t.Errorf("seller credits = %+v for a fee of %.2f", credits, tt.wantFee)
+			}
+		})
+	}
+}
+
+func TestRejectTransfer(t *testing.T) {
+	tests := []struct {
+		name       string
+		transferID uint
+		userID     uint
+		status     models.TransferStatus
+		racing     bool
+		wantCode   apperrors.Code
+	}{
+		{"recipient", 1, recipientID, models.TransferStatusPending, false, ""},
+		{"missing transfer", 9, recipientID, models.TransferStatusPending, false, apperrors.CodeNotFound},
+		{"sender", 1, senderID, models.TransferStatusPending, false, apperrors.CodeForbidden},
+		{"already accepted", 1, recipientID, models.TransferStatusAccepted, false, apperrors.CodeValidationFailed},
+		{"accepted concurrently", 1, recipientID, models.TransferStatusPending, true, apperrors.CodeConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTransferFixture(t)
+			f.transfers.active[1].Status = tt.status
+			f.transfers.acceptedFirst = tt.racing
+
+			err := f.transferService().RejectTransfer(tt.transferID, tt.userID)
+			if code := apperrors.CodeOf(err); code != tt.wantCode {
+				t.Errorf("error %v has code %q, want %q", err, code, tt.wantCode)
+			}
+
+			wantStatus := tt.status
+			if tt.wantCode == "" {
+				wantStatus = models.TransferStatusRejected
+			}
+			if status := f.transfers.active[1].Status; status != wantStatus {
+				t.Errorf("transfer status = %d, want %d", status, wantStatus)
+			}
+		})
+	}
+}
+
+func TestCreateClaimLinkURL(t *testing.T) {
+	f := newTransferFixture(t)
+	delete(f.transfers.active, 1)
+
+	resp, err := f.transferService().CreateClaimLink(1, senderID, "/api/v2/claim-links")
+	if err != nil {
+		t.Fatalf("CreateClaimLink: %v", err)
+	}
+
+	link := f.transfers.links[resp.ID]
+	if want := "https://tickets.example.com/api/v2/claim-links/" + link.Token; resp.URL != want {
+		t.Errorf("url = %q, want %q", resp.URL, want)
+	}
+}
+
+// claimLinkFixture adds an open claim link "token" for the sender's ticket
+// and no pending transfer
+func claimLinkFixture(t *testing.T) *transferFixture {
+	f := newTransferFixture(t)
+	delete(f.transfers.active, 1)
+	f.transfers.links = map[uint]*models.TicketClaimLink{
+		1: {ID: 1, PurchasedTicketID: 1, FromUserID: senderID, Token: "token",
+			Status: models.ClaimLinkStatusOpen, ExpiresAt: time.Now().Add(time.Hour).Unix()},
+	}
+	return f
+}
+
+func TestClaimTicket(t *testing.T) {
+	f := claimLinkFixture(t)
+	f.events.events[1].TransferFee = 5
+
+	resp, err := f.transferService().ClaimTicket(context.Background(), "token", recipientID, &AcceptTransferRequest{PaymentMethodID: 1})
+	if err != nil {
+		t.Fatalf("ClaimTicket: %v", err)
+	}
+
+	if owner := f.purchased.tickets[1].UserID; owner != recipientID {
+		t.Errorf("ticket owner = %d, want %d", owner, recipientID)
+	}
+	if resp.FeePayment == nil || resp.FeePayment.Amount != 5 {
+		t.Errorf("fee payment = %+v, want 5.00", resp.FeePayment)
+	}
+	if status := f.transfers.links[1].Status; status != models.ClaimLinkStatusClaimed || len(f.transfers.done) != 1 {
+		t.Errorf("link status %d with %d completed transfers, want claimed with one", status, len(f.transfers.done))
+	}
+}
+
+func TestClaimTicketRefusals(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(f *transferFixture)
+		wantCode apperrors.Code
+	}{
+		{"fee declined", func(f *transferFixture) { f.events.events[1].TransferFee = 5 }, apperrors.CodePaymentDeclined},
+		{"ticket given away since the link was made", func(f *transferFixture) {
+			f.purchased.tickets[1].UserID = 12
+		}, apperrors.CodeConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := claimLinkFixture(t)
+			tt.setup(f)
+
+			_, err := f.transferService().ClaimTicket(context.Background(), "token", recipientID, &AcceptTransferRequest{PaymentMethodID: 2})
+			if code := apperrors.CodeOf(err); code != tt.wantCode {
+				t.Errorf("error %v has code %q, want %q", err, code, tt.wantCode)
+			}
+			if status := f.transfers.links[1].Status; status != models.ClaimLinkStatusOpen {
+				t.Errorf("link status = %d, want it open again", status)
+			}
+			if len(f.transfers.done) != 0 || len(f.outbox.messages) != 0 {
+				t.Errorf("recorded %d completed transfers and %d messages, want none", len(f.transfers.done), len(f.outbox.messages))
+			}
+		})
+	}
+}
@@ -3,21 +3,26 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"eticketing/internal/models"
 	"eticketing/internal/repositories"
 	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
 	"gorm.io/gorm"
 )
 
 type UserService struct {
-	userRepo repositories.UserRepository
+	userRepo            repositories.UserRepository
+	deletionGracePeriod time.Duration
 }
 
 type UpdateProfileRequest struct {
 	Name     string `json:"name"`
 	Surname  string `json:"surname"`
 	Username string `json:"username"`
+	Locale   string `json:"locale" binding:"omitempty,oneof=en uk"`
 }
 
 type ChangePasswordRequest struct {
@@ -25,17 +30,26 @@ type ChangePasswordRequest struct {
 	NewPassword     string `json:"new_password" binding:"required"`
 }
 
-func NewUserService(userRepo repositories.UserRepository) *UserService {
-	return &UserService{userRepo: userRepo}
+// AccountDeletionResponse tells the user when their data will be anonymized
+type AccountDeletionResponse struct {
+	RequestedAt int64 `json:"requested_at"`
+	ScheduledAt int64 `json:"scheduled_at"`
+}
+
+func NewUserService(userRepo repositories.UserRepository, deletionGracePeriod time.Duration) *UserService {
+	return &UserService{
+		userRepo:            userRepo,
+		deletionGracePeriod: deletionGracePeriod,
+	}
 }
 
 func (s *UserService) GetProfile(userID uint) (*UserInfo, error) {
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, apperrors.NotFound("user not found")
 		}
-		return nil, errors.New("failed to get user profile")
+		return nil, apperrors.Internal("failed to get user profile")
 	}
 
 	return &UserInfo{
@@ -45,24 +59,27 @@ func (s *UserService) GetProfile(userID uint) (*UserInfo, error) {
 		Name:     user.Name,
 		Surname:  user.Surname,
 		UserType: models.UserTypeUser,
+		Locale:   user.Locale,
+
+		DeletionScheduledAt: user.DeletionScheduledAt,
 	}, nil
 }
 
 func (s *UserService) UpdateProfile(userID uint, req *UpdateProfileRequest) (*UserInfo, error) {
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, apperrors.NotFound("user not found")
 	}
 
 	// Validate username if provided
 	if req.Username != "" && req.Username != user.Username {
 		if valid, validationErrors := utils.ValidateUsername(req.Username); !valid {
-			return nil, errors.New("username validation failed: " + validationErrors[0])
+			return nil, apperrors.Validation("username validation failed: " + validationErrors[0])
 		}
 
 		// Check if username is already taken
 		if existingUser, _ := s.userRepo.GetByUsername(req.Username); existingUser != nil && existingUser.ID != userID {
-			return nil, errors.New("username already taken")
+			return nil, apperrors.Conflict("username already taken")
 		}
 		user.Username = utils.SanitizeString(req.Username)
 	}
@@ -74,9 +91,12 @@ func (s *UserService) UpdateProfile(userID uint, req *UpdateProfileRequest) (*Us
 	if req.Surname != "" {
 		user.Surname = utils.SanitizeString(req.Surname)
 	}
+	if req.Locale != "" {
+		user.Locale = req.Locale
+	}
 
 	if err := s.userRepo.Update(user); err != nil {
-		return nil, errors.New("failed to update profile")
+		return nil, apperrors.Internal("failed to update profile")
 	}
 
 	return &UserInfo{
@@ -86,51 +106,98 @@ func (s *UserService) UpdateProfile(userID uint, req *UpdateProfileRequest) (*Us
 		Name:     user.Name,
 		Surname:  user.Surname,
 		UserType: models.UserTypeUser,
+		Locale:   user.Locale,
 	}, nil
 }
 
 func (s *UserService) ChangePassword(userID uint, req *ChangePasswordRequest) error {
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
-		return errors.New("user not found")
+		return apperrors.NotFound("user not found")
 	}
 
 	// Verify current password
 	if !utils.CheckPassword(req.CurrentPassword, user.PasswordHash) {
-		return errors.New("current password is incorrect")
+		return apperrors.Validation("current password is incorrect")
 	}
 
 	// Validate new password
 	if valid, validationErrors := utils.ValidatePassword(req.NewPassword); !valid {
-		return errors.New("password validation failed: " + validationErrors[0])
+		return apperrors.Validation("password validation failed: " + validationErrors[0])
 	}
 
 	// Hash new password
 	hashedPassword, err := utils.HashPassword(req.NewPassword)
 	if err != nil {
-		return errors.New("failed to hash password")
+		return apperrors.Internal("failed to hash password")
 	}
 
 	user.PasswordHash = hashedPassword
 	if err := s.userRepo.Update(user); err != nil {
-		return errors.New("failed to update password")
+		return apperrors.Internal("failed to update password")
 	}
 
 	return nil
 }
 
-func (s *UserService) DeleteAccount(userID uint) error {
-	// TODO: Add business logic to check if user can be deleted
-	// For example, check if they have active tickets, pending transfers, etc.
+// DeleteAccount schedules the account for anonymization after the grace
+// period. Until then the user can still sign in and cancel.
+func (s *UserService) DeleteAccount(userID uint) (*AccountDeletionResponse, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, apperrors.NotFound("user not found")
+	}
+
+	if user.DeletionScheduledAt != nil {
+		return nil, apperrors.Conflict("account deletion is already scheduled")
+	}
+
+	now := time.Now().Unix()
+	scheduledAt := now + int64(s.deletionGracePeriod.Seconds())
+	user.DeletionRequestedAt = &now
+	user.DeletionScheduledAt = &scheduledAt
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, apperrors.Internal("failed to schedule account deletion")
+	}
+
+	return &AccountDeletionResponse{RequestedAt: now, ScheduledAt: scheduledAt}, nil
+}
 
+func (s *UserService) CancelAccountDeletion(userID uint) error {
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
-		return errors.New("user not found")
+		return apperrors.NotFound("user not found")
 	}
 
-	if err := s.userRepo.Delete(user.ID); err != nil {
-		return errors.New("failed to delete account")
+	if user.DeletionScheduledAt == nil {
+		return apperrors.Validation("account deletion is not scheduled")
+	}
+
+	user.DeletionRequestedAt = nil
+	user.DeletionScheduledAt = nil
+	if err := s.userRepo.Update(user); err != nil {
+		return apperrors.Internal("failed to cancel account deletion")
 	}
 
 	return nil
 }
+
+// AnonymizeDueAccounts anonymizes accounts whose deletion grace period has
+// ended
+func (s *UserService) AnonymizeDueAccounts(limit int) (int, error) {
+	now := time.Now().Unix()
+	users, err := s.userRepo.ListDueForAnonymization(now, limit)
+	if err != nil {
+		return 0, apperrors.Internal("failed to find accounts due for deletion")
+	}
+
+	anonymized := 0
+	for _, user := range users {
+		if err := s.userRepo.Anonymize(user.ID, now); err != nil {
+			return anonymized, fmt.Errorf("failed to anonymize user %d: %w", user.ID, err)
+		}
+		anonymized++
+	}
+
+	return anonymized, nil
+}
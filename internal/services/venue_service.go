@@ -0,0 +1,140 @@
+// internal/services/venue_service.go
+package services
+
+import (
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/repositories"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+)
+
+type VenueService struct {
+	venueRepo repositories.VenueRepository
+	eventRepo repositories.EventRepository
+}
+
+type VenueRequest struct {
+	Name       string   `json:"name" binding:"required,max=255"`
+	Address    string   `json:"address" binding:"required"`
+	Latitude   *float64 `json:"latitude" binding:"required_with=Longitude,omitempty,latitude"`
+	Longitude  *float64 `json:"longitude" binding:"required_with=Latitude,omitempty,longitude"`
+	Capacity   int      `json:"capacity" binding:"min=0"`
+	SeatMapURL string   `json:"seat_map_url" binding:"omitempty,url"`
+}
+
+func NewVenueService(venueRepo repositories.VenueRepository, eventRepo repositories.EventRepository) *VenueService {
+	return &VenueService{
+		venueRepo: venueRepo,
+		eventRepo: eventRepo,
+	}
+}
+
+func (s *VenueService) CreateVenue(sellerID uint, req *VenueRequest) (*models.Venue, error) {
+	venue := &models.Venue{
+		SellerID:  sellerID,
+		CreatedAt: time.Now().Unix(),
+	}
+	s.applyRequest(venue, req)
+
+	if err := s.venueRepo.Create(venue); err != nil {
+		return nil, apperrors.Internal("failed to create venue")
+	}
+
+	return venue, nil
+}
+
+func (s *VenueService) UpdateVenue(venueID, sellerID uint, req *VenueRequest) (*models.Venue, error) {
+	venue, err := s.getOwnedVenue(venueID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.applyRequest(venue, req)
+
+	if err := s.venueRepo.Update(venue); err != nil {
+		return nil, apperrors.Internal("failed to update venue")
+	}
+
+	return venue, nil
+}
+
+// DeleteVenue removes a venue no event refers to any more
+func (s *VenueService) DeleteVenue(venueID, sellerID uint) error {
+	if _, err := s.getOwnedVenue(venueID, sellerID); err != nil {
+		return err
+	}
+
+	events, err := s.eventRepo.CountByVenue(venueID, 0)
+	if err != nil {
+		return apperrors.Internal("failed to count venue events")
+	}
+	if events > 0 {
+		return apperrors.Conflict("venue is used by events")
+	}
+
+	if err := s.venueRepo.Delete(venueID); err != nil {
+		return apperrors.Internal("failed to delete venue")
+	}
+
+	return nil
+}
+
+func (s *VenueService) GetSellerVenues(sellerID uint) ([]models.Venue, error) {
+	venues, err := s.venueRepo.ListBySeller(sellerID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve venues")
+	}
+	return venues, nil
+}
+
+func (s *VenueService) GetVenues(page, limit int) (*utils.PaginatedResponse, error) {
+	offset := (page - 1) * limit
+	venues, err := s.venueRepo.List(limit, offset)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve venues")
+	}
+
+	total, err := s.venueRepo.Count()
+	if err != nil {
+		return nil, apperrors.Internal("failed to count venues")
+	}
+
+	return &utils.PaginatedResponse{
+		Success:    true,
+		Message:    "Venues retrieved successfully",
+		Data:       venues,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, nil
+}
+
+func (s *VenueService) GetVenue(venueID uint) (*models.Venue, error) {
+	venue, err := s.venueRepo.GetByID(venueID)
+	if err != nil {
+		return nil, apperrors.NotFound("venue not found")
+	}
+	return venue, nil
+}
+
+func (s *VenueService) applyRequest(venue *models.Venue, req *VenueRequest) {
+	venue.Name = utils.SanitizeString(req.Name)
+	venue.Address = utils.SanitizeString(req.Address)
+	venue.Latitude = req.Latitude
+	venue.Longitude = req.Longitude
+	venue.Capacity = req.Capacity
+	venue.SeatMapURL = req.SeatMapURL
+}
+
+func (s *VenueService) getOwnedVenue(venueID, sellerID uint) (*models.Venue, error) {
+	venue, err := s.venueRepo.GetByID(venueID)
+	if err != nil {
+		return nil, apperrors.NotFound("venue not found")
+	}
+
+	if venue.SellerID != sellerID {
+		return nil, apperrors.Forbidden("unauthorized to manage this venue")
+	}
+
+	return venue, nil
+}
@@ -0,0 +1,341 @@
+// internal/services/webhook_service.go
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"eticketing/internal/models"
+	"eticketing/internal/outbox"
+	"eticketing/internal/repositories"
+	"eticketing/internal/utils"
+	apperrors "eticketing/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// webhookTopics are the outbox topics sellers may subscribe to
+var webhookTopics = map[string]bool{
+	models.OutboxTopicTicketSold:     true,
+	models.OutboxTopicOrderRefunded:  true,
+	models.OutboxTopicEventApproved:  true,
+	models.OutboxTopicEventRejected:  true,
+	models.OutboxTopicEventSuspended: true,
+	models.OutboxTopicEventPublished: true,
+	models.OutboxTopicSaleCreated:    true,
+	models.OutboxTopicDisputeUpdated: true,
+}
+
+type WebhookService struct {
+	webhookRepo repositories.WebhookRepository
+	outboxRepo  repositories.OutboxRepository
+	client      *http.Client
+}
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required,url"`
+	Events []string `json:"events" binding:"required,min=1"`
+}
+
+type WebhookResponse struct {
+	ID        uint     `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	IsActive  bool     `json:"is_active"`
+	CreatedAt int64    `json:"created_at"`
+	Secret    string   `json:"secret,omitempty"` // Only returned on creation
+}
+
+// webhookBody is the JSON document POSTed to seller endpoints
+type webhookBody struct {
+	ID        uint            `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt int64           `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func NewWebhookService(
+	webhookRepo repositories.WebhookRepository,
+	outboxRepo repositories.OutboxRepository,
+	timeout time.Duration,
+) *WebhookService {
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		outboxRepo:  outboxRepo,
+		client:      utils.NewPublicHTTPClient(timeout),
+	}
+}
+
+// CreateWebhook registers an endpoint for the seller. Endpoints must
+// resolve to public addresses only; deliveries are refused at connect time
+// too, should the host later resolve elsewhere.
+func (s *WebhookService) CreateWebhook(sellerID uint, req *CreateWebhookRequest) (*WebhookResponse, error) {
+	parsed, err := url.Parse(req.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return nil, apperrors.Validation("webhook URL must be an http or https URL")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := utils.ResolvePublicHost(ctx, parsed.Hostname()); err != nil {
+		return nil, apperrors.Validation("webhook URL must point at a publicly reachable host")
+	}
+
+	for _, event := range req.Events {
+		if !webhookTopics[event] {
+			return nil, apperrors.Validationf("unsupported webhook event: %s", event)
+		}
+	}
+
+	secret, err := s.generateSecret()
+	if err != nil {
+		return nil, apperrors.Internal("failed to generate webhook secret")
+	}
+
+	webhook := &models.SellerWebhook{
+		SellerID:  sellerID,
+		URL:       req.URL,
+		Secret:    secret,
+		Events:    strings.Join(req.Events, ","),
+		IsActive:  true,
+		CreatedAt: time.Now().Unix(),
+	}
+
+	if err := s.webhookRepo.Create(webhook); err != nil {
+		return nil, apperrors.Internal("failed to create webhook")
+	}
+
+	response := s.convertToResponse(webhook)
+	response.Secret = secret
+	return response, nil
+}
+
+func (s *WebhookService) GetSellerWebhooks(sellerID uint) ([]WebhookResponse, error) {
+	webhooks, err := s.webhookRepo.ListBySeller(sellerID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve webhooks")
+	}
+
+	var responses []WebhookResponse
+	for i := range webhooks {
+		responses = append(responses, *s.convertToResponse(&webhooks[i]))
+	}
+
+	return responses, nil
+}
+
+func (s *WebhookService) DeleteWebhook(webhookID, sellerID uint) error {
+	if _, err := s.getOwnedWebhook(webhookID, sellerID); err != nil {
+		return err
+	}
+
+	if err := s.webhookRepo.Delete(webhookID); err != nil {
+		return apperrors.Internal("failed to delete webhook")
+	}
+
+	return nil
+}
+
+func (s *WebhookService) GetDeliveries(webhookID, sellerID uint, page, limit int) (*utils.PaginatedResponse, error) {
+	if _, err := s.getOwnedWebhook(webhookID, sellerID); err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * limit
+	deliveries, err := s.webhookRepo.ListDeliveries(webhookID, limit, offset)
+	if err != nil {
+		return nil, apperrors.Internal("failed to retrieve webhook deliveries")
+	}
+
+	total, err := s.webhookRepo.CountDeliveries(webhookID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count webhook deliveries")
+	}
+
+	return &utils.PaginatedResponse{
+		Success:    true,
+		Message:    "Webhook deliveries retrieved successfully",
+		Data:       deliveries,
+		Pagination: utils.CalculatePagination(page, limit, total),
+	}, nil
+}
+
+// FanOut is an outbox handler that queues one webhook.delivery message per
+// seller endpoint subscribed to the message's topic, once per message
+func (s *WebhookService) FanOut(ctx context.Context, message *models.OutboxMessage) error {
+	var target struct {
+		SellerID uint `json:"seller_id"`
+	}
+	if err := json.Unmarshal([]byte(message.Payload), &target); err != nil {
+		return fmt.Errorf("invalid payload for %s: %w", message.Topic, err)
+	}
+	if target.SellerID == 0 {
+		return nil
+	}
+
+	webhooks, err := s.webhookRepo.ListActiveBySeller(target.SellerID)
+	if err != nil {
+		return err
+	}
+
+	var deliveries []*models.OutboxMessage
+	for _, webhook := range webhooks {
+		if !s.isSubscribed(&webhook, message.Topic) {
+			continue
+		}
+
+		delivery, err := outbox.NewFanOutMessage(models.OutboxTopicWebhookDelivery, outbox.WebhookDeliveryPayload{
+			WebhookID: webhook.ID,
+			SourceID:  message.ID,
+			Topic:     message.Topic,
+			Data:      json.RawMessage(message.Payload),
+		}, message, strconv.FormatUint(uint64(webhook.ID), 10))
+		if err != nil {
+			return err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	// Deliveries already queued by an earlier run for this message, retried
+	// because another handler of the topic failed, are skipped
+	return s.outboxRepo.CreateIfAbsent(deliveries)
+}
+
+// Deliver is the outbox handler for webhook.delivery messages. It POSTs the
+// signed payload and logs the attempt; a non-2xx response triggers a retry.
+func (s *WebhookService) Deliver(ctx context.Context, message *models.OutboxMessage) error {
+	var payload outbox.WebhookDeliveryPayload
+	if err := json.Unmarshal([]byte(message.Payload), &payload); err != nil {
+		return fmt.Errorf("invalid webhook delivery payload: %w", err)
+	}
+
+	webhook, err := s.webhookRepo.GetByID(payload.WebhookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil // Webhook was deleted; nothing to deliver
+		}
+		return err
+	}
+	if !webhook.IsActive {
+		return nil
+	}
+
+	body, err := json.Marshal(webhookBody{
+		ID:        payload.SourceID,
+		Type:      payload.Topic,
+		CreatedAt: message.CreatedAt,
+		Data:      payload.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	statusCode, deliverErr := s.post(ctx, webhook, payload.Topic, body)
+
+	delivery := &models.WebhookDelivery{
+		WebhookID:       webhook.ID,
+		OutboxMessageID: message.ID,
+		Topic:           payload.Topic,
+		Attempt:         message.Attempts + 1,
+		StatusCode:      statusCode,
+		Success:         deliverErr == nil,
+		DurationMs:      time.Since(start).Milliseconds(),
+		CreatedAt:       start.Unix(),
+	}
+	if deliverErr != nil {
+		delivery.Error = deliverErr.Error()
+	}
+
+	if err := s.webhookRepo.CreateDelivery(delivery); err != nil {
+		return errors.Join(deliverErr, err)
+	}
+
+	return deliverErr
+}
+
+func (s *WebhookService) post(ctx context.Context, webhook *models.SellerWebhook, topic string, body []byte) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", topic)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+s.sign(webhook.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint returned %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// sign computes HMAC-SHA256 over "<timestamp>.<body>" so receivers can reject replays
+func (s *WebhookService) sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *WebhookService) getOwnedWebhook(webhookID, sellerID uint) (*models.SellerWebhook, error) {
+	webhook, err := s.webhookRepo.GetByID(webhookID)
+	if err != nil {
+		return nil, apperrors.NotFound("webhook not found")
+	}
+
+	if webhook.SellerID != sellerID {
+		return nil, apperrors.Forbidden("unauthorized to access this webhook")
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) isSubscribed(webhook *models.SellerWebhook, topic string) bool {
+	for _, event := range strings.Split(webhook.Events, ",") {
+		if event == topic {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *WebhookService) generateSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(bytes), nil
+}
+
+func (s *WebhookService) convertToResponse(webhook *models.SellerWebhook) *WebhookResponse {
+	return &WebhookResponse{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    strings.Split(webhook.Events, ","),
+		IsActive:  webhook.IsActive,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"eticketing/internal/i18n"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// MaxUnixTimestamp is 2100-01-01T00:00:00Z; anything later is almost
+// certainly milliseconds sent where seconds were expected
+const MaxUnixTimestamp int64 = 4102444800
+
+// RegisterValidators adds the custom binding tags used by request DTOs:
+//
+//	unixtime - a Unix timestamp (seconds) after the epoch and before 2100
+//
+// Combine with gtfield to require an end after its start, e.g.
+// `binding:"required,unixtime,gtfield=StartDate"`.
+func RegisterValidators() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("unexpected binding validator engine")
+	}
+
+	// Report JSON field names instead of Go struct field names
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	return v.RegisterValidation("unixtime", validateUnixTime)
+}
+
+func validateUnixTime(fl validator.FieldLevel) bool {
+	switch fl.Field().Kind() {
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		ts := fl.Field().Int()
+		return ts > 0 && ts < MaxUnixTimestamp
+	default:
+		return false
+	}
+}
+
+// BindingErrors turns validator errors into a field -> message map suitable
+// for ValidationErrorResponse. It returns nil for other errors (e.g. bad JSON).
+func BindingErrors(err error) map[string]string {
+	return LocalizedBindingErrors(err, i18n.Default)
+}
+
+// LocalizedBindingErrors is BindingErrors with the messages in locale
+func LocalizedBindingErrors(err error, locale i18n.Locale) map[string]string {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	fieldErrors := make(map[string]string, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		fieldErrors[fieldErr.Field()] = bindingErrorMessage(fieldErr, locale)
+	}
+	return fieldErrors
+}
+
+func bindingErrorMessage(fieldErr validator.FieldError, locale i18n.Locale) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return i18n.T(locale, "is required")
+	case "unixtime":
+		return i18n.T(locale, "must be a Unix timestamp in seconds between 1970 and 2100")
+	case "gtfield":
+		return i18n.T(locale, "must be after %s", snakeCase(fieldErr.Param()))
+	case "min":
+		return i18n.T(locale, "must be at least %s", fieldErr.Param())
+	case "max":
+		return i18n.T(locale, "must be at most %s", fieldErr.Param())
+	default:
+		return i18n.T(locale, "is invalid")
+	}
+}
+
+// snakeCase maps a Go field name such as StartDate to its JSON name start_date
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// csvFlushRows is how many rows a CSVStream buffers before sending them on
+const csvFlushRows = 500
+
+// CSVStream writes CSV rows to w and flushes them every few hundred rows,
+// through to the client when w is an HTTP response, so a large export is
+// sent while it is being produced instead of being built in memory first
+type CSVStream struct {
+	writer  *csv.Writer
+	flusher interface{ Flush() }
+	pending int
+}
+
+func NewCSVStream(w io.Writer) *CSVStream {
+	stream := &CSVStream{writer: csv.NewWriter(w)}
+	stream.flusher, _ = w.(interface{ Flush() })
+	return stream
+}
+
+// Write writes one row. Cells are escaped against formula injection, since
+// exports carry text buyers and sellers typed in.
+func (s *CSVStream) Write(record []string) error {
+	escaped := make([]string, len(record))
+	for i, cell := range record {
+		escaped[i] = EscapeCSVCell(cell)
+	}
+	if err := s.writer.Write(escaped); err != nil {
+		return err
+	}
+	s.pending++
+	if s.pending >= csvFlushRows {
+		return s.Flush()
+	}
+	return nil
+}
+
+// Flush sends every row written so far
+func (s *CSVStream) Flush() error {
+	s.pending = 0
+	s.writer.Flush()
+	if err := s.writer.Error(); err != nil {
+		return err
+	}
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+	return nil
+}
+
+// EscapeCSVCell prefixes a cell spreadsheet apps would read as a formula,
+// one starting with =, +, -, @, a tab or a carriage return, with a quote.
+// Plain numbers such as negative amounts are left alone.
+func EscapeCSVCell(cell string) string {
+	if cell == "" || !strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return cell
+	}
+	if _, err := strconv.ParseFloat(cell, 64); err == nil {
+		return cell
+	}
+	return "'" + cell
+}
+
+// StreamCSV serves the CSV that write produces as a file download. An error
+// before anything was sent is reported as usual; once rows have gone out
+// the status can't change, so the file is cut short and the error is
+// recorded in the request log.
+func StreamCSV(c *gin.Context, filename string, write func(w io.Writer) error) {
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+
+	out := &countingWriter{ResponseWriter: c.Writer}
+	if err := write(out); err != nil {
+		if out.n == 0 && !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			ServiceErrorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+		_ = c.Error(err)
+	}
+}
+
+// countingWriter tells whether any of the body was handed on, even if a
+// middleware such as compression is still holding it back
+type countingWriter struct {
+	gin.ResponseWriter
+	n int
+}
+
+func (w *countingWriter) Write(data []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(data)
+	w.n += n
+	return n, err
+}
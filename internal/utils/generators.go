@@ -3,6 +3,7 @@ package utils
 import (
 	"crypto/rand"
 	"encoding/binary"
+	"encoding/hex"
 	"math"
 )
 
@@ -14,3 +15,12 @@ func CryptoFloat64() (float64, error) {
 	}
 	return float64(binary.BigEndian.Uint64(b[:])) / math.MaxUint64, nil
 }
+
+// RandomHex returns n random bytes hex-encoded, for unguessable tokens
+func RandomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -15,6 +15,10 @@ type JWTClaims struct {
 	Email    string          `json:"email"`
 	UserType models.UserType `json:"user_type"`
 	Type     string          `json:"type"` // "access" or "refresh"
+
+	// Set on impersonation tokens to the admin acting as this user
+	ImpersonatorID uint `json:"impersonator_id,omitempty"`
+
 	jwt.RegisteredClaims
 }
 
@@ -66,6 +70,32 @@ func (j *JWTManager) GenerateRefreshToken(userID uint, username, email string, u
 	return token.SignedString([]byte(j.config.Secret))
 }
 
+// GenerateImpersonationToken issues a short-lived access token that lets an
+// admin act as a user or seller. There is no refresh token; the admin asks
+// for a new one when it expires.
+func (j *JWTManager) GenerateImpersonationToken(userID uint, username, email string, userType models.UserType, adminID uint) (string, time.Time, error) {
+	expiresAt := time.Now().Add(j.config.ImpersonationDuration)
+	claims := JWTClaims{
+		UserID:         userID,
+		Username:       username,
+		Email:          email,
+		UserType:       userType,
+		Type:           "access",
+		ImpersonatorID: adminID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    j.config.Issuer,
+			Subject:   email,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(j.config.Secret))
+	return signed, expiresAt, err
+}
+
 func (j *JWTManager) ValidateToken(tokenString string) (*JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
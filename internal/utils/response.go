@@ -1,8 +1,12 @@
 package utils
 
 import (
-	"github.com/gin-gonic/gin"
+	"errors"
 	"net/http"
+
+	"eticketing/internal/i18n"
+	apperrors "eticketing/pkg/errors"
+	"github.com/gin-gonic/gin"
 )
 
 type APIResponse struct {
@@ -10,6 +14,7 @@ type APIResponse struct {
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
 	Error   string      `json:"error,omitempty"`
+	Code    string      `json:"code,omitempty"`
 }
 
 type PaginatedResponse struct {
@@ -26,10 +31,26 @@ type Pagination struct {
 	TotalPages int   `json:"total_pages"`
 }
 
+// Locale is the language negotiated for the request, see
+// middleware.LocaleMiddleware
+func Locale(c *gin.Context) i18n.Locale {
+	if locale, ok := c.Get(i18n.ContextKey); ok {
+		if locale, ok := locale.(i18n.Locale); ok {
+			return locale
+		}
+	}
+	return i18n.Default
+}
+
+// localize translates a response message into the request's language
+func localize(c *gin.Context, message string) string {
+	return i18n.Translate(Locale(c), message)
+}
+
 func SuccessResponse(c *gin.Context, message string, data interface{}) {
 	c.JSON(http.StatusOK, APIResponse{
 		Success: true,
-		Message: message,
+		Message: localize(c, message),
 		Data:    data,
 	})
 }
@@ -37,7 +58,16 @@ func SuccessResponse(c *gin.Context, message string, data interface{}) {
 func CreatedResponse(c *gin.Context, message string, data interface{}) {
 	c.JSON(http.StatusCreated, APIResponse{
 		Success: true,
-		Message: message,
+		Message: localize(c, message),
+		Data:    data,
+	})
+}
+
+// AcceptedResponse reports work that was queued rather than done
+func AcceptedResponse(c *gin.Context, message string, data interface{}) {
+	c.JSON(http.StatusAccepted, APIResponse{
+		Success: true,
+		Message: localize(c, message),
 		Data:    data,
 	})
 }
@@ -45,20 +75,77 @@ func CreatedResponse(c *gin.Context, message string, data interface{}) {
 func PaginatedSuccessResponse(c *gin.Context, message string, data interface{}, pagination Pagination) {
 	c.JSON(http.StatusOK, PaginatedResponse{
 		Success:    true,
-		Message:    message,
+		Message:    localize(c, message),
 		Data:       data,
 		Pagination: pagination,
 	})
 }
 
 func ErrorResponse(c *gin.Context, statusCode int, message string) {
+	codedErrorResponse(c, statusCode, defaultErrorCode(statusCode), message)
+}
+
+// ServiceErrorResponse is the single place service errors become HTTP
+// responses. Typed errors are reported with their own status and code;
+// untyped ones fall back to statusCode and its generic code
+func ServiceErrorResponse(c *gin.Context, statusCode int, err error) {
+	if typed, ok := apperrors.As(err); ok {
+		codedErrorResponse(c, typed.Status(), typed.Code, err.Error())
+		return
+	}
+	codedErrorResponse(c, statusCode, defaultErrorCode(statusCode), err.Error())
+}
+
+// ServiceErrorResponseWithData is ServiceErrorResponse with a payload that
+// explains the error, such as the rows of an upload that failed validation
+func ServiceErrorResponseWithData(c *gin.Context, statusCode int, err error, data interface{}) {
+	code := defaultErrorCode(statusCode)
+	if typed, ok := apperrors.As(err); ok {
+		statusCode, code = typed.Status(), typed.Code
+	}
+	message := localize(c, err.Error())
 	c.JSON(statusCode, APIResponse{
 		Success: false,
 		Message: message,
+		Data:    data,
 		Error:   message,
+		Code:    string(code),
 	})
 }
 
+func codedErrorResponse(c *gin.Context, statusCode int, code apperrors.Code, message string) {
+	message = localize(c, message)
+	c.JSON(statusCode, APIResponse{
+		Success: false,
+		Message: message,
+		Error:   message,
+		Code:    string(code),
+	})
+}
+
+func defaultErrorCode(statusCode int) apperrors.Code {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return apperrors.CodeBadRequest
+	case http.StatusUnauthorized:
+		return apperrors.CodeUnauthorized
+	case http.StatusPaymentRequired:
+		return apperrors.CodePaymentDeclined
+	case http.StatusForbidden:
+		return apperrors.CodeForbidden
+	case http.StatusNotFound:
+		return apperrors.CodeNotFound
+	case http.StatusConflict:
+		return apperrors.CodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return apperrors.CodeRequestTooLarge
+	case http.StatusServiceUnavailable:
+		return apperrors.CodeUnavailable
+	default:
+		return apperrors.CodeInternal
+	}
+}
+
 func BadRequestResponse(c *gin.Context, message string) {
 	ErrorResponse(c, http.StatusBadRequest, message)
 }
@@ -83,10 +170,39 @@ func InternalErrorResponse(c *gin.Context, message string) {
 	ErrorResponse(c, http.StatusInternalServerError, message)
 }
 
+// PaymentRequiredResponse reports a declined charge along with what the
+// client needs to retry it
+func PaymentRequiredResponse(c *gin.Context, message string, data interface{}) {
+	message = localize(c, message)
+	c.JSON(http.StatusPaymentRequired, APIResponse{
+		Success: false,
+		Message: message,
+		Data:    data,
+		Error:   message,
+		Code:    string(apperrors.CodePaymentDeclined),
+	})
+}
+
 func ValidationErrorResponse(c *gin.Context, errors map[string]string) {
 	c.JSON(http.StatusBadRequest, gin.H{
 		"success": false,
-		"message": "Validation failed",
+		"message": localize(c, "Validation failed"),
+		"code":    apperrors.CodeValidationFailed,
 		"errors":  errors,
 	})
 }
+
+// BindingErrorResponse reports per-field validation failures when binding
+// failed validation, and a generic bad request otherwise
+func BindingErrorResponse(c *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		ErrorResponse(c, http.StatusRequestEntityTooLarge, i18n.T(Locale(c), "Request body exceeds %d bytes", tooLarge.Limit))
+		return
+	}
+	if fieldErrors := LocalizedBindingErrors(err, Locale(c)); fieldErrors != nil {
+		ValidationErrorResponse(c, fieldErrors)
+		return
+	}
+	BadRequestResponse(c, "Invalid request data")
+}
@@ -1 +1,174 @@
+// Package errors defines the typed errors services return so that handlers
+// can report a machine-readable code and the right HTTP status alongside the
+// human-readable message
 package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+)
+
+// Code is a stable, machine-readable identifier clients can branch on
+type Code string
+
+const (
+	CodeBadRequest       Code = "BAD_REQUEST"
+	CodeValidationFailed Code = "VALIDATION_FAILED"
+	CodeUnauthorized     Code = "UNAUTHORIZED"
+	CodeForbidden        Code = "FORBIDDEN"
+	CodeNotFound         Code = "NOT_FOUND"
+	CodeConflict         Code = "CONFLICT"
+	CodeRequestTooLarge  Code = "REQUEST_TOO_LARGE"
+	CodeInternal         Code = "INTERNAL_ERROR"
+	CodeUnavailable      Code = "SERVICE_UNAVAILABLE"
+
+	CodeTicketsSoldOut       Code = "TICKETS_SOLD_OUT"
+	CodeTicketUnavailable    Code = "TICKET_UNAVAILABLE"
+	CodeSaleNotActive        Code = "SALE_NOT_ACTIVE"
+	CodeEventNotOnSale       Code = "EVENT_NOT_ON_SALE"
+	CodePaymentDeclined      Code = "PAYMENT_DECLINED"
+	CodeOrderNotPayable      Code = "ORDER_NOT_PAYABLE"
+	CodePaymentWindowExpired Code = "PAYMENT_WINDOW_EXPIRED"
+	CodeRefundWindowClosed   Code = "REFUND_WINDOW_CLOSED"
+	CodeNoPurchaseRight      Code = "NO_PURCHASE_RIGHT"
+	CodePurchaseDeclined     Code = "PURCHASE_DECLINED"
+
+	CodeFeatureDisabled        Code = "FEATURE_DISABLED"
+	CodeMaintenance            Code = "MAINTENANCE"
+	CodeQueueAdmissionRequired Code = "QUEUE_ADMISSION_REQUIRED"
+	CodePolicyNotAccepted      Code = "POLICY_NOT_ACCEPTED"
+	CodeSellerNotApproved      Code = "SELLER_NOT_APPROVED"
+)
+
+// Kind classifies an error by how it should be reported over HTTP
+type Kind int
+
+const (
+	KindBadRequest Kind = iota
+	KindValidation
+	KindUnauthorized
+	KindPaymentRequired
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindInternal
+	KindUnavailable
+)
+
+var kindStatus = map[Kind]int{
+	KindBadRequest:      http.StatusBadRequest,
+	KindValidation:      http.StatusBadRequest,
+	KindUnauthorized:    http.StatusUnauthorized,
+	KindPaymentRequired: http.StatusPaymentRequired,
+	KindForbidden:       http.StatusForbidden,
+	KindNotFound:        http.StatusNotFound,
+	KindConflict:        http.StatusConflict,
+	KindInternal:        http.StatusInternalServerError,
+	KindUnavailable:     http.StatusServiceUnavailable,
+}
+
+var kindCode = map[Kind]Code{
+	KindBadRequest:      CodeBadRequest,
+	KindValidation:      CodeValidationFailed,
+	KindUnauthorized:    CodeUnauthorized,
+	KindPaymentRequired: CodePaymentDeclined,
+	KindForbidden:       CodeForbidden,
+	KindNotFound:        CodeNotFound,
+	KindConflict:        CodeConflict,
+	KindInternal:        CodeInternal,
+	KindUnavailable:     CodeUnavailable,
+}
+
+// Error is an error carrying a Kind and a Code. Its Error() is the plain
+// message so callers comparing messages keep working
+type Error struct {
+	Kind    Kind
+	Code    Code
+	Message string
+}
+
+// New returns a bad request error with a specific code
+func New(code Code, message string) *Error {
+	return &Error{Kind: KindBadRequest, Code: code, Message: message}
+}
+
+func newKind(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Code: kindCode[kind], Message: message}
+}
+
+func Validation(message string) *Error {
+	return newKind(KindValidation, message)
+}
+
+func Unauthorized(message string) *Error {
+	return newKind(KindUnauthorized, message)
+}
+
+func PaymentRequired(message string) *Error {
+	return newKind(KindPaymentRequired, message)
+}
+
+func Forbidden(message string) *Error {
+	return newKind(KindForbidden, message)
+}
+
+func NotFound(message string) *Error {
+	return newKind(KindNotFound, message)
+}
+
+func Conflict(message string) *Error {
+	return newKind(KindConflict, message)
+}
+
+func Internal(message string) *Error {
+	return newKind(KindInternal, message)
+}
+
+func Unavailable(message string) *Error {
+	return newKind(KindUnavailable, message)
+}
+
+func Validationf(format string, args ...interface{}) *Error {
+	return Validation(fmt.Sprintf(format, args...))
+}
+
+// WithCode returns a copy of e reported under a more specific code
+func (e *Error) WithCode(code Code) *Error {
+	copied := *e
+	copied.Code = code
+	return &copied
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Status is the HTTP status the error should be reported with
+func (e *Error) Status() int {
+	return kindStatus[e.Kind]
+}
+
+// As returns the first typed error in err's chain
+func As(err error) (*Error, bool) {
+	var typed *Error
+	if stderrors.As(err, &typed) {
+		return typed, true
+	}
+	return nil, false
+}
+
+// CodeOf returns the code of the first typed error in err's chain, or an
+// empty code when there is none
+func CodeOf(err error) Code {
+	if typed, ok := As(err); ok {
+		return typed.Code
+	}
+	return ""
+}
+
+// IsNotFound reports whether err is a not-found error
+func IsNotFound(err error) bool {
+	typed, ok := As(err)
+	return ok && typed.Kind == KindNotFound
+}